@@ -1,121 +1,511 @@
 package main
 
 import (
-	"bytes"
-	"crypto/rand"
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
-	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
-	"math/big"
-	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
 )
 
 const (
-	keysPairDir = "./"
-	certName    = "public.crt"
-	keyName     = "private.key"
+	certName       = "public.crt"
+	keyName        = "private.key"
+	caCertName     = "ca.crt"
+	caKeyName      = "ca.key"
+	clientCertName = "client.crt"
+	clientKeyName  = "client.key"
+
+	defaultHosts      = "localhost,127.0.0.1,::1"
+	defaultRenewDays  = 30
+	defaultCADays     = 3650
+	defaultIssueDays  = 365
+	defaultOrg        = "GophKeeper"
+	defaultCAName     = "GophKeeper local CA"
+	defaultClientName = "GophKeeper client"
 )
 
+const usage = `Usage: certManager [command] [flags]
+
+Without a command creates a self-signed server certificate ./public.crt with the key ./private.key
+if any of them is missing, or renews the certificate expiring within 30 days.
+
+Commands:
+  ca       create a local CA
+  issue    issue a server or a client certificate signed by the CA, or a self-signed one
+  inspect  show the certificates of the PEM files
+  renew    renew a certificate approaching expiry
+
+Run "certManager <command> -h" for the flags of the command.
+`
+
 func main() {
-	if err := checkCert(); err != nil {
-		log.Printf("Check Certificate Error: %s", err.Error())
-		log.Printf("Creating new keys pair")
+	log.SetFlags(0)
 
-		if err = generateCert(); err != nil {
-			log.Fatalf("Generate Certificate Error: %s", err.Error())
+	if len(os.Args) < 2 {
+		if err := ensureDefault(); err != nil {
+			log.Fatalf("Certificate Error: %s", err.Error())
 		}
+		return
+	}
+
+	var err error
+	switch command, args := os.Args[1], os.Args[2:]; command {
+	case "ca":
+		err = runCA(args)
+	case "issue":
+		err = runIssue(args)
+	case "inspect":
+		err = runInspect(args)
+	case "renew":
+		err = runRenew(args)
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		log.Fatalf("unknown command %q", command)
+	}
+
+	if err != nil {
+		log.Fatalf("%s Error: %s", os.Args[1], err.Error())
+	}
+}
+
+// ensureDefault keeps the server certificate in the working directory: it is created self-signed
+// if the certificate or the key is missing, and renewed with the same key when it approaches expiry.
+func ensureDefault() error {
+	_, errCert := os.Stat(certName)
+	_, errKey := os.Stat(keyName)
+	if errCert != nil || errKey != nil {
+		log.Printf("Certificate or key is missing, creating new keys pair")
+
+		return runIssue([]string{"-force"})
+	}
+
+	return runRenew(nil)
+}
+
+// runCA creates the self-signed certificate and the key of a local CA.
+func runCA(args []string) error {
+	flags := flag.NewFlagSet("ca", flag.ExitOnError)
+	certFile := flags.String("cert", caCertName, "Path to write the CA certificate")
+	keyFile := flags.String("key", caKeyName, "Path to write the CA private key")
+	commonName := flags.String("cn", defaultCAName, "Common name of the CA")
+	org := flags.String("org", defaultOrg, "Organization of the CA")
+	keyType := flags.String("key-type", string(pki.KeyECDSA), "Key type: ecdsa (P-256), ed25519 or rsa")
+	days := flags.Int("days", defaultCADays, "Validity of the CA certificate in days")
+	force := flags.Bool("force", false, "Overwrite existing files")
+	_ = flags.Parse(args)
+
+	if err := checkOverwrite(*force, *certFile, *keyFile); err != nil {
+		return err
+	}
+
+	key, err := pki.GenerateKey(pki.KeyType(*keyType))
+	if err != nil {
+		return err
+	}
+
+	cert, certPEM, err := pki.SelfSigned(&pki.Profile{
+		Usage:        pki.UsageCA,
+		CommonName:   *commonName,
+		Organization: splitList(*org),
+		Validity:     daysDuration(*days),
+	}, key, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = writeKeyPair(*certFile, certPEM, *keyFile, key); err != nil {
+		return err
+	}
+
+	log.Printf("CA certificate %s valid until %s, key %s", *certFile, cert.NotAfter.Format(time.RFC3339), *keyFile)
+
+	return nil
+}
+
+// runIssue issues a server or a client certificate with a new key. The certificate is signed by the CA
+// if its certificate and key are given, and self-signed otherwise.
+func runIssue(args []string) error {
+	flags := flag.NewFlagSet("issue", flag.ExitOnError)
+	certType := flags.String("type", string(pki.UsageServer), "Certificate type: server or client")
+	caCert := flags.String("ca-cert", "", "Path to the CA certificate, the certificate is self-signed if empty")
+	caKey := flags.String("ca-key", "", "Path to the CA private key")
+	certFile := flags.String("cert", "", "Path to write the certificate, "+certName+" for server and "+clientCertName+" for client by default")
+	keyFile := flags.String("key", "", "Path to write the private key, "+keyName+" for server and "+clientKeyName+" for client by default")
+	commonName := flags.String("cn", "", "Common name, the first host for server and \""+defaultClientName+"\" for client by default")
+	org := flags.String("org", defaultOrg, "Organization")
+	hosts := flags.String("hosts", defaultHosts, "Comma separated DNS names and IP addresses of the server")
+	keyType := flags.String("key-type", string(pki.KeyECDSA), "Key type: ecdsa (P-256), ed25519 or rsa")
+	days := flags.Int("days", defaultIssueDays, "Validity of the certificate in days")
+	force := flags.Bool("force", false, "Overwrite existing files")
+	_ = flags.Parse(args)
+
+	profile := &pki.Profile{
+		Usage:        pki.Usage(*certType),
+		CommonName:   *commonName,
+		Organization: splitList(*org),
+		Validity:     daysDuration(*days),
+	}
+
+	switch profile.Usage {
+	case pki.UsageServer:
+		profile.Hosts = splitList(*hosts)
+		if profile.CommonName == "" && len(profile.Hosts) > 0 {
+			profile.CommonName = profile.Hosts[0]
+		}
+		setDefault(certFile, certName)
+		setDefault(keyFile, keyName)
+	case pki.UsageClient:
+		setDefault(commonName, defaultClientName)
+		profile.CommonName = *commonName
+		setDefault(certFile, clientCertName)
+		setDefault(keyFile, clientKeyName)
+	default:
+		return fmt.Errorf("unsupported certificate type %q, expected server or client", *certType)
+	}
+
+	if err := checkOverwrite(*force, *certFile, *keyFile); err != nil {
+		return err
+	}
+
+	authority, err := loadAuthority(*caCert, *caKey)
+	if err != nil {
+		return err
+	}
+
+	key, err := pki.GenerateKey(pki.KeyType(*keyType))
+	if err != nil {
+		return err
+	}
+
+	cert, certPEM, err := sign(authority, profile, key, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = writeKeyPair(*certFile, certPEM, *keyFile, key); err != nil {
+		return err
+	}
+
+	log.Printf("%s certificate %s valid until %s, key %s", profile.Usage, *certFile, cert.NotAfter.Format(time.RFC3339), *keyFile)
+
+	return nil
+}
+
+// runInspect prints the certificates of the PEM files.
+func runInspect(args []string) error {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: certManager inspect [file ...], %s by default\n", certName)
+	}
+	_ = flags.Parse(args)
+
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{certName}
+	}
+
+	for _, file := range files {
+		certs, err := readCertificates(file)
+		if err != nil {
+			return err
+		}
+
+		for _, cert := range certs {
+			fmt.Printf("%s:\n%s\n", file, describe(cert, time.Now()))
+		}
+	}
+
+	return nil
+}
+
+// runRenew issues the certificate anew with the same subject, hosts, usage and validity if it expires soon.
+// The key is kept unless a new one is asked for, so a renewed CA still verifies the certificates it issued.
+func runRenew(args []string) error {
+	flags := flag.NewFlagSet("renew", flag.ExitOnError)
+	certFile := flags.String("cert", certName, "Path to the certificate")
+	keyFile := flags.String("key", keyName, "Path to the private key")
+	caCert := flags.String("ca-cert", "", "Path to the CA certificate, empty for self-signed certificates")
+	caKey := flags.String("ca-key", "", "Path to the CA private key")
+	within := flags.Int("within", defaultRenewDays, "Renew the certificate expiring within the number of days")
+	rekey := flags.Bool("rekey", false, "Generate a new key of the same type")
+	force := flags.Bool("force", false, "Renew the certificate regardless of its expiry")
+	_ = flags.Parse(args)
+
+	certPEM, err := os.ReadFile(*certFile)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	cert, err := pki.ParseCertificate(certPEM)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *certFile, err)
+	}
+
+	now := time.Now()
+	if !*force && cert.NotAfter.After(now.Add(daysDuration(*within))) {
+		log.Printf("Certificate %s is valid until %s, nothing to renew", *certFile, cert.NotAfter.Format(time.RFC3339))
+		return nil
+	}
+
+	profile, err := pki.ProfileOf(cert)
+	if err != nil {
+		return err
+	}
+
+	authority, err := loadAuthority(*caCert, *caKey)
+	if err != nil {
+		return err
+	}
+
+	// Сертификат продлевается тем же УЦ, которым был выпущен
+	switch {
+	case authority != nil && !authority.Issued(cert):
+		return fmt.Errorf("certificate %s is not issued by the CA %s", *certFile, *caCert)
+	case authority == nil && cert.CheckSignatureFrom(cert) != nil:
+		return fmt.Errorf("certificate %s is issued by a CA, pass -ca-cert and -ca-key to renew it", *certFile)
+	}
+
+	key, err := renewalKey(cert, *keyFile, *rekey)
+	if err != nil {
+		return err
 	}
+
+	renewed, renewedPEM, err := sign(authority, profile, key, now)
+	if err != nil {
+		return err
+	}
+
+	if *rekey {
+		err = writeKeyPair(*certFile, renewedPEM, *keyFile, key)
+	} else {
+		err = writeFile(*certFile, renewedPEM, 0644)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Certificate %s renewed until %s", *certFile, renewed.NotAfter.Format(time.RFC3339))
+
+	return nil
 }
 
-// generateCert generates a new certificate and key pair.  It creates a self-signed certificate
-// for testing purposes.  In a production environment, a Certificate Authority (CA) should be used.
-func generateCert() error {
-	// Generate template
-	cert := &x509.Certificate{
-		SerialNumber: big.NewInt(time.Now().Unix()),
-		Subject: pkix.Name{
-			Organization: []string{"Ya Praktikum"},
-			Country:      []string{"RU"},
-		},
-		IPAddresses: []net.IP{net.IPv4(0, 0, 0, 0), net.IPv6loopback},
-		DNSNames:    []string{"localhost"},
-		NotBefore:   time.Now(),
-		NotAfter:    time.Now().AddDate(0, 1, 0),
-		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:    x509.KeyUsageDigitalSignature & x509.KeyUsageKeyEncipherment,
+// renewalKey returns the key of the renewed certificate: a new key of the same type, or the current key
+// checked to match the certificate.
+func renewalKey(cert *x509.Certificate, keyFile string, rekey bool) (crypto.Signer, error) {
+	if rekey {
+		keyType, err := pki.KeyTypeOf(cert.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		return pki.GenerateKey(keyType)
 	}
 
-	// Generate private key
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	keyPEM, err := os.ReadFile(keyFile)
 	if err != nil {
-		return fmt.Errorf("failed generate private key: %w", err)
+		return nil, fmt.Errorf("failed to read private key: %w", err)
 	}
 
-	// Создание сертификата
-	certBytes, err := x509.CreateCertificate(rand.Reader, cert, cert, &privateKey.PublicKey, privateKey)
+	key, err := pki.ParsePrivateKey(keyPEM)
 	if err != nil {
-		return fmt.Errorf("failed create certificate: %w", err)
+		return nil, fmt.Errorf("%s: %w", keyFile, err)
 	}
 
-	// Шифрование блока сертификата
-	var certPEM bytes.Buffer
-	if err = pem.Encode(&certPEM, &pem.Block{
-		Type:  "CERTIFICATE",
-		Bytes: certBytes,
-	}); err != nil {
-		return fmt.Errorf("failed pem encode certificate: %w", err)
+	public, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !public.Equal(key.Public()) {
+		return nil, fmt.Errorf("private key %s does not match the certificate", keyFile)
+	}
+
+	return key, nil
+}
+
+// loadAuthority loads the CA from the files, or returns nil if none of them is given.
+func loadAuthority(certFile string, keyFile string) (*pki.Authority, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both -ca-cert and -ca-key must be set")
 	}
 
-	// Шифрование блока закрытого ключа
-	var keyPEM bytes.Buffer
-	if err = pem.Encode(&keyPEM, &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
-	}); err != nil {
-		return fmt.Errorf("failed pem encode private key: %w", err)
+	return pki.LoadAuthority(certFile, keyFile)
+}
+
+// sign issues the certificate of the profile signed by the CA, or self-signed if the CA is nil.
+func sign(authority *pki.Authority, profile *pki.Profile, key crypto.Signer, now time.Time) (*x509.Certificate, []byte, error) {
+	if authority == nil {
+		return pki.SelfSigned(profile, key, now)
 	}
 
-	// Парсинг директории ключей и создание
-	keyPath := strings.Split(keysPairDir, "/")
-	if len(keyPath) > 1 {
-		container := strings.Join(keyPath[:len(keyPath)-1], "/")
-		if err = os.MkdirAll(container, 0755); err != nil {
-			return fmt.Errorf("failed create container directory: %w", err)
+	return authority.Issue(profile, key.Public(), now)
+}
+
+// describe renders the certificate fields worth checking: subject, issuer, usage, hosts, key and validity.
+func describe(cert *x509.Certificate, now time.Time) string {
+	var sb strings.Builder
+
+	usage := "unknown"
+	if profile, err := pki.ProfileOf(cert); err == nil {
+		usage = string(profile.Usage)
+	}
+	keyType, err := pki.KeyTypeOf(cert.PublicKey)
+	if err != nil {
+		keyType = pki.KeyType(cert.PublicKeyAlgorithm.String())
+	}
+
+	hosts := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		hosts = append(hosts, ip.String())
+	}
+
+	left := cert.NotAfter.Sub(now)
+	expiry := fmt.Sprintf("expires in %d days", int(left.Hours()/24))
+	switch {
+	case left <= 0:
+		expiry = "EXPIRED"
+	case now.Before(cert.NotBefore):
+		expiry = "NOT YET VALID"
+	case left <= daysDuration(defaultRenewDays):
+		expiry += ", renew it"
+	}
+
+	sb.WriteString(fmt.Sprintf("  Subject:     %s\n", cert.Subject))
+	sb.WriteString(fmt.Sprintf("  Issuer:      %s\n", cert.Issuer))
+	sb.WriteString(fmt.Sprintf("  Usage:       %s\n", usage))
+	if len(hosts) > 0 {
+		sb.WriteString(fmt.Sprintf("  Hosts:       %s\n", strings.Join(hosts, ", ")))
+	}
+	sb.WriteString(fmt.Sprintf("  Key:         %s\n", keyType))
+	sb.WriteString(fmt.Sprintf("  Serial:      %x\n", cert.SerialNumber))
+	sb.WriteString(fmt.Sprintf("  Valid:       %s - %s (%s)\n",
+		cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339), expiry))
+	sb.WriteString(fmt.Sprintf("  Fingerprint: %s\n", pki.Fingerprint(cert)))
+
+	return sb.String()
+}
+
+// readCertificates parses all the PEM encoded certificates of the file.
+func readCertificates(file string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to parse certificate: %w", file, err)
 		}
+		certs = append(certs, cert)
 	}
 
-	// Запись приватного ключа
-	if err = os.WriteFile(keysPairDir+keyName, keyPEM.Bytes(), 0644); err != nil {
-		return fmt.Errorf("failed write tls private key: %w", err)
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%s: no PEM certificates found", file)
 	}
 
-	// Запись серификата
-	if err = os.WriteFile(keysPairDir+certName, certPEM.Bytes(), 0600); err != nil {
-		return fmt.Errorf("failed write tls public key: %w", err)
+	return certs, nil
+}
+
+// writeKeyPair writes the private key with the 0600 permissions and then the certificate.
+func writeKeyPair(certFile string, certPEM []byte, keyFile string, key crypto.Signer) error {
+	keyPEM, err := pki.MarshalPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err = writeFile(keyFile, keyPEM, 0600); err != nil {
+		return fmt.Errorf("failed write private key: %w", err)
+	}
+
+	if err = writeFile(certFile, certPEM, 0644); err != nil {
+		return fmt.Errorf("failed write certificate: %w", err)
 	}
 
 	return nil
 }
 
-// Проверяет наличие ключей и сертификата
-// Пересоздает пару, в случае отсутствия любого из ключей
-func checkCert() error {
-	_, errKey := os.ReadFile(keysPairDir + keyName)
-	if errKey != nil {
-		return fmt.Errorf("error reading tls private key: %w", errKey)
+// writeFile replaces the file atomically: the data is written into a temporary file of the same directory,
+// created with the 0600 permissions, which is renamed after the permissions are set.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed create container directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
 
+// checkOverwrite fails if any of the files exists, unless overwriting is forced.
+func checkOverwrite(force bool, files ...string) error {
+	if force {
+		return nil
 	}
 
-	_, errCert := os.ReadFile(keysPairDir + certName)
-	if errCert != nil {
-		return fmt.Errorf("error reading tls public key: %w", errCert)
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			return fmt.Errorf("%s already exists, pass -force to overwrite it", file)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// splitList splits the comma separated list, dropping the empty elements.
+func splitList(list string) []string {
+	var res []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// setDefault sets the flag value unless it is set already.
+func setDefault(value *string, def string) {
+	if *value == "" {
+		*value = def
+	}
+}
+
+// daysDuration converts the number of days into the duration.
+func daysDuration(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
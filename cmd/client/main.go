@@ -3,13 +3,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/mikhaylov123ty/GophKeeper/internal/client/app"
 	grpcClient "github.com/mikhaylov123ty/GophKeeper/internal/client/grpc"
 
 	clientConfig "github.com/mikhaylov123ty/GophKeeper/internal/client/config"
+	"github.com/mikhaylov123ty/GophKeeper/pkg/tracing"
 )
 
 var (
@@ -34,6 +37,18 @@ func main() {
 		slog.String("Output Folder", config.OutputFolder),
 	)
 
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		ServiceName:    "gophkeeper-client",
+		ServiceVersion: buildVersion,
+		Exporter:       config.Tracing.Exporter,
+		Endpoint:       config.Tracing.Endpoint,
+		Insecure:       config.Tracing.Insecure,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
 	grpc, err := grpcClient.New()
 	if err != nil {
 		panic(err)
@@ -41,6 +56,15 @@ func main() {
 
 	appSvc := app.New(grpc)
 
+	if config.Command != "" {
+		if err = appSvc.RunCommand(config.Command, config.CommandArgs); err != nil {
+			slog.Error("command failed", slog.String("command", config.Command), slog.String("error", err.Error()))
+			shutdownTracing(context.Background())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err = appSvc.Run(); err != nil {
 		panic(err)
 	}
@@ -3,15 +3,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"log/slog"
-	"sync"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/mikhaylov123ty/GophKeeper/internal/server"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/config"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/storage"
 	"github.com/mikhaylov123ty/GophKeeper/pkg/logger"
+	"github.com/mikhaylov123ty/GophKeeper/pkg/tracing"
 )
 
 var (
@@ -19,14 +23,22 @@ var (
 	buildDate    = "N/A"
 )
 
-// The main function serves as the starting point of the application execution
+// The main function serves as the starting point of the application execution,
+// it exits with a non-zero code if the server failed.
 func main() {
+	os.Exit(run())
+}
+
+// run starts the server and returns the exit code once it is stopped, the storage and the tracing
+// are closed before returning in any case.
+func run() int {
 	fmt.Printf("Server Build Version: %s\n", buildVersion)
 	fmt.Printf("Server Build Date: %s\n", buildDate)
 
 	cfg, err := config.Init()
 	if err != nil {
-		panic(err)
+		log.Printf("failed to initialize config: %s", err)
+		return 1
 	}
 
 	log.Printf("config initialized %+v", *cfg)
@@ -35,7 +47,8 @@ func main() {
 		config.GetLogger().LogLevel,
 		config.GetLogger().LogFormat,
 	); err != nil {
-		panic(err)
+		log.Printf("failed to initialize logger: %s", err)
+		return 1
 	}
 
 	slog.Info("logger initialized",
@@ -43,9 +56,30 @@ func main() {
 		slog.String("format", config.GetLogger().LogFormat),
 	)
 
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Options{
+		ServiceName:    "gophkeeper-server",
+		ServiceVersion: buildVersion,
+		Exporter:       config.GetTracing().Exporter,
+		Endpoint:       config.GetTracing().Endpoint,
+		Insecure:       config.GetTracing().Insecure,
+	})
+	if err != nil {
+		slog.Error("failed to initialize tracing", slog.String("error", err.Error()))
+		return 1
+	}
+	defer func() {
+		// Отправка оставшихся спанов
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to shutdown tracing", slog.String("error", err.Error()))
+		}
+	}()
+
+	slog.Info("tracing initialized", slog.String("exporter", config.GetTracing().Exporter))
+
 	storageService, err := storage.NewInstance(config.GetDB())
 	if err != nil {
-		panic(err)
+		slog.Error("failed to initialize storage", slog.String("error", err.Error()))
+		return 1
 	}
 	defer storageService.Close()
 
@@ -56,19 +90,20 @@ func main() {
 
 	serverInstance, err := server.New(storageService)
 	if err != nil {
-		panic(err)
+		slog.Error("failed to initialize server", slog.String("error", err.Error()))
+		return 1
 	}
 
-	//TODO add graceful shutdown
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
+	// Сервер останавливается по сигналу, после чего закрываются хранилище и трассировка
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-		if err = serverInstance.Start(); err != nil {
-			panic(err)
-		}
-	}()
+	if err = serverInstance.Start(ctx); err != nil {
+		slog.Error("server failed", slog.String("error", err.Error()))
+		return 1
+	}
+
+	slog.Info("server stopped")
 
-	wg.Wait()
+	return 0
 }
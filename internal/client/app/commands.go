@@ -0,0 +1,484 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/archive"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/config"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/importer"
+)
+
+// Names of the non-interactive client commands.
+const (
+	ExportCommand         = "export"
+	ImportArchiveCommand  = "import-archive"
+	RotateKeyCommand      = "rotate-key"
+	RecoveryKitCommand    = "recovery-kit"
+	RecoverAccountCommand = "recover-account"
+	EnrollDeviceCommand   = "enroll-device"
+	DevicesCommand        = "devices"
+)
+
+const (
+	plaintextJSON         = "json"
+	plaintextCSV          = "csv"
+	plaintextConfirmation = "I understand"
+
+	defaultRotationBatch = 100
+)
+
+// categories lists the item categories included in the vault exports.
+var categories = []string{models.CredsCategory, models.TextCategory, models.CardCategory, models.FileCategory}
+
+// RunCommand executes the named non-interactive command with its arguments instead of starting the TUI.
+func (a *App) RunCommand(name string, args []string) error {
+	switch name {
+	case ExportCommand:
+		return a.export(args)
+	case ImportArchiveCommand:
+		return a.importArchive(args)
+	case RotateKeyCommand:
+		return a.rotateKey(args)
+	case RecoveryKitCommand:
+		return a.recoveryKit(args)
+	case RecoverAccountCommand:
+		return a.recoverAccount(args)
+	case EnrollDeviceCommand:
+		return a.enrollDevice(args)
+	case DevicesCommand:
+		return a.devices(args)
+	default:
+		return fmt.Errorf("unknown command %q, expected one of: %s, %s, %s, %s, %s, %s, %s", name, ExportCommand,
+			ImportArchiveCommand, RotateKeyCommand, RecoveryKitCommand, RecoverAccountCommand, EnrollDeviceCommand,
+			DevicesCommand)
+	}
+}
+
+// export writes all items of the account into an archive encrypted with an export passphrase,
+// or into a plaintext JSON/CSV file when explicitly confirmed.
+func (a *App) export(args []string) error {
+	flags := flag.NewFlagSet(ExportCommand, flag.ContinueOnError)
+	output := flags.String("o", "", "Output file. Example: \"./vault.gka\"")
+	plaintext := flags.String("plaintext", "", "Write an unencrypted export in the given format: json or csv")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return fmt.Errorf("output file is required")
+	}
+
+	if *plaintext != "" && *plaintext != plaintextJSON && *plaintext != plaintextCSV {
+		return fmt.Errorf("unsupported plaintext format %q, expected json or csv", *plaintext)
+	}
+
+	prompt := newPrompter()
+	itemsManager, login, err := a.login(prompt)
+	if err != nil {
+		return err
+	}
+
+	payload := &archive.Payload{
+		ExportedAt: time.Now().UTC(),
+		Login:      login,
+	}
+	for _, category := range categories {
+		for _, v := range itemsManager.GetMetaData(category) {
+			data, err := itemsManager.GetItemData(v.DataID)
+			if err != nil {
+				return fmt.Errorf("failed to get item %q: %w", v.Title, err)
+			}
+
+			payload.Entries = append(payload.Entries, &archive.Entry{
+				Category:    category,
+				Title:       v.Title,
+				Description: v.Description,
+				Tags:        v.Tags,
+				Folder:      models.FolderPath(itemsManager.GetFolders(), v.FolderID),
+				Created:     v.Created,
+				Modified:    v.Modified,
+				Data:        json.RawMessage(data),
+			})
+		}
+	}
+
+	var write func(io.Writer) error
+	if *plaintext != "" {
+		answer, err := prompt.line(fmt.Sprintf("The export will NOT be encrypted, anyone with the file can read every secret.\n"+
+			"Type %q to continue: ", plaintextConfirmation))
+		if err != nil {
+			return err
+		}
+		if answer != plaintextConfirmation {
+			return fmt.Errorf("plaintext export cancelled")
+		}
+
+		write = func(w io.Writer) error {
+			if *plaintext == plaintextCSV {
+				return archive.WriteCSV(w, payload)
+			}
+			return archive.WriteJSON(w, payload)
+		}
+	} else {
+		passphrase, err := prompt.newSecret("Export passphrase: ")
+		if err != nil {
+			return err
+		}
+
+		sealed, err := archive.Seal(payload, passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to seal archive: %w", err)
+		}
+
+		write = func(w io.Writer) error {
+			_, err := w.Write(sealed)
+			return err
+		}
+	}
+
+	if err = writeFile(*output, write); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(prompt.out, "Exported %d items to %s\n", len(payload.Entries), *output)
+
+	return nil
+}
+
+// importArchive restores the items of an encrypted archive into the account, skipping duplicates.
+func (a *App) importArchive(args []string) error {
+	flags := flag.NewFlagSet(ImportArchiveCommand, flag.ContinueOnError)
+	input := flags.String("i", "", "Archive file. Example: \"./vault.gka\"")
+	dryRun := flags.Bool("dry-run", false, "Only show what would be imported")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *input == "" {
+		return fmt.Errorf("input file is required")
+	}
+
+	data, err := os.ReadFile(filepath.Clean(*input))
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	prompt := newPrompter()
+	passphrase, err := prompt.secret("Export passphrase: ")
+	if err != nil {
+		return err
+	}
+
+	payload, err := archive.Open(data, passphrase)
+	if err != nil {
+		return err
+	}
+
+	itemsManager, _, err := a.login(prompt)
+	if err != nil {
+		return err
+	}
+
+	batch := &importer.Batch{}
+	for _, v := range payload.Entries {
+		if !isCategory(v.Category) {
+			batch.Skipped++
+			continue
+		}
+
+		batch.Items = append(batch.Items, &importer.Item{
+			Category:    v.Category,
+			Title:       v.Title,
+			Description: v.Description,
+			Tags:        v.Tags,
+			Folder:      v.Folder,
+			Data:        v.Data,
+		})
+	}
+
+	summary := importer.Plan(batch, itemsManager)
+	fmt.Fprint(prompt.out, summary.String())
+	if *dryRun {
+		return nil
+	}
+
+	uploaded, err := importer.Upload(summary, itemsManager)
+	if err != nil {
+		return fmt.Errorf("imported %d of %d items: %w", uploaded, len(summary.Items), err)
+	}
+
+	fmt.Fprintf(prompt.out, "Imported %d items\n", uploaded)
+
+	return nil
+}
+
+// rotateKey replaces the vault key of the account with a new one and re-encrypts the keys of all items with it.
+// An interrupted rotation is resumed by running the command again.
+func (a *App) rotateKey(args []string) error {
+	flags := flag.NewFlagSet(RotateKeyCommand, flag.ContinueOnError)
+	batch := flags.Int("batch", defaultRotationBatch, "Number of records re-encrypted per request")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *batch <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+
+	prompt := newPrompter()
+	login, password, err := prompt.credentials()
+	if err != nil {
+		return err
+	}
+
+	itemsManager, err := a.authenticate(login, password)
+	if err != nil {
+		return err
+	}
+
+	if err = itemsManager.RotateVaultKey(password, int32(*batch), func(done int, remaining int64) {
+		fmt.Fprintf(prompt.out, "Re-encrypted %d records, %d left\n", done, remaining)
+	}); err != nil {
+		return fmt.Errorf("%w\nrun %s again to resume", err, RotateKeyCommand)
+	}
+
+	fmt.Fprintln(prompt.out, "Vault key rotated, the previous key is retired")
+
+	return nil
+}
+
+// recoveryKit creates a new recovery kit of the account, replacing the previous one, and prints the recovery kit sheet
+// or writes it into a file readable only by the owner.
+func (a *App) recoveryKit(args []string) error {
+	flags := flag.NewFlagSet(RecoveryKitCommand, flag.ContinueOnError)
+	output := flags.String("o", "", "Write the recovery kit sheet into the file instead of the terminal. Example: \"./recovery.txt\"")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	prompt := newPrompter()
+	login, password, err := prompt.credentials()
+	if err != nil {
+		return err
+	}
+
+	itemsManager, err := a.authenticate(login, password)
+	if err != nil {
+		return err
+	}
+
+	recoveryKey, err := itemsManager.CreateRecoveryKit(password)
+	if err != nil {
+		return err
+	}
+
+	sheet := utils.RecoveryKitSheet(login, recoveryKey, time.Now())
+	if *output == "" {
+		fmt.Fprint(os.Stdout, sheet)
+		return nil
+	}
+
+	if err = writeFile(*output, func(w io.Writer) error {
+		_, err := io.WriteString(w, sheet)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(prompt.out, "Recovery kit written to %s\n", *output)
+
+	return nil
+}
+
+// recoverAccount sets a new master password of the account with the recovery key of its recovery kit.
+func (a *App) recoverAccount(args []string) error {
+	flags := flag.NewFlagSet(RecoverAccountCommand, flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	prompt := newPrompter()
+	login, err := prompt.line("Login: ")
+	if err != nil {
+		return err
+	}
+
+	recoveryKey, err := prompt.secret("Recovery key: ")
+	if err != nil {
+		return err
+	}
+
+	password, err := prompt.newSecret("New password: ")
+	if err != nil {
+		return err
+	}
+
+	if err = tui.NewItemsManager(a.grpcClient).RecoverAccount(login, recoveryKey, password); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(prompt.out, "Master password changed, other sessions are signed out")
+
+	return nil
+}
+
+// enrollDevice enrolls the device for the mutual TLS after the password login and writes the device certificate
+// with its private key into the configured files, the key readable only by the owner.
+func (a *App) enrollDevice(args []string) error {
+	hostname, _ := os.Hostname()
+
+	flags := flag.NewFlagSet(EnrollDeviceCommand, flag.ContinueOnError)
+	name := flags.String("name", hostname, "Name of the device shown in the list of devices")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	keys := config.GetKeys()
+	if keys.ClientCert == "" {
+		return fmt.Errorf("configure the client certificate and key files first")
+	}
+
+	// Файлы проверяются до регистрации, чтобы не оставить на сервере устройство без ключа
+	for _, path := range []string{keys.ClientCert, keys.ClientKey} {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("file %s already exists, remove it to enroll the device again", path)
+		}
+	}
+
+	prompt := newPrompter()
+	itemsManager, _, err := a.login(prompt)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := itemsManager.EnrollDevice(*name)
+	if err != nil {
+		return err
+	}
+
+	if err = writeFile(keys.ClientKey, func(w io.Writer) error {
+		_, err := w.Write(credentials.PrivateKey)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err = writeFile(keys.ClientCert, func(w io.Writer) error {
+		_, err := w.Write(credentials.Certificate)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(prompt.out, "Device %s enrolled as %s\nCertificate fingerprint: %s\nExpires at: %s\n",
+		credentials.Device.Name, credentials.Device.ID, credentials.Device.Fingerprint, credentials.Device.Expires)
+
+	return nil
+}
+
+// devices lists the devices enrolled by the user or revokes one of them.
+func (a *App) devices(args []string) error {
+	flags := flag.NewFlagSet(DevicesCommand, flag.ContinueOnError)
+	revoke := flags.String("revoke", "", "ID of the device to revoke")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	prompt := newPrompter()
+	itemsManager, _, err := a.login(prompt)
+	if err != nil {
+		return err
+	}
+
+	if *revoke != "" {
+		if err = itemsManager.RevokeDevice(*revoke); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(prompt.out, "Device %s revoked\n", *revoke)
+
+		return nil
+	}
+
+	devices, err := itemsManager.GetDevices()
+	if err != nil {
+		return err
+	}
+
+	if len(devices) == 0 {
+		fmt.Fprintln(prompt.out, "No devices enrolled")
+	}
+	for _, v := range devices {
+		fmt.Fprintln(os.Stdout, v.Line())
+	}
+
+	return nil
+}
+
+// login asks for the account credentials, authenticates and synchronizes the metadata of the account.
+func (a *App) login(prompt *prompter) (*tui.ItemsManager, string, error) {
+	login, password, err := prompt.credentials()
+	if err != nil {
+		return nil, "", err
+	}
+
+	itemsManager, err := a.authenticate(login, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return itemsManager, login, nil
+}
+
+// authenticate logs into the account with the credentials and synchronizes the metadata of the account.
+func (a *App) authenticate(login string, password string) (*tui.ItemsManager, error) {
+	itemsManager := tui.NewItemsManager(a.grpcClient)
+	if err := itemsManager.PostUserData(login, password); err != nil {
+		return nil, err
+	}
+
+	if err := itemsManager.SyncMeta(); err != nil {
+		return nil, err
+	}
+
+	return itemsManager, nil
+}
+
+// writeFile creates the file readable only by the owner and writes the content into it.
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("file %s already exists", path)
+		}
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if err = write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return file.Close()
+}
+
+// isCategory checks the category is one of the known item categories.
+func isCategory(category string) bool {
+	for _, v := range categories {
+		if v == category {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// prompter reads answers of the non-interactive commands from the terminal, hiding secret input when possible.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// newPrompter creates a prompter reading from the standard input and writing questions to the standard error.
+func newPrompter() *prompter {
+	return &prompter{
+		in:  bufio.NewReader(os.Stdin),
+		out: os.Stderr,
+	}
+}
+
+// line asks the question and returns the trimmed answer.
+func (p *prompter) line(question string) (string, error) {
+	fmt.Fprint(p.out, question)
+
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	return strings.TrimSpace(answer), nil
+}
+
+// secret asks the question without echoing the answer when the standard input is a terminal.
+func (p *prompter) secret(question string) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return p.line(question)
+	}
+
+	fmt.Fprint(p.out, question)
+	answer, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(p.out)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret: %w", err)
+	}
+
+	return string(answer), nil
+}
+
+// credentials asks for the login and the password of the account.
+func (p *prompter) credentials() (string, string, error) {
+	login, err := p.line("Login: ")
+	if err != nil {
+		return "", "", err
+	}
+
+	password, err := p.secret("Password: ")
+	if err != nil {
+		return "", "", err
+	}
+
+	return login, password, nil
+}
+
+// newSecret asks for a new secret twice and checks both answers match.
+func (p *prompter) newSecret(question string) (string, error) {
+	first, err := p.secret(question)
+	if err != nil {
+		return "", err
+	}
+
+	second, err := p.secret("Repeat " + strings.ToLower(question[:1]) + question[1:])
+	if err != nil {
+		return "", err
+	}
+
+	if first != second {
+		return "", fmt.Errorf("entered values do not match")
+	}
+
+	return first, nil
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// CreateAPIToken creates the API token of the user valid for the days and returns it with its secret,
+// which the server doesn't show again. The token reaches the items of the folders and the data types only, when they are set.
+func (im *ItemsManager) CreateAPIToken(name string, readOnly bool, folderIDs []string, dataTypes []string,
+	ttlDays int32) (*models.APIToken, string, error) {
+	resp, err := im.grpcClient.Handlers.APITokenHandler.CreateAPIToken(context.Background(), &pb.CreateAPITokenRequest{
+		UserId:    im.userID,
+		Name:      name,
+		ReadOnly:  readOnly,
+		FolderIds: folderIDs,
+		DataTypes: dataTypes,
+		TtlDays:   ttlDays,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create api token: %w", err)
+	}
+
+	return apiTokenFromProto(resp.GetToken()), resp.GetSecret(), nil
+}
+
+// GetAPITokens returns the API tokens of the user, the expired ones included.
+func (im *ItemsManager) GetAPITokens() ([]*models.APIToken, error) {
+	resp, err := im.grpcClient.Handlers.APITokenHandler.GetAPITokens(context.Background(), &pb.GetAPITokensRequest{
+		UserId: im.userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api tokens: %w", err)
+	}
+
+	tokens := make([]*models.APIToken, len(resp.GetTokens()))
+	for i, v := range resp.GetTokens() {
+		tokens[i] = apiTokenFromProto(v)
+	}
+
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes the API token of the user, the server rejects it from now on.
+func (im *ItemsManager) RevokeAPIToken(tokenID string) error {
+	if _, err := im.grpcClient.Handlers.APITokenHandler.RevokeAPIToken(context.Background(), &pb.RevokeAPITokenRequest{
+		UserId:  im.userID,
+		TokenId: tokenID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+
+	return nil
+}
+
+// apiTokenFromProto converts the API token received from the server into the token shown to the user.
+func apiTokenFromProto(token *pb.APIToken) *models.APIToken {
+	return &models.APIToken{
+		ID:        token.GetId(),
+		Name:      token.GetName(),
+		ReadOnly:  token.GetReadOnly(),
+		FolderIDs: token.GetFolderIds(),
+		DataTypes: token.GetDataTypes(),
+		Expires:   token.GetExpires(),
+		Created:   token.GetCreated(),
+		LastUsed:  token.GetLastUsed(),
+	}
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// auditPageSize is the number of the audit events shown on a page of the activity.
+const auditPageSize = 50
+
+// GetAuditEvents returns a page of the audit log of the user from the latest event, narrowed down to the action
+// when it is set. The hashes of the events are recomputed here and the links of the consecutive events are checked,
+// so the events altered on the server are reported in the Broken field of the page.
+func (im *ItemsManager) GetAuditEvents(action string, pageToken string) (*models.AuditPage, error) {
+	resp, err := im.grpcClient.Handlers.AuditHandler.ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+		UserId:    im.userID,
+		Action:    action,
+		PageSize:  auditPageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit events: %w", err)
+	}
+
+	page := &models.AuditPage{
+		Events:        make([]*models.AuditEvent, len(resp.GetEvents())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+
+	chain := make([]*domain.AuditEvent, len(resp.GetEvents()))
+	for i, v := range resp.GetEvents() {
+		page.Events[i] = auditEventFromProto(v)
+
+		if chain[i], err = auditChainEvent(v); err != nil {
+			page.Broken = err.Error()
+		}
+	}
+
+	if page.Broken == "" {
+		if err = domain.VerifyAuditChain(chain); err != nil {
+			page.Broken = err.Error()
+		}
+	}
+
+	return page, nil
+}
+
+// auditEventFromProto converts the audit event received from the server into the event shown to the user.
+func auditEventFromProto(event *pb.AuditEvent) *models.AuditEvent {
+	return &models.AuditEvent{
+		Seq:        event.GetSeq(),
+		Action:     event.GetAction(),
+		Success:    event.GetSuccess(),
+		ItemID:     event.GetItemId(),
+		DeviceID:   event.GetDeviceId(),
+		APITokenID: event.GetApiTokenId(),
+		Peer:       event.GetPeer(),
+		Details:    event.GetDetails(),
+		Created:    event.GetCreated(),
+	}
+}
+
+// auditChainEvent converts the audit event received from the server into the event its hash is computed of.
+func auditChainEvent(event *pb.AuditEvent) (*domain.AuditEvent, error) {
+	res := &domain.AuditEvent{
+		Seq:      event.GetSeq(),
+		Action:   event.GetAction(),
+		Success:  event.GetSuccess(),
+		ItemID:   event.GetItemId(),
+		Peer:     event.GetPeer(),
+		Details:  event.GetDetails(),
+		PrevHash: event.GetPrevHash(),
+		Hash:     event.GetHash(),
+	}
+
+	var err error
+	if res.UserID, err = uuid.Parse(event.GetUserId()); err != nil {
+		return nil, fmt.Errorf("event %d has invalid user id", event.GetSeq())
+	}
+	if res.Created, err = time.Parse(time.RFC3339Nano, event.GetCreated()); err != nil {
+		return nil, fmt.Errorf("event %d has invalid time", event.GetSeq())
+	}
+	if event.GetDeviceId() != "" {
+		if res.DeviceID, err = uuid.Parse(event.GetDeviceId()); err != nil {
+			return nil, fmt.Errorf("event %d has invalid device id", event.GetSeq())
+		}
+	}
+	if event.GetApiTokenId() != "" {
+		if res.APITokenID, err = uuid.Parse(event.GetApiTokenId()); err != nil {
+			return nil, fmt.Errorf("event %d has invalid token id", event.GetSeq())
+		}
+	}
+
+	return res, nil
+}
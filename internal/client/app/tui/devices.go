@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// DeviceCredentials holds the PEM encoded certificate of the enrolled device with its private key,
+// and the certificate of the client CA which signed it.
+type DeviceCredentials struct {
+	Device        *models.Device
+	Certificate   []byte
+	PrivateKey    []byte
+	CACertificate []byte
+}
+
+// EnrollDevice enrolls the device under the name for the mutual TLS. The P-256 key of the device is generated here
+// and never leaves it, the server signs only the certificate request.
+func (im *ItemsManager) EnrollDevice(name string) (*DeviceCredentials, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate device key: %w", err)
+	}
+
+	// Сервер заменяет субъект идентификаторами пользователя и устройства
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: name},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode device key: %w", err)
+	}
+
+	resp, err := im.grpcClient.Handlers.DeviceHandler.EnrollDevice(context.Background(), &pb.EnrollDeviceRequest{
+		UserId: im.userID,
+		Name:   name,
+		Csr:    csr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll device: %w", err)
+	}
+
+	return &DeviceCredentials{
+		Device:        deviceFromProto(resp.GetDevice()),
+		Certificate:   resp.GetCertificate(),
+		PrivateKey:    pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		CACertificate: resp.GetCaCertificate(),
+	}, nil
+}
+
+// GetDevices returns the devices enrolled by the user, the revoked ones included.
+func (im *ItemsManager) GetDevices() ([]*models.Device, error) {
+	resp, err := im.grpcClient.Handlers.DeviceHandler.GetDevices(context.Background(), &pb.GetDevicesRequest{
+		UserId: im.userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	devices := make([]*models.Device, len(resp.GetDevices()))
+	for i, v := range resp.GetDevices() {
+		devices[i] = deviceFromProto(v)
+	}
+
+	return devices, nil
+}
+
+// RevokeDevice revokes the device of the user, the server rejects its certificate from now on.
+func (im *ItemsManager) RevokeDevice(deviceID string) error {
+	if _, err := im.grpcClient.Handlers.DeviceHandler.RevokeDevice(context.Background(), &pb.RevokeDeviceRequest{
+		UserId:   im.userID,
+		DeviceId: deviceID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke device: %w", err)
+	}
+
+	return nil
+}
+
+// deviceFromProto converts the device received from the server into the device shown to the user.
+func deviceFromProto(device *pb.Device) *models.Device {
+	return &models.Device{
+		ID:          device.GetId(),
+		Name:        device.GetName(),
+		Fingerprint: device.GetFingerprint(),
+		Expires:     device.GetExpires(),
+		Created:     device.GetCreated(),
+		Revoked:     device.GetRevoked(),
+	}
+}
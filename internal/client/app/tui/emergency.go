@@ -0,0 +1,337 @@
+package tui
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/uuid"
+	grpcLib "google.golang.org/grpc"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// emergencyKey is the vault key of the owner released to the user as a trusted contact, with its version.
+type emergencyKey struct {
+	keyID uint32
+	key   []byte
+}
+
+// GrantEmergencyAccess designates the user of the login as a trusted contact with the waiting period in hours.
+// The current vault key is sealed to the public sharing key of the contact, the server keeps it until the access
+// is approved by the user or the waiting period of a request passes.
+func (im *ItemsManager) GrantEmergencyAccess(login string, waitHours uint32) (*models.EmergencyAccess, error) {
+	contact, err := im.grpcClient.Handlers.SharingHandler.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{
+		Login: login,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of %s: %w", login, err)
+	}
+
+	vaultKey, err := im.vaultKey(im.vaultKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := vault.SealEmergencyKey(contact.GetPublicKey(), im.userID, im.vaultKeyID, vaultKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := im.grpcClient.Handlers.EmergencyHandler.GrantEmergencyAccess(context.Background(), &pb.GrantEmergencyAccessRequest{
+		UserId:       im.userID,
+		ContactLogin: login,
+		WaitHours:    waitHours,
+		KeyId:        im.vaultKeyID,
+		SealedKey:    sealed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant emergency access: %w", err)
+	}
+
+	return emergencyFromProto(resp.GetAccess()), nil
+}
+
+// GetEmergencyAccess returns the trusted contacts of the user and the owners who trust the user.
+func (im *ItemsManager) GetEmergencyAccess() ([]*models.EmergencyAccess, []*models.EmergencyAccess, error) {
+	resp, err := im.emergencyAccess()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	granted := make([]*models.EmergencyAccess, len(resp.GetGranted()))
+	for i, v := range resp.GetGranted() {
+		granted[i] = emergencyFromProto(v)
+	}
+
+	trustedBy := make([]*models.EmergencyAccess, len(resp.GetTrustedBy()))
+	for i, v := range resp.GetTrustedBy() {
+		trustedBy[i] = emergencyFromProto(v)
+	}
+
+	return granted, trustedBy, nil
+}
+
+// RevokeEmergencyAccess removes the trusted contact of the user.
+func (im *ItemsManager) RevokeEmergencyAccess(accessID string) error {
+	if _, err := im.grpcClient.Handlers.EmergencyHandler.RevokeEmergencyAccess(context.Background(), &pb.RevokeEmergencyAccessRequest{
+		UserId:   im.userID,
+		AccessId: accessID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke emergency access: %w", err)
+	}
+
+	return nil
+}
+
+// RequestEmergencyAccess requests the access to the vault of the owner who trusts the user, starting the waiting period.
+func (im *ItemsManager) RequestEmergencyAccess(accessID string) (*models.EmergencyAccess, error) {
+	resp, err := im.grpcClient.Handlers.EmergencyHandler.RequestEmergencyAccess(context.Background(), &pb.RequestEmergencyAccessRequest{
+		UserId:   im.userID,
+		AccessId: accessID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to request emergency access: %w", err)
+	}
+
+	return emergencyFromProto(resp.GetAccess()), nil
+}
+
+// AnswerEmergencyAccess approves the request of the trusted contact at once or rejects it.
+func (im *ItemsManager) AnswerEmergencyAccess(accessID string, approve bool) error {
+	if _, err := im.grpcClient.Handlers.EmergencyHandler.AnswerEmergencyAccess(context.Background(), &pb.AnswerEmergencyAccessRequest{
+		UserId:   im.userID,
+		AccessId: accessID,
+		Approve:  approve,
+	}); err != nil {
+		return fmt.Errorf("failed to answer emergency access: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmergencyItems opens the vault of the owner released to the user and returns the metadata of its items.
+// The metadata stored before the encryption of the metadata is returned as is.
+func (im *ItemsManager) GetEmergencyItems(accessID string) ([]*models.MetaItem, error) {
+	key, err := im.openEmergencyVault(accessID)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*models.MetaItem
+	var pageToken string
+	for {
+		resp, err := im.grpcClient.Handlers.EmergencyHandler.GetEmergencyItems(context.Background(), &pb.GetEmergencyItemsRequest{
+			UserId:    im.userID,
+			AccessId:  accessID,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get emergency items: %w", err)
+		}
+
+		for _, v := range resp.GetItems() {
+			id, err := uuid.Parse(v.GetId())
+			if err != nil {
+				return nil, fmt.Errorf("invalid meta item id: %s", v.GetId())
+			}
+
+			item := &models.MetaItem{
+				ID:          id,
+				Category:    v.GetDataType(),
+				Title:       v.GetTitle(),
+				Description: v.GetDescription(),
+				DataID:      v.GetDataId(),
+				Created:     v.GetCreated(),
+				Modified:    v.GetModified(),
+				Tags:        v.GetTags(),
+			}
+			if err = openEmergencyMeta(key, v, item); err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+
+		if pageToken = resp.GetNextPageToken(); pageToken == "" {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// GetEmergencyItem fetches and decrypts the data of the item of the owner released to the user.
+// Items stored before the data keys were introduced are decrypted with the certificate derived key.
+func (im *ItemsManager) GetEmergencyItem(accessID string, dataID string) (string, error) {
+	key, err := im.openEmergencyVault(accessID)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := im.grpcClient.Handlers.EmergencyHandler.GetEmergencyItem(context.Background(), &pb.GetEmergencyItemRequest{
+		UserId:   im.userID,
+		AccessId: accessID,
+		DataId:   dataID,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	)
+	if err != nil {
+		return "", fmt.Errorf("could not get emergency item: %w", err)
+	}
+
+	if len(resp.GetWrappedKey()) == 0 {
+		data, err := utils.DeryptData(resp.GetData())
+		if err != nil {
+			return "", fmt.Errorf("failed decrypt data: %w", err)
+		}
+
+		return string(data), nil
+	}
+
+	keyID, err := vault.WrappedKeyID(resp.GetWrappedKey())
+	if err != nil {
+		return "", err
+	}
+	if keyID != key.keyID {
+		return "", fmt.Errorf("item %s is encrypted with vault key %d, released key %d", dataID, keyID, key.keyID)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(string(resp.GetData()))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	data, err := vault.OpenItem(key.key, dataID, blob, resp.GetWrappedKey())
+	if err != nil {
+		return "", fmt.Errorf("failed decrypt data: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// resealEmergencyKeys seals the vault key of the given version to every trusted contact of the user,
+// so the contacts keep their access after a key rotation.
+func (im *ItemsManager) resealEmergencyKeys(keyID uint32) error {
+	resp, err := im.emergencyAccess()
+	if err != nil {
+		return err
+	}
+
+	if len(resp.GetGranted()) == 0 {
+		return nil
+	}
+
+	vaultKey, err := im.vaultKey(keyID)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.UpdateEmergencyKeysRequest{UserId: im.userID}
+	for _, v := range resp.GetGranted() {
+		sealed, err := vault.SealEmergencyKey(v.GetContactPublicKey(), im.userID, keyID, vaultKey)
+		if err != nil {
+			return fmt.Errorf("emergency access of %s: %w", v.GetContactLogin(), err)
+		}
+
+		request.Keys = append(request.Keys, &pb.EmergencyKey{
+			AccessId:  v.GetId(),
+			KeyId:     keyID,
+			SealedKey: sealed,
+		})
+	}
+
+	if _, err = im.grpcClient.Handlers.EmergencyHandler.UpdateEmergencyKeys(context.Background(), request); err != nil {
+		return fmt.Errorf("failed to update emergency keys: %w", err)
+	}
+
+	return nil
+}
+
+// emergencyAccess requests the emergency access of the user as an owner and as a trusted contact.
+func (im *ItemsManager) emergencyAccess() (*pb.GetEmergencyAccessResponse, error) {
+	resp, err := im.grpcClient.Handlers.EmergencyHandler.GetEmergencyAccess(context.Background(), &pb.GetEmergencyAccessRequest{
+		UserId: im.userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency access: %w", err)
+	}
+
+	return resp, nil
+}
+
+// openEmergencyVault returns the vault key of the owner released by the access, opening it with the private sharing key
+// of the user on the first use.
+func (im *ItemsManager) openEmergencyVault(accessID string) (*emergencyKey, error) {
+	if key, ok := im.emergencyKeys[accessID]; ok {
+		return key, nil
+	}
+
+	if im.sharingKey == nil {
+		return nil, fmt.Errorf("sharing key is not loaded")
+	}
+
+	resp, err := im.grpcClient.Handlers.EmergencyHandler.GetEmergencyVault(context.Background(), &pb.GetEmergencyVaultRequest{
+		UserId:   im.userID,
+		AccessId: accessID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get emergency vault: %w", err)
+	}
+
+	keyID, key, err := vault.OpenEmergencyKey(im.sharingKey, resp.GetOwnerId(), resp.GetSealedKey())
+	if err != nil {
+		return nil, err
+	}
+
+	im.emergencyKeys[accessID] = &emergencyKey{keyID: keyID, key: key}
+
+	return im.emergencyKeys[accessID], nil
+}
+
+// openEmergencyMeta decrypts the title, description and tags of the item of the owner with the released vault key,
+// if they are encrypted.
+func openEmergencyMeta(key *emergencyKey, metaData *pb.MetaData, item *models.MetaItem) error {
+	if len(metaData.GetEncryptedMeta()) == 0 {
+		return nil
+	}
+
+	keyID, err := vault.MetaKeyID(metaData.GetEncryptedMeta())
+	if err != nil {
+		return fmt.Errorf("item %s: %w", metaData.GetId(), err)
+	}
+	if keyID != key.keyID {
+		return fmt.Errorf("item %s is encrypted with vault key %d, released key %d", metaData.GetId(), keyID, key.keyID)
+	}
+
+	meta, err := vault.OpenMeta(key.key, metaData.GetId(), metaData.GetDataType(), metaData.GetEncryptedMeta())
+	if err != nil {
+		return fmt.Errorf("item %s: %w", metaData.GetId(), err)
+	}
+
+	item.Title = meta.Title
+	item.Description = meta.Description
+	item.Tags = meta.Tags
+
+	return nil
+}
+
+// emergencyFromProto converts the emergency access received from the server into the access shown to the user.
+func emergencyFromProto(access *pb.EmergencyAccess) *models.EmergencyAccess {
+	res := &models.EmergencyAccess{
+		ID:           access.GetId(),
+		OwnerLogin:   access.GetOwnerLogin(),
+		ContactLogin: access.GetContactLogin(),
+		WaitHours:    access.GetWaitHours(),
+		Status:       access.GetStatus(),
+		ReleaseAt:    access.GetReleaseAt(),
+	}
+	if len(access.GetContactPublicKey()) > 0 {
+		res.Fingerprint = vault.KeyFingerprint(access.GetContactPublicKey())
+	}
+
+	return res
+}
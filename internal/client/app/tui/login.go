@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+	"github.com/mikhaylov123ty/GophKeeper/internal/srp"
+)
+
+// srpLogin logs in with the SRP exchange and checks the proof of the server.
+func (im *ItemsManager) srpLogin(login string, password string) (*pb.PostUserDataResponse, error) {
+	client, start, err := im.startLogin(login)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := im.clientProof(client, start, login, password)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := im.grpcClient.Handlers.AuthHandler.FinishLogin(context.Background(), &pb.FinishLoginRequest{
+		SessionId:   start.GetSessionId(),
+		ClientProof: proof,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Сервер подтверждает, что знает верификатор пользователя
+	if err = client.VerifyServer(res.GetServerProof()); err != nil {
+		return nil, fmt.Errorf("server failed to prove the password record: %w", err)
+	}
+
+	return res, nil
+}
+
+// registerOrUpgrade handles the login rejected by the SRP exchange. The server doesn't tell an unknown login
+// or a legacy account from a wrong password, so the unknown login is registered and logged in, and a taken login
+// is tried as a legacy account. The error of the SRP exchange is returned if the password is wrong.
+func (im *ItemsManager) registerOrUpgrade(login string, password string, loginErr error) (*pb.PostUserDataResponse, error) {
+	err := im.register(login, password)
+	if err == nil {
+		return im.srpLogin(login, password)
+	}
+	if status.Code(err) != codes.AlreadyExists {
+		return nil, err
+	}
+
+	res, err := im.upgradeLogin(login, password)
+	if status.Code(err) == codes.PermissionDenied {
+		return nil, loginErr
+	}
+
+	return res, err
+}
+
+// upgradeLogin logs a legacy account in with the password, replacing the password hash on the server
+// with the SRP record of the password.
+func (im *ItemsManager) upgradeLogin(login string, password string) (*pb.PostUserDataResponse, error) {
+	record, err := vault.NewLoginRecord(login, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return im.grpcClient.Handlers.AuthHandler.PostUserData(context.Background(), &pb.PostUserDataRequest{
+		Login:     login,
+		Password:  password,
+		SrpRecord: loginRecordToProto(record),
+	})
+}
+
+// register creates the account with the SRP record of the password.
+func (im *ItemsManager) register(login string, password string) error {
+	record, err := vault.NewLoginRecord(login, password)
+	if err != nil {
+		return err
+	}
+
+	if _, err = im.grpcClient.Handlers.AuthHandler.RegisterUser(context.Background(), &pb.RegisterUserRequest{
+		Login:     login,
+		SrpRecord: loginRecordToProto(record),
+	}); err != nil {
+		return fmt.Errorf("failed to register: %w", err)
+	}
+
+	return nil
+}
+
+// confirmPassword proves the knowledge of the master password by a new SRP exchange, returning the exchange ID
+// and the proof of the client to be sent with the request changing the account.
+func (im *ItemsManager) confirmPassword(password string) (string, []byte, error) {
+	client, start, err := im.startLogin(im.login)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to confirm password: %w", err)
+	}
+
+	proof, err := im.clientProof(client, start, im.login, password)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return start.GetSessionId(), proof, nil
+}
+
+// startLogin generates the ephemeral key pair of the client and starts the SRP exchange.
+func (im *ItemsManager) startLogin(login string) (*srp.Client, *pb.StartLoginResponse, error) {
+	client, err := srp.NewClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start, err := im.grpcClient.Handlers.AuthHandler.StartLogin(context.Background(), &pb.StartLoginRequest{
+		Login:           login,
+		ClientPublicKey: client.PublicKey(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, start, nil
+}
+
+// clientProof derives the SRP secret from the password with the parameters of the account
+// and computes the proof of the client.
+func (im *ItemsManager) clientProof(client *srp.Client, start *pb.StartLoginResponse, login string, password string) ([]byte, error) {
+	record := start.GetSrpRecord()
+	x, err := vault.LoginSecret(login, password, &vault.LoginRecord{
+		Salt:    record.GetSalt(),
+		Time:    record.GetKdfTime(),
+		Memory:  record.GetKdfMemory(),
+		Threads: record.GetKdfThreads(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Proof(login, record.GetSalt(), x, start.GetServerPublicKey())
+}
+
+// loginRecordToProto converts the SRP registration record into its gRPC representation.
+func loginRecordToProto(record *vault.LoginRecord) *pb.SrpRecord {
+	return &pb.SrpRecord{
+		Salt:       record.Salt,
+		Verifier:   record.Verifier,
+		KdfTime:    record.Time,
+		KdfMemory:  record.Memory,
+		KdfThreads: record.Threads,
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APITokenTTLDays lists the lifetimes in days the user chooses from when creating an API token.
+var APITokenTTLDays = []int32{30, 90, 180, 365}
+
+// APIToken represents a personal API token of the user for the automation. FolderIDs and DataTypes restrict
+// the items reached by the token, empty ones don't. LastUsed is empty until the token is used.
+type APIToken struct {
+	ID        string
+	Name      string
+	ReadOnly  bool
+	FolderIDs []string
+	DataTypes []string
+	Expires   string
+	Created   string
+	LastUsed  string
+}
+
+// Line renders the token as a line of the list of the API tokens of the user.
+func (t *APIToken) Line() string {
+	access := "read-write"
+	if t.ReadOnly {
+		access = "read-only"
+	}
+
+	scope := "all items"
+	if len(t.DataTypes) > 0 {
+		scope = strings.Join(t.DataTypes, ",")
+	}
+	if len(t.FolderIDs) > 0 {
+		scope += fmt.Sprintf(" in %d folders", len(t.FolderIDs))
+	}
+
+	lastUsed := "never used"
+	if t.LastUsed != "" {
+		lastUsed = "last used at " + t.LastUsed
+	}
+
+	return fmt.Sprintf("%s %s, %s, %s, expires at %s, %s", t.ID, t.Name, access, scope, t.Expires, lastUsed)
+}
+
+// NextTTLDays returns the lifetime following the current one among the offered lifetimes, wrapping around.
+func NextTTLDays(current int32) int32 {
+	for i, v := range APITokenTTLDays {
+		if v == current {
+			return APITokenTTLDays[(i+1)%len(APITokenTTLDays)]
+		}
+	}
+
+	return APITokenTTLDays[0]
+}
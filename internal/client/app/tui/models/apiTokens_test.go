@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIToken_Line(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *APIToken
+		want  string
+	}{
+		{
+			name:  "unrestricted",
+			token: &APIToken{ID: "t1", Name: "ci", Expires: "2027-01-02T10:00:00Z"},
+			want:  "t1 ci, read-write, all items, expires at 2027-01-02T10:00:00Z, never used",
+		},
+		{
+			name: "scoped",
+			token: &APIToken{ID: "t1", Name: "ci", ReadOnly: true, DataTypes: []string{"Creds", "Text"},
+				FolderIDs: []string{"f1"}, Expires: "2027-01-02T10:00:00Z", LastUsed: "2026-05-01T10:00:00Z"},
+			want: "t1 ci, read-only, Creds,Text in 1 folders, expires at 2027-01-02T10:00:00Z, last used at 2026-05-01T10:00:00Z",
+		},
+		{
+			name:  "folders only",
+			token: &APIToken{ID: "t1", Name: "ci", FolderIDs: []string{"f1", "f2"}, Expires: "2027-01-02T10:00:00Z"},
+			want:  "t1 ci, read-write, all items in 2 folders, expires at 2027-01-02T10:00:00Z, never used",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.token.Line())
+		})
+	}
+}
+
+func TestNextTTLDays(t *testing.T) {
+	tests := []struct {
+		current int32
+		want    int32
+	}{
+		{current: 30, want: 90},
+		{current: 180, want: 365},
+		{current: 365, want: 30},
+		{current: 7, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.current), func(t *testing.T) {
+			assert.Equal(t, tt.want, NextTTLDays(tt.current))
+		})
+	}
+}
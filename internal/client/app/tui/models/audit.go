@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+)
+
+// AuditActions lists the actions the activity of the user is filtered by, the empty one shows every action.
+var AuditActions = []string{"", "login", "item_read", "item_create", "item_update", "item_delete", "share_create",
+	"share_revoke", "api_token_create", "api_token_revoke", "device_enroll", "password_change"}
+
+// AuditEvent represents an event of the audit log of the user. DeviceID and APITokenID are empty
+// when the request had no device certificate or API token.
+type AuditEvent struct {
+	Seq        int64
+	Action     string
+	Success    bool
+	ItemID     string
+	DeviceID   string
+	APITokenID string
+	Peer       string
+	Details    string
+	Created    string
+}
+
+// AuditPage represents a page of the audit log with the result of the verification of its hash chain.
+// Broken describes the first inconsistency of the chain and is empty when the page is intact.
+type AuditPage struct {
+	Events        []*AuditEvent
+	NextPageToken string
+	Broken        string
+}
+
+// Line renders the event as a line of the activity of the user.
+func (e *AuditEvent) Line() string {
+	res := fmt.Sprintf("#%d %s %s", e.Seq, e.Created, e.Action)
+	if !e.Success {
+		res += " FAILED"
+		if e.Details != "" {
+			res += " (" + e.Details + ")"
+		}
+	}
+	if e.ItemID != "" {
+		res += " item " + e.ItemID
+	}
+	if e.Peer != "" {
+		res += " from " + e.Peer
+	}
+	if e.DeviceID != "" {
+		res += " device " + e.DeviceID
+	}
+	if e.APITokenID != "" {
+		res += " token " + e.APITokenID
+	}
+
+	return res
+}
+
+// NextAuditAction returns the action following the current one among the filtered actions, wrapping around.
+func NextAuditAction(current string) string {
+	for i, v := range AuditActions {
+		if v == current {
+			return AuditActions[(i+1)%len(AuditActions)]
+		}
+	}
+
+	return AuditActions[0]
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEvent_Line(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *AuditEvent
+		want  string
+	}{
+		{
+			name:  "login",
+			event: &AuditEvent{Seq: 1, Action: "login", Success: true, Created: "2026-05-01T10:00:00Z", Peer: "10.0.0.1:5000"},
+			want:  "#1 2026-05-01T10:00:00Z login from 10.0.0.1:5000",
+		},
+		{
+			name: "failed login",
+			event: &AuditEvent{Seq: 2, Action: "login", Details: "PermissionDenied", Created: "2026-05-01T10:00:00Z",
+				Peer: "10.0.0.1:5000"},
+			want: "#2 2026-05-01T10:00:00Z login FAILED (PermissionDenied) from 10.0.0.1:5000",
+		},
+		{
+			name: "item read by token",
+			event: &AuditEvent{Seq: 3, Action: "item_read", Success: true, ItemID: "i1", DeviceID: "d1", APITokenID: "t1",
+				Created: "2026-05-01T10:00:00Z"},
+			want: "#3 2026-05-01T10:00:00Z item_read item i1 device d1 token t1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.event.Line())
+		})
+	}
+}
+
+func TestNextAuditAction(t *testing.T) {
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{current: "", want: "login"},
+		{current: "login", want: "item_read"},
+		{current: "password_change", want: ""},
+		{current: "unknown", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextAuditAction(tt.current))
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Device represents a device of the user enrolled for the mutual TLS, with the fingerprint of its certificate.
+// Revoked is empty while the device is enrolled.
+type Device struct {
+	ID          string
+	Name        string
+	Fingerprint string
+	Expires     string
+	Created     string
+	Revoked     string
+}
+
+// Line renders the device as a line of the list of the devices of the user.
+func (d *Device) Line() string {
+	if d.Revoked != "" {
+		return fmt.Sprintf("%s %s revoked at %s", d.ID, d.Name, d.Revoked)
+	}
+
+	return fmt.Sprintf("%s %s cert %s expires at %s", d.ID, d.Name, d.Fingerprint, d.Expires)
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevice_Line(t *testing.T) {
+	tests := []struct {
+		name   string
+		device *Device
+		want   string
+	}{
+		{
+			name:   "enrolled",
+			device: &Device{ID: "d1", Name: "laptop", Fingerprint: "ab12", Expires: "2027-01-02T10:00:00Z"},
+			want:   "d1 laptop cert ab12 expires at 2027-01-02T10:00:00Z",
+		},
+		{
+			name: "revoked",
+			device: &Device{ID: "d1", Name: "laptop", Fingerprint: "ab12", Expires: "2027-01-02T10:00:00Z",
+				Revoked: "2026-05-01T10:00:00Z"},
+			want: "d1 laptop revoked at 2026-05-01T10:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.device.Line())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Statuses of the emergency access, as stored on the server.
+const (
+	EmergencyStatusGranted   = "granted"
+	EmergencyStatusRequested = "requested"
+	EmergencyStatusApproved  = "approved"
+	EmergencyStatusRejected  = "rejected"
+)
+
+// EmergencyWaitHours lists the waiting periods the owner chooses from when designating a trusted contact.
+var EmergencyWaitHours = []uint32{24, 48, 72, 168, 336, 720}
+
+// EmergencyAccess represents the emergency access to the vault of the owner by the trusted contact.
+// The owner sees the contact login and its key fingerprint, the contact sees the owner login.
+// ReleaseAt is set while the access is requested: the moment the vault key is released unless the owner rejects it.
+type EmergencyAccess struct {
+	ID           string
+	OwnerLogin   string
+	ContactLogin string
+	Fingerprint  string
+	WaitHours    uint32
+	Status       string
+	ReleaseAt    string
+}
+
+// Released reports whether the trusted contact can open the vault of the owner.
+func (e *EmergencyAccess) Released() bool {
+	return e.Status == EmergencyStatusApproved
+}
+
+// GrantedLine renders the access as a line of the list of trusted contacts of the owner.
+func (e *EmergencyAccess) GrantedLine() string {
+	return fmt.Sprintf("%s key %s, wait %dh: %s", e.ContactLogin, e.Fingerprint, e.WaitHours, e.statusLine())
+}
+
+// TrustedByLine renders the access as a line of the list of owners who trust the user.
+func (e *EmergencyAccess) TrustedByLine() string {
+	return fmt.Sprintf("%s, wait %dh: %s", e.OwnerLogin, e.WaitHours, e.statusLine())
+}
+
+// statusLine renders the status of the access with the release moment of a request.
+func (e *EmergencyAccess) statusLine() string {
+	if e.Status == EmergencyStatusRequested {
+		return fmt.Sprintf("%s, released at %s", e.Status, e.ReleaseAt)
+	}
+
+	return e.Status
+}
+
+// NextWaitHours returns the waiting period following the current one among the offered periods, wrapping around.
+func NextWaitHours(current uint32) uint32 {
+	for i, v := range EmergencyWaitHours {
+		if v == current {
+			return EmergencyWaitHours[(i+1)%len(EmergencyWaitHours)]
+		}
+	}
+
+	return EmergencyWaitHours[0]
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmergencyAccess_Released(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{status: EmergencyStatusGranted, want: false},
+		{status: EmergencyStatusRequested, want: false},
+		{status: EmergencyStatusApproved, want: true},
+		{status: EmergencyStatusRejected, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			access := &EmergencyAccess{Status: tt.status}
+			assert.Equal(t, tt.want, access.Released())
+		})
+	}
+}
+
+func TestEmergencyAccess_Lines(t *testing.T) {
+	tests := []struct {
+		name          string
+		access        *EmergencyAccess
+		wantGranted   string
+		wantTrustedBy string
+	}{
+		{
+			name: "granted",
+			access: &EmergencyAccess{OwnerLogin: "alice", ContactLogin: "bob", Fingerprint: "ab12", WaitHours: 48,
+				Status: EmergencyStatusGranted},
+			wantGranted:   "bob key ab12, wait 48h: granted",
+			wantTrustedBy: "alice, wait 48h: granted",
+		},
+		{
+			name: "requested",
+			access: &EmergencyAccess{OwnerLogin: "alice", ContactLogin: "bob", Fingerprint: "ab12", WaitHours: 24,
+				Status: EmergencyStatusRequested, ReleaseAt: "2026-01-02T10:00:00Z"},
+			wantGranted:   "bob key ab12, wait 24h: requested, released at 2026-01-02T10:00:00Z",
+			wantTrustedBy: "alice, wait 24h: requested, released at 2026-01-02T10:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantGranted, tt.access.GrantedLine())
+			assert.Equal(t, tt.wantTrustedBy, tt.access.TrustedByLine())
+		})
+	}
+}
+
+func TestNextWaitHours(t *testing.T) {
+	tests := []struct {
+		current uint32
+		want    uint32
+	}{
+		{current: 24, want: 48},
+		{current: 168, want: 336},
+		{current: 720, want: 24},
+		{current: 5, want: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.current), func(t *testing.T) {
+			assert.Equal(t, tt.want, NextWaitHours(tt.current))
+		})
+	}
+}
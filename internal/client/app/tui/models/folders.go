@@ -0,0 +1,105 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// Folder represents a user-defined folder of items, ParentID is empty for the top-level folders.
+type Folder struct {
+	ID       string
+	Name     string
+	ParentID string
+}
+
+// ParseTags splits the comma separated tags typed by the user, dropping empty ones and duplicates.
+func ParseTags(tags string) []string {
+	var res []string
+	seen := map[string]bool{}
+	for _, v := range strings.Split(tags, ",") {
+		tag := strings.TrimSpace(v)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		res = append(res, tag)
+	}
+
+	return res
+}
+
+// FormatTags joins the tags into the comma separated form accepted by ParseTags.
+func FormatTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
+
+// SplitFolderPath splits the slash separated folder path into the folder names, dropping empty ones.
+func SplitFolderPath(path string) []string {
+	var res []string
+	for _, v := range strings.Split(path, "/") {
+		if name := strings.TrimSpace(v); name != "" {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// FolderPath returns the slash separated path of the folder from the top level, or an empty string for unknown folders.
+func FolderPath(folders []*Folder, id string) string {
+	byID := make(map[string]*Folder, len(folders))
+	for _, v := range folders {
+		byID[v.ID] = v
+	}
+
+	var names []string
+	for folder, ok := byID[id]; ok && len(names) <= len(folders); folder, ok = byID[folder.ParentID] {
+		names = append([]string{folder.Name}, names...)
+	}
+
+	return strings.Join(names, "/")
+}
+
+// FindFolder returns the folder with the given name inside the parent folder, or nil if there is none.
+func FindFolder(folders []*Folder, parentID string, name string) *Folder {
+	for _, v := range folders {
+		if v.ParentID == parentID && v.Name == name {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// SubFolders returns the folders placed directly inside the parent folder, sorted by name.
+func SubFolders(folders []*Folder, parentID string) []*Folder {
+	var res []*Folder
+	for _, v := range folders {
+		if v.ParentID == parentID {
+			res = append(res, v)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return strings.ToLower(res[i].Name) < strings.ToLower(res[j].Name)
+	})
+
+	return res
+}
+
+// DescendantFolders returns the IDs of the folder and all of its subfolders.
+func DescendantFolders(folders []*Folder, id string) map[string]bool {
+	res := map[string]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for _, v := range folders {
+			if res[v.ParentID] && !res[v.ID] {
+				res[v.ID] = true
+				changed = true
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tags string
+		want []string
+	}{
+		{name: "empty", tags: " ", want: nil},
+		{name: "trims and deduplicates", tags: "work, home,,work ", want: []string{"work", "home"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseTags(tt.tags))
+		})
+	}
+
+	assert.Equal(t, "work, home", FormatTags([]string{"work", "home"}))
+}
+
+func TestFolders(t *testing.T) {
+	folders := []*Folder{
+		{ID: "1", Name: "Work"},
+		{ID: "2", Name: "servers", ParentID: "1"},
+		{ID: "3", Name: "Db", ParentID: "2"},
+		{ID: "4", Name: "Clients", ParentID: "1"},
+		{ID: "5", Name: "Home"},
+	}
+
+	assert.Equal(t, []string{"Work", "servers"}, SplitFolderPath("/Work// servers /"))
+	assert.Equal(t, "Work/servers/Db", FolderPath(folders, "3"))
+	assert.Equal(t, "", FolderPath(folders, "missing"))
+	assert.Equal(t, folders[1], FindFolder(folders, "1", "servers"))
+	assert.Nil(t, FindFolder(folders, "", "servers"))
+	assert.Equal(t, []*Folder{folders[3], folders[1]}, SubFolders(folders, "1"))
+	assert.Equal(t, map[string]bool{"1": true, "2": true, "3": true, "4": true}, DescendantFolders(folders, "1"))
+}
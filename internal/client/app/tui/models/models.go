@@ -3,7 +3,9 @@ package models
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +15,22 @@ import (
 	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
 )
 
+// Item categories shared by the screens and the services producing items for them.
+const (
+	TextCategory  = "Text"
+	CredsCategory = "Creds"
+	FileCategory  = "Files"
+	CardCategory  = "Cards"
+)
+
+// Fields the item lists can be sorted by. Relevance keeps the order of the search ranking.
+const (
+	SortByRelevance = "relevance"
+	SortByTitle     = "title"
+	SortByCreated   = "created"
+	SortByModified  = "modified"
+)
+
 // Screen is an interface defining methods for screen management used in a terminal-based UI application.
 // Update handles messages or events and returns the updated Screen along with an optional command to execute.
 // View returns the string representation of the current screen for rendering.
@@ -29,6 +47,47 @@ type Screen interface {
 // DeleteItem removes an item using uuid, string key, and additional parameters.
 // PostUserData handles user authentication by posting user data with the given credentials.
 // SyncMeta synchronizes the metadata across the system.
+// GetFolders returns the cached folders of the user.
+// FolderByPath returns the ID of the folder with the given slash separated path, creating missing folders.
+// PostFolder creates a folder with the given name inside the parent folder.
+// DeleteFolder removes a folder with its subfolders, moving their items to the top level.
+// ChangePassword changes the master password, given the current and the new one.
+// CreateRecoveryKit replaces the recovery kit of the account and returns the new recovery key.
+// NewAccount reports whether the account was created by the last login.
+// ShareItem shares the item with the user of the login, read-only or editable.
+// GetShares returns the shares of the item with the given data ID.
+// RevokeShare revokes the share of the item and re-encrypts the item for the remaining recipients.
+// GetSharedWithMe returns the items other users have shared with the user.
+// GetSharedItem fetches the data of the item shared by the share with the given ID.
+// UpdateSharedItem saves the new data of the item shared for editing by the share with the given ID.
+// GetOrgs returns the organizations the user is a member of or invited to.
+// CreateOrg creates an organization with the given name owned by the user.
+// DeleteOrg removes the organization with all its collections.
+// AnswerInvitation accepts or declines the invitation into the organization.
+// GetOrgMembers returns the members and the invited users of the organization.
+// InviteMember invites the user of the login into the organization with the role.
+// RemoveMember removes the member from the organization and re-keys the collections of the organization.
+// ChangeRole changes the role of the member of the organization.
+// GetCollections returns the collections of the organization.
+// CreateCollection creates a collection with the given name in the organization.
+// DeleteCollection removes the collection with its items.
+// GetCollectionItems returns the metadata of the items of the collection.
+// GetCollectionItem fetches the data of the item of the collection.
+// PostCollectionItem saves the item data with its metadata in the collection.
+// DeleteCollectionItem removes the item of a collection.
+// GrantEmergencyAccess designates the user of the login as a trusted contact with the waiting period in hours.
+// GetEmergencyAccess returns the trusted contacts of the user and the owners who trust the user.
+// RevokeEmergencyAccess removes the trusted contact of the user.
+// RequestEmergencyAccess requests the access to the vault of the owner, starting the waiting period.
+// AnswerEmergencyAccess approves or rejects the request of the trusted contact.
+// GetEmergencyItems returns the metadata of the items of the owner released to the user.
+// GetEmergencyItem fetches the data of the item of the owner released to the user.
+// CreateAPIToken creates a personal API token with the name, access, folders, data types and lifetime in days,
+// returning its secret.
+// GetAPITokens returns the personal API tokens of the user.
+// RevokeAPIToken revokes the personal API token of the user.
+// GetAuditEvents returns a page of the audit log of the user filtered by the action, with its hash chain verified.
+// GetUsage returns the storage used by the user with the quota limits.
 type ItemsManager interface {
 	GetMetaData(string) []*MetaItem
 	SaveMetaItem(string, *MetaItem)
@@ -37,6 +96,46 @@ type ItemsManager interface {
 	DeleteItem(uuid.UUID, string, string) error
 	PostUserData(string, string) error
 	SyncMeta() error
+	GetFolders() []*Folder
+	FolderByPath(string) (string, error)
+	PostFolder(string, string) (*Folder, error)
+	DeleteFolder(string) error
+	ChangePassword(string, string) error
+	CreateRecoveryKit(string) (string, error)
+	NewAccount() bool
+	ShareItem(*MetaItem, string, bool) (*Share, error)
+	GetShares(string) ([]*Share, error)
+	RevokeShare(*MetaItem, string) error
+	GetSharedWithMe() ([]*Share, error)
+	GetSharedItem(string) (string, error)
+	UpdateSharedItem(string, []byte) error
+	GetOrgs() ([]*Organization, error)
+	CreateOrg(string) (*Organization, error)
+	DeleteOrg(string) error
+	AnswerInvitation(string, bool) error
+	GetOrgMembers(string) ([]*OrgMember, error)
+	InviteMember(string, string, string) (*OrgMember, error)
+	RemoveMember(string, string) error
+	ChangeRole(string, string, string) error
+	GetCollections(string) ([]*Collection, error)
+	CreateCollection(string, string) (*Collection, error)
+	DeleteCollection(string) error
+	GetCollectionItems(string) ([]*MetaItem, error)
+	GetCollectionItem(string, string) (string, error)
+	PostCollectionItem(string, []byte, *pb.MetaData) (*pb.PostItemDataResponse, error)
+	DeleteCollectionItem(string) error
+	GrantEmergencyAccess(string, uint32) (*EmergencyAccess, error)
+	GetEmergencyAccess() ([]*EmergencyAccess, []*EmergencyAccess, error)
+	RevokeEmergencyAccess(string) error
+	RequestEmergencyAccess(string) (*EmergencyAccess, error)
+	AnswerEmergencyAccess(string, bool) error
+	GetEmergencyItems(string) ([]*MetaItem, error)
+	GetEmergencyItem(string, string) (string, error)
+	CreateAPIToken(string, bool, []string, []string, int32) (*APIToken, string, error)
+	GetAPITokens() ([]*APIToken, error)
+	RevokeAPIToken(string) error
+	GetAuditEvents(string, string) (*AuditPage, error)
+	GetUsage() (*Usage, error)
 }
 
 // CredsData represents a structure containing login credentials with a Login and Password field.
@@ -101,20 +200,79 @@ func (m Model) View() string {
 // including its ID, title, description, and timestamps.
 type MetaItem struct {
 	ID          uuid.UUID
+	Category    string
 	Title       string
 	Description string
 	DataID      string
 	Created     string
 	Modified    string
+	Tags        []string
+	FolderID    string
+}
+
+// FilterValue returns the title, the description and the tags of the MetaItem used by the fuzzy search of the lists.
+func (m MetaItem) FilterValue() string {
+	return strings.Join(append([]string{m.Title, m.Description}, m.Tags...), " ")
+}
+
+// SortMetaItems returns a sorted copy of the items. Titles are sorted alphabetically ignoring case,
+// dates from the newest to the oldest with the items of invalid dates last. Unknown fields, including relevance,
+// keep the original order.
+func SortMetaItems(items []*MetaItem, by string) []*MetaItem {
+	res := make([]*MetaItem, len(items))
+	copy(res, items)
+
+	switch by {
+	case SortByTitle:
+		sort.SliceStable(res, func(i, j int) bool {
+			return strings.ToLower(res[i].Title) < strings.ToLower(res[j].Title)
+		})
+	case SortByCreated:
+		sort.SliceStable(res, func(i, j int) bool {
+			return newerThan(res[i].Created, res[j].Created)
+		})
+	case SortByModified:
+		sort.SliceStable(res, func(i, j int) bool {
+			return newerThan(res[i].Modified, res[j].Modified)
+		})
+	}
+
+	return res
 }
 
-// FilterValue returns a string representation used to filter or identify the MetaItem.
-// Currently, it returns an empty string.
-func (m MetaItem) FilterValue() string { return "" }
+// newerThan reports whether the RFC3339 date a is later than b. The dates are compared as instants,
+// so the offsets of the dates may differ, and a valid date is newer than an invalid one.
+func newerThan(a string, b string) bool {
+	timeA, errA := time.Parse(time.RFC3339, a)
+	timeB, errB := time.Parse(time.RFC3339, b)
+
+	switch {
+	case errA != nil:
+		return false
+	case errB != nil:
+		return true
+	default:
+		return timeA.After(timeB)
+	}
+}
+
+// NextSort returns the sort field following the current one among the given fields, wrapping around.
+func NextSort(current string, fields []string) string {
+	for i, v := range fields {
+		if v == current {
+			return fields[(i+1)%len(fields)]
+		}
+	}
+
+	return fields[0]
+}
 
 // MetaItemDelegate manages the rendering, spacing,
 // and height for a list of MetaItem instances in the UI.
-type MetaItemDelegate struct{}
+// ShowCategory prefixes every item with its category, which is used by the lists mixing several categories.
+type MetaItemDelegate struct {
+	ShowCategory bool
+}
 
 // Height returns the constant height of a MetaItemDelegate,
 // which is used to define the height of each list item.
@@ -137,6 +295,12 @@ func (d MetaItemDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	}
 
 	str := fmt.Sprintf("%d. Title: %s | Description: %s | Created: %s | Modified: %s", index+1, i.Title, i.Description, i.Created, i.Modified)
+	if d.ShowCategory {
+		str = fmt.Sprintf("%d. [%s] Title: %s | Description: %s | Created: %s | Modified: %s", index+1, i.Category, i.Title, i.Description, i.Created, i.Modified)
+	}
+	if len(i.Tags) > 0 {
+		str += " | Tags: " + FormatTags(i.Tags)
+	}
 
 	var fn func(...string) string
 	if index == m.Index() {
@@ -20,7 +20,62 @@ func TestMetaItem_FilterValue(t *testing.T) {
 		Modified:    "2024-01-02",
 	}
 
-	assert.Equal(t, "", item.FilterValue(), "FilterValue should return empty string")
+	assert.Equal(t, "Test Title Test Description", item.FilterValue(), "FilterValue should return title and description")
+
+	item.Tags = []string{"work", "mail"}
+	assert.Equal(t, "Test Title Test Description work mail", item.FilterValue(), "FilterValue should include tags")
+}
+
+func TestSortMetaItems(t *testing.T) {
+	items := []*MetaItem{
+		createMetaItem("beta", "", "2024-01-02T00:00:00Z", "2024-01-05T00:00:00Z"),
+		createMetaItem("Alpha", "", "2024-01-03T00:00:00Z", "2024-01-04T00:00:00Z"),
+		createMetaItem("gamma", "", "2024-01-01T00:00:00Z", "2024-01-06T00:00:00Z"),
+	}
+
+	titles := func(items []*MetaItem) []string {
+		var res []string
+		for _, v := range items {
+			res = append(res, v.Title)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name string
+		by   string
+		want []string
+	}{
+		{name: "by title", by: SortByTitle, want: []string{"Alpha", "beta", "gamma"}},
+		{name: "by created", by: SortByCreated, want: []string{"Alpha", "beta", "gamma"}},
+		{name: "by modified", by: SortByModified, want: []string{"gamma", "beta", "Alpha"}},
+		{name: "by relevance keeps order", by: SortByRelevance, want: []string{"beta", "Alpha", "gamma"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, titles(SortMetaItems(items, tt.by)))
+		})
+	}
+
+	assert.Equal(t, "beta", items[0].Title, "SortMetaItems should not modify the source slice")
+
+	// Даты с разными смещениями сравниваются как моменты времени, некорректные даты идут последними
+	mixed := []*MetaItem{
+		createMetaItem("invalid", "", "", "yesterday"),
+		createMetaItem("utc", "", "", "2024-03-31T01:30:00Z"),
+		createMetaItem("cest", "", "", "2024-03-31T03:15:00+02:00"),
+		createMetaItem("moscow", "", "", "2024-03-31T04:45:00+03:00"),
+	}
+	assert.Equal(t, []string{"moscow", "utc", "cest", "invalid"}, titles(SortMetaItems(mixed, SortByModified)))
+}
+
+func TestNextSort(t *testing.T) {
+	fields := []string{SortByTitle, SortByCreated, SortByModified}
+
+	assert.Equal(t, SortByCreated, NextSort(SortByTitle, fields))
+	assert.Equal(t, SortByTitle, NextSort(SortByModified, fields))
+	assert.Equal(t, SortByTitle, NextSort("", fields))
 }
 
 // Helper to create a MetaItem for rendering
@@ -117,6 +172,23 @@ func TestMetaItemDelegate_Render(t *testing.T) {
 
 	// It should not have the "[x]" indicator since it's not selected
 	assert.NotContains(t, output2, "[x]")
+
+	// Category prefix is rendered only when enabled
+	item.Category = "Creds"
+	buf.Reset()
+	delegate.Render(&buf, l, 0, item)
+	assert.NotContains(t, buf.String(), "[Creds]")
+
+	buf.Reset()
+	MetaItemDelegate{ShowCategory: true}.Render(&buf, l, 0, item)
+	assert.Contains(t, buf.String(), "[Creds]")
+
+	// Tags are rendered only when present
+	assert.NotContains(t, buf.String(), "Tags:")
+	item.Tags = []string{"work", "mail"}
+	buf.Reset()
+	delegate.Render(&buf, l, 0, item)
+	assert.Contains(t, buf.String(), "Tags: work, mail")
 }
 
 // Test the Render method with a non-MetaItem item (should do nothing)
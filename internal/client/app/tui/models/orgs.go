@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Roles of the members of an organization, as stored on the server.
+const (
+	OrgRoleOwner    = "owner"
+	OrgRoleAdmin    = "admin"
+	OrgRoleMember   = "member"
+	OrgRoleReadOnly = "read-only"
+)
+
+// InviteRoles lists the roles a user can be invited with or given, the owner role stays with the creator.
+var InviteRoles = []string{OrgRoleMember, OrgRoleReadOnly, OrgRoleAdmin}
+
+// Organization represents an organization the user is a member of or invited to, with the role of the user.
+type Organization struct {
+	ID       string
+	Name     string
+	Role     string
+	Accepted bool
+}
+
+// CanManage reports whether the user manages the members and the collections of the organization.
+func (o *Organization) CanManage() bool {
+	return o.Role == OrgRoleOwner || o.Role == OrgRoleAdmin
+}
+
+// Line renders the organization as a line of the list of organizations of the user.
+func (o *Organization) Line() string {
+	if !o.Accepted {
+		return fmt.Sprintf("%s (invited as %s)", o.Name, o.Role)
+	}
+
+	return fmt.Sprintf("%s (%s)", o.Name, o.Role)
+}
+
+// OrgMember represents a member of an organization or an invited user with the fingerprint of the public key
+// the collection keys are sealed to.
+type OrgMember struct {
+	UserID      string
+	Login       string
+	Role        string
+	Accepted    bool
+	Fingerprint string
+}
+
+// Line renders the member as a line of the list of members of the organization.
+func (m *OrgMember) Line() string {
+	line := fmt.Sprintf("%s (%s) key %s", m.Login, m.Role, m.Fingerprint)
+	if !m.Accepted {
+		line += " invited"
+	}
+
+	return line
+}
+
+// Collection represents a collection of items shared by the members of an organization.
+// KeyID is the version of the collection key, increased every time a member is removed.
+type Collection struct {
+	ID    string
+	OrgID string
+	Name  string
+	KeyID uint32
+}
+
+// NextRole returns the role following the current one among the roles a member can be given, wrapping around.
+func NextRole(current string) string {
+	return NextSort(current, InviteRoles)
+}
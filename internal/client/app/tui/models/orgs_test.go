@@ -0,0 +1,94 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganization_CanManage(t *testing.T) {
+	tests := []struct {
+		role string
+		want bool
+	}{
+		{role: OrgRoleOwner, want: true},
+		{role: OrgRoleAdmin, want: true},
+		{role: OrgRoleMember, want: false},
+		{role: OrgRoleReadOnly, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			org := &Organization{Role: tt.role}
+			assert.Equal(t, tt.want, org.CanManage())
+		})
+	}
+}
+
+func TestOrganization_Line(t *testing.T) {
+	tests := []struct {
+		name string
+		org  *Organization
+		want string
+	}{
+		{
+			name: "member",
+			org:  &Organization{Name: "Team", Role: OrgRoleAdmin, Accepted: true},
+			want: "Team (admin)",
+		},
+		{
+			name: "invited",
+			org:  &Organization{Name: "Team", Role: OrgRoleReadOnly},
+			want: "Team (invited as read-only)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.org.Line())
+		})
+	}
+}
+
+func TestOrgMember_Line(t *testing.T) {
+	tests := []struct {
+		name   string
+		member *OrgMember
+		want   string
+	}{
+		{
+			name:   "accepted",
+			member: &OrgMember{Login: "bob", Role: OrgRoleMember, Accepted: true, Fingerprint: "ab12"},
+			want:   "bob (member) key ab12",
+		},
+		{
+			name:   "invited",
+			member: &OrgMember{Login: "bob", Role: OrgRoleReadOnly, Fingerprint: "ab12"},
+			want:   "bob (read-only) key ab12 invited",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.member.Line())
+		})
+	}
+}
+
+func TestNextRole(t *testing.T) {
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{current: OrgRoleMember, want: OrgRoleReadOnly},
+		{current: OrgRoleReadOnly, want: OrgRoleAdmin},
+		{current: OrgRoleAdmin, want: OrgRoleMember},
+		{current: OrgRoleOwner, want: OrgRoleMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextRole(tt.current))
+		})
+	}
+}
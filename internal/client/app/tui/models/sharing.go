@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Permissions of the shared items, as stored on the server.
+const (
+	SharePermissionRead = "read"
+	SharePermissionEdit = "edit"
+)
+
+// Share represents an item shared between users. The owner sees the recipient login, its key fingerprint
+// and the permission, the recipient sees the owner login and the title and description sealed to it.
+type Share struct {
+	ID             string
+	DataID         string
+	Category       string
+	Title          string
+	Description    string
+	OwnerLogin     string
+	RecipientLogin string
+	Fingerprint    string
+	Permission     string
+	Modified       string
+}
+
+// Editable reports whether the recipient is allowed to change the content of the shared item.
+func (s *Share) Editable() bool {
+	return s.Permission == SharePermissionEdit
+}
+
+// SharePermission returns the permission of a share, read-only unless editable.
+func SharePermission(editable bool) string {
+	if editable {
+		return SharePermissionEdit
+	}
+
+	return SharePermissionRead
+}
+
+// SharedWithLine renders the share as a line of the list of recipients of an item.
+func (s *Share) SharedWithLine() string {
+	return fmt.Sprintf("%s (%s) key %s", s.RecipientLogin, s.Permission, s.Fingerprint)
+}
+
+// SharedByLine renders the share as a line of the list of items shared with the user.
+func (s *Share) SharedByLine() string {
+	return fmt.Sprintf("[%s] %s | %s | from %s (%s)", s.Category, s.Title, s.Description, s.OwnerLogin, s.Permission)
+}
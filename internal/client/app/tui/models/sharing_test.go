@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharePermission(t *testing.T) {
+	assert.Equal(t, SharePermissionEdit, SharePermission(true))
+	assert.Equal(t, SharePermissionRead, SharePermission(false))
+
+	assert.True(t, (&Share{Permission: SharePermission(true)}).Editable())
+	assert.False(t, (&Share{Permission: SharePermission(false)}).Editable())
+}
+
+func TestShare_Lines(t *testing.T) {
+	share := &Share{
+		Category:       CredsCategory,
+		Title:          "Mail",
+		Description:    "work",
+		OwnerLogin:     "alice",
+		RecipientLogin: "bob",
+		Fingerprint:    "ab12:cd34",
+		Permission:     SharePermissionRead,
+	}
+
+	assert.Equal(t, "bob (read) key ab12:cd34", share.SharedWithLine())
+	assert.Equal(t, "[Creds] Mail | work | from alice (read)", share.SharedByLine())
+}
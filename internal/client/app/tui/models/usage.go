@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// usageBarWidth is the number of the cells of the usage bar.
+const usageBarWidth = 20
+
+// Usage represents the storage used by the user with the quota of the user. Zero limits are not applied.
+type Usage struct {
+	Items       int64
+	Bytes       int64
+	MaxItems    int64
+	MaxBytes    int64
+	MaxItemSize int64
+}
+
+// Bar renders the usage as a bar filled by the share of the most used limit, with the used bytes and items.
+// Without the limits only the used bytes and items are rendered.
+func (u *Usage) Bar() string {
+	res := FormatBytes(u.Bytes)
+	if u.MaxBytes > 0 {
+		res += " of " + FormatBytes(u.MaxBytes)
+	}
+	if u.MaxItems > 0 {
+		res += fmt.Sprintf(", %d of %d items", u.Items, u.MaxItems)
+	} else {
+		res += fmt.Sprintf(", %d items", u.Items)
+	}
+
+	if u.MaxBytes <= 0 && u.MaxItems <= 0 {
+		return "Storage: " + res
+	}
+
+	share := max(usageShare(u.Bytes, u.MaxBytes), usageShare(u.Items, u.MaxItems))
+	filled := min(int(share*usageBarWidth+0.5), usageBarWidth)
+
+	return fmt.Sprintf("Storage: [%s%s] %d%% %s", strings.Repeat("#", filled), strings.Repeat("-", usageBarWidth-filled),
+		int(share*100), res)
+}
+
+// usageShare returns the used share of the limit, zero without the limit.
+func usageShare(used int64, limit int64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+
+	return float64(used) / float64(limit)
+}
+
+// FormatBytes renders the size in bytes with the binary unit suited to it, e.g. "1.5 MiB".
+func FormatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
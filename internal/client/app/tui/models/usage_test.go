@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage_Bar(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage *Usage
+		want  string
+	}{
+		{
+			name:  "unlimited",
+			usage: &Usage{Items: 3, Bytes: 1536},
+			want:  "Storage: 1.5 KiB, 3 items",
+		},
+		{
+			name:  "bytes limit",
+			usage: &Usage{Items: 3, Bytes: 25 << 20, MaxBytes: 100 << 20},
+			want:  "Storage: [#####---------------] 25% 25.0 MiB of 100.0 MiB, 3 items",
+		},
+		{
+			name:  "items limit is the most used",
+			usage: &Usage{Items: 9, Bytes: 10, MaxBytes: 1000, MaxItems: 10},
+			want:  "Storage: [##################--] 90% 10 B of 1000 B, 9 of 10 items",
+		},
+		{
+			name:  "over quota",
+			usage: &Usage{Items: 1, Bytes: 2048, MaxBytes: 1024},
+			want:  "Storage: [####################] 200% 2.0 KiB of 1.0 KiB, 1 items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.usage.Bar())
+		})
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{size: 0, want: "0 B"},
+		{size: 1023, want: "1023 B"},
+		{size: 1024, want: "1.0 KiB"},
+		{size: 30 << 20, want: "30.0 MiB"},
+		{size: 3 << 30, want: "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatBytes(tt.size))
+		})
+	}
+}
@@ -0,0 +1,555 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	grpcLib "google.golang.org/grpc"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// collectionKey is the opened key of a collection with its version.
+type collectionKey struct {
+	keyID uint32
+	key   []byte
+}
+
+// GetOrgs returns the organizations the user is a member of or invited to.
+func (im *ItemsManager) GetOrgs() ([]*models.Organization, error) {
+	resp, err := im.grpcClient.Handlers.OrgHandler.GetOrgs(context.Background(), &pb.GetOrgsRequest{
+		UserId: im.userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	orgs := make([]*models.Organization, 0, len(resp.GetOrganizations()))
+	for _, v := range resp.GetOrganizations() {
+		orgs = append(orgs, orgFromProto(v))
+	}
+
+	return orgs, nil
+}
+
+// CreateOrg creates an organization owned by the user.
+func (im *ItemsManager) CreateOrg(name string) (*models.Organization, error) {
+	resp, err := im.grpcClient.Handlers.OrgHandler.CreateOrg(context.Background(), &pb.CreateOrgRequest{
+		UserId: im.userID,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return orgFromProto(resp.GetOrganization()), nil
+}
+
+// DeleteOrg removes the organization with all its collections.
+func (im *ItemsManager) DeleteOrg(orgID string) error {
+	if _, err := im.grpcClient.Handlers.OrgHandler.DeleteOrg(context.Background(), &pb.DeleteOrgRequest{
+		UserId: im.userID,
+		OrgId:  orgID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return nil
+}
+
+// AnswerInvitation accepts or declines the invitation of the user into the organization.
+func (im *ItemsManager) AnswerInvitation(orgID string, accept bool) error {
+	if _, err := im.grpcClient.Handlers.OrgHandler.AnswerInvitation(context.Background(), &pb.AnswerInvitationRequest{
+		UserId: im.userID,
+		OrgId:  orgID,
+		Accept: accept,
+	}); err != nil {
+		return fmt.Errorf("failed to answer invitation: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrgMembers returns the members and the invited users of the organization with the fingerprints of their keys.
+func (im *ItemsManager) GetOrgMembers(orgID string) ([]*models.OrgMember, error) {
+	members, err := im.orgMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*models.OrgMember, 0, len(members))
+	for _, v := range members {
+		res = append(res, &models.OrgMember{
+			UserID:      v.GetUserId(),
+			Login:       v.GetLogin(),
+			Role:        v.GetRole(),
+			Accepted:    v.GetAccepted(),
+			Fingerprint: vault.KeyFingerprint(v.GetPublicKey()),
+		})
+	}
+
+	return res, nil
+}
+
+// InviteMember invites the user of the login into the organization with the role. The current keys of all
+// collections of the organization are sealed to the public sharing key of the invited user along with the invitation.
+func (im *ItemsManager) InviteMember(orgID string, login string, role string) (*models.OrgMember, error) {
+	recipient, err := im.grpcClient.Handlers.SharingHandler.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{
+		Login: login,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of %s: %w", login, err)
+	}
+
+	collections, err := im.GetCollections(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*pb.CollectionKey, 0, len(collections))
+	for _, v := range collections {
+		key, err := im.collectionKey(v.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		wrapped, err := vault.SealCollectionKey(recipient.GetPublicKey(), v.ID, key.keyID, key.key)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, &pb.CollectionKey{
+			CollectionId: v.ID,
+			UserId:       recipient.GetUserId(),
+			KeyId:        key.keyID,
+			WrappedKey:   wrapped,
+		})
+	}
+
+	resp, err := im.grpcClient.Handlers.OrgHandler.InviteMember(context.Background(), &pb.InviteMemberRequest{
+		UserId: im.userID,
+		OrgId:  orgID,
+		Login:  login,
+		Role:   role,
+		Keys:   keys,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to invite %s: %w", login, err)
+	}
+
+	member := resp.GetMember()
+
+	return &models.OrgMember{
+		UserID:      member.GetUserId(),
+		Login:       member.GetLogin(),
+		Role:        member.GetRole(),
+		Accepted:    member.GetAccepted(),
+		Fingerprint: vault.KeyFingerprint(member.GetPublicKey()),
+	}, nil
+}
+
+// RemoveMember removes the member from the organization. The removed member may have kept the collection keys,
+// so every collection of the organization is re-encrypted under a new key shared with the remaining members.
+func (im *ItemsManager) RemoveMember(orgID string, memberID string) error {
+	if _, err := im.grpcClient.Handlers.OrgHandler.RemoveMember(context.Background(), &pb.RemoveMemberRequest{
+		UserId:   im.userID,
+		OrgId:    orgID,
+		MemberId: memberID,
+	}); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	collections, err := im.GetCollections(orgID)
+	if err != nil {
+		return err
+	}
+
+	members, err := im.orgMembers(orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range collections {
+		if err = im.rekeyCollection(v, members); err != nil {
+			return fmt.Errorf("failed to re-key collection %s: %w", v.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ChangeRole changes the role of the member of the organization.
+func (im *ItemsManager) ChangeRole(orgID string, memberID string, role string) error {
+	if _, err := im.grpcClient.Handlers.OrgHandler.ChangeRole(context.Background(), &pb.ChangeRoleRequest{
+		UserId:   im.userID,
+		OrgId:    orgID,
+		MemberId: memberID,
+		Role:     role,
+	}); err != nil {
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollections returns the collections of the organization and opens their keys with the private sharing key.
+func (im *ItemsManager) GetCollections(orgID string) ([]*models.Collection, error) {
+	resp, err := im.grpcClient.Handlers.OrgHandler.GetCollections(context.Background(), &pb.GetCollectionsRequest{
+		UserId: im.userID,
+		OrgId:  orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collections: %w", err)
+	}
+
+	collections := make([]*models.Collection, 0, len(resp.GetCollections()))
+	for _, v := range resp.GetCollections() {
+		keyID, key, err := vault.OpenCollectionKey(im.sharingKey, v.GetId(), v.GetWrappedKey())
+		if err != nil {
+			return nil, fmt.Errorf("collection %s: %w", v.GetName(), err)
+		}
+
+		if keyID != v.GetKeyId() {
+			return nil, fmt.Errorf("collection %s: key version %d is not the current %d", v.GetName(), keyID, v.GetKeyId())
+		}
+
+		im.collectionKeys[v.GetId()] = &collectionKey{keyID: keyID, key: key}
+		collections = append(collections, &models.Collection{
+			ID:    v.GetId(),
+			OrgID: v.GetOrgId(),
+			Name:  v.GetName(),
+			KeyID: v.GetKeyId(),
+		})
+	}
+
+	return collections, nil
+}
+
+// CreateCollection creates a collection of the organization under a new key sealed to every member.
+func (im *ItemsManager) CreateCollection(orgID string, name string) (*models.Collection, error) {
+	members, err := im.orgMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := vault.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	collectionID := uuid.New().String()
+	keys, err := sealCollectionKeys(members, collectionID, vault.FirstKeyID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := im.grpcClient.Handlers.OrgHandler.CreateCollection(context.Background(), &pb.CreateCollectionRequest{
+		UserId: im.userID,
+		OrgId:  orgID,
+		Id:     collectionID,
+		Name:   name,
+		Keys:   keys,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	im.collectionKeys[collectionID] = &collectionKey{keyID: vault.FirstKeyID, key: key}
+
+	return &models.Collection{
+		ID:    resp.GetCollection().GetId(),
+		OrgID: resp.GetCollection().GetOrgId(),
+		Name:  resp.GetCollection().GetName(),
+		KeyID: resp.GetCollection().GetKeyId(),
+	}, nil
+}
+
+// DeleteCollection removes the collection with its items.
+func (im *ItemsManager) DeleteCollection(collectionID string) error {
+	if _, err := im.grpcClient.Handlers.OrgHandler.DeleteCollection(context.Background(), &pb.DeleteCollectionRequest{
+		UserId:       im.userID,
+		CollectionId: collectionID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete collection: %w", err)
+	}
+
+	delete(im.collectionKeys, collectionID)
+
+	return nil
+}
+
+// GetCollectionItems returns the metadata of the items of the collection opened with the collection key.
+func (im *ItemsManager) GetCollectionItems(collectionID string) ([]*models.MetaItem, error) {
+	key, err := im.collectionKey(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := im.grpcClient.Handlers.OrgHandler.GetCollectionItems(context.Background(), &pb.GetCollectionItemsRequest{
+		UserId:       im.userID,
+		CollectionId: collectionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection items: %w", err)
+	}
+
+	items := make([]*models.MetaItem, 0, len(resp.GetItems()))
+	for _, v := range resp.GetItems() {
+		meta, err := vault.OpenMeta(key.key, v.GetId(), v.GetDataType(), v.GetEncryptedMeta())
+		if err != nil {
+			return nil, fmt.Errorf("item %s: %w", v.GetId(), err)
+		}
+
+		itemID, err := uuid.Parse(v.GetId())
+		if err != nil {
+			return nil, fmt.Errorf("invalid item id %s: %w", v.GetId(), err)
+		}
+
+		items = append(items, &models.MetaItem{
+			ID:          itemID,
+			Category:    v.GetDataType(),
+			Title:       meta.Title,
+			Description: meta.Description,
+			Tags:        meta.Tags,
+			DataID:      v.GetId(),
+			Created:     v.GetCreated(),
+			Modified:    v.GetModified(),
+		})
+	}
+
+	return items, nil
+}
+
+// GetCollectionItem fetches the item of the collection and decrypts it with the collection key.
+func (im *ItemsManager) GetCollectionItem(collectionID string, itemID string) (string, error) {
+	key, err := im.collectionKey(collectionID)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := im.collectionItem(itemID)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := vault.OpenItem(key.key, itemID, item.GetData(), item.GetWrappedKey())
+	if err != nil {
+		return "", fmt.Errorf("failed decrypt data: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// PostCollectionItem encrypts the item and its metadata with the current collection key and saves it
+// in the collection. The ID of the metadata is the ID of the item.
+func (im *ItemsManager) PostCollectionItem(collectionID string, data []byte, metaData *pb.MetaData) (*pb.PostItemDataResponse, error) {
+	key, err := im.collectionKey(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := sealCollectionItem(key, collectionID, metaData.GetId(), metaData.GetDataType(), data, &vault.Meta{
+		Title:       metaData.GetTitle(),
+		Description: metaData.GetDescription(),
+		Tags:        metaData.GetTags(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := im.grpcClient.Handlers.OrgHandler.PostCollectionItem(context.Background(), &pb.PostCollectionItemRequest{
+		UserId: im.userID,
+		Item:   item,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to post collection item: %w", err)
+	}
+
+	return &pb.PostItemDataResponse{
+		DataId:   item.GetId(),
+		Created:  resp.GetCreated(),
+		Modified: resp.GetModified(),
+	}, nil
+}
+
+// DeleteCollectionItem removes the item of the collection.
+func (im *ItemsManager) DeleteCollectionItem(itemID string) error {
+	if _, err := im.grpcClient.Handlers.OrgHandler.DeleteCollectionItem(context.Background(), &pb.DeleteCollectionItemRequest{
+		UserId: im.userID,
+		ItemId: itemID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete collection item: %w", err)
+	}
+
+	return nil
+}
+
+// rekeyCollection re-encrypts all items of the collection under a new collection key of the next version
+// and seals the new key to the members. The server replaces the key and the items at once.
+func (im *ItemsManager) rekeyCollection(collection *models.Collection, members []*pb.OrgMember) error {
+	oldKey, err := im.collectionKey(collection.ID)
+	if err != nil {
+		return err
+	}
+
+	key, err := vault.NewKey()
+	if err != nil {
+		return err
+	}
+
+	newKey := &collectionKey{keyID: oldKey.keyID + 1, key: key}
+	keys, err := sealCollectionKeys(members, collection.ID, newKey.keyID, newKey.key)
+	if err != nil {
+		return err
+	}
+
+	resp, err := im.grpcClient.Handlers.OrgHandler.GetCollectionItems(context.Background(), &pb.GetCollectionItemsRequest{
+		UserId:       im.userID,
+		CollectionId: collection.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get collection items: %w", err)
+	}
+
+	items := make([]*pb.CollectionItem, 0, len(resp.GetItems()))
+	for _, v := range resp.GetItems() {
+		item, err := im.collectionItem(v.GetId())
+		if err != nil {
+			return err
+		}
+
+		data, err := vault.OpenItem(oldKey.key, item.GetId(), item.GetData(), item.GetWrappedKey())
+		if err != nil {
+			return fmt.Errorf("item %s: %w", item.GetId(), err)
+		}
+
+		meta, err := vault.OpenMeta(oldKey.key, item.GetId(), item.GetDataType(), item.GetEncryptedMeta())
+		if err != nil {
+			return fmt.Errorf("item %s: %w", item.GetId(), err)
+		}
+
+		rekeyed, err := sealCollectionItem(newKey, collection.ID, item.GetId(), item.GetDataType(), data, meta)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, rekeyed)
+	}
+
+	if _, err = im.grpcClient.Handlers.OrgHandler.RekeyCollection(context.Background(), &pb.RekeyCollectionRequest{
+		UserId:       im.userID,
+		CollectionId: collection.ID,
+		KeyId:        newKey.keyID,
+		Keys:         keys,
+		Items:        items,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	); err != nil {
+		return fmt.Errorf("failed to rekey collection: %w", err)
+	}
+
+	im.collectionKeys[collection.ID] = newKey
+
+	return nil
+}
+
+// orgMembers returns the members of the organization with their public sharing keys.
+func (im *ItemsManager) orgMembers(orgID string) ([]*pb.OrgMember, error) {
+	resp, err := im.grpcClient.Handlers.OrgHandler.GetOrgMembers(context.Background(), &pb.GetOrgMembersRequest{
+		UserId: im.userID,
+		OrgId:  orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	return resp.GetMembers(), nil
+}
+
+// collectionKey returns the opened key of the collection loaded by GetCollections.
+func (im *ItemsManager) collectionKey(collectionID string) (*collectionKey, error) {
+	key, ok := im.collectionKeys[collectionID]
+	if !ok {
+		return nil, fmt.Errorf("key of collection %s is not loaded", collectionID)
+	}
+
+	return key, nil
+}
+
+// collectionItem fetches the encrypted item of a collection.
+func (im *ItemsManager) collectionItem(itemID string) (*pb.CollectionItem, error) {
+	resp, err := im.grpcClient.Handlers.OrgHandler.GetCollectionItem(context.Background(), &pb.GetCollectionItemRequest{
+		UserId: im.userID,
+		ItemId: itemID,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not get collection item: %w", err)
+	}
+
+	return resp.GetItem(), nil
+}
+
+// sealCollectionKeys seals the collection key to the public sharing key of every member.
+func sealCollectionKeys(members []*pb.OrgMember, collectionID string, keyID uint32, key []byte) ([]*pb.CollectionKey, error) {
+	keys := make([]*pb.CollectionKey, 0, len(members))
+	for _, v := range members {
+		wrapped, err := vault.SealCollectionKey(v.GetPublicKey(), collectionID, keyID, key)
+		if err != nil {
+			return nil, fmt.Errorf("member %s: %w", v.GetLogin(), err)
+		}
+
+		keys = append(keys, &pb.CollectionKey{
+			CollectionId: collectionID,
+			UserId:       v.GetUserId(),
+			KeyId:        keyID,
+			WrappedKey:   wrapped,
+		})
+	}
+
+	return keys, nil
+}
+
+// sealCollectionItem encrypts the item data under a new data key wrapped with the collection key
+// and seals the metadata with the collection key.
+func sealCollectionItem(key *collectionKey, collectionID string, itemID string, dataType string, data []byte,
+	meta *vault.Meta) (*pb.CollectionItem, error) {
+	blob, wrappedKey, err := vault.SealItem(key.key, key.keyID, itemID, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+
+	encryptedMeta, err := vault.SealMeta(key.key, key.keyID, itemID, dataType, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CollectionItem{
+		Id:            itemID,
+		CollectionId:  collectionID,
+		Data:          blob,
+		WrappedKey:    wrappedKey,
+		KeyId:         key.keyID,
+		EncryptedMeta: encryptedMeta,
+		DataType:      dataType,
+	}, nil
+}
+
+// orgFromProto converts the organization received from the server.
+func orgFromProto(org *pb.Organization) *models.Organization {
+	return &models.Organization{
+		ID:       org.GetId(),
+		Name:     org.GetName(),
+		Role:     org.GetRole(),
+		Accepted: org.GetAccepted(),
+	}
+}
@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// CreateRecoveryKit generates a new recovery key, seals the loaded vault keys to it and stores the kit on the server,
+// replacing the previous one. The current password is confirmed by an SRP exchange. Returns the recovery key formatted
+// to be written down: it is never sent to the server and can't be shown again.
+func (im *ItemsManager) CreateRecoveryKit(password string) (string, error) {
+	if len(im.vaultKeys) == 0 {
+		return "", fmt.Errorf("vault key is not loaded")
+	}
+
+	recoveryKey, err := vault.NewRecoveryKey()
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, err := vault.RecoveryPublicKey(recoveryKey)
+	if err != nil {
+		return "", err
+	}
+
+	authToken, err := vault.RecoveryAuthToken(recoveryKey)
+	if err != nil {
+		return "", err
+	}
+
+	sessionID, proof, err := im.confirmPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	request := &pb.PostRecoveryKitRequest{
+		UserId:       im.userID,
+		PublicKey:    publicKey,
+		AuthToken:    authToken,
+		SrpSessionId: sessionID,
+		SrpProof:     proof,
+	}
+	for keyID, key := range im.vaultKeys {
+		sealed, err := vault.SealForRecovery(publicKey, keyID, key)
+		if err != nil {
+			return "", err
+		}
+
+		request.VaultKeys = append(request.VaultKeys, &pb.VaultKey{
+			KeyId:              keyID,
+			RecoveryWrappedKey: sealed,
+		})
+	}
+
+	if _, err = im.grpcClient.Handlers.AuthHandler.PostRecoveryKit(context.Background(), request); err != nil {
+		return "", fmt.Errorf("failed to save recovery kit: %w", err)
+	}
+
+	im.recoveryPublicKey = publicKey
+
+	return vault.FormatRecoveryKey(recoveryKey), nil
+}
+
+// RecoverAccount sets a new master password of the account with the recovery key instead of the forgotten one.
+// The vault keys are opened with the recovery key and re-wrapped with the new password on the client,
+// the server replaces the SRP record of the password and the keys at once and revokes the other sessions of the user.
+func (im *ItemsManager) RecoverAccount(login string, recoveryKey string, newPassword string) error {
+	key, err := vault.ParseRecoveryKey(recoveryKey)
+	if err != nil {
+		return err
+	}
+
+	authToken, err := vault.RecoveryAuthToken(key)
+	if err != nil {
+		return err
+	}
+
+	kit, err := im.grpcClient.Handlers.AuthHandler.GetRecoveryKit(context.Background(), &pb.GetRecoveryKitRequest{
+		Login:     login,
+		AuthToken: authToken,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get recovery kit: %w", err)
+	}
+
+	if len(kit.GetVaultKeys()) == 0 {
+		return fmt.Errorf("account has no vault keys to recover")
+	}
+
+	vaultKeys := make(map[uint32][]byte, len(kit.GetVaultKeys()))
+	record, err := vault.NewLoginRecord(login, newPassword)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.RecoverAccountRequest{
+		Login:     login,
+		AuthToken: authToken,
+		SrpRecord: loginRecordToProto(record),
+	}
+	for _, v := range kit.GetVaultKeys() {
+		if len(v.GetRecoveryWrappedKey()) == 0 {
+			return fmt.Errorf("vault key %d is not covered by the recovery kit", v.GetKeyId())
+		}
+
+		vaultKey, err := vault.OpenWithRecovery(key, v.GetKeyId(), v.GetRecoveryWrappedKey())
+		if err != nil {
+			return err
+		}
+
+		wrapped, err := vault.Wrap(vaultKey, newPassword)
+		if err != nil {
+			return err
+		}
+
+		rewrapped := wrappedToProto(wrapped)
+		rewrapped.KeyId = v.GetKeyId()
+		request.VaultKeys = append(request.VaultKeys, rewrapped)
+		vaultKeys[v.GetKeyId()] = vaultKey
+	}
+
+	res, err := im.grpcClient.Handlers.AuthHandler.RecoverAccount(context.Background(), request)
+	if err != nil {
+		return fmt.Errorf("failed to recover account: %w", err)
+	}
+
+	im.userID = res.GetUserId()
+	im.login = login
+	im.grpcClient.JWTToken = res.GetJwt()
+	im.vaultKeys = vaultKeys
+	for keyID := range vaultKeys {
+		im.vaultKeyID = max(im.vaultKeyID, keyID)
+	}
+
+	return nil
+}
+
+// NewAccount reports whether the account was registered by the last login, so the recovery kit can be offered.
+func (im *ItemsManager) NewAccount() bool {
+	return im.newAccount
+}
@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// RotateVaultKey replaces the vault key of the user with a new one wrapped with the master password.
+// The data keys of the items and the encrypted metadata are re-encrypted with the new key batch by batch, the item data
+// itself is left as is. The private sharing key is re-wrapped before the batches, the new key is sealed to the trusted
+// contacts after them.
+// The progress callback receives the number of records re-encrypted so far and the number left.
+// An interrupted rotation is resumed by the next call: the new key is kept on the server as pending and the records
+// encrypted with either key stay readable. The previous key is retired only when every record has been re-encrypted.
+func (im *ItemsManager) RotateVaultKey(password string, batchSize int32, progress func(done int, remaining int64)) error {
+	if len(im.vaultKeys) == 0 {
+		return fmt.Errorf("vault key is not loaded")
+	}
+
+	// Ротация продолжается, если при входе получен незавершенный новый ключ
+	if len(im.vaultKeys) == 1 {
+		if err := im.startKeyRotation(password); err != nil {
+			return err
+		}
+	}
+
+	newKeyID := im.vaultKeyID
+	newKey, err := im.vaultKey(newKeyID)
+	if err != nil {
+		return err
+	}
+
+	// Закрытый ключ обмена перешифровывается новым ключом хранилища до записей, сервер учитывает его среди оставшихся
+	if im.sharingKey != nil && im.sharingKeyID != newKeyID {
+		if err = im.postSharingKey(newKeyID); err != nil {
+			return err
+		}
+	}
+
+	var done int
+	remaining := int64(-1)
+	for {
+		batch, err := im.grpcClient.Handlers.VaultHandler.GetStaleKeys(context.Background(), &pb.GetStaleKeysRequest{
+			UserId: im.userID,
+			KeyId:  newKeyID,
+			Limit:  batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get records to re-encrypt: %w", err)
+		}
+
+		if len(batch.GetItems()) == 0 && len(batch.GetMetas()) == 0 {
+			break
+		}
+
+		request := &pb.RewrapKeysRequest{
+			UserId: im.userID,
+			KeyId:  newKeyID,
+		}
+
+		for _, v := range batch.GetItems() {
+			keyID, err := vault.WrappedKeyID(v.GetWrappedKey())
+			if err != nil {
+				return fmt.Errorf("item %s: %w", v.GetDataId(), err)
+			}
+
+			oldKey, err := im.vaultKey(keyID)
+			if err != nil {
+				return fmt.Errorf("item %s: %w", v.GetDataId(), err)
+			}
+
+			wrapped, err := vault.RewrapDataKey(oldKey, newKey, newKeyID, v.GetDataId(), v.GetWrappedKey())
+			if err != nil {
+				return fmt.Errorf("item %s: %w", v.GetDataId(), err)
+			}
+
+			request.Items = append(request.Items, &pb.ItemKey{
+				DataId:     v.GetDataId(),
+				WrappedKey: wrapped,
+			})
+		}
+
+		for _, v := range batch.GetMetas() {
+			keyID, err := vault.MetaKeyID(v.GetEncryptedMeta())
+			if err != nil {
+				return fmt.Errorf("meta %s: %w", v.GetId(), err)
+			}
+
+			oldKey, err := im.vaultKey(keyID)
+			if err != nil {
+				return fmt.Errorf("meta %s: %w", v.GetId(), err)
+			}
+
+			sealed, err := vault.ResealMeta(oldKey, newKey, newKeyID, v.GetId(), v.GetDataType(), v.GetEncryptedMeta())
+			if err != nil {
+				return fmt.Errorf("meta %s: %w", v.GetId(), err)
+			}
+
+			request.Metas = append(request.Metas, &pb.MetaData{
+				Id:            v.GetId(),
+				EncryptedMeta: sealed,
+				MetaKeyId:     newKeyID,
+			})
+		}
+
+		resp, err := im.grpcClient.Handlers.VaultHandler.RewrapKeys(context.Background(), request)
+		if err != nil {
+			return fmt.Errorf("failed to store re-encrypted records: %w", err)
+		}
+
+		// Защита от бесконечного цикла, если сервер не принимает записи
+		if remaining >= 0 && resp.GetRemaining() >= remaining {
+			return fmt.Errorf("key rotation made no progress, %d records left", resp.GetRemaining())
+		}
+		remaining = resp.GetRemaining()
+
+		done += len(request.GetItems()) + len(request.GetMetas())
+		progress(done, remaining)
+	}
+
+	// Доверенные контакты получают новый ключ до удаления прежнего
+	if err = im.resealEmergencyKeys(newKeyID); err != nil {
+		return err
+	}
+
+	if _, err = im.grpcClient.Handlers.VaultHandler.FinishKeyRotation(context.Background(), &pb.FinishKeyRotationRequest{
+		UserId: im.userID,
+		KeyId:  newKeyID,
+	}); err != nil {
+		return fmt.Errorf("failed to finish key rotation: %w", err)
+	}
+
+	im.vaultKeys = map[uint32][]byte{newKeyID: newKey}
+
+	return nil
+}
+
+// startKeyRotation generates a new vault key, wraps it with the master password and uploads it as pending.
+// If the user has a recovery kit, the new key is sealed to the recovery public key too, so the kit keeps working.
+// New records are encrypted with the new key from now on.
+func (im *ItemsManager) startKeyRotation(password string) error {
+	key, err := vault.NewKey()
+	if err != nil {
+		return err
+	}
+
+	wrapped, err := vault.Wrap(key, password)
+	if err != nil {
+		return err
+	}
+
+	vaultKey := wrappedToProto(wrapped)
+	if len(im.recoveryPublicKey) > 0 {
+		// Сервер выдает новому ключу следующий номер после текущего
+		vaultKey.RecoveryWrappedKey, err = vault.SealForRecovery(im.recoveryPublicKey, im.vaultKeyID+1, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := im.grpcClient.Handlers.VaultHandler.StartKeyRotation(context.Background(), &pb.StartKeyRotationRequest{
+		UserId:   im.userID,
+		VaultKey: vaultKey,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start key rotation: %w", err)
+	}
+
+	im.vaultKeys[resp.GetKeyId()] = key
+	im.vaultKeyID = resp.GetKeyId()
+
+	return nil
+}
@@ -10,11 +10,19 @@ import (
 )
 
 const (
-	TextCategory  = "Text"
-	CredsCategory = "Creds"
-	FileCategory  = "Files"
-	CardCategory  = "Cards"
-	ExitCategory  = "Exit" // New exit category
+	TextCategory      = models.TextCategory
+	CredsCategory     = models.CredsCategory
+	FileCategory      = models.FileCategory
+	CardCategory      = models.CardCategory
+	FoldersCategory   = "Folders"
+	SearchCategory    = "Search"
+	ImportCategory    = "Import"
+	SharedCategory    = "Shared with me"
+	TeamsCategory     = "Teams"
+	EmergencyCategory = "Emergency access"
+	ActivityCategory  = "Activity"
+	SettingsCategory  = "Settings"
+	ExitCategory      = "Exit" // New exit category
 )
 
 // ActionsMenu represents a UI menu for managing actions within a specific category of items.
@@ -0,0 +1,135 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+// activityScreen represents the audit log of the user page by page from the latest event.
+// action filters the events, pageToken names the shown page and is empty for the first one.
+type activityScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	page         *models.AuditPage
+	cursor       int
+	action       string
+	pageToken    string
+}
+
+// Update handles the navigation over the events, the action filter and the paging of the audit log.
+func (screen *activityScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyDown:
+		if len(screen.page.Events) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.page.Events)
+		}
+
+	case tea.KeyUp:
+		if len(screen.page.Events) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.page.Events)) % len(screen.page.Events)
+		}
+
+	case tea.KeyCtrlF:
+		screen.action = models.NextAuditAction(screen.action)
+		screen.reset()
+
+	case tea.KeyCtrlN:
+		if screen.page.NextPageToken != "" {
+			screen.pageToken = screen.page.NextPageToken
+			screen.page = nil
+			screen.cursor = 0
+		}
+
+	case tea.KeyCtrlR:
+		screen.reset()
+	}
+
+	return screen, nil
+}
+
+// View renders the events of the page with the result of the verification of their hash chain.
+func (screen *activityScreen) View() string {
+	action := screen.action
+	if action == "" {
+		action = "all"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render("Activity:\n\n"))
+	sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("Action: %s\n\n", action)))
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load activity: %s\n", err)))
+		sb.WriteString(utils.ActivityFooter())
+		return sb.String()
+	}
+
+	if len(screen.page.Events) == 0 {
+		sb.WriteString(utils.UnselectedStyle.Render("No events.\n"))
+	}
+	for i, v := range screen.page.Events {
+		if screen.cursor == i {
+			sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.Line())))
+		} else {
+			sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.Line())))
+		}
+	}
+
+	if screen.page.Broken != "" {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("\nAudit chain is broken: %s\n", screen.page.Broken)))
+	} else if len(screen.page.Events) > 0 {
+		sb.WriteString(utils.UnselectedStyle.Render("\nChain verified.\n"))
+	}
+	if screen.page.NextPageToken != "" {
+		sb.WriteString(utils.UnselectedStyle.Render("More events on the next page.\n"))
+	}
+	sb.WriteString(utils.ActivityFooter())
+
+	return sb.String()
+}
+
+// load fetches the page of the audit log unless it is already loaded.
+func (screen *activityScreen) load() error {
+	if screen.page != nil {
+		return nil
+	}
+
+	page, err := screen.itemsManager.GetAuditEvents(screen.action, screen.pageToken)
+	if err != nil {
+		return err
+	}
+
+	screen.page = page
+	if screen.cursor >= len(page.Events) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// reset returns the screen to the first page of the audit log, it is fetched again.
+func (screen *activityScreen) reset() {
+	screen.page = nil
+	screen.pageToken = ""
+	screen.cursor = 0
+}
@@ -0,0 +1,221 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+// apiTokenDataTypes lists the data types the new API token is limited to, the empty one doesn't limit it.
+var apiTokenDataTypes = []string{"", models.CredsCategory, models.TextCategory, models.CardCategory, models.FileCategory}
+
+// apiTokensScreen represents the personal API tokens of the user with the form of a new token.
+// name, readOnly, ttlDays, dataType and folder describe the token to be created, folder is an index into the folders
+// of the user shifted by one, zero standing for all folders. secret is shown once after the token is created.
+type apiTokensScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	tokens       []*models.APIToken
+	loaded       bool
+	cursor       int
+	name         string
+	readOnly     bool
+	ttlDays      int32
+	dataType     int
+	folder       int
+	secret       string
+	message      string
+}
+
+// Update handles the form of a new token, creating it on Enter, and revoking the token under the cursor.
+func (screen *apiTokensScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyDown:
+		if len(screen.tokens) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.tokens)
+		}
+
+	case tea.KeyUp:
+		if len(screen.tokens) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.tokens)) % len(screen.tokens)
+		}
+
+	case tea.KeyBackspace:
+		if len(screen.name) > 0 {
+			screen.name = screen.name[:len(screen.name)-1]
+		}
+
+	case tea.KeyCtrlO:
+		screen.readOnly = !screen.readOnly
+
+	case tea.KeyCtrlW:
+		screen.ttlDays = models.NextTTLDays(screen.ttl())
+
+	case tea.KeyCtrlT:
+		screen.dataType = (screen.dataType + 1) % len(apiTokenDataTypes)
+
+	case tea.KeyCtrlF:
+		screen.folder = (screen.folder + 1) % (len(screen.itemsManager.GetFolders()) + 1)
+
+	case tea.KeyCtrlR:
+		screen.loaded = false
+
+	case tea.KeyEnter:
+		if screen.name == "" {
+			return screen, nil
+		}
+
+		var folderIDs, dataTypes []string
+		if folder := screen.selectedFolder(); folder != nil {
+			folderIDs = []string{folder.ID}
+		}
+		if dataType := apiTokenDataTypes[screen.dataType]; dataType != "" {
+			dataTypes = []string{dataType}
+		}
+
+		token, secret, err := screen.itemsManager.CreateAPIToken(screen.name, screen.readOnly, folderIDs, dataTypes, screen.ttl())
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.secret = secret
+		screen.message = fmt.Sprintf("Token %s created, copy it now, it is not shown again.", token.Name)
+		screen.name = ""
+		screen.loaded = false
+
+	case tea.KeyCtrlD:
+		if len(screen.tokens) == 0 {
+			return screen, nil
+		}
+
+		token := screen.tokens[screen.cursor]
+		if err := screen.itemsManager.RevokeAPIToken(token.ID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.secret = ""
+		screen.message = fmt.Sprintf("Token %s revoked.", token.Name)
+		screen.loaded = false
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.name += input
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the form of a new token, the secret of the created one and the tokens of the user.
+func (screen *apiTokensScreen) View() string {
+	access := "read-write"
+	if screen.readOnly {
+		access = "read-only"
+	}
+
+	dataType := apiTokenDataTypes[screen.dataType]
+	if dataType == "" {
+		dataType = "all"
+	}
+
+	folder := "all"
+	if selected := screen.selectedFolder(); selected != nil {
+		folder = selected.Name
+	}
+
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render("API tokens:\n\n"))
+	sb.WriteString(fmt.Sprintf("%s %s\n", utils.CursorStyle.Render("Token name:"), utils.CursorStyle.Render(screen.name)))
+	sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("Access: %s | Expires in: %d days | Data type: %s | Folder: %s\n\n",
+		access, screen.ttl(), dataType, folder)))
+
+	if screen.secret != "" {
+		sb.WriteString(utils.SelectedStyle.Render(screen.secret + "\n\n"))
+	}
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load API tokens: %s\n", err)))
+	} else {
+		if len(screen.tokens) == 0 {
+			sb.WriteString(utils.UnselectedStyle.Render("No API tokens.\n"))
+		}
+		for i, v := range screen.tokens {
+			if screen.cursor == i {
+				sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.Line())))
+			} else {
+				sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.Line())))
+			}
+		}
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.APITokensFooter())
+
+	return sb.String()
+}
+
+// load fetches the API tokens of the user unless they are already loaded.
+func (screen *apiTokensScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	tokens, err := screen.itemsManager.GetAPITokens()
+	if err != nil {
+		return err
+	}
+
+	screen.tokens = tokens
+	screen.loaded = true
+	if screen.cursor >= len(tokens) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// ttl returns the lifetime offered for the new token, the first of the offered lifetimes by default.
+func (screen *apiTokensScreen) ttl() int32 {
+	if screen.ttlDays == 0 {
+		return models.APITokenTTLDays[0]
+	}
+
+	return screen.ttlDays
+}
+
+// selectedFolder returns the folder the new token is limited to, or nil for all folders.
+func (screen *apiTokensScreen) selectedFolder() *models.Folder {
+	folders := screen.itemsManager.GetFolders()
+	if screen.folder == 0 || screen.folder > len(folders) {
+		return nil
+	}
+
+	return folders[screen.folder-1]
+}
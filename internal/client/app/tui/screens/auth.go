@@ -65,6 +65,16 @@ func (s *AuthScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
 				}, nil
 			}
 
+			// Новому пользователю предлагается создать набор восстановления
+			if s.itemsManager.NewAccount() {
+				return &recoveryKitScreen{
+					itemsManager: s.itemsManager,
+					next:         s.next,
+					login:        s.username,
+					password:     s.password,
+				}, nil
+			}
+
 			return s.next, nil
 		case tea.KeyCtrlQ:
 			return s, tea.Quit
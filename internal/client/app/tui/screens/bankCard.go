@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	cardFields = 5
+	cardFields = 7
 )
 
 // viewBankCardDataScreen represents a screen for viewing detailed bank card information.
@@ -121,6 +121,8 @@ func (screen *addBankCardItemScreen) View() string {
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
 	}
 	styles[screen.cursor] = utils.CursorStyle
 
@@ -130,11 +132,14 @@ func (screen *addBankCardItemScreen) View() string {
 	addLine("Card Num:", screen.newItemData.CardNum, styles[2])
 	addLine("Expiry:", screen.newItemData.Expiry, styles[3])
 	addLine("CVV:", screen.newItemData.CVV, styles[4])
+	addLine("Tags:", screen.newTags, styles[5])
+	addLine("Folder:", screen.newFolder, styles[6])
 
 	// Combine the lines with newlines
 	result := strings.Join(lines, "\n")
 
 	result += utils.AddItemsFooter()
+	result += utils.AddItemTagsFooter()
 
 	return result
 }
@@ -145,7 +150,7 @@ func (screen *addBankCardItemScreen) handleInput(input string) {
 		return
 	}
 
-	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.CardNum, screen.newItemData.Expiry, screen.newItemData.CVV}
+	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.CardNum, screen.newItemData.Expiry, screen.newItemData.CVV, screen.newTags, screen.newFolder}
 
 	// Backspace logic
 	if input == "backspace" {
@@ -165,4 +170,6 @@ func (screen *addBankCardItemScreen) handleInput(input string) {
 	screen.newItemData.CardNum = fields[2]
 	screen.newItemData.Expiry = fields[3]
 	screen.newItemData.CVV = fields[4]
+	screen.newTags = fields[5]
+	screen.newFolder = fields[6]
 }
@@ -16,7 +16,7 @@ import (
 )
 
 const (
-	binaryFields = 3
+	binaryFields = 5
 	mB           = 1048576
 	contentLimit = 30
 )
@@ -166,17 +166,22 @@ func (screen *addBinaryItemScreen) View() string {
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
 	}
 	styles[screen.cursor] = utils.CursorStyle
 	// Build each line
 	addLine("Title:", screen.newTitle, styles[0])
 	addLine("Description:", screen.newDesc, styles[1])
 	addLine("File Path:", screen.newItemData.FilePath, styles[2])
+	addLine("Tags:", screen.newTags, styles[3])
+	addLine("Folder:", screen.newFolder, styles[4])
 
 	// Combine the lines with newlines
 	result := strings.Join(lines, "\n")
 
 	result += utils.AddItemsFooter()
+	result += utils.AddItemTagsFooter()
 
 	return result
 }
@@ -187,7 +192,7 @@ func (screen *addBinaryItemScreen) handleInput(input string) {
 		return
 	}
 
-	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.FilePath}
+	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.FilePath, screen.newTags, screen.newFolder}
 
 	// Backspace logic
 	if input == "backspace" {
@@ -205,6 +210,8 @@ func (screen *addBinaryItemScreen) handleInput(input string) {
 	screen.newTitle = fields[0]
 	screen.newDesc = fields[1]
 	screen.newItemData.FilePath = fields[2]
+	screen.newTags = fields[3]
+	screen.newFolder = fields[4]
 
 	if screen.cursor == 2 && screen.newItemData.FilePath != "" {
 		if screen.newItemData.FilePath[0] == '[' && screen.newItemData.FilePath[len(screen.newItemData.FilePath)-1] == ']' {
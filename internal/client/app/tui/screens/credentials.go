@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	credsFields = 4
+	credsFields = 6
 )
 
 // viewCredsDataScreen is a screen type for displaying credential data within a terminal-based UI application.
@@ -121,6 +121,8 @@ func (screen *addCredsItemScreen) View() string {
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
 	}
 	styles[screen.cursor] = utils.CursorStyle
 
@@ -129,11 +131,14 @@ func (screen *addCredsItemScreen) View() string {
 	addLine("Description:", screen.newDesc, styles[1])
 	addLine("Login:", screen.newItemData.Login, styles[2])
 	addLine("Password:", screen.newItemData.Password, styles[3])
+	addLine("Tags:", screen.newTags, styles[4])
+	addLine("Folder:", screen.newFolder, styles[5])
 
 	// Combine the lines with newlines
 	result := strings.Join(lines, "\n")
 
 	result += utils.AddItemsFooter()
+	result += utils.AddItemTagsFooter()
 
 	return result
 }
@@ -144,7 +149,7 @@ func (screen *addCredsItemScreen) handleInput(input string) {
 		return
 	}
 
-	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.Login, screen.newItemData.Password}
+	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.Login, screen.newItemData.Password, screen.newTags, screen.newFolder}
 
 	// Backspace logic
 	if input == "backspace" {
@@ -163,4 +168,6 @@ func (screen *addCredsItemScreen) handleInput(input string) {
 	screen.newDesc = fields[1]
 	screen.newItemData.Login = fields[2]
 	screen.newItemData.Password = fields[3]
+	screen.newTags = fields[4]
+	screen.newFolder = fields[5]
 }
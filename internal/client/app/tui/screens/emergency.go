@@ -0,0 +1,354 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+// emergencyScreen represents the trusted contacts of the user followed by the owners who trust the user.
+// The cursor moves over both lists, the actions depend on the list of the selected access.
+// login and waitHours describe the trusted contact to be added, typed on the screen.
+type emergencyScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	granted      []*models.EmergencyAccess
+	trustedBy    []*models.EmergencyAccess
+	loaded       bool
+	cursor       int
+	login        string
+	waitHours    uint32
+	message      string
+}
+
+// Update handles adding, revoking and answering the trusted contacts, requesting the access and opening released vaults.
+func (screen *emergencyScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	total := len(screen.granted) + len(screen.trustedBy)
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyDown:
+		if total > 0 {
+			screen.cursor = (screen.cursor + 1) % total
+		}
+
+	case tea.KeyUp:
+		if total > 0 {
+			screen.cursor = (screen.cursor - 1 + total) % total
+		}
+
+	case tea.KeyBackspace:
+		if len(screen.login) > 0 {
+			screen.login = screen.login[:len(screen.login)-1]
+		}
+
+	case tea.KeyCtrlW:
+		screen.waitHours = models.NextWaitHours(screen.wait())
+
+	case tea.KeyCtrlR:
+		screen.loaded = false
+
+	case tea.KeyEnter:
+		if screen.login != "" {
+			access, err := screen.itemsManager.GrantEmergencyAccess(screen.login, screen.wait())
+			if err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+
+			screen.message = fmt.Sprintf("%s is a trusted contact now, verify the key %s with them.",
+				access.ContactLogin, access.Fingerprint)
+			screen.login = ""
+			screen.loaded = false
+
+			return screen, nil
+		}
+
+		access := screen.selectedTrustedBy()
+		if access == nil {
+			return screen, nil
+		}
+
+		if !access.Released() {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        fmt.Errorf("vault of %s is not released, it is %s", access.OwnerLogin, access.Status),
+			}, nil
+		}
+
+		return &emergencyVaultScreen{itemsManager: screen.itemsManager, backScreen: screen, access: access}, nil
+
+	case tea.KeyCtrlA, tea.KeyCtrlX:
+		access := screen.selectedGranted()
+		if access == nil || access.Status != models.EmergencyStatusRequested {
+			return screen, nil
+		}
+
+		approve := keyMsg.Type == tea.KeyCtrlA
+		if err := screen.itemsManager.AnswerEmergencyAccess(access.ID, approve); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Request of %s rejected.", access.ContactLogin)
+		if approve {
+			screen.message = fmt.Sprintf("Request of %s approved, your vault is released.", access.ContactLogin)
+		}
+		screen.loaded = false
+
+	case tea.KeyCtrlD:
+		access := screen.selectedGranted()
+		if access == nil {
+			return screen, nil
+		}
+
+		if err := screen.itemsManager.RevokeEmergencyAccess(access.ID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("%s is not a trusted contact anymore.", access.ContactLogin)
+		screen.loaded = false
+
+	case tea.KeyCtrlE:
+		access := screen.selectedTrustedBy()
+		if access == nil {
+			return screen, nil
+		}
+
+		requested, err := screen.itemsManager.RequestEmergencyAccess(access.ID)
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Access to the vault of %s requested, it is released at %s unless rejected.",
+			access.OwnerLogin, requested.ReleaseAt)
+		screen.loaded = false
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.login += input
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the fields of a new trusted contact, the trusted contacts and the owners who trust the user.
+func (screen *emergencyScreen) View() string {
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render("Emergency access:\n\n"))
+	sb.WriteString(fmt.Sprintf("%s %s\n", utils.CursorStyle.Render("Trusted contact login:"), utils.CursorStyle.Render(screen.login)))
+	sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("Waiting period: %d hours\n\n", screen.wait())))
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load emergency access: %s\n", err)))
+	} else {
+		sb.WriteString(utils.TitleStyle.Render("Your trusted contacts:\n"))
+		if len(screen.granted) == 0 {
+			sb.WriteString(utils.UnselectedStyle.Render("No trusted contacts.\n"))
+		}
+		for i, v := range screen.granted {
+			screen.writeLine(&sb, i, v.GrantedLine())
+		}
+
+		sb.WriteString(utils.TitleStyle.Render("\nTrusted by:\n"))
+		if len(screen.trustedBy) == 0 {
+			sb.WriteString(utils.UnselectedStyle.Render("Nobody trusts you with their vault.\n"))
+		}
+		for i, v := range screen.trustedBy {
+			screen.writeLine(&sb, len(screen.granted)+i, v.TrustedByLine())
+		}
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.EmergencyFooter())
+
+	return sb.String()
+}
+
+// writeLine renders the line of the list at the position, marking it if it is under the cursor.
+func (screen *emergencyScreen) writeLine(sb *strings.Builder, position int, line string) {
+	if screen.cursor == position {
+		sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", line)))
+	} else {
+		sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", line)))
+	}
+}
+
+// load fetches the emergency access of the user unless it is already loaded.
+func (screen *emergencyScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	granted, trustedBy, err := screen.itemsManager.GetEmergencyAccess()
+	if err != nil {
+		return err
+	}
+
+	screen.granted = granted
+	screen.trustedBy = trustedBy
+	screen.loaded = true
+	if screen.cursor >= len(granted)+len(trustedBy) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// wait returns the waiting period offered for the new trusted contact, the first of the offered periods by default.
+func (screen *emergencyScreen) wait() uint32 {
+	if screen.waitHours == 0 {
+		return models.EmergencyWaitHours[0]
+	}
+
+	return screen.waitHours
+}
+
+// selectedGranted returns the trusted contact under the cursor, or nil if the cursor is on the other list.
+func (screen *emergencyScreen) selectedGranted() *models.EmergencyAccess {
+	if screen.cursor >= len(screen.granted) {
+		return nil
+	}
+
+	return screen.granted[screen.cursor]
+}
+
+// selectedTrustedBy returns the owner who trusts the user under the cursor, or nil if the cursor is on the other list.
+func (screen *emergencyScreen) selectedTrustedBy() *models.EmergencyAccess {
+	position := screen.cursor - len(screen.granted)
+	if position < 0 || position >= len(screen.trustedBy) {
+		return nil
+	}
+
+	return screen.trustedBy[position]
+}
+
+// emergencyVaultScreen represents the items of the vault of the owner released to the user, read-only.
+type emergencyVaultScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	access       *models.EmergencyAccess
+	items        []*models.MetaItem
+	loaded       bool
+	cursor       int
+}
+
+// Update handles navigation over the items of the released vault and viewing of the selected one.
+func (screen *emergencyVaultScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+q", "esc":
+		return screen.backScreen, nil
+
+	case "down":
+		if len(screen.items) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.items)
+		}
+
+	case "up":
+		if len(screen.items) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.items)) % len(screen.items)
+		}
+
+	case "enter":
+		if screen.cursor >= len(screen.items) {
+			return screen, nil
+		}
+
+		item := screen.items[screen.cursor]
+		itemData, err := screen.itemsManager.GetEmergencyItem(screen.access.ID, item.DataID)
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		return routeViewData(screen, itemData, item.Category), nil
+	}
+
+	return screen, nil
+}
+
+// View renders the items of the released vault with their categories.
+func (screen *emergencyVaultScreen) View() string {
+	if err := screen.load(); err != nil {
+		return utils.SelectedStyle.Render(fmt.Sprintf("Failed to open the vault: %s\n", err)) + utils.EmergencyVaultFooter()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render(fmt.Sprintf("Vault of %s:\n\n", screen.access.OwnerLogin)))
+	if len(screen.items) == 0 {
+		sb.WriteString(utils.UnselectedStyle.Render("The vault is empty.\n"))
+	}
+	for i, v := range screen.items {
+		line := fmt.Sprintf("[%s] %s | %s", v.Category, v.Title, v.Description)
+		if screen.cursor == i {
+			sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", line)))
+		} else {
+			sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", line)))
+		}
+	}
+	sb.WriteString(utils.EmergencyVaultFooter())
+
+	return sb.String()
+}
+
+// load opens the released vault and fetches its items unless they are already loaded.
+func (screen *emergencyVaultScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	items, err := screen.itemsManager.GetEmergencyItems(screen.access.ID)
+	if err != nil {
+		return err
+	}
+
+	screen.items = items
+	screen.loaded = true
+
+	return nil
+}
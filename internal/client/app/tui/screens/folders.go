@@ -0,0 +1,209 @@
+package screens
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+// folderEntry is a single line of the folder tree screen: either a subfolder or an item of the current folder.
+type folderEntry struct {
+	folder *models.Folder
+	item   *models.MetaItem
+}
+
+// foldersScreen represents a screen for browsing the folder tree and the items placed into the folders.
+// folderID holds the currently opened folder, an empty ID stands for the top level.
+// naming is set while the user types newName of a folder to be created inside the current one.
+type foldersScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	folderID     string
+	cursor       int
+	naming       bool
+	newName      string
+}
+
+// Update handles navigation over the folder tree, opening of items and creation and removal of folders.
+func (screen *foldersScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if screen.naming {
+		return screen.updateNaming(keyMsg), nil
+	}
+
+	entries := screen.entries()
+
+	switch keyMsg.String() {
+	case "ctrl+q":
+		return screen.backScreen, nil
+
+	case "down":
+		if len(entries) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(entries)
+		}
+
+	case "up":
+		if len(entries) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(entries)) % len(entries)
+		}
+
+	case "backspace":
+		screen.openFolder(screen.parentID())
+
+	case "n":
+		screen.naming = true
+		screen.newName = ""
+
+	case "d":
+		if screen.cursor < len(entries) && entries[screen.cursor].folder != nil {
+			if err := screen.itemsManager.DeleteFolder(entries[screen.cursor].folder.ID); err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+			screen.cursor = 0
+		}
+
+	case "enter":
+		if screen.cursor >= len(entries) {
+			return screen, nil
+		}
+
+		entry := entries[screen.cursor]
+		if entry.folder != nil {
+			screen.openFolder(entry.folder.ID)
+			return screen, nil
+		}
+
+		itemData, err := screen.itemsManager.GetItemData(entry.item.DataID)
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		return routeViewData(screen, itemData, entry.item.Category), nil
+	}
+
+	return screen, nil
+}
+
+// View renders the path of the current folder with its subfolders and items.
+func (screen *foldersScreen) View() string {
+	s := utils.TitleStyle.Render(fmt.Sprintf("Folder: /%s\n\n",
+		models.FolderPath(screen.itemsManager.GetFolders(), screen.folderID)))
+
+	if screen.naming {
+		s += utils.CursorStyle.Render(fmt.Sprintf("New folder name: %s\n", screen.newName))
+		s += utils.AddItemsFooter()
+		return s
+	}
+
+	entries := screen.entries()
+	if len(entries) == 0 {
+		s += utils.SelectedStyle.Render("Folder is empty.\n")
+	}
+
+	for i, v := range entries {
+		var line string
+		if v.folder != nil {
+			line = fmt.Sprintf("%s/", v.folder.Name)
+		} else {
+			line = fmt.Sprintf("[%s] %s", v.item.Category, v.item.Title)
+			if len(v.item.Tags) > 0 {
+				line += " | Tags: " + models.FormatTags(v.item.Tags)
+			}
+		}
+
+		if screen.cursor == i {
+			s += utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", line))
+		} else {
+			s += utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", line))
+		}
+	}
+	s += utils.FoldersFooter()
+
+	return s
+}
+
+// updateNaming handles typing of the new folder name, creating the folder on Enter.
+func (screen *foldersScreen) updateNaming(keyMsg tea.KeyMsg) models.Screen {
+	switch keyMsg.Type {
+	case tea.KeyEnter:
+		screen.naming = false
+		if screen.newName == "" {
+			return screen
+		}
+
+		if _, err := screen.itemsManager.PostFolder(screen.newName, screen.folderID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}
+		}
+
+	case tea.KeyCtrlQ, tea.KeyEsc:
+		screen.naming = false
+
+	case tea.KeyBackspace:
+		if len(screen.newName) > 0 {
+			screen.newName = screen.newName[:len(screen.newName)-1]
+		}
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.newName += input
+		}
+	}
+
+	return screen
+}
+
+// entries returns the subfolders of the current folder followed by its items sorted by title.
+func (screen *foldersScreen) entries() []folderEntry {
+	var res []folderEntry
+	for _, v := range models.SubFolders(screen.itemsManager.GetFolders(), screen.folderID) {
+		res = append(res, folderEntry{folder: v})
+	}
+
+	var items []*models.MetaItem
+	for _, category := range searchCategories {
+		for _, v := range screen.itemsManager.GetMetaData(category) {
+			if v.FolderID == screen.folderID {
+				items = append(items, v)
+			}
+		}
+	}
+
+	for _, v := range models.SortMetaItems(items, models.SortByTitle) {
+		res = append(res, folderEntry{item: v})
+	}
+
+	return res
+}
+
+// openFolder makes the folder current and resets the cursor.
+func (screen *foldersScreen) openFolder(id string) {
+	screen.folderID = id
+	screen.cursor = 0
+}
+
+// parentID returns the parent of the current folder, the top level stays the top level.
+func (screen *foldersScreen) parentID() string {
+	for _, v := range screen.itemsManager.GetFolders() {
+		if v.ID == screen.folderID {
+			return v.ParentID
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,159 @@
+package screens
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/importer"
+)
+
+const (
+	importFields = 3
+)
+
+// importScreen represents a screen for importing items exported from other password managers.
+// The first Enter parses the file and shows a dry-run summary, the second one uploads the non-duplicate items.
+// The password opens the encrypted files, such as the KeePass KDBX database.
+type importScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	format       string
+	filePath     string
+	password     string
+	cursor       int
+	summary      *importer.Summary
+}
+
+// Update handles user input of the import screen, running the dry run and the upload on Enter.
+func (screen *importScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyEnter:
+			if screen.summary == nil {
+				summary, err := screen.plan()
+				if err != nil {
+					return &ErrorScreen{
+						backScreen: screen,
+						err:        err,
+					}, nil
+				}
+				screen.summary = summary
+
+				return screen, nil
+			}
+
+			uploaded, err := importer.Upload(screen.summary, screen.itemsManager)
+			if err != nil {
+				return &ErrorScreen{
+					backScreen: screen.backScreen,
+					err:        fmt.Errorf("imported %d of %d items: %w", uploaded, len(screen.summary.Items), err),
+				}, nil
+			}
+
+			return screen.backScreen, nil
+
+		case tea.KeyCtrlQ:
+			return screen.backScreen, nil
+
+		case tea.KeyUp:
+			screen.cursor = (screen.cursor - 1 + importFields) % importFields
+
+		case tea.KeyDown:
+			screen.cursor = (screen.cursor + 1) % importFields
+
+		default:
+			if screen.summary == nil {
+				screen.handleInput(keyMsg.String())
+			}
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the import form or, after the dry run, the summary of the items to be uploaded.
+func (screen *importScreen) View() string {
+	if screen.summary != nil {
+		body := utils.TitleStyle.Render("Import Summary (dry run):\n\n")
+		body += utils.SelectedStyle.Render(screen.summary.String())
+		body += utils.ImportFooter()
+
+		return body
+	}
+
+	var lines []string
+	addLine := func(label string, value string, style lipgloss.Style) {
+		lines = append(lines, fmt.Sprintf("%s %s", style.Render(label), style.Render(value)))
+	}
+
+	styles := []lipgloss.Style{
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+	}
+	styles[screen.cursor] = utils.CursorStyle
+
+	addLine("Format:", screen.format, styles[0])
+	addLine("File Path:", screen.filePath, styles[1])
+	addLine("Password (KDBX):", strings.Repeat("•", utf8.RuneCountInString(screen.password)), styles[2])
+
+	result := utils.TitleStyle.Render(fmt.Sprintf("Import from: %s\n", strings.Join(importer.Formats(), ", ")))
+	result += strings.Join(lines, "\n")
+	result += utils.AddItemsFooter()
+
+	return result
+}
+
+// plan reads the export file and runs the dry run against the already stored items.
+func (screen *importScreen) plan() (*importer.Summary, error) {
+	file, err := os.Open(filepath.Clean(screen.filePath))
+	if err != nil {
+		return nil, fmt.Errorf("filepath: %s, error: %w", screen.filePath, err)
+	}
+	defer file.Close()
+
+	batch, err := importer.Parse(screen.format, file, screen.password)
+	if err != nil {
+		return nil, err
+	}
+
+	return importer.Plan(batch, screen.itemsManager), nil
+}
+
+// handleInput processes user keyboard input and updates the format, file path and password fields.
+func (screen *importScreen) handleInput(input string) {
+	if input == "\x00" {
+		return
+	}
+
+	fields := []string{screen.format, screen.filePath, screen.password}
+
+	// Backspace logic, the whole last character is removed as the password may be typed in any language
+	if input == "backspace" {
+		_, size := utf8.DecodeLastRuneInString(fields[screen.cursor])
+		fields[screen.cursor] = fields[screen.cursor][:len(fields[screen.cursor])-size]
+	} else {
+		// Ignore special keys, but keep pasted paths
+		if input != "up" && input != "down" && input != "esc" {
+			fields[screen.cursor] += input
+		}
+	}
+
+	screen.format = fields[0]
+	screen.filePath = fields[1]
+	screen.password = fields[2]
+
+	if screen.cursor == 1 && screen.filePath != "" {
+		if screen.filePath[0] == '[' && screen.filePath[len(screen.filePath)-1] == ']' {
+			screen.filePath = screen.filePath[1 : len(screen.filePath)-1]
+		}
+	}
+}
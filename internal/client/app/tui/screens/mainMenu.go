@@ -49,6 +49,30 @@ func (m MainMenu) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
 			if category == ExitCategory {
 				return m, tea.Quit // Exit the application
 			}
+			if category == FoldersCategory {
+				return &foldersScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == SearchCategory {
+				return &searchScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == ImportCategory {
+				return &importScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == SharedCategory {
+				return &sharedItemsScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == TeamsCategory {
+				return &orgsScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == EmergencyCategory {
+				return &emergencyScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == ActivityCategory {
+				return &activityScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
+			if category == SettingsCategory {
+				return &settingsScreen{itemsManager: m.itemsManager, backScreen: m}, nil
+			}
 			m.nextScreen = &ActionsMenu{
 				options:      []string{ViewOption, AddOption, BackOption},
 				category:     category,
@@ -72,6 +96,9 @@ func (m MainMenu) View() string {
 			s += utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", category)) // Unselected option with color
 		}
 	}
+	if usage, err := m.itemsManager.GetUsage(); err == nil {
+		s += utils.UnselectedStyle.Render("\n" + usage.Bar() + "\n")
+	}
 	s += utils.NavigateFooter()
 	return s
 }
@@ -11,26 +11,28 @@ import (
 	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
 )
 
+// listSortFields lists the fields the category item list can be sorted by.
+var listSortFields = []string{models.SortByTitle, models.SortByCreated, models.SortByModified}
+
 // viewMetaItemsScreen represents a screen for viewing metadata items of a particular category.
 // category specifies the metadata category to be viewed.
 // itemsManager provides the methods required to manage metadata items.
 // backScreen defines the previous screen for handling navigation.
 // list is the UI model for displaying and interacting with metadata items.
 // listTitle represents the title displayed at the top of the list.
+// sortBy holds the field the items are currently sorted by.
 type viewMetaItemsScreen struct {
 	category     string
 	itemsManager models.ItemsManager
 	backScreen   models.Screen
 	list         *list.Model
 	listTitle    string
+	sortBy       string
 }
 
 // View generates and returns the string representation of the viewMetaItemsScreen for rendering in the UI.
 func (screen *viewMetaItemsScreen) View() string {
-	if screen.list == nil {
-		listModel := list.New([]list.Item{}, models.MetaItemDelegate{}, 10, utils.ListHeight)
-		screen.list = &listModel
-	}
+	screen.initList()
 
 	metaData := screen.itemsManager.GetMetaData(screen.category)
 
@@ -38,15 +40,11 @@ func (screen *viewMetaItemsScreen) View() string {
 		return utils.SelectedStyle.Render("No items to display.\n\n") + utils.ItemDataFooter()
 	}
 
-	listItems := []list.Item{}
-	for _, v := range metaData {
-		listItems = append(listItems, v)
+	// Пока активен поиск, элементы списка обновляются только через фильтр
+	if screen.list.FilterState() == list.Unfiltered {
+		screen.refresh()
 	}
 
-	screen.list.SetItems(listItems)
-	screen.list.SetShowHelp(false)
-	screen.list.Title = screen.listTitle + " List"
-
 	s := screen.list.View()
 	s += utils.ListItemsFooter()
 
@@ -55,6 +53,16 @@ func (screen *viewMetaItemsScreen) View() string {
 
 // Update handles user input and updates the state of the viewMetaItemsScreen based on the received message.
 func (screen *viewMetaItemsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	screen.initList()
+
+	// Ввод поискового запроса полностью обрабатывается списком
+	if screen.list.SettingFilter() {
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+q" {
+			return screen.backScreen, nil
+		}
+		return screen, screen.updateList(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -64,6 +72,18 @@ func (screen *viewMetaItemsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd)
 		case "up":
 			screen.list.CursorUp()
 
+		case "/":
+			return screen, screen.updateList(msg)
+
+		case "esc":
+			if screen.list.IsFiltered() {
+				return screen, screen.updateList(msg)
+			}
+
+		case "s":
+			screen.sortBy = models.NextSort(screen.sortBy, listSortFields)
+			return screen, screen.refresh()
+
 		case "enter":
 			if screen.list.SelectedItem() == nil {
 				return screen.backScreen, nil
@@ -77,15 +97,24 @@ func (screen *viewMetaItemsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd)
 				}, nil
 			}
 
-			return screen.routeViewData(itemData, screen.category), nil
+			return routeViewData(screen, itemData, screen.category), nil
 
 		case "e":
 			if screen.list.SelectedItem() != nil {
 				return screen.routeEditData(screen.category), nil
 			}
 
+		case "h":
+			if selectedItem, ok := screen.list.SelectedItem().(*models.MetaItem); ok {
+				return &shareScreen{
+					itemsManager: screen.itemsManager,
+					backScreen:   screen,
+					item:         selectedItem,
+				}, nil
+			}
+
 		case "d":
-			if len(screen.list.Items()) > 0 {
+			if screen.list.SelectedItem() != nil {
 				if err := screen.itemsManager.DeleteItem(
 					screen.list.SelectedItem().(*models.MetaItem).ID,
 					screen.category,
@@ -97,19 +126,67 @@ func (screen *viewMetaItemsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd)
 					}, nil
 				}
 				screen.list.CursorUp()
+				return screen, screen.refresh()
 			}
 
 		case "ctrl+q":
 			return screen.backScreen, nil // Go back when ESC is pressed
 		}
+
+	default:
+		// Результаты нечеткого поиска приходят в список отдельным сообщением
+		return screen, screen.updateList(msg)
 	}
 	return screen, nil
 }
 
+// initList lazily creates the list model of the screen.
+func (screen *viewMetaItemsScreen) initList() {
+	if screen.list != nil {
+		return
+	}
+
+	listModel := list.New([]list.Item{}, models.MetaItemDelegate{}, 10, utils.ListHeight)
+	listModel.SetShowHelp(false)
+	listModel.SetFilteringEnabled(true)
+	listModel.DisableQuitKeybindings()
+	screen.list = &listModel
+
+	if screen.sortBy == "" {
+		screen.sortBy = models.SortByTitle
+	}
+	if screen.listTitle == "" {
+		screen.listTitle = screen.category
+	}
+}
+
+// refresh reloads the sorted items of the category into the list, returning the command re-applying an active filter.
+func (screen *viewMetaItemsScreen) refresh() tea.Cmd {
+	metaData := models.SortMetaItems(screen.itemsManager.GetMetaData(screen.category), screen.sortBy)
+
+	listItems := make([]list.Item, 0, len(metaData))
+	for _, v := range metaData {
+		listItems = append(listItems, v)
+	}
+
+	screen.list.Title = fmt.Sprintf("%s List (sorted by %s)", screen.listTitle, screen.sortBy)
+
+	return screen.list.SetItems(listItems)
+}
+
+// updateList passes the message to the list model and returns its command.
+func (screen *viewMetaItemsScreen) updateList(msg tea.Msg) tea.Cmd {
+	listModel, cmd := screen.list.Update(msg)
+	screen.list = &listModel
+
+	return cmd
+}
+
 // routeViewData maps the provided item data and category to the corresponding screen type for detailed view rendering.
 // Returns a specific data screen or error screen if the data cannot be unmarshalled or processed.
+// The screen is the one to return to from the item view.
 // TODO build common unmarshaler func
-func (screen *viewMetaItemsScreen) routeViewData(itemData string, category string) models.Screen {
+func routeViewData(screen models.Screen, itemData string, category string) models.Screen {
 	switch category {
 	case TextCategory:
 		var textData models.TextData
@@ -192,28 +269,36 @@ func (screen *viewMetaItemsScreen) routeEditData(category string) models.Screen
 		return &ErrorScreen{backScreen: screen, err: fmt.Errorf("item not found")}
 	}
 
-	createItemScreen := func() *itemScreen {
-		return &itemScreen{
-			itemsManager: screen.itemsManager,
-			backScreen:   screen,
-			category:     screen.category,
-			newTitle:     selectedItem.Title,
-			newDesc:      selectedItem.Description,
-			selectedItem: selectedItem,
-		}
+	editScreen := newEditScreen(category, &itemScreen{
+		itemsManager: screen.itemsManager,
+		backScreen:   screen,
+		category:     screen.category,
+		newTitle:     selectedItem.Title,
+		newDesc:      selectedItem.Description,
+		newTags:      models.FormatTags(selectedItem.Tags),
+		newFolder:    models.FolderPath(screen.itemsManager.GetFolders(), selectedItem.FolderID),
+		selectedItem: selectedItem,
+	})
+	if editScreen == nil {
+		return screen
 	}
 
+	return editScreen
+}
+
+// newEditScreen wraps the item screen into the editing screen of the category, returns nil for an unknown category.
+func newEditScreen(category string, is *itemScreen) models.Screen {
 	// Map category to corresponding screen
 	switch category {
 	case TextCategory:
-		return &addTextItemScreen{itemScreen: createItemScreen()}
+		return &addTextItemScreen{itemScreen: is}
 	case CardCategory:
-		return &addBankCardItemScreen{itemScreen: createItemScreen()}
+		return &addBankCardItemScreen{itemScreen: is}
 	case CredsCategory:
-		return &addCredsItemScreen{itemScreen: createItemScreen()}
+		return &addCredsItemScreen{itemScreen: is}
 	case FileCategory:
-		return &addBinaryItemScreen{itemScreen: createItemScreen()}
+		return &addBinaryItemScreen{itemScreen: is}
 	}
 
-	return screen
+	return nil
 }
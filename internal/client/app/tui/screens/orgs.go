@@ -0,0 +1,651 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// collectionCategories lists the item categories a team collection holds.
+var collectionCategories = []string{TextCategory, CredsCategory, FileCategory, CardCategory}
+
+// orgsScreen represents the list of the organizations of the user and the invitations into other organizations.
+// name is the name of the organization to be created, typed on the screen.
+type orgsScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	orgs         []*models.Organization
+	loaded       bool
+	cursor       int
+	name         string
+	message      string
+}
+
+// Update handles navigation over the organizations, creating, opening and deleting them and answering the invitations.
+func (screen *orgsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyDown:
+		if len(screen.orgs) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.orgs)
+		}
+
+	case tea.KeyUp:
+		if len(screen.orgs) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.orgs)) % len(screen.orgs)
+		}
+
+	case tea.KeyBackspace:
+		if len(screen.name) > 0 {
+			screen.name = screen.name[:len(screen.name)-1]
+		}
+
+	case tea.KeyEnter:
+		if screen.name != "" {
+			org, err := screen.itemsManager.CreateOrg(screen.name)
+			if err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+
+			screen.message = fmt.Sprintf("Organization %s created.", org.Name)
+			screen.name = ""
+			screen.loaded = false
+
+			return screen, nil
+		}
+
+		org := screen.selected()
+		if org == nil {
+			return screen, nil
+		}
+
+		if !org.Accepted {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        fmt.Errorf("accept the invitation into %s first", org.Name),
+			}, nil
+		}
+
+		return &orgScreen{itemsManager: screen.itemsManager, backScreen: screen, org: org}, nil
+
+	case tea.KeyCtrlA, tea.KeyCtrlX:
+		org := screen.selected()
+		if org == nil || org.Accepted {
+			return screen, nil
+		}
+
+		accept := keyMsg.Type == tea.KeyCtrlA
+		if err := screen.itemsManager.AnswerInvitation(org.ID, accept); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Invitation into %s declined.", org.Name)
+		if accept {
+			screen.message = fmt.Sprintf("Invitation into %s accepted.", org.Name)
+		}
+		screen.loaded = false
+
+	case tea.KeyCtrlD:
+		org := screen.selected()
+		if org == nil {
+			return screen, nil
+		}
+
+		if err := screen.itemsManager.DeleteOrg(org.ID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Organization %s deleted.", org.Name)
+		screen.loaded = false
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.name += input
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the name field of a new organization and the organizations of the user.
+func (screen *orgsScreen) View() string {
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render("Teams:\n\n"))
+	sb.WriteString(fmt.Sprintf("%s %s\n\n", utils.CursorStyle.Render("New organization:"), utils.CursorStyle.Render(screen.name)))
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load organizations: %s\n", err)))
+	} else if len(screen.orgs) == 0 {
+		sb.WriteString(utils.UnselectedStyle.Render("You are not a member of any organization.\n"))
+	} else {
+		for i, v := range screen.orgs {
+			if screen.cursor == i {
+				sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.Line())))
+			} else {
+				sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.Line())))
+			}
+		}
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.OrgsFooter())
+
+	return sb.String()
+}
+
+// load fetches the organizations unless they are already loaded.
+func (screen *orgsScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	orgs, err := screen.itemsManager.GetOrgs()
+	if err != nil {
+		return err
+	}
+
+	screen.orgs = orgs
+	screen.loaded = true
+	if screen.cursor >= len(orgs) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// selected returns the organization under the cursor, or nil if there are none.
+func (screen *orgsScreen) selected() *models.Organization {
+	if screen.cursor >= len(screen.orgs) {
+		return nil
+	}
+
+	return screen.orgs[screen.cursor]
+}
+
+// orgScreen represents the collections of an organization.
+// name is the name of the collection to be created, typed on the screen.
+type orgScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	org          *models.Organization
+	collections  []*models.Collection
+	loaded       bool
+	cursor       int
+	name         string
+	message      string
+}
+
+// Update handles navigation over the collections, creating, opening and deleting them and opening the members.
+func (screen *orgScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyDown:
+		if len(screen.collections) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.collections)
+		}
+
+	case tea.KeyUp:
+		if len(screen.collections) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.collections)) % len(screen.collections)
+		}
+
+	case tea.KeyBackspace:
+		if len(screen.name) > 0 {
+			screen.name = screen.name[:len(screen.name)-1]
+		}
+
+	case tea.KeyEnter:
+		if screen.name != "" {
+			collection, err := screen.itemsManager.CreateCollection(screen.org.ID, screen.name)
+			if err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+
+			screen.message = fmt.Sprintf("Collection %s created.", collection.Name)
+			screen.name = ""
+			screen.loaded = false
+
+			return screen, nil
+		}
+
+		if screen.cursor >= len(screen.collections) {
+			return screen, nil
+		}
+
+		collection := screen.collections[screen.cursor]
+		items, err := screen.itemsManager.GetCollectionItems(collection.ID)
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		return &collectionScreen{
+			itemsManager: &collectionItemsManager{
+				ItemsManager: screen.itemsManager,
+				collectionID: collection.ID,
+				items:        items,
+			},
+			backScreen: screen,
+			collection: collection,
+		}, nil
+
+	case tea.KeyCtrlD:
+		if screen.cursor >= len(screen.collections) {
+			return screen, nil
+		}
+
+		collection := screen.collections[screen.cursor]
+		if err := screen.itemsManager.DeleteCollection(collection.ID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Collection %s deleted.", collection.Name)
+		screen.loaded = false
+
+	case tea.KeyCtrlU:
+		// Коллекции перезагружаются после возврата, так как удаление участника меняет их ключи
+		screen.loaded = false
+
+		return &orgMembersScreen{itemsManager: screen.itemsManager, backScreen: screen, org: screen.org}, nil
+
+	case tea.KeyCtrlR:
+		screen.loaded = false
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.name += input
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the name field of a new collection and the collections of the organization.
+func (screen *orgScreen) View() string {
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render(fmt.Sprintf("%s collections:\n\n", screen.org.Name)))
+	sb.WriteString(fmt.Sprintf("%s %s\n\n", utils.CursorStyle.Render("New collection:"), utils.CursorStyle.Render(screen.name)))
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load collections: %s\n", err)))
+	} else if len(screen.collections) == 0 {
+		sb.WriteString(utils.UnselectedStyle.Render("The organization has no collections.\n"))
+	} else {
+		for i, v := range screen.collections {
+			if screen.cursor == i {
+				sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.Name)))
+			} else {
+				sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.Name)))
+			}
+		}
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.OrgFooter())
+
+	return sb.String()
+}
+
+// load fetches the collections of the organization unless they are already loaded.
+func (screen *orgScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	collections, err := screen.itemsManager.GetCollections(screen.org.ID)
+	if err != nil {
+		return err
+	}
+
+	screen.collections = collections
+	screen.loaded = true
+	if screen.cursor >= len(collections) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// orgMembersScreen represents the members of an organization and the invitation of new ones.
+// login and role are the login of the user to be invited and the role offered to the user.
+type orgMembersScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	org          *models.Organization
+	members      []*models.OrgMember
+	loaded       bool
+	cursor       int
+	login        string
+	role         string
+	message      string
+}
+
+// Update handles the input of the login, inviting the user on Enter, changing the role and removing the selected member.
+func (screen *orgMembersScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyCtrlE:
+		screen.role = models.NextRole(screen.inviteRole())
+
+	case tea.KeyDown:
+		if len(screen.members) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.members)
+		}
+
+	case tea.KeyUp:
+		if len(screen.members) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.members)) % len(screen.members)
+		}
+
+	case tea.KeyBackspace:
+		if len(screen.login) > 0 {
+			screen.login = screen.login[:len(screen.login)-1]
+		}
+
+	case tea.KeyEnter:
+		if screen.login == "" {
+			return screen, nil
+		}
+
+		member, err := screen.itemsManager.InviteMember(screen.org.ID, screen.login, screen.inviteRole())
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("%s invited as %s, key fingerprint %s.", member.Login, member.Role, member.Fingerprint)
+		screen.login = ""
+		screen.loaded = false
+
+	case tea.KeyCtrlT:
+		if screen.cursor >= len(screen.members) {
+			return screen, nil
+		}
+
+		member := screen.members[screen.cursor]
+		role := models.NextRole(member.Role)
+		if err := screen.itemsManager.ChangeRole(screen.org.ID, member.UserID, role); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("%s is %s now.", member.Login, role)
+		screen.loaded = false
+
+	case tea.KeyCtrlR:
+		if screen.cursor >= len(screen.members) {
+			return screen, nil
+		}
+
+		member := screen.members[screen.cursor]
+		if err := screen.itemsManager.RemoveMember(screen.org.ID, member.UserID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("%s removed, the collections are re-encrypted.", member.Login)
+		screen.loaded = false
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.login += input
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the login field, the role of the invitation and the members of the organization.
+func (screen *orgMembersScreen) View() string {
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render(fmt.Sprintf("%s members:\n\n", screen.org.Name)))
+	sb.WriteString(fmt.Sprintf("%s %s\n", utils.CursorStyle.Render("Invite login:"), utils.CursorStyle.Render(screen.login)))
+	sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("Role: %s\n\n", screen.inviteRole())))
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load members: %s\n", err)))
+	} else {
+		for i, v := range screen.members {
+			if screen.cursor == i {
+				sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.Line())))
+			} else {
+				sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.Line())))
+			}
+		}
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.OrgMembersFooter())
+
+	return sb.String()
+}
+
+// load fetches the members of the organization unless they are already loaded.
+func (screen *orgMembersScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	members, err := screen.itemsManager.GetOrgMembers(screen.org.ID)
+	if err != nil {
+		return err
+	}
+
+	screen.members = members
+	screen.loaded = true
+	if screen.cursor >= len(members) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// inviteRole returns the role offered to the invited user, a member by default.
+func (screen *orgMembersScreen) inviteRole() string {
+	if screen.role == "" {
+		return models.OrgRoleMember
+	}
+
+	return screen.role
+}
+
+// collectionScreen represents the item categories of a team collection, each opening the usual actions menu
+// over the items of the collection.
+type collectionScreen struct {
+	itemsManager *collectionItemsManager
+	backScreen   models.Screen
+	collection   *models.Collection
+	cursor       int
+}
+
+// Update handles navigation over the categories of the collection.
+func (screen *collectionScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+q", "esc":
+		return screen.backScreen, nil
+	case "down":
+		screen.cursor = (screen.cursor + 1) % len(collectionCategories)
+	case "up":
+		screen.cursor = (screen.cursor - 1 + len(collectionCategories)) % len(collectionCategories)
+	case "enter":
+		return &ActionsMenu{
+			options:      []string{ViewOption, AddOption, BackOption},
+			category:     collectionCategories[screen.cursor],
+			itemsManager: screen.itemsManager,
+			backScreen:   screen,
+		}, nil
+	}
+
+	return screen, nil
+}
+
+// View renders the categories of the collection with the number of items in each.
+func (screen *collectionScreen) View() string {
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render(fmt.Sprintf("Collection %s:\n\n", screen.collection.Name)))
+	for i, v := range collectionCategories {
+		line := fmt.Sprintf("%s (%d)", v, len(screen.itemsManager.GetMetaData(v)))
+		if screen.cursor == i {
+			sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", line)))
+		} else {
+			sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", line)))
+		}
+	}
+	sb.WriteString(utils.CollectionFooter())
+
+	return sb.String()
+}
+
+// collectionItemsManager lets the item screens work with the items of a team collection in place of the personal
+// vault: the items are encrypted with the collection key and stored in the collection. The collections have no
+// folders and their items are shared with the members of the organization, not with single users.
+type collectionItemsManager struct {
+	models.ItemsManager
+	collectionID string
+	items        []*models.MetaItem
+}
+
+// GetMetaData returns the items of the collection of the category.
+func (cm *collectionItemsManager) GetMetaData(category string) []*models.MetaItem {
+	var res []*models.MetaItem
+	for _, v := range cm.items {
+		if v.Category == category {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// SaveMetaItem adds the new item to the items of the collection.
+func (cm *collectionItemsManager) SaveMetaItem(_ string, newItem *models.MetaItem) {
+	cm.items = append(cm.items, newItem)
+}
+
+// PostItemData saves the item in the collection, the ID of the metadata is used as the ID of the item.
+func (cm *collectionItemsManager) PostItemData(data []byte, _ string, metaData *pb.MetaData) (*pb.PostItemDataResponse, error) {
+	return cm.PostCollectionItem(cm.collectionID, data, metaData)
+}
+
+// GetItemData fetches the data of the item of the collection.
+func (cm *collectionItemsManager) GetItemData(dataID string) (string, error) {
+	return cm.GetCollectionItem(cm.collectionID, dataID)
+}
+
+// DeleteItem removes the item from the collection.
+func (cm *collectionItemsManager) DeleteItem(id uuid.UUID, _ string, dataID string) error {
+	if err := cm.DeleteCollectionItem(dataID); err != nil {
+		return err
+	}
+
+	for i, v := range cm.items {
+		if v.ID == id {
+			cm.items = append(cm.items[:i], cm.items[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// GetFolders keeps the folders of the user out of the collection.
+func (cm *collectionItemsManager) GetFolders() []*models.Folder {
+	return nil
+}
+
+// FolderByPath keeps the items of the collection out of the folders of the user.
+func (cm *collectionItemsManager) FolderByPath(string) (string, error) {
+	return "", nil
+}
+
+// ShareItem refuses to share the items of a collection, they are shared with the members of the organization.
+func (cm *collectionItemsManager) ShareItem(*models.MetaItem, string, bool) (*models.Share, error) {
+	return nil, fmt.Errorf("items of a collection are shared with the members of the organization")
+}
+
+// GetShares refuses to list the shares of the items of a collection.
+func (cm *collectionItemsManager) GetShares(string) ([]*models.Share, error) {
+	return nil, fmt.Errorf("items of a collection are shared with the members of the organization")
+}
@@ -0,0 +1,71 @@
+package screens
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+// recoveryKitScreen offers a new user to create a recovery kit right after the registration.
+// password is the master password just entered, it is dropped once the kit is created or skipped.
+// sheet holds the rendered recovery kit, shown once.
+type recoveryKitScreen struct {
+	itemsManager models.ItemsManager
+	next         models.Screen
+	login        string
+	password     string
+	sheet        string
+}
+
+// Update creates the recovery kit on Enter and leaves the screen on CTRL+Q or on Enter after the kit is shown.
+func (screen *recoveryKitScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyCtrlQ:
+			screen.password = ""
+			return screen.next, nil
+
+		case tea.KeyEnter:
+			if screen.sheet != "" {
+				return screen.next, nil
+			}
+
+			recoveryKey, err := screen.itemsManager.CreateRecoveryKit(screen.password)
+			if err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+
+			screen.password = ""
+			screen.sheet = utils.RecoveryKitSheet(screen.login, recoveryKey, time.Now())
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the recovery kit offer or the created recovery kit sheet.
+func (screen *recoveryKitScreen) View() string {
+	var sb strings.Builder
+
+	if screen.sheet != "" {
+		sb.WriteString(screen.sheet)
+		sb.WriteString(utils.SelectedStyle.Render("\nWrite the key down or print this screen, it won't be shown again.\n"))
+		sb.WriteString(utils.ItemDataFooter())
+
+		return sb.String()
+	}
+
+	sb.WriteString(utils.TitleStyle.Render("Recovery kit\n\n"))
+	sb.WriteString("Without the master password the vault can't be decrypted, not even by the server.\n")
+	sb.WriteString("A recovery kit holds a recovery key which lets you set a new master password if you forget it.\n")
+	sb.WriteString(utils.RecoveryKitFooter())
+
+	return sb.String()
+}
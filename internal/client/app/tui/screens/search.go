@@ -0,0 +1,158 @@
+package screens
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+// searchCategories lists the item categories covered by the global search.
+var searchCategories = []string{TextCategory, CredsCategory, FileCategory, CardCategory}
+
+// searchSortFields lists the fields the search results can be sorted by.
+var searchSortFields = []string{models.SortByRelevance, models.SortByTitle, models.SortByCreated, models.SortByModified}
+
+// searchScreen represents a screen for fuzzy searching over the titles and descriptions of items of all categories.
+// query holds the search text typed by the user, sortBy the field the results are sorted by.
+type searchScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	query        string
+	sortBy       string
+	list         *list.Model
+}
+
+// Update handles typing of the search query, navigation over the results and opening of the selected item.
+func (screen *searchScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	screen.initList()
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyCtrlQ:
+			return screen.backScreen, nil
+
+		case tea.KeyDown:
+			screen.list.CursorDown()
+
+		case tea.KeyUp:
+			screen.list.CursorUp()
+
+		case tea.KeyTab:
+			screen.sortBy = models.NextSort(screen.sortBy, searchSortFields)
+			screen.refresh()
+
+		case tea.KeyEnter:
+			selectedItem, ok := screen.list.SelectedItem().(*models.MetaItem)
+			if !ok || selectedItem == nil {
+				return screen, nil
+			}
+
+			itemData, err := screen.itemsManager.GetItemData(selectedItem.DataID)
+			if err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+
+			return routeViewData(screen, itemData, selectedItem.Category), nil
+
+		case tea.KeyBackspace:
+			if len(screen.query) > 0 {
+				screen.query = screen.query[:len(screen.query)-1]
+				screen.refresh()
+			}
+
+		default:
+			if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+				screen.query += input
+				screen.refresh()
+			}
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the search query and the list of matching items.
+func (screen *searchScreen) View() string {
+	screen.initList()
+
+	s := utils.TitleStyle.Render(fmt.Sprintf("Search: %s\n\n", screen.query))
+	if len(screen.list.Items()) == 0 {
+		s += utils.SelectedStyle.Render("No items found.\n")
+	} else {
+		s += screen.list.View()
+	}
+	s += utils.SearchFooter()
+
+	return s
+}
+
+// initList lazily creates the results list and fills it with all items.
+func (screen *searchScreen) initList() {
+	if screen.list != nil {
+		return
+	}
+
+	listModel := list.New([]list.Item{}, models.MetaItemDelegate{ShowCategory: true}, 10, utils.ListHeight)
+	listModel.SetShowHelp(false)
+	listModel.SetFilteringEnabled(false)
+	listModel.DisableQuitKeybindings()
+	screen.list = &listModel
+
+	if screen.sortBy == "" {
+		screen.sortBy = models.SortByRelevance
+	}
+
+	screen.refresh()
+}
+
+// refresh recomputes the results for the current query and sort field and resets the selection.
+func (screen *searchScreen) refresh() {
+	results := searchMetaItems(screen.itemsManager, screen.query)
+	sortBy := screen.sortBy
+	if screen.query == "" && sortBy == models.SortByRelevance {
+		sortBy = models.SortByTitle
+	}
+	results = models.SortMetaItems(results, sortBy)
+
+	listItems := make([]list.Item, 0, len(results))
+	for _, v := range results {
+		listItems = append(listItems, v)
+	}
+
+	screen.list.SetItems(listItems)
+	screen.list.ResetSelected()
+	screen.list.Title = fmt.Sprintf("Results: %d (sorted by %s)", len(results), screen.sortBy)
+}
+
+// searchMetaItems returns the items of all categories fuzzy matching the query by title and description,
+// ordered by relevance. An empty query matches every item.
+func searchMetaItems(itemsManager models.ItemsManager, query string) []*models.MetaItem {
+	var items []*models.MetaItem
+	for _, category := range searchCategories {
+		items = append(items, itemsManager.GetMetaData(category)...)
+	}
+
+	if query == "" {
+		return items
+	}
+
+	targets := make([]string, len(items))
+	for i, v := range items {
+		targets[i] = v.FilterValue()
+	}
+
+	ranks := list.DefaultFilter(query, targets)
+	res := make([]*models.MetaItem, 0, len(ranks))
+	for _, v := range ranks {
+		res = append(res, items[v.Index])
+	}
+
+	return res
+}
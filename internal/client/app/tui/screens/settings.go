@@ -0,0 +1,112 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+const (
+	settingsFields = 3
+)
+
+// settingsScreen represents the account settings screen with the master password change form,
+// the personal API tokens of the user are opened from it.
+// fields hold the current password, the new password and its repetition, message reports the result of the change.
+type settingsScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	fields       [settingsFields]string
+	cursor       int
+	message      string
+}
+
+// Update handles the input of the password fields and changes the password on Enter.
+func (screen *settingsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.Type {
+		case tea.KeyCtrlQ:
+			return screen.backScreen, nil
+
+		case tea.KeyCtrlT:
+			return &apiTokensScreen{itemsManager: screen.itemsManager, backScreen: screen}, nil
+
+		case tea.KeyTab, tea.KeyDown:
+			screen.cursor = (screen.cursor + 1) % settingsFields
+
+		case tea.KeyUp:
+			screen.cursor = (screen.cursor - 1 + settingsFields) % settingsFields
+
+		case tea.KeyBackspace:
+			if field := screen.fields[screen.cursor]; len(field) > 0 {
+				screen.fields[screen.cursor] = field[:len(field)-1]
+			}
+
+		case tea.KeyEnter:
+			if err := screen.changePassword(); err != nil {
+				return &ErrorScreen{
+					backScreen: screen,
+					err:        err,
+				}, nil
+			}
+
+			screen.fields = [settingsFields]string{}
+			screen.cursor = 0
+			screen.message = "Password changed, other sessions are signed out."
+
+		default:
+			if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+				screen.fields[screen.cursor] += input
+			}
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the password change form with the masked fields.
+func (screen *settingsScreen) View() string {
+	styles := []lipgloss.Style{
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+	}
+	styles[screen.cursor] = utils.CursorStyle
+
+	labels := []string{"Current password:", "New password:", "Repeat new password:"}
+
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render("Settings: change master password\n\n"))
+	for i, label := range labels {
+		sb.WriteString(fmt.Sprintf("%s %s\n", styles[i].Render(label),
+			styles[i].Render(strings.Repeat("•", len(screen.fields[i])))))
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.SettingsFooter())
+
+	return sb.String()
+}
+
+// changePassword checks the form and changes the password of the account.
+func (screen *settingsScreen) changePassword() error {
+	current, newPassword, repeated := screen.fields[0], screen.fields[1], screen.fields[2]
+
+	switch {
+	case current == "" || newPassword == "":
+		return fmt.Errorf("current and new passwords are required")
+	case newPassword != repeated:
+		return fmt.Errorf("new passwords do not match")
+	case newPassword == current:
+		return fmt.Errorf("new password must differ from the current one")
+	}
+
+	return screen.itemsManager.ChangePassword(current, newPassword)
+}
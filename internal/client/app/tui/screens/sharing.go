@@ -0,0 +1,318 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// sharedItemsScreen represents the list of the items other users have shared with the user.
+// shares are loaded when the screen is shown and reloaded after an item is edited or on request.
+type sharedItemsScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	shares       []*models.Share
+	loaded       bool
+	cursor       int
+}
+
+// Update handles navigation over the shared items, opening and editing of the selected one.
+func (screen *sharedItemsScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+q":
+		return screen.backScreen, nil
+
+	case "down":
+		if len(screen.shares) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.shares)
+		}
+
+	case "up":
+		if len(screen.shares) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.shares)) % len(screen.shares)
+		}
+
+	case "r":
+		screen.loaded = false
+
+	case "enter":
+		if screen.cursor >= len(screen.shares) {
+			return screen, nil
+		}
+
+		share := screen.shares[screen.cursor]
+		itemData, err := screen.itemsManager.GetSharedItem(share.ID)
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		return routeViewData(screen, itemData, share.Category), nil
+
+	case "e":
+		if screen.cursor >= len(screen.shares) {
+			return screen, nil
+		}
+
+		share := screen.shares[screen.cursor]
+		if !share.Editable() {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        fmt.Errorf("item is shared read-only"),
+			}, nil
+		}
+
+		editScreen := newEditScreen(share.Category, &itemScreen{
+			itemsManager: &sharedItemsManager{ItemsManager: screen.itemsManager, shareID: share.ID},
+			backScreen:   screen,
+			category:     share.Category,
+			newTitle:     share.Title,
+			newDesc:      share.Description,
+			selectedItem: &models.MetaItem{
+				Category:    share.Category,
+				Title:       share.Title,
+				Description: share.Description,
+				DataID:      share.DataID,
+			},
+		})
+		if editScreen == nil {
+			return screen, nil
+		}
+
+		// После редактирования список перезагружается, чтобы показать новое время изменения
+		screen.loaded = false
+
+		return editScreen, nil
+	}
+
+	return screen, nil
+}
+
+// View renders the items shared with the user with their owners and permissions.
+func (screen *sharedItemsScreen) View() string {
+	if err := screen.load(); err != nil {
+		return utils.SelectedStyle.Render(fmt.Sprintf("Failed to load shared items: %s\n", err)) + utils.ItemDataFooter()
+	}
+
+	if len(screen.shares) == 0 {
+		return utils.SelectedStyle.Render("No items are shared with you.\n\n") + utils.ItemDataFooter()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render("Shared with me:\n\n"))
+	for i, v := range screen.shares {
+		if screen.cursor == i {
+			sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.SharedByLine())))
+		} else {
+			sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.SharedByLine())))
+		}
+	}
+	sb.WriteString(utils.SharedItemsFooter())
+
+	return sb.String()
+}
+
+// load fetches the shared items unless they are already loaded.
+func (screen *sharedItemsScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	shares, err := screen.itemsManager.GetSharedWithMe()
+	if err != nil {
+		return err
+	}
+
+	screen.shares = shares
+	screen.loaded = true
+	if screen.cursor >= len(shares) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
+
+// sharedItemsManager lets the editing screens of the items save an item shared for editing: the data is saved
+// through the share, the metadata of the item belongs to its owner and is left unchanged.
+type sharedItemsManager struct {
+	models.ItemsManager
+	shareID string
+}
+
+// PostItemData saves the new data of the shared item, the metadata is ignored.
+func (sm *sharedItemsManager) PostItemData(data []byte, dataID string, _ *pb.MetaData) (*pb.PostItemDataResponse, error) {
+	if err := sm.UpdateSharedItem(sm.shareID, data); err != nil {
+		return nil, err
+	}
+
+	return &pb.PostItemDataResponse{DataId: dataID}, nil
+}
+
+// FolderByPath keeps the shared item out of the folders of the user.
+func (sm *sharedItemsManager) FolderByPath(string) (string, error) {
+	return "", nil
+}
+
+// shareScreen represents the screen sharing the item with other users and revoking the existing shares.
+// login is the login of the user to share the item with, editable allows the user to change the item.
+// cursor selects one of the existing shares to be revoked.
+type shareScreen struct {
+	itemsManager models.ItemsManager
+	backScreen   models.Screen
+	item         *models.MetaItem
+	shares       []*models.Share
+	loaded       bool
+	login        string
+	editable     bool
+	cursor       int
+	message      string
+}
+
+// Update handles the input of the login, sharing the item on Enter and revoking the selected share.
+func (screen *shareScreen) Update(msg tea.Msg) (models.Screen, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return screen, nil
+	}
+
+	if err := screen.load(); err != nil {
+		return &ErrorScreen{
+			backScreen: screen.backScreen,
+			err:        err,
+		}, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlQ:
+		return screen.backScreen, nil
+
+	case tea.KeyCtrlE:
+		screen.editable = !screen.editable
+
+	case tea.KeyDown:
+		if len(screen.shares) > 0 {
+			screen.cursor = (screen.cursor + 1) % len(screen.shares)
+		}
+
+	case tea.KeyUp:
+		if len(screen.shares) > 0 {
+			screen.cursor = (screen.cursor - 1 + len(screen.shares)) % len(screen.shares)
+		}
+
+	case tea.KeyBackspace:
+		if len(screen.login) > 0 {
+			screen.login = screen.login[:len(screen.login)-1]
+		}
+
+	case tea.KeyEnter:
+		if screen.login == "" {
+			return screen, nil
+		}
+
+		share, err := screen.itemsManager.ShareItem(screen.item, screen.login, screen.editable)
+		if err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Shared with %s, key fingerprint %s.", share.RecipientLogin, share.Fingerprint)
+		screen.login = ""
+		screen.loaded = false
+
+	case tea.KeyCtrlR:
+		if screen.cursor >= len(screen.shares) {
+			return screen, nil
+		}
+
+		share := screen.shares[screen.cursor]
+		if err := screen.itemsManager.RevokeShare(screen.item, share.ID); err != nil {
+			return &ErrorScreen{
+				backScreen: screen,
+				err:        err,
+			}, nil
+		}
+
+		screen.message = fmt.Sprintf("Access of %s revoked, the item is re-encrypted.", share.RecipientLogin)
+		screen.loaded = false
+
+	default:
+		if input := keyMsg.String(); len(input) == 1 && input != "\x00" {
+			screen.login += input
+		}
+	}
+
+	return screen, nil
+}
+
+// View renders the login field, the permission of the new share and the users the item is shared with.
+func (screen *shareScreen) View() string {
+	var sb strings.Builder
+	sb.WriteString(utils.TitleStyle.Render(fmt.Sprintf("Share %q\n\n", screen.item.Title)))
+
+	permission := models.SharePermission(screen.editable)
+	sb.WriteString(fmt.Sprintf("%s %s\n", utils.CursorStyle.Render("Login:"), utils.CursorStyle.Render(screen.login)))
+	sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("Permission: %s\n\n", permission)))
+
+	if err := screen.load(); err != nil {
+		sb.WriteString(utils.SelectedStyle.Render(fmt.Sprintf("Failed to load shares: %s\n", err)))
+	} else if len(screen.shares) == 0 {
+		sb.WriteString(utils.UnselectedStyle.Render("The item is not shared.\n"))
+	} else {
+		sb.WriteString(utils.TitleStyle.Render("Shared with:\n"))
+		for i, v := range screen.shares {
+			if screen.cursor == i {
+				sb.WriteString(utils.CursorStyle.Render(fmt.Sprintf("[x] %s\n", v.SharedWithLine())))
+			} else {
+				sb.WriteString(utils.UnselectedStyle.Render(fmt.Sprintf("[ ] %s\n", v.SharedWithLine())))
+			}
+		}
+	}
+
+	if screen.message != "" {
+		sb.WriteString(utils.SelectedStyle.Render("\n" + screen.message + "\n"))
+	}
+	sb.WriteString(utils.ShareFooter())
+
+	return sb.String()
+}
+
+// load fetches the shares of the item unless they are already loaded.
+func (screen *shareScreen) load() error {
+	if screen.loaded {
+		return nil
+	}
+
+	shares, err := screen.itemsManager.GetShares(screen.item.DataID)
+	if err != nil {
+		return err
+	}
+
+	screen.shares = shares
+	screen.loaded = true
+	if screen.cursor >= len(shares) {
+		screen.cursor = 0
+	}
+
+	return nil
+}
@@ -13,7 +13,7 @@ import (
 )
 
 const (
-	textFields = 3
+	textFields = 5
 )
 
 // viewTextDataScreen represents a screen displaying a specific textual data item.
@@ -121,6 +121,8 @@ func (screen *addTextItemScreen) View() string {
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
 		utils.UnselectedStyle,
+		utils.UnselectedStyle,
+		utils.UnselectedStyle,
 	}
 	styles[screen.cursor] = utils.CursorStyle
 
@@ -128,11 +130,14 @@ func (screen *addTextItemScreen) View() string {
 	addLine("Title:", screen.newTitle, styles[0])
 	addLine("Description:", screen.newDesc, styles[1])
 	addLine("Text:", screen.newItemData.Text, styles[2])
+	addLine("Tags:", screen.newTags, styles[3])
+	addLine("Folder:", screen.newFolder, styles[4])
 
 	// Combine the lines with newlines
 	result := strings.Join(lines, "\n")
 
 	result += utils.AddItemsFooter()
+	result += utils.AddItemTagsFooter()
 
 	return result
 }
@@ -143,7 +148,7 @@ func (screen *addTextItemScreen) handleInput(input string) {
 		return
 	}
 
-	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.Text}
+	fields := []string{screen.newTitle, screen.newDesc, screen.newItemData.Text, screen.newTags, screen.newFolder}
 
 	// Backspace logic
 	if input == "backspace" {
@@ -161,4 +166,6 @@ func (screen *addTextItemScreen) handleInput(input string) {
 	screen.newTitle = fields[0]
 	screen.newDesc = fields[1]
 	screen.newItemData.Text = fields[2]
+	screen.newTags = fields[3]
+	screen.newFolder = fields[4]
 }
@@ -10,11 +10,14 @@ import (
 )
 
 // itemScreen manages the state and behavior of a screen for creating or editing items with metadata in the application.
+// newTags holds the comma separated tags of the item, newFolder the slash separated path of its folder.
 type itemScreen struct {
 	itemsManager models.ItemsManager
 	category     string
 	newTitle     string
 	newDesc      string
+	newTags      string
+	newFolder    string
 	cursor       int
 	backScreen   models.Screen
 	selectedItem *models.MetaItem
@@ -31,10 +34,18 @@ func (is *itemScreen) postItemData(itemData []byte) error {
 		id = uuid.New()
 	}
 
+	folderID, err := is.itemsManager.FolderByPath(is.newFolder)
+	if err != nil {
+		return fmt.Errorf("failed to get folder: %w", err)
+	}
+
 	newItem := models.MetaItem{
 		ID:          id,
+		Category:    is.category,
 		Title:       is.newTitle,
 		Description: is.newDesc,
+		Tags:        models.ParseTags(is.newTags),
+		FolderID:    folderID,
 	}
 
 	metaData := pb.MetaData{
@@ -42,6 +53,8 @@ func (is *itemScreen) postItemData(itemData []byte) error {
 		Title:       newItem.Title,
 		Description: newItem.Description,
 		DataType:    is.category,
+		Tags:        newItem.Tags,
+		FolderId:    newItem.FolderID,
 	}
 
 	resp, err := is.itemsManager.PostItemData(itemData, dataID, &metaData)
@@ -52,6 +65,8 @@ func (is *itemScreen) postItemData(itemData []byte) error {
 	if is.selectedItem != nil {
 		is.selectedItem.Title = is.newTitle
 		is.selectedItem.Description = is.newDesc
+		is.selectedItem.Tags = newItem.Tags
+		is.selectedItem.FolderID = newItem.FolderID
 		is.selectedItem.Modified = resp.Modified
 	} else {
 		newItem.DataID = resp.DataId
@@ -0,0 +1,383 @@
+package tui
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	grpcLib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// initSharingKey loads the sharing key pair of the user, unwrapping the private key with the vault key.
+// On the first login after the sharing was introduced the user has no sharing key yet, so a new pair is generated,
+// the private key is wrapped with the current vault key and both are uploaded to the server.
+func (im *ItemsManager) initSharingKey() error {
+	resp, err := im.grpcClient.Handlers.SharingHandler.GetSharingKey(context.Background(), &pb.GetSharingKeyRequest{
+		UserId: im.userID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return im.newSharingKey()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get sharing key: %w", err)
+	}
+
+	sharingKey := resp.GetSharingKey()
+	keyID, err := vault.WrappedKeyID(sharingKey.GetWrappedPrivateKey())
+	if err != nil {
+		return err
+	}
+
+	vaultKey, err := im.vaultKey(keyID)
+	if err != nil {
+		return err
+	}
+
+	private, err := vault.UnwrapSharingKey(vaultKey, im.userID, sharingKey.GetPublicKey(), sharingKey.GetWrappedPrivateKey())
+	if err != nil {
+		return err
+	}
+
+	im.sharingKey = private
+	im.sharingPublicKey = sharingKey.GetPublicKey()
+	im.sharingKeyID = keyID
+
+	return nil
+}
+
+// newSharingKey generates the sharing key pair of the user and uploads it wrapped with the current vault key.
+func (im *ItemsManager) newSharingKey() error {
+	private, public, err := vault.NewSharingKey()
+	if err != nil {
+		return err
+	}
+
+	im.sharingKey = private
+	im.sharingPublicKey = public
+
+	return im.postSharingKey(im.vaultKeyID)
+}
+
+// postSharingKey wraps the private sharing key with the vault key of the given version and uploads it.
+// The server keeps the public key unchanged, only the wrapping of the private key is replaced.
+func (im *ItemsManager) postSharingKey(keyID uint32) error {
+	vaultKey, err := im.vaultKey(keyID)
+	if err != nil {
+		return err
+	}
+
+	wrapped, err := vault.WrapSharingKey(vaultKey, keyID, im.userID, im.sharingKey)
+	if err != nil {
+		return err
+	}
+
+	if _, err = im.grpcClient.Handlers.SharingHandler.PostSharingKey(context.Background(), &pb.PostSharingKeyRequest{
+		UserId: im.userID,
+		SharingKey: &pb.SharingKey{
+			PublicKey:         im.sharingPublicKey,
+			WrappedPrivateKey: wrapped,
+			KeyId:             keyID,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to post sharing key: %w", err)
+	}
+
+	im.sharingKeyID = keyID
+
+	return nil
+}
+
+// ShareItem shares the item with the user of the login. The data key of the item is sealed to the public sharing key
+// of the recipient together with the title and description, the server never sees any of them.
+// Items stored before the data keys were introduced are re-encrypted under a data key first.
+func (im *ItemsManager) ShareItem(item *models.MetaItem, login string, editable bool) (*models.Share, error) {
+	recipient, err := im.grpcClient.Handlers.SharingHandler.GetPublicKey(context.Background(), &pb.GetPublicKeyRequest{
+		Login: login,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of %s: %w", login, err)
+	}
+
+	dataKeyID, dataKey, err := im.itemDataKey(item.DataID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataKey == nil {
+		if err = im.rekeyItem(item); err != nil {
+			return nil, err
+		}
+
+		if dataKeyID, dataKey, err = im.itemDataKey(item.DataID); err != nil {
+			return nil, err
+		}
+	}
+
+	return im.shareDataKey(item, login, recipient.GetPublicKey(), models.SharePermission(editable), dataKeyID, dataKey)
+}
+
+// shareDataKey seals the data key and the title and description of the item to the public key of the recipient
+// and stores the share. Sharing the item with the same user again replaces the previous share.
+func (im *ItemsManager) shareDataKey(item *models.MetaItem, login string, publicKey []byte, permission string,
+	dataKeyID []byte, dataKey []byte) (*models.Share, error) {
+	wrappedKey, err := vault.ShareDataKey(publicKey, item.DataID, dataKeyID, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedMeta, err := vault.SealSharedMeta(publicKey, item.DataID, &vault.Meta{
+		Title:       item.Title,
+		Description: item.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := im.grpcClient.Handlers.SharingHandler.ShareItem(context.Background(), &pb.ShareItemRequest{
+		UserId:         im.userID,
+		DataId:         item.DataID,
+		RecipientLogin: login,
+		WrappedKey:     wrappedKey,
+		EncryptedMeta:  encryptedMeta,
+		Permission:     permission,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to share item: %w", err)
+	}
+
+	share := shareFromProto(resp.GetShare())
+	share.Title = item.Title
+	share.Description = item.Description
+
+	return share, nil
+}
+
+// GetShares returns the users the item is shared with, with the fingerprints of their public keys.
+func (im *ItemsManager) GetShares(dataID string) ([]*models.Share, error) {
+	resp, err := im.grpcClient.Handlers.SharingHandler.GetShares(context.Background(), &pb.GetSharesRequest{
+		UserId: im.userID,
+		DataId: dataID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shares: %w", err)
+	}
+
+	shares := make([]*models.Share, 0, len(resp.GetShares()))
+	for _, v := range resp.GetShares() {
+		shares = append(shares, shareFromProto(v))
+	}
+
+	return shares, nil
+}
+
+// RevokeShare removes the share of the item. The former recipient may still have the data key, so the item
+// is re-encrypted under a new data key and shared again with the remaining recipients.
+func (im *ItemsManager) RevokeShare(item *models.MetaItem, shareID string) error {
+	if _, err := im.grpcClient.Handlers.SharingHandler.RevokeShare(context.Background(), &pb.RevokeShareRequest{
+		UserId:  im.userID,
+		ShareId: shareID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke share: %w", err)
+	}
+
+	shares, err := im.grpcClient.Handlers.SharingHandler.GetShares(context.Background(), &pb.GetSharesRequest{
+		UserId: im.userID,
+		DataId: item.DataID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get shares: %w", err)
+	}
+
+	if err = im.rekeyItem(item); err != nil {
+		return err
+	}
+
+	dataKeyID, dataKey, err := im.itemDataKey(item.DataID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range shares.GetShares() {
+		if _, err = im.shareDataKey(item, v.GetRecipientLogin(), v.GetRecipientPublicKey(), v.GetPermission(),
+			dataKeyID, dataKey); err != nil {
+			return fmt.Errorf("failed to share item with %s again: %w", v.GetRecipientLogin(), err)
+		}
+	}
+
+	return nil
+}
+
+// GetSharedWithMe returns the items other users have shared with the user, with the titles and descriptions
+// opened with the private sharing key.
+func (im *ItemsManager) GetSharedWithMe() ([]*models.Share, error) {
+	resp, err := im.grpcClient.Handlers.SharingHandler.GetSharedWithMe(context.Background(), &pb.GetSharedWithMeRequest{
+		UserId: im.userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared items: %w", err)
+	}
+
+	shares := make([]*models.Share, 0, len(resp.GetShares()))
+	for _, v := range resp.GetShares() {
+		share := shareFromProto(v)
+
+		meta, err := vault.OpenSharedMeta(im.sharingKey, v.GetDataId(), v.GetEncryptedMeta())
+		if err != nil {
+			return nil, fmt.Errorf("shared item from %s: %w", v.GetOwnerLogin(), err)
+		}
+
+		share.Title = meta.Title
+		share.Description = meta.Description
+		shares = append(shares, share)
+	}
+
+	return shares, nil
+}
+
+// GetSharedItem fetches the item shared with the user and decrypts it with the data key shared with the user.
+func (im *ItemsManager) GetSharedItem(shareID string) (string, error) {
+	resp, err := im.grpcClient.Handlers.SharingHandler.GetSharedItem(context.Background(), &pb.GetSharedItemRequest{
+		ShareId: shareID,
+		UserId:  im.userID,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	)
+	if err != nil {
+		return "", fmt.Errorf("could not get shared item: %w", err)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(string(resp.GetData()))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	decryptedData, err := vault.OpenSharedItem(im.sharingKey, resp.GetShare().GetDataId(), blob, resp.GetShare().GetWrappedKey())
+	if err != nil {
+		return "", fmt.Errorf("failed decrypt data: %w", err)
+	}
+
+	return string(decryptedData), nil
+}
+
+// UpdateSharedItem encrypts the new data of the item shared for editing with the shared data key, so the owner
+// and the other recipients keep opening it, and saves it on the server.
+func (im *ItemsManager) UpdateSharedItem(shareID string, data []byte) error {
+	resp, err := im.grpcClient.Handlers.SharingHandler.GetSharedItem(context.Background(), &pb.GetSharedItemRequest{
+		ShareId: shareID,
+		UserId:  im.userID,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	)
+	if err != nil {
+		return fmt.Errorf("could not get shared item: %w", err)
+	}
+
+	share := resp.GetShare()
+	if share.GetPermission() != models.SharePermissionEdit {
+		return fmt.Errorf("item is shared read-only")
+	}
+
+	blob, err := vault.SealSharedItem(im.sharingKey, share.GetDataId(), share.GetWrappedKey(), data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt data: %w", err)
+	}
+
+	if _, err = im.grpcClient.Handlers.SharingHandler.UpdateSharedItem(context.Background(), &pb.UpdateSharedItemRequest{
+		ShareId: shareID,
+		Data:    []byte(base64.StdEncoding.EncodeToString(blob)),
+		UserId:  im.userID,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	); err != nil {
+		return fmt.Errorf("failed to update shared item: %w", err)
+	}
+
+	return nil
+}
+
+// itemDataKey fetches the item and opens its data key with the vault key.
+// Returns nil keys for the items stored before the data keys were introduced.
+func (im *ItemsManager) itemDataKey(dataID string) ([]byte, []byte, error) {
+	response, err := im.grpcClient.Handlers.ItemDataHandler.GetItemData(context.Background(), &pb.GetItemDataRequest{
+		DataId: dataID,
+	},
+		grpcLib.MaxCallRecvMsgSize(messageLimit),
+		grpcLib.MaxCallSendMsgSize(messageLimit),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get item data: %w", err)
+	}
+
+	if len(response.GetWrappedKey()) == 0 {
+		return nil, nil, nil
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(string(response.GetData()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	keyID, err := vault.WrappedKeyID(response.GetWrappedKey())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vaultKey, err := im.vaultKey(keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vault.ItemDataKey(vaultKey, dataID, blob, response.GetWrappedKey())
+}
+
+// rekeyItem re-encrypts the item under a new data key, keeping its metadata.
+func (im *ItemsManager) rekeyItem(item *models.MetaItem) error {
+	data, err := im.GetItemData(item.DataID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := im.postItemData([]byte(data), item.DataID, metaItemToProto(item), false)
+	if err != nil {
+		return err
+	}
+
+	item.Modified = resp.GetModified()
+
+	return nil
+}
+
+// metaItemToProto converts the cached metadata item into the metadata sent along with the item data.
+func metaItemToProto(item *models.MetaItem) *pb.MetaData {
+	return &pb.MetaData{
+		Id:          item.ID.String(),
+		Title:       item.Title,
+		Description: item.Description,
+		DataType:    item.Category,
+		Tags:        item.Tags,
+		FolderId:    item.FolderID,
+	}
+}
+
+// shareFromProto converts the share received from the server into the share shown to the user.
+func shareFromProto(share *pb.Share) *models.Share {
+	return &models.Share{
+		ID:             share.GetId(),
+		DataID:         share.GetDataId(),
+		Category:       share.GetDataType(),
+		OwnerLogin:     share.GetOwnerLogin(),
+		RecipientLogin: share.GetRecipientLogin(),
+		Fingerprint:    vault.KeyFingerprint(share.GetRecipientPublicKey()),
+		Permission:     share.GetPermission(),
+		Modified:       share.GetModified(),
+	}
+}
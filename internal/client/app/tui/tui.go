@@ -2,6 +2,7 @@ package tui
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	grpcLib "google.golang.org/grpc"
 
@@ -12,39 +13,80 @@ import (
 	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
 	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/screens"
 	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/config"
 	"github.com/mikhaylov123ty/GophKeeper/internal/client/grpc"
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/vault"
 	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
 )
 
 const (
 	mB           = 1048576
 	messageLimit = 60 * mB
+	metaPageSize = 500
 )
 
 // ItemsManager is responsible for managing metadata items, gRPC client interactions, and user authentication data.
+// vaultKeys holds the vault keys of the user unwrapped with the master password at login by their versions,
+// vaultKeyID is the version new records are encrypted with: the newest one, while a key rotation is not finished.
+// login is kept to confirm the master password by a new SRP exchange before the sensitive changes.
+// recoveryPublicKey is set when the user has a recovery kit, the new vault keys are sealed to it as well,
+// newAccount is set when the first vault key was generated by the last login.
+// sharingKey and sharingPublicKey are the key pair the items are shared with the user by, sharingKeyID is the version
+// of the vault key the private key is wrapped with on the server.
+// collectionKeys holds the opened keys of the team collections by their IDs, loaded with the collections.
+// emergencyKeys holds the vault keys of the owners released to the user as a trusted contact by the access IDs.
+// usage caches the storage usage of the user shown in the main menu until the next item is saved or deleted.
 type ItemsManager struct {
-	metaItems  map[string][]*models.MetaItem
-	grpcClient *grpc.Client
-	userID     string
+	metaItems         map[string][]*models.MetaItem
+	folders           []*models.Folder
+	grpcClient        *grpc.Client
+	userID            string
+	login             string
+	vaultKeys         map[uint32][]byte
+	vaultKeyID        uint32
+	recoveryPublicKey []byte
+	newAccount        bool
+	sharingKey        []byte
+	sharingPublicKey  []byte
+	sharingKeyID      uint32
+	collectionKeys    map[string]*collectionKey
+	emergencyKeys     map[string]*emergencyKey
+	usage             *models.Usage
+}
+
+// NewItemsManager initializes an ItemsManager connected to the gRPC services, without any user interface.
+// It is shared by the TUI and the non-interactive client commands.
+func NewItemsManager(grpcClient *grpc.Client) *ItemsManager {
+	return &ItemsManager{
+		metaItems:      map[string][]*models.MetaItem{},
+		grpcClient:     grpcClient,
+		collectionKeys: map[string]*collectionKey{},
+		emergencyKeys:  map[string]*emergencyKey{},
+	}
 }
 
 // NewItemManager - Initializes and returns a new instance of an `ItemManager`,
 // which is responsible for managing TUI interactions and connecting with gRPC services.
 func NewItemManager(grpcClient *grpc.Client) (*models.Model, error) {
-	im := ItemsManager{
-		metaItems:  map[string][]*models.MetaItem{},
-		grpcClient: grpcClient,
-	}
+	im := NewItemsManager(grpcClient)
 
 	mainMenu := screens.NewMainMenu([]string{
 		screens.TextCategory,
 		screens.CredsCategory,
 		screens.FileCategory,
 		screens.CardCategory,
+		screens.FoldersCategory,
+		screens.SearchCategory,
+		screens.ImportCategory,
+		screens.SharedCategory,
+		screens.TeamsCategory,
+		screens.EmergencyCategory,
+		screens.ActivityCategory,
+		screens.SettingsCategory,
 		screens.ExitCategory,
-	}, &im)
+	}, im)
 
-	auth := screens.NewAuthScreen(mainMenu, &im)
+	auth := screens.NewAuthScreen(mainMenu, im)
 
 	return auth, nil
 }
@@ -60,32 +102,72 @@ func (im *ItemsManager) SaveMetaItem(category string, newItem *models.MetaItem)
 }
 
 // PostItemData sends item data and metadata to the associated gRPC service after encrypting the data.
+// The data is encrypted under a new data key wrapped by the vault key, new items get their data ID on the client,
+// as the ciphertext is bound to it. Edited items keep their data key, so the users the item is shared with
+// keep opening it.
 func (im *ItemsManager) PostItemData(data []byte, dataID string, metaData *pb.MetaData) (*pb.PostItemDataResponse, error) {
-	encryptedData, err := utils.EncryptData(data)
+	return im.postItemData(data, dataID, metaData, true)
+}
+
+// postItemData encrypts and sends the item, reusing the data key of an existing item when keepDataKey is set.
+func (im *ItemsManager) postItemData(data []byte, dataID string, metaData *pb.MetaData, keepDataKey bool) (*pb.PostItemDataResponse, error) {
+	vaultKey, err := im.vaultKey(im.vaultKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataKeyID, dataKey []byte
+	if dataID == "" {
+		dataID = uuid.New().String()
+	} else if keepDataKey {
+		if dataKeyID, dataKey, err = im.itemDataKey(dataID); err != nil {
+			return nil, err
+		}
+	}
+
+	var blob, wrappedKey []byte
+	if dataKey != nil {
+		blob, wrappedKey, err = vault.ResealItem(vaultKey, im.vaultKeyID, dataID, dataKeyID, dataKey, data)
+	} else {
+		blob, wrappedKey, err = vault.SealItem(vaultKey, im.vaultKeyID, dataID, data)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
 
 	metaData.UserId = im.userID
 
+	if config.GetEncryptMeta() {
+		if metaData, err = im.sealMeta(metaData); err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := im.grpcClient.Handlers.ItemDataHandler.PostItemData(context.Background(),
 		&pb.PostItemDataRequest{
-			Data:     encryptedData,
-			DataId:   dataID,
-			MetaData: metaData,
+			Data:       []byte(base64.StdEncoding.EncodeToString(blob)),
+			DataId:     dataID,
+			MetaData:   metaData,
+			WrappedKey: wrappedKey,
+			KeyId:      im.vaultKeyID,
 		},
 		grpcLib.MaxCallRecvMsgSize(messageLimit),
 		grpcLib.MaxCallSendMsgSize(messageLimit),
 	)
 	if err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			return nil, fmt.Errorf("storage quota exceeded: %s", status.Convert(err).Message())
+		}
 		return nil, fmt.Errorf("post item failed:  %w,", err)
 	}
+	im.usage = nil
 
 	return resp, err
 }
 
 // GetItemData retrieves the item data associated with the given data ID,
-// decrypts it using the utility functions, and returns the decrypted data as a string.
+// decrypts it and returns the decrypted data as a string.
+// Items stored before the data keys were introduced are decrypted with the certificate derived key.
 func (im *ItemsManager) GetItemData(dataID string) (string, error) {
 	response, err := im.grpcClient.Handlers.ItemDataHandler.GetItemData(context.Background(), &pb.GetItemDataRequest{
 		DataId: dataID,
@@ -97,21 +179,56 @@ func (im *ItemsManager) GetItemData(dataID string) (string, error) {
 		return "", fmt.Errorf("could not get text data: %w", err)
 	}
 
-	decryptedData, err := utils.DeryptData(response.Data)
+	if len(response.GetWrappedKey()) == 0 {
+		decryptedData, err := utils.DeryptData(response.Data)
+		if err != nil {
+			return "", fmt.Errorf("failed decrypt data: %w", err)
+		}
+
+		return string(decryptedData), nil
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(string(response.GetData()))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	keyID, err := vault.WrappedKeyID(response.GetWrappedKey())
+	if err != nil {
+		return "", err
+	}
+
+	vaultKey, err := im.vaultKey(keyID)
+	if err != nil {
+		return "", err
+	}
+
+	decryptedData, err := vault.OpenItem(vaultKey, dataID, blob, response.GetWrappedKey())
 	if err != nil {
 		return "", fmt.Errorf("failed decrypt data: %w", err)
 	}
 
 	return string(decryptedData), nil
+}
 
+// vaultKey returns the unwrapped vault key of the given version.
+func (im *ItemsManager) vaultKey(keyID uint32) ([]byte, error) {
+	key, ok := im.vaultKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("vault key %d is not loaded", keyID)
+	}
+
+	return key, nil
 }
 
-// PostUserData sends user credentials to the authentication service, retrieves a user ID and JWT token, and stores them.
+// PostUserData logs the user in with the SRP exchange and stores the user ID and the JWT token.
+// When the exchange is rejected, an unknown login is registered, and a legacy account created before the SRP login
+// logs in with the password once, sending the SRP record which replaces the password hash on the server.
 func (im *ItemsManager) PostUserData(login string, password string) error {
-	res, err := im.grpcClient.Handlers.AuthHandler.PostUserData(context.Background(), &pb.PostUserDataRequest{
-		Login:    login,
-		Password: password,
-	})
+	res, err := im.srpLogin(login, password)
+	if status.Code(err) == codes.PermissionDenied {
+		res, err = im.registerOrUpgrade(login, password, err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed login: %w", err)
 	}
@@ -125,43 +242,339 @@ func (im *ItemsManager) PostUserData(login string, password string) error {
 	}
 
 	im.userID = res.UserId
+	im.usage = nil
+	im.login = login
 	im.grpcClient.JWTToken = res.Jwt
+	im.recoveryPublicKey = res.GetRecoveryPublicKey()
+
+	if err = im.initVaultKeys(res.GetVaultKey(), res.GetPendingVaultKey(), password); err != nil {
+		return err
+	}
+
+	return im.initSharingKey()
+}
+
+// ChangePassword changes the master password of the account. The old password is confirmed by an SRP exchange,
+// the loaded vault keys are re-wrapped with the new password and sent together with the SRP record of the new password,
+// the server replaces both at once and revokes the other sessions of the user.
+func (im *ItemsManager) ChangePassword(oldPassword string, newPassword string) error {
+	if len(im.vaultKeys) == 0 {
+		return fmt.Errorf("vault key is not loaded")
+	}
+
+	record, err := vault.NewLoginRecord(im.login, newPassword)
+	if err != nil {
+		return err
+	}
+
+	sessionID, proof, err := im.confirmPassword(oldPassword)
+	if err != nil {
+		return err
+	}
+
+	request := &pb.ChangePasswordRequest{
+		UserId:       im.userID,
+		SrpSessionId: sessionID,
+		SrpProof:     proof,
+		SrpRecord:    loginRecordToProto(record),
+	}
+	for keyID, key := range im.vaultKeys {
+		wrapped, err := vault.Wrap(key, newPassword)
+		if err != nil {
+			return err
+		}
+
+		vaultKey := wrappedToProto(wrapped)
+		vaultKey.KeyId = keyID
+		request.VaultKeys = append(request.VaultKeys, vaultKey)
+	}
+
+	res, err := im.grpcClient.Handlers.AuthHandler.ChangePassword(context.Background(), request)
+	if err != nil {
+		return fmt.Errorf("failed to change password: %w", err)
+	}
+
+	im.grpcClient.JWTToken = res.GetJwt()
 
 	return nil
 }
 
-// SyncMeta synchronizes metadata by retrieving and storing metadata items for the current user from the gRPC service.
-func (im *ItemsManager) SyncMeta() error {
-	metaItems, err := im.grpcClient.Handlers.MetaDataHandler.GetMetaData(context.Background(),
-		&pb.GetMetaDataRequest{UserId: im.userID})
+// initVaultKeys unwraps the vault keys of the user with the master password: the key in use and the new key of
+// an unfinished rotation, if any. On the first login the user has no vault key yet, so a new one is generated,
+// wrapped and uploaded to the server.
+func (im *ItemsManager) initVaultKeys(active *pb.VaultKey, pending *pb.VaultKey, password string) error {
+	im.vaultKeys = map[uint32][]byte{}
+
+	if active == nil {
+		key, err := vault.NewKey()
+		if err != nil {
+			return err
+		}
+
+		wrapped, err := vault.Wrap(key, password)
+		if err != nil {
+			return err
+		}
+
+		if _, err = im.grpcClient.Handlers.AuthHandler.PostVaultKey(context.Background(), &pb.PostVaultKeyRequest{
+			UserId:   im.userID,
+			VaultKey: wrappedToProto(wrapped),
+		}); err != nil {
+			return fmt.Errorf("failed to upload vault key: %w", err)
+		}
+		im.vaultKeys[vault.FirstKeyID] = key
+		im.vaultKeyID = vault.FirstKeyID
+		im.newAccount = true
+
+		return nil
+	}
+
+	for _, wrapped := range []*pb.VaultKey{active, pending} {
+		if wrapped == nil {
+			continue
+		}
+
+		key, err := vault.Unwrap(&vault.WrappedKey{
+			Key:     wrapped.GetWrappedKey(),
+			Salt:    wrapped.GetSalt(),
+			Time:    wrapped.GetKdfTime(),
+			Memory:  wrapped.GetKdfMemory(),
+			Threads: wrapped.GetKdfThreads(),
+		}, password)
+		if err != nil {
+			return err
+		}
+
+		keyID := wrapped.GetKeyId()
+		if keyID == 0 {
+			keyID = vault.FirstKeyID
+		}
+		im.vaultKeys[keyID] = key
+		im.vaultKeyID = max(im.vaultKeyID, keyID)
+	}
+
+	return nil
+}
+
+// wrappedToProto converts the wrapped vault key into its gRPC representation.
+func wrappedToProto(wrapped *vault.WrappedKey) *pb.VaultKey {
+	return &pb.VaultKey{
+		WrappedKey: wrapped.Key,
+		Salt:       wrapped.Salt,
+		KdfTime:    wrapped.Time,
+		KdfMemory:  wrapped.Memory,
+		KdfThreads: wrapped.Threads,
+	}
+}
+
+// sealMeta returns a copy of the metadata with the title, description and tags encrypted with the vault key,
+// leaving only the identifiers, the data type and the folder in clear.
+func (im *ItemsManager) sealMeta(metaData *pb.MetaData) (*pb.MetaData, error) {
+	vaultKey, err := im.vaultKey(im.vaultKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedMeta, err := vault.SealMeta(vaultKey, im.vaultKeyID, metaData.GetId(), metaData.GetDataType(), &vault.Meta{
+		Title:       metaData.GetTitle(),
+		Description: metaData.GetDescription(),
+		Tags:        metaData.GetTags(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.MetaData{
+		Id:            metaData.GetId(),
+		DataType:      metaData.GetDataType(),
+		DataId:        metaData.GetDataId(),
+		UserId:        metaData.GetUserId(),
+		FolderId:      metaData.GetFolderId(),
+		EncryptedMeta: encryptedMeta,
+		MetaKeyId:     im.vaultKeyID,
+	}, nil
+}
+
+// openMeta decrypts the title, description and tags of the metadata item received from the server, if they are encrypted.
+func (im *ItemsManager) openMeta(metaData *pb.MetaData, item *models.MetaItem) error {
+	if len(metaData.GetEncryptedMeta()) == 0 {
+		return nil
+	}
+
+	keyID, err := vault.MetaKeyID(metaData.GetEncryptedMeta())
+	if err != nil {
+		return fmt.Errorf("item %s: %w", metaData.GetId(), err)
+	}
+
+	vaultKey, err := im.vaultKey(keyID)
 	if err != nil {
-		if e, ok := status.FromError(err); ok {
-			if e.Code() == codes.NotFound {
-				return nil
+		return err
+	}
+
+	meta, err := vault.OpenMeta(vaultKey, metaData.GetId(), metaData.GetDataType(), metaData.GetEncryptedMeta())
+	if err != nil {
+		return fmt.Errorf("item %s: %w", metaData.GetId(), err)
+	}
+
+	item.Title = meta.Title
+	item.Description = meta.Description
+	item.Tags = meta.Tags
+
+	return nil
+}
+
+// SyncMeta synchronizes metadata by retrieving and storing metadata items and folders for the current user from the gRPC service.
+// The metadata is requested page by page until the last page, replacing the cached items.
+func (im *ItemsManager) SyncMeta() error {
+	metaItems := map[string][]*models.MetaItem{}
+
+	var pageToken string
+	for {
+		resp, err := im.grpcClient.Handlers.MetaDataHandler.GetMetaData(context.Background(),
+			&pb.GetMetaDataRequest{
+				UserId:    im.userID,
+				PageSize:  metaPageSize,
+				PageToken: pageToken,
+			})
+		if err != nil {
+			if e, ok := status.FromError(err); ok && e.Code() == codes.NotFound {
+				break
 			}
-		} else {
 			return fmt.Errorf("failed to get meta data: %w", err)
 		}
+
+		for _, metaItem := range resp.GetItems() {
+			id, err := uuid.Parse(metaItem.GetId())
+			if err != nil {
+				return fmt.Errorf("invalid meta item id: %s", metaItem.GetId())
+			}
+			item := &models.MetaItem{
+				ID:          id,
+				Category:    metaItem.GetDataType(),
+				Title:       metaItem.GetTitle(),
+				Description: metaItem.GetDescription(),
+				DataID:      metaItem.GetDataId(),
+				Created:     metaItem.GetCreated(),
+				Modified:    metaItem.GetModified(),
+				Tags:        metaItem.GetTags(),
+				FolderID:    metaItem.GetFolderId(),
+			}
+			if err = im.openMeta(metaItem, item); err != nil {
+				return fmt.Errorf("failed to decrypt meta data: %w", err)
+			}
+			metaItems[metaItem.DataType] = append(metaItems[metaItem.DataType], item)
+		}
+
+		if pageToken = resp.GetNextPageToken(); pageToken == "" {
+			break
+		}
 	}
 
-	for _, metaItem := range metaItems.Items {
-		id, err := uuid.Parse(metaItem.GetId())
-		if err != nil {
-			return fmt.Errorf("invalid meta item id: %s", metaItem.GetId())
-		}
-		im.metaItems[metaItem.DataType] = append(im.metaItems[metaItem.DataType], &models.MetaItem{
-			ID:          id,
-			Title:       metaItem.GetTitle(),
-			Description: metaItem.GetDescription(),
-			DataID:      metaItem.GetDataId(),
-			Created:     metaItem.GetCreated(),
-			Modified:    metaItem.GetModified(),
+	im.metaItems = metaItems
+
+	return im.syncFolders()
+}
+
+// syncFolders retrieves and caches the folders of the current user from the gRPC service.
+func (im *ItemsManager) syncFolders() error {
+	resp, err := im.grpcClient.Handlers.FolderHandler.GetFolders(context.Background(),
+		&pb.GetFoldersRequest{UserId: im.userID})
+	if err != nil {
+		if e, ok := status.FromError(err); ok && e.Code() == codes.NotFound {
+			im.folders = nil
+			return nil
+		}
+		return fmt.Errorf("failed to get folders: %w", err)
+	}
+
+	im.folders = make([]*models.Folder, 0, len(resp.GetFolders()))
+	for _, v := range resp.GetFolders() {
+		im.folders = append(im.folders, &models.Folder{
+			ID:       v.GetId(),
+			Name:     v.GetName(),
+			ParentID: v.GetParentId(),
 		})
 	}
 
 	return nil
 }
 
+// GetFolders returns the cached folders of the current user.
+func (im *ItemsManager) GetFolders() []*models.Folder {
+	return im.folders
+}
+
+// PostFolder creates a folder with the given name inside the parent folder, an empty parent ID means the top level.
+func (im *ItemsManager) PostFolder(name string, parentID string) (*models.Folder, error) {
+	resp, err := im.grpcClient.Handlers.FolderHandler.PostFolder(context.Background(), &pb.PostFolderRequest{
+		Folder: &pb.Folder{
+			Name:     name,
+			ParentId: parentID,
+			UserId:   im.userID,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create folder: %w", err)
+	}
+
+	folder := &models.Folder{
+		ID:       resp.GetFolder().GetId(),
+		Name:     resp.GetFolder().GetName(),
+		ParentID: resp.GetFolder().GetParentId(),
+	}
+	im.folders = append(im.folders, folder)
+
+	return folder, nil
+}
+
+// FolderByPath returns the ID of the folder with the given slash separated path, creating the missing folders
+// along the path. An empty path means the top level and returns an empty ID.
+func (im *ItemsManager) FolderByPath(path string) (string, error) {
+	var parentID string
+	for _, name := range models.SplitFolderPath(path) {
+		folder := models.FindFolder(im.folders, parentID, name)
+		if folder == nil {
+			var err error
+			if folder, err = im.PostFolder(name, parentID); err != nil {
+				return "", err
+			}
+		}
+		parentID = folder.ID
+	}
+
+	return parentID, nil
+}
+
+// DeleteFolder removes the folder with its subfolders and moves the cached items of the removed folders to the top level.
+func (im *ItemsManager) DeleteFolder(id string) error {
+	if _, err := im.grpcClient.Handlers.FolderHandler.DeleteFolder(context.Background(), &pb.DeleteFolderRequest{
+		FolderId: id,
+		UserId:   im.userID,
+	}); err != nil {
+		return fmt.Errorf("could not delete folder: %w", err)
+	}
+
+	removed := models.DescendantFolders(im.folders, id)
+
+	folders := im.folders[:0]
+	for _, v := range im.folders {
+		if !removed[v.ID] {
+			folders = append(folders, v)
+		}
+	}
+	im.folders = folders
+
+	for _, items := range im.metaItems {
+		for _, v := range items {
+			if removed[v.FolderID] {
+				v.FolderID = ""
+			}
+		}
+	}
+
+	return nil
+}
+
 // DeleteItem removes a metadata item by its ID, category, and data ID, and updates the local metadata cache.
 func (im *ItemsManager) DeleteItem(metaItemID uuid.UUID, category string, dataID string) error {
 	resp, err := im.grpcClient.Handlers.MetaDataHandler.DeleteMetaData(context.Background(), &pb.DeleteMetaDataRequest{
@@ -172,6 +585,7 @@ func (im *ItemsManager) DeleteItem(metaItemID uuid.UUID, category string, dataID
 	if err != nil && resp.GetError() != "" {
 		return fmt.Errorf("could not delete meta data: %w", err)
 	}
+	im.usage = nil
 
 	for i, v := range im.metaItems[category] {
 		if v.ID == metaItemID {
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// GetUsage returns the storage used by the user with the limits of the quota, zero limits are not enforced.
+// The usage is requested once and cached until an item is saved or deleted, as the main menu shows it on every render.
+func (im *ItemsManager) GetUsage() (*models.Usage, error) {
+	if im.usage != nil {
+		return im.usage, nil
+	}
+
+	resp, err := im.grpcClient.Handlers.ItemDataHandler.GetUsage(context.Background(), &pb.GetUsageRequest{
+		UserId: im.userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	im.usage = &models.Usage{
+		Items:       resp.GetItems(),
+		Bytes:       resp.GetBytes(),
+		MaxItems:    resp.GetMaxItems(),
+		MaxBytes:    resp.GetMaxBytes(),
+		MaxItemSize: resp.GetMaxItemSize(),
+	}
+
+	return im.usage, nil
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mdp/qrterminal/v3"
+)
+
+// RecoveryKitSheet renders the printable recovery kit of the account: the login, the creation date,
+// the recovery key as text and the same key as a QR code drawn with the terminal block characters.
+func RecoveryKitSheet(login string, recoveryKey string, created time.Time) string {
+	var sb strings.Builder
+
+	sb.WriteString("GophKeeper recovery kit\n")
+	sb.WriteString(strings.Repeat("=", 40) + "\n")
+	sb.WriteString(fmt.Sprintf("Login:   %s\n", login))
+	sb.WriteString(fmt.Sprintf("Created: %s\n\n", created.Format(time.DateOnly)))
+	sb.WriteString("Recovery key:\n")
+	sb.WriteString(recoveryKey + "\n\n")
+
+	qrterminal.GenerateHalfBlock(recoveryKey, qrterminal.M, &sb)
+
+	sb.WriteString("\nKeep this sheet offline. Anyone holding the key and your login can reset the master password\n")
+	sb.WriteString("and read the vault. A new kit replaces this one.\n")
+
+	return sb.String()
+}
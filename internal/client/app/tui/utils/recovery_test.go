@@ -0,0 +1,20 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/utils"
+)
+
+func TestRecoveryKitSheet(t *testing.T) {
+	key := "ABCD-EFGH-IJKL-MNOP-QRST-UVWX-YZ23-4567-ABCD-EFGH-IJKL-MNOP-QRST"
+	sheet := utils.RecoveryKitSheet("user", key, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	assert.Contains(t, sheet, "Login:   user")
+	assert.Contains(t, sheet, "Created: 2025-01-02")
+	assert.Contains(t, sheet, key)
+	assert.Contains(t, sheet, "▀", "sheet contains the QR code")
+}
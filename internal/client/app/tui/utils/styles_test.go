@@ -77,6 +77,7 @@ func TestListItemsFooter(t *testing.T) {
 			wantSubstrings: []string{
 				"Use arrow keys to navigate",
 				"E to edit",
+				"H to share",
 				"D to delete",
 				"Enter to select",
 				"CTRL+Q to cancel",
@@ -171,3 +172,365 @@ func TestAuthFooter(t *testing.T) {
 		})
 	}
 }
+
+func TestFoldersFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "FoldersFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"N to create a folder",
+				"D to delete a folder",
+				"Backspace to go up",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.FoldersFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestSettingsFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "SettingsFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Tab to switch fields",
+				"Enter to change the password",
+				"CTRL+T to manage API tokens",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.SettingsFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestAPITokensFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "APITokensFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to create a token",
+				"CTRL+O to toggle read-only",
+				"CTRL+D to revoke a token",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.APITokensFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestActivityFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "ActivityFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"CTRL+F to filter by action",
+				"CTRL+N for the next page",
+				"CTRL+R to reload",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.ActivityFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestRecoveryKitFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "RecoveryKitFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to create the recovery kit",
+				"CTRL+Q to skip",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.RecoveryKitFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestSharedItemsFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "SharedItemsFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to view",
+				"E to edit",
+				"R to reload",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.SharedItemsFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestShareFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "ShareFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to share",
+				"CTRL+E to allow editing",
+				"CTRL+R to revoke",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.ShareFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestOrgsFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "OrgsFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to create an organization",
+				"CTRL+A to accept",
+				"CTRL+X to decline",
+				"CTRL+D to delete",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.OrgsFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestOrgFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "OrgFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to create a collection",
+				"CTRL+U for members",
+				"CTRL+R to reload",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.OrgFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestOrgMembersFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "OrgMembersFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to invite",
+				"CTRL+T to change the role",
+				"CTRL+R to remove",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.OrgMembersFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestCollectionFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "CollectionFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to select a category",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.CollectionFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestEmergencyFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "EmergencyFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"Enter to add a trusted contact",
+				"CTRL+W to change the waiting period",
+				"CTRL+A to approve",
+				"CTRL+X to reject",
+				"CTRL+D to revoke",
+				"CTRL+E to request access",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.EmergencyFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
+
+func TestEmergencyVaultFooter(t *testing.T) {
+	type args struct{}
+	tests := []struct {
+		name           string
+		args           args
+		wantSubstrings []string
+	}{
+		{
+			name: "EmergencyVaultFooter contains instructions",
+			args: args{},
+			wantSubstrings: []string{
+				"read-only",
+				"Enter to view an item",
+				"CTRL+Q to return",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := utils.EmergencyVaultFooter()
+			for _, substr := range tt.wantSubstrings {
+				require.Contains(t, result, substr)
+			}
+		})
+	}
+}
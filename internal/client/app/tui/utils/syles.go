@@ -38,7 +38,7 @@ func AddItemsFooter() string {
 
 // ListItemsFooter returns a styled string displaying navigation and action instructions for a list interface.
 func ListItemsFooter() string {
-	return BackgroundStyle.Render(SeparatorStyle.Render("\nUse arrow keys to navigate. E to edit. D to delete. Enter to select. CTRL+Q to cancel.\n"))
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nUse arrow keys to navigate. / to search, Esc to clear. S to sort. E to edit. H to share. D to delete. Enter to select. CTRL+Q to cancel.\n"))
 }
 
 // ItemDataFooter renders a styled footer with a prompt to return using CTRL+Q.
@@ -51,6 +51,86 @@ func BinaryItemDataFooter() string {
 	return BackgroundStyle.Render(SeparatorStyle.Render("\nPress D to download file to output folder. CTRL+Q to return.\n"))
 }
 
+// AddItemTagsFooter returns a styled hint on the format of the tags and folder fields of the add item screens.
+func AddItemTagsFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("Tags are comma separated, folder is a path like Work/Servers.\n"))
+}
+
+// FoldersFooter returns a styled footer string with instructions for the folder tree screen.
+func FoldersFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nUse arrow keys to navigate, Enter to open. N to create a folder, D to delete a folder. Backspace to go up, CTRL+Q to return.\n"))
+}
+
+// SearchFooter returns a styled footer string with instructions for the global search screen.
+func SearchFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType to search. Use arrow keys to navigate, Tab to change sorting, Enter to select, CTRL+Q to return.\n"))
+}
+
+// ImportFooter returns a styled footer string with instructions for confirming or cancelling the import.
+func ImportFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nPress Enter to upload the items, CTRL+Q to cancel.\n"))
+}
+
+// SettingsFooter returns a styled footer string with instructions for the password change form of the settings screen.
+func SettingsFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nPress Tab to switch fields, Enter to change the password. CTRL+T to manage API tokens, CTRL+Q to return.\n"))
+}
+
+// APITokensFooter returns a styled footer string with instructions for the API tokens screen.
+func APITokensFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType the name and press Enter to create a token, CTRL+O to toggle read-only, CTRL+W to change the lifetime, CTRL+T the data type and CTRL+F the folder. Use arrow keys to navigate, CTRL+D to revoke a token, CTRL+R to reload, CTRL+Q to return.\n"))
+}
+
+// ActivityFooter returns a styled footer string with instructions for the activity screen.
+func ActivityFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nUse arrow keys to navigate, CTRL+F to filter by action, CTRL+N for the next page, CTRL+R to reload, CTRL+Q to return.\n"))
+}
+
+// SharedItemsFooter returns a styled footer string with instructions for the list of the items shared with the user.
+func SharedItemsFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nUse arrow keys to navigate, Enter to view. E to edit an item shared for editing. R to reload, CTRL+Q to return.\n"))
+}
+
+// ShareFooter returns a styled footer string with instructions for the screen sharing an item.
+func ShareFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType the login and press Enter to share, CTRL+E to allow editing. Use arrow keys to select a user, CTRL+R to revoke. CTRL+Q to return.\n"))
+}
+
+// OrgsFooter returns a styled footer string with instructions for the list of the organizations of the user.
+func OrgsFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType a name and press Enter to create an organization. Use arrow keys to navigate, Enter to open, CTRL+A to accept and CTRL+X to decline an invitation, CTRL+D to delete. CTRL+Q to return.\n"))
+}
+
+// OrgFooter returns a styled footer string with instructions for the collections of an organization.
+func OrgFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType a name and press Enter to create a collection. Use arrow keys to navigate, Enter to open, CTRL+D to delete. CTRL+U for members, CTRL+R to reload, CTRL+Q to return.\n"))
+}
+
+// OrgMembersFooter returns a styled footer string with instructions for the members of an organization.
+func OrgMembersFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType the login and press Enter to invite, CTRL+E to change the role. Use arrow keys to select a member, CTRL+T to change the role, CTRL+R to remove. CTRL+Q to return.\n"))
+}
+
+// CollectionFooter returns a styled footer string with instructions for the categories of a team collection.
+func CollectionFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nUse arrow keys to navigate, Enter to select a category, CTRL+Q to return.\n"))
+}
+
+// EmergencyFooter returns a styled footer string with instructions for the emergency access screen.
+func EmergencyFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nType the login and press Enter to add a trusted contact, CTRL+W to change the waiting period. Use arrow keys to navigate, CTRL+A to approve and CTRL+X to reject a request, CTRL+D to revoke a contact. CTRL+E to request access, Enter to open a released vault, CTRL+R to reload, CTRL+Q to return.\n"))
+}
+
+// EmergencyVaultFooter returns a styled footer string with instructions for the released vault of another user.
+func EmergencyVaultFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nThe vault is read-only. Use arrow keys to navigate, Enter to view an item, CTRL+Q to return.\n"))
+}
+
+// RecoveryKitFooter returns a styled footer string with instructions for the recovery kit screen.
+func RecoveryKitFooter() string {
+	return BackgroundStyle.Render(SeparatorStyle.Render("\nPress Enter to create the recovery kit, CTRL+Q to skip.\n"))
+}
+
 // AuthFooter returns a styled footer string providing instructions for navigating and exiting the authentication screen.
 func AuthFooter() string {
 	return BackgroundStyle.Render(SeparatorStyle.Render("\nPress Tab to switch fields, Enter to submit, or CTRL+Q to exit.\n"))
@@ -0,0 +1,230 @@
+// Модуль archive реализует переносимый формат резервной копии хранилища
+package archive
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Archive format identifiers and key derivation defaults.
+const (
+	FormatName    = "gophkeeper-archive"
+	FormatVersion = 1
+
+	kdfArgon2id   = "argon2id"
+	cipherAESGCM  = "AES-256-GCM"
+	kdfTime       = 3
+	kdfMemory     = 64 * 1024
+	kdfThreads    = 4
+	kdfKeyLength  = 32
+	kdfSaltLength = 16
+
+	maxKDFTime   = 16
+	maxKDFMemory = 1024 * 1024
+
+	minPassphraseLength = 8
+)
+
+// Entry represents a single decrypted vault item together with its metadata.
+// Folder is the slash separated path of the item folder, empty for the top level.
+// Data holds the JSON payload of the item category, as shown by the item screens.
+type Entry struct {
+	Category    string          `json:"category"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Tags        []string        `json:"tags,omitempty"`
+	Folder      string          `json:"folder,omitempty"`
+	Created     string          `json:"created"`
+	Modified    string          `json:"modified"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Payload represents the plaintext content of an archive.
+type Payload struct {
+	ExportedAt time.Time `json:"exported_at"`
+	Login      string    `json:"login"`
+	Entries    []*Entry  `json:"entries"`
+}
+
+// Archive represents the versioned encrypted container written to disk.
+// The payload is sealed with AES-256-GCM under a key derived from the export passphrase.
+type Archive struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	KDF        KDF    `json:"kdf"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// KDF describes the key derivation parameters used for the archive key.
+type KDF struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+// Seal encrypts the payload under the passphrase and returns the encoded archive.
+func Seal(payload *Payload, passphrase string) ([]byte, error) {
+	if len(passphrase) < minPassphraseLength {
+		return nil, fmt.Errorf("passphrase must be at least %d characters long", minPassphraseLength)
+	}
+
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	salt := make([]byte, kdfSaltLength)
+	if _, err = io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	archive := Archive{
+		Format:  FormatName,
+		Version: FormatVersion,
+		KDF: KDF{
+			Name:    kdfArgon2id,
+			Salt:    salt,
+			Time:    kdfTime,
+			Memory:  kdfMemory,
+			Threads: kdfThreads,
+		},
+		Cipher: cipherAESGCM,
+	}
+
+	gcm, err := archive.KDF.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Nonce = make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, archive.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	archive.Ciphertext = gcm.Seal(nil, archive.Nonce, plaintext, archive.header())
+
+	res, err := json.MarshalIndent(&archive, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal archive: %w", err)
+	}
+
+	return res, nil
+}
+
+// Open decodes the archive, checks its format and version and decrypts the payload with the passphrase.
+func Open(data []byte, passphrase string) (*Payload, error) {
+	var archive Archive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archive: %w", err)
+	}
+
+	if archive.Format != FormatName {
+		return nil, fmt.Errorf("unknown archive format %q", archive.Format)
+	}
+
+	if archive.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", archive.Version)
+	}
+
+	if archive.KDF.Name != kdfArgon2id || archive.Cipher != cipherAESGCM {
+		return nil, fmt.Errorf("unsupported archive algorithms %s/%s", archive.KDF.Name, archive.Cipher)
+	}
+
+	// Ограничение параметров KDF, чтобы подмененный архив не исчерпал память клиента
+	if archive.KDF.Time == 0 || archive.KDF.Time > maxKDFTime ||
+		archive.KDF.Memory > maxKDFMemory || archive.KDF.Threads == 0 {
+		return nil, fmt.Errorf("invalid archive kdf parameters")
+	}
+
+	gcm, err := archive.KDF.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(archive.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid archive nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, archive.Nonce, archive.Ciphertext, archive.header())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt archive, wrong passphrase or corrupted file")
+	}
+
+	var payload Payload
+	if err = json.Unmarshal(plaintext, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	return &payload, nil
+}
+
+// WriteJSON writes the payload as indented plaintext JSON.
+func WriteJSON(w io.Writer, payload *Payload) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(payload); err != nil {
+		return fmt.Errorf("failed to encode json: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the payload entries as plaintext CSV, one item per row with its data as a JSON column.
+// The tags are joined with commas.
+func WriteCSV(w io.Writer, payload *Payload) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"category", "title", "description", "tags", "folder", "created", "modified", "data"}); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, v := range payload.Entries {
+		if err := writer.Write([]string{v.Category, v.Title, v.Description, strings.Join(v.Tags, ","), v.Folder,
+			v.Created, v.Modified, string(v.Data)}); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// header returns the archive parameters authenticated together with the ciphertext, so they can not be altered.
+func (a *Archive) header() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s|%d|%s|%x|%d|%d|%d|%s", a.Format, a.Version, a.KDF.Name, a.KDF.Salt, a.KDF.Time, a.KDF.Memory, a.KDF.Threads, a.Cipher)
+
+	return buf.Bytes()
+}
+
+// cipher derives the archive key from the passphrase and returns the AES-GCM instance for it.
+func (k KDF) cipher(passphrase string) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), k.Salt, k.Time, k.Memory, k.Threads, kdfKeyLength)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize gcm: %w", err)
+	}
+
+	return gcm, nil
+}
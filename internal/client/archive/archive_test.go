@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPayload() *Payload {
+	return &Payload{
+		ExportedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Login:      "user",
+		Entries: []*Entry{
+			{
+				Category:    "Creds",
+				Title:       "Mail",
+				Description: "https://mail.com",
+				Tags:        []string{"work", "mail"},
+				Folder:      "Work/Accounts",
+				Created:     "2025-01-01T00:00:00Z",
+				Modified:    "2025-01-01T00:00:00Z",
+				Data:        json.RawMessage(`{"login":"bob","password":"secret"}`),
+			},
+		},
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+		open       string
+		tamper     func(*Archive)
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "round trip",
+			passphrase: "correct horse",
+			open:       "correct horse",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "wrong passphrase",
+			passphrase: "correct horse",
+			open:       "battery staple",
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "tampered kdf parameters",
+			passphrase: "correct horse",
+			open:       "correct horse",
+			tamper:     func(a *Archive) { a.KDF.Time = 4 },
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "unsupported version",
+			passphrase: "correct horse",
+			open:       "correct horse",
+			tamper:     func(a *Archive) { a.Version = 99 },
+			wantErr:    assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := Seal(testPayload(), tt.passphrase)
+			require.NoError(t, err)
+			assert.NotContains(t, string(sealed), "secret")
+
+			if tt.tamper != nil {
+				var a Archive
+				require.NoError(t, json.Unmarshal(sealed, &a))
+				tt.tamper(&a)
+				sealed, err = json.Marshal(&a)
+				require.NoError(t, err)
+			}
+
+			payload, err := Open(sealed, tt.open)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, testPayload(), payload)
+			}
+		})
+	}
+}
+
+func TestSeal_ShortPassphrase(t *testing.T) {
+	_, err := Seal(testPayload(), "short")
+	assert.Error(t, err)
+}
+
+func TestWritePlaintext(t *testing.T) {
+	var jsonBuf bytes.Buffer
+	require.NoError(t, WriteJSON(&jsonBuf, testPayload()))
+	assert.Contains(t, jsonBuf.String(), `"password": "secret"`)
+
+	var csvBuf bytes.Buffer
+	require.NoError(t, WriteCSV(&csvBuf, testPayload()))
+	lines := strings.Split(strings.TrimSpace(csvBuf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "category,title,description,tags,folder,created,modified,data", lines[0])
+	assert.Contains(t, lines[1], `Mail,https://mail.com,"work,mail",Work/Accounts,`)
+}
@@ -6,17 +6,36 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+
+	"github.com/mikhaylov123ty/GophKeeper/pkg/tracing"
 )
 
 var cfg *ClientConfig
 
+// defaultServerName is the name the server certificate is verified for unless configured.
+const defaultServerName = "localhost"
+
 // ClientConfig - структура конфигурации агента
 type ClientConfig struct {
 	Address      *Address `json:"address"`
 	ConfigFile   string
 	Keys         *Keys
 	OutputFolder string
+
+	// ServerName is the name the server certificate is verified for, also sent as the SNI.
+	ServerName string
+
+	// EncryptMeta enables the client-side encryption of the item titles, descriptions and tags with the vault key.
+	EncryptMeta bool
+
+	// Tracing configures the export of the spans of the requests, the trace context is sent to the server with them.
+	Tracing Tracing
+
+	// Command and CommandArgs hold the optional non-interactive command given after the flags.
+	Command     string
+	CommandArgs []string
 }
 
 // Address represents a network location with a host and a gRPC port.
@@ -27,8 +46,21 @@ type Address struct {
 	GRPCPort string `json:"grpc_port"`
 }
 
+// Keys represents the TLS files of the client: the certificate the server is verified with and the device
+// certificate with its private key, presented to the server with the mutual TLS once the device is enrolled.
 type Keys struct {
 	PublicCert string `json:"public_cert"`
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"client_key"`
+}
+
+// Tracing represents the export of the OpenTelemetry spans of the requests to the server.
+// Exporter is "otlp" or empty to disable the tracing, the stdout exporter is not available as it would draw over
+// the terminal UI. Endpoint is the "host:port" of the OTLP gRPC collector, Insecure sends the spans without TLS.
+type Tracing struct {
+	Exporter string `json:"exporter"`
+	Endpoint string `json:"endpoint"`
+	Insecure bool   `json:"insecure"`
 }
 
 // New initializes a new instance of ClientConfig, parsing flags, environment variables, and potentially a config file.
@@ -54,6 +86,10 @@ func New() (*ClientConfig, error) {
 		return nil, fmt.Errorf("error parsing environment variables: %w", err)
 	}
 
+	if config.ServerName == "" {
+		config.ServerName = defaultServerName
+	}
+
 	if err = config.Validate(); err != nil {
 		return nil, fmt.Errorf("error validating config: %w", err)
 	}
@@ -71,16 +107,32 @@ func (a *ClientConfig) parseFlags() {
 
 	// Флаги подписи
 	flag.StringVar(&a.Keys.PublicCert, "certificate", "", "TLS public cert file")
+	flag.StringVar(&a.Keys.ClientCert, "client-cert", "", "Device certificate file for mutual TLS")
+	flag.StringVar(&a.Keys.ClientKey, "client-key", "", "Device private key file for mutual TLS")
+	flag.StringVar(&a.ServerName, "server-name", "", "Server name the certificate is verified for. Example: \"keeper.example.com\"")
 
 	// Флаг файла конфигурации
 	flag.StringVar(&a.ConfigFile, "config", "", "Config file")
 
 	flag.StringVar(&a.OutputFolder, "files-output", "", "Output folder for downloaded files.")
 
+	flag.BoolVar(&a.EncryptMeta, "encrypt-meta", false, "Encrypt item titles, descriptions and tags with the vault key")
+
+	// Флаги трассировки
+	flag.StringVar(&a.Tracing.Exporter, "tracing-exporter", "", "Exporter of the traces: \"otlp\", disabled when empty")
+	flag.StringVar(&a.Tracing.Endpoint, "tracing-endpoint", "", "OTLP gRPC collector endpoint. Example: \"localhost:4317\"")
+	flag.BoolVar(&a.Tracing.Insecure, "tracing-insecure", false, "Send the traces to the OTLP collector without TLS")
+
 	_ = flag.Value(a.Address)
 	flag.Var(a.Address, "a", "Host and port on which to listen gRPC requests. Example: \"localhost:443\" or \":443\"")
 
 	flag.Parse()
+
+	// Команда и ее аргументы после флагов
+	if args := flag.Args(); len(args) > 0 {
+		a.Command = args[0]
+		a.CommandArgs = args[1:]
+	}
 }
 
 // ParseEnv reads configuration values from environment variables and updates the ClientConfig instance accordingly.
@@ -95,6 +147,18 @@ func (a *ClientConfig) ParseEnv() error {
 		a.Keys.PublicCert = publicCert
 	}
 
+	if clientCert := os.Getenv("CLIENT_CERT"); clientCert != "" {
+		a.Keys.ClientCert = clientCert
+	}
+
+	if clientKey := os.Getenv("CLIENT_KEY"); clientKey != "" {
+		a.Keys.ClientKey = clientKey
+	}
+
+	if serverName := os.Getenv("SERVER_NAME"); serverName != "" {
+		a.ServerName = serverName
+	}
+
 	if config := os.Getenv("CONFIG"); config != "" {
 		a.ConfigFile = config
 	}
@@ -107,6 +171,30 @@ func (a *ClientConfig) ParseEnv() error {
 		a.OutputFolder = outputFolder
 	}
 
+	if encryptMeta := os.Getenv("ENCRYPT_META"); encryptMeta != "" {
+		value, err := strconv.ParseBool(encryptMeta)
+		if err != nil {
+			return fmt.Errorf("error parsing ENCRYPT_META: %w", err)
+		}
+		a.EncryptMeta = value
+	}
+
+	if tracingExporter := os.Getenv("TRACING_EXPORTER"); tracingExporter != "" {
+		a.Tracing.Exporter = tracingExporter
+	}
+
+	if tracingEndpoint := os.Getenv("TRACING_ENDPOINT"); tracingEndpoint != "" {
+		a.Tracing.Endpoint = tracingEndpoint
+	}
+
+	if tracingInsecure := os.Getenv("TRACING_INSECURE"); tracingInsecure != "" {
+		value, err := strconv.ParseBool(tracingInsecure)
+		if err != nil {
+			return fmt.Errorf("error parsing TRACING_INSECURE: %w", err)
+		}
+		a.Tracing.Insecure = value
+	}
+
 	return nil
 }
 
@@ -132,7 +220,12 @@ func (a *ClientConfig) UnmarshalJSON(b []byte) error {
 	var cfgFile struct {
 		Address      *Address `json:"address"`
 		PublicCert   string   `json:"public_cert"`
+		ClientCert   string   `json:"client_cert"`
+		ClientKey    string   `json:"client_key"`
+		ServerName   string   `json:"server_name"`
 		OutputFolder string   `json:"files_output_folder"`
+		EncryptMeta  bool     `json:"encrypt_meta"`
+		Tracing      *Tracing `json:"tracing"`
 	}
 
 	if err = json.Unmarshal(b, &cfgFile); err != nil {
@@ -150,10 +243,38 @@ func (a *ClientConfig) UnmarshalJSON(b []byte) error {
 		a.Keys.PublicCert = cfgFile.PublicCert
 	}
 
+	if a.Keys.ClientCert == "" && cfgFile.ClientCert != "" {
+		a.Keys.ClientCert = cfgFile.ClientCert
+	}
+
+	if a.Keys.ClientKey == "" && cfgFile.ClientKey != "" {
+		a.Keys.ClientKey = cfgFile.ClientKey
+	}
+
+	if a.ServerName == "" && cfgFile.ServerName != "" {
+		a.ServerName = cfgFile.ServerName
+	}
+
 	if a.OutputFolder == "" && cfgFile.OutputFolder != "" {
 		a.OutputFolder = cfgFile.OutputFolder
 	}
 
+	if !a.EncryptMeta && cfgFile.EncryptMeta {
+		a.EncryptMeta = true
+	}
+
+	if cfgFile.Tracing != nil {
+		if a.Tracing.Exporter == "" && cfgFile.Tracing.Exporter != "" {
+			a.Tracing.Exporter = cfgFile.Tracing.Exporter
+		}
+		if a.Tracing.Endpoint == "" && cfgFile.Tracing.Endpoint != "" {
+			a.Tracing.Endpoint = cfgFile.Tracing.Endpoint
+		}
+		if !a.Tracing.Insecure && cfgFile.Tracing.Insecure {
+			a.Tracing.Insecure = true
+		}
+	}
+
 	return nil
 }
 
@@ -162,6 +283,16 @@ func (a *ClientConfig) Validate() error {
 		return fmt.Errorf("certificate is required")
 	}
 
+	// Сертификат устройства задается вместе с ключом
+	if (a.Keys.ClientCert == "") != (a.Keys.ClientKey == "") {
+		return fmt.Errorf("client certificate and key are required together")
+	}
+
+	// Спаны в stdout перемешались бы с интерфейсом
+	if a.Tracing.Exporter != tracing.ExporterNone && a.Tracing.Exporter != tracing.ExporterOTLP {
+		return fmt.Errorf("unsupported tracing exporter %q, only %q is available", a.Tracing.Exporter, tracing.ExporterOTLP)
+	}
+
 	// Check if folder exists and is persistent
 	info, err := os.Stat(a.OutputFolder)
 	if err != nil {
@@ -205,9 +336,15 @@ func GetKeys() *Keys {
 	return cfg.Keys
 }
 
+// GetServerName returns the name the server certificate is verified for.
+func GetServerName() string { return cfg.ServerName }
+
 // GetOutputFolder returns the output folder path configured in the ClientConfig.
 func GetOutputFolder() string { return cfg.OutputFolder }
 
+// GetEncryptMeta reports whether the item metadata is encrypted with the vault key before it is sent to the server.
+func GetEncryptMeta() bool { return cfg.EncryptMeta }
+
 // NewTestConfig initializes a new ClientConfig instance with default Address and Keys and returns it.
 func NewTestConfig() (*ClientConfig, error) {
 	config := &ClientConfig{
@@ -60,12 +60,20 @@ func TestParseEnv(t *testing.T) {
 	os.Setenv("CONFIG", "config.json")
 	os.Setenv("GRPC_PORT", "9999")
 	os.Setenv("OUTPUT_FOLDER", "/env/output")
+	os.Setenv("ENCRYPT_META", "true")
+	os.Setenv("CLIENT_CERT", "device.crt")
+	os.Setenv("CLIENT_KEY", "device.key")
+	os.Setenv("SERVER_NAME", "keeper.example.com")
 	defer func() {
 		os.Unsetenv("ADDRESS")
 		os.Unsetenv("PUBLIC_CERT")
 		os.Unsetenv("CONFIG")
 		os.Unsetenv("GRPC_PORT")
 		os.Unsetenv("OUTPUT_FOLDER")
+		os.Unsetenv("ENCRYPT_META")
+		os.Unsetenv("CLIENT_CERT")
+		os.Unsetenv("CLIENT_KEY")
+		os.Unsetenv("SERVER_NAME")
 	}()
 
 	cfg := &config.ClientConfig{
@@ -81,6 +89,10 @@ func TestParseEnv(t *testing.T) {
 	assert.Equal(t, "envcert.pem", cfg.Keys.PublicCert)
 	assert.Equal(t, "config.json", cfg.ConfigFile)
 	assert.Equal(t, "/env/output", cfg.OutputFolder)
+	assert.True(t, cfg.EncryptMeta)
+	assert.Equal(t, "device.crt", cfg.Keys.ClientCert)
+	assert.Equal(t, "device.key", cfg.Keys.ClientKey)
+	assert.Equal(t, "keeper.example.com", cfg.ServerName)
 }
 
 // TestInitConfigFile reads a sample config file
@@ -93,6 +105,9 @@ func TestInitConfigFile(t *testing.T) {
 	jsonContent := `{
         "address": {"host": "localhost", "grpc_port": "7777"},
         "public_cert": "certfile.pem",
+        "client_cert": "device.crt",
+        "client_key": "device.key",
+        "server_name": "keeper.example.com",
         "files_output_folder": "/tmp/output"
     }`
 
@@ -111,6 +126,9 @@ func TestInitConfigFile(t *testing.T) {
 
 	assert.Equal(t, "7777", cfg.Address.GRPCPort)
 	assert.Equal(t, "certfile.pem", cfg.Keys.PublicCert)
+	assert.Equal(t, "device.crt", cfg.Keys.ClientCert)
+	assert.Equal(t, "device.key", cfg.Keys.ClientKey)
+	assert.Equal(t, "keeper.example.com", cfg.ServerName)
 	assert.Equal(t, "/tmp/output", cfg.OutputFolder)
 }
 
@@ -133,4 +151,62 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, "8888", cfg.Address.GRPCPort)
 	assert.Equal(t, "mycert.pem", cfg.Keys.PublicCert)
 	assert.Equal(t, "./", cfg.OutputFolder)
+	assert.Equal(t, "localhost", cfg.ServerName)
+}
+
+// TestInitConfigFile_Tracing reads the tracing section of the config file
+func TestInitConfigFile_Tracing(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config*.json")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write([]byte(`{
+        "address": {"host": "localhost", "grpc_port": "7777"},
+        "public_cert": "certfile.pem",
+        "tracing": {"exporter": "otlp", "endpoint": "collector:4317", "insecure": true}
+    }`))
+	require.NoError(t, err)
+	require.NoError(t, tmpFile.Close())
+
+	cfg := &config.ClientConfig{
+		ConfigFile: tmpFile.Name(),
+		Address:    &config.Address{},
+		Keys:       &config.Keys{},
+	}
+	cfg.Tracing.Endpoint = "flag:4317"
+
+	require.NoError(t, cfg.InitConfigFile())
+	assert.Equal(t, config.Tracing{Exporter: "otlp", Endpoint: "flag:4317", Insecure: true}, cfg.Tracing)
+}
+
+// TestValidate_Tracing checks the exporters available to the client
+func TestValidate_Tracing(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{name: "disabled"},
+		{name: "otlp", exporter: "otlp"},
+		{name: "stdout", exporter: "stdout", wantErr: true},
+		{name: "unknown", exporter: "jaeger", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.ClientConfig{
+				Address:      &config.Address{},
+				Keys:         &config.Keys{PublicCert: "cert.pem"},
+				OutputFolder: t.TempDir(),
+				Tracing:      config.Tracing{Exporter: tt.exporter},
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
 }
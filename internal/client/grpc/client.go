@@ -2,9 +2,14 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc/credentials"
 
 	"google.golang.org/grpc"
@@ -24,13 +29,30 @@ type Client struct {
 // ItemDataHandler interacts with services handling item data operations.
 // MetaDataHandler interacts with services handling metadata operations.
 // AuthHandler interacts with services handling user authentication operations.
+// FolderHandler interacts with services handling folders of items.
+// VaultHandler interacts with services handling the rotation of the vault keys.
+// SharingHandler interacts with services handling the sharing of items between users.
+// OrgHandler interacts with services handling the organizations, their members and collections.
+// EmergencyHandler interacts with services handling the emergency access of the trusted contacts.
+// DeviceHandler interacts with services handling the enrolment of the devices for the mutual TLS.
+// APITokenHandler interacts with services handling the personal API tokens of the user.
+// AuditHandler interacts with services handling the audit log of the user.
 type Handlers struct {
-	ItemDataHandler pb.ItemDataHandlersClient
-	MetaDataHandler pb.MetaDataHandlersClient
-	AuthHandler     pb.UserHandlersClient
+	ItemDataHandler  pb.ItemDataHandlersClient
+	MetaDataHandler  pb.MetaDataHandlersClient
+	AuthHandler      pb.UserHandlersClient
+	FolderHandler    pb.FolderHandlersClient
+	VaultHandler     pb.VaultHandlersClient
+	SharingHandler   pb.SharingHandlersClient
+	OrgHandler       pb.OrgHandlersClient
+	EmergencyHandler pb.EmergencyHandlersClient
+	DeviceHandler    pb.DeviceHandlersClient
+	APITokenHandler  pb.APITokenHandlersClient
+	AuditHandler     pb.AuditHandlersClient
 }
 
 // New initializes and returns a new gRPC client with TLS credentials and middleware for JWT authentication.
+// The requests are traced and carry the trace context to the server.
 func New() (*Client, error) {
 	var err error
 	instance := Client{}
@@ -39,24 +61,34 @@ func New() (*Client, error) {
 		instance.withJWT,
 	}
 
-	tlsCred, err := credentials.NewClientTLSFromFile(config.GetKeys().PublicCert, "localhost")
+	tlsConfig, err := newTLSConfig(config.GetKeys(), config.GetServerName())
 	if err != nil {
 		return nil, fmt.Errorf("could not load tls cert: %s", err)
 	}
+	tlsCred := credentials.NewTLS(tlsConfig)
 
 	conn, err := grpc.NewClient(
 		config.GetAddress().String(),
 		grpc.WithTransportCredentials(tlsCred),
 		grpc.WithChainUnaryInterceptor(interceptors...),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed creating grpc client: %w", err)
 	}
 
 	instance.Handlers = &Handlers{
-		ItemDataHandler: pb.NewItemDataHandlersClient(conn),
-		MetaDataHandler: pb.NewMetaDataHandlersClient(conn),
-		AuthHandler:     pb.NewUserHandlersClient(conn),
+		ItemDataHandler:  pb.NewItemDataHandlersClient(conn),
+		MetaDataHandler:  pb.NewMetaDataHandlersClient(conn),
+		AuthHandler:      pb.NewUserHandlersClient(conn),
+		FolderHandler:    pb.NewFolderHandlersClient(conn),
+		VaultHandler:     pb.NewVaultHandlersClient(conn),
+		SharingHandler:   pb.NewSharingHandlersClient(conn),
+		OrgHandler:       pb.NewOrgHandlersClient(conn),
+		EmergencyHandler: pb.NewEmergencyHandlersClient(conn),
+		DeviceHandler:    pb.NewDeviceHandlersClient(conn),
+		APITokenHandler:  pb.NewAPITokenHandlersClient(conn),
+		AuditHandler:     pb.NewAuditHandlersClient(conn),
 	}
 
 	slog.Info("Client Created", slog.String("address", ":"+config.GetAddress().GRPCPort))
@@ -64,6 +96,47 @@ func New() (*Client, error) {
 	return &instance, nil
 }
 
+// newTLSConfig builds the TLS configuration verifying the server certificate for the server name.
+// The device certificate is presented to the server when the files are configured and the device is enrolled.
+func newTLSConfig(keys *config.Keys, serverName string) (*tls.Config, error) {
+	serverCert, err := os.ReadFile(keys.PublicCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(serverCert) {
+		return nil, fmt.Errorf("no certificates in %s", keys.PublicCert)
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    pool,
+		ServerName: serverName,
+	}
+	if keys.ClientCert != "" {
+		tlsConfig.GetClientCertificate = clientCertificate(keys.ClientCert, keys.ClientKey)
+	}
+
+	return tlsConfig, nil
+}
+
+// clientCertificate returns the callback loading the device certificate on every handshake, so the certificate
+// received by the enrolment is used by the next connections. No certificate is presented until the files exist.
+func clientCertificate(certFile string, keyFile string) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		if _, err := os.Stat(certFile); errors.Is(err, os.ErrNotExist) {
+			return &tls.Certificate{}, nil
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load device certificate: %w", err)
+		}
+
+		return &cert, nil
+	}
+}
+
 // withJWT adds a JWT token to the gRPC request context as an Authorization header if the token is set in the client.
 // It then invokes the given gRPC method using the provided invoker and options.
 func (c *Client) withJWT(ctx context.Context, method string, req any, reply any, cc *grpc.ClientConn,
@@ -0,0 +1,218 @@
+package importer
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Argon2d (RFC 9106) is the default key derivation of the KeePass databases, golang.org/x/crypto/argon2 implements
+// only Argon2i and Argon2id.
+const (
+	argon2Version    = 0x13
+	argon2dType      = 0
+	argon2SyncPoints = 4
+	argon2BlockWords = 128
+)
+
+// argon2Block is a 1 KiB block of the Argon2 memory.
+type argon2Block [argon2BlockWords]uint64
+
+// argon2dKey derives the key of keyLen bytes from the password and the salt with Argon2d of the given number
+// of passes, memory in KiB and lanes. The secret and the associated data are optional.
+func argon2dKey(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) []byte {
+	h0 := argon2InitHash(password, salt, secret, data, time, memory, threads, keyLen)
+
+	memory = memory / (argon2SyncPoints * threads) * (argon2SyncPoints * threads)
+	if memory < 2*argon2SyncPoints*threads {
+		memory = 2 * argon2SyncPoints * threads
+	}
+	laneLength := memory / threads
+	segmentLength := laneLength / argon2SyncPoints
+
+	blocks := make([]argon2Block, memory)
+	var buf [1024]byte
+	for lane := uint32(0); lane < threads; lane++ {
+		binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
+		for i := uint32(0); i < 2; i++ {
+			binary.LittleEndian.PutUint32(h0[blake2b.Size:], i)
+			argon2Hash(buf[:], h0[:])
+			for j := range blocks[lane*laneLength+i] {
+				blocks[lane*laneLength+i][j] = binary.LittleEndian.Uint64(buf[j*8:])
+			}
+		}
+	}
+
+	// Сегменты одного среза не ссылаются друг на друга, поэтому полосы обрабатываются по очереди
+	for pass := uint32(0); pass < time; pass++ {
+		for slice := uint32(0); slice < argon2SyncPoints; slice++ {
+			for lane := uint32(0); lane < threads; lane++ {
+				index := uint32(0)
+				if pass == 0 && slice == 0 {
+					index = 2
+				}
+
+				offset := lane*laneLength + slice*segmentLength + index
+				for ; index < segmentLength; index, offset = index+1, offset+1 {
+					prev := offset - 1
+					if index == 0 && slice == 0 {
+						prev += laneLength
+					}
+
+					ref := argon2RefIndex(blocks[prev][0], pass, slice, lane, index, laneLength, segmentLength, threads)
+					argon2Compress(&blocks[offset], &blocks[prev], &blocks[ref], pass > 0)
+				}
+			}
+		}
+	}
+
+	last := blocks[memory-1]
+	for lane := uint32(0); lane < threads-1; lane++ {
+		for i, v := range blocks[lane*laneLength+laneLength-1] {
+			last[i] ^= v
+		}
+	}
+	for i, v := range last {
+		binary.LittleEndian.PutUint64(buf[i*8:], v)
+	}
+
+	key := make([]byte, keyLen)
+	argon2Hash(key, buf[:])
+
+	return key
+}
+
+// argon2InitHash returns the initial hash H0 of the parameters with the room for the block and the lane numbers.
+func argon2InitHash(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) [blake2b.Size + 8]byte {
+	var h0 [blake2b.Size + 8]byte
+
+	b2, _ := blake2b.New512(nil)
+	for _, v := range []uint32{threads, keyLen, memory, time, argon2Version, argon2dType} {
+		b2.Write(binary.LittleEndian.AppendUint32(nil, v))
+	}
+	for _, v := range [][]byte{password, salt, secret, data} {
+		b2.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(v))))
+		b2.Write(v)
+	}
+	b2.Sum(h0[:0])
+
+	return h0
+}
+
+// argon2RefIndex returns the index of the reference block of the current one, chosen by the first word
+// of the previous block as Argon2d does.
+func argon2RefIndex(random uint64, pass, slice, lane, index, laneLength, segmentLength, threads uint32) uint32 {
+	refLane := uint32(random>>32) % threads
+	if pass == 0 && slice == 0 {
+		refLane = lane
+	}
+
+	var area, start uint32
+	if pass == 0 {
+		area = slice * segmentLength
+	} else {
+		area = laneLength - segmentLength
+		start = (slice + 1) % argon2SyncPoints * segmentLength
+	}
+	if refLane == lane {
+		area += index
+	}
+	if refLane == lane || index == 0 {
+		area--
+	}
+
+	x := random & 0xFFFFFFFF
+	x = x * x >> 32
+	x = uint64(area) * x >> 32
+
+	return refLane*laneLength + (start+area-1-uint32(x))%laneLength
+}
+
+// argon2Compress computes the compression function G of the previous and the reference blocks into the block,
+// XORing it with the old content of the block on the passes after the first one.
+func argon2Compress(out, prev, ref *argon2Block, xor bool) {
+	var r, z argon2Block
+	for i := range r {
+		r[i] = prev[i] ^ ref[i]
+	}
+	z = r
+
+	for i := 0; i < argon2BlockWords; i += 16 {
+		blamka(&z, i, i+1, i+2, i+3, i+4, i+5, i+6, i+7, i+8, i+9, i+10, i+11, i+12, i+13, i+14, i+15)
+	}
+	for i := 0; i < 16; i += 2 {
+		blamka(&z, i, i+1, i+16, i+17, i+32, i+33, i+48, i+49, i+64, i+65, i+80, i+81, i+96, i+97, i+112, i+113)
+	}
+
+	for i := range z {
+		if xor {
+			out[i] ^= z[i] ^ r[i]
+		} else {
+			out[i] = z[i] ^ r[i]
+		}
+	}
+}
+
+// blamka applies the permutation P to the 16 words of the block at the indexes.
+func blamka(b *argon2Block, i ...int) {
+	gb(b, i[0], i[4], i[8], i[12])
+	gb(b, i[1], i[5], i[9], i[13])
+	gb(b, i[2], i[6], i[10], i[14])
+	gb(b, i[3], i[7], i[11], i[15])
+	gb(b, i[0], i[5], i[10], i[15])
+	gb(b, i[1], i[6], i[11], i[12])
+	gb(b, i[2], i[7], i[8], i[13])
+	gb(b, i[3], i[4], i[9], i[14])
+}
+
+// gb is the BLAKE2b round function with the multiplications added by Argon2.
+func gb(v *argon2Block, a, b, c, d int) {
+	v[a] += v[b] + 2*uint64(uint32(v[a]))*uint64(uint32(v[b]))
+	v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+	v[c] += v[d] + 2*uint64(uint32(v[c]))*uint64(uint32(v[d]))
+	v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+	v[a] += v[b] + 2*uint64(uint32(v[a]))*uint64(uint32(v[b]))
+	v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+	v[c] += v[d] + 2*uint64(uint32(v[c]))*uint64(uint32(v[d]))
+	v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+}
+
+// argon2Hash computes the variable length hash H' of the input into the output.
+func argon2Hash(out []byte, in []byte) {
+	newHash := func(size int) hash.Hash {
+		h, _ := blake2b.New(size, nil)
+		return h
+	}
+
+	outLen := len(out)
+	prefix := binary.LittleEndian.AppendUint32(nil, uint32(outLen))
+	if outLen <= blake2b.Size {
+		h := newHash(outLen)
+		h.Write(prefix)
+		h.Write(in)
+		h.Sum(out[:0])
+		return
+	}
+
+	h := newHash(blake2b.Size)
+	h.Write(prefix)
+	h.Write(in)
+	v := h.Sum(nil)
+
+	pos := 0
+	for outLen-pos > blake2b.Size {
+		copy(out[pos:], v[:blake2b.Size/2])
+		pos += blake2b.Size / 2
+
+		size := blake2b.Size
+		if outLen-pos <= blake2b.Size {
+			size = outLen - pos
+		}
+		h = newHash(size)
+		h.Write(v)
+		v = h.Sum(nil)
+	}
+	copy(out[pos:], v)
+}
@@ -0,0 +1,17 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgon2dKey(t *testing.T) {
+	// Тестовый вектор Argon2d из RFC 9106, раздел 5.1
+	key := argon2dKey(bytes.Repeat([]byte{0x01}, 32), bytes.Repeat([]byte{0x02}, 16), bytes.Repeat([]byte{0x03}, 8),
+		bytes.Repeat([]byte{0x04}, 12), 3, 32, 4, 32)
+
+	assert.Equal(t, "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb", hex.EncodeToString(key))
+}
@@ -0,0 +1,101 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Bitwarden item types of the unencrypted JSON export.
+const (
+	bitwardenLogin = 1
+	bitwardenNote  = 2
+	bitwardenCard  = 3
+)
+
+// bitwardenExport represents the unencrypted Bitwarden JSON export.
+type bitwardenExport struct {
+	Encrypted bool             `json:"encrypted"`
+	Items     []*bitwardenItem `json:"items"`
+}
+
+// bitwardenItem represents a single vault item of the Bitwarden export.
+type bitwardenItem struct {
+	Type  int    `json:"type"`
+	Name  string `json:"name"`
+	Notes string `json:"notes"`
+	Login *struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		URIs     []struct {
+			URI string `json:"uri"`
+		} `json:"uris"`
+	} `json:"login"`
+	Card *struct {
+		Number   string `json:"number"`
+		ExpMonth string `json:"expMonth"`
+		ExpYear  string `json:"expYear"`
+		Code     string `json:"code"`
+	} `json:"card"`
+}
+
+// bitwardenParser reads the Bitwarden JSON export.
+type bitwardenParser struct{}
+
+// Parse maps Bitwarden logins onto credentials, secure notes onto text notes and cards onto bank cards.
+// Identities are not supported and are counted as skipped.
+func (p bitwardenParser) Parse(r io.Reader) (*Batch, error) {
+	var export bitwardenExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("failed to decode json: %w", err)
+	}
+
+	if export.Encrypted {
+		return nil, fmt.Errorf("encrypted exports are not supported, export the vault as unencrypted json")
+	}
+
+	batch := &Batch{}
+	for _, v := range export.Items {
+		var item *Item
+		var err error
+		switch {
+		case v.Type == bitwardenLogin && v.Login != nil:
+			var url string
+			if len(v.Login.URIs) > 0 {
+				url = v.Login.URIs[0].URI
+			}
+			item, err = newCredsItem(v.Name, url, v.Login.Username, v.Login.Password, v.Notes, "Bitwarden")
+		case v.Type == bitwardenNote:
+			item, err = newTextItem(v.Name, v.Notes, "Bitwarden")
+		case v.Type == bitwardenCard && v.Card != nil:
+			item, err = newCardItem(v.Name, v.Card.Number, cardExpiry(v.Card.ExpMonth, v.Card.ExpYear), v.Card.Code, v.Notes, "Bitwarden")
+		default:
+			batch.Skipped++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		batch.Items = append(batch.Items, item)
+	}
+
+	return batch, nil
+}
+
+// cardExpiry formats the card expiry as MM/YY.
+func cardExpiry(month string, year string) string {
+	if month == "" && year == "" {
+		return ""
+	}
+
+	if len(month) == 1 {
+		month = "0" + month
+	}
+	if len(year) == 4 {
+		year = year[2:]
+	}
+
+	return strings.Join([]string{month, year}, "/")
+}
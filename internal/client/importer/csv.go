@@ -0,0 +1,190 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	lastPassNoteURL = "http://sn"
+)
+
+// csvRecords reads a CSV export with a header line and returns its rows as maps keyed by the lower-cased column names.
+func csvRecords(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("empty csv file")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+
+	var res []map[string]string
+	for {
+		row, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read csv row: %w", err)
+		}
+
+		record := make(map[string]string, len(header))
+		for i, v := range row {
+			if i < len(header) {
+				record[header[i]] = v
+			}
+		}
+		res = append(res, record)
+	}
+
+	return res, nil
+}
+
+// field returns the first non-blank value of the record among the given column aliases. Values are not trimmed,
+// as leading and trailing spaces may be a part of a password.
+func field(record map[string]string, aliases ...string) string {
+	for _, alias := range aliases {
+		if v := record[alias]; strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// onePasswordParser reads the 1Password CSV export.
+type onePasswordParser struct{}
+
+// Parse maps 1Password CSV rows onto credentials, or onto text notes for rows without login and password.
+func (p onePasswordParser) Parse(r io.Reader) (*Batch, error) {
+	records, err := csvRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{}
+	for _, record := range records {
+		title := field(record, "title", "name")
+		url := field(record, "url", "website", "urls")
+		login := field(record, "username", "login")
+		password := field(record, "password")
+		notes := field(record, "notes", "notesplain")
+
+		var item *Item
+		switch {
+		case login != "" || password != "":
+			item, err = newCredsItem(title, url, login, password, notes, "1Password")
+		case title != "" && notes != "":
+			item, err = newTextItem(title, notes, "1Password")
+		default:
+			batch.Skipped++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		batch.Items = append(batch.Items, item)
+	}
+
+	return batch, nil
+}
+
+// lastPassParser reads the LastPass CSV export.
+type lastPassParser struct{}
+
+// Parse maps LastPass CSV rows onto credentials, secure notes onto text notes and credit card notes onto cards.
+func (p lastPassParser) Parse(r io.Reader) (*Batch, error) {
+	records, err := csvRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{}
+	for _, record := range records {
+		title := field(record, "name")
+		url := field(record, "url")
+		extra := field(record, "extra")
+
+		var item *Item
+		switch {
+		case url == lastPassNoteURL && strings.HasPrefix(extra, "NoteType:Credit Card"):
+			fields := lastPassNoteFields(extra)
+			item, err = newCardItem(title, fields["number"], fields["expiration date"], fields["security code"], fields["notes"], "LastPass")
+		case url == lastPassNoteURL:
+			if extra == "" {
+				batch.Skipped++
+				continue
+			}
+			item, err = newTextItem(title, extra, "LastPass")
+		case field(record, "username") != "" || field(record, "password") != "":
+			item, err = newCredsItem(title, url, field(record, "username"), field(record, "password"), extra, "LastPass")
+		default:
+			batch.Skipped++
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		batch.Items = append(batch.Items, item)
+	}
+
+	return batch, nil
+}
+
+// lastPassNoteFields parses the "Key:Value" lines of a LastPass structured secure note.
+func lastPassNoteFields(extra string) map[string]string {
+	res := map[string]string{}
+	for _, line := range strings.Split(extra, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok {
+			res[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+		}
+	}
+
+	return res
+}
+
+// browserParser reads the Chrome and Firefox password CSV exports, which only contain credentials.
+type browserParser struct{}
+
+// Parse maps browser CSV rows onto credentials, using the host of the URL as a title when the export has no names.
+func (p browserParser) Parse(r io.Reader) (*Batch, error) {
+	records, err := csvRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &Batch{}
+	for _, record := range records {
+		url := field(record, "url", "origin_url")
+		login := field(record, "username", "username_value")
+		password := field(record, "password", "password_value")
+		if url == "" && login == "" && password == "" {
+			batch.Skipped++
+			continue
+		}
+
+		item, err := newCredsItem(field(record, "name"), url, login, password, field(record, "note"), "browser")
+		if err != nil {
+			return nil, err
+		}
+
+		batch.Items = append(batch.Items, item)
+	}
+
+	return batch, nil
+}
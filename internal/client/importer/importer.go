@@ -0,0 +1,320 @@
+// Модуль importer переносит записи из других менеджеров паролей в GophKeeper
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// Supported export formats of the other password managers.
+const (
+	FormatKeePass   = "keepass"
+	FormatBitwarden = "bitwarden"
+	Format1Password = "1password"
+	FormatLastPass  = "lastpass"
+	FormatChrome    = "chrome"
+	FormatFirefox   = "firefox"
+)
+
+const (
+	descriptionLimit = 60
+)
+
+// Item represents a single entry parsed from an export file and mapped onto one of the GophKeeper categories.
+// Data holds the JSON encoded category payload, exactly as the add item screens would post it.
+type Item struct {
+	Category    string
+	Title       string
+	Description string
+	URL         string
+	Tags        []string
+	Folder      string
+	Data        []byte
+}
+
+// Batch holds the items parsed from an export file and the number of entries which could not be mapped.
+type Batch struct {
+	Items   []*Item
+	Skipped int
+}
+
+// Parser defines the contract for reading a password manager export into a Batch of items.
+type Parser interface {
+	Parse(io.Reader) (*Batch, error)
+}
+
+// passwordParser is implemented by the parsers of the formats which can be encrypted with a master password.
+type passwordParser interface {
+	ParsePassword(io.Reader, string) (*Batch, error)
+}
+
+// parsers maps the supported format names onto their parser implementations.
+var parsers = map[string]Parser{
+	FormatKeePass:   keePassParser{},
+	FormatBitwarden: bitwardenParser{},
+	Format1Password: onePasswordParser{},
+	FormatLastPass:  lastPassParser{},
+	FormatChrome:    browserParser{},
+	FormatFirefox:   browserParser{},
+}
+
+// Formats returns the sorted list of supported export format names.
+func Formats() []string {
+	res := make([]string, 0, len(parsers))
+	for k := range parsers {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// Parse reads the export file from the reader with the parser registered for the given format.
+// The password opens the encrypted files of the formats supporting them, such as the KeePass KDBX database,
+// and is ignored otherwise.
+func Parse(format string, r io.Reader, password string) (*Batch, error) {
+	parser, ok := parsers[strings.ToLower(strings.TrimSpace(format))]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q, expected one of: %s", format, strings.Join(Formats(), ", "))
+	}
+
+	var batch *Batch
+	var err error
+	if protected, ok := parser.(passwordParser); ok {
+		batch, err = protected.ParsePassword(r, password)
+	} else {
+		batch, err = parser.Parse(r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s export: %w", format, err)
+	}
+
+	return batch, nil
+}
+
+// metaProvider defines the contract for reading the already stored metadata items of a category.
+type metaProvider interface {
+	GetMetaData(string) []*models.MetaItem
+}
+
+// itemPoster defines the contract for uploading item data through the existing item RPCs and caching its metadata.
+// FolderByPath returns the folder of the item by its path, creating the missing folders.
+type itemPoster interface {
+	PostItemData([]byte, string, *pb.MetaData) (*pb.PostItemDataResponse, error)
+	SaveMetaItem(string, *models.MetaItem)
+	FolderByPath(string) (string, error)
+}
+
+// Summary is the dry-run result of an import: items to upload, detected duplicates and per-category counters.
+type Summary struct {
+	Items      []*Item
+	Duplicates []*Item
+	ByCategory map[string]int
+	Skipped    int
+}
+
+// Plan checks the parsed batch against the stored items and against itself, marking entries with the same title
+// or URL in the same category as duplicates. Nothing is uploaded, so the result can be shown as a dry run.
+func Plan(batch *Batch, provider metaProvider) *Summary {
+	summary := &Summary{
+		ByCategory: map[string]int{},
+		Skipped:    batch.Skipped,
+	}
+
+	seen := map[string]bool{}
+	for _, category := range []string{models.TextCategory, models.CredsCategory, models.FileCategory, models.CardCategory} {
+		for _, v := range provider.GetMetaData(category) {
+			seen[duplicateKey(category, "title", v.Title)] = true
+			seen[duplicateKey(category, "url", v.Description)] = true
+		}
+	}
+
+	for _, item := range batch.Items {
+		titleKey := duplicateKey(item.Category, "title", item.Title)
+		urlKey := duplicateKey(item.Category, "url", item.URL)
+		if seen[titleKey] || (item.URL != "" && seen[urlKey]) {
+			summary.Duplicates = append(summary.Duplicates, item)
+			continue
+		}
+
+		seen[titleKey] = true
+		if item.URL != "" {
+			seen[urlKey] = true
+		}
+
+		summary.Items = append(summary.Items, item)
+		summary.ByCategory[item.Category]++
+	}
+
+	return summary
+}
+
+// Upload posts every non-duplicate item of the summary through the items RPCs and returns the number of uploaded items.
+// The upload stops on the first failure, so already uploaded items are kept and reported by the counter.
+func Upload(summary *Summary, poster itemPoster) (int, error) {
+	for i, item := range summary.Items {
+		folderID, err := poster.FolderByPath(item.Folder)
+		if err != nil {
+			return i, fmt.Errorf("failed to get folder of %q: %w", item.Title, err)
+		}
+
+		metaItem := &models.MetaItem{
+			ID:          uuid.New(),
+			Category:    item.Category,
+			Title:       item.Title,
+			Description: item.Description,
+			Tags:        item.Tags,
+			FolderID:    folderID,
+		}
+
+		resp, err := poster.PostItemData(item.Data, "", &pb.MetaData{
+			Id:          metaItem.ID.String(),
+			Title:       metaItem.Title,
+			Description: metaItem.Description,
+			DataType:    item.Category,
+			Tags:        metaItem.Tags,
+			FolderId:    metaItem.FolderID,
+		})
+		if err != nil {
+			return i, fmt.Errorf("failed to upload %q: %w", item.Title, err)
+		}
+
+		metaItem.DataID = resp.GetDataId()
+		metaItem.Created = resp.GetCreated()
+		metaItem.Modified = resp.GetModified()
+		poster.SaveMetaItem(item.Category, metaItem)
+	}
+
+	return len(summary.Items), nil
+}
+
+// String renders the summary as a short human-readable report.
+func (s *Summary) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Items to import: %d\n", len(s.Items)))
+	for _, category := range []string{models.CredsCategory, models.TextCategory, models.CardCategory, models.FileCategory} {
+		sb.WriteString(fmt.Sprintf("  %s: %d\n", category, s.ByCategory[category]))
+	}
+	sb.WriteString(fmt.Sprintf("Duplicates skipped: %d\n", len(s.Duplicates)))
+	sb.WriteString(fmt.Sprintf("Unsupported entries: %d\n", s.Skipped))
+
+	return sb.String()
+}
+
+// duplicateKey builds the case-insensitive lookup key used for duplicate detection.
+func duplicateKey(category string, kind string, value string) string {
+	return category + "|" + kind + "|" + strings.ToLower(strings.TrimSpace(value))
+}
+
+// newCredsItem builds a credentials item, using the URL as description when present.
+func newCredsItem(title string, url string, login string, password string, notes string, source string) (*Item, error) {
+	data, err := json.Marshal(&models.CredsData{
+		Login:    login,
+		Password: password,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal creds data: %w", err)
+	}
+
+	if title == "" {
+		title = hostOf(url)
+	}
+
+	return &Item{
+		Category:    models.CredsCategory,
+		Title:       title,
+		Description: describe(url, notes, source),
+		URL:         url,
+		Data:        data,
+	}, nil
+}
+
+// newTextItem builds a text note item.
+func newTextItem(title string, text string, source string) (*Item, error) {
+	data, err := json.Marshal(&models.TextData{Text: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal text data: %w", err)
+	}
+
+	return &Item{
+		Category:    models.TextCategory,
+		Title:       title,
+		Description: describe("", text, source),
+		Data:        data,
+	}, nil
+}
+
+// newCardItem builds a bank card item.
+func newCardItem(title string, number string, expiry string, cvv string, notes string, source string) (*Item, error) {
+	data, err := json.Marshal(&models.BankCardData{
+		CardNum: number,
+		Expiry:  expiry,
+		CVV:     cvv,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal card data: %w", err)
+	}
+
+	return &Item{
+		Category:    models.CardCategory,
+		Title:       title,
+		Description: describe("", notes, source),
+		Data:        data,
+	}, nil
+}
+
+// newFileItem builds a file item from an attachment.
+func newFileItem(title string, name string, content []byte, source string) (*Item, error) {
+	data, err := json.Marshal(&models.BinaryData{
+		Name:     name,
+		Content:  content,
+		FileSize: float64(len(content)) / (1 << 20),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal file data: %w", err)
+	}
+
+	return &Item{
+		Category:    models.FileCategory,
+		Title:       title,
+		Description: describe("", name, source),
+		Data:        data,
+	}, nil
+}
+
+// describe picks the item description: the URL, the first line of the notes or the source name, as the add
+// item screens never post an empty description.
+func describe(url string, notes string, source string) string {
+	if url != "" {
+		return url
+	}
+
+	if line, _, _ := strings.Cut(strings.TrimSpace(notes), "\n"); line != "" {
+		// Обрезается по символам, чтобы не разрезать многобайтовый символ UTF-8
+		if runes := []rune(line); len(runes) > descriptionLimit {
+			line = string(runes[:descriptionLimit])
+		}
+		return line
+	}
+
+	return "Imported from " + source
+}
+
+// hostOf returns the host part of the URL to be used as a title for entries without a name.
+func hostOf(url string) string {
+	host := url
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+
+	return host
+}
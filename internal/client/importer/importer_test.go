@@ -0,0 +1,217 @@
+package importer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+type mockStore struct {
+	meta    map[string][]*models.MetaItem
+	posted  []*pb.MetaData
+	folders []string
+}
+
+func (m *mockStore) GetMetaData(category string) []*models.MetaItem {
+	return m.meta[category]
+}
+
+func (m *mockStore) PostItemData(_ []byte, _ string, meta *pb.MetaData) (*pb.PostItemDataResponse, error) {
+	m.posted = append(m.posted, meta)
+	return &pb.PostItemDataResponse{DataId: "data", Created: "now", Modified: "now"}, nil
+}
+
+func (m *mockStore) SaveMetaItem(category string, item *models.MetaItem) {
+	m.meta[category] = append(m.meta[category], item)
+}
+
+func (m *mockStore) FolderByPath(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	m.folders = append(m.folders, path)
+	return "folder " + path, nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         string
+		input          string
+		wantCategories []string
+		wantTitles     []string
+		wantSkipped    int
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "bitwarden json",
+			format: FormatBitwarden,
+			input: `{"encrypted":false,"items":[
+				{"type":1,"name":"GitHub","login":{"username":"bob","password":"secret","uris":[{"uri":"https://github.com"}]}},
+				{"type":2,"name":"Note","notes":"some text"},
+				{"type":3,"name":"Visa","card":{"number":"4111","expMonth":"7","expYear":"2029","code":"123"}},
+				{"type":4,"name":"Identity"}]}`,
+			wantCategories: []string{models.CredsCategory, models.TextCategory, models.CardCategory},
+			wantTitles:     []string{"GitHub", "Note", "Visa"},
+			wantSkipped:    1,
+			wantErr:        assert.NoError,
+		},
+		{
+			name:    "encrypted bitwarden json",
+			format:  FormatBitwarden,
+			input:   `{"encrypted":true,"items":[]}`,
+			wantErr: assert.Error,
+		},
+		{
+			name:   "1password csv",
+			format: Format1Password,
+			input: "Title,Url,Username,Password,Notes\n" +
+				"Mail,https://mail.com,alice,pass,\n" +
+				"Wifi,,,,router password on the back\n",
+			wantCategories: []string{models.CredsCategory, models.TextCategory},
+			wantTitles:     []string{"Mail", "Wifi"},
+			wantErr:        assert.NoError,
+		},
+		{
+			name:   "lastpass csv",
+			format: FormatLastPass,
+			input: "url,username,password,totp,extra,name,grouping,fav\n" +
+				"https://site.com,carl,pwd,,,Site,,0\n" +
+				"http://sn,,,,\"NoteType:Credit Card\nNumber:5555\nExpiration Date:01/30\nSecurity Code:999\",Card,,0\n" +
+				"http://sn,,,,plain note,Note,,0\n",
+			wantCategories: []string{models.CredsCategory, models.CardCategory, models.TextCategory},
+			wantTitles:     []string{"Site", "Card", "Note"},
+			wantErr:        assert.NoError,
+		},
+		{
+			name:   "firefox csv without names",
+			format: FormatFirefox,
+			input: "\"url\",\"username\",\"password\",\"httpRealm\"\n" +
+				"\"https://example.org/login\",\"dan\",\"pw\",\"\"\n",
+			wantCategories: []string{models.CredsCategory},
+			wantTitles:     []string{"example.org"},
+			wantErr:        assert.NoError,
+		},
+		{
+			name:   "keepass xml",
+			format: FormatKeePass,
+			input: `<KeePassFile><Meta><Binaries><Binary ID="0">aGVsbG8=</Binary></Binaries></Meta><Root><Group><Name>Root</Name>
+				<Entry><String><Key>Title</Key><Value>Server</Value></String><String><Key>UserName</Key><Value>root</Value></String>
+				<String><Key>Password</Key><Value>toor</Value></String><Binary><Key>id_rsa</Key><Value Ref="0"/></Binary></Entry>
+				<Group><Name>Recycle Bin</Name><Entry><String><Key>Title</Key><Value>Old</Value></String><String><Key>Password</Key><Value>x</Value></String></Entry></Group>
+				</Group></Root></KeePassFile>`,
+			wantCategories: []string{models.CredsCategory, models.FileCategory},
+			wantTitles:     []string{"Server", "Server id_rsa"},
+			wantErr:        assert.NoError,
+		},
+		{
+			name:    "keepass kdbx truncated",
+			format:  FormatKeePass,
+			input:   string([]byte{0x03, 0xD9, 0xA2, 0x9A, 0x00}),
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown format",
+			format:  "unknown",
+			input:   "",
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, err := Parse(tt.format, strings.NewReader(tt.input), "")
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+
+			var categories, titles []string
+			for _, v := range batch.Items {
+				categories = append(categories, v.Category)
+				titles = append(titles, v.Title)
+				assert.NotEmpty(t, v.Description)
+			}
+
+			assert.Equal(t, tt.wantCategories, categories)
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, tt.wantSkipped, batch.Skipped)
+		})
+	}
+}
+
+func TestParse_CredsPayload(t *testing.T) {
+	batch, err := Parse(FormatChrome, strings.NewReader("name,url,username,password\nSite,https://site.com,eve, spaced \n"), "")
+	require.NoError(t, err)
+	require.Len(t, batch.Items, 1)
+
+	var creds models.CredsData
+	require.NoError(t, json.Unmarshal(batch.Items[0].Data, &creds))
+	assert.Equal(t, "eve", creds.Login)
+	assert.Equal(t, " spaced ", creds.Password)
+	assert.Equal(t, "https://site.com", batch.Items[0].Description)
+}
+
+func TestDescribe(t *testing.T) {
+	notes := strings.Repeat("Заметка к записи ", 10) + "\nвторая строка"
+
+	description := describe("", notes, "KeePass")
+	assert.True(t, utf8.ValidString(description))
+	assert.Equal(t, descriptionLimit, utf8.RuneCountInString(description))
+	assert.True(t, strings.HasPrefix(notes, description))
+
+	_, err := proto.Marshal(&pb.MetaData{Description: description})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "https://site.com", describe("https://site.com", notes, "KeePass"))
+	assert.Equal(t, "Imported from KeePass", describe("", " ", "KeePass"))
+}
+
+func TestPlanAndUpload(t *testing.T) {
+	store := &mockStore{meta: map[string][]*models.MetaItem{
+		models.CredsCategory: {{Title: "Existing", Description: "https://existing.com"}},
+	}}
+
+	batch := &Batch{
+		Items: []*Item{
+			{Category: models.CredsCategory, Title: "existing", Description: "x"},
+			{Category: models.CredsCategory, Title: "Other", URL: "https://existing.com", Description: "https://existing.com"},
+			{Category: models.CredsCategory, Title: "New", URL: "https://new.com", Description: "https://new.com"},
+			{Category: models.CredsCategory, Title: "New copy", URL: "https://new.com", Description: "https://new.com"},
+			{Category: models.TextCategory, Title: "Existing", Description: "note", Tags: []string{"home"}, Folder: "Notes/Old"},
+		},
+		Skipped: 2,
+	}
+
+	summary := Plan(batch, store)
+	assert.Len(t, summary.Items, 2)
+	assert.Len(t, summary.Duplicates, 3)
+	assert.Equal(t, 1, summary.ByCategory[models.CredsCategory])
+	assert.Equal(t, 1, summary.ByCategory[models.TextCategory])
+	assert.Contains(t, summary.String(), "Duplicates skipped: 3")
+	assert.Empty(t, store.posted)
+
+	uploaded, err := Upload(summary, store)
+	require.NoError(t, err)
+	assert.Equal(t, 2, uploaded)
+	assert.Len(t, store.posted, 2)
+	assert.Equal(t, models.CredsCategory, store.posted[0].DataType)
+	assert.Len(t, store.meta[models.TextCategory], 1)
+	assert.Equal(t, "data", store.meta[models.TextCategory][0].DataID)
+
+	// Теги и папка сохраняются в метаданных записи
+	assert.Equal(t, []string{"Notes/Old"}, store.folders)
+	assert.Equal(t, []string{"home"}, store.posted[1].GetTags())
+	assert.Equal(t, "folder Notes/Old", store.posted[1].GetFolderId())
+	assert.Equal(t, "folder Notes/Old", store.meta[models.TextCategory][0].FolderID)
+	assert.Empty(t, store.posted[0].GetFolderId())
+}
@@ -0,0 +1,712 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/salsa20/salsa"
+	"golang.org/x/crypto/twofish"
+)
+
+// Outer header fields of the KDBX database.
+const (
+	kdbxEndOfHeader         = 0
+	kdbxCipherID            = 2
+	kdbxCompressionFlags    = 3
+	kdbxMasterSeed          = 4
+	kdbxTransformSeed       = 5
+	kdbxTransformRounds     = 6
+	kdbxEncryptionIV        = 7
+	kdbxProtectedStreamKey  = 8
+	kdbxStreamStartBytes    = 9
+	kdbxInnerRandomStreamID = 10
+	kdbxKDFParameters       = 11
+)
+
+// Inner header fields of the KDBX 4 database.
+const (
+	kdbxInnerEndOfHeader = 0
+	kdbxInnerStreamID    = 1
+	kdbxInnerStreamKey   = 2
+	kdbxInnerBinary      = 3
+)
+
+// Inner random streams protecting the values marked as protected in the XML document.
+const (
+	kdbxStreamSalsa20  = 2
+	kdbxStreamChaCha20 = 3
+)
+
+const (
+	// kdbxMaxKDFMemory limits the Argon2 memory the database can ask for, in bytes.
+	kdbxMaxKDFMemory = 4 << 30
+	// variantDictionaryVersion is the major version of the KDF parameters format.
+	variantDictionaryVersion = 0x0100
+	// salsa20BlockSize is the size of the Salsa20 key stream block.
+	salsa20BlockSize = 64
+)
+
+var (
+	// kdbxSignature2 follows kdbxSignature in the KDBX 2.x and later databases.
+	kdbxSignature2 = []byte{0x67, 0xFB, 0x4B, 0xB5}
+
+	kdbxCipherAES      = []byte{0x31, 0xC1, 0xF2, 0xE6, 0xBF, 0x71, 0x43, 0x50, 0xBE, 0x58, 0x05, 0x21, 0x6A, 0xFC, 0x5A, 0xFF}
+	kdbxCipherChaCha20 = []byte{0xD6, 0x03, 0x8A, 0x2B, 0x8B, 0x6F, 0x4C, 0xB5, 0xA5, 0x24, 0x33, 0x9A, 0x31, 0xDB, 0xB5, 0x9A}
+	kdbxCipherTwofish  = []byte{0xAD, 0x68, 0xF2, 0x9F, 0x57, 0x6F, 0x4B, 0xB9, 0xA3, 0x6A, 0xD4, 0x7A, 0xF9, 0x65, 0x34, 0x6C}
+
+	kdbxKDFAES      = []byte{0xC9, 0xD9, 0xF3, 0x9A, 0x62, 0x8A, 0x44, 0x60, 0xBF, 0x74, 0x0D, 0x08, 0xC1, 0x8A, 0x4F, 0xEA}
+	kdbxKDFArgon2d  = []byte{0xEF, 0x63, 0x6D, 0xDF, 0x8C, 0x29, 0x44, 0x4B, 0x91, 0xF7, 0xA9, 0xA4, 0x03, 0xE3, 0x0A, 0x0C}
+	kdbxKDFArgon2id = []byte{0x9E, 0x29, 0x8B, 0x19, 0x56, 0xDB, 0x47, 0x73, 0xB2, 0x3D, 0xFC, 0x3E, 0xC6, 0xF0, 0xA1, 0xE6}
+
+	// kdbxSalsa20Nonce is the fixed nonce of the Salsa20 inner stream.
+	kdbxSalsa20Nonce = []byte{0xE8, 0x30, 0x09, 0x4B, 0x97, 0x20, 0x5D, 0x2A}
+)
+
+// errKDBXKey is returned when the database can't be decrypted with the password.
+var errKDBXKey = errors.New("invalid master password or corrupted database, key files are not supported")
+
+// kdbxDatabase holds the decrypted XML document of a KDBX database with the protected values in plain text,
+// and the attachments of the KDBX 4 inner header referenced by their index.
+type kdbxDatabase struct {
+	xml      []byte
+	binaries [][]byte
+}
+
+// kdbxHeader holds the outer header fields of a KDBX database. raw is the header as stored, the KDBX 4 databases
+// authenticate it.
+type kdbxHeader struct {
+	major           uint16
+	cipherID        []byte
+	compressed      bool
+	masterSeed      []byte
+	encryptionIV    []byte
+	transformSeed   []byte
+	transformRounds uint64
+	streamKey       []byte
+	streamStart     []byte
+	streamID        uint32
+	kdfParameters   map[string][]byte
+	raw             []byte
+}
+
+// openKDBX decrypts the KDBX 3.1 or KDBX 4 database protected with the master password.
+func openKDBX(data []byte, password string) (*kdbxDatabase, error) {
+	header, err := readKDBXHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	payload := data[len(header.raw):]
+
+	passwordHash := sha256.Sum256([]byte(password))
+	compositeKey := sha256.Sum256(passwordHash[:])
+	transformedKey, err := header.transformKey(compositeKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if header.major == 3 {
+		return openKDBX3(header, payload, transformedKey)
+	}
+
+	return openKDBX4(header, payload, transformedKey)
+}
+
+// openKDBX3 decrypts the payload of the KDBX 3.1 database, checks the stream start bytes and the hashes of the blocks.
+func openKDBX3(header *kdbxHeader, payload []byte, transformedKey []byte) (*kdbxDatabase, error) {
+	key := sha256.Sum256(append(bytes.Clone(header.masterSeed), transformedKey...))
+	content, err := decryptKDBX(header.cipherID, key[:], header.encryptionIV, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) < len(header.streamStart) || !bytes.Equal(content[:len(header.streamStart)], header.streamStart) {
+		return nil, errKDBXKey
+	}
+
+	content, err = readHashedBlocks(content[len(header.streamStart):])
+	if err != nil {
+		return nil, err
+	}
+
+	if content, err = header.decompress(content); err != nil {
+		return nil, err
+	}
+
+	stream, err := newInnerStream(header.streamID, header.streamKey)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := unprotectXML(content, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kdbxDatabase{xml: document}, nil
+}
+
+// openKDBX4 checks the hash and the HMAC of the header, reads the blocks authenticated with HMAC, decrypts them
+// and reads the inner header holding the inner stream key and the attachments.
+func openKDBX4(header *kdbxHeader, payload []byte, transformedKey []byte) (*kdbxDatabase, error) {
+	if len(payload) < 2*sha256.Size {
+		return nil, fmt.Errorf("truncated kdbx header")
+	}
+
+	headerHash := sha256.Sum256(header.raw)
+	if !bytes.Equal(headerHash[:], payload[:sha256.Size]) {
+		return nil, fmt.Errorf("kdbx header is corrupted")
+	}
+
+	hmacKey := sha512.Sum512(append(append(bytes.Clone(header.masterSeed), transformedKey...), 0x01))
+	if !hmac.Equal(blockHMAC(hmacKey[:], math.MaxUint64, header.raw), payload[sha256.Size:2*sha256.Size]) {
+		return nil, errKDBXKey
+	}
+
+	encrypted, err := readHMACBlocks(payload[2*sha256.Size:], hmacKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	key := sha256.Sum256(append(bytes.Clone(header.masterSeed), transformedKey...))
+	content, err := decryptKDBX(header.cipherID, key[:], header.encryptionIV, encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	if content, err = header.decompress(content); err != nil {
+		return nil, err
+	}
+
+	db := &kdbxDatabase{}
+	var streamID uint32
+	var streamKey []byte
+	reader := bytes.NewReader(content)
+	for {
+		id, value, err := readKDBXField(reader, 4)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kdbx inner header: %w", err)
+		}
+
+		switch id {
+		case kdbxInnerStreamID:
+			if len(value) != 4 {
+				return nil, fmt.Errorf("invalid kdbx inner stream id")
+			}
+			streamID = binary.LittleEndian.Uint32(value)
+		case kdbxInnerStreamKey:
+			streamKey = value
+		case kdbxInnerBinary:
+			// Первый байт вложения хранит флаги, защита действует только в памяти KeePass
+			if len(value) == 0 {
+				return nil, fmt.Errorf("invalid kdbx attachment")
+			}
+			db.binaries = append(db.binaries, value[1:])
+		}
+
+		if id == kdbxInnerEndOfHeader {
+			break
+		}
+	}
+
+	stream, err := newInnerStream(streamID, streamKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if db.xml, err = unprotectXML(content[len(content)-reader.Len():], stream); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// readKDBXHeader checks the signature and the version of the database and reads the fields of the outer header.
+func readKDBXHeader(data []byte) (*kdbxHeader, error) {
+	if len(data) < 12 || !bytes.Equal(data[:4], kdbxSignature) || !bytes.Equal(data[4:8], kdbxSignature2) {
+		return nil, fmt.Errorf("not a kdbx database")
+	}
+
+	header := &kdbxHeader{major: binary.LittleEndian.Uint16(data[10:12])}
+	if header.major != 3 && header.major != 4 {
+		return nil, fmt.Errorf("unsupported kdbx version %d, expected 3 or 4", header.major)
+	}
+
+	sizeLen := 2
+	if header.major == 4 {
+		sizeLen = 4
+	}
+
+	reader := bytes.NewReader(data[12:])
+	for {
+		id, value, err := readKDBXField(reader, sizeLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kdbx header: %w", err)
+		}
+
+		if err = header.set(id, value); err != nil {
+			return nil, err
+		}
+
+		if id == kdbxEndOfHeader {
+			break
+		}
+	}
+	header.raw = data[:len(data)-reader.Len()]
+
+	if header.masterSeed == nil || header.cipherID == nil || header.encryptionIV == nil {
+		return nil, fmt.Errorf("kdbx header misses the cipher parameters")
+	}
+
+	return header, nil
+}
+
+// set stores the value of the outer header field.
+func (h *kdbxHeader) set(id byte, value []byte) error {
+	switch id {
+	case kdbxCipherID:
+		h.cipherID = value
+	case kdbxCompressionFlags:
+		if len(value) != 4 {
+			return fmt.Errorf("invalid kdbx compression flags")
+		}
+		h.compressed = binary.LittleEndian.Uint32(value) == 1
+	case kdbxMasterSeed:
+		h.masterSeed = value
+	case kdbxTransformSeed:
+		h.transformSeed = value
+	case kdbxTransformRounds:
+		if len(value) != 8 {
+			return fmt.Errorf("invalid kdbx transform rounds")
+		}
+		h.transformRounds = binary.LittleEndian.Uint64(value)
+	case kdbxEncryptionIV:
+		h.encryptionIV = value
+	case kdbxProtectedStreamKey:
+		h.streamKey = value
+	case kdbxStreamStartBytes:
+		h.streamStart = value
+	case kdbxInnerRandomStreamID:
+		if len(value) != 4 {
+			return fmt.Errorf("invalid kdbx inner stream id")
+		}
+		h.streamID = binary.LittleEndian.Uint32(value)
+	case kdbxKDFParameters:
+		parameters, err := readVariantDictionary(value)
+		if err != nil {
+			return fmt.Errorf("failed to read kdf parameters: %w", err)
+		}
+		h.kdfParameters = parameters
+	}
+
+	return nil
+}
+
+// transformKey derives the transformed key from the composite key with the key derivation of the database:
+// AES-KDF in KDBX 3.1, AES-KDF, Argon2d or Argon2id configured by the KDF parameters in KDBX 4.
+func (h *kdbxHeader) transformKey(compositeKey []byte) ([]byte, error) {
+	if h.major == 3 {
+		return aesKDF(compositeKey, h.transformSeed, h.transformRounds)
+	}
+
+	params := h.kdfParameters
+	switch kdf := params["$UUID"]; {
+	case bytes.Equal(kdf, kdbxKDFAES):
+		rounds, err := variantUint(params, "R", 8)
+		if err != nil {
+			return nil, err
+		}
+		return aesKDF(compositeKey, params["S"], rounds)
+
+	case bytes.Equal(kdf, kdbxKDFArgon2d), bytes.Equal(kdf, kdbxKDFArgon2id):
+		iterations, err := variantUint(params, "I", 8)
+		if err != nil {
+			return nil, err
+		}
+		memory, err := variantUint(params, "M", 8)
+		if err != nil {
+			return nil, err
+		}
+		parallelism, err := variantUint(params, "P", 4)
+		if err != nil {
+			return nil, err
+		}
+		version, err := variantUint(params, "V", 4)
+		if err != nil {
+			return nil, err
+		}
+
+		if version != argon2Version {
+			return nil, fmt.Errorf("unsupported argon2 version %#x", version)
+		}
+		if iterations < 1 || iterations > math.MaxUint32 || parallelism < 1 || parallelism > math.MaxUint8 ||
+			memory < 8*1024*parallelism || memory > kdbxMaxKDFMemory {
+			return nil, fmt.Errorf("unsupported argon2 parameters")
+		}
+
+		if bytes.Equal(kdf, kdbxKDFArgon2id) {
+			return argon2.IDKey(compositeKey, params["S"], uint32(iterations), uint32(memory/1024), uint8(parallelism), 32), nil
+		}
+		return argon2dKey(compositeKey, params["S"], params["K"], params["A"], uint32(iterations), uint32(memory/1024),
+			uint32(parallelism), 32), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported kdbx key derivation")
+	}
+}
+
+// decompress unpacks the gzip compressed content of the database.
+func (h *kdbxHeader) decompress(content []byte) ([]byte, error) {
+	if !h.compressed {
+		return content, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip: %w", err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// aesKDF encrypts the key with AES-256 keyed with the seed the number of rounds and hashes the result.
+func aesKDF(key []byte, seed []byte, rounds uint64) ([]byte, error) {
+	block, err := aes.NewCipher(seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aes-kdf seed: %w", err)
+	}
+	if len(key) != 2*aes.BlockSize {
+		return nil, fmt.Errorf("invalid aes-kdf key")
+	}
+
+	transformed := bytes.Clone(key)
+	for range rounds {
+		block.Encrypt(transformed[:aes.BlockSize], transformed[:aes.BlockSize])
+		block.Encrypt(transformed[aes.BlockSize:], transformed[aes.BlockSize:])
+	}
+	sum := sha256.Sum256(transformed)
+
+	return sum[:], nil
+}
+
+// decryptKDBX decrypts the payload with AES-256 or Twofish in CBC mode with PKCS #7 padding, or with ChaCha20.
+// A padding mismatch means the key is wrong.
+func decryptKDBX(cipherID []byte, key []byte, iv []byte, payload []byte) ([]byte, error) {
+	var block cipher.Block
+	var err error
+	switch {
+	case bytes.Equal(cipherID, kdbxCipherAES):
+		block, err = aes.NewCipher(key)
+	case bytes.Equal(cipherID, kdbxCipherTwofish):
+		block, err = twofish.NewCipher(key)
+	case bytes.Equal(cipherID, kdbxCipherChaCha20):
+		stream, err := chacha20.NewUnauthenticatedCipher(key, iv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chacha20 parameters: %w", err)
+		}
+		content := make([]byte, len(payload))
+		stream.XORKeyStream(content, payload)
+		return content, nil
+	default:
+		return nil, fmt.Errorf("unsupported kdbx cipher")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != block.BlockSize() || len(payload) == 0 || len(payload)%block.BlockSize() != 0 {
+		return nil, fmt.Errorf("invalid kdbx payload size")
+	}
+
+	content := make([]byte, len(payload))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(content, payload)
+
+	padding := int(content[len(content)-1])
+	if padding == 0 || padding > block.BlockSize() {
+		return nil, errKDBXKey
+	}
+	for _, v := range content[len(content)-padding:] {
+		if int(v) != padding {
+			return nil, errKDBXKey
+		}
+	}
+
+	return content[:len(content)-padding], nil
+}
+
+// readHashedBlocks joins the blocks of the KDBX 3.1 payload, checking the SHA-256 of each block.
+func readHashedBlocks(data []byte) ([]byte, error) {
+	var res []byte
+	reader := bytes.NewReader(data)
+	for {
+		var block struct {
+			Index uint32
+			Hash  [sha256.Size]byte
+			Size  uint32
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &block); err != nil {
+			return nil, fmt.Errorf("failed to read kdbx block: %w", err)
+		}
+		if block.Size == 0 {
+			return res, nil
+		}
+		if int64(block.Size) > int64(reader.Len()) {
+			return nil, fmt.Errorf("truncated kdbx block %d", block.Index)
+		}
+
+		content := make([]byte, block.Size)
+		_, _ = reader.Read(content)
+		if sha256.Sum256(content) != block.Hash {
+			return nil, fmt.Errorf("kdbx block %d is corrupted", block.Index)
+		}
+		res = append(res, content...)
+	}
+}
+
+// readHMACBlocks joins the blocks of the KDBX 4 payload, checking the HMAC-SHA256 of each block
+// keyed with the key of its index.
+func readHMACBlocks(data []byte, hmacKey []byte) ([]byte, error) {
+	var res []byte
+	reader := bytes.NewReader(data)
+	for index := uint64(0); ; index++ {
+		var block struct {
+			HMAC [sha256.Size]byte
+			Size uint32
+		}
+		if err := binary.Read(reader, binary.LittleEndian, &block); err != nil {
+			return nil, fmt.Errorf("failed to read kdbx block: %w", err)
+		}
+		if int64(block.Size) > int64(reader.Len()) {
+			return nil, fmt.Errorf("truncated kdbx block %d", index)
+		}
+
+		content := make([]byte, block.Size)
+		_, _ = reader.Read(content)
+		message := binary.LittleEndian.AppendUint64(nil, index)
+		message = binary.LittleEndian.AppendUint32(message, block.Size)
+		if !hmac.Equal(blockHMAC(hmacKey, index, append(message, content...)), block.HMAC[:]) {
+			return nil, fmt.Errorf("kdbx block %d is corrupted", index)
+		}
+
+		if block.Size == 0 {
+			return res, nil
+		}
+		res = append(res, content...)
+	}
+}
+
+// blockHMAC returns the HMAC-SHA256 of the data keyed with the key of the block index derived from the HMAC key.
+// The header is authenticated as the block of the maximum index.
+func blockHMAC(hmacKey []byte, index uint64, data []byte) []byte {
+	key := sha512.Sum512(append(binary.LittleEndian.AppendUint64(nil, index), hmacKey...))
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+// readKDBXField reads the field ID and the value of a header field, the size of the value is sizeLen bytes long.
+func readKDBXField(reader *bytes.Reader, sizeLen int) (byte, []byte, error) {
+	id, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sizeBytes := make([]byte, 4)
+	if _, err = io.ReadFull(reader, sizeBytes[:sizeLen]); err != nil {
+		return 0, nil, err
+	}
+	size := binary.LittleEndian.Uint32(sizeBytes)
+	if int64(size) > int64(reader.Len()) {
+		return 0, nil, fmt.Errorf("field %d is truncated", id)
+	}
+
+	value := make([]byte, size)
+	_, _ = reader.Read(value)
+
+	return id, value, nil
+}
+
+// readVariantDictionary reads the KDF parameters stored as a KeePass variant dictionary into the raw values by name.
+func readVariantDictionary(data []byte) (map[string][]byte, error) {
+	reader := bytes.NewReader(data)
+	var version uint16
+	if err := binary.Read(reader, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version&0xFF00 != variantDictionaryVersion {
+		return nil, fmt.Errorf("unsupported version %#x", version)
+	}
+
+	res := make(map[string][]byte)
+	for {
+		valueType, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if valueType == 0 {
+			return res, nil
+		}
+
+		var name, value []byte
+		for _, v := range []*[]byte{&name, &value} {
+			var size uint32
+			if err = binary.Read(reader, binary.LittleEndian, &size); err != nil {
+				return nil, err
+			}
+			if int64(size) > int64(reader.Len()) {
+				return nil, fmt.Errorf("truncated entry")
+			}
+			*v = make([]byte, size)
+			_, _ = reader.Read(*v)
+		}
+		res[string(name)] = value
+	}
+}
+
+// variantUint returns the unsigned integer KDF parameter of the size in bytes.
+func variantUint(params map[string][]byte, name string, size int) (uint64, error) {
+	value, ok := params[name]
+	if !ok || len(value) != size {
+		return 0, fmt.Errorf("invalid kdf parameter %s", name)
+	}
+
+	if size == 4 {
+		return uint64(binary.LittleEndian.Uint32(value)), nil
+	}
+
+	return binary.LittleEndian.Uint64(value), nil
+}
+
+// newInnerStream creates the inner random stream protecting the values of the XML document.
+func newInnerStream(id uint32, key []byte) (cipher.Stream, error) {
+	switch id {
+	case kdbxStreamSalsa20:
+		stream := &salsa20Stream{used: salsa20BlockSize}
+		stream.key = sha256.Sum256(key)
+		copy(stream.counter[:], kdbxSalsa20Nonce)
+		return stream, nil
+
+	case kdbxStreamChaCha20:
+		sum := sha512.Sum512(key)
+		return chacha20.NewUnauthenticatedCipher(sum[:chacha20.KeySize], sum[chacha20.KeySize:chacha20.KeySize+chacha20.NonceSize])
+
+	default:
+		return nil, fmt.Errorf("unsupported kdbx inner stream %d", id)
+	}
+}
+
+// salsa20Stream is the Salsa20 key stream continued across the protected values.
+type salsa20Stream struct {
+	key     [32]byte
+	counter [16]byte
+	block   [salsa20BlockSize]byte
+	used    int
+}
+
+// XORKeyStream XORs the source with the next bytes of the key stream.
+func (s *salsa20Stream) XORKeyStream(dst, src []byte) {
+	for i, v := range src {
+		if s.used == len(s.block) {
+			var zero [salsa20BlockSize]byte
+			salsa.XORKeyStream(s.block[:], zero[:], &s.counter, &s.key)
+			binary.LittleEndian.PutUint64(s.counter[8:], binary.LittleEndian.Uint64(s.counter[8:])+1)
+			s.used = 0
+		}
+
+		dst[i] = v ^ s.block[s.used]
+		s.used++
+	}
+}
+
+// unprotectXML rewrites the XML document with the protected values decrypted by the inner stream in the document order.
+// The protected string values are written as plain text, the protected attachments stay base64 encoded.
+func unprotectXML(document []byte, stream cipher.Stream) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(document, []byte("\xef\xbb\xbf"))))
+	var res bytes.Buffer
+	encoder := xml.NewEncoder(&res)
+
+	var protected *xml.StartElement
+	var value strings.Builder
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode xml: %w", err)
+		}
+
+		switch v := token.(type) {
+		case xml.ProcInst:
+			continue
+
+		case xml.StartElement:
+			if isProtected(v) {
+				element := v.Copy()
+				protected = &element
+				value.Reset()
+			}
+
+		case xml.CharData:
+			if protected != nil {
+				value.Write(v)
+				continue
+			}
+
+		case xml.EndElement:
+			if protected != nil {
+				content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value.String()))
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode protected value: %w", err)
+				}
+				stream.XORKeyStream(content, content)
+
+				text := string(content)
+				if protected.Name.Local != "Value" {
+					text = base64.StdEncoding.EncodeToString(content)
+				}
+				if err = encoder.EncodeToken(xml.CharData(text)); err != nil {
+					return nil, err
+				}
+				protected = nil
+			}
+		}
+
+		if err = encoder.EncodeToken(token); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	return res.Bytes(), nil
+}
+
+// isProtected reports whether the value of the element is protected by the inner stream.
+func isProtected(element xml.StartElement) bool {
+	for _, v := range element.Attr {
+		if v.Name.Local == "Protected" && strings.EqualFold(v.Value, "true") {
+			return true
+		}
+	}
+
+	return false
+}
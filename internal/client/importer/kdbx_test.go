@@ -0,0 +1,327 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/twofish"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/client/app/tui/models"
+)
+
+// testKDBXDocument is the XML document of the test databases, the protected values are written in plain text
+// and protected by the writer. The history entry checks the values are unprotected in the document order.
+const testKDBXDocument = `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile><Meta><Generator>KeePass</Generator>%s</Meta><Root><Group><Name>Root</Name>
+<Entry><String><Key>Title</Key><Value>Server</Value></String><String><Key>UserName</Key><Value>root</Value></String>
+<String><Key>Password</Key><Value Protected="True">пароль</Value></String><Binary><Key>id_rsa</Key><Value Ref="0"/></Binary>
+<History><Entry><String><Key>Password</Key><Value Protected="True">old password</Value></String></Entry></History></Entry>
+<Entry><String><Key>Title</Key><Value>Note</Value></String><String><Key>Notes</Key><Value Protected="True">секретная заметка</Value></String></Entry>
+<Group><Name>Recycle Bin</Name><Entry><String><Key>Title</Key><Value>Old</Value></String><String><Key>Password</Key><Value Protected="True">x</Value></String></Entry></Group>
+</Group></Root></KeePassFile>`
+
+// testKDBX describes the test database: the KDBX version, the cipher, the KDF of KDBX 4 and the inner stream.
+type testKDBX struct {
+	major    uint16
+	cipherID []byte
+	kdf      []byte
+	streamID uint32
+}
+
+// write builds the database of the document protected with the password, the attachment is stored in the metadata
+// of KDBX 3.1 and in the inner header of KDBX 4.
+func (db testKDBX) write(t *testing.T, password string) []byte {
+	t.Helper()
+
+	masterSeed, streamKey, transformSeed := testRandom(t, 32), testRandom(t, 64), testRandom(t, 32)
+	iv := testRandom(t, 16)
+	if bytes.Equal(db.cipherID, kdbxCipherChaCha20) {
+		iv = iv[:12]
+	}
+
+	kdfParams := map[string][]byte{"$UUID": db.kdf, "S": transformSeed}
+	switch {
+	case db.major == 3 || bytes.Equal(db.kdf, kdbxKDFAES):
+		kdfParams["R"] = binary.LittleEndian.AppendUint64(nil, 100)
+	default:
+		kdfParams["I"] = binary.LittleEndian.AppendUint64(nil, 2)
+		kdfParams["M"] = binary.LittleEndian.AppendUint64(nil, 64*1024)
+		kdfParams["P"] = binary.LittleEndian.AppendUint32(nil, 2)
+		kdfParams["V"] = binary.LittleEndian.AppendUint32(nil, argon2Version)
+	}
+
+	header := &kdbxHeader{major: db.major, transformSeed: transformSeed, transformRounds: 100, kdfParameters: kdfParams}
+	passwordHash := sha256.Sum256([]byte(password))
+	compositeKey := sha256.Sum256(passwordHash[:])
+	transformedKey, err := header.transformKey(compositeKey[:])
+	require.NoError(t, err)
+	key := sha256.Sum256(append(bytes.Clone(masterSeed), transformedKey...))
+
+	stream, err := newInnerStream(db.streamID, streamKey)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	out.Write(kdbxSignature)
+	out.Write(kdbxSignature2)
+	out.Write(binary.LittleEndian.AppendUint32(nil, uint32(db.major)<<16))
+
+	if db.major == 3 {
+		streamStart := testRandom(t, 32)
+		for _, v := range []struct {
+			id    byte
+			value []byte
+		}{
+			{kdbxCipherID, db.cipherID},
+			{kdbxCompressionFlags, binary.LittleEndian.AppendUint32(nil, 1)},
+			{kdbxMasterSeed, masterSeed},
+			{kdbxTransformSeed, transformSeed},
+			{kdbxTransformRounds, binary.LittleEndian.AppendUint64(nil, 100)},
+			{kdbxEncryptionIV, iv},
+			{kdbxProtectedStreamKey, streamKey},
+			{kdbxStreamStartBytes, streamStart},
+			{kdbxInnerRandomStreamID, binary.LittleEndian.AppendUint32(nil, db.streamID)},
+			{kdbxEndOfHeader, []byte("\r\n\r\n")},
+		} {
+			out.WriteByte(v.id)
+			out.Write(binary.LittleEndian.AppendUint16(nil, uint16(len(v.value))))
+			out.Write(v.value)
+		}
+
+		document := testProtectXML(t, fmt.Sprintf(testKDBXDocument, `<Binaries><Binary ID="0">aGVsbG8=</Binary></Binaries>`), stream)
+		content := testGzip(t, document)
+
+		var blocks bytes.Buffer
+		blocks.Write(streamStart)
+		blocks.Write(binary.LittleEndian.AppendUint32(nil, 0))
+		hash := sha256.Sum256(content)
+		blocks.Write(hash[:])
+		blocks.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(content))))
+		blocks.Write(content)
+		blocks.Write(binary.LittleEndian.AppendUint32(nil, 1))
+		blocks.Write(make([]byte, sha256.Size+4))
+
+		out.Write(testEncrypt(t, db.cipherID, key[:], iv, blocks.Bytes()))
+
+		return out.Bytes()
+	}
+
+	for _, v := range []struct {
+		id    byte
+		value []byte
+	}{
+		{kdbxCipherID, db.cipherID},
+		{kdbxCompressionFlags, binary.LittleEndian.AppendUint32(nil, 1)},
+		{kdbxMasterSeed, masterSeed},
+		{kdbxEncryptionIV, iv},
+		{kdbxKDFParameters, testVariantDictionary(kdfParams)},
+		{kdbxEndOfHeader, []byte("\r\n\r\n")},
+	} {
+		out.WriteByte(v.id)
+		out.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(v.value))))
+		out.Write(v.value)
+	}
+	rawHeader := bytes.Clone(out.Bytes())
+	headerHash := sha256.Sum256(rawHeader)
+	hmacKey := sha512.Sum512(append(append(bytes.Clone(masterSeed), transformedKey...), 0x01))
+	out.Write(headerHash[:])
+	out.Write(blockHMAC(hmacKey[:], math.MaxUint64, rawHeader))
+
+	var inner bytes.Buffer
+	for _, v := range []struct {
+		id    byte
+		value []byte
+	}{
+		{kdbxInnerStreamID, binary.LittleEndian.AppendUint32(nil, db.streamID)},
+		{kdbxInnerStreamKey, streamKey},
+		{kdbxInnerBinary, append([]byte{0x01}, "hello"...)},
+		{kdbxInnerEndOfHeader, nil},
+	} {
+		inner.WriteByte(v.id)
+		inner.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(v.value))))
+		inner.Write(v.value)
+	}
+	inner.Write(testProtectXML(t, fmt.Sprintf(testKDBXDocument, ""), stream))
+
+	encrypted := testEncrypt(t, db.cipherID, key[:], iv, testGzip(t, inner.Bytes()))
+	// Содержимое делится на два блока и завершающий пустой блок
+	for index, block := range [][]byte{encrypted[:len(encrypted)/2], encrypted[len(encrypted)/2:], nil} {
+		message := binary.LittleEndian.AppendUint64(nil, uint64(index))
+		message = binary.LittleEndian.AppendUint32(message, uint32(len(block)))
+		out.Write(blockHMAC(hmacKey[:], uint64(index), append(message, block...)))
+		out.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(block))))
+		out.Write(block)
+	}
+
+	return out.Bytes()
+}
+
+// testProtectXML protects the values marked as protected with the inner stream in the document order.
+func testProtectXML(t *testing.T, document string, stream cipher.Stream) []byte {
+	t.Helper()
+
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	var res bytes.Buffer
+	encoder := xml.NewEncoder(&res)
+	protected := false
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		switch v := token.(type) {
+		case xml.StartElement:
+			protected = isProtected(v)
+		case xml.CharData:
+			if protected {
+				content := []byte(v)
+				stream.XORKeyStream(content, content)
+				token = xml.CharData(base64.StdEncoding.EncodeToString(content))
+			}
+		case xml.EndElement:
+			protected = false
+		}
+		require.NoError(t, encoder.EncodeToken(token))
+	}
+	require.NoError(t, encoder.Flush())
+
+	return res.Bytes()
+}
+
+// testEncrypt encrypts the content as decryptKDBX decrypts it.
+func testEncrypt(t *testing.T, cipherID []byte, key []byte, iv []byte, content []byte) []byte {
+	t.Helper()
+
+	if bytes.Equal(cipherID, kdbxCipherChaCha20) {
+		stream, err := chacha20.NewUnauthenticatedCipher(key, iv)
+		require.NoError(t, err)
+		res := make([]byte, len(content))
+		stream.XORKeyStream(res, content)
+		return res
+	}
+
+	var block cipher.Block
+	var err error
+	if bytes.Equal(cipherID, kdbxCipherTwofish) {
+		block, err = twofish.NewCipher(key)
+	} else {
+		block, err = aes.NewCipher(key)
+	}
+	require.NoError(t, err)
+
+	padding := block.BlockSize() - len(content)%block.BlockSize()
+	res := append(bytes.Clone(content), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(res, res)
+
+	return res
+}
+
+// testVariantDictionary writes the KDF parameters as byte arrays, the integer parameters as unsigned integers.
+func testVariantDictionary(params map[string][]byte) []byte {
+	res := binary.LittleEndian.AppendUint16(nil, variantDictionaryVersion)
+	for name, value := range params {
+		valueType := byte(0x42)
+		switch {
+		case len(name) == 1 && len(value) == 4:
+			valueType = 0x04
+		case len(name) == 1 && len(value) == 8:
+			valueType = 0x05
+		}
+
+		res = append(res, valueType)
+		res = binary.LittleEndian.AppendUint32(res, uint32(len(name)))
+		res = append(res, name...)
+		res = binary.LittleEndian.AppendUint32(res, uint32(len(value)))
+		res = append(res, value...)
+	}
+
+	return append(res, 0)
+}
+
+func testGzip(t *testing.T, content []byte) []byte {
+	var res bytes.Buffer
+	writer := gzip.NewWriter(&res)
+	_, err := writer.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	return res.Bytes()
+}
+
+func testRandom(t *testing.T, size int) []byte {
+	res := make([]byte, size)
+	_, err := rand.Read(res)
+	require.NoError(t, err)
+
+	return res
+}
+
+func TestParse_KDBX(t *testing.T) {
+	tests := []struct {
+		name string
+		db   testKDBX
+	}{
+		{name: "kdbx 3.1 aes salsa20", db: testKDBX{major: 3, cipherID: kdbxCipherAES, streamID: kdbxStreamSalsa20}},
+		{name: "kdbx 4 argon2d chacha20", db: testKDBX{major: 4, cipherID: kdbxCipherChaCha20, kdf: kdbxKDFArgon2d, streamID: kdbxStreamChaCha20}},
+		{name: "kdbx 4 argon2id aes", db: testKDBX{major: 4, cipherID: kdbxCipherAES, kdf: kdbxKDFArgon2id, streamID: kdbxStreamChaCha20}},
+		{name: "kdbx 4 aes-kdf twofish salsa20", db: testKDBX{major: 4, cipherID: kdbxCipherTwofish, kdf: kdbxKDFAES, streamID: kdbxStreamSalsa20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.db.write(t, "мастер-пароль")
+
+			batch, err := Parse(FormatKeePass, bytes.NewReader(data), "мастер-пароль")
+			require.NoError(t, err)
+
+			var categories, titles []string
+			for _, v := range batch.Items {
+				categories = append(categories, v.Category)
+				titles = append(titles, v.Title)
+			}
+			assert.Equal(t, []string{models.CredsCategory, models.FileCategory, models.TextCategory}, categories)
+			assert.Equal(t, []string{"Server", "Server id_rsa", "Note"}, titles)
+			assert.Contains(t, string(batch.Items[0].Data), "пароль")
+			assert.Contains(t, string(batch.Items[1].Data), base64.StdEncoding.EncodeToString([]byte("hello")))
+			assert.Contains(t, string(batch.Items[2].Data), "секретная заметка")
+
+			_, err = Parse(FormatKeePass, bytes.NewReader(data), "wrong")
+			assert.ErrorIs(t, err, errKDBXKey)
+		})
+	}
+}
+
+func TestParse_KDBXCorrupted(t *testing.T) {
+	data := testKDBX{major: 4, cipherID: kdbxCipherAES, kdf: kdbxKDFAES, streamID: kdbxStreamChaCha20}.write(t, "password")
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)-50] ^= 0xFF
+	_, err := Parse(FormatKeePass, bytes.NewReader(corrupted), "password")
+	assert.ErrorContains(t, err, "corrupted")
+
+	_, err = Parse(FormatKeePass, bytes.NewReader(data[:len(data)/2]), "password")
+	assert.Error(t, err)
+
+	version5 := bytes.Clone(data)
+	version5[10] = 5
+	_, err = Parse(FormatKeePass, bytes.NewReader(version5), "password")
+	assert.ErrorContains(t, err, "unsupported kdbx version")
+}
@@ -0,0 +1,190 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	keePassRecycleBin = "Recycle Bin"
+)
+
+// kdbxSignature is the magic number KeePass database files start with.
+var kdbxSignature = []byte{0x03, 0xD9, 0xA2, 0x9A}
+
+// keePassFile represents the KeePass 2 XML export and the XML document of the KDBX database.
+type keePassFile struct {
+	Binaries []keePassBinary `xml:"Meta>Binaries>Binary"`
+	Groups   []*keePassGroup `xml:"Root>Group"`
+}
+
+// keePassBinary represents an attachment stored in the export metadata and referenced by entries.
+type keePassBinary struct {
+	ID         string `xml:"ID,attr"`
+	Compressed bool   `xml:"Compressed,attr"`
+	Content    string `xml:",chardata"`
+}
+
+// keePassGroup represents a folder of entries, which can hold nested groups.
+type keePassGroup struct {
+	Name    string          `xml:"Name"`
+	Entries []*keePassEntry `xml:"Entry"`
+	Groups  []*keePassGroup `xml:"Group"`
+}
+
+// keePassEntry represents a single KeePass entry with its string fields and attachment references.
+type keePassEntry struct {
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+	Binaries []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Ref string `xml:"Ref,attr"`
+		} `xml:"Value"`
+	} `xml:"Binary"`
+}
+
+// keePassParser reads the KeePass 2 XML export and the KDBX database.
+type keePassParser struct{}
+
+// Parse reads the KeePass 2 XML export, the KDBX database needs the master password and is read by ParsePassword.
+func (p keePassParser) Parse(r io.Reader) (*Batch, error) {
+	return p.ParsePassword(r, "")
+}
+
+// ParsePassword maps KeePass entries onto credentials or text notes, and their attachments onto files.
+// The KDBX 3.1 and KDBX 4 databases are decrypted with the master password, the XML export is read as is.
+func (p keePassParser) ParsePassword(r io.Reader, password string) (*Batch, error) {
+	reader := bufio.NewReader(r)
+	if signature, err := reader.Peek(len(kdbxSignature)); err != nil || !bytes.Equal(signature, kdbxSignature) {
+		return p.parseXML(reader, nil)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kdbx database: %w", err)
+	}
+
+	db, err := openKDBX(data, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseXML(bytes.NewReader(db.xml), db.binaries)
+}
+
+// parseXML decodes the KeePass XML document. The attachments are stored in the document metadata
+// or passed from the inner header of the KDBX 4 database, where the entries reference them by index.
+func (p keePassParser) parseXML(r io.Reader, inner [][]byte) (*Batch, error) {
+	var file keePassFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode xml: %w", err)
+	}
+
+	binaries := make(map[string][]byte, len(file.Binaries)+len(inner))
+	for i, v := range inner {
+		binaries[strconv.Itoa(i)] = v
+	}
+	for _, v := range file.Binaries {
+		content, err := v.decode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode attachment %s: %w", v.ID, err)
+		}
+		binaries[v.ID] = content
+	}
+
+	batch := &Batch{}
+	for _, group := range file.Groups {
+		if err := p.walk(group, binaries, batch); err != nil {
+			return nil, err
+		}
+	}
+
+	return batch, nil
+}
+
+// walk collects the entries of the group and of its nested groups, skipping the recycle bin.
+func (p keePassParser) walk(group *keePassGroup, binaries map[string][]byte, batch *Batch) error {
+	if group.Name == keePassRecycleBin {
+		return nil
+	}
+
+	for _, entry := range group.Entries {
+		fields := make(map[string]string, len(entry.Strings))
+		for _, v := range entry.Strings {
+			fields[v.Key] = v.Value
+		}
+
+		title := fields["Title"]
+		var item *Item
+		var err error
+		switch {
+		case fields["UserName"] != "" || fields["Password"] != "":
+			item, err = newCredsItem(title, fields["URL"], fields["UserName"], fields["Password"], fields["Notes"], "KeePass")
+		case fields["Notes"] != "":
+			item, err = newTextItem(title, fields["Notes"], "KeePass")
+		}
+		if err != nil {
+			return err
+		}
+
+		if item != nil {
+			batch.Items = append(batch.Items, item)
+		}
+
+		for _, v := range entry.Binaries {
+			content, ok := binaries[v.Value.Ref]
+			if !ok {
+				batch.Skipped++
+				continue
+			}
+
+			fileItem, err := newFileItem(strings.TrimSpace(title+" "+v.Key), v.Key, content, "KeePass")
+			if err != nil {
+				return err
+			}
+			batch.Items = append(batch.Items, fileItem)
+		}
+
+		if item == nil && len(entry.Binaries) == 0 {
+			batch.Skipped++
+		}
+	}
+
+	for _, nested := range group.Groups {
+		if err := p.walk(nested, binaries, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decode returns the attachment content, decoding base64 and decompressing gzip when required.
+func (b keePassBinary) decode() ([]byte, error) {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b.Content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
+	}
+
+	if !b.Compressed {
+		return content, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip: %w", err)
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,47 @@
+package vault
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Collection keys.
+//
+// Every collection of an organization has its own random key, used for the items of the collection in place
+// of the vault key. The collection key is sealed to the public sharing key of every member:
+// format version (1 byte) | collection key ID (4 bytes) | ephemeral public key (32 bytes) | nonce | ciphertext.
+// The ciphertext authenticates the header and the collection ID, so the key can't be moved to another collection
+// or presented as another version of the key.
+const (
+	collectionKeyVersion byte = 1
+	collectionKeyHeader       = 1 + 4
+	collectionKeyInfo         = "gophkeeper-collection-key"
+)
+
+// SealCollectionKey seals the collection key identified by keyID to the public sharing key of the member.
+func SealCollectionKey(member []byte, collectionID string, keyID uint32, key []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid collection key size %d", len(key))
+	}
+
+	header := make([]byte, collectionKeyHeader)
+	header[0] = collectionKeyVersion
+	binary.BigEndian.PutUint32(header[1:], keyID)
+
+	return sealTo(member, header, collectionKeyInfo, key, []byte(collectionID))
+}
+
+// OpenCollectionKey opens the collection key sealed by SealCollectionKey with the private sharing key of the member
+// and returns the key ID with the key.
+func OpenCollectionKey(private []byte, collectionID string, sealed []byte) (uint32, []byte, error) {
+	if len(sealed) < collectionKeyHeader || sealed[0] != collectionKeyVersion {
+		return 0, nil, fmt.Errorf("unsupported collection key format")
+	}
+
+	key, err := openFrom(private, sealed, collectionKeyHeader, collectionKeyInfo, []byte(collectionID))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to open collection key: %w", err)
+	}
+
+	return binary.BigEndian.Uint32(sealed[1:collectionKeyHeader]), key, nil
+}
@@ -0,0 +1,49 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpenCollectionKey(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	private, public, err := NewSharingKey()
+	require.NoError(t, err)
+	otherPrivate, _, err := NewSharingKey()
+	require.NoError(t, err)
+
+	sealed, err := SealCollectionKey(public, "collection-1", 3, key)
+	require.NoError(t, err)
+	assert.Len(t, sealed, 97)
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[4] = 4
+
+	tests := []struct {
+		name         string
+		private      []byte
+		collectionID string
+		sealed       []byte
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{name: "round trip", private: private, collectionID: "collection-1", sealed: sealed, wantErr: assert.NoError},
+		{name: "another member", private: otherPrivate, collectionID: "collection-1", sealed: sealed, wantErr: assert.Error},
+		{name: "another collection", private: private, collectionID: "collection-2", sealed: sealed, wantErr: assert.Error},
+		{name: "key id swapped", private: private, collectionID: "collection-1", sealed: tampered, wantErr: assert.Error},
+		{name: "unsupported format", private: private, collectionID: "collection-1", sealed: []byte{9}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID, got, err := OpenCollectionKey(tt.private, tt.collectionID, tt.sealed)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, uint32(3), keyID)
+				assert.Equal(t, key, got)
+			}
+		})
+	}
+}
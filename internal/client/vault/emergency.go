@@ -0,0 +1,46 @@
+package vault
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Emergency keys.
+//
+// The owner of the vault seals the vault key to the public sharing key of the trusted contact, the server releases it
+// to the contact once the emergency access is approved:
+// format version (1 byte) | vault key ID (4 bytes) | ephemeral public key (32 bytes) | nonce | ciphertext.
+// The ciphertext authenticates the header and the owner ID, so the key can't be presented as the key of another vault.
+const (
+	emergencyKeyVersion byte = 1
+	emergencyKeyHeader       = 1 + 4
+	emergencyKeyInfo         = "gophkeeper-emergency-key"
+)
+
+// SealEmergencyKey seals the vault key of the owner identified by keyID to the public sharing key of the trusted contact.
+func SealEmergencyKey(contact []byte, ownerID string, keyID uint32, key []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid vault key size %d", len(key))
+	}
+
+	header := make([]byte, emergencyKeyHeader)
+	header[0] = emergencyKeyVersion
+	binary.BigEndian.PutUint32(header[1:], keyID)
+
+	return sealTo(contact, header, emergencyKeyInfo, key, []byte(ownerID))
+}
+
+// OpenEmergencyKey opens the vault key sealed by SealEmergencyKey with the private sharing key of the trusted contact
+// and returns the key ID with the key.
+func OpenEmergencyKey(private []byte, ownerID string, sealed []byte) (uint32, []byte, error) {
+	if len(sealed) < emergencyKeyHeader || sealed[0] != emergencyKeyVersion {
+		return 0, nil, fmt.Errorf("unsupported emergency key format")
+	}
+
+	key, err := openFrom(private, sealed, emergencyKeyHeader, emergencyKeyInfo, []byte(ownerID))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to open emergency key: %w", err)
+	}
+
+	return binary.BigEndian.Uint32(sealed[1:emergencyKeyHeader]), key, nil
+}
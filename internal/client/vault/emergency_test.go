@@ -0,0 +1,49 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpenEmergencyKey(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	private, public, err := NewSharingKey()
+	require.NoError(t, err)
+	otherPrivate, _, err := NewSharingKey()
+	require.NoError(t, err)
+
+	sealed, err := SealEmergencyKey(public, "owner-1", 2, key)
+	require.NoError(t, err)
+	assert.Len(t, sealed, 97)
+
+	tampered := append([]byte(nil), sealed...)
+	tampered[4] = 5
+
+	tests := []struct {
+		name    string
+		private []byte
+		ownerID string
+		sealed  []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "round trip", private: private, ownerID: "owner-1", sealed: sealed, wantErr: assert.NoError},
+		{name: "another contact", private: otherPrivate, ownerID: "owner-1", sealed: sealed, wantErr: assert.Error},
+		{name: "another owner", private: private, ownerID: "owner-2", sealed: sealed, wantErr: assert.Error},
+		{name: "key id swapped", private: private, ownerID: "owner-1", sealed: tampered, wantErr: assert.Error},
+		{name: "unsupported format", private: private, ownerID: "owner-1", sealed: []byte{9}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID, got, err := OpenEmergencyKey(tt.private, tt.ownerID, tt.sealed)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, uint32(2), keyID)
+				assert.Equal(t, key, got)
+			}
+		})
+	}
+}
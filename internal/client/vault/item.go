@@ -0,0 +1,205 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Envelope encryption format of the item data.
+//
+// The data is encrypted with a random data key, the blob starts with a header:
+// magic (3 bytes) | format version (1 byte) | algorithm (1 byte) | data key ID (16 bytes) | nonce | ciphertext.
+// The data key is wrapped by the vault key and stored next to the blob:
+// format version (1 byte) | vault key ID (4 bytes) | data key ID (16 bytes) | nonce | ciphertext.
+// Both ciphertexts authenticate their headers and the item data ID, so the server can't swap blobs or keys between items.
+const (
+	FirstKeyID uint32 = 1
+
+	itemVersion   byte = 1
+	algAES256GCM  byte = 1
+	dataKeyIDSize      = 16
+
+	itemHeaderSize    = 3 + 1 + 1 + dataKeyIDSize
+	wrappedHeaderSize = 1 + 4 + dataKeyIDSize
+)
+
+var itemMagic = []byte{0x00, 'G', 'K'}
+
+// SealItem encrypts the item data under a new random data key and wraps the data key with the vault key
+// identified by keyID. Returns the encrypted blob and the wrapped data key to be stored alongside it.
+func SealItem(vaultKey []byte, keyID uint32, dataID string, data []byte) ([]byte, []byte, error) {
+	dataKey, err := NewKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataKeyID := make([]byte, dataKeyIDSize)
+	if _, err = io.ReadFull(rand.Reader, dataKeyID); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key id: %w", err)
+	}
+
+	return ResealItem(vaultKey, keyID, dataID, dataKeyID, dataKey, data)
+}
+
+// ResealItem encrypts the new item data under the existing data key of the item, so the copies of the data key
+// shared with other users keep opening the item. Returns the encrypted blob and the data key wrapped with
+// the vault key identified by keyID.
+func ResealItem(vaultKey []byte, keyID uint32, dataID string, dataKeyID []byte, dataKey []byte, data []byte) ([]byte, []byte, error) {
+	blob, err := sealItemData(dataKey, dataID, dataKeyID, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrapped, err := WrapDataKey(vaultKey, keyID, dataID, dataKeyID, dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blob, wrapped, nil
+}
+
+// OpenItem decrypts the item data sealed by SealItem. The vault key must be the one identified by WrappedKeyID.
+func OpenItem(vaultKey []byte, dataID string, blob []byte, wrapped []byte) ([]byte, error) {
+	dataKeyID, dataKey, err := ItemDataKey(vaultKey, dataID, blob, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return openItemData(dataKey, dataID, dataKeyID, blob)
+}
+
+// ItemDataKey opens the data key of the item with the vault key, returning the data key ID from the blob header
+// together with the key.
+func ItemDataKey(vaultKey []byte, dataID string, blob []byte, wrapped []byte) ([]byte, []byte, error) {
+	dataKeyID, err := ItemDataKeyID(blob)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dataKey, err := UnwrapDataKey(vaultKey, dataID, dataKeyID, wrapped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dataKeyID, dataKey, nil
+}
+
+// ItemDataKeyID returns the ID of the data key the item blob is encrypted with.
+func ItemDataKeyID(blob []byte) ([]byte, error) {
+	if !IsEnvelope(blob) {
+		return nil, fmt.Errorf("item is not envelope encrypted")
+	}
+	if blob[3] != itemVersion || blob[4] != algAES256GCM {
+		return nil, fmt.Errorf("unsupported item format version %d, algorithm %d", blob[3], blob[4])
+	}
+
+	return blob[5:itemHeaderSize], nil
+}
+
+// IsEnvelope reports whether the blob is in the envelope encryption format.
+func IsEnvelope(blob []byte) bool {
+	return len(blob) > itemHeaderSize && bytes.HasPrefix(blob, itemMagic)
+}
+
+// WrappedKeyID returns the ID of the vault key the data key is wrapped with.
+func WrappedKeyID(wrapped []byte) (uint32, error) {
+	if len(wrapped) < wrappedHeaderSize || wrapped[0] != itemVersion {
+		return 0, fmt.Errorf("unsupported wrapped data key format")
+	}
+
+	return binary.BigEndian.Uint32(wrapped[1:5]), nil
+}
+
+// WrapDataKey wraps the data key of the item with the vault key identified by keyID.
+func WrapDataKey(vaultKey []byte, keyID uint32, dataID string, dataKeyID []byte, dataKey []byte) ([]byte, error) {
+	header := make([]byte, 0, wrappedHeaderSize)
+	header = append(header, itemVersion)
+	header = binary.BigEndian.AppendUint32(header, keyID)
+	header = append(header, dataKeyID...)
+
+	sealed, err := seal(vaultKey, dataKey, itemAAD(header, dataID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return append(header, sealed...), nil
+}
+
+// UnwrapDataKey opens the wrapped data key of the item, checking it belongs to the data key ID of the blob.
+func UnwrapDataKey(vaultKey []byte, dataID string, dataKeyID []byte, wrapped []byte) ([]byte, error) {
+	if _, err := WrappedKeyID(wrapped); err != nil {
+		return nil, err
+	}
+
+	header := wrapped[:wrappedHeaderSize]
+	if !bytes.Equal(header[5:], dataKeyID) {
+		return nil, fmt.Errorf("wrapped data key does not belong to the item")
+	}
+
+	dataKey, err := open(vaultKey, wrapped[wrappedHeaderSize:], itemAAD(header, dataID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	return dataKey, nil
+}
+
+// RewrapDataKey re-wraps the data key of the item from the old vault key to the new vault key identified by newKeyID.
+// The item data is left as is, so the rotation doesn't need to download the data.
+func RewrapDataKey(oldKey []byte, newKey []byte, newKeyID uint32, dataID string, wrapped []byte) ([]byte, error) {
+	if _, err := WrappedKeyID(wrapped); err != nil {
+		return nil, err
+	}
+
+	dataKeyID := wrapped[5:wrappedHeaderSize]
+	dataKey, err := UnwrapDataKey(oldKey, dataID, dataKeyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return WrapDataKey(newKey, newKeyID, dataID, dataKeyID, dataKey)
+}
+
+// sealItemData encrypts the item data with the data key, prepending the header with the data key ID.
+func sealItemData(dataKey []byte, dataID string, dataKeyID []byte, data []byte) ([]byte, error) {
+	header := make([]byte, 0, itemHeaderSize)
+	header = append(header, itemMagic...)
+	header = append(header, itemVersion, algAES256GCM)
+	header = append(header, dataKeyID...)
+
+	sealed, err := seal(dataKey, data, itemAAD(header, dataID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt item: %w", err)
+	}
+
+	return append(header, sealed...), nil
+}
+
+// openItemData decrypts the item blob with the data key, checking the blob is encrypted with the data key ID.
+func openItemData(dataKey []byte, dataID string, dataKeyID []byte, blob []byte) ([]byte, error) {
+	blobKeyID, err := ItemDataKeyID(blob)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(blobKeyID, dataKeyID) {
+		return nil, fmt.Errorf("item is encrypted with another data key")
+	}
+
+	data, err := open(dataKey, blob[itemHeaderSize:], itemAAD(blob[:itemHeaderSize], dataID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt item: %w", err)
+	}
+
+	return data, nil
+}
+
+// itemAAD builds the additional authenticated data from the header and the item data ID.
+func itemAAD(header []byte, dataID string) []byte {
+	aad := make([]byte, 0, len(header)+len(dataID))
+	aad = append(aad, header...)
+
+	return append(aad, dataID...)
+}
@@ -0,0 +1,94 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpenItem(t *testing.T) {
+	vaultKey, err := NewKey()
+	require.NoError(t, err)
+
+	data := []byte(`{"login":"bob","password":"secret"}`)
+	blob, wrapped, err := SealItem(vaultKey, FirstKeyID, "data-1", data)
+	require.NoError(t, err)
+	assert.True(t, IsEnvelope(blob))
+	assert.NotContains(t, string(blob), "secret")
+
+	keyID, err := WrappedKeyID(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, FirstKeyID, keyID)
+
+	otherBlob, otherWrapped, err := SealItem(vaultKey, FirstKeyID, "data-2", []byte("other"))
+	require.NoError(t, err)
+
+	otherKey, err := NewKey()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		key     []byte
+		dataID  string
+		blob    []byte
+		wrapped []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "round trip", key: vaultKey, dataID: "data-1", blob: blob, wrapped: wrapped, wantErr: assert.NoError},
+		{name: "wrong vault key", key: otherKey, dataID: "data-1", blob: blob, wrapped: wrapped, wantErr: assert.Error},
+		{name: "blob moved to another item", key: vaultKey, dataID: "data-2", blob: blob, wrapped: wrapped, wantErr: assert.Error},
+		{name: "blob swapped with another item", key: vaultKey, dataID: "data-1", blob: otherBlob, wrapped: wrapped, wantErr: assert.Error},
+		{name: "data key swapped with another item", key: vaultKey, dataID: "data-1", blob: blob, wrapped: otherWrapped, wantErr: assert.Error},
+		{name: "legacy blob", key: vaultKey, dataID: "data-1", blob: []byte("bGVnYWN5"), wrapped: wrapped, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OpenItem(tt.key, tt.dataID, tt.blob, tt.wrapped)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, data, got)
+			}
+		})
+	}
+}
+
+func TestOpenItem_TamperedHeader(t *testing.T) {
+	vaultKey, err := NewKey()
+	require.NoError(t, err)
+
+	blob, wrapped, err := SealItem(vaultKey, FirstKeyID, "data-1", []byte("text"))
+	require.NoError(t, err)
+
+	blob[4] = 2
+	_, err = OpenItem(vaultKey, "data-1", blob, wrapped)
+	assert.Error(t, err)
+}
+
+func TestRewrapDataKey(t *testing.T) {
+	oldKey, err := NewKey()
+	require.NoError(t, err)
+	newKey, err := NewKey()
+	require.NoError(t, err)
+
+	blob, wrapped, err := SealItem(oldKey, FirstKeyID, "data-1", []byte("text"))
+	require.NoError(t, err)
+
+	rewrapped, err := RewrapDataKey(oldKey, newKey, 2, "data-1", wrapped)
+	require.NoError(t, err)
+
+	keyID, err := WrappedKeyID(rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), keyID)
+
+	got, err := OpenItem(newKey, "data-1", blob, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("text"), got)
+
+	_, err = OpenItem(oldKey, "data-1", blob, rewrapped)
+	assert.Error(t, err)
+
+	_, err = RewrapDataKey(oldKey, newKey, 2, "data-2", wrapped)
+	assert.Error(t, err, "data key must stay bound to its item")
+}
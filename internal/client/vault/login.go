@@ -0,0 +1,61 @@
+package vault
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/srp"
+)
+
+// loginSecretInfo separates the SRP secret from the keys wrapping the vault key derived from the same password.
+const loginSecretInfo = "gophkeeper-srp|"
+
+// LoginRecord represents the SRP registration record of the account: the verifier of the secret derived from
+// the login and the master password with Argon2id, with the salt and the parameters needed to derive it again.
+type LoginRecord struct {
+	Salt     []byte
+	Verifier []byte
+	Time     uint32
+	Memory   uint32
+	Threads  uint32
+}
+
+// NewLoginRecord derives the SRP secret from the login and the password with a random salt
+// and returns the registration record the server stores instead of the password.
+func NewLoginRecord(login string, password string) (*LoginRecord, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	record := &LoginRecord{
+		Salt:    salt,
+		Time:    kdfTime,
+		Memory:  kdfMemory,
+		Threads: kdfThreads,
+	}
+
+	x, err := LoginSecret(login, password, record)
+	if err != nil {
+		return nil, err
+	}
+	record.Verifier = srp.Verifier(x)
+
+	return record, nil
+}
+
+// LoginSecret derives the SRP secret x from the login and the password with the salt and the parameters
+// of the record. The parameters come from the server, so they are checked against the limits before the derivation.
+func LoginSecret(login string, password string, record *LoginRecord) ([]byte, error) {
+	if record.Time < 1 || record.Time > maxKDFTime ||
+		record.Memory > maxKDFMemory ||
+		record.Threads < 1 || record.Threads > maxKDFThreads {
+		return nil, fmt.Errorf("unsupported login key derivation parameters")
+	}
+
+	return argon2.IDKey([]byte(loginSecretInfo+login+"|"+password), record.Salt, record.Time, record.Memory,
+		uint8(record.Threads), KeySize), nil
+}
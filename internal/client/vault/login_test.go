@@ -0,0 +1,55 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/srp"
+)
+
+func TestLoginRecord(t *testing.T) {
+	record, err := NewLoginRecord("user", "correct horse")
+	require.NoError(t, err)
+	require.NoError(t, srp.ValidateVerifier(record.Verifier))
+
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{name: "valid password", login: "user", password: "correct horse", wantErr: assert.NoError},
+		{name: "wrong password", login: "user", password: "battery staple", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, err := LoginSecret(tt.login, tt.password, record)
+			require.NoError(t, err)
+
+			client, err := srp.NewClient()
+			require.NoError(t, err)
+
+			server, err := srp.NewServer(record.Verifier)
+			require.NoError(t, err)
+
+			proof, err := client.Proof(tt.login, record.Salt, x, server.PublicKey())
+			require.NoError(t, err)
+
+			_, err = server.Verify("user", record.Salt, client.PublicKey(), proof)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestLoginSecret_UnsupportedParameters(t *testing.T) {
+	_, err := LoginSecret("user", "password", &LoginRecord{
+		Salt:    make([]byte, saltSize),
+		Time:    maxKDFTime + 1,
+		Memory:  kdfMemory,
+		Threads: kdfThreads,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package vault
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Recovery kit.
+//
+// The recovery key is a random 256-bit key shown to the user once, as a printable sheet. An X25519 key pair and
+// an authentication token are derived from it: the server keeps only the public key and a hash of the token.
+// The vault keys are sealed to the public key, so a client holding the vault key can add the keys of later rotations
+// to the kit without knowing the recovery key. A sealed key is:
+// format version (1 byte) | vault key ID (4 bytes) | ephemeral public key (32 bytes) | nonce | ciphertext.
+const (
+	RecoveryKeySize = 32
+
+	recoveryVersion       byte = 1
+	recoveryGroupSize          = 4
+	recoveryPublicSize         = 32
+	recoveryHeaderSize         = 1 + 4 + recoveryPublicSize
+	recoveryKeyInfo            = "gophkeeper-recovery-x25519"
+	recoveryAuthInfo           = "gophkeeper-recovery-auth"
+	recoveryWrapInfo           = "gophkeeper-recovery-wrap"
+	recoveryAuthTokenSize      = 32
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewRecoveryKey generates a random recovery key.
+func NewRecoveryKey() ([]byte, error) {
+	key := make([]byte, RecoveryKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate recovery key: %w", err)
+	}
+
+	return key, nil
+}
+
+// FormatRecoveryKey renders the recovery key as dash separated groups of base32 characters, easy to write down.
+func FormatRecoveryKey(key []byte) string {
+	encoded := recoveryEncoding.EncodeToString(key)
+
+	groups := make([]string, 0, len(encoded)/recoveryGroupSize+1)
+	for len(encoded) > recoveryGroupSize {
+		groups = append(groups, encoded[:recoveryGroupSize])
+		encoded = encoded[recoveryGroupSize:]
+	}
+	groups = append(groups, encoded)
+
+	return strings.Join(groups, "-")
+}
+
+// ParseRecoveryKey parses the recovery key typed by the user, ignoring the case, dashes and spaces.
+func ParseRecoveryKey(s string) ([]byte, error) {
+	s = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s)))
+
+	key, err := recoveryEncoding.DecodeString(s)
+	if err != nil || len(key) != RecoveryKeySize {
+		return nil, fmt.Errorf("invalid recovery key format")
+	}
+
+	return key, nil
+}
+
+// RecoveryPublicKey returns the public key the vault keys are sealed to for the recovery.
+func RecoveryPublicKey(recoveryKey []byte) ([]byte, error) {
+	private, err := recoveryPrivateKey(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return private.PublicKey().Bytes(), nil
+}
+
+// RecoveryAuthToken returns the token proving the possession of the recovery key to the server.
+func RecoveryAuthToken(recoveryKey []byte) ([]byte, error) {
+	token, err := hkdf.Key(sha256.New, recoveryKey, nil, recoveryAuthInfo, recoveryAuthTokenSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive recovery auth token: %w", err)
+	}
+
+	return token, nil
+}
+
+// SealForRecovery seals the vault key identified by keyID to the recovery public key.
+func SealForRecovery(publicKey []byte, keyID uint32, vaultKey []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recovery public key: %w", err)
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree recovery key: %w", err)
+	}
+
+	header := binary.BigEndian.AppendUint32([]byte{recoveryVersion}, keyID)
+	header = append(header, ephemeral.PublicKey().Bytes()...)
+
+	wrapKey, err := recoveryWrapKey(shared, header, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(wrapKey, vaultKey, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal vault key for recovery: %w", err)
+	}
+
+	return append(header, sealed...), nil
+}
+
+// OpenWithRecovery opens the vault key identified by keyID sealed by SealForRecovery with the recovery key.
+func OpenWithRecovery(recoveryKey []byte, keyID uint32, sealed []byte) ([]byte, error) {
+	if len(sealed) < recoveryHeaderSize || sealed[0] != recoveryVersion {
+		return nil, fmt.Errorf("unsupported recovery key format")
+	}
+
+	header := sealed[:recoveryHeaderSize]
+	if binary.BigEndian.Uint32(header[1:5]) != keyID {
+		return nil, fmt.Errorf("recovery key is sealed for another vault key")
+	}
+
+	private, err := recoveryPrivateKey(recoveryKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(header[5:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+
+	shared, err := private.ECDH(ephemeral)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree recovery key: %w", err)
+	}
+
+	wrapKey, err := recoveryWrapKey(shared, header, private.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	vaultKey, err := open(wrapKey, sealed[recoveryHeaderSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vault key, wrong recovery key: %w", err)
+	}
+
+	if len(vaultKey) != KeySize {
+		return nil, fmt.Errorf("invalid vault key size %d", len(vaultKey))
+	}
+
+	return vaultKey, nil
+}
+
+// recoveryPrivateKey derives the X25519 private key from the recovery key.
+func recoveryPrivateKey(recoveryKey []byte) (*ecdh.PrivateKey, error) {
+	if len(recoveryKey) != RecoveryKeySize {
+		return nil, fmt.Errorf("invalid recovery key size %d", len(recoveryKey))
+	}
+
+	seed, err := hkdf.Key(sha256.New, recoveryKey, nil, recoveryKeyInfo, recoveryPublicSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive recovery private key: %w", err)
+	}
+
+	return ecdh.X25519().NewPrivateKey(seed)
+}
+
+// recoveryWrapKey derives the key sealing the vault key from the shared secret, bound to the header and the recipient.
+func recoveryWrapKey(shared []byte, header []byte, recipient []byte) ([]byte, error) {
+	salt := make([]byte, 0, len(header)+len(recipient))
+	salt = append(salt, header...)
+	salt = append(salt, recipient...)
+
+	key, err := hkdf.Key(sha256.New, shared, salt, recoveryWrapInfo, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive recovery wrap key: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,69 @@
+package vault
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatParseRecoveryKey(t *testing.T) {
+	key, err := NewRecoveryKey()
+	require.NoError(t, err)
+
+	formatted := FormatRecoveryKey(key)
+	assert.Len(t, strings.Split(formatted, "-"), 13)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "as printed", input: formatted, wantErr: assert.NoError},
+		{name: "lower case with spaces", input: strings.ToLower(strings.ReplaceAll(formatted, "-", " ")), wantErr: assert.NoError},
+		{name: "truncated", input: formatted[:len(formatted)-5], wantErr: assert.Error},
+		{name: "invalid characters", input: strings.Repeat("1", 52), wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecoveryKey(tt.input)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, key, got)
+			}
+		})
+	}
+}
+
+func TestSealOpenRecovery(t *testing.T) {
+	recoveryKey, err := NewRecoveryKey()
+	require.NoError(t, err)
+	vaultKey, err := NewKey()
+	require.NoError(t, err)
+
+	publicKey, err := RecoveryPublicKey(recoveryKey)
+	require.NoError(t, err)
+
+	sealed, err := SealForRecovery(publicKey, 2, vaultKey)
+	require.NoError(t, err)
+
+	got, err := OpenWithRecovery(recoveryKey, 2, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, vaultKey, got)
+
+	_, err = OpenWithRecovery(recoveryKey, 3, sealed)
+	assert.Error(t, err, "sealed key must be bound to the vault key ID")
+
+	otherKey, err := NewRecoveryKey()
+	require.NoError(t, err)
+	_, err = OpenWithRecovery(otherKey, 2, sealed)
+	assert.Error(t, err)
+
+	token, err := RecoveryAuthToken(recoveryKey)
+	require.NoError(t, err)
+	otherToken, err := RecoveryAuthToken(otherKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, otherToken)
+}
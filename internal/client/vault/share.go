@@ -0,0 +1,251 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Item sharing.
+//
+// Every user has an X25519 sharing key pair: the server keeps the public key in clear and the private key wrapped
+// with the vault key, in the format of a wrapped data key bound to the user and the public key.
+// An item is shared by sealing its data key to the public key of the recipient, the item data itself is not copied:
+// format version (1 byte) | data key ID (16 bytes) | ephemeral public key (32 bytes) | nonce | ciphertext.
+// The title and description of the shared item are sealed to the recipient the same way, without the data key ID.
+// Both ciphertexts authenticate their headers and the item data ID.
+const (
+	SharingKeySize = 32
+
+	shareVersion     byte = 1
+	shareMetaVersion byte = 1
+	sharePublicSize       = 32
+	shareHeaderSize       = 1 + dataKeyIDSize + sharePublicSize
+	shareMetaHeader       = 1 + sharePublicSize
+	shareKeyInfo          = "gophkeeper-share-key"
+	shareMetaInfo         = "gophkeeper-share-meta"
+	sharingKeyPrefix      = "gophkeeper-sharing-key|"
+	fingerprintSize       = 8
+)
+
+// NewSharingKey generates a random sharing key pair and returns the private and the public keys.
+func NewSharingKey() ([]byte, []byte, error) {
+	private, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate sharing key: %w", err)
+	}
+
+	return private.Bytes(), private.PublicKey().Bytes(), nil
+}
+
+// WrapSharingKey wraps the private sharing key of the user with the vault key identified by keyID.
+func WrapSharingKey(vaultKey []byte, keyID uint32, userID string, private []byte) ([]byte, error) {
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sharing key: %w", err)
+	}
+
+	return WrapDataKey(vaultKey, keyID, sharingKeyPrefix+userID, sharingKeyID(key.PublicKey().Bytes()), private)
+}
+
+// UnwrapSharingKey opens the private sharing key of the user wrapped by WrapSharingKey and checks it belongs
+// to the public key. The vault key must be the one identified by WrappedKeyID.
+func UnwrapSharingKey(vaultKey []byte, userID string, publicKey []byte, wrapped []byte) ([]byte, error) {
+	private, err := UnwrapDataKey(vaultKey, sharingKeyPrefix+userID, sharingKeyID(publicKey), wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap sharing key: %w", err)
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sharing key: %w", err)
+	}
+
+	if !bytes.Equal(key.PublicKey().Bytes(), publicKey) {
+		return nil, fmt.Errorf("sharing key does not match its public key")
+	}
+
+	return private, nil
+}
+
+// ShareDataKey seals the data key of the item to the public sharing key of the recipient.
+func ShareDataKey(recipient []byte, dataID string, dataKeyID []byte, dataKey []byte) ([]byte, error) {
+	if len(dataKeyID) != dataKeyIDSize {
+		return nil, fmt.Errorf("invalid data key id size %d", len(dataKeyID))
+	}
+
+	header := append([]byte{shareVersion}, dataKeyID...)
+
+	return sealTo(recipient, header, shareKeyInfo, dataKey, []byte(dataID))
+}
+
+// OpenSharedItem decrypts the item data shared with the user: the data key sealed by ShareDataKey is opened
+// with the private sharing key of the user.
+func OpenSharedItem(private []byte, dataID string, blob []byte, shared []byte) ([]byte, error) {
+	dataKeyID, dataKey, err := openSharedDataKey(private, dataID, shared)
+	if err != nil {
+		return nil, err
+	}
+
+	return openItemData(dataKey, dataID, dataKeyID, blob)
+}
+
+// SealSharedItem encrypts the new item data with the data key shared with the user, so the owner and the other
+// recipients keep opening the item. Returns the encrypted blob.
+func SealSharedItem(private []byte, dataID string, shared []byte, data []byte) ([]byte, error) {
+	dataKeyID, dataKey, err := openSharedDataKey(private, dataID, shared)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealItemData(dataKey, dataID, dataKeyID, data)
+}
+
+// SealSharedMeta seals the title and description of the item to the public sharing key of the recipient.
+func SealSharedMeta(recipient []byte, dataID string, meta *Meta) ([]byte, error) {
+	plaintext, err := json.Marshal(&Meta{
+		Title:       meta.Title,
+		Description: meta.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal meta: %w", err)
+	}
+
+	return sealTo(recipient, []byte{shareMetaVersion}, shareMetaInfo, plaintext, []byte(dataID))
+}
+
+// OpenSharedMeta opens the title and description of the shared item sealed by SealSharedMeta.
+func OpenSharedMeta(private []byte, dataID string, sealed []byte) (*Meta, error) {
+	if len(sealed) < shareMetaHeader || sealed[0] != shareMetaVersion {
+		return nil, fmt.Errorf("unsupported shared meta format")
+	}
+
+	plaintext, err := openFrom(private, sealed, 1, shareMetaInfo, []byte(dataID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open shared meta: %w", err)
+	}
+
+	var meta Meta
+	if err = json.Unmarshal(plaintext, &meta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shared meta: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// KeyFingerprint returns a short fingerprint of the public sharing key, to be compared by the users out of band.
+func KeyFingerprint(publicKey []byte) string {
+	sum := sha256.Sum256(publicKey)
+	encoded := hex.EncodeToString(sum[:fingerprintSize])
+
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+
+	return strings.Join(groups, ":")
+}
+
+// openSharedDataKey opens the data key sealed by ShareDataKey, returning the data key ID from its header.
+func openSharedDataKey(private []byte, dataID string, shared []byte) ([]byte, []byte, error) {
+	if len(shared) < shareHeaderSize || shared[0] != shareVersion {
+		return nil, nil, fmt.Errorf("unsupported shared data key format")
+	}
+
+	dataKey, err := openFrom(private, shared, 1+dataKeyIDSize, shareKeyInfo, []byte(dataID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open shared data key: %w", err)
+	}
+
+	return shared[1 : 1+dataKeyIDSize], dataKey, nil
+}
+
+// sharingKeyID binds the wrapped private sharing key to the public key, in place of the data key ID.
+func sharingKeyID(publicKey []byte) []byte {
+	sum := sha256.Sum256(publicKey)
+	return sum[:dataKeyIDSize]
+}
+
+// sealTo seals the plaintext to the X25519 public key with an ephemeral key pair. The result is the header followed
+// by the ephemeral public key, the nonce and the ciphertext authenticating both and the additional data.
+func sealTo(recipient []byte, header []byte, info string, plaintext []byte, aad []byte) ([]byte, error) {
+	publicKey, err := ecdh.X25519().NewPublicKey(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient public key: %w", err)
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	shared, err := ephemeral.ECDH(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree shared key: %w", err)
+	}
+
+	header = append(header, ephemeral.PublicKey().Bytes()...)
+
+	key, err := shareWrapKey(shared, header, recipient, info)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(key, plaintext, append(bytes.Clone(header), aad...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal for recipient: %w", err)
+	}
+
+	return append(header, sealed...), nil
+}
+
+// openFrom opens the blob sealed by sealTo with the private key. headerSize is the size of the header preceding
+// the ephemeral public key.
+func openFrom(private []byte, blob []byte, headerSize int, info string, aad []byte) ([]byte, error) {
+	if len(blob) < headerSize+sharePublicSize {
+		return nil, fmt.Errorf("sealed blob is too short")
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sharing key: %w", err)
+	}
+
+	header := blob[:headerSize+sharePublicSize]
+	ephemeral, err := ecdh.X25519().NewPublicKey(header[headerSize:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+	}
+
+	shared, err := key.ECDH(ephemeral)
+	if err != nil {
+		return nil, fmt.Errorf("failed to agree shared key: %w", err)
+	}
+
+	wrapKey, err := shareWrapKey(shared, header, key.PublicKey().Bytes(), info)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(wrapKey, blob[len(header):], append(bytes.Clone(header), aad...))
+}
+
+// shareWrapKey derives the key sealing the shared secret from the agreed key, bound to the header and the recipient.
+func shareWrapKey(shared []byte, header []byte, recipient []byte, info string) ([]byte, error) {
+	salt := make([]byte, 0, len(header)+len(recipient))
+	salt = append(salt, header...)
+	salt = append(salt, recipient...)
+
+	key, err := hkdf.Key(sha256.New, shared, salt, info, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive share key: %w", err)
+	}
+
+	return key, nil
+}
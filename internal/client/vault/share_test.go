@@ -0,0 +1,118 @@
+package vault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapUnwrapSharingKey(t *testing.T) {
+	vaultKey, err := NewKey()
+	require.NoError(t, err)
+	private, public, err := NewSharingKey()
+	require.NoError(t, err)
+	_, otherPublic, err := NewSharingKey()
+	require.NoError(t, err)
+
+	wrapped, err := WrapSharingKey(vaultKey, 2, "user-1", private)
+	require.NoError(t, err)
+
+	keyID, err := WrappedKeyID(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), keyID)
+
+	tests := []struct {
+		name    string
+		userID  string
+		public  []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "round trip", userID: "user-1", public: public, wantErr: assert.NoError},
+		{name: "another user", userID: "user-2", public: public, wantErr: assert.Error},
+		{name: "public key swapped", userID: "user-1", public: otherPublic, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnwrapSharingKey(vaultKey, tt.userID, tt.public, wrapped)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, private, got)
+			}
+		})
+	}
+}
+
+func TestShareItem(t *testing.T) {
+	vaultKey, err := NewKey()
+	require.NoError(t, err)
+	private, public, err := NewSharingKey()
+	require.NoError(t, err)
+	otherPrivate, _, err := NewSharingKey()
+	require.NoError(t, err)
+
+	blob, wrapped, err := SealItem(vaultKey, FirstKeyID, "data-1", []byte("secret"))
+	require.NoError(t, err)
+
+	dataKeyID, dataKey, err := ItemDataKey(vaultKey, "data-1", blob, wrapped)
+	require.NoError(t, err)
+
+	shared, err := ShareDataKey(public, "data-1", dataKeyID, dataKey)
+	require.NoError(t, err)
+
+	got, err := OpenSharedItem(private, "data-1", blob, shared)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), got)
+
+	_, err = OpenSharedItem(otherPrivate, "data-1", blob, shared)
+	assert.Error(t, err, "only the recipient opens the shared key")
+
+	_, err = OpenSharedItem(private, "data-2", blob, shared)
+	assert.Error(t, err, "shared key must be bound to the item")
+
+	// Изменения получателя и владельца открываются друг у друга тем же ключом данных
+	edited, err := SealSharedItem(private, "data-1", shared, []byte("edited"))
+	require.NoError(t, err)
+
+	got, err = OpenItem(vaultKey, "data-1", edited, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("edited"), got)
+
+	resealed, _, err := ResealItem(vaultKey, FirstKeyID, "data-1", dataKeyID, dataKey, []byte("owner"))
+	require.NoError(t, err)
+
+	got, err = OpenSharedItem(private, "data-1", resealed, shared)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("owner"), got)
+
+	rekeyed, _, err := SealItem(vaultKey, FirstKeyID, "data-1", []byte("rekeyed"))
+	require.NoError(t, err)
+
+	_, err = OpenSharedItem(private, "data-1", rekeyed, shared)
+	assert.Error(t, err, "revoked key must not open the re-keyed item")
+}
+
+func TestSealOpenSharedMeta(t *testing.T) {
+	private, public, err := NewSharingKey()
+	require.NoError(t, err)
+
+	sealed, err := SealSharedMeta(public, "data-1", &Meta{Title: "Mail", Description: "work", Tags: []string{"private"}})
+	require.NoError(t, err)
+
+	got, err := OpenSharedMeta(private, "data-1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, &Meta{Title: "Mail", Description: "work"}, got, "tags are not shared")
+
+	_, err = OpenSharedMeta(private, "data-2", sealed)
+	assert.Error(t, err)
+}
+
+func TestKeyFingerprint(t *testing.T) {
+	_, public, err := NewSharingKey()
+	require.NoError(t, err)
+
+	fingerprint := KeyFingerprint(public)
+	assert.Len(t, fingerprint, 19)
+	assert.Equal(t, fingerprint, KeyFingerprint(public))
+}
@@ -0,0 +1,232 @@
+// Модуль vault управляет ключом хранилища и шифрованием метаданных записей на клиенте
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Vault key sizes and the key derivation defaults of the key wrapping the vault key.
+const (
+	KeySize = 32
+
+	kdfTime    = 3
+	kdfMemory  = 64 * 1024
+	kdfThreads = 4
+	saltSize   = 16
+
+	maxKDFTime    = 10
+	maxKDFMemory  = 1024 * 1024
+	maxKDFThreads = 16
+
+	// Версия 1 не содержит версии ключа хранилища и всегда зашифрована первым ключом
+	metaVersionV1  byte = 1
+	metaVersion    byte = 2
+	metaHeaderSize      = 1 + 4
+
+	wrapAAD = "gophkeeper-vault-key"
+)
+
+// WrappedKey represents the vault key sealed with AES-256-GCM under a key derived from the master password with Argon2id.
+// Key holds the nonce followed by the ciphertext, the other fields are needed to derive the wrapping key again.
+type WrappedKey struct {
+	Key     []byte
+	Salt    []byte
+	Time    uint32
+	Memory  uint32
+	Threads uint32
+}
+
+// Meta represents the metadata of an item kept secret from the server: its title, description, tags
+// and any custom fields.
+type Meta struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags,omitempty"`
+	Custom      map[string]string `json:"custom,omitempty"`
+}
+
+// NewKey generates a random vault key.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate vault key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Wrap seals the vault key under a key derived from the password with a random salt.
+func Wrap(key []byte, password string) (*WrappedKey, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	wrapped := &WrappedKey{
+		Salt:    salt,
+		Time:    kdfTime,
+		Memory:  kdfMemory,
+		Threads: kdfThreads,
+	}
+
+	sealed, err := seal(wrapped.deriveKey(password), key, []byte(wrapAAD))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap vault key: %w", err)
+	}
+	wrapped.Key = sealed
+
+	return wrapped, nil
+}
+
+// Unwrap opens the vault key with the password. The key derivation parameters come from the server,
+// so they are checked against the limits before the derivation.
+func Unwrap(wrapped *WrappedKey, password string) ([]byte, error) {
+	if wrapped.Time < 1 || wrapped.Time > maxKDFTime ||
+		wrapped.Memory > maxKDFMemory ||
+		wrapped.Threads < 1 || wrapped.Threads > maxKDFThreads {
+		return nil, fmt.Errorf("unsupported vault key derivation parameters")
+	}
+
+	key, err := open(wrapped.deriveKey(password), wrapped.Key, []byte(wrapAAD))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap vault key, wrong password: %w", err)
+	}
+
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("invalid vault key size %d", len(key))
+	}
+
+	return key, nil
+}
+
+// SealMeta encrypts the metadata of the item with the vault key identified by keyID. The ciphertext is bound to
+// the item ID and data type, so the server can't swap the metadata between items.
+// The blob starts with the format version and the vault key ID, followed by the nonce and the ciphertext.
+func SealMeta(key []byte, keyID uint32, metaID string, dataType string, meta *Meta) ([]byte, error) {
+	plaintext, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal meta: %w", err)
+	}
+
+	header := binary.BigEndian.AppendUint32([]byte{metaVersion}, keyID)
+
+	sealed, err := seal(key, plaintext, metaAAD(header, metaID, dataType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt meta: %w", err)
+	}
+
+	return append(header, sealed...), nil
+}
+
+// OpenMeta decrypts the metadata of the item sealed by SealMeta. The vault key must be the one identified by MetaKeyID.
+func OpenMeta(key []byte, metaID string, dataType string, blob []byte) (*Meta, error) {
+	if _, err := MetaKeyID(blob); err != nil {
+		return nil, err
+	}
+
+	header, sealed := blob[:1], blob[1:]
+	if blob[0] == metaVersion {
+		header, sealed = blob[:metaHeaderSize], blob[metaHeaderSize:]
+	}
+
+	plaintext, err := open(key, sealed, metaAAD(header, metaID, dataType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt meta: %w", err)
+	}
+
+	var meta Meta
+	if err = json.Unmarshal(plaintext, &meta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal meta: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// MetaKeyID returns the ID of the vault key the metadata is sealed with.
+func MetaKeyID(blob []byte) (uint32, error) {
+	switch {
+	case len(blob) > 0 && blob[0] == metaVersionV1:
+		return FirstKeyID, nil
+	case len(blob) > metaHeaderSize && blob[0] == metaVersion:
+		return binary.BigEndian.Uint32(blob[1:metaHeaderSize]), nil
+	default:
+		return 0, fmt.Errorf("unsupported encrypted meta version")
+	}
+}
+
+// ResealMeta re-encrypts the metadata sealed with the old vault key under the new vault key identified by newKeyID.
+func ResealMeta(oldKey []byte, newKey []byte, newKeyID uint32, metaID string, dataType string, blob []byte) ([]byte, error) {
+	meta, err := OpenMeta(oldKey, metaID, dataType, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return SealMeta(newKey, newKeyID, metaID, dataType, meta)
+}
+
+// deriveKey derives the key wrapping the vault key from the password.
+func (w *WrappedKey) deriveKey(password string) []byte {
+	return argon2.IDKey([]byte(password), w.Salt, w.Time, w.Memory, uint8(w.Threads), KeySize)
+}
+
+// metaAAD builds the additional authenticated data binding the encrypted metadata to its item.
+// The first format version authenticates only the version byte, later ones the vault key ID as well.
+func metaAAD(header []byte, metaID string, dataType string) []byte {
+	if header[0] == metaVersionV1 {
+		return []byte(fmt.Sprintf("gophkeeper-meta|%d|%s|%s", metaVersionV1, metaID, dataType))
+	}
+
+	return []byte(fmt.Sprintf("gophkeeper-meta|%d|%d|%s|%s", header[0], binary.BigEndian.Uint32(header[1:]), metaID, dataType))
+}
+
+// seal encrypts the plaintext with AES-256-GCM, prepending the random nonce to the ciphertext.
+func seal(key []byte, plaintext []byte, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts the ciphertext produced by seal.
+func open(key []byte, sealed []byte, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], aad)
+}
+
+// newGCM creates the AES-GCM cipher for the key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,128 @@
+package vault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapUnwrap(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	wrapped, err := Wrap(key, "master password")
+	require.NoError(t, err)
+	assert.Len(t, wrapped.Key, KeySize+28)
+
+	tests := []struct {
+		name     string
+		password string
+		modify   func(*WrappedKey)
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{name: "correct password", password: "master password", wantErr: assert.NoError},
+		{name: "wrong password", password: "other password", wantErr: assert.Error},
+		{name: "tampered salt", password: "master password", modify: func(w *WrappedKey) { w.Salt = make([]byte, saltSize) }, wantErr: assert.Error},
+		{name: "excessive memory", password: "master password", modify: func(w *WrappedKey) { w.Memory = maxKDFMemory + 1 }, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := *wrapped
+			if tt.modify != nil {
+				tt.modify(&w)
+			}
+
+			got, err := Unwrap(&w, tt.password)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, key, got)
+			}
+		})
+	}
+}
+
+func TestSealOpenMeta(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	meta := &Meta{Title: "AWS root prod", Description: "console", Tags: []string{"aws"}}
+	blob, err := SealMeta(key, 2, "meta-1", "Creds", meta)
+	require.NoError(t, err)
+	assert.NotContains(t, string(blob), "AWS")
+
+	got, err := OpenMeta(key, "meta-1", "Creds", blob)
+	require.NoError(t, err)
+	assert.Equal(t, meta, got)
+
+	keyID, err := MetaKeyID(blob)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), keyID)
+
+	tampered := append([]byte{}, blob...)
+	tampered[4] = 3
+	_, err = OpenMeta(key, "meta-1", "Creds", tampered)
+	assert.Error(t, err, "meta must be bound to the vault key ID")
+
+	_, err = OpenMeta(key, "meta-2", "Creds", blob)
+	assert.Error(t, err, "meta must be bound to the item ID")
+
+	_, err = OpenMeta(key, "meta-1", "Text", blob)
+	assert.Error(t, err, "meta must be bound to the data type")
+
+	otherKey, err := NewKey()
+	require.NoError(t, err)
+	_, err = OpenMeta(otherKey, "meta-1", "Creds", blob)
+	assert.Error(t, err)
+}
+
+func TestOpenMeta_FirstVersion(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+
+	meta := &Meta{Title: "Mail"}
+	plaintext, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	sealed, err := seal(key, plaintext, []byte("gophkeeper-meta|1|meta-1|Creds"))
+	require.NoError(t, err)
+	blob := append([]byte{metaVersionV1}, sealed...)
+
+	keyID, err := MetaKeyID(blob)
+	require.NoError(t, err)
+	assert.Equal(t, FirstKeyID, keyID)
+
+	got, err := OpenMeta(key, "meta-1", "Creds", blob)
+	require.NoError(t, err)
+	assert.Equal(t, meta, got)
+}
+
+func TestResealMeta(t *testing.T) {
+	oldKey, err := NewKey()
+	require.NoError(t, err)
+	newKey, err := NewKey()
+	require.NoError(t, err)
+
+	meta := &Meta{Title: "Mail", Tags: []string{"work"}}
+	blob, err := SealMeta(oldKey, FirstKeyID, "meta-1", "Creds", meta)
+	require.NoError(t, err)
+
+	resealed, err := ResealMeta(oldKey, newKey, 2, "meta-1", "Creds", blob)
+	require.NoError(t, err)
+
+	keyID, err := MetaKeyID(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), keyID)
+
+	got, err := OpenMeta(newKey, "meta-1", "Creds", resealed)
+	require.NoError(t, err)
+	assert.Equal(t, meta, got)
+
+	_, err = OpenMeta(oldKey, "meta-1", "Creds", resealed)
+	assert.Error(t, err)
+
+	_, err = ResealMeta(newKey, newKey, 3, "meta-1", "Creds", blob)
+	assert.Error(t, err, "meta sealed with another key can't be resealed")
+}
@@ -1,36 +1,475 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 // UserData represents a user in the system with unique ID, login credentials, and timestamps for creation and modification.
+// SRPSalt, SRPVerifier and the KDF parameters are the SRP-6a registration record the user logs in with: the verifier
+// of the secret derived from the master password with Argon2id. Password holds the bcrypt hash of the accounts created
+// before the SRP login, until their next login.
+// SessionVersion is embedded into the issued tokens and incremented to revoke them.
+// RecoveryPublicKey and RecoveryVerifier are set when the user has created a recovery kit: the public key the vault keys
+// are sealed to and the SHA-256 hash of the token proving the possession of the recovery key.
+// Locked is set when an operator has locked the account, the locked user can't log in or use the issued tokens.
 type UserData struct {
+	ID                uuid.UUID `json:"id"`
+	Login             string    `json:"login"`
+	Password          string    `json:"password"`
+	SRPSalt           []byte    `json:"srp_salt"`
+	SRPVerifier       []byte    `json:"srp_verifier"`
+	KDFTime           uint32    `json:"kdf_time"`
+	KDFMemory         uint32    `json:"kdf_memory"`
+	KDFThreads        uint32    `json:"kdf_threads"`
+	SessionVersion    int       `json:"session_version"`
+	RecoveryPublicKey []byte    `json:"recovery_public_key"`
+	RecoveryVerifier  []byte    `json:"recovery_verifier"`
+	Locked            time.Time `json:"locked"`
+	Created           time.Time `json:"created"`
+	Modified          time.Time `json:"modified"`
+}
+
+// IsLocked reports whether the account was locked by an operator.
+func (u *UserData) IsLocked() bool {
+	return !u.Locked.IsZero()
+}
+
+// Meta represents metadata associated with an item or resource, including identification, ownership, and timestamps.
+// MetaKeyID is the version of the vault key EncryptedMeta is sealed with, zero for plain metadata.
+type Meta struct {
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Type          string    `json:"data_type"`
+	DataID        uuid.UUID `json:"data_id"`
+	UserID        uuid.UUID `json:"user_id"`
+	FolderID      uuid.UUID `json:"folder_id"`
+	Tags          []string  `json:"tags"`
+	EncryptedMeta []byte    `json:"encrypted_meta"`
+	MetaKeyID     uint32    `json:"meta_key_id"`
+	Created       time.Time `json:"created"`
+	Modified      time.Time `json:"modified"`
+}
+
+// MetaFilter narrows the metadata of a user down to the items marked with the tag, placed into the folder,
+// of the data type and modified since the given moment. Empty fields are not applied.
+// The records are ordered by ID, AfterID and Limit select a page of them: up to Limit records following AfterID.
+type MetaFilter struct {
+	Tag           string
+	FolderID      uuid.UUID
+	DataType      string
+	ModifiedSince time.Time
+	AfterID       uuid.UUID
+	Limit         int
+}
+
+// Folder represents a user-defined folder of items. Folders are nested by ParentID, uuid.Nil marks a top-level folder.
+type Folder struct {
 	ID       uuid.UUID `json:"id"`
-	Login    string    `json:"login"`
-	Password string    `json:"password"`
+	Name     string    `json:"name"`
+	ParentID uuid.UUID `json:"parent_id"`
+	UserID   uuid.UUID `json:"user_id"`
 	Created  time.Time `json:"created"`
 	Modified time.Time `json:"modified"`
 }
 
-// Meta represents metadata associated with an item or resource, including identification, ownership, and timestamps.
-type Meta struct {
+// VaultKey represents the vault key of a user wrapped on the client with a key derived from the master password.
+// The server stores it as an opaque blob together with the salt and the Argon2id parameters needed to unwrap it.
+// KeyID is the version of the key, State tells the key in use from the new key of an unfinished rotation.
+// RecoveryWrappedKey holds the same key sealed to the recovery public key of the user, if any.
+type VaultKey struct {
+	UserID             uuid.UUID `json:"user_id"`
+	KeyID              uint32    `json:"key_id"`
+	State              string    `json:"state"`
+	WrappedKey         []byte    `json:"wrapped_key"`
+	Salt               []byte    `json:"salt"`
+	KDFTime            uint32    `json:"kdf_time"`
+	KDFMemory          uint32    `json:"kdf_memory"`
+	KDFThreads         uint32    `json:"kdf_threads"`
+	RecoveryWrappedKey []byte    `json:"recovery_wrapped_key"`
+	Created            time.Time `json:"created"`
+	Modified           time.Time `json:"modified"`
+}
+
+// States of the vault keys.
+const (
+	VaultKeyActive  = "active"
+	VaultKeyPending = "pending"
+)
+
+// ItemData represents an entity containing a unique identifier and associated byte data.
+// WrappedKey holds the data key of the item encrypted by the client with the vault key of version KeyID.
+type ItemData struct {
+	ID         uuid.UUID `json:"id"`
+	Data       []byte    `json:"data"`
+	WrappedKey []byte    `json:"wrapped_key"`
+	KeyID      uint32    `json:"key_id"`
+}
+
+// SharingKey represents the X25519 key pair a user receives shared items with. PublicKey is kept in clear,
+// the private key is wrapped on the client with the vault key of version KeyID.
+type SharingKey struct {
+	UserID            uuid.UUID `json:"user_id"`
+	PublicKey         []byte    `json:"public_key"`
+	WrappedPrivateKey []byte    `json:"wrapped_private_key"`
+	KeyID             uint32    `json:"key_id"`
+	Created           time.Time `json:"created"`
+	Modified          time.Time `json:"modified"`
+}
+
+// Share represents the access to an item granted by its owner to another user. WrappedKey holds the data key
+// of the item and EncryptedMeta its title and description, both sealed on the client to the public sharing key
+// of the recipient. The logins and the public key of the recipient are filled in by the queries for display.
+type Share struct {
+	ID                 uuid.UUID `json:"id"`
+	DataID             uuid.UUID `json:"data_id"`
+	OwnerID            uuid.UUID `json:"owner_id"`
+	RecipientID        uuid.UUID `json:"recipient_id"`
+	OwnerLogin         string    `json:"owner_login"`
+	RecipientLogin     string    `json:"recipient_login"`
+	RecipientPublicKey []byte    `json:"recipient_public_key"`
+	WrappedKey         []byte    `json:"wrapped_key"`
+	EncryptedMeta      []byte    `json:"encrypted_meta"`
+	DataType           string    `json:"data_type"`
+	Permission         string    `json:"permission"`
+	Created            time.Time `json:"created"`
+	Modified           time.Time `json:"modified"`
+}
+
+// Permissions of the shared items: the recipient either only reads the item or edits its content as well.
+const (
+	SharePermissionRead = "read"
+	SharePermissionEdit = "edit"
+)
+
+// Organization represents a team owning collections of items shared by its members.
+// Role and Accepted describe the membership of the user the organization is listed for.
+type Organization struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	OwnerID  uuid.UUID `json:"owner_id"`
+	Role     string    `json:"role"`
+	Accepted bool      `json:"accepted"`
+	Created  time.Time `json:"created"`
+	Modified time.Time `json:"modified"`
+}
+
+// OrgMember represents the membership of a user in an organization. Invited members are not Accepted until
+// they answer the invitation. The login and the public sharing key of the member are filled in by the queries.
+type OrgMember struct {
+	OrgID     uuid.UUID `json:"org_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Login     string    `json:"login"`
+	Role      string    `json:"role"`
+	Accepted  bool      `json:"accepted"`
+	PublicKey []byte    `json:"public_key"`
+	Created   time.Time `json:"created"`
+	Modified  time.Time `json:"modified"`
+}
+
+// Roles of the organization members: the owner and the admins manage the members and the collections,
+// the members change the items and the read-only members only read them.
+const (
+	OrgRoleOwner    = "owner"
+	OrgRoleAdmin    = "admin"
+	OrgRoleMember   = "member"
+	OrgRoleReadOnly = "read-only"
+)
+
+// Collection represents a group of items of an organization encrypted with the collection key of version KeyID.
+// WrappedKey holds the current collection key sealed to the member the collection is listed for.
+type Collection struct {
+	ID         uuid.UUID `json:"id"`
+	OrgID      uuid.UUID `json:"org_id"`
+	Name       string    `json:"name"`
+	KeyID      uint32    `json:"key_id"`
+	WrappedKey []byte    `json:"wrapped_key"`
+	Created    time.Time `json:"created"`
+	Modified   time.Time `json:"modified"`
+}
+
+// CollectionKey represents the collection key of version KeyID sealed on the client to the public sharing key
+// of a member.
+type CollectionKey struct {
+	CollectionID uuid.UUID `json:"collection_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	KeyID        uint32    `json:"key_id"`
+	WrappedKey   []byte    `json:"wrapped_key"`
+}
+
+// CollectionItem represents an item of a collection. The data is encrypted with a data key wrapped by the collection
+// key of version KeyID, EncryptedMeta holds the title and description sealed with the same collection key.
+// The ID of the item is both the data ID and the metadata ID the ciphertexts are bound to.
+type CollectionItem struct {
+	ID            uuid.UUID `json:"id"`
+	CollectionID  uuid.UUID `json:"collection_id"`
+	Data          []byte    `json:"data"`
+	WrappedKey    []byte    `json:"wrapped_key"`
+	KeyID         uint32    `json:"key_id"`
+	EncryptedMeta []byte    `json:"encrypted_meta"`
+	DataType      string    `json:"data_type"`
+	CreatedBy     uuid.UUID `json:"created_by"`
+	Created       time.Time `json:"created"`
+	Modified      time.Time `json:"modified"`
+}
+
+// EmergencyAccess represents a trusted contact designated by the owner to reach the vault in an emergency.
+// SealedKey holds the vault key of version KeyID sealed on the client of the owner to the public sharing key
+// of the contact. The server releases it to the contact only when the access is approved: by the owner,
+// or when the owner has not rejected the request during WaitPeriod since RequestedAt.
+type EmergencyAccess struct {
+	ID               uuid.UUID     `json:"id"`
+	OwnerID          uuid.UUID     `json:"owner_id"`
+	OwnerLogin       string        `json:"owner_login"`
+	ContactID        uuid.UUID     `json:"contact_id"`
+	ContactLogin     string        `json:"contact_login"`
+	ContactPublicKey []byte        `json:"contact_public_key"`
+	WaitPeriod       time.Duration `json:"wait_period"`
+	Status           string        `json:"status"`
+	RequestedAt      time.Time     `json:"requested_at"`
+	KeyID            uint32        `json:"key_id"`
+	SealedKey        []byte        `json:"sealed_key"`
+	Created          time.Time     `json:"created"`
+	Modified         time.Time     `json:"modified"`
+}
+
+// Statuses of the emergency access: granted and not requested, requested by the contact and waiting,
+// approved and released to the contact, rejected by the owner.
+const (
+	EmergencyStatusGranted   = "granted"
+	EmergencyStatusRequested = "requested"
+	EmergencyStatusApproved  = "approved"
+	EmergencyStatusRejected  = "rejected"
+)
+
+// ReleaseAt returns the moment the requested access is released to the contact unless the owner rejects it.
+func (a *EmergencyAccess) ReleaseAt() time.Time {
+	return a.RequestedAt.Add(a.WaitPeriod)
+}
+
+// Device represents a client device of the user enrolled for the mutual TLS. The device certificate is signed by the
+// client CA of the server for the key generated on the device, Fingerprint is the SHA-256 of the certificate.
+// A revoked device is rejected even though its certificate is still valid.
+type Device struct {
 	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Type        string    `json:"data_type"`
-	DataID      uuid.UUID `json:"data_id"`
 	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	Fingerprint string    `json:"fingerprint"`
+	Expires     time.Time `json:"expires"`
 	Created     time.Time `json:"created"`
-	Modified    time.Time `json:"modified"`
+	Revoked     time.Time `json:"revoked"`
 }
 
-// ItemData represents an entity containing a unique identifier and associated byte data.
-type ItemData struct {
-	ID   uuid.UUID `json:"id"`
-	Data []byte    `json:"data"`
+// IsRevoked reports whether the device was revoked by the user.
+func (d *Device) IsRevoked() bool {
+	return !d.Revoked.IsZero()
+}
+
+// APIToken represents a personal access token the user creates for the automation instead of logging in
+// with the password. Hash is the SHA-256 of the token secret, the secret itself is shown to the user once.
+// The token only authenticates the requests: the items stay encrypted with the vault key, which the automation
+// has to hold on its own.
+type APIToken struct {
+	ID       uuid.UUID     `json:"id"`
+	UserID   uuid.UUID     `json:"user_id"`
+	Name     string        `json:"name"`
+	Hash     []byte        `json:"hash"`
+	Scope    APITokenScope `json:"scope"`
+	Expires  time.Time     `json:"expires"`
+	Created  time.Time     `json:"created"`
+	LastUsed time.Time     `json:"last_used"`
+}
+
+// APITokenScope limits what the API token reaches: ReadOnly denies the changes, FolderIDs and DataTypes restrict
+// the items to the folders and the data types. Empty lists don't restrict the items.
+type APITokenScope struct {
+	ReadOnly  bool        `json:"read_only"`
+	FolderIDs []uuid.UUID `json:"folder_ids"`
+	DataTypes []string    `json:"data_types"`
+}
+
+// IsExpired reports whether the token has expired by now.
+func (t *APIToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.Expires)
+}
+
+// AllowsFolder reports whether the folder is within the scope, uuid.Nil is the root of the vault.
+func (s *APITokenScope) AllowsFolder(folderID uuid.UUID) bool {
+	if len(s.FolderIDs) == 0 {
+		return true
+	}
+
+	for _, v := range s.FolderIDs {
+		if v == folderID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsDataType reports whether the data type is within the scope.
+func (s *APITokenScope) AllowsDataType(dataType string) bool {
+	if len(s.DataTypes) == 0 {
+		return true
+	}
+
+	for _, v := range s.DataTypes {
+		if v == dataType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allows reports whether an item of the data type placed into the folder is within the scope.
+func (s *APITokenScope) Allows(folderID uuid.UUID, dataType string) bool {
+	return s.AllowsFolder(folderID) && s.AllowsDataType(dataType)
+}
+
+// AuditEvent represents an event of the append-only audit log of the account: a login, an access to an item,
+// a share or a token change. The events of a user are numbered by Seq and chained by their hashes: Hash covers
+// the fields of the event and PrevHash, the hash of the previous event, so a changed or removed event breaks the chain.
+// ItemID is the ID of the item or of the other object the event is about, DeviceID and APITokenID identify
+// the device certificate and the API token of the request, if any.
+type AuditEvent struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Seq        int64     `json:"seq"`
+	Action     string    `json:"action"`
+	Success    bool      `json:"success"`
+	ItemID     string    `json:"item_id"`
+	DeviceID   uuid.UUID `json:"device_id"`
+	APITokenID uuid.UUID `json:"api_token_id"`
+	Peer       string    `json:"peer"`
+	Details    string    `json:"details"`
+	Created    time.Time `json:"created"`
+	PrevHash   []byte    `json:"prev_hash"`
+	Hash       []byte    `json:"hash"`
+}
+
+// Actions of the audit events.
+const (
+	AuditLogin             = "login"
+	AuditPasswordChange    = "password_change"
+	AuditAccountRecovery   = "account_recovery"
+	AuditRecoveryKitCreate = "recovery_kit_create"
+	AuditItemRead          = "item_read"
+	AuditItemCreate        = "item_create"
+	AuditItemUpdate        = "item_update"
+	AuditItemDelete        = "item_delete"
+	AuditShareCreate       = "share_create"
+	AuditShareRevoke       = "share_revoke"
+	AuditSharedItemRead    = "shared_item_read"
+	AuditSharedItemUpdate  = "shared_item_update"
+	AuditCollectionRead    = "collection_item_read"
+	AuditCollectionWrite   = "collection_item_write"
+	AuditCollectionDelete  = "collection_item_delete"
+	AuditEmergencyRequest  = "emergency_request"
+	AuditEmergencyAnswer   = "emergency_answer"
+	AuditEmergencyItemRead = "emergency_item_read"
+	AuditDeviceEnroll      = "device_enroll"
+	AuditDeviceRevoke      = "device_revoke"
+	AuditAPITokenCreate    = "api_token_create"
+	AuditAPITokenRevoke    = "api_token_revoke"
+)
+
+// Seal chains the event to the previous event of the user with its hash, nil for the first event,
+// and sets the hash of the event. The creation time is kept to microseconds, the precision of the database.
+func (e *AuditEvent) Seal(prevHash []byte) {
+	e.Created = e.Created.UTC().Truncate(time.Microsecond)
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash returns the SHA-256 of the fields of the event and the hash of the previous event.
+// Every field is length prefixed, so the values can't be shifted between the fields.
+func (e *AuditEvent) ComputeHash() []byte {
+	h := sha256.New()
+
+	writeField := func(value []byte) {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(value)))
+		h.Write(value)
+	}
+
+	var success byte
+	if e.Success {
+		success = 1
+	}
+
+	writeField(e.UserID[:])
+	writeField(binary.BigEndian.AppendUint64(nil, uint64(e.Seq)))
+	writeField([]byte(e.Action))
+	writeField([]byte{success})
+	writeField([]byte(e.ItemID))
+	writeField(e.DeviceID[:])
+	writeField(e.APITokenID[:])
+	writeField([]byte(e.Peer))
+	writeField([]byte(e.Details))
+	writeField(binary.BigEndian.AppendUint64(nil, uint64(e.Created.UTC().UnixMicro())))
+	writeField(e.PrevHash)
+
+	return h.Sum(nil)
+}
+
+// AuditFilter narrows the audit events of a user down to the action, the item and the time range.
+// Empty fields are not applied. The events are ordered from the latest, BeforeSeq and Limit select a page of them.
+type AuditFilter struct {
+	Action    string
+	ItemID    string
+	Since     time.Time
+	Until     time.Time
+	BeforeSeq int64
+	Limit     int
+}
+
+// StorageUsage represents the storage used by the vault of a user: the number of the items and the size
+// of their encrypted data in bytes.
+type StorageUsage struct {
+	UserID uuid.UUID `json:"user_id"`
+	Login  string    `json:"login"`
+	Items  int64     `json:"items"`
+	Bytes  int64     `json:"bytes"`
+}
+
+// StorageTotals represents the storage used on the server as a whole: the number of the users, of their items
+// and the size of the encrypted data of the items in bytes.
+type StorageTotals struct {
+	Users int64 `json:"users"`
+	Items int64 `json:"items"`
+	Bytes int64 `json:"bytes"`
+}
+
+// Quota represents the storage limits of a user: the total size of the encrypted data of the items in bytes,
+// the number of the items and the size of an item. Zero fields are not limited.
+type Quota struct {
+	MaxBytes    int64 `json:"max_bytes"`
+	MaxItems    int64 `json:"max_items"`
+	MaxItemSize int64 `json:"max_item_size"`
+}
+
+// Names of the integrity checks of the stored data.
+const (
+	IntegrityMetaWithoutData  = "meta_without_data"
+	IntegrityOrphanedItemData = "orphaned_item_data"
+	IntegrityMetaWithoutUser  = "meta_without_user"
+	IntegrityForeignFolder    = "meta_foreign_folder"
+	IntegrityShareWithoutData = "share_without_data"
+	IntegrityMissingVaultKey  = "missing_vault_key"
+	IntegrityBrokenAuditChain = "broken_audit_chain"
+)
+
+// IntegrityIssue represents an inconsistency of the stored data found by the integrity check named by Check.
+// ObjectID is the ID of the inconsistent row, UserID is the owner of the row when it is known.
+type IntegrityIssue struct {
+	Check    string    `json:"check"`
+	ObjectID string    `json:"object_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Details  string    `json:"details,omitempty"`
 }
 
 //TODO add OTP Data
@@ -64,3 +64,62 @@ func TestItemDataFields(t *testing.T) {
 	assert.NotNil(t, item.ID)
 	assert.Equal(t, dataBytes, item.Data)
 }
+
+func TestAPITokenScope_Allows(t *testing.T) {
+	folder, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		scope    APITokenScope
+		folderID uuid.UUID
+		dataType string
+		want     bool
+	}{
+		{name: "unrestricted", scope: APITokenScope{}, folderID: other, dataType: "Text", want: true},
+		{name: "allowed folder", scope: APITokenScope{FolderIDs: []uuid.UUID{folder}}, folderID: folder, dataType: "Text", want: true},
+		{name: "other folder", scope: APITokenScope{FolderIDs: []uuid.UUID{folder}}, folderID: other, dataType: "Text"},
+		{name: "root not in folders", scope: APITokenScope{FolderIDs: []uuid.UUID{folder}}, folderID: uuid.Nil, dataType: "Text"},
+		{name: "allowed type", scope: APITokenScope{DataTypes: []string{"Creds"}}, folderID: other, dataType: "Creds", want: true},
+		{name: "other type", scope: APITokenScope{DataTypes: []string{"Creds"}}, folderID: other, dataType: "Cards"},
+		{
+			name:     "folder and type",
+			scope:    APITokenScope{FolderIDs: []uuid.UUID{folder}, DataTypes: []string{"Creds"}},
+			folderID: folder,
+			dataType: "Creds",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.scope.Allows(tt.folderID, tt.dataType))
+		})
+	}
+}
+
+func TestUserData_IsLocked(t *testing.T) {
+	assert.False(t, (&UserData{}).IsLocked())
+	assert.True(t, (&UserData{Locked: time.Now()}).IsLocked())
+}
+
+func TestAPIToken_IsExpired(t *testing.T) {
+	now := time.Now()
+	token := &APIToken{Expires: now}
+
+	assert.True(t, token.IsExpired(now))
+	assert.False(t, token.IsExpired(now.Add(-time.Second)))
+}
+
+func TestAuditEvent_Seal(t *testing.T) {
+	event := &AuditEvent{UserID: uuid.New(), Seq: 1, Action: AuditLogin, Created: time.Date(2025, 1, 1, 10, 0, 0, 1500, time.Local)}
+	event.Seal(nil)
+
+	assert.Equal(t, time.UTC, event.Created.Location())
+	assert.Equal(t, 1000, event.Created.Nanosecond())
+	assert.Len(t, event.Hash, 32)
+	assert.Equal(t, event.Hash, event.ComputeHash())
+
+	chained := *event
+	chained.Seal([]byte("previous"))
+	assert.NotEqual(t, event.Hash, chained.Hash)
+}
@@ -0,0 +1,503 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	vaultKeySize       = 32
+	vaultKeySaltSize   = 16
+	vaultKeyMaxTime    = 10
+	vaultKeyMinMemory  = 8 * 1024
+	vaultKeyMaxMemory  = 1024 * 1024
+	vaultKeyMaxThreads = 16
+	aesGCMOverhead     = 12 + 16
+
+	srpVerifierMaxSize = 256
+
+	recoveryPublicKeySize  = 32
+	recoveryAuthTokenSize  = 32
+	recoveryWrappedKeySize = 1 + 4 + recoveryPublicKeySize + vaultKeySize + aesGCMOverhead
+
+	dataKeyIDSize            = 16
+	sharingPublicKeySize     = 32
+	sharingWrappedKeySize    = 1 + 4 + dataKeyIDSize + sharingPublicKeySize + aesGCMOverhead
+	shareWrappedKeySize      = 1 + dataKeyIDSize + sharingPublicKeySize + vaultKeySize + aesGCMOverhead
+	shareEncryptedMetaMin    = 1 + sharingPublicKeySize + aesGCMOverhead
+	shareEncryptedMetaMaxLen = 64 * 1024
+
+	collectionKeySize        = 1 + 4 + sharingPublicKeySize + vaultKeySize + aesGCMOverhead
+	collectionItemKeySize    = 1 + 4 + dataKeyIDSize + vaultKeySize + aesGCMOverhead
+	collectionMetaHeaderSize = 1 + 4
+
+	emergencyKeySize = 1 + 4 + sharingPublicKeySize + vaultKeySize + aesGCMOverhead
+)
+
+const (
+	tagsLimit       = 32
+	tagLengthLimit  = 64
+	folderNameLimit = 100
+	orgNameLimit    = 100
+	deviceNameLimit = 64
+
+	apiTokenNameLimit   = 64
+	apiTokenScopeLimit  = 32
+	apiTokenMaxLifetime = 365 * 24 * time.Hour
+
+	emergencyMinWait = time.Hour
+	emergencyMaxWait = 90 * 24 * time.Hour
+)
+
+// ErrFolderCycle is returned when a folder is moved into itself or into one of its subfolders.
+var ErrFolderCycle = errors.New("folder can't be moved into itself or its subfolder")
+
+// ErrVaultKeyExists is returned when a vault key is uploaded for a user who already has one.
+var ErrVaultKeyExists = errors.New("vault key already exists")
+
+// ErrVaultKeysChanged is returned when the vault keys re-wrapped with a new password don't match the stored ones,
+// for example because a key rotation was started meanwhile.
+var ErrVaultKeysChanged = errors.New("vault keys have changed, try again")
+
+// ErrSharingKeyExists is returned when a sharing key with another public key is uploaded for a user who already has one,
+// as the items shared with the user are sealed to the stored public key.
+var ErrSharingKeyExists = errors.New("sharing key already exists")
+
+// ErrRotationIncomplete is returned when a vault key rotation is finished while some items are still encrypted
+// with the previous keys.
+var ErrRotationIncomplete = errors.New("some items are still encrypted with the previous vault key")
+
+// ErrOrgPermission is returned when the role of the member doesn't allow the action in the organization.
+var ErrOrgPermission = errors.New("role does not allow the action")
+
+// ErrOrgMemberExists is returned when a user already a member of the organization is invited again.
+var ErrOrgMemberExists = errors.New("user is already a member of the organization")
+
+// ErrCollectionKeyChanged is returned when the items of a collection are saved with a collection key replaced meanwhile.
+var ErrCollectionKeyChanged = errors.New("collection key has changed, try again")
+
+// ErrCollectionItemsChanged is returned when a collection is re-keyed while some of its items are left encrypted
+// with the previous key.
+var ErrCollectionItemsChanged = errors.New("collection items have changed, try again")
+
+// ErrEmergencyAccessExists is returned when the owner designates the same trusted contact twice.
+var ErrEmergencyAccessExists = errors.New("emergency access is already granted to the contact")
+
+// ErrAPITokenExists is returned when the user creates an API token with the name of another token of the user.
+var ErrAPITokenExists = errors.New("API token with the name already exists")
+
+// ErrQuotaExceeded is returned when an item doesn't fit into the storage quota of the user.
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// ErrUserLocked is returned when the account locked by an operator logs in or uses an issued token.
+var ErrUserLocked = errors.New("account is locked")
+
+// CheckQuota checks the item of itemSize bytes fits into the quota of the user with the current usage. replacedSize is
+// the size of the stored data the item replaces, newItem is set when the item adds to the number of the items.
+// The returned error wraps ErrQuotaExceeded and describes the current usage.
+func CheckQuota(quota *Quota, usage *StorageUsage, itemSize int64, replacedSize int64, newItem bool) error {
+	if quota == nil {
+		return nil
+	}
+
+	if err := CheckItemSize(quota, itemSize); err != nil {
+		return err
+	}
+
+	if quota.MaxItems > 0 && newItem && usage.Items+1 > quota.MaxItems {
+		return fmt.Errorf("%w: %d of %d items used", ErrQuotaExceeded, usage.Items, quota.MaxItems)
+	}
+
+	if quota.MaxBytes > 0 && itemSize > replacedSize && usage.Bytes-replacedSize+itemSize > quota.MaxBytes {
+		return fmt.Errorf("%w: %d of %d bytes used, the item needs %d more bytes",
+			ErrQuotaExceeded, usage.Bytes, quota.MaxBytes, itemSize-replacedSize)
+	}
+
+	return nil
+}
+
+// CheckItemSize checks the item of itemSize bytes fits into the limit of the size of an item of the quota.
+// The returned error wraps ErrQuotaExceeded.
+func CheckItemSize(quota *Quota, itemSize int64) error {
+	if quota != nil && quota.MaxItemSize > 0 && itemSize > quota.MaxItemSize {
+		return fmt.Errorf("%w: item of %d bytes exceeds the limit of %d bytes per item",
+			ErrQuotaExceeded, itemSize, quota.MaxItemSize)
+	}
+
+	return nil
+}
+
+// NormalizeTags trims the tags, drops empty ones and duplicates, keeping the order of the first occurrence.
+// Returns an error if there are too many tags or a tag is too long.
+func NormalizeTags(tags []string) ([]string, error) {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, v := range tags {
+		tag := strings.TrimSpace(v)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		if len(tag) > tagLengthLimit {
+			return nil, fmt.Errorf("tag %q is longer than %d characters", tag, tagLengthLimit)
+		}
+
+		seen[tag] = true
+		res = append(res, tag)
+	}
+
+	if len(res) > tagsLimit {
+		return nil, fmt.Errorf("too many tags: %d, limit is %d", len(res), tagsLimit)
+	}
+
+	return res, nil
+}
+
+// ValidateFolder checks the folder name and that its parent is one of the user folders not causing a cycle.
+// The folders are all the stored folders of the user, the validated one may be among them when it is moved.
+func ValidateFolder(folder *Folder, folders []*Folder) error {
+	name := strings.TrimSpace(folder.Name)
+	if name == "" {
+		return fmt.Errorf("empty folder name")
+	}
+	if len(name) > folderNameLimit {
+		return fmt.Errorf("folder name is longer than %d characters", folderNameLimit)
+	}
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("folder name can't contain \"/\"")
+	}
+
+	if folder.ParentID == uuid.Nil {
+		return nil
+	}
+
+	parents := make(map[uuid.UUID]uuid.UUID, len(folders))
+	for _, v := range folders {
+		parents[v.ID] = v.ParentID
+	}
+
+	// Поднимается от нового родителя к корню, проверяя что папка не встречается на пути
+	for id, depth := folder.ParentID, 0; id != uuid.Nil; depth++ {
+		if id == folder.ID || depth > len(folders) {
+			return ErrFolderCycle
+		}
+
+		parentID, ok := parents[id]
+		if !ok {
+			return fmt.Errorf("parent folder %s not found", id)
+		}
+		id = parentID
+	}
+
+	return nil
+}
+
+// ValidateVaultKey checks the wrapped vault key has the size of an AES-GCM sealed 256-bit key and the Argon2id
+// parameters are within the limits the clients are able to derive the wrapping key with.
+func ValidateVaultKey(key *VaultKey) error {
+	if len(key.WrappedKey) != vaultKeySize+aesGCMOverhead {
+		return fmt.Errorf("invalid wrapped key size %d", len(key.WrappedKey))
+	}
+	if err := validateKDF(key.Salt, key.KDFTime, key.KDFMemory, key.KDFThreads); err != nil {
+		return err
+	}
+	if len(key.RecoveryWrappedKey) > 0 {
+		return ValidateRecoveryWrappedKey(key.RecoveryWrappedKey, key.KeyID)
+	}
+
+	return nil
+}
+
+// ValidateSRPRecord checks the SRP verifier of the user fits the size of the group and the Argon2id parameters
+// of the secret are within the limits the clients are able to derive it with.
+func ValidateSRPRecord(user *UserData) error {
+	if len(user.SRPVerifier) == 0 || len(user.SRPVerifier) > srpVerifierMaxSize {
+		return fmt.Errorf("invalid srp verifier size %d", len(user.SRPVerifier))
+	}
+
+	return validateKDF(user.SRPSalt, user.KDFTime, user.KDFMemory, user.KDFThreads)
+}
+
+// validateKDF checks the salt and the Argon2id parameters of a key derived from the master password.
+func validateKDF(salt []byte, time uint32, memory uint32, threads uint32) error {
+	if len(salt) < vaultKeySaltSize {
+		return fmt.Errorf("salt must be at least %d bytes", vaultKeySaltSize)
+	}
+	if time < 1 || time > vaultKeyMaxTime {
+		return fmt.Errorf("kdf time must be between 1 and %d", vaultKeyMaxTime)
+	}
+	if memory < vaultKeyMinMemory || memory > vaultKeyMaxMemory {
+		return fmt.Errorf("kdf memory must be between %d and %d KiB", vaultKeyMinMemory, vaultKeyMaxMemory)
+	}
+	if threads < 1 || threads > vaultKeyMaxThreads {
+		return fmt.Errorf("kdf threads must be between 1 and %d", vaultKeyMaxThreads)
+	}
+
+	return nil
+}
+
+// ValidateRecoveryKit checks the recovery public key is an X25519 key and the auth token has the size of the derived token.
+func ValidateRecoveryKit(publicKey []byte, authToken []byte) error {
+	if len(publicKey) != recoveryPublicKeySize {
+		return fmt.Errorf("invalid recovery public key size %d", len(publicKey))
+	}
+	if len(authToken) != recoveryAuthTokenSize {
+		return fmt.Errorf("invalid recovery auth token size %d", len(authToken))
+	}
+
+	return nil
+}
+
+// ValidateRecoveryWrappedKey checks the vault key sealed for the recovery has the size of a sealed 256-bit key
+// with its header and the ephemeral public key, and the header names the vault key it is stored for.
+func ValidateRecoveryWrappedKey(wrapped []byte, keyID uint32) error {
+	if len(wrapped) != recoveryWrappedKeySize {
+		return fmt.Errorf("invalid recovery wrapped key size %d", len(wrapped))
+	}
+	if sealedFor := binary.BigEndian.Uint32(wrapped[1:5]); sealedFor != keyID {
+		return fmt.Errorf("recovery wrapped key is sealed for vault key %d instead of %d", sealedFor, keyID)
+	}
+
+	return nil
+}
+
+// ValidateSharingKey checks the public sharing key is an X25519 key and the private key is wrapped as a 32-byte key
+// with the header naming the vault key it is wrapped with.
+func ValidateSharingKey(key *SharingKey) error {
+	if len(key.PublicKey) != sharingPublicKeySize {
+		return fmt.Errorf("invalid sharing public key size %d", len(key.PublicKey))
+	}
+	if len(key.WrappedPrivateKey) != sharingWrappedKeySize {
+		return fmt.Errorf("invalid wrapped sharing key size %d", len(key.WrappedPrivateKey))
+	}
+	if wrappedWith := binary.BigEndian.Uint32(key.WrappedPrivateKey[1:5]); wrappedWith != key.KeyID {
+		return fmt.Errorf("sharing key is wrapped with vault key %d instead of %d", wrappedWith, key.KeyID)
+	}
+
+	return nil
+}
+
+// ValidateShare checks the permission of the share is known and the data key and metadata are sealed to the recipient
+// in the expected sizes.
+func ValidateShare(share *Share) error {
+	if share.Permission != SharePermissionRead && share.Permission != SharePermissionEdit {
+		return fmt.Errorf("permission must be %s or %s", SharePermissionRead, SharePermissionEdit)
+	}
+	if len(share.WrappedKey) != shareWrappedKeySize {
+		return fmt.Errorf("invalid shared data key size %d", len(share.WrappedKey))
+	}
+	if len(share.EncryptedMeta) < shareEncryptedMetaMin || len(share.EncryptedMeta) > shareEncryptedMetaMaxLen {
+		return fmt.Errorf("shared meta must be between %d and %d bytes", shareEncryptedMetaMin, shareEncryptedMetaMaxLen)
+	}
+
+	return nil
+}
+
+// ValidateOrgName checks the name of an organization or a collection is not empty and fits the limit.
+func ValidateOrgName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty name")
+	}
+	if len([]rune(name)) > orgNameLimit {
+		return fmt.Errorf("name is longer than %d characters", orgNameLimit)
+	}
+
+	return nil
+}
+
+// CanManageOrg reports whether the members with the role manage the members and the collections of the organization.
+func CanManageOrg(role string) bool {
+	return role == OrgRoleOwner || role == OrgRoleAdmin
+}
+
+// CanWriteItems reports whether the members with the role change the items of the collections.
+func CanWriteItems(role string) bool {
+	return CanManageOrg(role) || role == OrgRoleMember
+}
+
+// ValidateRoleChange checks the member with the actor role may give the role to a member currently having
+// the current role, an empty one for an invited user. There is a single owner, who can't be demoted,
+// and only the owner appoints and demotes the admins.
+func ValidateRoleChange(actor string, current string, role string) error {
+	if role != OrgRoleAdmin && role != OrgRoleMember && role != OrgRoleReadOnly {
+		return fmt.Errorf("role must be %s, %s or %s", OrgRoleAdmin, OrgRoleMember, OrgRoleReadOnly)
+	}
+	if !CanManageOrg(actor) || current == OrgRoleOwner {
+		return ErrOrgPermission
+	}
+	if actor != OrgRoleOwner && (current == OrgRoleAdmin || role == OrgRoleAdmin) {
+		return ErrOrgPermission
+	}
+
+	return nil
+}
+
+// ValidateMemberRemoval checks the member with the actor role may remove a member with the given role.
+// The owner is never removed, admins are removed only by the owner.
+func ValidateMemberRemoval(actor string, role string) error {
+	if !CanManageOrg(actor) || role == OrgRoleOwner {
+		return ErrOrgPermission
+	}
+	if actor != OrgRoleOwner && role == OrgRoleAdmin {
+		return ErrOrgPermission
+	}
+
+	return nil
+}
+
+// ValidateCollectionKeys checks the collection keys of version keyID are sealed to every one of the members exactly
+// once, in the expected size and with the header naming the key version.
+func ValidateCollectionKeys(keys []*CollectionKey, members []*OrgMember, keyID uint32) error {
+	expected := make(map[uuid.UUID]bool, len(members))
+	for _, v := range members {
+		expected[v.UserID] = true
+	}
+
+	for _, v := range keys {
+		if !expected[v.UserID] {
+			return fmt.Errorf("collection key for %s is not expected", v.UserID)
+		}
+		delete(expected, v.UserID)
+
+		if v.KeyID != keyID {
+			return fmt.Errorf("collection key version %d instead of %d", v.KeyID, keyID)
+		}
+		if len(v.WrappedKey) != collectionKeySize {
+			return fmt.Errorf("invalid collection key size %d", len(v.WrappedKey))
+		}
+		if sealedFor := binary.BigEndian.Uint32(v.WrappedKey[1:5]); sealedFor != keyID {
+			return fmt.Errorf("collection key is sealed as version %d instead of %d", sealedFor, keyID)
+		}
+	}
+
+	if len(expected) > 0 {
+		return fmt.Errorf("collection key is missing for %d members", len(expected))
+	}
+
+	return nil
+}
+
+// ValidateCollectionItem checks the item data is present and its data key and metadata are encrypted
+// with the collection key of the item version.
+func ValidateCollectionItem(item *CollectionItem) error {
+	if len(item.Data) == 0 {
+		return fmt.Errorf("empty item data")
+	}
+	if item.DataType == "" {
+		return fmt.Errorf("empty data type")
+	}
+	if len(item.WrappedKey) != collectionItemKeySize {
+		return fmt.Errorf("invalid wrapped data key size %d", len(item.WrappedKey))
+	}
+	if wrappedWith := binary.BigEndian.Uint32(item.WrappedKey[1:5]); wrappedWith != item.KeyID {
+		return fmt.Errorf("data key is wrapped with collection key %d instead of %d", wrappedWith, item.KeyID)
+	}
+	if len(item.EncryptedMeta) <= collectionMetaHeaderSize+aesGCMOverhead || len(item.EncryptedMeta) > shareEncryptedMetaMaxLen {
+		return fmt.Errorf("encrypted meta must be between %d and %d bytes", collectionMetaHeaderSize+aesGCMOverhead+1,
+			shareEncryptedMetaMaxLen)
+	}
+	if sealedWith := binary.BigEndian.Uint32(item.EncryptedMeta[1:5]); sealedWith != item.KeyID {
+		return fmt.Errorf("meta is sealed with collection key %d instead of %d", sealedWith, item.KeyID)
+	}
+
+	return nil
+}
+
+// ValidateEmergencyWait checks the waiting period of the emergency access is between an hour and 90 days.
+func ValidateEmergencyWait(wait time.Duration) error {
+	if wait < emergencyMinWait || wait > emergencyMaxWait {
+		return fmt.Errorf("waiting period must be between %s and %s", emergencyMinWait, emergencyMaxWait)
+	}
+
+	return nil
+}
+
+// ValidateEmergencyKey checks the vault key sealed to the trusted contact has the size of a sealed 256-bit key
+// with its header and the ephemeral public key, and the header names the vault key it is sealed for.
+func ValidateEmergencyKey(sealed []byte, keyID uint32) error {
+	if len(sealed) != emergencyKeySize {
+		return fmt.Errorf("invalid emergency key size %d", len(sealed))
+	}
+	if sealedFor := binary.BigEndian.Uint32(sealed[1:5]); sealedFor != keyID {
+		return fmt.Errorf("emergency key is sealed for vault key %d instead of %d", sealedFor, keyID)
+	}
+
+	return nil
+}
+
+// ValidateDeviceName checks the name of an enrolled device is not empty and fits the limit.
+func ValidateDeviceName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("empty device name")
+	}
+	if len([]rune(name)) > deviceNameLimit {
+		return fmt.Errorf("device name is longer than %d characters", deviceNameLimit)
+	}
+
+	return nil
+}
+
+// ValidateAPIToken checks the name of the API token, its expiry is in the future within a year from now,
+// and the scope lists fit the limit.
+func ValidateAPIToken(token *APIToken, now time.Time) error {
+	if strings.TrimSpace(token.Name) == "" {
+		return fmt.Errorf("empty token name")
+	}
+	if len([]rune(token.Name)) > apiTokenNameLimit {
+		return fmt.Errorf("token name is longer than %d characters", apiTokenNameLimit)
+	}
+
+	if !token.Expires.After(now) {
+		return fmt.Errorf("token expiry must be in the future")
+	}
+	if token.Expires.Sub(now) > apiTokenMaxLifetime {
+		return fmt.Errorf("token can't be valid for more than %d days", int(apiTokenMaxLifetime.Hours()/24))
+	}
+
+	if len(token.Scope.FolderIDs) > apiTokenScopeLimit || len(token.Scope.DataTypes) > apiTokenScopeLimit {
+		return fmt.Errorf("token scope is limited to %d folders and %d data types", apiTokenScopeLimit, apiTokenScopeLimit)
+	}
+	for _, v := range token.Scope.DataTypes {
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("empty data type in token scope")
+		}
+	}
+
+	return nil
+}
+
+// VerifyAuditChain checks the hash of every event and the links between the consecutive events of the user.
+// The events are ordered from the latest, as they are listed. The events of a filtered list are not consecutive,
+// only the hashes of such events and the links of the neighbours are checked.
+func VerifyAuditChain(events []*AuditEvent) error {
+	for i, v := range events {
+		if !bytes.Equal(v.Hash, v.ComputeHash()) {
+			return fmt.Errorf("event %d is altered", v.Seq)
+		}
+
+		if v.Seq == 1 && len(v.PrevHash) != 0 {
+			return fmt.Errorf("first event has a previous one")
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		next := events[i-1]
+		if next.UserID != v.UserID || next.Seq <= v.Seq {
+			return fmt.Errorf("event %d is out of order", next.Seq)
+		}
+
+		if next.Seq == v.Seq+1 && !bytes.Equal(next.PrevHash, v.Hash) {
+			return fmt.Errorf("event %d does not follow event %d", next.Seq, v.Seq)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,660 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "trims and deduplicates",
+			tags:    []string{" work ", "", "home", "work"},
+			want:    []string{"work", "home"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "empty",
+			tags:    nil,
+			want:    []string{},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "too long tag",
+			tags:    []string{strings.Repeat("a", tagLengthLimit+1)},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateFolder(t *testing.T) {
+	root := &Folder{ID: uuid.New(), Name: "Work"}
+	child := &Folder{ID: uuid.New(), Name: "Servers", ParentID: root.ID}
+	folders := []*Folder{root, child}
+
+	tests := []struct {
+		name    string
+		folder  *Folder
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "top level",
+			folder:  &Folder{ID: uuid.New(), Name: "Home"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "nested",
+			folder:  &Folder{ID: uuid.New(), Name: "Db", ParentID: child.ID},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "empty name",
+			folder:  &Folder{ID: uuid.New(), Name: " "},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "slash in name",
+			folder:  &Folder{ID: uuid.New(), Name: "a/b"},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "unknown parent",
+			folder:  &Folder{ID: uuid.New(), Name: "Db", ParentID: uuid.New()},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "moved into own subfolder",
+			folder:  &Folder{ID: root.ID, Name: "Work", ParentID: child.ID},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateFolder(tt.folder, folders))
+		})
+	}
+}
+
+func TestValidateVaultKey(t *testing.T) {
+	valid := func() *VaultKey {
+		return &VaultKey{
+			WrappedKey: make([]byte, vaultKeySize+aesGCMOverhead),
+			Salt:       make([]byte, vaultKeySaltSize),
+			KDFTime:    3,
+			KDFMemory:  64 * 1024,
+			KDFThreads: 4,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*VaultKey)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", modify: func(*VaultKey) {}, wantErr: assert.NoError},
+		{name: "short key", modify: func(k *VaultKey) { k.WrappedKey = k.WrappedKey[1:] }, wantErr: assert.Error},
+		{name: "short salt", modify: func(k *VaultKey) { k.Salt = k.Salt[1:] }, wantErr: assert.Error},
+		{name: "zero time", modify: func(k *VaultKey) { k.KDFTime = 0 }, wantErr: assert.Error},
+		{name: "huge memory", modify: func(k *VaultKey) { k.KDFMemory = vaultKeyMaxMemory + 1 }, wantErr: assert.Error},
+		{name: "zero threads", modify: func(k *VaultKey) { k.KDFThreads = 0 }, wantErr: assert.Error},
+		{name: "recovery key", modify: func(k *VaultKey) { k.RecoveryWrappedKey = make([]byte, recoveryWrappedKeySize) }, wantErr: assert.NoError},
+		{name: "short recovery key", modify: func(k *VaultKey) { k.RecoveryWrappedKey = make([]byte, recoveryWrappedKeySize-1) }, wantErr: assert.Error},
+		{name: "recovery key of another key", modify: func(k *VaultKey) {
+			k.KeyID = 2
+			k.RecoveryWrappedKey = make([]byte, recoveryWrappedKeySize)
+		}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := valid()
+			tt.modify(key)
+			tt.wantErr(t, ValidateVaultKey(key))
+		})
+	}
+}
+
+func TestValidateSRPRecord(t *testing.T) {
+	valid := func() *UserData {
+		return &UserData{
+			SRPSalt:     make([]byte, vaultKeySaltSize),
+			SRPVerifier: make([]byte, srpVerifierMaxSize),
+			KDFTime:     3,
+			KDFMemory:   64 * 1024,
+			KDFThreads:  4,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*UserData)
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", modify: func(*UserData) {}, wantErr: assert.NoError},
+		{name: "empty verifier", modify: func(u *UserData) { u.SRPVerifier = nil }, wantErr: assert.Error},
+		{name: "long verifier", modify: func(u *UserData) { u.SRPVerifier = make([]byte, srpVerifierMaxSize+1) }, wantErr: assert.Error},
+		{name: "short salt", modify: func(u *UserData) { u.SRPSalt = u.SRPSalt[1:] }, wantErr: assert.Error},
+		{name: "small memory", modify: func(u *UserData) { u.KDFMemory = vaultKeyMinMemory - 1 }, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := valid()
+			tt.modify(user)
+			tt.wantErr(t, ValidateSRPRecord(user))
+		})
+	}
+}
+
+func TestValidateRecoveryKit(t *testing.T) {
+	tests := []struct {
+		name      string
+		publicKey []byte
+		authToken []byte
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{name: "valid", publicKey: make([]byte, 32), authToken: make([]byte, 32), wantErr: assert.NoError},
+		{name: "empty public key", authToken: make([]byte, 32), wantErr: assert.Error},
+		{name: "short auth token", publicKey: make([]byte, 32), authToken: make([]byte, 16), wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateRecoveryKit(tt.publicKey, tt.authToken))
+		})
+	}
+}
+
+func TestValidateSharingKey(t *testing.T) {
+	wrapped := func(keyID byte) []byte {
+		res := make([]byte, 81)
+		res[0], res[4] = 1, keyID
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		key     *SharingKey
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", key: &SharingKey{PublicKey: make([]byte, 32), WrappedPrivateKey: wrapped(2), KeyID: 2}, wantErr: assert.NoError},
+		{name: "short public key", key: &SharingKey{PublicKey: make([]byte, 16), WrappedPrivateKey: wrapped(2), KeyID: 2}, wantErr: assert.Error},
+		{name: "short wrapped key", key: &SharingKey{PublicKey: make([]byte, 32), WrappedPrivateKey: make([]byte, 40), KeyID: 2}, wantErr: assert.Error},
+		{name: "wrapped with another vault key", key: &SharingKey{PublicKey: make([]byte, 32), WrappedPrivateKey: wrapped(1), KeyID: 2}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateSharingKey(tt.key))
+		})
+	}
+}
+
+func TestValidateShare(t *testing.T) {
+	tests := []struct {
+		name    string
+		share   *Share
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "valid",
+			share:   &Share{Permission: SharePermissionEdit, WrappedKey: make([]byte, 109), EncryptedMeta: make([]byte, 100)},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "unknown permission",
+			share:   &Share{Permission: "owner", WrappedKey: make([]byte, 109), EncryptedMeta: make([]byte, 100)},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "short data key",
+			share:   &Share{Permission: SharePermissionRead, WrappedKey: make([]byte, 60), EncryptedMeta: make([]byte, 100)},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "empty meta",
+			share:   &Share{Permission: SharePermissionRead, WrappedKey: make([]byte, 109)},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateShare(tt.share))
+		})
+	}
+}
+
+func TestValidateOrgName(t *testing.T) {
+	tests := []struct {
+		name    string
+		orgName string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", orgName: "Team", wantErr: assert.NoError},
+		{name: "empty", orgName: "", wantErr: assert.Error},
+		{name: "too long", orgName: strings.Repeat("o", 101), wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateOrgName(tt.orgName))
+		})
+	}
+}
+
+func TestValidateRoleChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		current string
+		role    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "owner appoints admin", actor: OrgRoleOwner, current: OrgRoleMember, role: OrgRoleAdmin, wantErr: assert.NoError},
+		{name: "owner demotes admin", actor: OrgRoleOwner, current: OrgRoleAdmin, role: OrgRoleReadOnly, wantErr: assert.NoError},
+		{name: "admin invites member", actor: OrgRoleAdmin, current: "", role: OrgRoleMember, wantErr: assert.NoError},
+		{name: "admin restricts member", actor: OrgRoleAdmin, current: OrgRoleMember, role: OrgRoleReadOnly, wantErr: assert.NoError},
+		{name: "admin appoints admin", actor: OrgRoleAdmin, current: OrgRoleMember, role: OrgRoleAdmin, wantErr: assert.Error},
+		{name: "admin demotes admin", actor: OrgRoleAdmin, current: OrgRoleAdmin, role: OrgRoleMember, wantErr: assert.Error},
+		{name: "member invites", actor: OrgRoleMember, current: "", role: OrgRoleReadOnly, wantErr: assert.Error},
+		{name: "owner demoted", actor: OrgRoleOwner, current: OrgRoleOwner, role: OrgRoleAdmin, wantErr: assert.Error},
+		{name: "second owner", actor: OrgRoleOwner, current: OrgRoleAdmin, role: OrgRoleOwner, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateRoleChange(tt.actor, tt.current, tt.role))
+		})
+	}
+}
+
+func TestValidateMemberRemoval(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   string
+		role    string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "owner removes admin", actor: OrgRoleOwner, role: OrgRoleAdmin, wantErr: assert.NoError},
+		{name: "admin removes member", actor: OrgRoleAdmin, role: OrgRoleReadOnly, wantErr: assert.NoError},
+		{name: "admin removes admin", actor: OrgRoleAdmin, role: OrgRoleAdmin, wantErr: assert.Error},
+		{name: "member removes member", actor: OrgRoleMember, role: OrgRoleMember, wantErr: assert.Error},
+		{name: "owner removed", actor: OrgRoleOwner, role: OrgRoleOwner, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateMemberRemoval(tt.actor, tt.role))
+		})
+	}
+}
+
+func TestValidateCollectionKeys(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	members := []*OrgMember{{UserID: first}, {UserID: second}}
+
+	wrapped := func(keyID byte) []byte {
+		res := make([]byte, 97)
+		res[0], res[4] = 1, keyID
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		keys    []*CollectionKey
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "every member",
+			keys:    []*CollectionKey{{UserID: first, KeyID: 2, WrappedKey: wrapped(2)}, {UserID: second, KeyID: 2, WrappedKey: wrapped(2)}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "member missing",
+			keys:    []*CollectionKey{{UserID: first, KeyID: 2, WrappedKey: wrapped(2)}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "member twice",
+			keys:    []*CollectionKey{{UserID: first, KeyID: 2, WrappedKey: wrapped(2)}, {UserID: first, KeyID: 2, WrappedKey: wrapped(2)}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "stranger",
+			keys:    []*CollectionKey{{UserID: first, KeyID: 2, WrappedKey: wrapped(2)}, {UserID: uuid.New(), KeyID: 2, WrappedKey: wrapped(2)}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "another version",
+			keys:    []*CollectionKey{{UserID: first, KeyID: 2, WrappedKey: wrapped(1)}, {UserID: second, KeyID: 2, WrappedKey: wrapped(2)}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "short key",
+			keys:    []*CollectionKey{{UserID: first, KeyID: 2, WrappedKey: make([]byte, 40)}, {UserID: second, KeyID: 2, WrappedKey: wrapped(2)}},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateCollectionKeys(tt.keys, members, 2))
+		})
+	}
+}
+
+func TestValidateCollectionItem(t *testing.T) {
+	withKeyID := func(size int, keyID byte) []byte {
+		res := make([]byte, size)
+		res[0], res[4] = 1, keyID
+		return res
+	}
+
+	item := func(wrappedWith byte, metaWith byte) *CollectionItem {
+		return &CollectionItem{
+			Data:          []byte("data"),
+			DataType:      "Creds",
+			WrappedKey:    withKeyID(81, wrappedWith),
+			KeyID:         3,
+			EncryptedMeta: withKeyID(60, metaWith),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		item    *CollectionItem
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", item: item(3, 3), wantErr: assert.NoError},
+		{name: "data key of another version", item: item(2, 3), wantErr: assert.Error},
+		{name: "meta of another version", item: item(3, 2), wantErr: assert.Error},
+		{name: "empty data", item: &CollectionItem{DataType: "Creds", WrappedKey: withKeyID(81, 3), KeyID: 3, EncryptedMeta: withKeyID(60, 3)}, wantErr: assert.Error},
+		{name: "short meta", item: &CollectionItem{Data: []byte("data"), DataType: "Creds", WrappedKey: withKeyID(81, 3), KeyID: 3, EncryptedMeta: withKeyID(10, 3)}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateCollectionItem(tt.item))
+		})
+	}
+}
+
+func TestValidateEmergencyWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		wait    time.Duration
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "one hour", wait: time.Hour, wantErr: assert.NoError},
+		{name: "a week", wait: 7 * 24 * time.Hour, wantErr: assert.NoError},
+		{name: "too short", wait: time.Minute, wantErr: assert.Error},
+		{name: "too long", wait: 91 * 24 * time.Hour, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateEmergencyWait(tt.wait))
+		})
+	}
+}
+
+func TestValidateEmergencyKey(t *testing.T) {
+	sealed := make([]byte, 97)
+	sealed[0], sealed[4] = 1, 3
+
+	tests := []struct {
+		name    string
+		sealed  []byte
+		keyID   uint32
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", sealed: sealed, keyID: 3, wantErr: assert.NoError},
+		{name: "another vault key", sealed: sealed, keyID: 2, wantErr: assert.Error},
+		{name: "short key", sealed: sealed[:60], keyID: 3, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateEmergencyKey(tt.sealed, tt.keyID))
+		})
+	}
+}
+
+func TestEmergencyAccess_ReleaseAt(t *testing.T) {
+	requested := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	access := &EmergencyAccess{RequestedAt: requested, WaitPeriod: 48 * time.Hour}
+
+	assert.Equal(t, time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC), access.ReleaseAt())
+}
+
+func TestValidateDeviceName(t *testing.T) {
+	tests := []struct {
+		name       string
+		deviceName string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{name: "valid", deviceName: "laptop", wantErr: assert.NoError},
+		{name: "empty", deviceName: "", wantErr: assert.Error},
+		{name: "blank", deviceName: "  ", wantErr: assert.Error},
+		{name: "too long", deviceName: strings.Repeat("d", 65), wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateDeviceName(tt.deviceName))
+		})
+	}
+}
+
+func TestValidateAPIToken(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	scope := func(folders int, types ...string) APITokenScope {
+		res := APITokenScope{DataTypes: types}
+		for range folders {
+			res.FolderIDs = append(res.FolderIDs, uuid.New())
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		token   *APIToken
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", token: &APIToken{Name: "ci", Expires: now.Add(30 * 24 * time.Hour)}, wantErr: assert.NoError},
+		{name: "valid scope", token: &APIToken{Name: "ci", Expires: now.Add(time.Hour), Scope: scope(2, "Creds")}, wantErr: assert.NoError},
+		{name: "year", token: &APIToken{Name: "ci", Expires: now.Add(365 * 24 * time.Hour)}, wantErr: assert.NoError},
+		{name: "empty name", token: &APIToken{Name: " ", Expires: now.Add(time.Hour)}, wantErr: assert.Error},
+		{name: "long name", token: &APIToken{Name: strings.Repeat("n", 65), Expires: now.Add(time.Hour)}, wantErr: assert.Error},
+		{name: "expired", token: &APIToken{Name: "ci", Expires: now}, wantErr: assert.Error},
+		{name: "too long lifetime", token: &APIToken{Name: "ci", Expires: now.Add(366 * 24 * time.Hour)}, wantErr: assert.Error},
+		{name: "too many folders", token: &APIToken{Name: "ci", Expires: now.Add(time.Hour), Scope: scope(33)}, wantErr: assert.Error},
+		{name: "empty data type", token: &APIToken{Name: "ci", Expires: now.Add(time.Hour), Scope: scope(0, "")}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, ValidateAPIToken(tt.token, now))
+		})
+	}
+}
+
+// auditChain builds the chain of the events of the user with the actions, ordered from the latest.
+func auditChain(userID uuid.UUID, actions ...string) []*AuditEvent {
+	var prev []byte
+	res := make([]*AuditEvent, len(actions))
+	for i, action := range actions {
+		event := &AuditEvent{
+			UserID:  userID,
+			Seq:     int64(i + 1),
+			Action:  action,
+			Success: true,
+			Created: time.Now().Add(time.Duration(i) * time.Second),
+		}
+		event.Seal(prev)
+		prev = event.Hash
+		res[len(actions)-1-i] = event
+	}
+
+	return res
+}
+
+func TestCheckQuota(t *testing.T) {
+	quota := &Quota{MaxBytes: 1000, MaxItems: 10, MaxItemSize: 500}
+	usage := &StorageUsage{Items: 9, Bytes: 800}
+
+	tests := []struct {
+		name         string
+		quota        *Quota
+		usage        *StorageUsage
+		itemSize     int64
+		replacedSize int64
+		newItem      bool
+		wantErr      string
+	}{
+		{name: "no quota", quota: nil, usage: usage, itemSize: 1 << 30, newItem: true},
+		{name: "unlimited", quota: &Quota{}, usage: usage, itemSize: 1 << 30, newItem: true},
+		{name: "fits", quota: quota, usage: usage, itemSize: 200, newItem: true},
+		{name: "item too large", quota: quota, usage: usage, itemSize: 501, wantErr: "item of 501 bytes exceeds the limit of 500 bytes"},
+		{name: "too many items", quota: quota, usage: &StorageUsage{Items: 10}, itemSize: 1, newItem: true, wantErr: "10 of 10 items used"},
+		{name: "update at item limit", quota: quota, usage: &StorageUsage{Items: 10}, itemSize: 1},
+		{name: "too many bytes", quota: quota, usage: usage, itemSize: 201, newItem: true, wantErr: "800 of 1000 bytes used, the item needs 201 more bytes"},
+		{name: "replacing frees bytes", quota: quota, usage: usage, itemSize: 400, replacedSize: 300},
+		{name: "shrinking over quota", quota: quota, usage: &StorageUsage{Items: 1, Bytes: 2000}, itemSize: 100, replacedSize: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckQuota(tt.quota, tt.usage, tt.itemSize, tt.replacedSize, tt.newItem)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrQuotaExceeded)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCheckItemSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    *Quota
+		itemSize int64
+		wantErr  bool
+	}{
+		{name: "no quota", quota: nil, itemSize: 1 << 30},
+		{name: "unlimited", quota: &Quota{MaxBytes: 10}, itemSize: 1 << 30},
+		{name: "at limit", quota: &Quota{MaxItemSize: 500}, itemSize: 500},
+		{name: "over limit", quota: &Quota{MaxItemSize: 500}, itemSize: 501, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckItemSize(tt.quota, tt.itemSize)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrQuotaExceeded)
+		})
+	}
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		events  func() []*AuditEvent
+		wantErr bool
+	}{
+		{
+			name:   "valid chain",
+			events: func() []*AuditEvent { return auditChain(userID, AuditLogin, AuditItemRead, AuditItemDelete) },
+		},
+		{
+			name: "filtered chain",
+			events: func() []*AuditEvent {
+				events := auditChain(userID, AuditLogin, AuditItemRead, AuditItemDelete)
+				return []*AuditEvent{events[0], events[2]}
+			},
+		},
+		{
+			name:   "empty",
+			events: func() []*AuditEvent { return nil },
+		},
+		{
+			name: "altered event",
+			events: func() []*AuditEvent {
+				events := auditChain(userID, AuditLogin, AuditItemRead)
+				events[1].Success = false
+				return events
+			},
+			wantErr: true,
+		},
+		{
+			name: "removed event",
+			events: func() []*AuditEvent {
+				events := auditChain(userID, AuditLogin, AuditItemRead, AuditItemDelete)
+				// Удаленное событие заменяется пересчитанным, но звено цепочки не совпадает
+				events[1].Action = AuditItemUpdate
+				events[1].Seal(events[2].Hash)
+				return events
+			},
+			wantErr: true,
+		},
+		{
+			name: "first event with previous",
+			events: func() []*AuditEvent {
+				events := auditChain(userID, AuditLogin)
+				events[0].Seal([]byte("forged"))
+				return events
+			},
+			wantErr: true,
+		},
+		{
+			name: "out of order",
+			events: func() []*AuditEvent {
+				events := auditChain(userID, AuditLogin, AuditItemRead)
+				return []*AuditEvent{events[1], events[0]}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyAuditChain(tt.events())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
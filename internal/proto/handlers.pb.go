@@ -22,10 +22,13 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Вход по паролю сохранен для учетных записей, созданных до входа по SRP:
+// после проверки пароля сервер сохраняет srp_record и удаляет хэш пароля
 type PostUserDataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	SrpRecord     *SrpRecord             `protobuf:"bytes,3,opt,name=srp_record,json=srpRecord,proto3" json:"srp_record,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,13 +77,24 @@ func (x *PostUserDataRequest) GetPassword() string {
 	return ""
 }
 
+func (x *PostUserDataRequest) GetSrpRecord() *SrpRecord {
+	if x != nil {
+		return x.SrpRecord
+	}
+	return nil
+}
+
 type PostUserDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"` // ошибка
-	Jwt           string                 `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Error             string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"` // ошибка
+	Jwt               string                 `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	UserId            string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultKey          *VaultKey              `protobuf:"bytes,4,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`                              // пустой, если ключ хранилища еще не загружен
+	PendingVaultKey   *VaultKey              `protobuf:"bytes,5,opt,name=pending_vault_key,json=pendingVaultKey,proto3" json:"pending_vault_key,omitempty"`       // новый ключ незавершенной ротации
+	RecoveryPublicKey []byte                 `protobuf:"bytes,6,opt,name=recovery_public_key,json=recoveryPublicKey,proto3" json:"recovery_public_key,omitempty"` // пустой, если набор восстановления не создан
+	ServerProof       []byte                 `protobuf:"bytes,7,opt,name=server_proof,json=serverProof,proto3" json:"server_proof,omitempty"`                     // доказательство сервера M2 при входе по SRP
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *PostUserDataResponse) Reset() {
@@ -134,29 +148,60 @@ func (x *PostUserDataResponse) GetUserId() string {
 	return ""
 }
 
-type PostItemDataRequest struct {
+func (x *PostUserDataResponse) GetVaultKey() *VaultKey {
+	if x != nil {
+		return x.VaultKey
+	}
+	return nil
+}
+
+func (x *PostUserDataResponse) GetPendingVaultKey() *VaultKey {
+	if x != nil {
+		return x.PendingVaultKey
+	}
+	return nil
+}
+
+func (x *PostUserDataResponse) GetRecoveryPublicKey() []byte {
+	if x != nil {
+		return x.RecoveryPublicKey
+	}
+	return nil
+}
+
+func (x *PostUserDataResponse) GetServerProof() []byte {
+	if x != nil {
+		return x.ServerProof
+	}
+	return nil
+}
+
+// Регистрационная запись SRP-6a: верификатор секрета, полученного из мастер-пароля (Argon2id)
+type SrpRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	DataId        string                 `protobuf:"bytes,2,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
-	MetaData      *MetaData              `protobuf:"bytes,3,opt,name=meta_data,json=metaData,proto3" json:"meta_data,omitempty"`
+	Salt          []byte                 `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	Verifier      []byte                 `protobuf:"bytes,2,opt,name=verifier,proto3" json:"verifier,omitempty"` // пустой в ответе StartLogin
+	KdfTime       uint32                 `protobuf:"varint,3,opt,name=kdf_time,json=kdfTime,proto3" json:"kdf_time,omitempty"`
+	KdfMemory     uint32                 `protobuf:"varint,4,opt,name=kdf_memory,json=kdfMemory,proto3" json:"kdf_memory,omitempty"`
+	KdfThreads    uint32                 `protobuf:"varint,5,opt,name=kdf_threads,json=kdfThreads,proto3" json:"kdf_threads,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostItemDataRequest) Reset() {
-	*x = PostItemDataRequest{}
+func (x *SrpRecord) Reset() {
+	*x = SrpRecord{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostItemDataRequest) String() string {
+func (x *SrpRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostItemDataRequest) ProtoMessage() {}
+func (*SrpRecord) ProtoMessage() {}
 
-func (x *PostItemDataRequest) ProtoReflect() protoreflect.Message {
+func (x *SrpRecord) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -168,55 +213,68 @@ func (x *PostItemDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostItemDataRequest.ProtoReflect.Descriptor instead.
-func (*PostItemDataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SrpRecord.ProtoReflect.Descriptor instead.
+func (*SrpRecord) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *PostItemDataRequest) GetData() []byte {
+func (x *SrpRecord) GetSalt() []byte {
 	if x != nil {
-		return x.Data
+		return x.Salt
 	}
 	return nil
 }
 
-func (x *PostItemDataRequest) GetDataId() string {
+func (x *SrpRecord) GetVerifier() []byte {
 	if x != nil {
-		return x.DataId
+		return x.Verifier
 	}
-	return ""
+	return nil
 }
 
-func (x *PostItemDataRequest) GetMetaData() *MetaData {
+func (x *SrpRecord) GetKdfTime() uint32 {
 	if x != nil {
-		return x.MetaData
+		return x.KdfTime
 	}
-	return nil
+	return 0
 }
 
-type PostItemDataResponse struct {
+func (x *SrpRecord) GetKdfMemory() uint32 {
+	if x != nil {
+		return x.KdfMemory
+	}
+	return 0
+}
+
+func (x *SrpRecord) GetKdfThreads() uint32 {
+	if x != nil {
+		return x.KdfThreads
+	}
+	return 0
+}
+
+type RegisterUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DataId        string                 `protobuf:"bytes,1,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
-	Created       string                 `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
-	Modified      string                 `protobuf:"bytes,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	SrpRecord     *SrpRecord             `protobuf:"bytes,2,opt,name=srp_record,json=srpRecord,proto3" json:"srp_record,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PostItemDataResponse) Reset() {
-	*x = PostItemDataResponse{}
+func (x *RegisterUserRequest) Reset() {
+	*x = RegisterUserRequest{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PostItemDataResponse) String() string {
+func (x *RegisterUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostItemDataResponse) ProtoMessage() {}
+func (*RegisterUserRequest) ProtoMessage() {}
 
-func (x *PostItemDataResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -228,53 +286,46 @@ func (x *PostItemDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostItemDataResponse.ProtoReflect.Descriptor instead.
-func (*PostItemDataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterUserRequest.ProtoReflect.Descriptor instead.
+func (*RegisterUserRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *PostItemDataResponse) GetDataId() string {
-	if x != nil {
-		return x.DataId
-	}
-	return ""
-}
-
-func (x *PostItemDataResponse) GetCreated() string {
+func (x *RegisterUserRequest) GetLogin() string {
 	if x != nil {
-		return x.Created
+		return x.Login
 	}
 	return ""
 }
 
-func (x *PostItemDataResponse) GetModified() string {
+func (x *RegisterUserRequest) GetSrpRecord() *SrpRecord {
 	if x != nil {
-		return x.Modified
+		return x.SrpRecord
 	}
-	return ""
+	return nil
 }
 
-type GetItemDataRequest struct {
+type RegisterUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DataId        string                 `protobuf:"bytes,1,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetItemDataRequest) Reset() {
-	*x = GetItemDataRequest{}
+func (x *RegisterUserResponse) Reset() {
+	*x = RegisterUserResponse{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetItemDataRequest) String() string {
+func (x *RegisterUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetItemDataRequest) ProtoMessage() {}
+func (*RegisterUserResponse) ProtoMessage() {}
 
-func (x *GetItemDataRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterUserResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -286,39 +337,40 @@ func (x *GetItemDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetItemDataRequest.ProtoReflect.Descriptor instead.
-func (*GetItemDataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterUserResponse.ProtoReflect.Descriptor instead.
+func (*RegisterUserResponse) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetItemDataRequest) GetDataId() string {
+func (x *RegisterUserResponse) GetError() string {
 	if x != nil {
-		return x.DataId
+		return x.Error
 	}
 	return ""
 }
 
-type GetItemDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StartLoginRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Login           string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	ClientPublicKey []byte                 `protobuf:"bytes,2,opt,name=client_public_key,json=clientPublicKey,proto3" json:"client_public_key,omitempty"` // A
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *GetItemDataResponse) Reset() {
-	*x = GetItemDataResponse{}
+func (x *StartLoginRequest) Reset() {
+	*x = StartLoginRequest{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetItemDataResponse) String() string {
+func (x *StartLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetItemDataResponse) ProtoMessage() {}
+func (*StartLoginRequest) ProtoMessage() {}
 
-func (x *GetItemDataResponse) ProtoReflect() protoreflect.Message {
+func (x *StartLoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -330,46 +382,49 @@ func (x *GetItemDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetItemDataResponse.ProtoReflect.Descriptor instead.
-func (*GetItemDataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartLoginRequest.ProtoReflect.Descriptor instead.
+func (*StartLoginRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetItemDataResponse) GetData() []byte {
+func (x *StartLoginRequest) GetLogin() string {
 	if x != nil {
-		return x.Data
+		return x.Login
+	}
+	return ""
+}
+
+func (x *StartLoginRequest) GetClientPublicKey() []byte {
+	if x != nil {
+		return x.ClientPublicKey
 	}
 	return nil
 }
 
-type MetaData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	DataType      string                 `protobuf:"bytes,4,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
-	DataId        string                 `protobuf:"bytes,5,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Created       string                 `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
-	Modified      string                 `protobuf:"bytes,8,opt,name=modified,proto3" json:"modified,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type StartLoginResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Error           string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	SessionId       string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ServerPublicKey []byte                 `protobuf:"bytes,3,opt,name=server_public_key,json=serverPublicKey,proto3" json:"server_public_key,omitempty"` // B
+	SrpRecord       *SrpRecord             `protobuf:"bytes,4,opt,name=srp_record,json=srpRecord,proto3" json:"srp_record,omitempty"`                     // соль и параметры Argon2id
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *MetaData) Reset() {
-	*x = MetaData{}
+func (x *StartLoginResponse) Reset() {
+	*x = StartLoginResponse{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MetaData) String() string {
+func (x *StartLoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MetaData) ProtoMessage() {}
+func (*StartLoginResponse) ProtoMessage() {}
 
-func (x *MetaData) ProtoReflect() protoreflect.Message {
+func (x *StartLoginResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,88 +436,61 @@ func (x *MetaData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MetaData.ProtoReflect.Descriptor instead.
-func (*MetaData) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartLoginResponse.ProtoReflect.Descriptor instead.
+func (*StartLoginResponse) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *MetaData) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *MetaData) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *MetaData) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
-}
-
-func (x *MetaData) GetDataType() string {
-	if x != nil {
-		return x.DataType
-	}
-	return ""
-}
-
-func (x *MetaData) GetDataId() string {
+func (x *StartLoginResponse) GetError() string {
 	if x != nil {
-		return x.DataId
+		return x.Error
 	}
 	return ""
 }
 
-func (x *MetaData) GetUserId() string {
+func (x *StartLoginResponse) GetSessionId() string {
 	if x != nil {
-		return x.UserId
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *MetaData) GetCreated() string {
+func (x *StartLoginResponse) GetServerPublicKey() []byte {
 	if x != nil {
-		return x.Created
+		return x.ServerPublicKey
 	}
-	return ""
+	return nil
 }
 
-func (x *MetaData) GetModified() string {
+func (x *StartLoginResponse) GetSrpRecord() *SrpRecord {
 	if x != nil {
-		return x.Modified
+		return x.SrpRecord
 	}
-	return ""
+	return nil
 }
 
-type GetMetaDataRequest struct {
+type FinishLoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ClientProof   []byte                 `protobuf:"bytes,2,opt,name=client_proof,json=clientProof,proto3" json:"client_proof,omitempty"` // M1
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMetaDataRequest) Reset() {
-	*x = GetMetaDataRequest{}
+func (x *FinishLoginRequest) Reset() {
+	*x = FinishLoginRequest{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMetaDataRequest) String() string {
+func (x *FinishLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMetaDataRequest) ProtoMessage() {}
+func (*FinishLoginRequest) ProtoMessage() {}
 
-func (x *GetMetaDataRequest) ProtoReflect() protoreflect.Message {
+func (x *FinishLoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -474,39 +502,53 @@ func (x *GetMetaDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMetaDataRequest.ProtoReflect.Descriptor instead.
-func (*GetMetaDataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FinishLoginRequest.ProtoReflect.Descriptor instead.
+func (*FinishLoginRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetMetaDataRequest) GetUserId() string {
+func (x *FinishLoginRequest) GetSessionId() string {
 	if x != nil {
-		return x.UserId
+		return x.SessionId
 	}
 	return ""
 }
 
-type GetMetaDataResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*MetaData            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *FinishLoginRequest) GetClientProof() []byte {
+	if x != nil {
+		return x.ClientProof
+	}
+	return nil
 }
 
-func (x *GetMetaDataResponse) Reset() {
-	*x = GetMetaDataResponse{}
+// Ключ хранилища, зашифрованный на клиенте ключом из мастер-пароля (Argon2id)
+type VaultKey struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	WrappedKey         []byte                 `protobuf:"bytes,1,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	Salt               []byte                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+	KdfTime            uint32                 `protobuf:"varint,3,opt,name=kdf_time,json=kdfTime,proto3" json:"kdf_time,omitempty"`
+	KdfMemory          uint32                 `protobuf:"varint,4,opt,name=kdf_memory,json=kdfMemory,proto3" json:"kdf_memory,omitempty"`
+	KdfThreads         uint32                 `protobuf:"varint,5,opt,name=kdf_threads,json=kdfThreads,proto3" json:"kdf_threads,omitempty"`
+	KeyId              uint32                 `protobuf:"varint,6,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`                                         // версия ключа, растет с каждой ротацией
+	RecoveryWrappedKey []byte                 `protobuf:"bytes,7,opt,name=recovery_wrapped_key,json=recoveryWrappedKey,proto3" json:"recovery_wrapped_key,omitempty"` // ключ, зашифрованный для открытого ключа восстановления
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *VaultKey) Reset() {
+	*x = VaultKey{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMetaDataResponse) String() string {
+func (x *VaultKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMetaDataResponse) ProtoMessage() {}
+func (*VaultKey) ProtoMessage() {}
 
-func (x *GetMetaDataResponse) ProtoReflect() protoreflect.Message {
+func (x *VaultKey) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -518,41 +560,82 @@ func (x *GetMetaDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMetaDataResponse.ProtoReflect.Descriptor instead.
-func (*GetMetaDataResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VaultKey.ProtoReflect.Descriptor instead.
+func (*VaultKey) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetMetaDataResponse) GetItems() []*MetaData {
+func (x *VaultKey) GetWrappedKey() []byte {
 	if x != nil {
-		return x.Items
+		return x.WrappedKey
 	}
 	return nil
 }
 
-type DeleteMetaDataRequest struct {
+func (x *VaultKey) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *VaultKey) GetKdfTime() uint32 {
+	if x != nil {
+		return x.KdfTime
+	}
+	return 0
+}
+
+func (x *VaultKey) GetKdfMemory() uint32 {
+	if x != nil {
+		return x.KdfMemory
+	}
+	return 0
+}
+
+func (x *VaultKey) GetKdfThreads() uint32 {
+	if x != nil {
+		return x.KdfThreads
+	}
+	return 0
+}
+
+func (x *VaultKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *VaultKey) GetRecoveryWrappedKey() []byte {
+	if x != nil {
+		return x.RecoveryWrappedKey
+	}
+	return nil
+}
+
+type PostVaultKeyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MetadataId    string                 `protobuf:"bytes,1,opt,name=metadata_id,json=metadataId,proto3" json:"metadata_id,omitempty"`
-	MetadataType  string                 `protobuf:"bytes,2,opt,name=metadata_type,json=metadataType,proto3" json:"metadata_type,omitempty"`
-	DataId        string                 `protobuf:"bytes,3,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultKey      *VaultKey              `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMetaDataRequest) Reset() {
-	*x = DeleteMetaDataRequest{}
+func (x *PostVaultKeyRequest) Reset() {
+	*x = PostVaultKeyRequest{}
 	mi := &file_internal_proto_handlers_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMetaDataRequest) String() string {
+func (x *PostVaultKeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMetaDataRequest) ProtoMessage() {}
+func (*PostVaultKeyRequest) ProtoMessage() {}
 
-func (x *DeleteMetaDataRequest) ProtoReflect() protoreflect.Message {
+func (x *PostVaultKeyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_handlers_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -564,54 +647,7163 @@ func (x *DeleteMetaDataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMetaDataRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMetaDataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PostVaultKeyRequest.ProtoReflect.Descriptor instead.
+func (*PostVaultKeyRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DeleteMetaDataRequest) GetMetadataId() string {
+func (x *PostVaultKeyRequest) GetUserId() string {
 	if x != nil {
-		return x.MetadataId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *DeleteMetaDataRequest) GetMetadataType() string {
+func (x *PostVaultKeyRequest) GetVaultKey() *VaultKey {
 	if x != nil {
-		return x.MetadataType
+		return x.VaultKey
 	}
-	return ""
+	return nil
 }
 
-func (x *DeleteMetaDataRequest) GetDataId() string {
-	if x != nil {
-		return x.DataId
-	}
+type PostVaultKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostVaultKeyResponse) Reset() {
+	*x = PostVaultKeyResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostVaultKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostVaultKeyResponse) ProtoMessage() {}
+
+func (x *PostVaultKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostVaultKeyResponse.ProtoReflect.Descriptor instead.
+func (*PostVaultKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PostVaultKeyResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Текущий пароль подтверждается новым обменом SRP: StartLogin и доказательство клиента M1
+type ChangePasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultKeys     []*VaultKey            `protobuf:"bytes,4,rep,name=vault_keys,json=vaultKeys,proto3" json:"vault_keys,omitempty"` // все ключи хранилища, зашифрованные новым паролем
+	SrpSessionId  string                 `protobuf:"bytes,5,opt,name=srp_session_id,json=srpSessionId,proto3" json:"srp_session_id,omitempty"`
+	SrpProof      []byte                 `protobuf:"bytes,6,opt,name=srp_proof,json=srpProof,proto3" json:"srp_proof,omitempty"`
+	SrpRecord     *SrpRecord             `protobuf:"bytes,7,opt,name=srp_record,json=srpRecord,proto3" json:"srp_record,omitempty"` // запись нового пароля
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChangePasswordRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetVaultKeys() []*VaultKey {
+	if x != nil {
+		return x.VaultKeys
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetSrpSessionId() string {
+	if x != nil {
+		return x.SrpSessionId
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetSrpProof() []byte {
+	if x != nil {
+		return x.SrpProof
+	}
+	return nil
+}
+
+func (x *ChangePasswordRequest) GetSrpRecord() *SrpRecord {
+	if x != nil {
+		return x.SrpRecord
+	}
+	return nil
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Jwt           string                 `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"` // новый токен, прежние токены пользователя отозваны
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChangePasswordResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ChangePasswordResponse) GetJwt() string {
+	if x != nil {
+		return x.Jwt
+	}
+	return ""
+}
+
+type PostRecoveryKitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,3,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`            // открытый ключ восстановления X25519
+	AuthToken     []byte                 `protobuf:"bytes,4,opt,name=auth_token,json=authToken,proto3" json:"auth_token,omitempty"`            // токен, подтверждающий владение ключом восстановления
+	VaultKeys     []*VaultKey            `protobuf:"bytes,5,rep,name=vault_keys,json=vaultKeys,proto3" json:"vault_keys,omitempty"`            // key_id и recovery_wrapped_key всех ключей хранилища
+	SrpSessionId  string                 `protobuf:"bytes,6,opt,name=srp_session_id,json=srpSessionId,proto3" json:"srp_session_id,omitempty"` // текущий пароль подтверждается обменом SRP
+	SrpProof      []byte                 `protobuf:"bytes,7,opt,name=srp_proof,json=srpProof,proto3" json:"srp_proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRecoveryKitRequest) Reset() {
+	*x = PostRecoveryKitRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRecoveryKitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRecoveryKitRequest) ProtoMessage() {}
+
+func (x *PostRecoveryKitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRecoveryKitRequest.ProtoReflect.Descriptor instead.
+func (*PostRecoveryKitRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PostRecoveryKitRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostRecoveryKitRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *PostRecoveryKitRequest) GetAuthToken() []byte {
+	if x != nil {
+		return x.AuthToken
+	}
+	return nil
+}
+
+func (x *PostRecoveryKitRequest) GetVaultKeys() []*VaultKey {
+	if x != nil {
+		return x.VaultKeys
+	}
+	return nil
+}
+
+func (x *PostRecoveryKitRequest) GetSrpSessionId() string {
+	if x != nil {
+		return x.SrpSessionId
+	}
+	return ""
+}
+
+func (x *PostRecoveryKitRequest) GetSrpProof() []byte {
+	if x != nil {
+		return x.SrpProof
+	}
+	return nil
+}
+
+type PostRecoveryKitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostRecoveryKitResponse) Reset() {
+	*x = PostRecoveryKitResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostRecoveryKitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostRecoveryKitResponse) ProtoMessage() {}
+
+func (x *PostRecoveryKitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostRecoveryKitResponse.ProtoReflect.Descriptor instead.
+func (*PostRecoveryKitResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PostRecoveryKitResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetRecoveryKitRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	AuthToken     []byte                 `protobuf:"bytes,2,opt,name=auth_token,json=authToken,proto3" json:"auth_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecoveryKitRequest) Reset() {
+	*x = GetRecoveryKitRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecoveryKitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecoveryKitRequest) ProtoMessage() {}
+
+func (x *GetRecoveryKitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecoveryKitRequest.ProtoReflect.Descriptor instead.
+func (*GetRecoveryKitRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRecoveryKitRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *GetRecoveryKitRequest) GetAuthToken() []byte {
+	if x != nil {
+		return x.AuthToken
+	}
+	return nil
+}
+
+type GetRecoveryKitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VaultKeys     []*VaultKey            `protobuf:"bytes,1,rep,name=vault_keys,json=vaultKeys,proto3" json:"vault_keys,omitempty"` // key_id и recovery_wrapped_key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecoveryKitResponse) Reset() {
+	*x = GetRecoveryKitResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecoveryKitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecoveryKitResponse) ProtoMessage() {}
+
+func (x *GetRecoveryKitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecoveryKitResponse.ProtoReflect.Descriptor instead.
+func (*GetRecoveryKitResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRecoveryKitResponse) GetVaultKeys() []*VaultKey {
+	if x != nil {
+		return x.VaultKeys
+	}
+	return nil
+}
+
+type RecoverAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	AuthToken     []byte                 `protobuf:"bytes,2,opt,name=auth_token,json=authToken,proto3" json:"auth_token,omitempty"`
+	VaultKeys     []*VaultKey            `protobuf:"bytes,4,rep,name=vault_keys,json=vaultKeys,proto3" json:"vault_keys,omitempty"` // все ключи хранилища, зашифрованные новым паролем
+	SrpRecord     *SrpRecord             `protobuf:"bytes,5,opt,name=srp_record,json=srpRecord,proto3" json:"srp_record,omitempty"` // запись нового пароля
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoverAccountRequest) Reset() {
+	*x = RecoverAccountRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoverAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverAccountRequest) ProtoMessage() {}
+
+func (x *RecoverAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoverAccountRequest.ProtoReflect.Descriptor instead.
+func (*RecoverAccountRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecoverAccountRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *RecoverAccountRequest) GetAuthToken() []byte {
+	if x != nil {
+		return x.AuthToken
+	}
+	return nil
+}
+
+func (x *RecoverAccountRequest) GetVaultKeys() []*VaultKey {
+	if x != nil {
+		return x.VaultKeys
+	}
+	return nil
+}
+
+func (x *RecoverAccountRequest) GetSrpRecord() *SrpRecord {
+	if x != nil {
+		return x.SrpRecord
+	}
+	return nil
+}
+
+type RecoverAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Jwt           string                 `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoverAccountResponse) Reset() {
+	*x = RecoverAccountResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoverAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoverAccountResponse) ProtoMessage() {}
+
+func (x *RecoverAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoverAccountResponse.ProtoReflect.Descriptor instead.
+func (*RecoverAccountResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecoverAccountResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RecoverAccountResponse) GetJwt() string {
+	if x != nil {
+		return x.Jwt
+	}
+	return ""
+}
+
+func (x *RecoverAccountResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PostItemDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	DataId        string                 `protobuf:"bytes,2,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	MetaData      *MetaData              `protobuf:"bytes,3,opt,name=meta_data,json=metaData,proto3" json:"meta_data,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,4,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // ключ данных записи, зашифрованный ключом хранилища
+	KeyId         uint32                 `protobuf:"varint,5,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`               // версия ключа хранилища, которым зашифрован ключ данных
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostItemDataRequest) Reset() {
+	*x = PostItemDataRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostItemDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostItemDataRequest) ProtoMessage() {}
+
+func (x *PostItemDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostItemDataRequest.ProtoReflect.Descriptor instead.
+func (*PostItemDataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PostItemDataRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PostItemDataRequest) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *PostItemDataRequest) GetMetaData() *MetaData {
+	if x != nil {
+		return x.MetaData
+	}
+	return nil
+}
+
+func (x *PostItemDataRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *PostItemDataRequest) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type PostItemDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataId        string                 `protobuf:"bytes,1,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	Created       string                 `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      string                 `protobuf:"bytes,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostItemDataResponse) Reset() {
+	*x = PostItemDataResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostItemDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostItemDataResponse) ProtoMessage() {}
+
+func (x *PostItemDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostItemDataResponse.ProtoReflect.Descriptor instead.
+func (*PostItemDataResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PostItemDataResponse) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *PostItemDataResponse) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *PostItemDataResponse) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+type GetItemDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataId        string                 `protobuf:"bytes,1,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemDataRequest) Reset() {
+	*x = GetItemDataRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemDataRequest) ProtoMessage() {}
+
+func (x *GetItemDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemDataRequest.ProtoReflect.Descriptor instead.
+func (*GetItemDataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetItemDataRequest) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+type GetItemDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // пустой для записей, зашифрованных до перехода на ключи данных
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemDataResponse) Reset() {
+	*x = GetItemDataResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemDataResponse) ProtoMessage() {}
+
+func (x *GetItemDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemDataResponse.ProtoReflect.Descriptor instead.
+func (*GetItemDataResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetItemDataResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetItemDataResponse) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUsageRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Использование хранилища пользователем и его квота, нулевые ограничения не применяются
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         int64                  `protobuf:"varint,1,opt,name=items,proto3" json:"items,omitempty"`
+	Bytes         int64                  `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	MaxItems      int64                  `protobuf:"varint,3,opt,name=max_items,json=maxItems,proto3" json:"max_items,omitempty"`
+	MaxBytes      int64                  `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	MaxItemSize   int64                  `protobuf:"varint,5,opt,name=max_item_size,json=maxItemSize,proto3" json:"max_item_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetUsageResponse) GetItems() int64 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetMaxItems() int64 {
+	if x != nil {
+		return x.MaxItems
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetMaxItemSize() int64 {
+	if x != nil {
+		return x.MaxItemSize
+	}
+	return 0
+}
+
+type MetaData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	DataType      string                 `protobuf:"bytes,4,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	DataId        string                 `protobuf:"bytes,5,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Created       string                 `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      string                 `protobuf:"bytes,8,opt,name=modified,proto3" json:"modified,omitempty"`
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	FolderId      string                 `protobuf:"bytes,10,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	EncryptedMeta []byte                 `protobuf:"bytes,11,opt,name=encrypted_meta,json=encryptedMeta,proto3" json:"encrypted_meta,omitempty"` // название, описание и теги, зашифрованные ключом хранилища
+	MetaKeyId     uint32                 `protobuf:"varint,12,opt,name=meta_key_id,json=metaKeyId,proto3" json:"meta_key_id,omitempty"`          // версия ключа хранилища, которым зашифрованы метаданные
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetaData) Reset() {
+	*x = MetaData{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetaData) ProtoMessage() {}
+
+func (x *MetaData) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetaData.ProtoReflect.Descriptor instead.
+func (*MetaData) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MetaData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MetaData) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MetaData) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MetaData) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *MetaData) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *MetaData) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MetaData) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *MetaData) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+func (x *MetaData) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *MetaData) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *MetaData) GetEncryptedMeta() []byte {
+	if x != nil {
+		return x.EncryptedMeta
+	}
+	return nil
+}
+
+func (x *MetaData) GetMetaKeyId() uint32 {
+	if x != nil {
+		return x.MetaKeyId
+	}
+	return 0
+}
+
+type GetMetaDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`                                          // только элементы с тегом
+	FolderId      string                 `protobuf:"bytes,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`                // только элементы папки
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`               // размер страницы, по умолчанию 100, не более 1000
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`             // next_page_token предыдущей страницы
+	DataType      string                 `protobuf:"bytes,6,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`                // только элементы категории
+	ModifiedSince string                 `protobuf:"bytes,7,opt,name=modified_since,json=modifiedSince,proto3" json:"modified_since,omitempty"` // только измененные начиная с момента в RFC3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetaDataRequest) Reset() {
+	*x = GetMetaDataRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetaDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetaDataRequest) ProtoMessage() {}
+
+func (x *GetMetaDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetaDataRequest.ProtoReflect.Descriptor instead.
+func (*GetMetaDataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetMetaDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetMetaDataRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *GetMetaDataRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *GetMetaDataRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetMetaDataRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetMetaDataRequest) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *GetMetaDataRequest) GetModifiedSince() string {
+	if x != nil {
+		return x.ModifiedSince
+	}
+	return ""
+}
+
+type GetMetaDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*MetaData            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // пустой на последней странице
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMetaDataResponse) Reset() {
+	*x = GetMetaDataResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMetaDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMetaDataResponse) ProtoMessage() {}
+
+func (x *GetMetaDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMetaDataResponse.ProtoReflect.Descriptor instead.
+func (*GetMetaDataResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetMetaDataResponse) GetItems() []*MetaData {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetMetaDataResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DeleteMetaDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MetadataId    string                 `protobuf:"bytes,1,opt,name=metadata_id,json=metadataId,proto3" json:"metadata_id,omitempty"`
+	MetadataType  string                 `protobuf:"bytes,2,opt,name=metadata_type,json=metadataType,proto3" json:"metadata_type,omitempty"`
+	DataId        string                 `protobuf:"bytes,3,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetaDataRequest) Reset() {
+	*x = DeleteMetaDataRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetaDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetaDataRequest) ProtoMessage() {}
+
+func (x *DeleteMetaDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetaDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMetaDataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteMetaDataRequest) GetMetadataId() string {
+	if x != nil {
+		return x.MetadataId
+	}
+	return ""
+}
+
+func (x *DeleteMetaDataRequest) GetMetadataType() string {
+	if x != nil {
+		return x.MetadataType
+	}
+	return ""
+}
+
+func (x *DeleteMetaDataRequest) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+type DeleteMetaDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMetaDataResponse) Reset() {
+	*x = DeleteMetaDataResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMetaDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMetaDataResponse) ProtoMessage() {}
+
+func (x *DeleteMetaDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMetaDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMetaDataResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteMetaDataResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Folder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      string                 `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Created       string                 `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      string                 `protobuf:"bytes,6,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Folder) Reset() {
+	*x = Folder{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Folder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Folder) ProtoMessage() {}
+
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Folder) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Folder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Folder) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Folder) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Folder) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Folder) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+type PostFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostFolderRequest) Reset() {
+	*x = PostFolderRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostFolderRequest) ProtoMessage() {}
+
+func (x *PostFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostFolderRequest.ProtoReflect.Descriptor instead.
+func (*PostFolderRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PostFolderRequest) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type PostFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostFolderResponse) Reset() {
+	*x = PostFolderResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostFolderResponse) ProtoMessage() {}
+
+func (x *PostFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostFolderResponse.ProtoReflect.Descriptor instead.
+func (*PostFolderResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PostFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type GetFoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFoldersRequest) Reset() {
+	*x = GetFoldersRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFoldersRequest) ProtoMessage() {}
+
+func (x *GetFoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFoldersRequest.ProtoReflect.Descriptor instead.
+func (*GetFoldersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetFoldersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetFoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFoldersResponse) Reset() {
+	*x = GetFoldersResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFoldersResponse) ProtoMessage() {}
+
+func (x *GetFoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFoldersResponse.ProtoReflect.Descriptor instead.
+func (*GetFoldersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetFoldersResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+type DeleteFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FolderId      string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderRequest) Reset() {
+	*x = DeleteFolderRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderRequest) ProtoMessage() {}
+
+func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFolderRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *DeleteFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderResponse) Reset() {
+	*x = DeleteFolderResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderResponse) ProtoMessage() {}
+
+func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFolderResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeleteFolderResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type StartKeyRotationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	VaultKey      *VaultKey              `protobuf:"bytes,2,opt,name=vault_key,json=vaultKey,proto3" json:"vault_key,omitempty"` // новый ключ хранилища
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartKeyRotationRequest) Reset() {
+	*x = StartKeyRotationRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartKeyRotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartKeyRotationRequest) ProtoMessage() {}
+
+func (x *StartKeyRotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartKeyRotationRequest.ProtoReflect.Descriptor instead.
+func (*StartKeyRotationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StartKeyRotationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StartKeyRotationRequest) GetVaultKey() *VaultKey {
+	if x != nil {
+		return x.VaultKey
+	}
+	return nil
+}
+
+type StartKeyRotationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartKeyRotationResponse) Reset() {
+	*x = StartKeyRotationResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartKeyRotationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartKeyRotationResponse) ProtoMessage() {}
+
+func (x *StartKeyRotationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartKeyRotationResponse.ProtoReflect.Descriptor instead.
+func (*StartKeyRotationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StartKeyRotationResponse) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+// Ключ данных записи, зашифрованный ключом хранилища
+type ItemKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DataId        string                 `protobuf:"bytes,1,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemKey) Reset() {
+	*x = ItemKey{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemKey) ProtoMessage() {}
+
+func (x *ItemKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemKey.ProtoReflect.Descriptor instead.
+func (*ItemKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ItemKey) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *ItemKey) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+type GetStaleKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // новый ключ ротации
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`              // размер пачки, по умолчанию 100, не более 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStaleKeysRequest) Reset() {
+	*x = GetStaleKeysRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStaleKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStaleKeysRequest) ProtoMessage() {}
+
+func (x *GetStaleKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStaleKeysRequest.ProtoReflect.Descriptor instead.
+func (*GetStaleKeysRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetStaleKeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetStaleKeysRequest) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *GetStaleKeysRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetStaleKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ItemKey             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`          // ключи данных, зашифрованные прежними ключами
+	Metas         []*MetaData            `protobuf:"bytes,2,rep,name=metas,proto3" json:"metas,omitempty"`          // метаданные, зашифрованные прежними ключами
+	Remaining     int64                  `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"` // всего записей и метаданных под прежними ключами
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStaleKeysResponse) Reset() {
+	*x = GetStaleKeysResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStaleKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStaleKeysResponse) ProtoMessage() {}
+
+func (x *GetStaleKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStaleKeysResponse.ProtoReflect.Descriptor instead.
+func (*GetStaleKeysResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetStaleKeysResponse) GetItems() []*ItemKey {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetStaleKeysResponse) GetMetas() []*MetaData {
+	if x != nil {
+		return x.Metas
+	}
+	return nil
+}
+
+func (x *GetStaleKeysResponse) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+type RewrapKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Items         []*ItemKey             `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Metas         []*MetaData            `protobuf:"bytes,4,rep,name=metas,proto3" json:"metas,omitempty"` // id и encrypted_meta
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewrapKeysRequest) Reset() {
+	*x = RewrapKeysRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewrapKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewrapKeysRequest) ProtoMessage() {}
+
+func (x *RewrapKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewrapKeysRequest.ProtoReflect.Descriptor instead.
+func (*RewrapKeysRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RewrapKeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RewrapKeysRequest) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *RewrapKeysRequest) GetItems() []*ItemKey {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *RewrapKeysRequest) GetMetas() []*MetaData {
+	if x != nil {
+		return x.Metas
+	}
+	return nil
+}
+
+type RewrapKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Remaining     int64                  `protobuf:"varint,1,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewrapKeysResponse) Reset() {
+	*x = RewrapKeysResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewrapKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewrapKeysResponse) ProtoMessage() {}
+
+func (x *RewrapKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewrapKeysResponse.ProtoReflect.Descriptor instead.
+func (*RewrapKeysResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RewrapKeysResponse) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+type FinishKeyRotationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishKeyRotationRequest) Reset() {
+	*x = FinishKeyRotationRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishKeyRotationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishKeyRotationRequest) ProtoMessage() {}
+
+func (x *FinishKeyRotationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishKeyRotationRequest.ProtoReflect.Descriptor instead.
+func (*FinishKeyRotationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *FinishKeyRotationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FinishKeyRotationRequest) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type FinishKeyRotationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishKeyRotationResponse) Reset() {
+	*x = FinishKeyRotationResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishKeyRotationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishKeyRotationResponse) ProtoMessage() {}
+
+func (x *FinishKeyRotationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishKeyRotationResponse.ProtoReflect.Descriptor instead.
+func (*FinishKeyRotationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *FinishKeyRotationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Пара ключей X25519 для совместного доступа: закрытый ключ зашифрован ключом хранилища версии key_id
+type SharingKey struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey         []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	WrappedPrivateKey []byte                 `protobuf:"bytes,2,opt,name=wrapped_private_key,json=wrappedPrivateKey,proto3" json:"wrapped_private_key,omitempty"`
+	KeyId             uint32                 `protobuf:"varint,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SharingKey) Reset() {
+	*x = SharingKey{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharingKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharingKey) ProtoMessage() {}
+
+func (x *SharingKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharingKey.ProtoReflect.Descriptor instead.
+func (*SharingKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SharingKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SharingKey) GetWrappedPrivateKey() []byte {
+	if x != nil {
+		return x.WrappedPrivateKey
+	}
+	return nil
+}
+
+func (x *SharingKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+type PostSharingKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SharingKey    *SharingKey            `protobuf:"bytes,2,opt,name=sharing_key,json=sharingKey,proto3" json:"sharing_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostSharingKeyRequest) Reset() {
+	*x = PostSharingKeyRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSharingKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSharingKeyRequest) ProtoMessage() {}
+
+func (x *PostSharingKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSharingKeyRequest.ProtoReflect.Descriptor instead.
+func (*PostSharingKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PostSharingKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostSharingKeyRequest) GetSharingKey() *SharingKey {
+	if x != nil {
+		return x.SharingKey
+	}
+	return nil
+}
+
+type PostSharingKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostSharingKeyResponse) Reset() {
+	*x = PostSharingKeyResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostSharingKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSharingKeyResponse) ProtoMessage() {}
+
+func (x *PostSharingKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSharingKeyResponse.ProtoReflect.Descriptor instead.
+func (*PostSharingKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *PostSharingKeyResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetSharingKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharingKeyRequest) Reset() {
+	*x = GetSharingKeyRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharingKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharingKeyRequest) ProtoMessage() {}
+
+func (x *GetSharingKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharingKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetSharingKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetSharingKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetSharingKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SharingKey    *SharingKey            `protobuf:"bytes,1,opt,name=sharing_key,json=sharingKey,proto3" json:"sharing_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharingKeyResponse) Reset() {
+	*x = GetSharingKeyResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharingKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharingKeyResponse) ProtoMessage() {}
+
+func (x *GetSharingKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharingKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetSharingKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetSharingKeyResponse) GetSharingKey() *SharingKey {
+	if x != nil {
+		return x.SharingKey
+	}
+	return nil
+}
+
+type GetPublicKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyRequest) Reset() {
+	*x = GetPublicKeyRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyRequest) ProtoMessage() {}
+
+func (x *GetPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetPublicKeyRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type GetPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicKeyResponse) Reset() {
+	*x = GetPublicKeyResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicKeyResponse) ProtoMessage() {}
+
+func (x *GetPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetPublicKeyResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPublicKeyResponse) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// Доступ к записи, выданный владельцем получателю
+type Share struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DataId             string                 `protobuf:"bytes,2,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	OwnerLogin         string                 `protobuf:"bytes,3,opt,name=owner_login,json=ownerLogin,proto3" json:"owner_login,omitempty"`
+	RecipientLogin     string                 `protobuf:"bytes,4,opt,name=recipient_login,json=recipientLogin,proto3" json:"recipient_login,omitempty"`
+	WrappedKey         []byte                 `protobuf:"bytes,5,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`          // ключ данных записи, зашифрованный открытым ключом получателя
+	EncryptedMeta      []byte                 `protobuf:"bytes,6,opt,name=encrypted_meta,json=encryptedMeta,proto3" json:"encrypted_meta,omitempty"` // название и описание, зашифрованные открытым ключом получателя
+	DataType           string                 `protobuf:"bytes,7,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	Permission         string                 `protobuf:"bytes,8,opt,name=permission,proto3" json:"permission,omitempty"` // read или edit
+	RecipientPublicKey []byte                 `protobuf:"bytes,9,opt,name=recipient_public_key,json=recipientPublicKey,proto3" json:"recipient_public_key,omitempty"`
+	Created            string                 `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
+	Modified           string                 `protobuf:"bytes,11,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Share) Reset() {
+	*x = Share{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Share) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Share) ProtoMessage() {}
+
+func (x *Share) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Share.ProtoReflect.Descriptor instead.
+func (*Share) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Share) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Share) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *Share) GetOwnerLogin() string {
+	if x != nil {
+		return x.OwnerLogin
+	}
+	return ""
+}
+
+func (x *Share) GetRecipientLogin() string {
+	if x != nil {
+		return x.RecipientLogin
+	}
+	return ""
+}
+
+func (x *Share) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *Share) GetEncryptedMeta() []byte {
+	if x != nil {
+		return x.EncryptedMeta
+	}
+	return nil
+}
+
+func (x *Share) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *Share) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+func (x *Share) GetRecipientPublicKey() []byte {
+	if x != nil {
+		return x.RecipientPublicKey
+	}
+	return nil
+}
+
+func (x *Share) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Share) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+type ShareItemRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DataId         string                 `protobuf:"bytes,2,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	RecipientLogin string                 `protobuf:"bytes,3,opt,name=recipient_login,json=recipientLogin,proto3" json:"recipient_login,omitempty"`
+	WrappedKey     []byte                 `protobuf:"bytes,4,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	EncryptedMeta  []byte                 `protobuf:"bytes,5,opt,name=encrypted_meta,json=encryptedMeta,proto3" json:"encrypted_meta,omitempty"`
+	Permission     string                 `protobuf:"bytes,6,opt,name=permission,proto3" json:"permission,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShareItemRequest) Reset() {
+	*x = ShareItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareItemRequest) ProtoMessage() {}
+
+func (x *ShareItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareItemRequest.ProtoReflect.Descriptor instead.
+func (*ShareItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ShareItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareItemRequest) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+func (x *ShareItemRequest) GetRecipientLogin() string {
+	if x != nil {
+		return x.RecipientLogin
+	}
+	return ""
+}
+
+func (x *ShareItemRequest) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *ShareItemRequest) GetEncryptedMeta() []byte {
+	if x != nil {
+		return x.EncryptedMeta
+	}
+	return nil
+}
+
+func (x *ShareItemRequest) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+type ShareItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *Share                 `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareItemResponse) Reset() {
+	*x = ShareItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareItemResponse) ProtoMessage() {}
+
+func (x *ShareItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareItemResponse.ProtoReflect.Descriptor instead.
+func (*ShareItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ShareItemResponse) GetShare() *Share {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type GetSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DataId        string                 `protobuf:"bytes,2,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharesRequest) Reset() {
+	*x = GetSharesRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharesRequest) ProtoMessage() {}
+
+func (x *GetSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharesRequest.ProtoReflect.Descriptor instead.
+func (*GetSharesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetSharesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSharesRequest) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+type GetSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*Share               `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharesResponse) Reset() {
+	*x = GetSharesResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharesResponse) ProtoMessage() {}
+
+func (x *GetSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharesResponse.ProtoReflect.Descriptor instead.
+func (*GetSharesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetSharesResponse) GetShares() []*Share {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type RevokeShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShareId       string                 `protobuf:"bytes,2,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareRequest) Reset() {
+	*x = RevokeShareRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareRequest) ProtoMessage() {}
+
+func (x *RevokeShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RevokeShareRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeShareRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+type RevokeShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareResponse) Reset() {
+	*x = RevokeShareResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareResponse) ProtoMessage() {}
+
+func (x *RevokeShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RevokeShareResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedWithMeRequest) Reset() {
+	*x = GetSharedWithMeRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedWithMeRequest) ProtoMessage() {}
+
+func (x *GetSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetSharedWithMeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetSharedWithMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*Share               `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedWithMeResponse) Reset() {
+	*x = GetSharedWithMeResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedWithMeResponse) ProtoMessage() {}
+
+func (x *GetSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*GetSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetSharedWithMeResponse) GetShares() []*Share {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type GetSharedItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // получатель
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedItemRequest) Reset() {
+	*x = GetSharedItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedItemRequest) ProtoMessage() {}
+
+func (x *GetSharedItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedItemRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetSharedItemRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *GetSharedItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetSharedItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Share         *Share                 `protobuf:"bytes,2,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedItemResponse) Reset() {
+	*x = GetSharedItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedItemResponse) ProtoMessage() {}
+
+func (x *GetSharedItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedItemResponse.ProtoReflect.Descriptor instead.
+func (*GetSharedItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *GetSharedItemResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetSharedItemResponse) GetShare() *Share {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type UpdateSharedItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareId       string                 `protobuf:"bytes,1,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                   // зашифрованные тем же ключом данных
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // получатель
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSharedItemRequest) Reset() {
+	*x = UpdateSharedItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSharedItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSharedItemRequest) ProtoMessage() {}
+
+func (x *UpdateSharedItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSharedItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSharedItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *UpdateSharedItemRequest) GetShareId() string {
+	if x != nil {
+		return x.ShareId
+	}
+	return ""
+}
+
+func (x *UpdateSharedItemRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UpdateSharedItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateSharedItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Modified      string                 `protobuf:"bytes,1,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSharedItemResponse) Reset() {
+	*x = UpdateSharedItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSharedItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSharedItemResponse) ProtoMessage() {}
+
+func (x *UpdateSharedItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSharedItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSharedItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UpdateSharedItemResponse) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+// Организация и роль в ней пользователя, для которого она получена
+type Organization struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`          // owner, admin, member или read-only
+	Accepted      bool                   `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"` // false, пока приглашение не принято
+	Created       string                 `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Organization) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *Organization) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+type OrgMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	Accepted      bool                   `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMember) Reset() {
+	*x = OrgMember{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMember) ProtoMessage() {}
+
+func (x *OrgMember) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMember.ProtoReflect.Descriptor instead.
+func (*OrgMember) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *OrgMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrgMember) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *OrgMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *OrgMember) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *OrgMember) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// Ключ коллекции, зашифрованный открытым ключом участника
+type CollectionKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,4,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionKey) Reset() {
+	*x = CollectionKey{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionKey) ProtoMessage() {}
+
+func (x *CollectionKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionKey.ProtoReflect.Descriptor instead.
+func (*CollectionKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CollectionKey) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *CollectionKey) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CollectionKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *CollectionKey) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+type Collection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,5,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // текущий ключ коллекции для запросившего участника
+	Created       string                 `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *Collection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Collection) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *Collection) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *Collection) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+type CollectionItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,4,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"` // ключ данных, зашифрованный ключом коллекции
+	KeyId         uint32                 `protobuf:"varint,5,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	EncryptedMeta []byte                 `protobuf:"bytes,6,opt,name=encrypted_meta,json=encryptedMeta,proto3" json:"encrypted_meta,omitempty"`
+	DataType      string                 `protobuf:"bytes,7,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	Created       string                 `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      string                 `protobuf:"bytes,9,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionItem) Reset() {
+	*x = CollectionItem{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItem) ProtoMessage() {}
+
+func (x *CollectionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItem.ProtoReflect.Descriptor instead.
+func (*CollectionItem) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CollectionItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *CollectionItem) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+func (x *CollectionItem) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *CollectionItem) GetEncryptedMeta() []byte {
+	if x != nil {
+		return x.EncryptedMeta
+	}
+	return nil
+}
+
+func (x *CollectionItem) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *CollectionItem) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+type CreateOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateOrgRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateOrgResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organization  *Organization          `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgResponse) Reset() {
+	*x = CreateOrgResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgResponse) ProtoMessage() {}
+
+func (x *CreateOrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrgResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateOrgResponse) GetOrganization() *Organization {
+	if x != nil {
+		return x.Organization
+	}
+	return nil
+}
+
+type GetOrgsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgsRequest) Reset() {
+	*x = GetOrgsRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgsRequest) ProtoMessage() {}
+
+func (x *GetOrgsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetOrgsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetOrgsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgsResponse) Reset() {
+	*x = GetOrgsResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgsResponse) ProtoMessage() {}
+
+func (x *GetOrgsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrgsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *GetOrgsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type DeleteOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgRequest) Reset() {
+	*x = DeleteOrgRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgRequest) ProtoMessage() {}
+
+func (x *DeleteOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrgRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *DeleteOrgRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteOrgRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type DeleteOrgResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgResponse) Reset() {
+	*x = DeleteOrgResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgResponse) ProtoMessage() {}
+
+func (x *DeleteOrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrgResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *DeleteOrgResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetOrgMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgMembersRequest) Reset() {
+	*x = GetOrgMembersRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgMembersRequest) ProtoMessage() {}
+
+func (x *GetOrgMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgMembersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrgMembersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetOrgMembersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetOrgMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type GetOrgMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrgMember           `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrgMembersResponse) Reset() {
+	*x = GetOrgMembersResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrgMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrgMembersResponse) ProtoMessage() {}
+
+func (x *GetOrgMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrgMembersResponse.ProtoReflect.Descriptor instead.
+func (*GetOrgMembersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetOrgMembersResponse) GetMembers() []*OrgMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type InviteMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Login         string                 `protobuf:"bytes,3,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Keys          []*CollectionKey       `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"` // ключи всех коллекций для приглашенного
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberRequest) Reset() {
+	*x = InviteMemberRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberRequest) ProtoMessage() {}
+
+func (x *InviteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberRequest.ProtoReflect.Descriptor instead.
+func (*InviteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *InviteMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *InviteMemberRequest) GetKeys() []*CollectionKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type InviteMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *OrgMember             `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteMemberResponse) Reset() {
+	*x = InviteMemberResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteMemberResponse) ProtoMessage() {}
+
+func (x *InviteMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteMemberResponse.ProtoReflect.Descriptor instead.
+func (*InviteMemberResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *InviteMemberResponse) GetMember() *OrgMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type AnswerInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerInvitationRequest) Reset() {
+	*x = AnswerInvitationRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerInvitationRequest) ProtoMessage() {}
+
+func (x *AnswerInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AnswerInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *AnswerInvitationRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AnswerInvitationRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AnswerInvitationRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type AnswerInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerInvitationResponse) Reset() {
+	*x = AnswerInvitationResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerInvitationResponse) ProtoMessage() {}
+
+func (x *AnswerInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerInvitationResponse.ProtoReflect.Descriptor instead.
+func (*AnswerInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *AnswerInvitationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *RemoveMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RemoveMemberResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ChangeRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	MemberId      string                 `protobuf:"bytes,3,opt,name=member_id,json=memberId,proto3" json:"member_id,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeRoleRequest) Reset() {
+	*x = ChangeRoleRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRoleRequest) ProtoMessage() {}
+
+func (x *ChangeRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRoleRequest.ProtoReflect.Descriptor instead.
+func (*ChangeRoleRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ChangeRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChangeRoleRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *ChangeRoleRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *ChangeRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ChangeRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeRoleResponse) Reset() {
+	*x = ChangeRoleResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeRoleResponse) ProtoMessage() {}
+
+func (x *ChangeRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeRoleResponse.ProtoReflect.Descriptor instead.
+func (*ChangeRoleResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ChangeRoleResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CreateCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Id            string                 `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Keys          []*CollectionKey       `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"` // ключ коллекции для каждого участника
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionRequest) Reset() {
+	*x = CreateCollectionRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionRequest) ProtoMessage() {}
+
+func (x *CreateCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionRequest.ProtoReflect.Descriptor instead.
+func (*CreateCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *CreateCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCollectionRequest) GetKeys() []*CollectionKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type CreateCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    *Collection            `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCollectionResponse) Reset() {
+	*x = CreateCollectionResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCollectionResponse) ProtoMessage() {}
+
+func (x *CreateCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCollectionResponse.ProtoReflect.Descriptor instead.
+func (*CreateCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *CreateCollectionResponse) GetCollection() *Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+type GetCollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionsRequest) Reset() {
+	*x = GetCollectionsRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionsRequest) ProtoMessage() {}
+
+func (x *GetCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GetCollectionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetCollectionsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type GetCollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionsResponse) Reset() {
+	*x = GetCollectionsResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionsResponse) ProtoMessage() {}
+
+func (x *GetCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*GetCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GetCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type DeleteCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionRequest) Reset() {
+	*x = DeleteCollectionRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *DeleteCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+type DeleteCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionResponse) Reset() {
+	*x = DeleteCollectionResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionResponse) ProtoMessage() {}
+
+func (x *DeleteCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *DeleteCollectionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetCollectionItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionItemsRequest) Reset() {
+	*x = GetCollectionItemsRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionItemsRequest) ProtoMessage() {}
+
+func (x *GetCollectionItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionItemsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetCollectionItemsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetCollectionItemsRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+type GetCollectionItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CollectionItem      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // без данных записей
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionItemsResponse) Reset() {
+	*x = GetCollectionItemsResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionItemsResponse) ProtoMessage() {}
+
+func (x *GetCollectionItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetCollectionItemsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *GetCollectionItemsResponse) GetItems() []*CollectionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetCollectionItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionItemRequest) Reset() {
+	*x = GetCollectionItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionItemRequest) ProtoMessage() {}
+
+func (x *GetCollectionItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionItemRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetCollectionItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetCollectionItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type GetCollectionItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *CollectionItem        `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionItemResponse) Reset() {
+	*x = GetCollectionItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionItemResponse) ProtoMessage() {}
+
+func (x *GetCollectionItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionItemResponse.ProtoReflect.Descriptor instead.
+func (*GetCollectionItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GetCollectionItemResponse) GetItem() *CollectionItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type PostCollectionItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Item          *CollectionItem        `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostCollectionItemRequest) Reset() {
+	*x = PostCollectionItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostCollectionItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostCollectionItemRequest) ProtoMessage() {}
+
+func (x *PostCollectionItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostCollectionItemRequest.ProtoReflect.Descriptor instead.
+func (*PostCollectionItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *PostCollectionItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PostCollectionItemRequest) GetItem() *CollectionItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type PostCollectionItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Created       string                 `protobuf:"bytes,1,opt,name=created,proto3" json:"created,omitempty"`
+	Modified      string                 `protobuf:"bytes,2,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostCollectionItemResponse) Reset() {
+	*x = PostCollectionItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostCollectionItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostCollectionItemResponse) ProtoMessage() {}
+
+func (x *PostCollectionItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostCollectionItemResponse.ProtoReflect.Descriptor instead.
+func (*PostCollectionItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *PostCollectionItemResponse) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *PostCollectionItemResponse) GetModified() string {
+	if x != nil {
+		return x.Modified
+	}
+	return ""
+}
+
+type DeleteCollectionItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionItemRequest) Reset() {
+	*x = DeleteCollectionItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionItemRequest) ProtoMessage() {}
+
+func (x *DeleteCollectionItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *DeleteCollectionItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteCollectionItemRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type DeleteCollectionItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCollectionItemResponse) Reset() {
+	*x = DeleteCollectionItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCollectionItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCollectionItemResponse) ProtoMessage() {}
+
+func (x *DeleteCollectionItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCollectionItemResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCollectionItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *DeleteCollectionItemResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RekeyCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CollectionId  string                 `protobuf:"bytes,2,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Keys          []*CollectionKey       `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	Items         []*CollectionItem      `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"` // все записи коллекции, зашифрованные новым ключом
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RekeyCollectionRequest) Reset() {
+	*x = RekeyCollectionRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RekeyCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RekeyCollectionRequest) ProtoMessage() {}
+
+func (x *RekeyCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RekeyCollectionRequest.ProtoReflect.Descriptor instead.
+func (*RekeyCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *RekeyCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RekeyCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *RekeyCollectionRequest) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *RekeyCollectionRequest) GetKeys() []*CollectionKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *RekeyCollectionRequest) GetItems() []*CollectionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RekeyCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RekeyCollectionResponse) Reset() {
+	*x = RekeyCollectionResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RekeyCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RekeyCollectionResponse) ProtoMessage() {}
+
+func (x *RekeyCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RekeyCollectionResponse.ProtoReflect.Descriptor instead.
+func (*RekeyCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *RekeyCollectionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Экстренный доступ доверенного контакта к хранилищу владельца
+type EmergencyAccess struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnerLogin       string                 `protobuf:"bytes,2,opt,name=owner_login,json=ownerLogin,proto3" json:"owner_login,omitempty"`
+	ContactLogin     string                 `protobuf:"bytes,3,opt,name=contact_login,json=contactLogin,proto3" json:"contact_login,omitempty"`
+	ContactPublicKey []byte                 `protobuf:"bytes,4,opt,name=contact_public_key,json=contactPublicKey,proto3" json:"contact_public_key,omitempty"`
+	WaitHours        uint32                 `protobuf:"varint,5,opt,name=wait_hours,json=waitHours,proto3" json:"wait_hours,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	RequestedAt      string                 `protobuf:"bytes,7,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"` // пустой, пока доступ не запрошен
+	ReleaseAt        string                 `protobuf:"bytes,8,opt,name=release_at,json=releaseAt,proto3" json:"release_at,omitempty"`       // время выдачи ключа, если владелец не отклонит запрос
+	KeyId            uint32                 `protobuf:"varint,9,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Created          string                 `protobuf:"bytes,10,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EmergencyAccess) Reset() {
+	*x = EmergencyAccess{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyAccess) ProtoMessage() {}
+
+func (x *EmergencyAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyAccess.ProtoReflect.Descriptor instead.
+func (*EmergencyAccess) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *EmergencyAccess) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetOwnerLogin() string {
+	if x != nil {
+		return x.OwnerLogin
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetContactLogin() string {
+	if x != nil {
+		return x.ContactLogin
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetContactPublicKey() []byte {
+	if x != nil {
+		return x.ContactPublicKey
+	}
+	return nil
+}
+
+func (x *EmergencyAccess) GetWaitHours() uint32 {
+	if x != nil {
+		return x.WaitHours
+	}
+	return 0
+}
+
+func (x *EmergencyAccess) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetRequestedAt() string {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetReleaseAt() string {
+	if x != nil {
+		return x.ReleaseAt
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *EmergencyAccess) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+type GrantEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ContactLogin  string                 `protobuf:"bytes,2,opt,name=contact_login,json=contactLogin,proto3" json:"contact_login,omitempty"`
+	WaitHours     uint32                 `protobuf:"varint,3,opt,name=wait_hours,json=waitHours,proto3" json:"wait_hours,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,4,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	SealedKey     []byte                 `protobuf:"bytes,5,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"` // ключ хранилища, зашифрованный открытым ключом контакта
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantEmergencyAccessRequest) Reset() {
+	*x = GrantEmergencyAccessRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *GrantEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*GrantEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *GrantEmergencyAccessRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantEmergencyAccessRequest) GetContactLogin() string {
+	if x != nil {
+		return x.ContactLogin
+	}
+	return ""
+}
+
+func (x *GrantEmergencyAccessRequest) GetWaitHours() uint32 {
+	if x != nil {
+		return x.WaitHours
+	}
+	return 0
+}
+
+func (x *GrantEmergencyAccessRequest) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *GrantEmergencyAccessRequest) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+type GrantEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Access        *EmergencyAccess       `protobuf:"bytes,1,opt,name=access,proto3" json:"access,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantEmergencyAccessResponse) Reset() {
+	*x = GrantEmergencyAccessResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *GrantEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*GrantEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *GrantEmergencyAccessResponse) GetAccess() *EmergencyAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+type GetEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyAccessRequest) Reset() {
+	*x = GetEmergencyAccessRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *GetEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *GetEmergencyAccessRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Granted       []*EmergencyAccess     `protobuf:"bytes,1,rep,name=granted,proto3" json:"granted,omitempty"`                      // доверенные контакты пользователя
+	TrustedBy     []*EmergencyAccess     `protobuf:"bytes,2,rep,name=trusted_by,json=trustedBy,proto3" json:"trusted_by,omitempty"` // владельцы, назначившие пользователя доверенным контактом
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyAccessResponse) Reset() {
+	*x = GetEmergencyAccessResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *GetEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *GetEmergencyAccessResponse) GetGranted() []*EmergencyAccess {
+	if x != nil {
+		return x.Granted
+	}
+	return nil
+}
+
+func (x *GetEmergencyAccessResponse) GetTrustedBy() []*EmergencyAccess {
+	if x != nil {
+		return x.TrustedBy
+	}
+	return nil
+}
+
+type RevokeEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessId      string                 `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeEmergencyAccessRequest) Reset() {
+	*x = RevokeEmergencyAccessRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *RevokeEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*RevokeEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *RevokeEmergencyAccessRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeEmergencyAccessRequest) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+type RevokeEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeEmergencyAccessResponse) Reset() {
+	*x = RevokeEmergencyAccessResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *RevokeEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*RevokeEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *RevokeEmergencyAccessResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RequestEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessId      string                 `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmergencyAccessRequest) Reset() {
+	*x = RequestEmergencyAccessRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *RequestEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*RequestEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *RequestEmergencyAccessRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RequestEmergencyAccessRequest) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+type RequestEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Access        *EmergencyAccess       `protobuf:"bytes,1,opt,name=access,proto3" json:"access,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmergencyAccessResponse) Reset() {
+	*x = RequestEmergencyAccessResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *RequestEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*RequestEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *RequestEmergencyAccessResponse) GetAccess() *EmergencyAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+type AnswerEmergencyAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessId      string                 `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,3,opt,name=approve,proto3" json:"approve,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerEmergencyAccessRequest) Reset() {
+	*x = AnswerEmergencyAccessRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerEmergencyAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerEmergencyAccessRequest) ProtoMessage() {}
+
+func (x *AnswerEmergencyAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerEmergencyAccessRequest.ProtoReflect.Descriptor instead.
+func (*AnswerEmergencyAccessRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *AnswerEmergencyAccessRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AnswerEmergencyAccessRequest) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+func (x *AnswerEmergencyAccessRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+type AnswerEmergencyAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerEmergencyAccessResponse) Reset() {
+	*x = AnswerEmergencyAccessResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerEmergencyAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerEmergencyAccessResponse) ProtoMessage() {}
+
+func (x *AnswerEmergencyAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerEmergencyAccessResponse.ProtoReflect.Descriptor instead.
+func (*AnswerEmergencyAccessResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *AnswerEmergencyAccessResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type EmergencyKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessId      string                 `protobuf:"bytes,1,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	SealedKey     []byte                 `protobuf:"bytes,3,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyKey) Reset() {
+	*x = EmergencyKey{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyKey) ProtoMessage() {}
+
+func (x *EmergencyKey) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyKey.ProtoReflect.Descriptor instead.
+func (*EmergencyKey) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *EmergencyKey) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+func (x *EmergencyKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *EmergencyKey) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+type UpdateEmergencyKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Keys          []*EmergencyKey        `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEmergencyKeysRequest) Reset() {
+	*x = UpdateEmergencyKeysRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEmergencyKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEmergencyKeysRequest) ProtoMessage() {}
+
+func (x *UpdateEmergencyKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEmergencyKeysRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEmergencyKeysRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *UpdateEmergencyKeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateEmergencyKeysRequest) GetKeys() []*EmergencyKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type UpdateEmergencyKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEmergencyKeysResponse) Reset() {
+	*x = UpdateEmergencyKeysResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEmergencyKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEmergencyKeysResponse) ProtoMessage() {}
+
+func (x *UpdateEmergencyKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEmergencyKeysResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEmergencyKeysResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *UpdateEmergencyKeysResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetEmergencyVaultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessId      string                 `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyVaultRequest) Reset() {
+	*x = GetEmergencyVaultRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyVaultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyVaultRequest) ProtoMessage() {}
+
+func (x *GetEmergencyVaultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyVaultRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencyVaultRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *GetEmergencyVaultRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetEmergencyVaultRequest) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+type GetEmergencyVaultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       string                 `protobuf:"bytes,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	KeyId         uint32                 `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	SealedKey     []byte                 `protobuf:"bytes,3,opt,name=sealed_key,json=sealedKey,proto3" json:"sealed_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyVaultResponse) Reset() {
+	*x = GetEmergencyVaultResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyVaultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyVaultResponse) ProtoMessage() {}
+
+func (x *GetEmergencyVaultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyVaultResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencyVaultResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *GetEmergencyVaultResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *GetEmergencyVaultResponse) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *GetEmergencyVaultResponse) GetSealedKey() []byte {
+	if x != nil {
+		return x.SealedKey
+	}
+	return nil
+}
+
+type GetEmergencyItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessId      string                 `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyItemsRequest) Reset() {
+	*x = GetEmergencyItemsRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyItemsRequest) ProtoMessage() {}
+
+func (x *GetEmergencyItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencyItemsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *GetEmergencyItemsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetEmergencyItemsRequest) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+func (x *GetEmergencyItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetEmergencyItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*MetaData            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyItemsResponse) Reset() {
+	*x = GetEmergencyItemsResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyItemsResponse) ProtoMessage() {}
+
+func (x *GetEmergencyItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencyItemsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *GetEmergencyItemsResponse) GetItems() []*MetaData {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetEmergencyItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetEmergencyItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AccessId      string                 `protobuf:"bytes,2,opt,name=access_id,json=accessId,proto3" json:"access_id,omitempty"`
+	DataId        string                 `protobuf:"bytes,3,opt,name=data_id,json=dataId,proto3" json:"data_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyItemRequest) Reset() {
+	*x = GetEmergencyItemRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyItemRequest) ProtoMessage() {}
+
+func (x *GetEmergencyItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyItemRequest.ProtoReflect.Descriptor instead.
+func (*GetEmergencyItemRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *GetEmergencyItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetEmergencyItemRequest) GetAccessId() string {
+	if x != nil {
+		return x.AccessId
+	}
+	return ""
+}
+
+func (x *GetEmergencyItemRequest) GetDataId() string {
+	if x != nil {
+		return x.DataId
+	}
+	return ""
+}
+
+type GetEmergencyItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	WrappedKey    []byte                 `protobuf:"bytes,2,opt,name=wrapped_key,json=wrappedKey,proto3" json:"wrapped_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmergencyItemResponse) Reset() {
+	*x = GetEmergencyItemResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmergencyItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmergencyItemResponse) ProtoMessage() {}
+
+func (x *GetEmergencyItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmergencyItemResponse.ProtoReflect.Descriptor instead.
+func (*GetEmergencyItemResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *GetEmergencyItemResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *GetEmergencyItemResponse) GetWrappedKey() []byte {
+	if x != nil {
+		return x.WrappedKey
+	}
+	return nil
+}
+
+// Устройство пользователя с клиентским сертификатом
+type Device struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"` // SHA-256 сертификата устройства
+	Expires       string                 `protobuf:"bytes,4,opt,name=expires,proto3" json:"expires,omitempty"`
+	Created       string                 `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
+	Revoked       string                 `protobuf:"bytes,6,opt,name=revoked,proto3" json:"revoked,omitempty"` // пустой, пока устройство не отозвано
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *Device) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Device) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Device) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *Device) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
+func (x *Device) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *Device) GetRevoked() string {
+	if x != nil {
+		return x.Revoked
+	}
+	return ""
+}
+
+// Регистрация устройства: сервер подписывает запрос на сертификат клиентским УЦ
+type EnrollDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Csr           []byte                 `protobuf:"bytes,3,opt,name=csr,proto3" json:"csr,omitempty"` // PKCS#10 в DER
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollDeviceRequest) Reset() {
+	*x = EnrollDeviceRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollDeviceRequest) ProtoMessage() {}
+
+func (x *EnrollDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollDeviceRequest.ProtoReflect.Descriptor instead.
+func (*EnrollDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *EnrollDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EnrollDeviceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnrollDeviceRequest) GetCsr() []byte {
+	if x != nil {
+		return x.Csr
+	}
+	return nil
+}
+
+type EnrollDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        *Device                `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	Certificate   []byte                 `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`                          // PEM
+	CaCertificate []byte                 `protobuf:"bytes,3,opt,name=ca_certificate,json=caCertificate,proto3" json:"ca_certificate,omitempty"` // PEM
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollDeviceResponse) Reset() {
+	*x = EnrollDeviceResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollDeviceResponse) ProtoMessage() {}
+
+func (x *EnrollDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollDeviceResponse.ProtoReflect.Descriptor instead.
+func (*EnrollDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *EnrollDeviceResponse) GetDevice() *Device {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *EnrollDeviceResponse) GetCertificate() []byte {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *EnrollDeviceResponse) GetCaCertificate() []byte {
+	if x != nil {
+		return x.CaCertificate
+	}
+	return nil
+}
+
+type GetDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDevicesRequest) Reset() {
+	*x = GetDevicesRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDevicesRequest) ProtoMessage() {}
+
+func (x *GetDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDevicesRequest.ProtoReflect.Descriptor instead.
+func (*GetDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *GetDevicesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Devices       []*Device              `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDevicesResponse) Reset() {
+	*x = GetDevicesResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDevicesResponse) ProtoMessage() {}
+
+func (x *GetDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDevicesResponse.ProtoReflect.Descriptor instead.
+func (*GetDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *GetDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type RevokeDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceRequest) Reset() {
+	*x = RevokeDeviceRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceRequest) ProtoMessage() {}
+
+func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *RevokeDeviceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RevokeDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceResponse) Reset() {
+	*x = RevokeDeviceResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceResponse) ProtoMessage() {}
+
+func (x *RevokeDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *RevokeDeviceResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Персональный API токен для автоматизации
+type APIToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	FolderIds     []string               `protobuf:"bytes,4,rep,name=folder_ids,json=folderIds,proto3" json:"folder_ids,omitempty"` // пустой - все папки
+	DataTypes     []string               `protobuf:"bytes,5,rep,name=data_types,json=dataTypes,proto3" json:"data_types,omitempty"` // пустой - все типы
+	Expires       string                 `protobuf:"bytes,6,opt,name=expires,proto3" json:"expires,omitempty"`
+	Created       string                 `protobuf:"bytes,7,opt,name=created,proto3" json:"created,omitempty"`
+	LastUsed      string                 `protobuf:"bytes,8,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"` // пустой, пока токен не использовался
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIToken) Reset() {
+	*x = APIToken{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIToken) ProtoMessage() {}
+
+func (x *APIToken) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIToken.ProtoReflect.Descriptor instead.
+func (*APIToken) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *APIToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIToken) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *APIToken) GetFolderIds() []string {
+	if x != nil {
+		return x.FolderIds
+	}
+	return nil
+}
+
+func (x *APIToken) GetDataTypes() []string {
+	if x != nil {
+		return x.DataTypes
+	}
+	return nil
+}
+
+func (x *APIToken) GetExpires() string {
+	if x != nil {
+		return x.Expires
+	}
+	return ""
+}
+
+func (x *APIToken) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *APIToken) GetLastUsed() string {
+	if x != nil {
+		return x.LastUsed
+	}
+	return ""
+}
+
+type CreateAPITokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ReadOnly      bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	FolderIds     []string               `protobuf:"bytes,4,rep,name=folder_ids,json=folderIds,proto3" json:"folder_ids,omitempty"`
+	DataTypes     []string               `protobuf:"bytes,5,rep,name=data_types,json=dataTypes,proto3" json:"data_types,omitempty"`
+	TtlDays       int32                  `protobuf:"varint,6,opt,name=ttl_days,json=ttlDays,proto3" json:"ttl_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPITokenRequest) Reset() {
+	*x = CreateAPITokenRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPITokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPITokenRequest) ProtoMessage() {}
+
+func (x *CreateAPITokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPITokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPITokenRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *CreateAPITokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateAPITokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPITokenRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *CreateAPITokenRequest) GetFolderIds() []string {
+	if x != nil {
+		return x.FolderIds
+	}
+	return nil
+}
+
+func (x *CreateAPITokenRequest) GetDataTypes() []string {
+	if x != nil {
+		return x.DataTypes
+	}
+	return nil
+}
+
+func (x *CreateAPITokenRequest) GetTtlDays() int32 {
+	if x != nil {
+		return x.TtlDays
+	}
+	return 0
+}
+
+// Секрет токена возвращается только при создании, сервер хранит его хеш
+type CreateAPITokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *APIToken              `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPITokenResponse) Reset() {
+	*x = CreateAPITokenResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPITokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPITokenResponse) ProtoMessage() {}
+
+func (x *CreateAPITokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPITokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPITokenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *CreateAPITokenResponse) GetToken() *APIToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *CreateAPITokenResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type GetAPITokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAPITokensRequest) Reset() {
+	*x = GetAPITokensRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAPITokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAPITokensRequest) ProtoMessage() {}
+
+func (x *GetAPITokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAPITokensRequest.ProtoReflect.Descriptor instead.
+func (*GetAPITokensRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *GetAPITokensRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetAPITokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*APIToken            `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAPITokensResponse) Reset() {
+	*x = GetAPITokensResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAPITokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAPITokensResponse) ProtoMessage() {}
+
+func (x *GetAPITokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAPITokensResponse.ProtoReflect.Descriptor instead.
+func (*GetAPITokensResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *GetAPITokensResponse) GetTokens() []*APIToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RevokeAPITokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TokenId       string                 `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPITokenRequest) Reset() {
+	*x = RevokeAPITokenRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPITokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPITokenRequest) ProtoMessage() {}
+
+func (x *RevokeAPITokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPITokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPITokenRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *RevokeAPITokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
 	return ""
 }
 
-type DeleteMetaDataResponse struct {
+func (x *RevokeAPITokenRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+type RevokeAPITokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Error         string                 `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMetaDataResponse) Reset() {
-	*x = DeleteMetaDataResponse{}
-	mi := &file_internal_proto_handlers_proto_msgTypes[10]
+func (x *RevokeAPITokenResponse) Reset() {
+	*x = RevokeAPITokenResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMetaDataResponse) String() string {
+func (x *RevokeAPITokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMetaDataResponse) ProtoMessage() {}
+func (*RevokeAPITokenResponse) ProtoMessage() {}
 
-func (x *DeleteMetaDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_handlers_proto_msgTypes[10]
+func (x *RevokeAPITokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,42 +7814,424 @@ func (x *DeleteMetaDataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMetaDataResponse.ProtoReflect.Descriptor instead.
-func (*DeleteMetaDataResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RevokeAPITokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAPITokenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{136}
 }
 
-func (x *DeleteMetaDataResponse) GetError() string {
+func (x *RevokeAPITokenResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
+// Событие журнала аудита, события пользователя связаны цепочкой хешей
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	ItemId        string                 `protobuf:"bytes,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,5,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`         // пустой без сертификата устройства
+	ApiTokenId    string                 `protobuf:"bytes,6,opt,name=api_token_id,json=apiTokenId,proto3" json:"api_token_id,omitempty"` // пустой без API токена
+	Peer          string                 `protobuf:"bytes,7,opt,name=peer,proto3" json:"peer,omitempty"`
+	Details       string                 `protobuf:"bytes,8,opt,name=details,proto3" json:"details,omitempty"`
+	Created       string                 `protobuf:"bytes,9,opt,name=created,proto3" json:"created,omitempty"` // RFC3339 с микросекундами
+	PrevHash      []byte                 `protobuf:"bytes,10,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash          []byte                 `protobuf:"bytes,11,opt,name=hash,proto3" json:"hash,omitempty"`
+	UserId        string                 `protobuf:"bytes,12,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *AuditEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AuditEvent) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetApiTokenId() string {
+	if x != nil {
+		return x.ApiTokenId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreated() string {
+	if x != nil {
+		return x.Created
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPrevHash() []byte {
+	if x != nil {
+		return x.PrevHash
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	ItemId        string                 `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"` // RFC3339
+	Until         string                 `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"` // RFC3339
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *ListAuditEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // от последнего события
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_internal_proto_handlers_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_handlers_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_handlers_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_internal_proto_handlers_proto protoreflect.FileDescriptor
 
 const file_internal_proto_handlers_proto_rawDesc = "" +
 	"\n" +
-	"\x1dinternal/proto/handlers.proto\x12\vserver_grpc\"G\n" +
+	"\x1dinternal/proto/handlers.proto\x12\vserver_grpc\"~\n" +
 	"\x13PostUserDataRequest\x12\x14\n" +
 	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"W\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x125\n" +
+	"\n" +
+	"srp_record\x18\x03 \x01(\v2\x16.server_grpc.SrpRecordR\tsrpRecord\"\xa1\x02\n" +
 	"\x14PostUserDataResponse\x12\x14\n" +
 	"\x05error\x18\x01 \x01(\tR\x05error\x12\x10\n" +
 	"\x03jwt\x18\x02 \x01(\tR\x03jwt\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\"v\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x122\n" +
+	"\tvault_key\x18\x04 \x01(\v2\x15.server_grpc.VaultKeyR\bvaultKey\x12A\n" +
+	"\x11pending_vault_key\x18\x05 \x01(\v2\x15.server_grpc.VaultKeyR\x0fpendingVaultKey\x12.\n" +
+	"\x13recovery_public_key\x18\x06 \x01(\fR\x11recoveryPublicKey\x12!\n" +
+	"\fserver_proof\x18\a \x01(\fR\vserverProof\"\x96\x01\n" +
+	"\tSrpRecord\x12\x12\n" +
+	"\x04salt\x18\x01 \x01(\fR\x04salt\x12\x1a\n" +
+	"\bverifier\x18\x02 \x01(\fR\bverifier\x12\x19\n" +
+	"\bkdf_time\x18\x03 \x01(\rR\akdfTime\x12\x1d\n" +
+	"\n" +
+	"kdf_memory\x18\x04 \x01(\rR\tkdfMemory\x12\x1f\n" +
+	"\vkdf_threads\x18\x05 \x01(\rR\n" +
+	"kdfThreads\"b\n" +
+	"\x13RegisterUserRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x125\n" +
+	"\n" +
+	"srp_record\x18\x02 \x01(\v2\x16.server_grpc.SrpRecordR\tsrpRecord\",\n" +
+	"\x14RegisterUserResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"U\n" +
+	"\x11StartLoginRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12*\n" +
+	"\x11client_public_key\x18\x02 \x01(\fR\x0fclientPublicKey\"\xba\x01\n" +
+	"\x12StartLoginResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12*\n" +
+	"\x11server_public_key\x18\x03 \x01(\fR\x0fserverPublicKey\x125\n" +
+	"\n" +
+	"srp_record\x18\x04 \x01(\v2\x16.server_grpc.SrpRecordR\tsrpRecordJ\x04\b\x05\x10\x06R\x06legacy\"V\n" +
+	"\x12FinishLoginRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
+	"\fclient_proof\x18\x02 \x01(\fR\vclientProof\"\xe3\x01\n" +
+	"\bVaultKey\x12\x1f\n" +
+	"\vwrapped_key\x18\x01 \x01(\fR\n" +
+	"wrappedKey\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\fR\x04salt\x12\x19\n" +
+	"\bkdf_time\x18\x03 \x01(\rR\akdfTime\x12\x1d\n" +
+	"\n" +
+	"kdf_memory\x18\x04 \x01(\rR\tkdfMemory\x12\x1f\n" +
+	"\vkdf_threads\x18\x05 \x01(\rR\n" +
+	"kdfThreads\x12\x15\n" +
+	"\x06key_id\x18\x06 \x01(\rR\x05keyId\x120\n" +
+	"\x14recovery_wrapped_key\x18\a \x01(\fR\x12recoveryWrappedKey\"b\n" +
+	"\x13PostVaultKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\tvault_key\x18\x02 \x01(\v2\x15.server_grpc.VaultKeyR\bvaultKey\",\n" +
+	"\x14PostVaultKeyResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\xec\x01\n" +
+	"\x15ChangePasswordRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x124\n" +
+	"\n" +
+	"vault_keys\x18\x04 \x03(\v2\x15.server_grpc.VaultKeyR\tvaultKeys\x12$\n" +
+	"\x0esrp_session_id\x18\x05 \x01(\tR\fsrpSessionId\x12\x1b\n" +
+	"\tsrp_proof\x18\x06 \x01(\fR\bsrpProof\x125\n" +
+	"\n" +
+	"srp_record\x18\a \x01(\v2\x16.server_grpc.SrpRecordR\tsrpRecordJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"@\n" +
+	"\x16ChangePasswordResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x10\n" +
+	"\x03jwt\x18\x02 \x01(\tR\x03jwt\"\xee\x01\n" +
+	"\x16PostRecoveryKitRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x03 \x01(\fR\tpublicKey\x12\x1d\n" +
+	"\n" +
+	"auth_token\x18\x04 \x01(\fR\tauthToken\x124\n" +
+	"\n" +
+	"vault_keys\x18\x05 \x03(\v2\x15.server_grpc.VaultKeyR\tvaultKeys\x12$\n" +
+	"\x0esrp_session_id\x18\x06 \x01(\tR\fsrpSessionId\x12\x1b\n" +
+	"\tsrp_proof\x18\a \x01(\fR\bsrpProofJ\x04\b\x02\x10\x03\"/\n" +
+	"\x17PostRecoveryKitResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"L\n" +
+	"\x15GetRecoveryKitRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1d\n" +
+	"\n" +
+	"auth_token\x18\x02 \x01(\fR\tauthToken\"N\n" +
+	"\x16GetRecoveryKitResponse\x124\n" +
+	"\n" +
+	"vault_keys\x18\x01 \x03(\v2\x15.server_grpc.VaultKeyR\tvaultKeys\"\xbf\x01\n" +
+	"\x15RecoverAccountRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x1d\n" +
+	"\n" +
+	"auth_token\x18\x02 \x01(\fR\tauthToken\x124\n" +
+	"\n" +
+	"vault_keys\x18\x04 \x03(\v2\x15.server_grpc.VaultKeyR\tvaultKeys\x125\n" +
+	"\n" +
+	"srp_record\x18\x05 \x01(\v2\x16.server_grpc.SrpRecordR\tsrpRecordJ\x04\b\x03\x10\x04\"Y\n" +
+	"\x16RecoverAccountResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\x12\x10\n" +
+	"\x03jwt\x18\x02 \x01(\tR\x03jwt\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xae\x01\n" +
 	"\x13PostItemDataRequest\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\fR\x04data\x12\x17\n" +
 	"\adata_id\x18\x02 \x01(\tR\x06dataId\x122\n" +
-	"\tmeta_data\x18\x03 \x01(\v2\x15.server_grpc.MetaDataR\bmetaData\"e\n" +
+	"\tmeta_data\x18\x03 \x01(\v2\x15.server_grpc.MetaDataR\bmetaData\x12\x1f\n" +
+	"\vwrapped_key\x18\x04 \x01(\fR\n" +
+	"wrappedKey\x12\x15\n" +
+	"\x06key_id\x18\x05 \x01(\rR\x05keyId\"e\n" +
 	"\x14PostItemDataResponse\x12\x17\n" +
 	"\adata_id\x18\x01 \x01(\tR\x06dataId\x12\x18\n" +
 	"\acreated\x18\x02 \x01(\tR\acreated\x12\x1a\n" +
 	"\bmodified\x18\x03 \x01(\tR\bmodified\"-\n" +
 	"\x12GetItemDataRequest\x12\x17\n" +
-	"\adata_id\x18\x01 \x01(\tR\x06dataId\")\n" +
+	"\adata_id\x18\x01 \x01(\tR\x06dataId\"J\n" +
 	"\x13GetItemDataResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"\xd7\x01\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\"*\n" +
+	"\x0fGetUsageRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9c\x01\n" +
+	"\x10GetUsageResponse\x12\x14\n" +
+	"\x05items\x18\x01 \x01(\x03R\x05items\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x1b\n" +
+	"\tmax_items\x18\x03 \x01(\x03R\bmaxItems\x12\x1b\n" +
+	"\tmax_bytes\x18\x04 \x01(\x03R\bmaxBytes\x12\"\n" +
+	"\rmax_item_size\x18\x05 \x01(\x03R\vmaxItemSize\"\xcf\x02\n" +
 	"\bMetaData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -666,26 +8240,536 @@ const file_internal_proto_handlers_proto_rawDesc = "" +
 	"\adata_id\x18\x05 \x01(\tR\x06dataId\x12\x17\n" +
 	"\auser_id\x18\x06 \x01(\tR\x06userId\x12\x18\n" +
 	"\acreated\x18\a \x01(\tR\acreated\x12\x1a\n" +
-	"\bmodified\x18\b \x01(\tR\bmodified\"-\n" +
+	"\bmodified\x18\b \x01(\tR\bmodified\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x1b\n" +
+	"\tfolder_id\x18\n" +
+	" \x01(\tR\bfolderId\x12%\n" +
+	"\x0eencrypted_meta\x18\v \x01(\fR\rencryptedMeta\x12\x1e\n" +
+	"\vmeta_key_id\x18\f \x01(\rR\tmetaKeyId\"\xdc\x01\n" +
 	"\x12GetMetaDataRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"B\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\tR\bfolderId\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tdata_type\x18\x06 \x01(\tR\bdataType\x12%\n" +
+	"\x0emodified_since\x18\a \x01(\tR\rmodifiedSince\"j\n" +
 	"\x13GetMetaDataResponse\x12+\n" +
-	"\x05items\x18\x01 \x03(\v2\x15.server_grpc.MetaDataR\x05items\"v\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.server_grpc.MetaDataR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"v\n" +
 	"\x15DeleteMetaDataRequest\x12\x1f\n" +
 	"\vmetadata_id\x18\x01 \x01(\tR\n" +
 	"metadataId\x12#\n" +
 	"\rmetadata_type\x18\x02 \x01(\tR\fmetadataType\x12\x17\n" +
 	"\adata_id\x18\x03 \x01(\tR\x06dataId\".\n" +
 	"\x16DeleteMetaDataResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error2c\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\x98\x01\n" +
+	"\x06Folder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\tR\acreated\x12\x1a\n" +
+	"\bmodified\x18\x06 \x01(\tR\bmodified\"@\n" +
+	"\x11PostFolderRequest\x12+\n" +
+	"\x06folder\x18\x01 \x01(\v2\x13.server_grpc.FolderR\x06folder\"A\n" +
+	"\x12PostFolderResponse\x12+\n" +
+	"\x06folder\x18\x01 \x01(\v2\x13.server_grpc.FolderR\x06folder\",\n" +
+	"\x11GetFoldersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"C\n" +
+	"\x12GetFoldersResponse\x12-\n" +
+	"\afolders\x18\x01 \x03(\v2\x13.server_grpc.FolderR\afolders\"K\n" +
+	"\x13DeleteFolderRequest\x12\x1b\n" +
+	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\",\n" +
+	"\x14DeleteFolderResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"f\n" +
+	"\x17StartKeyRotationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\tvault_key\x18\x02 \x01(\v2\x15.server_grpc.VaultKeyR\bvaultKey\"1\n" +
+	"\x18StartKeyRotationResponse\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\rR\x05keyId\"C\n" +
+	"\aItemKey\x12\x17\n" +
+	"\adata_id\x18\x01 \x01(\tR\x06dataId\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\"[\n" +
+	"\x13GetStaleKeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\rR\x05keyId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x8d\x01\n" +
+	"\x14GetStaleKeysResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.server_grpc.ItemKeyR\x05items\x12+\n" +
+	"\x05metas\x18\x02 \x03(\v2\x15.server_grpc.MetaDataR\x05metas\x12\x1c\n" +
+	"\tremaining\x18\x03 \x01(\x03R\tremaining\"\x9c\x01\n" +
+	"\x11RewrapKeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\rR\x05keyId\x12*\n" +
+	"\x05items\x18\x03 \x03(\v2\x14.server_grpc.ItemKeyR\x05items\x12+\n" +
+	"\x05metas\x18\x04 \x03(\v2\x15.server_grpc.MetaDataR\x05metas\"2\n" +
+	"\x12RewrapKeysResponse\x12\x1c\n" +
+	"\tremaining\x18\x01 \x01(\x03R\tremaining\"J\n" +
+	"\x18FinishKeyRotationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\rR\x05keyId\"1\n" +
+	"\x19FinishKeyRotationResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"r\n" +
+	"\n" +
+	"SharingKey\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12.\n" +
+	"\x13wrapped_private_key\x18\x02 \x01(\fR\x11wrappedPrivateKey\x12\x15\n" +
+	"\x06key_id\x18\x03 \x01(\rR\x05keyId\"j\n" +
+	"\x15PostSharingKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x128\n" +
+	"\vsharing_key\x18\x02 \x01(\v2\x17.server_grpc.SharingKeyR\n" +
+	"sharingKey\".\n" +
+	"\x16PostSharingKeyResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"/\n" +
+	"\x14GetSharingKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"Q\n" +
+	"\x15GetSharingKeyResponse\x128\n" +
+	"\vsharing_key\x18\x01 \x01(\v2\x17.server_grpc.SharingKeyR\n" +
+	"sharingKey\"+\n" +
+	"\x13GetPublicKeyRequest\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\"N\n" +
+	"\x14GetPublicKeyResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\"\xe7\x02\n" +
+	"\x05Share\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\adata_id\x18\x02 \x01(\tR\x06dataId\x12\x1f\n" +
+	"\vowner_login\x18\x03 \x01(\tR\n" +
+	"ownerLogin\x12'\n" +
+	"\x0frecipient_login\x18\x04 \x01(\tR\x0erecipientLogin\x12\x1f\n" +
+	"\vwrapped_key\x18\x05 \x01(\fR\n" +
+	"wrappedKey\x12%\n" +
+	"\x0eencrypted_meta\x18\x06 \x01(\fR\rencryptedMeta\x12\x1b\n" +
+	"\tdata_type\x18\a \x01(\tR\bdataType\x12\x1e\n" +
+	"\n" +
+	"permission\x18\b \x01(\tR\n" +
+	"permission\x120\n" +
+	"\x14recipient_public_key\x18\t \x01(\fR\x12recipientPublicKey\x12\x18\n" +
+	"\acreated\x18\n" +
+	" \x01(\tR\acreated\x12\x1a\n" +
+	"\bmodified\x18\v \x01(\tR\bmodified\"\xd5\x01\n" +
+	"\x10ShareItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\adata_id\x18\x02 \x01(\tR\x06dataId\x12'\n" +
+	"\x0frecipient_login\x18\x03 \x01(\tR\x0erecipientLogin\x12\x1f\n" +
+	"\vwrapped_key\x18\x04 \x01(\fR\n" +
+	"wrappedKey\x12%\n" +
+	"\x0eencrypted_meta\x18\x05 \x01(\fR\rencryptedMeta\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x06 \x01(\tR\n" +
+	"permission\"=\n" +
+	"\x11ShareItemResponse\x12(\n" +
+	"\x05share\x18\x01 \x01(\v2\x12.server_grpc.ShareR\x05share\"D\n" +
+	"\x10GetSharesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\adata_id\x18\x02 \x01(\tR\x06dataId\"?\n" +
+	"\x11GetSharesResponse\x12*\n" +
+	"\x06shares\x18\x01 \x03(\v2\x12.server_grpc.ShareR\x06shares\"H\n" +
+	"\x12RevokeShareRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bshare_id\x18\x02 \x01(\tR\ashareId\"+\n" +
+	"\x13RevokeShareResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"1\n" +
+	"\x16GetSharedWithMeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"E\n" +
+	"\x17GetSharedWithMeResponse\x12*\n" +
+	"\x06shares\x18\x01 \x03(\v2\x12.server_grpc.ShareR\x06shares\"J\n" +
+	"\x14GetSharedItemRequest\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"U\n" +
+	"\x15GetSharedItemResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12(\n" +
+	"\x05share\x18\x02 \x01(\v2\x12.server_grpc.ShareR\x05share\"a\n" +
+	"\x17UpdateSharedItemRequest\x12\x19\n" +
+	"\bshare_id\x18\x01 \x01(\tR\ashareId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"6\n" +
+	"\x18UpdateSharedItemResponse\x12\x1a\n" +
+	"\bmodified\x18\x01 \x01(\tR\bmodified\"|\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1a\n" +
+	"\baccepted\x18\x04 \x01(\bR\baccepted\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\tR\acreated\"\x89\x01\n" +
+	"\tOrgMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1a\n" +
+	"\baccepted\x18\x04 \x01(\bR\baccepted\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x05 \x01(\fR\tpublicKey\"\x85\x01\n" +
+	"\rCollectionKey\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06key_id\x18\x03 \x01(\rR\x05keyId\x12\x1f\n" +
+	"\vwrapped_key\x18\x04 \x01(\fR\n" +
+	"wrappedKey\"\x99\x01\n" +
+	"\n" +
+	"Collection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x15\n" +
+	"\x06key_id\x18\x04 \x01(\rR\x05keyId\x12\x1f\n" +
+	"\vwrapped_key\x18\x05 \x01(\fR\n" +
+	"wrappedKey\x12\x18\n" +
+	"\acreated\x18\x06 \x01(\tR\acreated\"\x8b\x02\n" +
+	"\x0eCollectionItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1f\n" +
+	"\vwrapped_key\x18\x04 \x01(\fR\n" +
+	"wrappedKey\x12\x15\n" +
+	"\x06key_id\x18\x05 \x01(\rR\x05keyId\x12%\n" +
+	"\x0eencrypted_meta\x18\x06 \x01(\fR\rencryptedMeta\x12\x1b\n" +
+	"\tdata_type\x18\a \x01(\tR\bdataType\x12\x18\n" +
+	"\acreated\x18\b \x01(\tR\acreated\x12\x1a\n" +
+	"\bmodified\x18\t \x01(\tR\bmodified\"?\n" +
+	"\x10CreateOrgRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"R\n" +
+	"\x11CreateOrgResponse\x12=\n" +
+	"\forganization\x18\x01 \x01(\v2\x19.server_grpc.OrganizationR\forganization\")\n" +
+	"\x0eGetOrgsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"R\n" +
+	"\x0fGetOrgsResponse\x12?\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x19.server_grpc.OrganizationR\rorganizations\"B\n" +
+	"\x10DeleteOrgRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\")\n" +
+	"\x11DeleteOrgResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"F\n" +
+	"\x14GetOrgMembersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\"I\n" +
+	"\x15GetOrgMembersResponse\x120\n" +
+	"\amembers\x18\x01 \x03(\v2\x16.server_grpc.OrgMemberR\amembers\"\x9f\x01\n" +
+	"\x13InviteMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05login\x18\x03 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12.\n" +
+	"\x04keys\x18\x05 \x03(\v2\x1a.server_grpc.CollectionKeyR\x04keys\"F\n" +
+	"\x14InviteMemberResponse\x12.\n" +
+	"\x06member\x18\x01 \x01(\v2\x16.server_grpc.OrgMemberR\x06member\"a\n" +
+	"\x17AnswerInvitationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\"0\n" +
+	"\x18AnswerInvitationResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"b\n" +
+	"\x13RemoveMemberRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\tR\bmemberId\",\n" +
+	"\x14RemoveMemberResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"t\n" +
+	"\x11ChangeRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x1b\n" +
+	"\tmember_id\x18\x03 \x01(\tR\bmemberId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"*\n" +
+	"\x12ChangeRoleResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\x9d\x01\n" +
+	"\x17CreateCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12.\n" +
+	"\x04keys\x18\x05 \x03(\v2\x1a.server_grpc.CollectionKeyR\x04keys\"S\n" +
+	"\x18CreateCollectionResponse\x127\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\v2\x17.server_grpc.CollectionR\n" +
+	"collection\"G\n" +
+	"\x15GetCollectionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\"S\n" +
+	"\x16GetCollectionsResponse\x129\n" +
+	"\vcollections\x18\x01 \x03(\v2\x17.server_grpc.CollectionR\vcollections\"W\n" +
+	"\x17DeleteCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\"0\n" +
+	"\x18DeleteCollectionResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"Y\n" +
+	"\x19GetCollectionItemsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\"O\n" +
+	"\x1aGetCollectionItemsResponse\x121\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.server_grpc.CollectionItemR\x05items\"L\n" +
+	"\x18GetCollectionItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"L\n" +
+	"\x19GetCollectionItemResponse\x12/\n" +
+	"\x04item\x18\x01 \x01(\v2\x1b.server_grpc.CollectionItemR\x04item\"e\n" +
+	"\x19PostCollectionItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12/\n" +
+	"\x04item\x18\x02 \x01(\v2\x1b.server_grpc.CollectionItemR\x04item\"R\n" +
+	"\x1aPostCollectionItemResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\tR\acreated\x12\x1a\n" +
+	"\bmodified\x18\x02 \x01(\tR\bmodified\"O\n" +
+	"\x1bDeleteCollectionItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\tR\x06itemId\"4\n" +
+	"\x1cDeleteCollectionItemResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\xd0\x01\n" +
+	"\x16RekeyCollectionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcollection_id\x18\x02 \x01(\tR\fcollectionId\x12\x15\n" +
+	"\x06key_id\x18\x03 \x01(\rR\x05keyId\x12.\n" +
+	"\x04keys\x18\x04 \x03(\v2\x1a.server_grpc.CollectionKeyR\x04keys\x121\n" +
+	"\x05items\x18\x05 \x03(\v2\x1b.server_grpc.CollectionItemR\x05items\"/\n" +
+	"\x17RekeyCollectionResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\xbf\x02\n" +
+	"\x0fEmergencyAccess\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vowner_login\x18\x02 \x01(\tR\n" +
+	"ownerLogin\x12#\n" +
+	"\rcontact_login\x18\x03 \x01(\tR\fcontactLogin\x12,\n" +
+	"\x12contact_public_key\x18\x04 \x01(\fR\x10contactPublicKey\x12\x1d\n" +
+	"\n" +
+	"wait_hours\x18\x05 \x01(\rR\twaitHours\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12!\n" +
+	"\frequested_at\x18\a \x01(\tR\vrequestedAt\x12\x1d\n" +
+	"\n" +
+	"release_at\x18\b \x01(\tR\treleaseAt\x12\x15\n" +
+	"\x06key_id\x18\t \x01(\rR\x05keyId\x12\x18\n" +
+	"\acreated\x18\n" +
+	" \x01(\tR\acreated\"\xb0\x01\n" +
+	"\x1bGrantEmergencyAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rcontact_login\x18\x02 \x01(\tR\fcontactLogin\x12\x1d\n" +
+	"\n" +
+	"wait_hours\x18\x03 \x01(\rR\twaitHours\x12\x15\n" +
+	"\x06key_id\x18\x04 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"sealed_key\x18\x05 \x01(\fR\tsealedKey\"T\n" +
+	"\x1cGrantEmergencyAccessResponse\x124\n" +
+	"\x06access\x18\x01 \x01(\v2\x1c.server_grpc.EmergencyAccessR\x06access\"4\n" +
+	"\x19GetEmergencyAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x91\x01\n" +
+	"\x1aGetEmergencyAccessResponse\x126\n" +
+	"\agranted\x18\x01 \x03(\v2\x1c.server_grpc.EmergencyAccessR\agranted\x12;\n" +
+	"\n" +
+	"trusted_by\x18\x02 \x03(\v2\x1c.server_grpc.EmergencyAccessR\ttrustedBy\"T\n" +
+	"\x1cRevokeEmergencyAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\taccess_id\x18\x02 \x01(\tR\baccessId\"5\n" +
+	"\x1dRevokeEmergencyAccessResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"U\n" +
+	"\x1dRequestEmergencyAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\taccess_id\x18\x02 \x01(\tR\baccessId\"V\n" +
+	"\x1eRequestEmergencyAccessResponse\x124\n" +
+	"\x06access\x18\x01 \x01(\v2\x1c.server_grpc.EmergencyAccessR\x06access\"n\n" +
+	"\x1cAnswerEmergencyAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\taccess_id\x18\x02 \x01(\tR\baccessId\x12\x18\n" +
+	"\aapprove\x18\x03 \x01(\bR\aapprove\"5\n" +
+	"\x1dAnswerEmergencyAccessResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"a\n" +
+	"\fEmergencyKey\x12\x1b\n" +
+	"\taccess_id\x18\x01 \x01(\tR\baccessId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"sealed_key\x18\x03 \x01(\fR\tsealedKey\"d\n" +
+	"\x1aUpdateEmergencyKeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12-\n" +
+	"\x04keys\x18\x02 \x03(\v2\x19.server_grpc.EmergencyKeyR\x04keys\"3\n" +
+	"\x1bUpdateEmergencyKeysResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"P\n" +
+	"\x18GetEmergencyVaultRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\taccess_id\x18\x02 \x01(\tR\baccessId\"l\n" +
+	"\x19GetEmergencyVaultResponse\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\tR\aownerId\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"sealed_key\x18\x03 \x01(\fR\tsealedKey\"o\n" +
+	"\x18GetEmergencyItemsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\taccess_id\x18\x02 \x01(\tR\baccessId\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"p\n" +
+	"\x19GetEmergencyItemsResponse\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.server_grpc.MetaDataR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"h\n" +
+	"\x17GetEmergencyItemRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\taccess_id\x18\x02 \x01(\tR\baccessId\x12\x17\n" +
+	"\adata_id\x18\x03 \x01(\tR\x06dataId\"O\n" +
+	"\x18GetEmergencyItemResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1f\n" +
+	"\vwrapped_key\x18\x02 \x01(\fR\n" +
+	"wrappedKey\"\x9c\x01\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vfingerprint\x18\x03 \x01(\tR\vfingerprint\x12\x18\n" +
+	"\aexpires\x18\x04 \x01(\tR\aexpires\x12\x18\n" +
+	"\acreated\x18\x05 \x01(\tR\acreated\x12\x18\n" +
+	"\arevoked\x18\x06 \x01(\tR\arevoked\"T\n" +
+	"\x13EnrollDeviceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03csr\x18\x03 \x01(\fR\x03csr\"\x8c\x01\n" +
+	"\x14EnrollDeviceResponse\x12+\n" +
+	"\x06device\x18\x01 \x01(\v2\x13.server_grpc.DeviceR\x06device\x12 \n" +
+	"\vcertificate\x18\x02 \x01(\fR\vcertificate\x12%\n" +
+	"\x0eca_certificate\x18\x03 \x01(\fR\rcaCertificate\",\n" +
+	"\x11GetDevicesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"C\n" +
+	"\x12GetDevicesResponse\x12-\n" +
+	"\adevices\x18\x01 \x03(\v2\x13.server_grpc.DeviceR\adevices\"K\n" +
+	"\x13RevokeDeviceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\",\n" +
+	"\x14RevokeDeviceResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\xda\x01\n" +
+	"\bAPIToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\x12\x1d\n" +
+	"\n" +
+	"folder_ids\x18\x04 \x03(\tR\tfolderIds\x12\x1d\n" +
+	"\n" +
+	"data_types\x18\x05 \x03(\tR\tdataTypes\x12\x18\n" +
+	"\aexpires\x18\x06 \x01(\tR\aexpires\x12\x18\n" +
+	"\acreated\x18\a \x01(\tR\acreated\x12\x1b\n" +
+	"\tlast_used\x18\b \x01(\tR\blastUsed\"\xba\x01\n" +
+	"\x15CreateAPITokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\x12\x1d\n" +
+	"\n" +
+	"folder_ids\x18\x04 \x03(\tR\tfolderIds\x12\x1d\n" +
+	"\n" +
+	"data_types\x18\x05 \x03(\tR\tdataTypes\x12\x19\n" +
+	"\bttl_days\x18\x06 \x01(\x05R\attlDays\"]\n" +
+	"\x16CreateAPITokenResponse\x12+\n" +
+	"\x05token\x18\x01 \x01(\v2\x15.server_grpc.APITokenR\x05token\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\".\n" +
+	"\x13GetAPITokensRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"E\n" +
+	"\x14GetAPITokensResponse\x12-\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x15.server_grpc.APITokenR\x06tokens\"K\n" +
+	"\x15RevokeAPITokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\btoken_id\x18\x02 \x01(\tR\atokenId\".\n" +
+	"\x16RevokeAPITokenResponse\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"\xba\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x17\n" +
+	"\aitem_id\x18\x04 \x01(\tR\x06itemId\x12\x1b\n" +
+	"\tdevice_id\x18\x05 \x01(\tR\bdeviceId\x12 \n" +
+	"\fapi_token_id\x18\x06 \x01(\tR\n" +
+	"apiTokenId\x12\x12\n" +
+	"\x04peer\x18\a \x01(\tR\x04peer\x12\x18\n" +
+	"\adetails\x18\b \x01(\tR\adetails\x12\x18\n" +
+	"\acreated\x18\t \x01(\tR\acreated\x12\x1b\n" +
+	"\tprev_hash\x18\n" +
+	" \x01(\fR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\v \x01(\fR\x04hash\x12\x17\n" +
+	"\auser_id\x18\f \x01(\tR\x06userId\"\xca\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x17\n" +
+	"\aitem_id\x18\x03 \x01(\tR\x06itemId\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"r\n" +
+	"\x17ListAuditEventsResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.server_grpc.AuditEventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\x9e\x06\n" +
 	"\fUserHandlers\x12S\n" +
-	"\fPostUserData\x12 .server_grpc.PostUserDataRequest\x1a!.server_grpc.PostUserDataResponse2\xb9\x01\n" +
+	"\fPostUserData\x12 .server_grpc.PostUserDataRequest\x1a!.server_grpc.PostUserDataResponse\x12S\n" +
+	"\fRegisterUser\x12 .server_grpc.RegisterUserRequest\x1a!.server_grpc.RegisterUserResponse\x12M\n" +
+	"\n" +
+	"StartLogin\x12\x1e.server_grpc.StartLoginRequest\x1a\x1f.server_grpc.StartLoginResponse\x12Q\n" +
+	"\vFinishLogin\x12\x1f.server_grpc.FinishLoginRequest\x1a!.server_grpc.PostUserDataResponse\x12S\n" +
+	"\fPostVaultKey\x12 .server_grpc.PostVaultKeyRequest\x1a!.server_grpc.PostVaultKeyResponse\x12Y\n" +
+	"\x0eChangePassword\x12\".server_grpc.ChangePasswordRequest\x1a#.server_grpc.ChangePasswordResponse\x12\\\n" +
+	"\x0fPostRecoveryKit\x12#.server_grpc.PostRecoveryKitRequest\x1a$.server_grpc.PostRecoveryKitResponse\x12Y\n" +
+	"\x0eGetRecoveryKit\x12\".server_grpc.GetRecoveryKitRequest\x1a#.server_grpc.GetRecoveryKitResponse\x12Y\n" +
+	"\x0eRecoverAccount\x12\".server_grpc.RecoverAccountRequest\x1a#.server_grpc.RecoverAccountResponse2\x82\x02\n" +
 	"\x10ItemDataHandlers\x12S\n" +
 	"\fPostItemData\x12 .server_grpc.PostItemDataRequest\x1a!.server_grpc.PostItemDataResponse\x12P\n" +
-	"\vGetItemData\x12\x1f.server_grpc.GetItemDataRequest\x1a .server_grpc.GetItemDataResponse2\xbf\x01\n" +
+	"\vGetItemData\x12\x1f.server_grpc.GetItemDataRequest\x1a .server_grpc.GetItemDataResponse\x12G\n" +
+	"\bGetUsage\x12\x1c.server_grpc.GetUsageRequest\x1a\x1d.server_grpc.GetUsageResponse2\xbf\x01\n" +
 	"\x10MetaDataHandlers\x12P\n" +
 	"\vGetMetaData\x12\x1f.server_grpc.GetMetaDataRequest\x1a .server_grpc.GetMetaDataResponse\x12Y\n" +
-	"\x0eDeleteMetaData\x12\".server_grpc.DeleteMetaDataRequest\x1a#.server_grpc.DeleteMetaDataResponseB\x13Z\x11internal/protobufb\x06proto3"
+	"\x0eDeleteMetaData\x12\".server_grpc.DeleteMetaDataRequest\x1a#.server_grpc.DeleteMetaDataResponse2\x83\x02\n" +
+	"\x0eFolderHandlers\x12M\n" +
+	"\n" +
+	"PostFolder\x12\x1e.server_grpc.PostFolderRequest\x1a\x1f.server_grpc.PostFolderResponse\x12M\n" +
+	"\n" +
+	"GetFolders\x12\x1e.server_grpc.GetFoldersRequest\x1a\x1f.server_grpc.GetFoldersResponse\x12S\n" +
+	"\fDeleteFolder\x12 .server_grpc.DeleteFolderRequest\x1a!.server_grpc.DeleteFolderResponse2\xf8\x02\n" +
+	"\rVaultHandlers\x12_\n" +
+	"\x10StartKeyRotation\x12$.server_grpc.StartKeyRotationRequest\x1a%.server_grpc.StartKeyRotationResponse\x12S\n" +
+	"\fGetStaleKeys\x12 .server_grpc.GetStaleKeysRequest\x1a!.server_grpc.GetStaleKeysResponse\x12M\n" +
+	"\n" +
+	"RewrapKeys\x12\x1e.server_grpc.RewrapKeysRequest\x1a\x1f.server_grpc.RewrapKeysResponse\x12b\n" +
+	"\x11FinishKeyRotation\x12%.server_grpc.FinishKeyRotationRequest\x1a&.server_grpc.FinishKeyRotationResponse2\x9a\x06\n" +
+	"\x0fSharingHandlers\x12Y\n" +
+	"\x0ePostSharingKey\x12\".server_grpc.PostSharingKeyRequest\x1a#.server_grpc.PostSharingKeyResponse\x12V\n" +
+	"\rGetSharingKey\x12!.server_grpc.GetSharingKeyRequest\x1a\".server_grpc.GetSharingKeyResponse\x12S\n" +
+	"\fGetPublicKey\x12 .server_grpc.GetPublicKeyRequest\x1a!.server_grpc.GetPublicKeyResponse\x12J\n" +
+	"\tShareItem\x12\x1d.server_grpc.ShareItemRequest\x1a\x1e.server_grpc.ShareItemResponse\x12J\n" +
+	"\tGetShares\x12\x1d.server_grpc.GetSharesRequest\x1a\x1e.server_grpc.GetSharesResponse\x12P\n" +
+	"\vRevokeShare\x12\x1f.server_grpc.RevokeShareRequest\x1a .server_grpc.RevokeShareResponse\x12\\\n" +
+	"\x0fGetSharedWithMe\x12#.server_grpc.GetSharedWithMeRequest\x1a$.server_grpc.GetSharedWithMeResponse\x12V\n" +
+	"\rGetSharedItem\x12!.server_grpc.GetSharedItemRequest\x1a\".server_grpc.GetSharedItemResponse\x12_\n" +
+	"\x10UpdateSharedItem\x12$.server_grpc.UpdateSharedItemRequest\x1a%.server_grpc.UpdateSharedItemResponse2\xb7\v\n" +
+	"\vOrgHandlers\x12J\n" +
+	"\tCreateOrg\x12\x1d.server_grpc.CreateOrgRequest\x1a\x1e.server_grpc.CreateOrgResponse\x12D\n" +
+	"\aGetOrgs\x12\x1b.server_grpc.GetOrgsRequest\x1a\x1c.server_grpc.GetOrgsResponse\x12J\n" +
+	"\tDeleteOrg\x12\x1d.server_grpc.DeleteOrgRequest\x1a\x1e.server_grpc.DeleteOrgResponse\x12V\n" +
+	"\rGetOrgMembers\x12!.server_grpc.GetOrgMembersRequest\x1a\".server_grpc.GetOrgMembersResponse\x12S\n" +
+	"\fInviteMember\x12 .server_grpc.InviteMemberRequest\x1a!.server_grpc.InviteMemberResponse\x12_\n" +
+	"\x10AnswerInvitation\x12$.server_grpc.AnswerInvitationRequest\x1a%.server_grpc.AnswerInvitationResponse\x12S\n" +
+	"\fRemoveMember\x12 .server_grpc.RemoveMemberRequest\x1a!.server_grpc.RemoveMemberResponse\x12M\n" +
+	"\n" +
+	"ChangeRole\x12\x1e.server_grpc.ChangeRoleRequest\x1a\x1f.server_grpc.ChangeRoleResponse\x12_\n" +
+	"\x10CreateCollection\x12$.server_grpc.CreateCollectionRequest\x1a%.server_grpc.CreateCollectionResponse\x12Y\n" +
+	"\x0eGetCollections\x12\".server_grpc.GetCollectionsRequest\x1a#.server_grpc.GetCollectionsResponse\x12_\n" +
+	"\x10DeleteCollection\x12$.server_grpc.DeleteCollectionRequest\x1a%.server_grpc.DeleteCollectionResponse\x12e\n" +
+	"\x12GetCollectionItems\x12&.server_grpc.GetCollectionItemsRequest\x1a'.server_grpc.GetCollectionItemsResponse\x12b\n" +
+	"\x11GetCollectionItem\x12%.server_grpc.GetCollectionItemRequest\x1a&.server_grpc.GetCollectionItemResponse\x12e\n" +
+	"\x12PostCollectionItem\x12&.server_grpc.PostCollectionItemRequest\x1a'.server_grpc.PostCollectionItemResponse\x12k\n" +
+	"\x14DeleteCollectionItem\x12(.server_grpc.DeleteCollectionItemRequest\x1a).server_grpc.DeleteCollectionItemResponse\x12\\\n" +
+	"\x0fRekeyCollection\x12#.server_grpc.RekeyCollectionRequest\x1a$.server_grpc.RekeyCollectionResponse2\xcd\a\n" +
+	"\x11EmergencyHandlers\x12k\n" +
+	"\x14GrantEmergencyAccess\x12(.server_grpc.GrantEmergencyAccessRequest\x1a).server_grpc.GrantEmergencyAccessResponse\x12e\n" +
+	"\x12GetEmergencyAccess\x12&.server_grpc.GetEmergencyAccessRequest\x1a'.server_grpc.GetEmergencyAccessResponse\x12n\n" +
+	"\x15RevokeEmergencyAccess\x12).server_grpc.RevokeEmergencyAccessRequest\x1a*.server_grpc.RevokeEmergencyAccessResponse\x12q\n" +
+	"\x16RequestEmergencyAccess\x12*.server_grpc.RequestEmergencyAccessRequest\x1a+.server_grpc.RequestEmergencyAccessResponse\x12n\n" +
+	"\x15AnswerEmergencyAccess\x12).server_grpc.AnswerEmergencyAccessRequest\x1a*.server_grpc.AnswerEmergencyAccessResponse\x12h\n" +
+	"\x13UpdateEmergencyKeys\x12'.server_grpc.UpdateEmergencyKeysRequest\x1a(.server_grpc.UpdateEmergencyKeysResponse\x12b\n" +
+	"\x11GetEmergencyVault\x12%.server_grpc.GetEmergencyVaultRequest\x1a&.server_grpc.GetEmergencyVaultResponse\x12b\n" +
+	"\x11GetEmergencyItems\x12%.server_grpc.GetEmergencyItemsRequest\x1a&.server_grpc.GetEmergencyItemsResponse\x12_\n" +
+	"\x10GetEmergencyItem\x12$.server_grpc.GetEmergencyItemRequest\x1a%.server_grpc.GetEmergencyItemResponse2\x89\x02\n" +
+	"\x0eDeviceHandlers\x12S\n" +
+	"\fEnrollDevice\x12 .server_grpc.EnrollDeviceRequest\x1a!.server_grpc.EnrollDeviceResponse\x12M\n" +
+	"\n" +
+	"GetDevices\x12\x1e.server_grpc.GetDevicesRequest\x1a\x1f.server_grpc.GetDevicesResponse\x12S\n" +
+	"\fRevokeDevice\x12 .server_grpc.RevokeDeviceRequest\x1a!.server_grpc.RevokeDeviceResponse2\x9d\x02\n" +
+	"\x10APITokenHandlers\x12Y\n" +
+	"\x0eCreateAPIToken\x12\".server_grpc.CreateAPITokenRequest\x1a#.server_grpc.CreateAPITokenResponse\x12S\n" +
+	"\fGetAPITokens\x12 .server_grpc.GetAPITokensRequest\x1a!.server_grpc.GetAPITokensResponse\x12Y\n" +
+	"\x0eRevokeAPIToken\x12\".server_grpc.RevokeAPITokenRequest\x1a#.server_grpc.RevokeAPITokenResponse2m\n" +
+	"\rAuditHandlers\x12\\\n" +
+	"\x0fListAuditEvents\x12#.server_grpc.ListAuditEventsRequest\x1a$.server_grpc.ListAuditEventsResponseB\x13Z\x11internal/protobufb\x06proto3"
 
 var (
 	file_internal_proto_handlers_proto_rawDescOnce sync.Once
@@ -699,38 +8783,331 @@ func file_internal_proto_handlers_proto_rawDescGZIP() []byte {
 	return file_internal_proto_handlers_proto_rawDescData
 }
 
-var file_internal_proto_handlers_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_internal_proto_handlers_proto_msgTypes = make([]protoimpl.MessageInfo, 140)
 var file_internal_proto_handlers_proto_goTypes = []any{
-	(*PostUserDataRequest)(nil),    // 0: server_grpc.PostUserDataRequest
-	(*PostUserDataResponse)(nil),   // 1: server_grpc.PostUserDataResponse
-	(*PostItemDataRequest)(nil),    // 2: server_grpc.PostItemDataRequest
-	(*PostItemDataResponse)(nil),   // 3: server_grpc.PostItemDataResponse
-	(*GetItemDataRequest)(nil),     // 4: server_grpc.GetItemDataRequest
-	(*GetItemDataResponse)(nil),    // 5: server_grpc.GetItemDataResponse
-	(*MetaData)(nil),               // 6: server_grpc.MetaData
-	(*GetMetaDataRequest)(nil),     // 7: server_grpc.GetMetaDataRequest
-	(*GetMetaDataResponse)(nil),    // 8: server_grpc.GetMetaDataResponse
-	(*DeleteMetaDataRequest)(nil),  // 9: server_grpc.DeleteMetaDataRequest
-	(*DeleteMetaDataResponse)(nil), // 10: server_grpc.DeleteMetaDataResponse
+	(*PostUserDataRequest)(nil),            // 0: server_grpc.PostUserDataRequest
+	(*PostUserDataResponse)(nil),           // 1: server_grpc.PostUserDataResponse
+	(*SrpRecord)(nil),                      // 2: server_grpc.SrpRecord
+	(*RegisterUserRequest)(nil),            // 3: server_grpc.RegisterUserRequest
+	(*RegisterUserResponse)(nil),           // 4: server_grpc.RegisterUserResponse
+	(*StartLoginRequest)(nil),              // 5: server_grpc.StartLoginRequest
+	(*StartLoginResponse)(nil),             // 6: server_grpc.StartLoginResponse
+	(*FinishLoginRequest)(nil),             // 7: server_grpc.FinishLoginRequest
+	(*VaultKey)(nil),                       // 8: server_grpc.VaultKey
+	(*PostVaultKeyRequest)(nil),            // 9: server_grpc.PostVaultKeyRequest
+	(*PostVaultKeyResponse)(nil),           // 10: server_grpc.PostVaultKeyResponse
+	(*ChangePasswordRequest)(nil),          // 11: server_grpc.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),         // 12: server_grpc.ChangePasswordResponse
+	(*PostRecoveryKitRequest)(nil),         // 13: server_grpc.PostRecoveryKitRequest
+	(*PostRecoveryKitResponse)(nil),        // 14: server_grpc.PostRecoveryKitResponse
+	(*GetRecoveryKitRequest)(nil),          // 15: server_grpc.GetRecoveryKitRequest
+	(*GetRecoveryKitResponse)(nil),         // 16: server_grpc.GetRecoveryKitResponse
+	(*RecoverAccountRequest)(nil),          // 17: server_grpc.RecoverAccountRequest
+	(*RecoverAccountResponse)(nil),         // 18: server_grpc.RecoverAccountResponse
+	(*PostItemDataRequest)(nil),            // 19: server_grpc.PostItemDataRequest
+	(*PostItemDataResponse)(nil),           // 20: server_grpc.PostItemDataResponse
+	(*GetItemDataRequest)(nil),             // 21: server_grpc.GetItemDataRequest
+	(*GetItemDataResponse)(nil),            // 22: server_grpc.GetItemDataResponse
+	(*GetUsageRequest)(nil),                // 23: server_grpc.GetUsageRequest
+	(*GetUsageResponse)(nil),               // 24: server_grpc.GetUsageResponse
+	(*MetaData)(nil),                       // 25: server_grpc.MetaData
+	(*GetMetaDataRequest)(nil),             // 26: server_grpc.GetMetaDataRequest
+	(*GetMetaDataResponse)(nil),            // 27: server_grpc.GetMetaDataResponse
+	(*DeleteMetaDataRequest)(nil),          // 28: server_grpc.DeleteMetaDataRequest
+	(*DeleteMetaDataResponse)(nil),         // 29: server_grpc.DeleteMetaDataResponse
+	(*Folder)(nil),                         // 30: server_grpc.Folder
+	(*PostFolderRequest)(nil),              // 31: server_grpc.PostFolderRequest
+	(*PostFolderResponse)(nil),             // 32: server_grpc.PostFolderResponse
+	(*GetFoldersRequest)(nil),              // 33: server_grpc.GetFoldersRequest
+	(*GetFoldersResponse)(nil),             // 34: server_grpc.GetFoldersResponse
+	(*DeleteFolderRequest)(nil),            // 35: server_grpc.DeleteFolderRequest
+	(*DeleteFolderResponse)(nil),           // 36: server_grpc.DeleteFolderResponse
+	(*StartKeyRotationRequest)(nil),        // 37: server_grpc.StartKeyRotationRequest
+	(*StartKeyRotationResponse)(nil),       // 38: server_grpc.StartKeyRotationResponse
+	(*ItemKey)(nil),                        // 39: server_grpc.ItemKey
+	(*GetStaleKeysRequest)(nil),            // 40: server_grpc.GetStaleKeysRequest
+	(*GetStaleKeysResponse)(nil),           // 41: server_grpc.GetStaleKeysResponse
+	(*RewrapKeysRequest)(nil),              // 42: server_grpc.RewrapKeysRequest
+	(*RewrapKeysResponse)(nil),             // 43: server_grpc.RewrapKeysResponse
+	(*FinishKeyRotationRequest)(nil),       // 44: server_grpc.FinishKeyRotationRequest
+	(*FinishKeyRotationResponse)(nil),      // 45: server_grpc.FinishKeyRotationResponse
+	(*SharingKey)(nil),                     // 46: server_grpc.SharingKey
+	(*PostSharingKeyRequest)(nil),          // 47: server_grpc.PostSharingKeyRequest
+	(*PostSharingKeyResponse)(nil),         // 48: server_grpc.PostSharingKeyResponse
+	(*GetSharingKeyRequest)(nil),           // 49: server_grpc.GetSharingKeyRequest
+	(*GetSharingKeyResponse)(nil),          // 50: server_grpc.GetSharingKeyResponse
+	(*GetPublicKeyRequest)(nil),            // 51: server_grpc.GetPublicKeyRequest
+	(*GetPublicKeyResponse)(nil),           // 52: server_grpc.GetPublicKeyResponse
+	(*Share)(nil),                          // 53: server_grpc.Share
+	(*ShareItemRequest)(nil),               // 54: server_grpc.ShareItemRequest
+	(*ShareItemResponse)(nil),              // 55: server_grpc.ShareItemResponse
+	(*GetSharesRequest)(nil),               // 56: server_grpc.GetSharesRequest
+	(*GetSharesResponse)(nil),              // 57: server_grpc.GetSharesResponse
+	(*RevokeShareRequest)(nil),             // 58: server_grpc.RevokeShareRequest
+	(*RevokeShareResponse)(nil),            // 59: server_grpc.RevokeShareResponse
+	(*GetSharedWithMeRequest)(nil),         // 60: server_grpc.GetSharedWithMeRequest
+	(*GetSharedWithMeResponse)(nil),        // 61: server_grpc.GetSharedWithMeResponse
+	(*GetSharedItemRequest)(nil),           // 62: server_grpc.GetSharedItemRequest
+	(*GetSharedItemResponse)(nil),          // 63: server_grpc.GetSharedItemResponse
+	(*UpdateSharedItemRequest)(nil),        // 64: server_grpc.UpdateSharedItemRequest
+	(*UpdateSharedItemResponse)(nil),       // 65: server_grpc.UpdateSharedItemResponse
+	(*Organization)(nil),                   // 66: server_grpc.Organization
+	(*OrgMember)(nil),                      // 67: server_grpc.OrgMember
+	(*CollectionKey)(nil),                  // 68: server_grpc.CollectionKey
+	(*Collection)(nil),                     // 69: server_grpc.Collection
+	(*CollectionItem)(nil),                 // 70: server_grpc.CollectionItem
+	(*CreateOrgRequest)(nil),               // 71: server_grpc.CreateOrgRequest
+	(*CreateOrgResponse)(nil),              // 72: server_grpc.CreateOrgResponse
+	(*GetOrgsRequest)(nil),                 // 73: server_grpc.GetOrgsRequest
+	(*GetOrgsResponse)(nil),                // 74: server_grpc.GetOrgsResponse
+	(*DeleteOrgRequest)(nil),               // 75: server_grpc.DeleteOrgRequest
+	(*DeleteOrgResponse)(nil),              // 76: server_grpc.DeleteOrgResponse
+	(*GetOrgMembersRequest)(nil),           // 77: server_grpc.GetOrgMembersRequest
+	(*GetOrgMembersResponse)(nil),          // 78: server_grpc.GetOrgMembersResponse
+	(*InviteMemberRequest)(nil),            // 79: server_grpc.InviteMemberRequest
+	(*InviteMemberResponse)(nil),           // 80: server_grpc.InviteMemberResponse
+	(*AnswerInvitationRequest)(nil),        // 81: server_grpc.AnswerInvitationRequest
+	(*AnswerInvitationResponse)(nil),       // 82: server_grpc.AnswerInvitationResponse
+	(*RemoveMemberRequest)(nil),            // 83: server_grpc.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),           // 84: server_grpc.RemoveMemberResponse
+	(*ChangeRoleRequest)(nil),              // 85: server_grpc.ChangeRoleRequest
+	(*ChangeRoleResponse)(nil),             // 86: server_grpc.ChangeRoleResponse
+	(*CreateCollectionRequest)(nil),        // 87: server_grpc.CreateCollectionRequest
+	(*CreateCollectionResponse)(nil),       // 88: server_grpc.CreateCollectionResponse
+	(*GetCollectionsRequest)(nil),          // 89: server_grpc.GetCollectionsRequest
+	(*GetCollectionsResponse)(nil),         // 90: server_grpc.GetCollectionsResponse
+	(*DeleteCollectionRequest)(nil),        // 91: server_grpc.DeleteCollectionRequest
+	(*DeleteCollectionResponse)(nil),       // 92: server_grpc.DeleteCollectionResponse
+	(*GetCollectionItemsRequest)(nil),      // 93: server_grpc.GetCollectionItemsRequest
+	(*GetCollectionItemsResponse)(nil),     // 94: server_grpc.GetCollectionItemsResponse
+	(*GetCollectionItemRequest)(nil),       // 95: server_grpc.GetCollectionItemRequest
+	(*GetCollectionItemResponse)(nil),      // 96: server_grpc.GetCollectionItemResponse
+	(*PostCollectionItemRequest)(nil),      // 97: server_grpc.PostCollectionItemRequest
+	(*PostCollectionItemResponse)(nil),     // 98: server_grpc.PostCollectionItemResponse
+	(*DeleteCollectionItemRequest)(nil),    // 99: server_grpc.DeleteCollectionItemRequest
+	(*DeleteCollectionItemResponse)(nil),   // 100: server_grpc.DeleteCollectionItemResponse
+	(*RekeyCollectionRequest)(nil),         // 101: server_grpc.RekeyCollectionRequest
+	(*RekeyCollectionResponse)(nil),        // 102: server_grpc.RekeyCollectionResponse
+	(*EmergencyAccess)(nil),                // 103: server_grpc.EmergencyAccess
+	(*GrantEmergencyAccessRequest)(nil),    // 104: server_grpc.GrantEmergencyAccessRequest
+	(*GrantEmergencyAccessResponse)(nil),   // 105: server_grpc.GrantEmergencyAccessResponse
+	(*GetEmergencyAccessRequest)(nil),      // 106: server_grpc.GetEmergencyAccessRequest
+	(*GetEmergencyAccessResponse)(nil),     // 107: server_grpc.GetEmergencyAccessResponse
+	(*RevokeEmergencyAccessRequest)(nil),   // 108: server_grpc.RevokeEmergencyAccessRequest
+	(*RevokeEmergencyAccessResponse)(nil),  // 109: server_grpc.RevokeEmergencyAccessResponse
+	(*RequestEmergencyAccessRequest)(nil),  // 110: server_grpc.RequestEmergencyAccessRequest
+	(*RequestEmergencyAccessResponse)(nil), // 111: server_grpc.RequestEmergencyAccessResponse
+	(*AnswerEmergencyAccessRequest)(nil),   // 112: server_grpc.AnswerEmergencyAccessRequest
+	(*AnswerEmergencyAccessResponse)(nil),  // 113: server_grpc.AnswerEmergencyAccessResponse
+	(*EmergencyKey)(nil),                   // 114: server_grpc.EmergencyKey
+	(*UpdateEmergencyKeysRequest)(nil),     // 115: server_grpc.UpdateEmergencyKeysRequest
+	(*UpdateEmergencyKeysResponse)(nil),    // 116: server_grpc.UpdateEmergencyKeysResponse
+	(*GetEmergencyVaultRequest)(nil),       // 117: server_grpc.GetEmergencyVaultRequest
+	(*GetEmergencyVaultResponse)(nil),      // 118: server_grpc.GetEmergencyVaultResponse
+	(*GetEmergencyItemsRequest)(nil),       // 119: server_grpc.GetEmergencyItemsRequest
+	(*GetEmergencyItemsResponse)(nil),      // 120: server_grpc.GetEmergencyItemsResponse
+	(*GetEmergencyItemRequest)(nil),        // 121: server_grpc.GetEmergencyItemRequest
+	(*GetEmergencyItemResponse)(nil),       // 122: server_grpc.GetEmergencyItemResponse
+	(*Device)(nil),                         // 123: server_grpc.Device
+	(*EnrollDeviceRequest)(nil),            // 124: server_grpc.EnrollDeviceRequest
+	(*EnrollDeviceResponse)(nil),           // 125: server_grpc.EnrollDeviceResponse
+	(*GetDevicesRequest)(nil),              // 126: server_grpc.GetDevicesRequest
+	(*GetDevicesResponse)(nil),             // 127: server_grpc.GetDevicesResponse
+	(*RevokeDeviceRequest)(nil),            // 128: server_grpc.RevokeDeviceRequest
+	(*RevokeDeviceResponse)(nil),           // 129: server_grpc.RevokeDeviceResponse
+	(*APIToken)(nil),                       // 130: server_grpc.APIToken
+	(*CreateAPITokenRequest)(nil),          // 131: server_grpc.CreateAPITokenRequest
+	(*CreateAPITokenResponse)(nil),         // 132: server_grpc.CreateAPITokenResponse
+	(*GetAPITokensRequest)(nil),            // 133: server_grpc.GetAPITokensRequest
+	(*GetAPITokensResponse)(nil),           // 134: server_grpc.GetAPITokensResponse
+	(*RevokeAPITokenRequest)(nil),          // 135: server_grpc.RevokeAPITokenRequest
+	(*RevokeAPITokenResponse)(nil),         // 136: server_grpc.RevokeAPITokenResponse
+	(*AuditEvent)(nil),                     // 137: server_grpc.AuditEvent
+	(*ListAuditEventsRequest)(nil),         // 138: server_grpc.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),        // 139: server_grpc.ListAuditEventsResponse
 }
 var file_internal_proto_handlers_proto_depIdxs = []int32{
-	6,  // 0: server_grpc.PostItemDataRequest.meta_data:type_name -> server_grpc.MetaData
-	6,  // 1: server_grpc.GetMetaDataResponse.items:type_name -> server_grpc.MetaData
-	0,  // 2: server_grpc.UserHandlers.PostUserData:input_type -> server_grpc.PostUserDataRequest
-	2,  // 3: server_grpc.ItemDataHandlers.PostItemData:input_type -> server_grpc.PostItemDataRequest
-	4,  // 4: server_grpc.ItemDataHandlers.GetItemData:input_type -> server_grpc.GetItemDataRequest
-	7,  // 5: server_grpc.MetaDataHandlers.GetMetaData:input_type -> server_grpc.GetMetaDataRequest
-	9,  // 6: server_grpc.MetaDataHandlers.DeleteMetaData:input_type -> server_grpc.DeleteMetaDataRequest
-	1,  // 7: server_grpc.UserHandlers.PostUserData:output_type -> server_grpc.PostUserDataResponse
-	3,  // 8: server_grpc.ItemDataHandlers.PostItemData:output_type -> server_grpc.PostItemDataResponse
-	5,  // 9: server_grpc.ItemDataHandlers.GetItemData:output_type -> server_grpc.GetItemDataResponse
-	8,  // 10: server_grpc.MetaDataHandlers.GetMetaData:output_type -> server_grpc.GetMetaDataResponse
-	10, // 11: server_grpc.MetaDataHandlers.DeleteMetaData:output_type -> server_grpc.DeleteMetaDataResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	2,   // 0: server_grpc.PostUserDataRequest.srp_record:type_name -> server_grpc.SrpRecord
+	8,   // 1: server_grpc.PostUserDataResponse.vault_key:type_name -> server_grpc.VaultKey
+	8,   // 2: server_grpc.PostUserDataResponse.pending_vault_key:type_name -> server_grpc.VaultKey
+	2,   // 3: server_grpc.RegisterUserRequest.srp_record:type_name -> server_grpc.SrpRecord
+	2,   // 4: server_grpc.StartLoginResponse.srp_record:type_name -> server_grpc.SrpRecord
+	8,   // 5: server_grpc.PostVaultKeyRequest.vault_key:type_name -> server_grpc.VaultKey
+	8,   // 6: server_grpc.ChangePasswordRequest.vault_keys:type_name -> server_grpc.VaultKey
+	2,   // 7: server_grpc.ChangePasswordRequest.srp_record:type_name -> server_grpc.SrpRecord
+	8,   // 8: server_grpc.PostRecoveryKitRequest.vault_keys:type_name -> server_grpc.VaultKey
+	8,   // 9: server_grpc.GetRecoveryKitResponse.vault_keys:type_name -> server_grpc.VaultKey
+	8,   // 10: server_grpc.RecoverAccountRequest.vault_keys:type_name -> server_grpc.VaultKey
+	2,   // 11: server_grpc.RecoverAccountRequest.srp_record:type_name -> server_grpc.SrpRecord
+	25,  // 12: server_grpc.PostItemDataRequest.meta_data:type_name -> server_grpc.MetaData
+	25,  // 13: server_grpc.GetMetaDataResponse.items:type_name -> server_grpc.MetaData
+	30,  // 14: server_grpc.PostFolderRequest.folder:type_name -> server_grpc.Folder
+	30,  // 15: server_grpc.PostFolderResponse.folder:type_name -> server_grpc.Folder
+	30,  // 16: server_grpc.GetFoldersResponse.folders:type_name -> server_grpc.Folder
+	8,   // 17: server_grpc.StartKeyRotationRequest.vault_key:type_name -> server_grpc.VaultKey
+	39,  // 18: server_grpc.GetStaleKeysResponse.items:type_name -> server_grpc.ItemKey
+	25,  // 19: server_grpc.GetStaleKeysResponse.metas:type_name -> server_grpc.MetaData
+	39,  // 20: server_grpc.RewrapKeysRequest.items:type_name -> server_grpc.ItemKey
+	25,  // 21: server_grpc.RewrapKeysRequest.metas:type_name -> server_grpc.MetaData
+	46,  // 22: server_grpc.PostSharingKeyRequest.sharing_key:type_name -> server_grpc.SharingKey
+	46,  // 23: server_grpc.GetSharingKeyResponse.sharing_key:type_name -> server_grpc.SharingKey
+	53,  // 24: server_grpc.ShareItemResponse.share:type_name -> server_grpc.Share
+	53,  // 25: server_grpc.GetSharesResponse.shares:type_name -> server_grpc.Share
+	53,  // 26: server_grpc.GetSharedWithMeResponse.shares:type_name -> server_grpc.Share
+	53,  // 27: server_grpc.GetSharedItemResponse.share:type_name -> server_grpc.Share
+	66,  // 28: server_grpc.CreateOrgResponse.organization:type_name -> server_grpc.Organization
+	66,  // 29: server_grpc.GetOrgsResponse.organizations:type_name -> server_grpc.Organization
+	67,  // 30: server_grpc.GetOrgMembersResponse.members:type_name -> server_grpc.OrgMember
+	68,  // 31: server_grpc.InviteMemberRequest.keys:type_name -> server_grpc.CollectionKey
+	67,  // 32: server_grpc.InviteMemberResponse.member:type_name -> server_grpc.OrgMember
+	68,  // 33: server_grpc.CreateCollectionRequest.keys:type_name -> server_grpc.CollectionKey
+	69,  // 34: server_grpc.CreateCollectionResponse.collection:type_name -> server_grpc.Collection
+	69,  // 35: server_grpc.GetCollectionsResponse.collections:type_name -> server_grpc.Collection
+	70,  // 36: server_grpc.GetCollectionItemsResponse.items:type_name -> server_grpc.CollectionItem
+	70,  // 37: server_grpc.GetCollectionItemResponse.item:type_name -> server_grpc.CollectionItem
+	70,  // 38: server_grpc.PostCollectionItemRequest.item:type_name -> server_grpc.CollectionItem
+	68,  // 39: server_grpc.RekeyCollectionRequest.keys:type_name -> server_grpc.CollectionKey
+	70,  // 40: server_grpc.RekeyCollectionRequest.items:type_name -> server_grpc.CollectionItem
+	103, // 41: server_grpc.GrantEmergencyAccessResponse.access:type_name -> server_grpc.EmergencyAccess
+	103, // 42: server_grpc.GetEmergencyAccessResponse.granted:type_name -> server_grpc.EmergencyAccess
+	103, // 43: server_grpc.GetEmergencyAccessResponse.trusted_by:type_name -> server_grpc.EmergencyAccess
+	103, // 44: server_grpc.RequestEmergencyAccessResponse.access:type_name -> server_grpc.EmergencyAccess
+	114, // 45: server_grpc.UpdateEmergencyKeysRequest.keys:type_name -> server_grpc.EmergencyKey
+	25,  // 46: server_grpc.GetEmergencyItemsResponse.items:type_name -> server_grpc.MetaData
+	123, // 47: server_grpc.EnrollDeviceResponse.device:type_name -> server_grpc.Device
+	123, // 48: server_grpc.GetDevicesResponse.devices:type_name -> server_grpc.Device
+	130, // 49: server_grpc.CreateAPITokenResponse.token:type_name -> server_grpc.APIToken
+	130, // 50: server_grpc.GetAPITokensResponse.tokens:type_name -> server_grpc.APIToken
+	137, // 51: server_grpc.ListAuditEventsResponse.events:type_name -> server_grpc.AuditEvent
+	0,   // 52: server_grpc.UserHandlers.PostUserData:input_type -> server_grpc.PostUserDataRequest
+	3,   // 53: server_grpc.UserHandlers.RegisterUser:input_type -> server_grpc.RegisterUserRequest
+	5,   // 54: server_grpc.UserHandlers.StartLogin:input_type -> server_grpc.StartLoginRequest
+	7,   // 55: server_grpc.UserHandlers.FinishLogin:input_type -> server_grpc.FinishLoginRequest
+	9,   // 56: server_grpc.UserHandlers.PostVaultKey:input_type -> server_grpc.PostVaultKeyRequest
+	11,  // 57: server_grpc.UserHandlers.ChangePassword:input_type -> server_grpc.ChangePasswordRequest
+	13,  // 58: server_grpc.UserHandlers.PostRecoveryKit:input_type -> server_grpc.PostRecoveryKitRequest
+	15,  // 59: server_grpc.UserHandlers.GetRecoveryKit:input_type -> server_grpc.GetRecoveryKitRequest
+	17,  // 60: server_grpc.UserHandlers.RecoverAccount:input_type -> server_grpc.RecoverAccountRequest
+	19,  // 61: server_grpc.ItemDataHandlers.PostItemData:input_type -> server_grpc.PostItemDataRequest
+	21,  // 62: server_grpc.ItemDataHandlers.GetItemData:input_type -> server_grpc.GetItemDataRequest
+	23,  // 63: server_grpc.ItemDataHandlers.GetUsage:input_type -> server_grpc.GetUsageRequest
+	26,  // 64: server_grpc.MetaDataHandlers.GetMetaData:input_type -> server_grpc.GetMetaDataRequest
+	28,  // 65: server_grpc.MetaDataHandlers.DeleteMetaData:input_type -> server_grpc.DeleteMetaDataRequest
+	31,  // 66: server_grpc.FolderHandlers.PostFolder:input_type -> server_grpc.PostFolderRequest
+	33,  // 67: server_grpc.FolderHandlers.GetFolders:input_type -> server_grpc.GetFoldersRequest
+	35,  // 68: server_grpc.FolderHandlers.DeleteFolder:input_type -> server_grpc.DeleteFolderRequest
+	37,  // 69: server_grpc.VaultHandlers.StartKeyRotation:input_type -> server_grpc.StartKeyRotationRequest
+	40,  // 70: server_grpc.VaultHandlers.GetStaleKeys:input_type -> server_grpc.GetStaleKeysRequest
+	42,  // 71: server_grpc.VaultHandlers.RewrapKeys:input_type -> server_grpc.RewrapKeysRequest
+	44,  // 72: server_grpc.VaultHandlers.FinishKeyRotation:input_type -> server_grpc.FinishKeyRotationRequest
+	47,  // 73: server_grpc.SharingHandlers.PostSharingKey:input_type -> server_grpc.PostSharingKeyRequest
+	49,  // 74: server_grpc.SharingHandlers.GetSharingKey:input_type -> server_grpc.GetSharingKeyRequest
+	51,  // 75: server_grpc.SharingHandlers.GetPublicKey:input_type -> server_grpc.GetPublicKeyRequest
+	54,  // 76: server_grpc.SharingHandlers.ShareItem:input_type -> server_grpc.ShareItemRequest
+	56,  // 77: server_grpc.SharingHandlers.GetShares:input_type -> server_grpc.GetSharesRequest
+	58,  // 78: server_grpc.SharingHandlers.RevokeShare:input_type -> server_grpc.RevokeShareRequest
+	60,  // 79: server_grpc.SharingHandlers.GetSharedWithMe:input_type -> server_grpc.GetSharedWithMeRequest
+	62,  // 80: server_grpc.SharingHandlers.GetSharedItem:input_type -> server_grpc.GetSharedItemRequest
+	64,  // 81: server_grpc.SharingHandlers.UpdateSharedItem:input_type -> server_grpc.UpdateSharedItemRequest
+	71,  // 82: server_grpc.OrgHandlers.CreateOrg:input_type -> server_grpc.CreateOrgRequest
+	73,  // 83: server_grpc.OrgHandlers.GetOrgs:input_type -> server_grpc.GetOrgsRequest
+	75,  // 84: server_grpc.OrgHandlers.DeleteOrg:input_type -> server_grpc.DeleteOrgRequest
+	77,  // 85: server_grpc.OrgHandlers.GetOrgMembers:input_type -> server_grpc.GetOrgMembersRequest
+	79,  // 86: server_grpc.OrgHandlers.InviteMember:input_type -> server_grpc.InviteMemberRequest
+	81,  // 87: server_grpc.OrgHandlers.AnswerInvitation:input_type -> server_grpc.AnswerInvitationRequest
+	83,  // 88: server_grpc.OrgHandlers.RemoveMember:input_type -> server_grpc.RemoveMemberRequest
+	85,  // 89: server_grpc.OrgHandlers.ChangeRole:input_type -> server_grpc.ChangeRoleRequest
+	87,  // 90: server_grpc.OrgHandlers.CreateCollection:input_type -> server_grpc.CreateCollectionRequest
+	89,  // 91: server_grpc.OrgHandlers.GetCollections:input_type -> server_grpc.GetCollectionsRequest
+	91,  // 92: server_grpc.OrgHandlers.DeleteCollection:input_type -> server_grpc.DeleteCollectionRequest
+	93,  // 93: server_grpc.OrgHandlers.GetCollectionItems:input_type -> server_grpc.GetCollectionItemsRequest
+	95,  // 94: server_grpc.OrgHandlers.GetCollectionItem:input_type -> server_grpc.GetCollectionItemRequest
+	97,  // 95: server_grpc.OrgHandlers.PostCollectionItem:input_type -> server_grpc.PostCollectionItemRequest
+	99,  // 96: server_grpc.OrgHandlers.DeleteCollectionItem:input_type -> server_grpc.DeleteCollectionItemRequest
+	101, // 97: server_grpc.OrgHandlers.RekeyCollection:input_type -> server_grpc.RekeyCollectionRequest
+	104, // 98: server_grpc.EmergencyHandlers.GrantEmergencyAccess:input_type -> server_grpc.GrantEmergencyAccessRequest
+	106, // 99: server_grpc.EmergencyHandlers.GetEmergencyAccess:input_type -> server_grpc.GetEmergencyAccessRequest
+	108, // 100: server_grpc.EmergencyHandlers.RevokeEmergencyAccess:input_type -> server_grpc.RevokeEmergencyAccessRequest
+	110, // 101: server_grpc.EmergencyHandlers.RequestEmergencyAccess:input_type -> server_grpc.RequestEmergencyAccessRequest
+	112, // 102: server_grpc.EmergencyHandlers.AnswerEmergencyAccess:input_type -> server_grpc.AnswerEmergencyAccessRequest
+	115, // 103: server_grpc.EmergencyHandlers.UpdateEmergencyKeys:input_type -> server_grpc.UpdateEmergencyKeysRequest
+	117, // 104: server_grpc.EmergencyHandlers.GetEmergencyVault:input_type -> server_grpc.GetEmergencyVaultRequest
+	119, // 105: server_grpc.EmergencyHandlers.GetEmergencyItems:input_type -> server_grpc.GetEmergencyItemsRequest
+	121, // 106: server_grpc.EmergencyHandlers.GetEmergencyItem:input_type -> server_grpc.GetEmergencyItemRequest
+	124, // 107: server_grpc.DeviceHandlers.EnrollDevice:input_type -> server_grpc.EnrollDeviceRequest
+	126, // 108: server_grpc.DeviceHandlers.GetDevices:input_type -> server_grpc.GetDevicesRequest
+	128, // 109: server_grpc.DeviceHandlers.RevokeDevice:input_type -> server_grpc.RevokeDeviceRequest
+	131, // 110: server_grpc.APITokenHandlers.CreateAPIToken:input_type -> server_grpc.CreateAPITokenRequest
+	133, // 111: server_grpc.APITokenHandlers.GetAPITokens:input_type -> server_grpc.GetAPITokensRequest
+	135, // 112: server_grpc.APITokenHandlers.RevokeAPIToken:input_type -> server_grpc.RevokeAPITokenRequest
+	138, // 113: server_grpc.AuditHandlers.ListAuditEvents:input_type -> server_grpc.ListAuditEventsRequest
+	1,   // 114: server_grpc.UserHandlers.PostUserData:output_type -> server_grpc.PostUserDataResponse
+	4,   // 115: server_grpc.UserHandlers.RegisterUser:output_type -> server_grpc.RegisterUserResponse
+	6,   // 116: server_grpc.UserHandlers.StartLogin:output_type -> server_grpc.StartLoginResponse
+	1,   // 117: server_grpc.UserHandlers.FinishLogin:output_type -> server_grpc.PostUserDataResponse
+	10,  // 118: server_grpc.UserHandlers.PostVaultKey:output_type -> server_grpc.PostVaultKeyResponse
+	12,  // 119: server_grpc.UserHandlers.ChangePassword:output_type -> server_grpc.ChangePasswordResponse
+	14,  // 120: server_grpc.UserHandlers.PostRecoveryKit:output_type -> server_grpc.PostRecoveryKitResponse
+	16,  // 121: server_grpc.UserHandlers.GetRecoveryKit:output_type -> server_grpc.GetRecoveryKitResponse
+	18,  // 122: server_grpc.UserHandlers.RecoverAccount:output_type -> server_grpc.RecoverAccountResponse
+	20,  // 123: server_grpc.ItemDataHandlers.PostItemData:output_type -> server_grpc.PostItemDataResponse
+	22,  // 124: server_grpc.ItemDataHandlers.GetItemData:output_type -> server_grpc.GetItemDataResponse
+	24,  // 125: server_grpc.ItemDataHandlers.GetUsage:output_type -> server_grpc.GetUsageResponse
+	27,  // 126: server_grpc.MetaDataHandlers.GetMetaData:output_type -> server_grpc.GetMetaDataResponse
+	29,  // 127: server_grpc.MetaDataHandlers.DeleteMetaData:output_type -> server_grpc.DeleteMetaDataResponse
+	32,  // 128: server_grpc.FolderHandlers.PostFolder:output_type -> server_grpc.PostFolderResponse
+	34,  // 129: server_grpc.FolderHandlers.GetFolders:output_type -> server_grpc.GetFoldersResponse
+	36,  // 130: server_grpc.FolderHandlers.DeleteFolder:output_type -> server_grpc.DeleteFolderResponse
+	38,  // 131: server_grpc.VaultHandlers.StartKeyRotation:output_type -> server_grpc.StartKeyRotationResponse
+	41,  // 132: server_grpc.VaultHandlers.GetStaleKeys:output_type -> server_grpc.GetStaleKeysResponse
+	43,  // 133: server_grpc.VaultHandlers.RewrapKeys:output_type -> server_grpc.RewrapKeysResponse
+	45,  // 134: server_grpc.VaultHandlers.FinishKeyRotation:output_type -> server_grpc.FinishKeyRotationResponse
+	48,  // 135: server_grpc.SharingHandlers.PostSharingKey:output_type -> server_grpc.PostSharingKeyResponse
+	50,  // 136: server_grpc.SharingHandlers.GetSharingKey:output_type -> server_grpc.GetSharingKeyResponse
+	52,  // 137: server_grpc.SharingHandlers.GetPublicKey:output_type -> server_grpc.GetPublicKeyResponse
+	55,  // 138: server_grpc.SharingHandlers.ShareItem:output_type -> server_grpc.ShareItemResponse
+	57,  // 139: server_grpc.SharingHandlers.GetShares:output_type -> server_grpc.GetSharesResponse
+	59,  // 140: server_grpc.SharingHandlers.RevokeShare:output_type -> server_grpc.RevokeShareResponse
+	61,  // 141: server_grpc.SharingHandlers.GetSharedWithMe:output_type -> server_grpc.GetSharedWithMeResponse
+	63,  // 142: server_grpc.SharingHandlers.GetSharedItem:output_type -> server_grpc.GetSharedItemResponse
+	65,  // 143: server_grpc.SharingHandlers.UpdateSharedItem:output_type -> server_grpc.UpdateSharedItemResponse
+	72,  // 144: server_grpc.OrgHandlers.CreateOrg:output_type -> server_grpc.CreateOrgResponse
+	74,  // 145: server_grpc.OrgHandlers.GetOrgs:output_type -> server_grpc.GetOrgsResponse
+	76,  // 146: server_grpc.OrgHandlers.DeleteOrg:output_type -> server_grpc.DeleteOrgResponse
+	78,  // 147: server_grpc.OrgHandlers.GetOrgMembers:output_type -> server_grpc.GetOrgMembersResponse
+	80,  // 148: server_grpc.OrgHandlers.InviteMember:output_type -> server_grpc.InviteMemberResponse
+	82,  // 149: server_grpc.OrgHandlers.AnswerInvitation:output_type -> server_grpc.AnswerInvitationResponse
+	84,  // 150: server_grpc.OrgHandlers.RemoveMember:output_type -> server_grpc.RemoveMemberResponse
+	86,  // 151: server_grpc.OrgHandlers.ChangeRole:output_type -> server_grpc.ChangeRoleResponse
+	88,  // 152: server_grpc.OrgHandlers.CreateCollection:output_type -> server_grpc.CreateCollectionResponse
+	90,  // 153: server_grpc.OrgHandlers.GetCollections:output_type -> server_grpc.GetCollectionsResponse
+	92,  // 154: server_grpc.OrgHandlers.DeleteCollection:output_type -> server_grpc.DeleteCollectionResponse
+	94,  // 155: server_grpc.OrgHandlers.GetCollectionItems:output_type -> server_grpc.GetCollectionItemsResponse
+	96,  // 156: server_grpc.OrgHandlers.GetCollectionItem:output_type -> server_grpc.GetCollectionItemResponse
+	98,  // 157: server_grpc.OrgHandlers.PostCollectionItem:output_type -> server_grpc.PostCollectionItemResponse
+	100, // 158: server_grpc.OrgHandlers.DeleteCollectionItem:output_type -> server_grpc.DeleteCollectionItemResponse
+	102, // 159: server_grpc.OrgHandlers.RekeyCollection:output_type -> server_grpc.RekeyCollectionResponse
+	105, // 160: server_grpc.EmergencyHandlers.GrantEmergencyAccess:output_type -> server_grpc.GrantEmergencyAccessResponse
+	107, // 161: server_grpc.EmergencyHandlers.GetEmergencyAccess:output_type -> server_grpc.GetEmergencyAccessResponse
+	109, // 162: server_grpc.EmergencyHandlers.RevokeEmergencyAccess:output_type -> server_grpc.RevokeEmergencyAccessResponse
+	111, // 163: server_grpc.EmergencyHandlers.RequestEmergencyAccess:output_type -> server_grpc.RequestEmergencyAccessResponse
+	113, // 164: server_grpc.EmergencyHandlers.AnswerEmergencyAccess:output_type -> server_grpc.AnswerEmergencyAccessResponse
+	116, // 165: server_grpc.EmergencyHandlers.UpdateEmergencyKeys:output_type -> server_grpc.UpdateEmergencyKeysResponse
+	118, // 166: server_grpc.EmergencyHandlers.GetEmergencyVault:output_type -> server_grpc.GetEmergencyVaultResponse
+	120, // 167: server_grpc.EmergencyHandlers.GetEmergencyItems:output_type -> server_grpc.GetEmergencyItemsResponse
+	122, // 168: server_grpc.EmergencyHandlers.GetEmergencyItem:output_type -> server_grpc.GetEmergencyItemResponse
+	125, // 169: server_grpc.DeviceHandlers.EnrollDevice:output_type -> server_grpc.EnrollDeviceResponse
+	127, // 170: server_grpc.DeviceHandlers.GetDevices:output_type -> server_grpc.GetDevicesResponse
+	129, // 171: server_grpc.DeviceHandlers.RevokeDevice:output_type -> server_grpc.RevokeDeviceResponse
+	132, // 172: server_grpc.APITokenHandlers.CreateAPIToken:output_type -> server_grpc.CreateAPITokenResponse
+	134, // 173: server_grpc.APITokenHandlers.GetAPITokens:output_type -> server_grpc.GetAPITokensResponse
+	136, // 174: server_grpc.APITokenHandlers.RevokeAPIToken:output_type -> server_grpc.RevokeAPITokenResponse
+	139, // 175: server_grpc.AuditHandlers.ListAuditEvents:output_type -> server_grpc.ListAuditEventsResponse
+	114, // [114:176] is the sub-list for method output_type
+	52,  // [52:114] is the sub-list for method input_type
+	52,  // [52:52] is the sub-list for extension type_name
+	52,  // [52:52] is the sub-list for extension extendee
+	0,   // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_handlers_proto_init() }
@@ -744,9 +9121,9 @@ func file_internal_proto_handlers_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_proto_handlers_proto_rawDesc), len(file_internal_proto_handlers_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   140,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   11,
 		},
 		GoTypes:           file_internal_proto_handlers_proto_goTypes,
 		DependencyIndexes: file_internal_proto_handlers_proto_depIdxs,
@@ -20,7 +20,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserHandlers_PostUserData_FullMethodName = "/server_grpc.UserHandlers/PostUserData"
+	UserHandlers_PostUserData_FullMethodName    = "/server_grpc.UserHandlers/PostUserData"
+	UserHandlers_RegisterUser_FullMethodName    = "/server_grpc.UserHandlers/RegisterUser"
+	UserHandlers_StartLogin_FullMethodName      = "/server_grpc.UserHandlers/StartLogin"
+	UserHandlers_FinishLogin_FullMethodName     = "/server_grpc.UserHandlers/FinishLogin"
+	UserHandlers_PostVaultKey_FullMethodName    = "/server_grpc.UserHandlers/PostVaultKey"
+	UserHandlers_ChangePassword_FullMethodName  = "/server_grpc.UserHandlers/ChangePassword"
+	UserHandlers_PostRecoveryKit_FullMethodName = "/server_grpc.UserHandlers/PostRecoveryKit"
+	UserHandlers_GetRecoveryKit_FullMethodName  = "/server_grpc.UserHandlers/GetRecoveryKit"
+	UserHandlers_RecoverAccount_FullMethodName  = "/server_grpc.UserHandlers/RecoverAccount"
 )
 
 // UserHandlersClient is the client API for UserHandlers service.
@@ -28,6 +36,14 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UserHandlersClient interface {
 	PostUserData(ctx context.Context, in *PostUserDataRequest, opts ...grpc.CallOption) (*PostUserDataResponse, error)
+	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error)
+	StartLogin(ctx context.Context, in *StartLoginRequest, opts ...grpc.CallOption) (*StartLoginResponse, error)
+	FinishLogin(ctx context.Context, in *FinishLoginRequest, opts ...grpc.CallOption) (*PostUserDataResponse, error)
+	PostVaultKey(ctx context.Context, in *PostVaultKeyRequest, opts ...grpc.CallOption) (*PostVaultKeyResponse, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	PostRecoveryKit(ctx context.Context, in *PostRecoveryKitRequest, opts ...grpc.CallOption) (*PostRecoveryKitResponse, error)
+	GetRecoveryKit(ctx context.Context, in *GetRecoveryKitRequest, opts ...grpc.CallOption) (*GetRecoveryKitResponse, error)
+	RecoverAccount(ctx context.Context, in *RecoverAccountRequest, opts ...grpc.CallOption) (*RecoverAccountResponse, error)
 }
 
 type userHandlersClient struct {
@@ -48,11 +64,99 @@ func (c *userHandlersClient) PostUserData(ctx context.Context, in *PostUserDataR
 	return out, nil
 }
 
+func (c *userHandlersClient) RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*RegisterUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterUserResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_RegisterUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) StartLogin(ctx context.Context, in *StartLoginRequest, opts ...grpc.CallOption) (*StartLoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartLoginResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_StartLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) FinishLogin(ctx context.Context, in *FinishLoginRequest, opts ...grpc.CallOption) (*PostUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostUserDataResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_FinishLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) PostVaultKey(ctx context.Context, in *PostVaultKeyRequest, opts ...grpc.CallOption) (*PostVaultKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostVaultKeyResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_PostVaultKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_ChangePassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) PostRecoveryKit(ctx context.Context, in *PostRecoveryKitRequest, opts ...grpc.CallOption) (*PostRecoveryKitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostRecoveryKitResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_PostRecoveryKit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) GetRecoveryKit(ctx context.Context, in *GetRecoveryKitRequest, opts ...grpc.CallOption) (*GetRecoveryKitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecoveryKitResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_GetRecoveryKit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userHandlersClient) RecoverAccount(ctx context.Context, in *RecoverAccountRequest, opts ...grpc.CallOption) (*RecoverAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoverAccountResponse)
+	err := c.cc.Invoke(ctx, UserHandlers_RecoverAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserHandlersServer is the server API for UserHandlers service.
 // All implementations must embed UnimplementedUserHandlersServer
 // for forward compatibility.
 type UserHandlersServer interface {
 	PostUserData(context.Context, *PostUserDataRequest) (*PostUserDataResponse, error)
+	RegisterUser(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error)
+	StartLogin(context.Context, *StartLoginRequest) (*StartLoginResponse, error)
+	FinishLogin(context.Context, *FinishLoginRequest) (*PostUserDataResponse, error)
+	PostVaultKey(context.Context, *PostVaultKeyRequest) (*PostVaultKeyResponse, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	PostRecoveryKit(context.Context, *PostRecoveryKitRequest) (*PostRecoveryKitResponse, error)
+	GetRecoveryKit(context.Context, *GetRecoveryKitRequest) (*GetRecoveryKitResponse, error)
+	RecoverAccount(context.Context, *RecoverAccountRequest) (*RecoverAccountResponse, error)
 	mustEmbedUnimplementedUserHandlersServer()
 }
 
@@ -66,6 +170,30 @@ type UnimplementedUserHandlersServer struct{}
 func (UnimplementedUserHandlersServer) PostUserData(context.Context, *PostUserDataRequest) (*PostUserDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PostUserData not implemented")
 }
+func (UnimplementedUserHandlersServer) RegisterUser(context.Context, *RegisterUserRequest) (*RegisterUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedUserHandlersServer) StartLogin(context.Context, *StartLoginRequest) (*StartLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartLogin not implemented")
+}
+func (UnimplementedUserHandlersServer) FinishLogin(context.Context, *FinishLoginRequest) (*PostUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishLogin not implemented")
+}
+func (UnimplementedUserHandlersServer) PostVaultKey(context.Context, *PostVaultKeyRequest) (*PostVaultKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostVaultKey not implemented")
+}
+func (UnimplementedUserHandlersServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedUserHandlersServer) PostRecoveryKit(context.Context, *PostRecoveryKitRequest) (*PostRecoveryKitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostRecoveryKit not implemented")
+}
+func (UnimplementedUserHandlersServer) GetRecoveryKit(context.Context, *GetRecoveryKitRequest) (*GetRecoveryKitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecoveryKit not implemented")
+}
+func (UnimplementedUserHandlersServer) RecoverAccount(context.Context, *RecoverAccountRequest) (*RecoverAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverAccount not implemented")
+}
 func (UnimplementedUserHandlersServer) mustEmbedUnimplementedUserHandlersServer() {}
 func (UnimplementedUserHandlersServer) testEmbeddedByValue()                      {}
 
@@ -105,6 +233,150 @@ func _UserHandlers_PostUserData_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserHandlers_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).RegisterUser(ctx, req.(*RegisterUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_StartLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).StartLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_StartLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).StartLogin(ctx, req.(*StartLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_FinishLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).FinishLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_FinishLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).FinishLogin(ctx, req.(*FinishLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_PostVaultKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostVaultKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).PostVaultKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_PostVaultKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).PostVaultKey(ctx, req.(*PostVaultKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_PostRecoveryKit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostRecoveryKitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).PostRecoveryKit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_PostRecoveryKit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).PostRecoveryKit(ctx, req.(*PostRecoveryKitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_GetRecoveryKit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecoveryKitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).GetRecoveryKit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_GetRecoveryKit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).GetRecoveryKit(ctx, req.(*GetRecoveryKitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserHandlers_RecoverAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserHandlersServer).RecoverAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserHandlers_RecoverAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserHandlersServer).RecoverAccount(ctx, req.(*RecoverAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserHandlers_ServiceDesc is the grpc.ServiceDesc for UserHandlers service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -116,6 +388,38 @@ var UserHandlers_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PostUserData",
 			Handler:    _UserHandlers_PostUserData_Handler,
 		},
+		{
+			MethodName: "RegisterUser",
+			Handler:    _UserHandlers_RegisterUser_Handler,
+		},
+		{
+			MethodName: "StartLogin",
+			Handler:    _UserHandlers_StartLogin_Handler,
+		},
+		{
+			MethodName: "FinishLogin",
+			Handler:    _UserHandlers_FinishLogin_Handler,
+		},
+		{
+			MethodName: "PostVaultKey",
+			Handler:    _UserHandlers_PostVaultKey_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _UserHandlers_ChangePassword_Handler,
+		},
+		{
+			MethodName: "PostRecoveryKit",
+			Handler:    _UserHandlers_PostRecoveryKit_Handler,
+		},
+		{
+			MethodName: "GetRecoveryKit",
+			Handler:    _UserHandlers_GetRecoveryKit_Handler,
+		},
+		{
+			MethodName: "RecoverAccount",
+			Handler:    _UserHandlers_RecoverAccount_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/handlers.proto",
@@ -124,6 +428,7 @@ var UserHandlers_ServiceDesc = grpc.ServiceDesc{
 const (
 	ItemDataHandlers_PostItemData_FullMethodName = "/server_grpc.ItemDataHandlers/PostItemData"
 	ItemDataHandlers_GetItemData_FullMethodName  = "/server_grpc.ItemDataHandlers/GetItemData"
+	ItemDataHandlers_GetUsage_FullMethodName     = "/server_grpc.ItemDataHandlers/GetUsage"
 )
 
 // ItemDataHandlersClient is the client API for ItemDataHandlers service.
@@ -132,6 +437,7 @@ const (
 type ItemDataHandlersClient interface {
 	PostItemData(ctx context.Context, in *PostItemDataRequest, opts ...grpc.CallOption) (*PostItemDataResponse, error)
 	GetItemData(ctx context.Context, in *GetItemDataRequest, opts ...grpc.CallOption) (*GetItemDataResponse, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
 }
 
 type itemDataHandlersClient struct {
@@ -162,12 +468,23 @@ func (c *itemDataHandlersClient) GetItemData(ctx context.Context, in *GetItemDat
 	return out, nil
 }
 
+func (c *itemDataHandlersClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, ItemDataHandlers_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ItemDataHandlersServer is the server API for ItemDataHandlers service.
 // All implementations must embed UnimplementedItemDataHandlersServer
 // for forward compatibility.
 type ItemDataHandlersServer interface {
 	PostItemData(context.Context, *PostItemDataRequest) (*PostItemDataResponse, error)
 	GetItemData(context.Context, *GetItemDataRequest) (*GetItemDataResponse, error)
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
 	mustEmbedUnimplementedItemDataHandlersServer()
 }
 
@@ -184,6 +501,9 @@ func (UnimplementedItemDataHandlersServer) PostItemData(context.Context, *PostIt
 func (UnimplementedItemDataHandlersServer) GetItemData(context.Context, *GetItemDataRequest) (*GetItemDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetItemData not implemented")
 }
+func (UnimplementedItemDataHandlersServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
 func (UnimplementedItemDataHandlersServer) mustEmbedUnimplementedItemDataHandlersServer() {}
 func (UnimplementedItemDataHandlersServer) testEmbeddedByValue()                          {}
 
@@ -241,6 +561,24 @@ func _ItemDataHandlers_GetItemData_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ItemDataHandlers_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItemDataHandlersServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ItemDataHandlers_GetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItemDataHandlersServer).GetUsage(ctx, req.(*GetUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ItemDataHandlers_ServiceDesc is the grpc.ServiceDesc for ItemDataHandlers service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -256,6 +594,10 @@ var ItemDataHandlers_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetItemData",
 			Handler:    _ItemDataHandlers_GetItemData_Handler,
 		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _ItemDataHandlers_GetUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/handlers.proto",
@@ -400,3 +742,2339 @@ var MetaDataHandlers_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/proto/handlers.proto",
 }
+
+const (
+	FolderHandlers_PostFolder_FullMethodName   = "/server_grpc.FolderHandlers/PostFolder"
+	FolderHandlers_GetFolders_FullMethodName   = "/server_grpc.FolderHandlers/GetFolders"
+	FolderHandlers_DeleteFolder_FullMethodName = "/server_grpc.FolderHandlers/DeleteFolder"
+)
+
+// FolderHandlersClient is the client API for FolderHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FolderHandlersClient interface {
+	PostFolder(ctx context.Context, in *PostFolderRequest, opts ...grpc.CallOption) (*PostFolderResponse, error)
+	GetFolders(ctx context.Context, in *GetFoldersRequest, opts ...grpc.CallOption) (*GetFoldersResponse, error)
+	DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error)
+}
+
+type folderHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFolderHandlersClient(cc grpc.ClientConnInterface) FolderHandlersClient {
+	return &folderHandlersClient{cc}
+}
+
+func (c *folderHandlersClient) PostFolder(ctx context.Context, in *PostFolderRequest, opts ...grpc.CallOption) (*PostFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostFolderResponse)
+	err := c.cc.Invoke(ctx, FolderHandlers_PostFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *folderHandlersClient) GetFolders(ctx context.Context, in *GetFoldersRequest, opts ...grpc.CallOption) (*GetFoldersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFoldersResponse)
+	err := c.cc.Invoke(ctx, FolderHandlers_GetFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *folderHandlersClient) DeleteFolder(ctx context.Context, in *DeleteFolderRequest, opts ...grpc.CallOption) (*DeleteFolderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFolderResponse)
+	err := c.cc.Invoke(ctx, FolderHandlers_DeleteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FolderHandlersServer is the server API for FolderHandlers service.
+// All implementations must embed UnimplementedFolderHandlersServer
+// for forward compatibility.
+type FolderHandlersServer interface {
+	PostFolder(context.Context, *PostFolderRequest) (*PostFolderResponse, error)
+	GetFolders(context.Context, *GetFoldersRequest) (*GetFoldersResponse, error)
+	DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error)
+	mustEmbedUnimplementedFolderHandlersServer()
+}
+
+// UnimplementedFolderHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFolderHandlersServer struct{}
+
+func (UnimplementedFolderHandlersServer) PostFolder(context.Context, *PostFolderRequest) (*PostFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostFolder not implemented")
+}
+func (UnimplementedFolderHandlersServer) GetFolders(context.Context, *GetFoldersRequest) (*GetFoldersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFolders not implemented")
+}
+func (UnimplementedFolderHandlersServer) DeleteFolder(context.Context, *DeleteFolderRequest) (*DeleteFolderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFolder not implemented")
+}
+func (UnimplementedFolderHandlersServer) mustEmbedUnimplementedFolderHandlersServer() {}
+func (UnimplementedFolderHandlersServer) testEmbeddedByValue()                        {}
+
+// UnsafeFolderHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FolderHandlersServer will
+// result in compilation errors.
+type UnsafeFolderHandlersServer interface {
+	mustEmbedUnimplementedFolderHandlersServer()
+}
+
+func RegisterFolderHandlersServer(s grpc.ServiceRegistrar, srv FolderHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedFolderHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FolderHandlers_ServiceDesc, srv)
+}
+
+func _FolderHandlers_PostFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FolderHandlersServer).PostFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FolderHandlers_PostFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FolderHandlersServer).PostFolder(ctx, req.(*PostFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FolderHandlers_GetFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFoldersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FolderHandlersServer).GetFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FolderHandlers_GetFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FolderHandlersServer).GetFolders(ctx, req.(*GetFoldersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FolderHandlers_DeleteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFolderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FolderHandlersServer).DeleteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FolderHandlers_DeleteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FolderHandlersServer).DeleteFolder(ctx, req.(*DeleteFolderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FolderHandlers_ServiceDesc is the grpc.ServiceDesc for FolderHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FolderHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.FolderHandlers",
+	HandlerType: (*FolderHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PostFolder",
+			Handler:    _FolderHandlers_PostFolder_Handler,
+		},
+		{
+			MethodName: "GetFolders",
+			Handler:    _FolderHandlers_GetFolders_Handler,
+		},
+		{
+			MethodName: "DeleteFolder",
+			Handler:    _FolderHandlers_DeleteFolder_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	VaultHandlers_StartKeyRotation_FullMethodName  = "/server_grpc.VaultHandlers/StartKeyRotation"
+	VaultHandlers_GetStaleKeys_FullMethodName      = "/server_grpc.VaultHandlers/GetStaleKeys"
+	VaultHandlers_RewrapKeys_FullMethodName        = "/server_grpc.VaultHandlers/RewrapKeys"
+	VaultHandlers_FinishKeyRotation_FullMethodName = "/server_grpc.VaultHandlers/FinishKeyRotation"
+)
+
+// VaultHandlersClient is the client API for VaultHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VaultHandlersClient interface {
+	StartKeyRotation(ctx context.Context, in *StartKeyRotationRequest, opts ...grpc.CallOption) (*StartKeyRotationResponse, error)
+	GetStaleKeys(ctx context.Context, in *GetStaleKeysRequest, opts ...grpc.CallOption) (*GetStaleKeysResponse, error)
+	RewrapKeys(ctx context.Context, in *RewrapKeysRequest, opts ...grpc.CallOption) (*RewrapKeysResponse, error)
+	FinishKeyRotation(ctx context.Context, in *FinishKeyRotationRequest, opts ...grpc.CallOption) (*FinishKeyRotationResponse, error)
+}
+
+type vaultHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVaultHandlersClient(cc grpc.ClientConnInterface) VaultHandlersClient {
+	return &vaultHandlersClient{cc}
+}
+
+func (c *vaultHandlersClient) StartKeyRotation(ctx context.Context, in *StartKeyRotationRequest, opts ...grpc.CallOption) (*StartKeyRotationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartKeyRotationResponse)
+	err := c.cc.Invoke(ctx, VaultHandlers_StartKeyRotation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vaultHandlersClient) GetStaleKeys(ctx context.Context, in *GetStaleKeysRequest, opts ...grpc.CallOption) (*GetStaleKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStaleKeysResponse)
+	err := c.cc.Invoke(ctx, VaultHandlers_GetStaleKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vaultHandlersClient) RewrapKeys(ctx context.Context, in *RewrapKeysRequest, opts ...grpc.CallOption) (*RewrapKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewrapKeysResponse)
+	err := c.cc.Invoke(ctx, VaultHandlers_RewrapKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vaultHandlersClient) FinishKeyRotation(ctx context.Context, in *FinishKeyRotationRequest, opts ...grpc.CallOption) (*FinishKeyRotationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishKeyRotationResponse)
+	err := c.cc.Invoke(ctx, VaultHandlers_FinishKeyRotation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VaultHandlersServer is the server API for VaultHandlers service.
+// All implementations must embed UnimplementedVaultHandlersServer
+// for forward compatibility.
+type VaultHandlersServer interface {
+	StartKeyRotation(context.Context, *StartKeyRotationRequest) (*StartKeyRotationResponse, error)
+	GetStaleKeys(context.Context, *GetStaleKeysRequest) (*GetStaleKeysResponse, error)
+	RewrapKeys(context.Context, *RewrapKeysRequest) (*RewrapKeysResponse, error)
+	FinishKeyRotation(context.Context, *FinishKeyRotationRequest) (*FinishKeyRotationResponse, error)
+	mustEmbedUnimplementedVaultHandlersServer()
+}
+
+// UnimplementedVaultHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVaultHandlersServer struct{}
+
+func (UnimplementedVaultHandlersServer) StartKeyRotation(context.Context, *StartKeyRotationRequest) (*StartKeyRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartKeyRotation not implemented")
+}
+func (UnimplementedVaultHandlersServer) GetStaleKeys(context.Context, *GetStaleKeysRequest) (*GetStaleKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStaleKeys not implemented")
+}
+func (UnimplementedVaultHandlersServer) RewrapKeys(context.Context, *RewrapKeysRequest) (*RewrapKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RewrapKeys not implemented")
+}
+func (UnimplementedVaultHandlersServer) FinishKeyRotation(context.Context, *FinishKeyRotationRequest) (*FinishKeyRotationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishKeyRotation not implemented")
+}
+func (UnimplementedVaultHandlersServer) mustEmbedUnimplementedVaultHandlersServer() {}
+func (UnimplementedVaultHandlersServer) testEmbeddedByValue()                       {}
+
+// UnsafeVaultHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VaultHandlersServer will
+// result in compilation errors.
+type UnsafeVaultHandlersServer interface {
+	mustEmbedUnimplementedVaultHandlersServer()
+}
+
+func RegisterVaultHandlersServer(s grpc.ServiceRegistrar, srv VaultHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedVaultHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VaultHandlers_ServiceDesc, srv)
+}
+
+func _VaultHandlers_StartKeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VaultHandlersServer).StartKeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VaultHandlers_StartKeyRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VaultHandlersServer).StartKeyRotation(ctx, req.(*StartKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VaultHandlers_GetStaleKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStaleKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VaultHandlersServer).GetStaleKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VaultHandlers_GetStaleKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VaultHandlersServer).GetStaleKeys(ctx, req.(*GetStaleKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VaultHandlers_RewrapKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RewrapKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VaultHandlersServer).RewrapKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VaultHandlers_RewrapKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VaultHandlersServer).RewrapKeys(ctx, req.(*RewrapKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VaultHandlers_FinishKeyRotation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishKeyRotationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VaultHandlersServer).FinishKeyRotation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VaultHandlers_FinishKeyRotation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VaultHandlersServer).FinishKeyRotation(ctx, req.(*FinishKeyRotationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VaultHandlers_ServiceDesc is the grpc.ServiceDesc for VaultHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VaultHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.VaultHandlers",
+	HandlerType: (*VaultHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StartKeyRotation",
+			Handler:    _VaultHandlers_StartKeyRotation_Handler,
+		},
+		{
+			MethodName: "GetStaleKeys",
+			Handler:    _VaultHandlers_GetStaleKeys_Handler,
+		},
+		{
+			MethodName: "RewrapKeys",
+			Handler:    _VaultHandlers_RewrapKeys_Handler,
+		},
+		{
+			MethodName: "FinishKeyRotation",
+			Handler:    _VaultHandlers_FinishKeyRotation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	SharingHandlers_PostSharingKey_FullMethodName   = "/server_grpc.SharingHandlers/PostSharingKey"
+	SharingHandlers_GetSharingKey_FullMethodName    = "/server_grpc.SharingHandlers/GetSharingKey"
+	SharingHandlers_GetPublicKey_FullMethodName     = "/server_grpc.SharingHandlers/GetPublicKey"
+	SharingHandlers_ShareItem_FullMethodName        = "/server_grpc.SharingHandlers/ShareItem"
+	SharingHandlers_GetShares_FullMethodName        = "/server_grpc.SharingHandlers/GetShares"
+	SharingHandlers_RevokeShare_FullMethodName      = "/server_grpc.SharingHandlers/RevokeShare"
+	SharingHandlers_GetSharedWithMe_FullMethodName  = "/server_grpc.SharingHandlers/GetSharedWithMe"
+	SharingHandlers_GetSharedItem_FullMethodName    = "/server_grpc.SharingHandlers/GetSharedItem"
+	SharingHandlers_UpdateSharedItem_FullMethodName = "/server_grpc.SharingHandlers/UpdateSharedItem"
+)
+
+// SharingHandlersClient is the client API for SharingHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SharingHandlersClient interface {
+	PostSharingKey(ctx context.Context, in *PostSharingKeyRequest, opts ...grpc.CallOption) (*PostSharingKeyResponse, error)
+	GetSharingKey(ctx context.Context, in *GetSharingKeyRequest, opts ...grpc.CallOption) (*GetSharingKeyResponse, error)
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error)
+	GetShares(ctx context.Context, in *GetSharesRequest, opts ...grpc.CallOption) (*GetSharesResponse, error)
+	RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error)
+	GetSharedWithMe(ctx context.Context, in *GetSharedWithMeRequest, opts ...grpc.CallOption) (*GetSharedWithMeResponse, error)
+	GetSharedItem(ctx context.Context, in *GetSharedItemRequest, opts ...grpc.CallOption) (*GetSharedItemResponse, error)
+	UpdateSharedItem(ctx context.Context, in *UpdateSharedItemRequest, opts ...grpc.CallOption) (*UpdateSharedItemResponse, error)
+}
+
+type sharingHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSharingHandlersClient(cc grpc.ClientConnInterface) SharingHandlersClient {
+	return &sharingHandlersClient{cc}
+}
+
+func (c *sharingHandlersClient) PostSharingKey(ctx context.Context, in *PostSharingKeyRequest, opts ...grpc.CallOption) (*PostSharingKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostSharingKeyResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_PostSharingKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) GetSharingKey(ctx context.Context, in *GetSharingKeyRequest, opts ...grpc.CallOption) (*GetSharingKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSharingKeyResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_GetSharingKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_GetPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) ShareItem(ctx context.Context, in *ShareItemRequest, opts ...grpc.CallOption) (*ShareItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareItemResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_ShareItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) GetShares(ctx context.Context, in *GetSharesRequest, opts ...grpc.CallOption) (*GetSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSharesResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_GetShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) RevokeShare(ctx context.Context, in *RevokeShareRequest, opts ...grpc.CallOption) (*RevokeShareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeShareResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_RevokeShare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) GetSharedWithMe(ctx context.Context, in *GetSharedWithMeRequest, opts ...grpc.CallOption) (*GetSharedWithMeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSharedWithMeResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_GetSharedWithMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) GetSharedItem(ctx context.Context, in *GetSharedItemRequest, opts ...grpc.CallOption) (*GetSharedItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSharedItemResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_GetSharedItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharingHandlersClient) UpdateSharedItem(ctx context.Context, in *UpdateSharedItemRequest, opts ...grpc.CallOption) (*UpdateSharedItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSharedItemResponse)
+	err := c.cc.Invoke(ctx, SharingHandlers_UpdateSharedItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SharingHandlersServer is the server API for SharingHandlers service.
+// All implementations must embed UnimplementedSharingHandlersServer
+// for forward compatibility.
+type SharingHandlersServer interface {
+	PostSharingKey(context.Context, *PostSharingKeyRequest) (*PostSharingKeyResponse, error)
+	GetSharingKey(context.Context, *GetSharingKeyRequest) (*GetSharingKeyResponse, error)
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error)
+	GetShares(context.Context, *GetSharesRequest) (*GetSharesResponse, error)
+	RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error)
+	GetSharedWithMe(context.Context, *GetSharedWithMeRequest) (*GetSharedWithMeResponse, error)
+	GetSharedItem(context.Context, *GetSharedItemRequest) (*GetSharedItemResponse, error)
+	UpdateSharedItem(context.Context, *UpdateSharedItemRequest) (*UpdateSharedItemResponse, error)
+	mustEmbedUnimplementedSharingHandlersServer()
+}
+
+// UnimplementedSharingHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSharingHandlersServer struct{}
+
+func (UnimplementedSharingHandlersServer) PostSharingKey(context.Context, *PostSharingKeyRequest) (*PostSharingKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostSharingKey not implemented")
+}
+func (UnimplementedSharingHandlersServer) GetSharingKey(context.Context, *GetSharingKeyRequest) (*GetSharingKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharingKey not implemented")
+}
+func (UnimplementedSharingHandlersServer) GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedSharingHandlersServer) ShareItem(context.Context, *ShareItemRequest) (*ShareItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareItem not implemented")
+}
+func (UnimplementedSharingHandlersServer) GetShares(context.Context, *GetSharesRequest) (*GetSharesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShares not implemented")
+}
+func (UnimplementedSharingHandlersServer) RevokeShare(context.Context, *RevokeShareRequest) (*RevokeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeShare not implemented")
+}
+func (UnimplementedSharingHandlersServer) GetSharedWithMe(context.Context, *GetSharedWithMeRequest) (*GetSharedWithMeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedWithMe not implemented")
+}
+func (UnimplementedSharingHandlersServer) GetSharedItem(context.Context, *GetSharedItemRequest) (*GetSharedItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedItem not implemented")
+}
+func (UnimplementedSharingHandlersServer) UpdateSharedItem(context.Context, *UpdateSharedItemRequest) (*UpdateSharedItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSharedItem not implemented")
+}
+func (UnimplementedSharingHandlersServer) mustEmbedUnimplementedSharingHandlersServer() {}
+func (UnimplementedSharingHandlersServer) testEmbeddedByValue()                         {}
+
+// UnsafeSharingHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SharingHandlersServer will
+// result in compilation errors.
+type UnsafeSharingHandlersServer interface {
+	mustEmbedUnimplementedSharingHandlersServer()
+}
+
+func RegisterSharingHandlersServer(s grpc.ServiceRegistrar, srv SharingHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedSharingHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SharingHandlers_ServiceDesc, srv)
+}
+
+func _SharingHandlers_PostSharingKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSharingKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).PostSharingKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_PostSharingKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).PostSharingKey(ctx, req.(*PostSharingKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_GetSharingKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharingKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).GetSharingKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_GetSharingKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).GetSharingKey(ctx, req.(*GetSharingKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_GetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_ShareItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).ShareItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_ShareItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).ShareItem(ctx, req.(*ShareItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_GetShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).GetShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_GetShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).GetShares(ctx, req.(*GetSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_RevokeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).RevokeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_RevokeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).RevokeShare(ctx, req.(*RevokeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_GetSharedWithMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedWithMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).GetSharedWithMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_GetSharedWithMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).GetSharedWithMe(ctx, req.(*GetSharedWithMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_GetSharedItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).GetSharedItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_GetSharedItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).GetSharedItem(ctx, req.(*GetSharedItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SharingHandlers_UpdateSharedItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSharedItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharingHandlersServer).UpdateSharedItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SharingHandlers_UpdateSharedItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharingHandlersServer).UpdateSharedItem(ctx, req.(*UpdateSharedItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SharingHandlers_ServiceDesc is the grpc.ServiceDesc for SharingHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SharingHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.SharingHandlers",
+	HandlerType: (*SharingHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PostSharingKey",
+			Handler:    _SharingHandlers_PostSharingKey_Handler,
+		},
+		{
+			MethodName: "GetSharingKey",
+			Handler:    _SharingHandlers_GetSharingKey_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _SharingHandlers_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "ShareItem",
+			Handler:    _SharingHandlers_ShareItem_Handler,
+		},
+		{
+			MethodName: "GetShares",
+			Handler:    _SharingHandlers_GetShares_Handler,
+		},
+		{
+			MethodName: "RevokeShare",
+			Handler:    _SharingHandlers_RevokeShare_Handler,
+		},
+		{
+			MethodName: "GetSharedWithMe",
+			Handler:    _SharingHandlers_GetSharedWithMe_Handler,
+		},
+		{
+			MethodName: "GetSharedItem",
+			Handler:    _SharingHandlers_GetSharedItem_Handler,
+		},
+		{
+			MethodName: "UpdateSharedItem",
+			Handler:    _SharingHandlers_UpdateSharedItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	OrgHandlers_CreateOrg_FullMethodName            = "/server_grpc.OrgHandlers/CreateOrg"
+	OrgHandlers_GetOrgs_FullMethodName              = "/server_grpc.OrgHandlers/GetOrgs"
+	OrgHandlers_DeleteOrg_FullMethodName            = "/server_grpc.OrgHandlers/DeleteOrg"
+	OrgHandlers_GetOrgMembers_FullMethodName        = "/server_grpc.OrgHandlers/GetOrgMembers"
+	OrgHandlers_InviteMember_FullMethodName         = "/server_grpc.OrgHandlers/InviteMember"
+	OrgHandlers_AnswerInvitation_FullMethodName     = "/server_grpc.OrgHandlers/AnswerInvitation"
+	OrgHandlers_RemoveMember_FullMethodName         = "/server_grpc.OrgHandlers/RemoveMember"
+	OrgHandlers_ChangeRole_FullMethodName           = "/server_grpc.OrgHandlers/ChangeRole"
+	OrgHandlers_CreateCollection_FullMethodName     = "/server_grpc.OrgHandlers/CreateCollection"
+	OrgHandlers_GetCollections_FullMethodName       = "/server_grpc.OrgHandlers/GetCollections"
+	OrgHandlers_DeleteCollection_FullMethodName     = "/server_grpc.OrgHandlers/DeleteCollection"
+	OrgHandlers_GetCollectionItems_FullMethodName   = "/server_grpc.OrgHandlers/GetCollectionItems"
+	OrgHandlers_GetCollectionItem_FullMethodName    = "/server_grpc.OrgHandlers/GetCollectionItem"
+	OrgHandlers_PostCollectionItem_FullMethodName   = "/server_grpc.OrgHandlers/PostCollectionItem"
+	OrgHandlers_DeleteCollectionItem_FullMethodName = "/server_grpc.OrgHandlers/DeleteCollectionItem"
+	OrgHandlers_RekeyCollection_FullMethodName      = "/server_grpc.OrgHandlers/RekeyCollection"
+)
+
+// OrgHandlersClient is the client API for OrgHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrgHandlersClient interface {
+	CreateOrg(ctx context.Context, in *CreateOrgRequest, opts ...grpc.CallOption) (*CreateOrgResponse, error)
+	GetOrgs(ctx context.Context, in *GetOrgsRequest, opts ...grpc.CallOption) (*GetOrgsResponse, error)
+	DeleteOrg(ctx context.Context, in *DeleteOrgRequest, opts ...grpc.CallOption) (*DeleteOrgResponse, error)
+	GetOrgMembers(ctx context.Context, in *GetOrgMembersRequest, opts ...grpc.CallOption) (*GetOrgMembersResponse, error)
+	InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InviteMemberResponse, error)
+	AnswerInvitation(ctx context.Context, in *AnswerInvitationRequest, opts ...grpc.CallOption) (*AnswerInvitationResponse, error)
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+	ChangeRole(ctx context.Context, in *ChangeRoleRequest, opts ...grpc.CallOption) (*ChangeRoleResponse, error)
+	CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error)
+	GetCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	GetCollectionItems(ctx context.Context, in *GetCollectionItemsRequest, opts ...grpc.CallOption) (*GetCollectionItemsResponse, error)
+	GetCollectionItem(ctx context.Context, in *GetCollectionItemRequest, opts ...grpc.CallOption) (*GetCollectionItemResponse, error)
+	PostCollectionItem(ctx context.Context, in *PostCollectionItemRequest, opts ...grpc.CallOption) (*PostCollectionItemResponse, error)
+	DeleteCollectionItem(ctx context.Context, in *DeleteCollectionItemRequest, opts ...grpc.CallOption) (*DeleteCollectionItemResponse, error)
+	RekeyCollection(ctx context.Context, in *RekeyCollectionRequest, opts ...grpc.CallOption) (*RekeyCollectionResponse, error)
+}
+
+type orgHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrgHandlersClient(cc grpc.ClientConnInterface) OrgHandlersClient {
+	return &orgHandlersClient{cc}
+}
+
+func (c *orgHandlersClient) CreateOrg(ctx context.Context, in *CreateOrgRequest, opts ...grpc.CallOption) (*CreateOrgResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrgResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_CreateOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) GetOrgs(ctx context.Context, in *GetOrgsRequest, opts ...grpc.CallOption) (*GetOrgsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrgsResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_GetOrgs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) DeleteOrg(ctx context.Context, in *DeleteOrgRequest, opts ...grpc.CallOption) (*DeleteOrgResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteOrgResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_DeleteOrg_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) GetOrgMembers(ctx context.Context, in *GetOrgMembersRequest, opts ...grpc.CallOption) (*GetOrgMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOrgMembersResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_GetOrgMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) InviteMember(ctx context.Context, in *InviteMemberRequest, opts ...grpc.CallOption) (*InviteMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteMemberResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_InviteMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) AnswerInvitation(ctx context.Context, in *AnswerInvitationRequest, opts ...grpc.CallOption) (*AnswerInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnswerInvitationResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_AnswerInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) ChangeRole(ctx context.Context, in *ChangeRoleRequest, opts ...grpc.CallOption) (*ChangeRoleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeRoleResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_ChangeRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) CreateCollection(ctx context.Context, in *CreateCollectionRequest, opts ...grpc.CallOption) (*CreateCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCollectionResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) GetCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionsResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_GetCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) GetCollectionItems(ctx context.Context, in *GetCollectionItemsRequest, opts ...grpc.CallOption) (*GetCollectionItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionItemsResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_GetCollectionItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) GetCollectionItem(ctx context.Context, in *GetCollectionItemRequest, opts ...grpc.CallOption) (*GetCollectionItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionItemResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_GetCollectionItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) PostCollectionItem(ctx context.Context, in *PostCollectionItemRequest, opts ...grpc.CallOption) (*PostCollectionItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostCollectionItemResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_PostCollectionItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) DeleteCollectionItem(ctx context.Context, in *DeleteCollectionItemRequest, opts ...grpc.CallOption) (*DeleteCollectionItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCollectionItemResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_DeleteCollectionItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orgHandlersClient) RekeyCollection(ctx context.Context, in *RekeyCollectionRequest, opts ...grpc.CallOption) (*RekeyCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RekeyCollectionResponse)
+	err := c.cc.Invoke(ctx, OrgHandlers_RekeyCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrgHandlersServer is the server API for OrgHandlers service.
+// All implementations must embed UnimplementedOrgHandlersServer
+// for forward compatibility.
+type OrgHandlersServer interface {
+	CreateOrg(context.Context, *CreateOrgRequest) (*CreateOrgResponse, error)
+	GetOrgs(context.Context, *GetOrgsRequest) (*GetOrgsResponse, error)
+	DeleteOrg(context.Context, *DeleteOrgRequest) (*DeleteOrgResponse, error)
+	GetOrgMembers(context.Context, *GetOrgMembersRequest) (*GetOrgMembersResponse, error)
+	InviteMember(context.Context, *InviteMemberRequest) (*InviteMemberResponse, error)
+	AnswerInvitation(context.Context, *AnswerInvitationRequest) (*AnswerInvitationResponse, error)
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	ChangeRole(context.Context, *ChangeRoleRequest) (*ChangeRoleResponse, error)
+	CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error)
+	GetCollections(context.Context, *GetCollectionsRequest) (*GetCollectionsResponse, error)
+	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	GetCollectionItems(context.Context, *GetCollectionItemsRequest) (*GetCollectionItemsResponse, error)
+	GetCollectionItem(context.Context, *GetCollectionItemRequest) (*GetCollectionItemResponse, error)
+	PostCollectionItem(context.Context, *PostCollectionItemRequest) (*PostCollectionItemResponse, error)
+	DeleteCollectionItem(context.Context, *DeleteCollectionItemRequest) (*DeleteCollectionItemResponse, error)
+	RekeyCollection(context.Context, *RekeyCollectionRequest) (*RekeyCollectionResponse, error)
+	mustEmbedUnimplementedOrgHandlersServer()
+}
+
+// UnimplementedOrgHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOrgHandlersServer struct{}
+
+func (UnimplementedOrgHandlersServer) CreateOrg(context.Context, *CreateOrgRequest) (*CreateOrgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrg not implemented")
+}
+func (UnimplementedOrgHandlersServer) GetOrgs(context.Context, *GetOrgsRequest) (*GetOrgsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrgs not implemented")
+}
+func (UnimplementedOrgHandlersServer) DeleteOrg(context.Context, *DeleteOrgRequest) (*DeleteOrgResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrg not implemented")
+}
+func (UnimplementedOrgHandlersServer) GetOrgMembers(context.Context, *GetOrgMembersRequest) (*GetOrgMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrgMembers not implemented")
+}
+func (UnimplementedOrgHandlersServer) InviteMember(context.Context, *InviteMemberRequest) (*InviteMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteMember not implemented")
+}
+func (UnimplementedOrgHandlersServer) AnswerInvitation(context.Context, *AnswerInvitationRequest) (*AnswerInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerInvitation not implemented")
+}
+func (UnimplementedOrgHandlersServer) RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedOrgHandlersServer) ChangeRole(context.Context, *ChangeRoleRequest) (*ChangeRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeRole not implemented")
+}
+func (UnimplementedOrgHandlersServer) CreateCollection(context.Context, *CreateCollectionRequest) (*CreateCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedOrgHandlersServer) GetCollections(context.Context, *GetCollectionsRequest) (*GetCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollections not implemented")
+}
+func (UnimplementedOrgHandlersServer) DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedOrgHandlersServer) GetCollectionItems(context.Context, *GetCollectionItemsRequest) (*GetCollectionItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionItems not implemented")
+}
+func (UnimplementedOrgHandlersServer) GetCollectionItem(context.Context, *GetCollectionItemRequest) (*GetCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollectionItem not implemented")
+}
+func (UnimplementedOrgHandlersServer) PostCollectionItem(context.Context, *PostCollectionItemRequest) (*PostCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostCollectionItem not implemented")
+}
+func (UnimplementedOrgHandlersServer) DeleteCollectionItem(context.Context, *DeleteCollectionItemRequest) (*DeleteCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollectionItem not implemented")
+}
+func (UnimplementedOrgHandlersServer) RekeyCollection(context.Context, *RekeyCollectionRequest) (*RekeyCollectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RekeyCollection not implemented")
+}
+func (UnimplementedOrgHandlersServer) mustEmbedUnimplementedOrgHandlersServer() {}
+func (UnimplementedOrgHandlersServer) testEmbeddedByValue()                     {}
+
+// UnsafeOrgHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrgHandlersServer will
+// result in compilation errors.
+type UnsafeOrgHandlersServer interface {
+	mustEmbedUnimplementedOrgHandlersServer()
+}
+
+func RegisterOrgHandlersServer(s grpc.ServiceRegistrar, srv OrgHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedOrgHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OrgHandlers_ServiceDesc, srv)
+}
+
+func _OrgHandlers_CreateOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).CreateOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_CreateOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).CreateOrg(ctx, req.(*CreateOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_GetOrgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).GetOrgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_GetOrgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).GetOrgs(ctx, req.(*GetOrgsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_DeleteOrg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOrgRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).DeleteOrg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_DeleteOrg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).DeleteOrg(ctx, req.(*DeleteOrgRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_GetOrgMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrgMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).GetOrgMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_GetOrgMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).GetOrgMembers(ctx, req.(*GetOrgMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_InviteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).InviteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_InviteMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).InviteMember(ctx, req.(*InviteMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_AnswerInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).AnswerInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_AnswerInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).AnswerInvitation(ctx, req.(*AnswerInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_ChangeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).ChangeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_ChangeRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).ChangeRole(ctx, req.(*ChangeRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).CreateCollection(ctx, req.(*CreateCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_GetCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).GetCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_GetCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).GetCollections(ctx, req.(*GetCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).DeleteCollection(ctx, req.(*DeleteCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_GetCollectionItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).GetCollectionItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_GetCollectionItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).GetCollectionItems(ctx, req.(*GetCollectionItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_GetCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).GetCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_GetCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).GetCollectionItem(ctx, req.(*GetCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_PostCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).PostCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_PostCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).PostCollectionItem(ctx, req.(*PostCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_DeleteCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).DeleteCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_DeleteCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).DeleteCollectionItem(ctx, req.(*DeleteCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrgHandlers_RekeyCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RekeyCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrgHandlersServer).RekeyCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrgHandlers_RekeyCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrgHandlersServer).RekeyCollection(ctx, req.(*RekeyCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrgHandlers_ServiceDesc is the grpc.ServiceDesc for OrgHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrgHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.OrgHandlers",
+	HandlerType: (*OrgHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrg",
+			Handler:    _OrgHandlers_CreateOrg_Handler,
+		},
+		{
+			MethodName: "GetOrgs",
+			Handler:    _OrgHandlers_GetOrgs_Handler,
+		},
+		{
+			MethodName: "DeleteOrg",
+			Handler:    _OrgHandlers_DeleteOrg_Handler,
+		},
+		{
+			MethodName: "GetOrgMembers",
+			Handler:    _OrgHandlers_GetOrgMembers_Handler,
+		},
+		{
+			MethodName: "InviteMember",
+			Handler:    _OrgHandlers_InviteMember_Handler,
+		},
+		{
+			MethodName: "AnswerInvitation",
+			Handler:    _OrgHandlers_AnswerInvitation_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _OrgHandlers_RemoveMember_Handler,
+		},
+		{
+			MethodName: "ChangeRole",
+			Handler:    _OrgHandlers_ChangeRole_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _OrgHandlers_CreateCollection_Handler,
+		},
+		{
+			MethodName: "GetCollections",
+			Handler:    _OrgHandlers_GetCollections_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _OrgHandlers_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "GetCollectionItems",
+			Handler:    _OrgHandlers_GetCollectionItems_Handler,
+		},
+		{
+			MethodName: "GetCollectionItem",
+			Handler:    _OrgHandlers_GetCollectionItem_Handler,
+		},
+		{
+			MethodName: "PostCollectionItem",
+			Handler:    _OrgHandlers_PostCollectionItem_Handler,
+		},
+		{
+			MethodName: "DeleteCollectionItem",
+			Handler:    _OrgHandlers_DeleteCollectionItem_Handler,
+		},
+		{
+			MethodName: "RekeyCollection",
+			Handler:    _OrgHandlers_RekeyCollection_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	EmergencyHandlers_GrantEmergencyAccess_FullMethodName   = "/server_grpc.EmergencyHandlers/GrantEmergencyAccess"
+	EmergencyHandlers_GetEmergencyAccess_FullMethodName     = "/server_grpc.EmergencyHandlers/GetEmergencyAccess"
+	EmergencyHandlers_RevokeEmergencyAccess_FullMethodName  = "/server_grpc.EmergencyHandlers/RevokeEmergencyAccess"
+	EmergencyHandlers_RequestEmergencyAccess_FullMethodName = "/server_grpc.EmergencyHandlers/RequestEmergencyAccess"
+	EmergencyHandlers_AnswerEmergencyAccess_FullMethodName  = "/server_grpc.EmergencyHandlers/AnswerEmergencyAccess"
+	EmergencyHandlers_UpdateEmergencyKeys_FullMethodName    = "/server_grpc.EmergencyHandlers/UpdateEmergencyKeys"
+	EmergencyHandlers_GetEmergencyVault_FullMethodName      = "/server_grpc.EmergencyHandlers/GetEmergencyVault"
+	EmergencyHandlers_GetEmergencyItems_FullMethodName      = "/server_grpc.EmergencyHandlers/GetEmergencyItems"
+	EmergencyHandlers_GetEmergencyItem_FullMethodName       = "/server_grpc.EmergencyHandlers/GetEmergencyItem"
+)
+
+// EmergencyHandlersClient is the client API for EmergencyHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmergencyHandlersClient interface {
+	GrantEmergencyAccess(ctx context.Context, in *GrantEmergencyAccessRequest, opts ...grpc.CallOption) (*GrantEmergencyAccessResponse, error)
+	GetEmergencyAccess(ctx context.Context, in *GetEmergencyAccessRequest, opts ...grpc.CallOption) (*GetEmergencyAccessResponse, error)
+	RevokeEmergencyAccess(ctx context.Context, in *RevokeEmergencyAccessRequest, opts ...grpc.CallOption) (*RevokeEmergencyAccessResponse, error)
+	RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error)
+	AnswerEmergencyAccess(ctx context.Context, in *AnswerEmergencyAccessRequest, opts ...grpc.CallOption) (*AnswerEmergencyAccessResponse, error)
+	UpdateEmergencyKeys(ctx context.Context, in *UpdateEmergencyKeysRequest, opts ...grpc.CallOption) (*UpdateEmergencyKeysResponse, error)
+	GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error)
+	GetEmergencyItems(ctx context.Context, in *GetEmergencyItemsRequest, opts ...grpc.CallOption) (*GetEmergencyItemsResponse, error)
+	GetEmergencyItem(ctx context.Context, in *GetEmergencyItemRequest, opts ...grpc.CallOption) (*GetEmergencyItemResponse, error)
+}
+
+type emergencyHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmergencyHandlersClient(cc grpc.ClientConnInterface) EmergencyHandlersClient {
+	return &emergencyHandlersClient{cc}
+}
+
+func (c *emergencyHandlersClient) GrantEmergencyAccess(ctx context.Context, in *GrantEmergencyAccessRequest, opts ...grpc.CallOption) (*GrantEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_GrantEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) GetEmergencyAccess(ctx context.Context, in *GetEmergencyAccessRequest, opts ...grpc.CallOption) (*GetEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_GetEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) RevokeEmergencyAccess(ctx context.Context, in *RevokeEmergencyAccessRequest, opts ...grpc.CallOption) (*RevokeEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_RevokeEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) RequestEmergencyAccess(ctx context.Context, in *RequestEmergencyAccessRequest, opts ...grpc.CallOption) (*RequestEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_RequestEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) AnswerEmergencyAccess(ctx context.Context, in *AnswerEmergencyAccessRequest, opts ...grpc.CallOption) (*AnswerEmergencyAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnswerEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_AnswerEmergencyAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) UpdateEmergencyKeys(ctx context.Context, in *UpdateEmergencyKeysRequest, opts ...grpc.CallOption) (*UpdateEmergencyKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateEmergencyKeysResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_UpdateEmergencyKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) GetEmergencyVault(ctx context.Context, in *GetEmergencyVaultRequest, opts ...grpc.CallOption) (*GetEmergencyVaultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmergencyVaultResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_GetEmergencyVault_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) GetEmergencyItems(ctx context.Context, in *GetEmergencyItemsRequest, opts ...grpc.CallOption) (*GetEmergencyItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmergencyItemsResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_GetEmergencyItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyHandlersClient) GetEmergencyItem(ctx context.Context, in *GetEmergencyItemRequest, opts ...grpc.CallOption) (*GetEmergencyItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmergencyItemResponse)
+	err := c.cc.Invoke(ctx, EmergencyHandlers_GetEmergencyItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmergencyHandlersServer is the server API for EmergencyHandlers service.
+// All implementations must embed UnimplementedEmergencyHandlersServer
+// for forward compatibility.
+type EmergencyHandlersServer interface {
+	GrantEmergencyAccess(context.Context, *GrantEmergencyAccessRequest) (*GrantEmergencyAccessResponse, error)
+	GetEmergencyAccess(context.Context, *GetEmergencyAccessRequest) (*GetEmergencyAccessResponse, error)
+	RevokeEmergencyAccess(context.Context, *RevokeEmergencyAccessRequest) (*RevokeEmergencyAccessResponse, error)
+	RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error)
+	AnswerEmergencyAccess(context.Context, *AnswerEmergencyAccessRequest) (*AnswerEmergencyAccessResponse, error)
+	UpdateEmergencyKeys(context.Context, *UpdateEmergencyKeysRequest) (*UpdateEmergencyKeysResponse, error)
+	GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error)
+	GetEmergencyItems(context.Context, *GetEmergencyItemsRequest) (*GetEmergencyItemsResponse, error)
+	GetEmergencyItem(context.Context, *GetEmergencyItemRequest) (*GetEmergencyItemResponse, error)
+	mustEmbedUnimplementedEmergencyHandlersServer()
+}
+
+// UnimplementedEmergencyHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEmergencyHandlersServer struct{}
+
+func (UnimplementedEmergencyHandlersServer) GrantEmergencyAccess(context.Context, *GrantEmergencyAccessRequest) (*GrantEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) GetEmergencyAccess(context.Context, *GetEmergencyAccessRequest) (*GetEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) RevokeEmergencyAccess(context.Context, *RevokeEmergencyAccessRequest) (*RevokeEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) RequestEmergencyAccess(context.Context, *RequestEmergencyAccessRequest) (*RequestEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) AnswerEmergencyAccess(context.Context, *AnswerEmergencyAccessRequest) (*AnswerEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) UpdateEmergencyKeys(context.Context, *UpdateEmergencyKeysRequest) (*UpdateEmergencyKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmergencyKeys not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) GetEmergencyVault(context.Context, *GetEmergencyVaultRequest) (*GetEmergencyVaultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyVault not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) GetEmergencyItems(context.Context, *GetEmergencyItemsRequest) (*GetEmergencyItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyItems not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) GetEmergencyItem(context.Context, *GetEmergencyItemRequest) (*GetEmergencyItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyItem not implemented")
+}
+func (UnimplementedEmergencyHandlersServer) mustEmbedUnimplementedEmergencyHandlersServer() {}
+func (UnimplementedEmergencyHandlersServer) testEmbeddedByValue()                           {}
+
+// UnsafeEmergencyHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmergencyHandlersServer will
+// result in compilation errors.
+type UnsafeEmergencyHandlersServer interface {
+	mustEmbedUnimplementedEmergencyHandlersServer()
+}
+
+func RegisterEmergencyHandlersServer(s grpc.ServiceRegistrar, srv EmergencyHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedEmergencyHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EmergencyHandlers_ServiceDesc, srv)
+}
+
+func _EmergencyHandlers_GrantEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).GrantEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_GrantEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).GrantEmergencyAccess(ctx, req.(*GrantEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_GetEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).GetEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_GetEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).GetEmergencyAccess(ctx, req.(*GetEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_RevokeEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).RevokeEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_RevokeEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).RevokeEmergencyAccess(ctx, req.(*RevokeEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_RequestEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).RequestEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_RequestEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).RequestEmergencyAccess(ctx, req.(*RequestEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_AnswerEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).AnswerEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_AnswerEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).AnswerEmergencyAccess(ctx, req.(*AnswerEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_UpdateEmergencyKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmergencyKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).UpdateEmergencyKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_UpdateEmergencyKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).UpdateEmergencyKeys(ctx, req.(*UpdateEmergencyKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_GetEmergencyVault_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyVaultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).GetEmergencyVault(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_GetEmergencyVault_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).GetEmergencyVault(ctx, req.(*GetEmergencyVaultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_GetEmergencyItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).GetEmergencyItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_GetEmergencyItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).GetEmergencyItems(ctx, req.(*GetEmergencyItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmergencyHandlers_GetEmergencyItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyHandlersServer).GetEmergencyItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmergencyHandlers_GetEmergencyItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyHandlersServer).GetEmergencyItem(ctx, req.(*GetEmergencyItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmergencyHandlers_ServiceDesc is the grpc.ServiceDesc for EmergencyHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmergencyHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.EmergencyHandlers",
+	HandlerType: (*EmergencyHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GrantEmergencyAccess",
+			Handler:    _EmergencyHandlers_GrantEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyAccess",
+			Handler:    _EmergencyHandlers_GetEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RevokeEmergencyAccess",
+			Handler:    _EmergencyHandlers_RevokeEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "RequestEmergencyAccess",
+			Handler:    _EmergencyHandlers_RequestEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "AnswerEmergencyAccess",
+			Handler:    _EmergencyHandlers_AnswerEmergencyAccess_Handler,
+		},
+		{
+			MethodName: "UpdateEmergencyKeys",
+			Handler:    _EmergencyHandlers_UpdateEmergencyKeys_Handler,
+		},
+		{
+			MethodName: "GetEmergencyVault",
+			Handler:    _EmergencyHandlers_GetEmergencyVault_Handler,
+		},
+		{
+			MethodName: "GetEmergencyItems",
+			Handler:    _EmergencyHandlers_GetEmergencyItems_Handler,
+		},
+		{
+			MethodName: "GetEmergencyItem",
+			Handler:    _EmergencyHandlers_GetEmergencyItem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	DeviceHandlers_EnrollDevice_FullMethodName = "/server_grpc.DeviceHandlers/EnrollDevice"
+	DeviceHandlers_GetDevices_FullMethodName   = "/server_grpc.DeviceHandlers/GetDevices"
+	DeviceHandlers_RevokeDevice_FullMethodName = "/server_grpc.DeviceHandlers/RevokeDevice"
+)
+
+// DeviceHandlersClient is the client API for DeviceHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DeviceHandlersClient interface {
+	EnrollDevice(ctx context.Context, in *EnrollDeviceRequest, opts ...grpc.CallOption) (*EnrollDeviceResponse, error)
+	GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (*GetDevicesResponse, error)
+	RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*RevokeDeviceResponse, error)
+}
+
+type deviceHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDeviceHandlersClient(cc grpc.ClientConnInterface) DeviceHandlersClient {
+	return &deviceHandlersClient{cc}
+}
+
+func (c *deviceHandlersClient) EnrollDevice(ctx context.Context, in *EnrollDeviceRequest, opts ...grpc.CallOption) (*EnrollDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceHandlers_EnrollDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceHandlersClient) GetDevices(ctx context.Context, in *GetDevicesRequest, opts ...grpc.CallOption) (*GetDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDevicesResponse)
+	err := c.cc.Invoke(ctx, DeviceHandlers_GetDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deviceHandlersClient) RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*RevokeDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeDeviceResponse)
+	err := c.cc.Invoke(ctx, DeviceHandlers_RevokeDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeviceHandlersServer is the server API for DeviceHandlers service.
+// All implementations must embed UnimplementedDeviceHandlersServer
+// for forward compatibility.
+type DeviceHandlersServer interface {
+	EnrollDevice(context.Context, *EnrollDeviceRequest) (*EnrollDeviceResponse, error)
+	GetDevices(context.Context, *GetDevicesRequest) (*GetDevicesResponse, error)
+	RevokeDevice(context.Context, *RevokeDeviceRequest) (*RevokeDeviceResponse, error)
+	mustEmbedUnimplementedDeviceHandlersServer()
+}
+
+// UnimplementedDeviceHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDeviceHandlersServer struct{}
+
+func (UnimplementedDeviceHandlersServer) EnrollDevice(context.Context, *EnrollDeviceRequest) (*EnrollDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollDevice not implemented")
+}
+func (UnimplementedDeviceHandlersServer) GetDevices(context.Context, *GetDevicesRequest) (*GetDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDevices not implemented")
+}
+func (UnimplementedDeviceHandlersServer) RevokeDevice(context.Context, *RevokeDeviceRequest) (*RevokeDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDevice not implemented")
+}
+func (UnimplementedDeviceHandlersServer) mustEmbedUnimplementedDeviceHandlersServer() {}
+func (UnimplementedDeviceHandlersServer) testEmbeddedByValue()                        {}
+
+// UnsafeDeviceHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DeviceHandlersServer will
+// result in compilation errors.
+type UnsafeDeviceHandlersServer interface {
+	mustEmbedUnimplementedDeviceHandlersServer()
+}
+
+func RegisterDeviceHandlersServer(s grpc.ServiceRegistrar, srv DeviceHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedDeviceHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DeviceHandlers_ServiceDesc, srv)
+}
+
+func _DeviceHandlers_EnrollDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceHandlersServer).EnrollDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceHandlers_EnrollDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceHandlersServer).EnrollDevice(ctx, req.(*EnrollDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceHandlers_GetDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceHandlersServer).GetDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceHandlers_GetDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceHandlersServer).GetDevices(ctx, req.(*GetDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeviceHandlers_RevokeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeviceHandlersServer).RevokeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeviceHandlers_RevokeDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeviceHandlersServer).RevokeDevice(ctx, req.(*RevokeDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DeviceHandlers_ServiceDesc is the grpc.ServiceDesc for DeviceHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DeviceHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.DeviceHandlers",
+	HandlerType: (*DeviceHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EnrollDevice",
+			Handler:    _DeviceHandlers_EnrollDevice_Handler,
+		},
+		{
+			MethodName: "GetDevices",
+			Handler:    _DeviceHandlers_GetDevices_Handler,
+		},
+		{
+			MethodName: "RevokeDevice",
+			Handler:    _DeviceHandlers_RevokeDevice_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	APITokenHandlers_CreateAPIToken_FullMethodName = "/server_grpc.APITokenHandlers/CreateAPIToken"
+	APITokenHandlers_GetAPITokens_FullMethodName   = "/server_grpc.APITokenHandlers/GetAPITokens"
+	APITokenHandlers_RevokeAPIToken_FullMethodName = "/server_grpc.APITokenHandlers/RevokeAPIToken"
+)
+
+// APITokenHandlersClient is the client API for APITokenHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APITokenHandlersClient interface {
+	CreateAPIToken(ctx context.Context, in *CreateAPITokenRequest, opts ...grpc.CallOption) (*CreateAPITokenResponse, error)
+	GetAPITokens(ctx context.Context, in *GetAPITokensRequest, opts ...grpc.CallOption) (*GetAPITokensResponse, error)
+	RevokeAPIToken(ctx context.Context, in *RevokeAPITokenRequest, opts ...grpc.CallOption) (*RevokeAPITokenResponse, error)
+}
+
+type aPITokenHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPITokenHandlersClient(cc grpc.ClientConnInterface) APITokenHandlersClient {
+	return &aPITokenHandlersClient{cc}
+}
+
+func (c *aPITokenHandlersClient) CreateAPIToken(ctx context.Context, in *CreateAPITokenRequest, opts ...grpc.CallOption) (*CreateAPITokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPITokenResponse)
+	err := c.cc.Invoke(ctx, APITokenHandlers_CreateAPIToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPITokenHandlersClient) GetAPITokens(ctx context.Context, in *GetAPITokensRequest, opts ...grpc.CallOption) (*GetAPITokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAPITokensResponse)
+	err := c.cc.Invoke(ctx, APITokenHandlers_GetAPITokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPITokenHandlersClient) RevokeAPIToken(ctx context.Context, in *RevokeAPITokenRequest, opts ...grpc.CallOption) (*RevokeAPITokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeAPITokenResponse)
+	err := c.cc.Invoke(ctx, APITokenHandlers_RevokeAPIToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APITokenHandlersServer is the server API for APITokenHandlers service.
+// All implementations must embed UnimplementedAPITokenHandlersServer
+// for forward compatibility.
+type APITokenHandlersServer interface {
+	CreateAPIToken(context.Context, *CreateAPITokenRequest) (*CreateAPITokenResponse, error)
+	GetAPITokens(context.Context, *GetAPITokensRequest) (*GetAPITokensResponse, error)
+	RevokeAPIToken(context.Context, *RevokeAPITokenRequest) (*RevokeAPITokenResponse, error)
+	mustEmbedUnimplementedAPITokenHandlersServer()
+}
+
+// UnimplementedAPITokenHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAPITokenHandlersServer struct{}
+
+func (UnimplementedAPITokenHandlersServer) CreateAPIToken(context.Context, *CreateAPITokenRequest) (*CreateAPITokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIToken not implemented")
+}
+func (UnimplementedAPITokenHandlersServer) GetAPITokens(context.Context, *GetAPITokensRequest) (*GetAPITokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAPITokens not implemented")
+}
+func (UnimplementedAPITokenHandlersServer) RevokeAPIToken(context.Context, *RevokeAPITokenRequest) (*RevokeAPITokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIToken not implemented")
+}
+func (UnimplementedAPITokenHandlersServer) mustEmbedUnimplementedAPITokenHandlersServer() {}
+func (UnimplementedAPITokenHandlersServer) testEmbeddedByValue()                          {}
+
+// UnsafeAPITokenHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APITokenHandlersServer will
+// result in compilation errors.
+type UnsafeAPITokenHandlersServer interface {
+	mustEmbedUnimplementedAPITokenHandlersServer()
+}
+
+func RegisterAPITokenHandlersServer(s grpc.ServiceRegistrar, srv APITokenHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedAPITokenHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&APITokenHandlers_ServiceDesc, srv)
+}
+
+func _APITokenHandlers_CreateAPIToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPITokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APITokenHandlersServer).CreateAPIToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APITokenHandlers_CreateAPIToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APITokenHandlersServer).CreateAPIToken(ctx, req.(*CreateAPITokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APITokenHandlers_GetAPITokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAPITokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APITokenHandlersServer).GetAPITokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APITokenHandlers_GetAPITokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APITokenHandlersServer).GetAPITokens(ctx, req.(*GetAPITokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APITokenHandlers_RevokeAPIToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPITokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APITokenHandlersServer).RevokeAPIToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: APITokenHandlers_RevokeAPIToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APITokenHandlersServer).RevokeAPIToken(ctx, req.(*RevokeAPITokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// APITokenHandlers_ServiceDesc is the grpc.ServiceDesc for APITokenHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var APITokenHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.APITokenHandlers",
+	HandlerType: (*APITokenHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAPIToken",
+			Handler:    _APITokenHandlers_CreateAPIToken_Handler,
+		},
+		{
+			MethodName: "GetAPITokens",
+			Handler:    _APITokenHandlers_GetAPITokens_Handler,
+		},
+		{
+			MethodName: "RevokeAPIToken",
+			Handler:    _APITokenHandlers_RevokeAPIToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
+
+const (
+	AuditHandlers_ListAuditEvents_FullMethodName = "/server_grpc.AuditHandlers/ListAuditEvents"
+)
+
+// AuditHandlersClient is the client API for AuditHandlers service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuditHandlersClient interface {
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+}
+
+type auditHandlersClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditHandlersClient(cc grpc.ClientConnInterface) AuditHandlersClient {
+	return &auditHandlersClient{cc}
+}
+
+func (c *auditHandlersClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, AuditHandlers_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuditHandlersServer is the server API for AuditHandlers service.
+// All implementations must embed UnimplementedAuditHandlersServer
+// for forward compatibility.
+type AuditHandlersServer interface {
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	mustEmbedUnimplementedAuditHandlersServer()
+}
+
+// UnimplementedAuditHandlersServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuditHandlersServer struct{}
+
+func (UnimplementedAuditHandlersServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedAuditHandlersServer) mustEmbedUnimplementedAuditHandlersServer() {}
+func (UnimplementedAuditHandlersServer) testEmbeddedByValue()                       {}
+
+// UnsafeAuditHandlersServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditHandlersServer will
+// result in compilation errors.
+type UnsafeAuditHandlersServer interface {
+	mustEmbedUnimplementedAuditHandlersServer()
+}
+
+func RegisterAuditHandlersServer(s grpc.ServiceRegistrar, srv AuditHandlersServer) {
+	// If the following call pancis, it indicates UnimplementedAuditHandlersServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuditHandlers_ServiceDesc, srv)
+}
+
+func _AuditHandlers_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditHandlersServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuditHandlers_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditHandlersServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuditHandlers_ServiceDesc is the grpc.ServiceDesc for AuditHandlers service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditHandlers_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "server_grpc.AuditHandlers",
+	HandlerType: (*AuditHandlersServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _AuditHandlers_ListAuditEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/handlers.proto",
+}
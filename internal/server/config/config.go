@@ -5,19 +5,28 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/mikhaylov123ty/GophKeeper/pkg/tracing"
 )
 
 var cfg *ServerConfig
 
 // ServerConfig represents the main server configuration structure.
-// It includes settings for address, logging, database, cryptographic keys, and configuration file location.
+// It includes settings for address, logging, database, cryptographic keys, storage quotas, metrics, tracing, gRPC serving options and configuration file location.
 type ServerConfig struct {
 	Address    *Address `json:"address"`
 	Logger     *Logger  `json:"logger"`
 	DB         *DB      `json:"db"`
 	Keys       *Keys    `json:"keys"`
+	Quotas     *Quotas  `json:"quotas"`
+	Metrics    *Metrics `json:"metrics"`
+	Tracing    *Tracing `json:"tracing"`
+	GRPC       *GRPC    `json:"grpc"`
 	ConfigFile string   `json:"config_file"`
 }
 
@@ -39,17 +48,79 @@ type DB struct {
 	MigrationsDir string `json:"migrations_dir"`
 }
 
+// Quotas represents the default storage quota of the users: the total size of the encrypted data of the items
+// in bytes, the number of the items and the size of an item. Zero limits are not applied. An operator may set
+// another quota of a user with the admin CLI.
+type Quotas struct {
+	MaxBytes    int64 `json:"max_bytes"`
+	MaxItems    int64 `json:"max_items"`
+	MaxItemSize int64 `json:"max_item_size"`
+}
+
+// Metrics represents the HTTP listener exposing the metrics of the server in the Prometheus text format
+// on the /metrics path. Address is the "host:port" to listen on, the metrics are not served when it is empty.
+type Metrics struct {
+	Address string `json:"address"`
+}
+
+// Tracing represents the export of the OpenTelemetry spans of the requests and the SQL queries.
+// Exporter is "stdout" or "otlp", the tracing is disabled when it is empty. Endpoint is the "host:port"
+// of the OTLP gRPC collector, Insecure sends the spans to it without TLS.
+type Tracing struct {
+	Exporter string `json:"exporter"`
+	Endpoint string `json:"endpoint"`
+	Insecure bool   `json:"insecure"`
+}
+
+// GRPC represents the serving options of the gRPC server. DrainTimeout is how long the requests in flight
+// are awaited on shutdown before the connections are closed, e.g. "30s". PreDrainDelay is how long the server
+// keeps accepting the requests while reporting NOT_SERVING before the drain, so the load balancers notice it.
+// Reflection enables the gRPC server reflection for the debugging tools such as grpcurl.
+type GRPC struct {
+	DrainTimeout  string `json:"drain_timeout"`
+	PreDrainDelay string `json:"pre_drain_delay"`
+	Reflection    bool   `json:"reflection"`
+}
+
+// defaultDrainTimeout is the drain timeout of the gRPC server unless configured.
+const defaultDrainTimeout = 30 * time.Second
+
 // Keys represents a container for cryptographic and JWT keys used for secure operations.
+// JWTKey is the legacy HS256 secret, JWT configures the asymmetric keyset replacing it.
+// LoginSecret derives the decoy login records of the unknown logins, the private key of the server is used unless set.
 type Keys struct {
 
 	// CryptoKeys represents a set of cryptographic keys including a private key and a corresponding certificate.
-	CryptoKeys *CryptoKeys `json:"crypto_keys"`
-	JWTKey     string      `json:"jwt_key"`
+	CryptoKeys  *CryptoKeys `json:"crypto_keys"`
+	JWTKey      string      `json:"jwt_key"`
+	JWT         *JWT        `json:"jwt"`
+	LoginSecret string      `json:"login_secret"`
+}
+
+// JWT represents the keyset of the access tokens. SigningKey is the path to the current Ed25519 or ECDSA P-256
+// private key, PreviousKeys are the paths to the keys the tokens signed before the rotation are verified with.
+// Issuer and Audience are put into the tokens and required from them when set, TTL is the token lifetime, e.g. "24h".
+// LegacyUntil is the RFC 3339 time the tokens signed with the legacy HS256 secret are accepted until once
+// the signing key is set, they are rejected unless it is set.
+type JWT struct {
+	SigningKey   string   `json:"signing_key"`
+	PreviousKeys []string `json:"previous_keys"`
+	Issuer       string   `json:"issuer"`
+	Audience     string   `json:"audience"`
+	TTL          string   `json:"ttl"`
+	LegacyUntil  string   `json:"legacy_until"`
 }
 
+// CryptoKeys represents the TLS certificate of the server with its private key and the optional client CA.
+// ClientCA and ClientCAKey enable the mutual TLS: the client certificates are verified with the CA and the devices
+// are enrolled with the certificates signed by it. RequireClientCert rejects the requests without a device certificate,
+// except the ones needed to log in and enroll the device.
 type CryptoKeys struct {
-	Certificate string `json:"certificate"`
-	PrivateKey  string `json:"private_key"`
+	Certificate       string `json:"certificate"`
+	PrivateKey        string `json:"private_key"`
+	ClientCA          string `json:"client_ca"`
+	ClientCAKey       string `json:"client_ca_key"`
+	RequireClientCert bool   `json:"require_client_cert"`
 }
 
 // Init initializes and validates the server configuration, including flags, environment variables, and optional config file.
@@ -61,7 +132,12 @@ func Init() (*ServerConfig, error) {
 		DB:      &DB{},
 		Keys: &Keys{
 			CryptoKeys: &CryptoKeys{},
+			JWT:        &JWT{},
 		},
+		Quotas:  &Quotas{},
+		Metrics: &Metrics{},
+		Tracing: &Tracing{},
+		GRPC:    &GRPC{},
 	}
 
 	// Парсинг флагов
@@ -88,6 +164,46 @@ func Init() (*ServerConfig, error) {
 	return config, nil
 }
 
+// InitDB initializes the database configuration of the tools working with the storage of the server, such as
+// the admin CLI. The DSN and the migrations directory of db are set by the flags of the tool, the empty ones are taken
+// from the server config file, and the environment variables override them as they do for the server.
+func InitDB(db *DB, configFile string) (*DB, error) {
+	config := &ServerConfig{
+		Address: &Address{},
+		Logger:  &Logger{},
+		DB:      db,
+		Keys: &Keys{
+			CryptoKeys: &CryptoKeys{},
+			JWT:        &JWT{},
+		},
+		Quotas:     &Quotas{},
+		Metrics:    &Metrics{},
+		Tracing:    &Tracing{},
+		GRPC:       &GRPC{},
+		ConfigFile: configFile,
+	}
+
+	if config.ConfigFile == "" {
+		config.ConfigFile = os.Getenv("CONFIG_FILE")
+	}
+
+	if config.ConfigFile != "" {
+		if err := config.InitConfigFile(); err != nil {
+			return nil, fmt.Errorf("failed init config file: %w", err)
+		}
+	}
+
+	if err := config.ParseEnv(); err != nil {
+		return nil, fmt.Errorf("error parsing environment variables: %w", err)
+	}
+
+	if config.DB.DSN == "" {
+		return nil, fmt.Errorf("database DSN is required")
+	}
+
+	return config.DB, nil
+}
+
 // parseFlags parses command-line flags and initializes the fields of ServerConfig accordingly.
 func (s *ServerConfig) parseFlags() {
 	// Базовые флаги
@@ -105,9 +221,46 @@ func (s *ServerConfig) parseFlags() {
 	flag.StringVar(&s.Keys.CryptoKeys.PrivateKey, "private-key", "", "Path to private key file")
 	flag.StringVar(&s.Keys.CryptoKeys.Certificate, "certificate", "", "Path to public cert file")
 
+	// Флаги клиентского УЦ
+	flag.StringVar(&s.Keys.CryptoKeys.ClientCA, "client-ca", "", "Path to client CA cert file, enables mutual TLS")
+	flag.StringVar(&s.Keys.CryptoKeys.ClientCAKey, "client-ca-key", "", "Path to client CA private key file")
+	flag.BoolVar(&s.Keys.CryptoKeys.RequireClientCert, "require-client-cert", false, "Reject requests without a device certificate")
+
 	// Флаги приватного и публичного ключей
 	flag.StringVar(&s.Keys.JWTKey, "jwt-key", "", "jwt key")
 
+	// Флаг секрета входа
+	flag.StringVar(&s.Keys.LoginSecret, "login-secret", "", "Secret deriving the login records of unknown logins")
+
+	// Флаги набора ключей JWT
+	flag.StringVar(&s.Keys.JWT.SigningKey, "jwt-signing-key", "", "Path to Ed25519 or ECDSA P-256 private key signing JWT")
+	flag.Func("jwt-previous-keys", "Comma separated paths to previous JWT keys, still accepted", func(value string) error {
+		s.Keys.JWT.PreviousKeys = splitList(value)
+		return nil
+	})
+	flag.StringVar(&s.Keys.JWT.Issuer, "jwt-issuer", "", "JWT issuer, required from tokens when set")
+	flag.StringVar(&s.Keys.JWT.Audience, "jwt-audience", "", "JWT audience, required from tokens when set")
+	flag.StringVar(&s.Keys.JWT.TTL, "jwt-ttl", "", "JWT lifetime. Example: \"24h\"")
+	flag.StringVar(&s.Keys.JWT.LegacyUntil, "jwt-legacy-until", "", "Time HS256 tokens are accepted until with the signing key. Example: \"2025-07-01T00:00:00Z\"")
+
+	// Флаги квот хранилища
+	flag.Int64Var(&s.Quotas.MaxBytes, "quota-bytes", 0, "Default storage quota of a user in bytes, 0 is unlimited")
+	flag.Int64Var(&s.Quotas.MaxItems, "quota-items", 0, "Default limit of the items of a user, 0 is unlimited")
+	flag.Int64Var(&s.Quotas.MaxItemSize, "quota-item-size", 0, "Default limit of the item size in bytes, 0 is unlimited")
+
+	// Флаг адреса метрик
+	flag.StringVar(&s.Metrics.Address, "metrics-address", "", "Host and port to serve Prometheus metrics on, disabled when empty")
+
+	// Флаги трассировки
+	flag.StringVar(&s.Tracing.Exporter, "tracing-exporter", "", "Exporter of the traces: \"stdout\" or \"otlp\", disabled when empty")
+	flag.StringVar(&s.Tracing.Endpoint, "tracing-endpoint", "", "OTLP gRPC collector endpoint. Example: \"localhost:4317\"")
+	flag.BoolVar(&s.Tracing.Insecure, "tracing-insecure", false, "Send the traces to the OTLP collector without TLS")
+
+	// Флаги gRPC сервера
+	flag.StringVar(&s.GRPC.DrainTimeout, "drain-timeout", "", "Time to finish the requests in flight on shutdown. Example: \"30s\"")
+	flag.StringVar(&s.GRPC.PreDrainDelay, "pre-drain-delay", "", "Time to report NOT_SERVING before draining on shutdown. Example: \"5s\"")
+	flag.BoolVar(&s.GRPC.Reflection, "grpc-reflection", false, "Enable gRPC server reflection for debugging")
+
 	// Флаг файла конфигурации
 	flag.StringVar(&s.ConfigFile, "config", "", "Config file")
 
@@ -150,10 +303,102 @@ func (s *ServerConfig) ParseEnv() error {
 		s.Keys.CryptoKeys.Certificate = certificate
 	}
 
+	if clientCA := os.Getenv("CLIENT_CA"); clientCA != "" {
+		s.Keys.CryptoKeys.ClientCA = clientCA
+	}
+
+	if clientCAKey := os.Getenv("CLIENT_CA_KEY"); clientCAKey != "" {
+		s.Keys.CryptoKeys.ClientCAKey = clientCAKey
+	}
+
+	if requireClientCert := os.Getenv("REQUIRE_CLIENT_CERT"); requireClientCert != "" {
+		if s.Keys.CryptoKeys.RequireClientCert, err = strconv.ParseBool(requireClientCert); err != nil {
+			return fmt.Errorf("error parsing REQUIRE_CLIENT_CERT: %w", err)
+		}
+	}
+
 	if jwtKey := os.Getenv("JWT_KEY"); jwtKey != "" {
 		s.Keys.JWTKey = jwtKey
 	}
 
+	if loginSecret := os.Getenv("LOGIN_SECRET"); loginSecret != "" {
+		s.Keys.LoginSecret = loginSecret
+	}
+
+	if signingKey := os.Getenv("JWT_SIGNING_KEY"); signingKey != "" {
+		s.Keys.JWT.SigningKey = signingKey
+	}
+
+	if previousKeys := os.Getenv("JWT_PREVIOUS_KEYS"); previousKeys != "" {
+		s.Keys.JWT.PreviousKeys = splitList(previousKeys)
+	}
+
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		s.Keys.JWT.Issuer = issuer
+	}
+
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		s.Keys.JWT.Audience = audience
+	}
+
+	if ttl := os.Getenv("JWT_TTL"); ttl != "" {
+		s.Keys.JWT.TTL = ttl
+	}
+
+	if legacyUntil := os.Getenv("JWT_LEGACY_UNTIL"); legacyUntil != "" {
+		s.Keys.JWT.LegacyUntil = legacyUntil
+	}
+
+	if maxBytes := os.Getenv("QUOTA_MAX_BYTES"); maxBytes != "" {
+		if s.Quotas.MaxBytes, err = strconv.ParseInt(maxBytes, 10, 64); err != nil {
+			return fmt.Errorf("error parsing QUOTA_MAX_BYTES: %w", err)
+		}
+	}
+
+	if maxItems := os.Getenv("QUOTA_MAX_ITEMS"); maxItems != "" {
+		if s.Quotas.MaxItems, err = strconv.ParseInt(maxItems, 10, 64); err != nil {
+			return fmt.Errorf("error parsing QUOTA_MAX_ITEMS: %w", err)
+		}
+	}
+
+	if maxItemSize := os.Getenv("QUOTA_MAX_ITEM_SIZE"); maxItemSize != "" {
+		if s.Quotas.MaxItemSize, err = strconv.ParseInt(maxItemSize, 10, 64); err != nil {
+			return fmt.Errorf("error parsing QUOTA_MAX_ITEM_SIZE: %w", err)
+		}
+	}
+
+	if metricsAddress := os.Getenv("METRICS_ADDRESS"); metricsAddress != "" {
+		s.Metrics.Address = metricsAddress
+	}
+
+	if tracingExporter := os.Getenv("TRACING_EXPORTER"); tracingExporter != "" {
+		s.Tracing.Exporter = tracingExporter
+	}
+
+	if tracingEndpoint := os.Getenv("TRACING_ENDPOINT"); tracingEndpoint != "" {
+		s.Tracing.Endpoint = tracingEndpoint
+	}
+
+	if tracingInsecure := os.Getenv("TRACING_INSECURE"); tracingInsecure != "" {
+		if s.Tracing.Insecure, err = strconv.ParseBool(tracingInsecure); err != nil {
+			return fmt.Errorf("error parsing TRACING_INSECURE: %w", err)
+		}
+	}
+
+	if drainTimeout := os.Getenv("DRAIN_TIMEOUT"); drainTimeout != "" {
+		s.GRPC.DrainTimeout = drainTimeout
+	}
+
+	if preDrainDelay := os.Getenv("PRE_DRAIN_DELAY"); preDrainDelay != "" {
+		s.GRPC.PreDrainDelay = preDrainDelay
+	}
+
+	if reflection := os.Getenv("GRPC_REFLECTION"); reflection != "" {
+		if s.GRPC.Reflection, err = strconv.ParseBool(reflection); err != nil {
+			return fmt.Errorf("error parsing GRPC_REFLECTION: %w", err)
+		}
+	}
+
 	if config := os.Getenv("CONFIG_FILE"); config != "" {
 		s.ConfigFile = config
 	}
@@ -186,6 +431,10 @@ func (s *ServerConfig) UnmarshalJSON(b []byte) error {
 		DB      *DB      `json:"db"`
 		Logger  *Logger  `json:"logger"`
 		Keys    *Keys    `json:"keys"`
+		Quotas  *Quotas  `json:"quotas"`
+		Metrics *Metrics `json:"metrics"`
+		Tracing *Tracing `json:"tracing"`
+		GRPC    *GRPC    `json:"grpc"`
 	}
 
 	if err = json.Unmarshal(b, &cfgFile); err != nil {
@@ -217,11 +466,95 @@ func (s *ServerConfig) UnmarshalJSON(b []byte) error {
 		s.Keys.CryptoKeys.Certificate = cfgFile.Keys.CryptoKeys.Certificate
 	}
 
+	// Client CA file parsing
+	if s.Keys.CryptoKeys.ClientCA == "" && cfgFile.Keys.CryptoKeys.ClientCA != "" {
+		s.Keys.CryptoKeys.ClientCA = cfgFile.Keys.CryptoKeys.ClientCA
+	}
+	if s.Keys.CryptoKeys.ClientCAKey == "" && cfgFile.Keys.CryptoKeys.ClientCAKey != "" {
+		s.Keys.CryptoKeys.ClientCAKey = cfgFile.Keys.CryptoKeys.ClientCAKey
+	}
+	if !s.Keys.CryptoKeys.RequireClientCert && cfgFile.Keys.CryptoKeys.RequireClientCert {
+		s.Keys.CryptoKeys.RequireClientCert = true
+	}
+
 	//JWT key file parsing
 	if s.Keys.JWTKey == "" && cfgFile.Keys.JWTKey != "" {
 		s.Keys.JWTKey = cfgFile.Keys.JWTKey
 	}
 
+	// Login secret file parsing
+	if s.Keys.LoginSecret == "" && cfgFile.Keys.LoginSecret != "" {
+		s.Keys.LoginSecret = cfgFile.Keys.LoginSecret
+	}
+
+	// JWT keyset file parsing
+	if cfgFile.Keys.JWT != nil {
+		if s.Keys.JWT.SigningKey == "" && cfgFile.Keys.JWT.SigningKey != "" {
+			s.Keys.JWT.SigningKey = cfgFile.Keys.JWT.SigningKey
+		}
+		if len(s.Keys.JWT.PreviousKeys) == 0 && len(cfgFile.Keys.JWT.PreviousKeys) != 0 {
+			s.Keys.JWT.PreviousKeys = cfgFile.Keys.JWT.PreviousKeys
+		}
+		if s.Keys.JWT.Issuer == "" && cfgFile.Keys.JWT.Issuer != "" {
+			s.Keys.JWT.Issuer = cfgFile.Keys.JWT.Issuer
+		}
+		if s.Keys.JWT.Audience == "" && cfgFile.Keys.JWT.Audience != "" {
+			s.Keys.JWT.Audience = cfgFile.Keys.JWT.Audience
+		}
+		if s.Keys.JWT.TTL == "" && cfgFile.Keys.JWT.TTL != "" {
+			s.Keys.JWT.TTL = cfgFile.Keys.JWT.TTL
+		}
+		if s.Keys.JWT.LegacyUntil == "" && cfgFile.Keys.JWT.LegacyUntil != "" {
+			s.Keys.JWT.LegacyUntil = cfgFile.Keys.JWT.LegacyUntil
+		}
+	}
+
+	// Quotas config file parsing
+	if cfgFile.Quotas != nil {
+		if s.Quotas.MaxBytes == 0 && cfgFile.Quotas.MaxBytes != 0 {
+			s.Quotas.MaxBytes = cfgFile.Quotas.MaxBytes
+		}
+		if s.Quotas.MaxItems == 0 && cfgFile.Quotas.MaxItems != 0 {
+			s.Quotas.MaxItems = cfgFile.Quotas.MaxItems
+		}
+		if s.Quotas.MaxItemSize == 0 && cfgFile.Quotas.MaxItemSize != 0 {
+			s.Quotas.MaxItemSize = cfgFile.Quotas.MaxItemSize
+		}
+	}
+
+	// Metrics config file parsing
+	if cfgFile.Metrics != nil {
+		if s.Metrics.Address == "" && cfgFile.Metrics.Address != "" {
+			s.Metrics.Address = cfgFile.Metrics.Address
+		}
+	}
+
+	// Tracing config file parsing
+	if cfgFile.Tracing != nil {
+		if s.Tracing.Exporter == "" && cfgFile.Tracing.Exporter != "" {
+			s.Tracing.Exporter = cfgFile.Tracing.Exporter
+		}
+		if s.Tracing.Endpoint == "" && cfgFile.Tracing.Endpoint != "" {
+			s.Tracing.Endpoint = cfgFile.Tracing.Endpoint
+		}
+		if !s.Tracing.Insecure && cfgFile.Tracing.Insecure {
+			s.Tracing.Insecure = true
+		}
+	}
+
+	// gRPC config file parsing
+	if cfgFile.GRPC != nil {
+		if s.GRPC.DrainTimeout == "" && cfgFile.GRPC.DrainTimeout != "" {
+			s.GRPC.DrainTimeout = cfgFile.GRPC.DrainTimeout
+		}
+		if s.GRPC.PreDrainDelay == "" && cfgFile.GRPC.PreDrainDelay != "" {
+			s.GRPC.PreDrainDelay = cfgFile.GRPC.PreDrainDelay
+		}
+		if !s.GRPC.Reflection && cfgFile.GRPC.Reflection {
+			s.GRPC.Reflection = true
+		}
+	}
+
 	// Logger config file parsing
 	if s.Logger.LogLevel == "" && cfgFile.Logger.LogLevel != "" {
 		s.Logger.LogLevel = cfgFile.Logger.LogLevel
@@ -234,8 +567,16 @@ func (s *ServerConfig) UnmarshalJSON(b []byte) error {
 }
 
 func (s *ServerConfig) Validate() error {
-	if s.Keys.JWTKey == "" {
-		return fmt.Errorf("JWT key is required")
+	if s.Keys.JWTKey == "" && s.Keys.JWT.SigningKey == "" {
+		return fmt.Errorf("JWT key or JWT signing key is required")
+	}
+
+	if _, err := s.Keys.JWT.TokenTTL(); err != nil {
+		return err
+	}
+
+	if _, err := s.Keys.JWT.LegacyDeadline(); err != nil {
+		return err
 	}
 
 	if s.Keys.CryptoKeys.PrivateKey == "" {
@@ -250,9 +591,96 @@ func (s *ServerConfig) Validate() error {
 		return fmt.Errorf("certificate is required")
 	}
 
+	// Клиентский УЦ задается сертификатом вместе с ключом
+	if (s.Keys.CryptoKeys.ClientCA == "") != (s.Keys.CryptoKeys.ClientCAKey == "") {
+		return fmt.Errorf("client CA certificate and key are required together")
+	}
+
+	if s.Keys.CryptoKeys.RequireClientCert && s.Keys.CryptoKeys.ClientCA == "" {
+		return fmt.Errorf("client CA is required to require client certificates")
+	}
+
+	if s.Quotas.MaxBytes < 0 || s.Quotas.MaxItems < 0 || s.Quotas.MaxItemSize < 0 {
+		return fmt.Errorf("quotas can't be negative")
+	}
+
+	if s.Metrics.Address != "" {
+		if _, _, err := net.SplitHostPort(s.Metrics.Address); err != nil {
+			return fmt.Errorf("invalid metrics address %q: %w", s.Metrics.Address, err)
+		}
+	}
+
+	if _, err := s.GRPC.Drain(); err != nil {
+		return err
+	}
+
+	if _, err := s.GRPC.PreDrain(); err != nil {
+		return err
+	}
+
+	if !tracing.ValidExporter(s.Tracing.Exporter) {
+		return fmt.Errorf("unknown tracing exporter %q", s.Tracing.Exporter)
+	}
+
 	return nil
 }
 
+// TokenTTL returns the configured lifetime of the tokens, or zero if it is not set.
+func (j *JWT) TokenTTL() (time.Duration, error) {
+	if j.TTL == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(j.TTL)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid JWT ttl %q", j.TTL)
+	}
+
+	return ttl, nil
+}
+
+// LegacyDeadline returns the time the legacy HS256 tokens are accepted until, or zero if it is not set.
+func (j *JWT) LegacyDeadline() (time.Time, error) {
+	if j.LegacyUntil == "" {
+		return time.Time{}, nil
+	}
+
+	deadline, err := time.Parse(time.RFC3339, j.LegacyUntil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid JWT legacy until %q", j.LegacyUntil)
+	}
+
+	return deadline, nil
+}
+
+// Drain returns the configured drain timeout of the gRPC server, or the default one if it is not set.
+func (g *GRPC) Drain() (time.Duration, error) {
+	if g.DrainTimeout == "" {
+		return defaultDrainTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(g.DrainTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid drain timeout %q", g.DrainTimeout)
+	}
+
+	return timeout, nil
+}
+
+// PreDrain returns the configured delay before draining the gRPC server, there is no delay if it is not set.
+func (g *GRPC) PreDrain() (time.Duration, error) {
+	if g.PreDrainDelay == "" {
+		return 0, nil
+	}
+
+	delay, err := time.ParseDuration(g.PreDrainDelay)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid pre-drain delay %q", g.PreDrainDelay)
+	}
+
+	return delay, nil
+}
+
 // String returns the Address in the "host:port" format.
 func (a *Address) String() string {
 	return a.Host + ":" + a.GRPCPort
@@ -291,17 +719,54 @@ func GetKeys() *Keys {
 	return cfg.Keys
 }
 
+// GetQuotas retrieves the default storage quota of the users.
+func GetQuotas() *Quotas {
+	return cfg.Quotas
+}
+
+// GetMetrics retrieves the configuration of the metrics listener.
+func GetMetrics() *Metrics {
+	return cfg.Metrics
+}
+
+// GetTracing retrieves the configuration of the tracing.
+func GetTracing() *Tracing {
+	return cfg.Tracing
+}
+
+// GetGRPC retrieves the serving options of the gRPC server.
+func GetGRPC() *GRPC {
+	return cfg.GRPC
+}
+
 // NewTestConfig initializes a new ServerConfig instance with default values and assigns it to the global cfg variable.
 func NewTestConfig() (*ServerConfig, error) {
 	config := &ServerConfig{
 		Address: &Address{},
 		Keys: &Keys{
 			CryptoKeys: &CryptoKeys{},
+			JWT:        &JWT{},
 		},
-		Logger: &Logger{},
-		DB:     &DB{},
+		Logger:  &Logger{},
+		DB:      &DB{},
+		Quotas:  &Quotas{},
+		Metrics: &Metrics{},
+		Tracing: &Tracing{},
+		GRPC:    &GRPC{},
 	}
 
 	cfg = config
 	return config, nil
 }
+
+// splitList splits the comma separated list, dropping the empty elements.
+func splitList(list string) []string {
+	var res []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -23,20 +24,35 @@ func TestParseEnv(t *testing.T) {
 		wantCert        string
 		wantPrivateKey  string
 		wantJWTKey      string
+		wantLoginSecret string
 		wantConfigFile  string
+		wantClientCA    string
+		wantClientCAKey string
+		wantRequireCert bool
+		wantJWT         *config.JWT
 	}{
 		{
 			name: "set env variables correctly",
 			args: args{
 				env: map[string]string{
-					"ADDRESS":      "127.0.0.1:9090",
-					"GRPC_PORT":    "8081",
-					"LOG_LEVEL":    "debug",
-					"DATABASE_DSN": "dsn_value",
-					"PRIVATE_KEY":  "./key.key",
-					"CERTIFICATE":  "./cert.crt",
-					"JWT_KEY":      "jwt",
-					"CONFIG_FILE":  "/tmp/config.json",
+					"ADDRESS":             "127.0.0.1:9090",
+					"GRPC_PORT":           "8081",
+					"LOG_LEVEL":           "debug",
+					"DATABASE_DSN":        "dsn_value",
+					"PRIVATE_KEY":         "./key.key",
+					"CERTIFICATE":         "./cert.crt",
+					"JWT_KEY":             "jwt",
+					"LOGIN_SECRET":        "login",
+					"CONFIG_FILE":         "/tmp/config.json",
+					"CLIENT_CA":           "./ca.crt",
+					"CLIENT_CA_KEY":       "./ca.key",
+					"REQUIRE_CLIENT_CERT": "true",
+					"JWT_SIGNING_KEY":     "./jwt.key",
+					"JWT_PREVIOUS_KEYS":   "./jwt-old.key, ./jwt-older.pub",
+					"JWT_ISSUER":          "gophkeeper",
+					"JWT_AUDIENCE":        "gophkeeper-clients",
+					"JWT_TTL":             "1h",
+					"JWT_LEGACY_UNTIL":    "2025-07-01T00:00:00Z",
 				},
 			},
 			wantAddressHost: "127.0.0.1",
@@ -46,7 +62,19 @@ func TestParseEnv(t *testing.T) {
 			wantCert:        "./cert.crt",
 			wantPrivateKey:  "./key.key",
 			wantJWTKey:      "jwt",
+			wantLoginSecret: "login",
 			wantConfigFile:  "/tmp/config.json",
+			wantClientCA:    "./ca.crt",
+			wantClientCAKey: "./ca.key",
+			wantRequireCert: true,
+			wantJWT: &config.JWT{
+				SigningKey:   "./jwt.key",
+				PreviousKeys: []string{"./jwt-old.key", "./jwt-older.pub"},
+				Issuer:       "gophkeeper",
+				Audience:     "gophkeeper-clients",
+				TTL:          "1h",
+				LegacyUntil:  "2025-07-01T00:00:00Z",
+			},
 		},
 	}
 
@@ -72,7 +100,12 @@ func TestParseEnv(t *testing.T) {
 			assert.Equal(t, tt.wantCert, cfg.Keys.CryptoKeys.Certificate)
 			assert.Equal(t, tt.wantPrivateKey, cfg.Keys.CryptoKeys.PrivateKey)
 			assert.Equal(t, tt.wantJWTKey, cfg.Keys.JWTKey)
+			assert.Equal(t, tt.wantLoginSecret, cfg.Keys.LoginSecret)
 			assert.Equal(t, tt.wantConfigFile, cfg.ConfigFile)
+			assert.Equal(t, tt.wantClientCA, cfg.Keys.CryptoKeys.ClientCA)
+			assert.Equal(t, tt.wantClientCAKey, cfg.Keys.CryptoKeys.ClientCAKey)
+			assert.Equal(t, tt.wantRequireCert, cfg.Keys.CryptoKeys.RequireClientCert)
+			assert.Equal(t, tt.wantJWT, cfg.Keys.JWT)
 		})
 	}
 }
@@ -94,6 +127,9 @@ func TestUnmarshalJSON(t *testing.T) {
 		wantCert        string
 		wantJWTKey      string
 		wantMigrations  string
+		wantClientCA    string
+		wantRequireCert bool
+		wantJWT         *config.JWT
 	}{
 		{
 			name: "correct JSON unmarshalling",
@@ -102,7 +138,8 @@ func TestUnmarshalJSON(t *testing.T) {
                     "address": {"host": "json_host", "grpc_port": "7777"},
                     "logger": {"log_level": "warn", "log_format": "text"},
                     "db": {"dsn": "json_dsn", "name": "json_db", "migrations_dir": "/json_migrations"},
-					"keys": {"crypto_keys": {"private_key": "./key.key","certificate": "./cert.crt"}, "jwt_key": "jwt"}
+					"keys": {"crypto_keys": {"private_key": "./key.key","certificate": "./cert.crt","client_ca": "./ca.crt","require_client_cert": true}, "jwt_key": "jwt",
+						"jwt": {"signing_key": "./jwt.key", "previous_keys": ["./jwt-old.pub"], "issuer": "gophkeeper", "ttl": "12h"}}
                 }`,
 			},
 			wantAddressHost: "json_host",
@@ -115,6 +152,29 @@ func TestUnmarshalJSON(t *testing.T) {
 			wantCert:        "./cert.crt",
 			wantJWTKey:      "jwt",
 			wantMigrations:  "/json_migrations",
+			wantClientCA:    "./ca.crt",
+			wantRequireCert: true,
+			wantJWT: &config.JWT{
+				SigningKey:   "./jwt.key",
+				PreviousKeys: []string{"./jwt-old.pub"},
+				Issuer:       "gophkeeper",
+				TTL:          "12h",
+			},
+		},
+		{
+			name: "without JWT keyset",
+			args: args{
+				jsonData: `{
+                    "address": {"host": "json_host", "grpc_port": "7777"},
+                    "logger": {},
+                    "db": {},
+					"keys": {"crypto_keys": {}, "jwt_key": "jwt"}
+                }`,
+			},
+			wantAddressHost: "json_host",
+			wantGRPCPort:    "7777",
+			wantJWTKey:      "jwt",
+			wantJWT:         &config.JWT{},
 		},
 	}
 
@@ -135,6 +195,9 @@ func TestUnmarshalJSON(t *testing.T) {
 			assert.Equal(t, tt.wantCert, cfg.Keys.CryptoKeys.Certificate)
 			assert.Equal(t, tt.wantPrivateKey, cfg.Keys.CryptoKeys.PrivateKey)
 			assert.Equal(t, tt.wantJWTKey, cfg.Keys.JWTKey)
+			assert.Equal(t, tt.wantClientCA, cfg.Keys.CryptoKeys.ClientCA)
+			assert.Equal(t, tt.wantRequireCert, cfg.Keys.CryptoKeys.RequireClientCert)
+			assert.Equal(t, tt.wantJWT, cfg.Keys.JWT)
 		})
 	}
 }
@@ -219,6 +282,80 @@ func TestInitConfigFile(t *testing.T) {
 	}
 }
 
+func TestInitDB(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config*.json")
+	assert.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write([]byte(`{
+		"address": {"host": "file_host", "grpc_port": "8888"},
+		"logger": {"log_level": "error"},
+		"db": {"dsn": "file_dsn", "migrations_dir": "/file_migrations"},
+		"keys": {"crypto_keys": {"private_key": "./key.key", "certificate": "./cert.crt"}, "jwt_key": "jwt"}
+	}`))
+	assert.NoError(t, err)
+	assert.NoError(t, tmpFile.Close())
+
+	type args struct {
+		db         *config.DB
+		configFile string
+		env        map[string]string
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantDSN           string
+		wantMigrationsDir string
+		expectError       bool
+	}{
+		{
+			name:              "flags take priority over config file",
+			args:              args{db: &config.DB{DSN: "flag_dsn"}, configFile: tmpFile.Name()},
+			wantDSN:           "flag_dsn",
+			wantMigrationsDir: "/file_migrations",
+		},
+		{
+			name:              "environment overrides config file",
+			args:              args{db: &config.DB{}, configFile: tmpFile.Name(), env: map[string]string{"DATABASE_DSN": "env_dsn"}},
+			wantDSN:           "env_dsn",
+			wantMigrationsDir: "/file_migrations",
+		},
+		{
+			name:              "config file from environment",
+			args:              args{db: &config.DB{}, env: map[string]string{"CONFIG_FILE": tmpFile.Name()}},
+			wantDSN:           "file_dsn",
+			wantMigrationsDir: "/file_migrations",
+		},
+		{
+			name:        "missing DSN",
+			args:        args{db: &config.DB{}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Переменные окружения других тестов не должны влиять на результат
+			for _, k := range []string{"DATABASE_DSN", "MIGRATIONS_DIR", "CONFIG_FILE"} {
+				t.Setenv(k, "")
+			}
+			for k, v := range tt.args.env {
+				t.Setenv(k, v)
+			}
+
+			db, err := config.InitDB(tt.args.db, tt.args.configFile)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDSN, db.DSN)
+			assert.Equal(t, tt.wantMigrationsDir, db.MigrationsDir)
+		})
+	}
+}
+
 func TestAddress_SetAndString(t *testing.T) {
 	type args struct {
 		value string
@@ -259,3 +396,341 @@ func TestAddress_SetAndString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_ClientCA(t *testing.T) {
+	keyFile, err := os.CreateTemp("", "private*.key")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	assert.NoError(t, keyFile.Close())
+
+	tests := []struct {
+		name        string
+		clientCA    string
+		clientCAKey string
+		requireCert bool
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{name: "without client CA", wantErr: assert.NoError},
+		{name: "client CA with key", clientCA: "./ca.crt", clientCAKey: "./ca.key", wantErr: assert.NoError},
+		{name: "required client cert", clientCA: "./ca.crt", clientCAKey: "./ca.key", requireCert: true, wantErr: assert.NoError},
+		{name: "client CA without key", clientCA: "./ca.crt", wantErr: assert.Error},
+		{name: "key without client CA", clientCAKey: "./ca.key", wantErr: assert.Error},
+		{name: "required client cert without CA", requireCert: true, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewTestConfig()
+			if err != nil {
+				panic(err)
+			}
+
+			cfg.Keys.JWTKey = "jwt"
+			cfg.Keys.CryptoKeys.PrivateKey = keyFile.Name()
+			cfg.Keys.CryptoKeys.Certificate = "./cert.crt"
+			cfg.Keys.CryptoKeys.ClientCA = tt.clientCA
+			cfg.Keys.CryptoKeys.ClientCAKey = tt.clientCAKey
+			cfg.Keys.CryptoKeys.RequireClientCert = tt.requireCert
+
+			tt.wantErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestValidate_JWT(t *testing.T) {
+	keyFile, err := os.CreateTemp("", "private*.key")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	assert.NoError(t, keyFile.Close())
+
+	tests := []struct {
+		name       string
+		jwtKey     string
+		signingKey string
+		ttl        string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{name: "legacy secret", jwtKey: "jwt", wantErr: assert.NoError},
+		{name: "signing key", signingKey: "./jwt.key", ttl: "30m", wantErr: assert.NoError},
+		{name: "no keys", wantErr: assert.Error},
+		{name: "invalid ttl", jwtKey: "jwt", ttl: "day", wantErr: assert.Error},
+		{name: "negative ttl", jwtKey: "jwt", ttl: "-1h", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewTestConfig()
+			if err != nil {
+				panic(err)
+			}
+
+			cfg.Keys.JWTKey = tt.jwtKey
+			cfg.Keys.JWT.SigningKey = tt.signingKey
+			cfg.Keys.JWT.TTL = tt.ttl
+			cfg.Keys.CryptoKeys.PrivateKey = keyFile.Name()
+			cfg.Keys.CryptoKeys.Certificate = "./cert.crt"
+
+			tt.wantErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestValidate_Quotas(t *testing.T) {
+	keyFile, err := os.CreateTemp("", "private*.key")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	assert.NoError(t, keyFile.Close())
+
+	tests := []struct {
+		name    string
+		quotas  config.Quotas
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "unlimited", wantErr: assert.NoError},
+		{name: "limited", quotas: config.Quotas{MaxBytes: 1 << 30, MaxItems: 1000, MaxItemSize: 30 << 20}, wantErr: assert.NoError},
+		{name: "negative bytes", quotas: config.Quotas{MaxBytes: -1}, wantErr: assert.Error},
+		{name: "negative items", quotas: config.Quotas{MaxItems: -1}, wantErr: assert.Error},
+		{name: "negative item size", quotas: config.Quotas{MaxItemSize: -1}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewTestConfig()
+			if err != nil {
+				panic(err)
+			}
+
+			cfg.Keys.JWTKey = "jwt"
+			cfg.Keys.CryptoKeys.PrivateKey = keyFile.Name()
+			cfg.Keys.CryptoKeys.Certificate = "./cert.crt"
+			*cfg.Quotas = tt.quotas
+
+			tt.wantErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestInitConfigFile_Quotas(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config*.json")
+	assert.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write([]byte(`{
+		"address": {"host": "file_host", "grpc_port": "8888"},
+		"logger": {"log_level": "error"},
+		"db": {"dsn": "file_dsn"},
+		"keys": {"crypto_keys": {"private_key": "./key.key", "certificate": "./cert.crt"}, "jwt_key": "jwt"},
+		"quotas": {"max_bytes": 1073741824, "max_items": 1000, "max_item_size": 31457280}
+	}`))
+	assert.NoError(t, err)
+	assert.NoError(t, tmpFile.Close())
+
+	cfg, err := config.NewTestConfig()
+	if err != nil {
+		panic(err)
+	}
+	cfg.ConfigFile = tmpFile.Name()
+	cfg.Quotas.MaxItems = 10
+
+	assert.NoError(t, cfg.InitConfigFile())
+	assert.Equal(t, config.Quotas{MaxBytes: 1 << 30, MaxItems: 10, MaxItemSize: 30 << 20}, *cfg.Quotas)
+}
+
+func TestValidate_Metrics(t *testing.T) {
+	keyFile, err := os.CreateTemp("", "private*.key")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	assert.NoError(t, keyFile.Close())
+
+	tests := []struct {
+		name    string
+		address string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "disabled", wantErr: assert.NoError},
+		{name: "port only", address: ":9090", wantErr: assert.NoError},
+		{name: "host and port", address: "localhost:9090", wantErr: assert.NoError},
+		{name: "no port", address: "localhost", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewTestConfig()
+			if err != nil {
+				panic(err)
+			}
+
+			cfg.Keys.JWTKey = "jwt"
+			cfg.Keys.CryptoKeys.PrivateKey = keyFile.Name()
+			cfg.Keys.CryptoKeys.Certificate = "./cert.crt"
+			cfg.Metrics.Address = tt.address
+
+			tt.wantErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestInitConfigFile_Metrics(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config*.json")
+	assert.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write([]byte(`{
+		"address": {"host": "file_host", "grpc_port": "8888"},
+		"logger": {"log_level": "error"},
+		"db": {"dsn": "file_dsn"},
+		"keys": {"crypto_keys": {"private_key": "./key.key", "certificate": "./cert.crt"}, "jwt_key": "jwt"},
+		"metrics": {"address": ":9090"}
+	}`))
+	assert.NoError(t, err)
+	assert.NoError(t, tmpFile.Close())
+
+	cfg, err := config.NewTestConfig()
+	if err != nil {
+		panic(err)
+	}
+	cfg.ConfigFile = tmpFile.Name()
+
+	assert.NoError(t, cfg.InitConfigFile())
+	assert.Equal(t, ":9090", cfg.Metrics.Address)
+}
+
+func TestValidate_Tracing(t *testing.T) {
+	keyFile, err := os.CreateTemp("", "private*.key")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	assert.NoError(t, keyFile.Close())
+
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{name: "disabled", wantErr: assert.NoError},
+		{name: "stdout", exporter: "stdout", wantErr: assert.NoError},
+		{name: "otlp", exporter: "otlp", wantErr: assert.NoError},
+		{name: "unknown", exporter: "jaeger", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.NewTestConfig()
+			if err != nil {
+				panic(err)
+			}
+
+			cfg.Keys.JWTKey = "jwt"
+			cfg.Keys.CryptoKeys.PrivateKey = keyFile.Name()
+			cfg.Keys.CryptoKeys.Certificate = "./cert.crt"
+			cfg.Tracing.Exporter = tt.exporter
+
+			tt.wantErr(t, cfg.Validate())
+		})
+	}
+}
+
+func TestParseEnv_Tracing(t *testing.T) {
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("TRACING_ENDPOINT", "collector:4317")
+	t.Setenv("TRACING_INSECURE", "true")
+
+	cfg, err := config.NewTestConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	assert.NoError(t, cfg.ParseEnv())
+	assert.Equal(t, config.Tracing{Exporter: "otlp", Endpoint: "collector:4317", Insecure: true}, *cfg.Tracing)
+
+	t.Setenv("TRACING_INSECURE", "maybe")
+	assert.Error(t, cfg.ParseEnv())
+}
+
+func TestJWT_LegacyDeadline(t *testing.T) {
+	tests := []struct {
+		name        string
+		legacyUntil string
+		want        time.Time
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{name: "not set", wantErr: assert.NoError},
+		{name: "configured", legacyUntil: "2025-07-01T00:00:00Z", want: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), wantErr: assert.NoError},
+		{name: "invalid", legacyUntil: "2025-07-01", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtConfig := &config.JWT{LegacyUntil: tt.legacyUntil}
+
+			got, err := jwtConfig.LegacyDeadline()
+			tt.wantErr(t, err)
+			assert.True(t, tt.want.Equal(got))
+		})
+	}
+}
+
+func TestGRPC_Drain(t *testing.T) {
+	tests := []struct {
+		name         string
+		drainTimeout string
+		want         time.Duration
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{name: "default", want: 30 * time.Second, wantErr: assert.NoError},
+		{name: "configured", drainTimeout: "1m", want: time.Minute, wantErr: assert.NoError},
+		{name: "invalid", drainTimeout: "soon", wantErr: assert.Error},
+		{name: "negative", drainTimeout: "-5s", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grpcConfig := &config.GRPC{DrainTimeout: tt.drainTimeout}
+
+			got, err := grpcConfig.Drain()
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGRPC_PreDrain(t *testing.T) {
+	tests := []struct {
+		name          string
+		preDrainDelay string
+		want          time.Duration
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{name: "default", want: 0, wantErr: assert.NoError},
+		{name: "configured", preDrainDelay: "5s", want: 5 * time.Second, wantErr: assert.NoError},
+		{name: "disabled", preDrainDelay: "0s", want: 0, wantErr: assert.NoError},
+		{name: "invalid", preDrainDelay: "soon", wantErr: assert.Error},
+		{name: "negative", preDrainDelay: "-5s", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grpcConfig := &config.GRPC{PreDrainDelay: tt.preDrainDelay}
+
+			got, err := grpcConfig.PreDrain()
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseEnv_GRPC(t *testing.T) {
+	t.Setenv("DRAIN_TIMEOUT", "45s")
+	t.Setenv("PRE_DRAIN_DELAY", "5s")
+	t.Setenv("GRPC_REFLECTION", "true")
+
+	cfg, err := config.NewTestConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	assert.NoError(t, cfg.ParseEnv())
+	assert.Equal(t, config.GRPC{DrainTimeout: "45s", PreDrainDelay: "5s", Reflection: true}, *cfg.GRPC)
+
+	t.Setenv("GRPC_REFLECTION", "maybe")
+	assert.Error(t, cfg.ParseEnv())
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// emergencyReleaseInterval is the period of checking the emergency access requests with a passed waiting period.
+const emergencyReleaseInterval = time.Minute
+
+// emergencyReleaser periodically approves the emergency access requests the owners have not rejected
+// within the waiting period, releasing the sealed vault keys to the trusted contacts.
+type emergencyReleaser struct {
+	store    emergencyReleaseStore
+	interval time.Duration
+}
+
+// emergencyReleaseStore defines a contract for approving the emergency access requests with a passed waiting period.
+type emergencyReleaseStore interface {
+	ReleaseEmergencyAccess(context.Context, time.Time) (int64, error)
+}
+
+// newEmergencyReleaser initializes and returns a new instance of emergencyReleaser with the provided store.
+func newEmergencyReleaser(store emergencyReleaseStore) *emergencyReleaser {
+	return &emergencyReleaser{
+		store:    store,
+		interval: emergencyReleaseInterval,
+	}
+}
+
+// Run releases the emergency access on every tick until the context is done.
+func (r *emergencyReleaser) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.release(ctx, now)
+		}
+	}
+}
+
+// release approves the requests with the waiting period passed by the moment and logs their count.
+func (r *emergencyReleaser) release(ctx context.Context, now time.Time) {
+	released, err := r.store.ReleaseEmergencyAccess(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not release emergency access", slog.String("error", err.Error()))
+		return
+	}
+
+	if released > 0 {
+		slog.Info("released emergency access", slog.Int64("count", released))
+	}
+}
@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/grpc/handlers"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
+)
+
+// auditRecorder defines the contract for appending an event to the audit log of its user.
+type auditRecorder interface {
+	SaveAuditEvent(context.Context, *domain.AuditEvent) error
+}
+
+// auditActions maps the methods recorded in the audit log to their actions. PostItemData is recorded
+// as an update when the request names the stored item.
+var auditActions = map[string]string{
+	pb.UserHandlers_PostUserData_FullMethodName:                domain.AuditLogin,
+	pb.UserHandlers_FinishLogin_FullMethodName:                 domain.AuditLogin,
+	pb.UserHandlers_ChangePassword_FullMethodName:              domain.AuditPasswordChange,
+	pb.UserHandlers_RecoverAccount_FullMethodName:              domain.AuditAccountRecovery,
+	pb.UserHandlers_PostRecoveryKit_FullMethodName:             domain.AuditRecoveryKitCreate,
+	pb.ItemDataHandlers_GetItemData_FullMethodName:             domain.AuditItemRead,
+	pb.ItemDataHandlers_PostItemData_FullMethodName:            domain.AuditItemCreate,
+	pb.MetaDataHandlers_DeleteMetaData_FullMethodName:          domain.AuditItemDelete,
+	pb.SharingHandlers_ShareItem_FullMethodName:                domain.AuditShareCreate,
+	pb.SharingHandlers_RevokeShare_FullMethodName:              domain.AuditShareRevoke,
+	pb.SharingHandlers_GetSharedItem_FullMethodName:            domain.AuditSharedItemRead,
+	pb.SharingHandlers_UpdateSharedItem_FullMethodName:         domain.AuditSharedItemUpdate,
+	pb.OrgHandlers_GetCollectionItem_FullMethodName:            domain.AuditCollectionRead,
+	pb.OrgHandlers_PostCollectionItem_FullMethodName:           domain.AuditCollectionWrite,
+	pb.OrgHandlers_DeleteCollectionItem_FullMethodName:         domain.AuditCollectionDelete,
+	pb.EmergencyHandlers_RequestEmergencyAccess_FullMethodName: domain.AuditEmergencyRequest,
+	pb.EmergencyHandlers_AnswerEmergencyAccess_FullMethodName:  domain.AuditEmergencyAnswer,
+	pb.EmergencyHandlers_GetEmergencyItem_FullMethodName:       domain.AuditEmergencyItemRead,
+	pb.DeviceHandlers_EnrollDevice_FullMethodName:              domain.AuditDeviceEnroll,
+	pb.DeviceHandlers_RevokeDevice_FullMethodName:              domain.AuditDeviceRevoke,
+	pb.APITokenHandlers_CreateAPIToken_FullMethodName:          domain.AuditAPITokenCreate,
+	pb.APITokenHandlers_RevokeAPIToken_FullMethodName:          domain.AuditAPITokenRevoke,
+}
+
+// withAudit is a gRPC interceptor that appends the requests of the methods of auditActions to the audit log
+// of the user, the failed ones included. The user is the authenticated one, or the account the login acts on.
+// The requests of the unknown accounts are not recorded, a failure to record the event is logged only.
+func (g *GRPCServer) withAudit(ctx context.Context, req any,
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	action, ok := auditActions[info.FullMethod]
+	if !ok || g.auditRecorder == nil {
+		return handler(ctx, req)
+	}
+
+	ctx, subject := handlers.ContextWithAuditSubject(ctx)
+	resp, err = handler(ctx, req)
+
+	event := &domain.AuditEvent{
+		UserID:  subject.UserID,
+		Action:  action,
+		Success: err == nil,
+		ItemID:  auditItemID(req, resp),
+		Created: time.Now(),
+	}
+
+	if userID, ok := handlers.UserIDFromContext(ctx); ok {
+		if event.UserID, ok = parseAuditUser(userID); !ok {
+			return resp, err
+		}
+	}
+	if event.UserID == uuid.Nil {
+		return resp, err
+	}
+
+	if action == domain.AuditItemCreate {
+		if request, ok := req.(*pb.PostItemDataRequest); ok && request.GetDataId() != "" {
+			event.Action = domain.AuditItemUpdate
+		}
+	}
+
+	if err != nil {
+		event.Details = status.Code(err).String()
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.Peer = p.Addr.String()
+	}
+
+	if cert := peerCertificate(ctx); cert != nil {
+		if _, deviceID, identityErr := pki.DeviceIdentity(cert); identityErr == nil {
+			event.DeviceID = deviceID
+		}
+	}
+
+	if token, ok := handlers.APITokenFromContext(ctx); ok {
+		event.APITokenID = token.ID
+	}
+
+	if recordErr := g.auditRecorder.SaveAuditEvent(ctx, event); recordErr != nil {
+		slog.ErrorContext(ctx, "could not record audit event", slog.String("action", event.Action),
+			slog.String("error", recordErr.Error()))
+	}
+
+	return resp, err
+}
+
+// parseAuditUser parses the ID of the authenticated user.
+func parseAuditUser(userID string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(userID)
+	return id, err == nil
+}
+
+// auditItemID returns the ID of the object the request is about: the item, the share, the token, the device
+// or the emergency access named by the request, or the ID of the object created by it.
+func auditItemID(req any, resp any) string {
+	for _, v := range []any{req, resp} {
+		if id := messageID(v); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// messageID returns the first ID set among the fields of the message naming an object.
+func messageID(message any) string {
+	var ids []string
+
+	if m, ok := message.(interface{ GetDataId() string }); ok {
+		ids = append(ids, m.GetDataId())
+	}
+	if m, ok := message.(interface{ GetShareId() string }); ok {
+		ids = append(ids, m.GetShareId())
+	}
+	if m, ok := message.(interface{ GetItemId() string }); ok {
+		ids = append(ids, m.GetItemId())
+	}
+	if m, ok := message.(interface{ GetTokenId() string }); ok {
+		ids = append(ids, m.GetTokenId())
+	}
+	if m, ok := message.(interface{ GetDeviceId() string }); ok {
+		ids = append(ids, m.GetDeviceId())
+	}
+	if m, ok := message.(interface{ GetAccessId() string }); ok {
+		ids = append(ids, m.GetAccessId())
+	}
+	if m, ok := message.(interface{ GetItem() *pb.CollectionItem }); ok {
+		ids = append(ids, m.GetItem().GetId())
+	}
+	if m, ok := message.(interface{ GetShare() *pb.Share }); ok {
+		ids = append(ids, m.GetShare().GetId())
+	}
+	if m, ok := message.(interface{ GetToken() *pb.APIToken }); ok {
+		ids = append(ids, m.GetToken().GetId())
+	}
+	if m, ok := message.(interface{ GetDevice() *pb.Device }); ok {
+		ids = append(ids, m.GetDevice().GetId())
+	}
+
+	for _, v := range ids {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
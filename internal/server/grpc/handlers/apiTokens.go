@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// APITokenPrefix starts every API token, it tells the API tokens from the JWTs in the authorization header.
+const APITokenPrefix = "gkp_"
+
+const (
+	apiTokenSecretSize      = 32
+	defaultAPITokenTTLDays  = 90
+	apiTokenLastUsedRefresh = time.Minute
+)
+
+// APITokenHandler manages the personal API tokens the users create for the automation and implements
+// the gRPC APITokenHandlersServer interface. The token is APITokenPrefix followed by the base64url encoded
+// token ID and secret, the server keeps the SHA-256 of the secret only.
+type APITokenHandler struct {
+	pb.UnimplementedAPITokenHandlersServer
+	apiTokenStore  apiTokenStore
+	folderProvider folderProvider
+}
+
+// apiTokenStore defines a contract for storing, finding and revoking the API tokens of the users.
+type apiTokenStore interface {
+	SaveAPIToken(context.Context, *domain.APIToken) error
+	GetAPITokenByID(context.Context, uuid.UUID) (*domain.APIToken, error)
+	GetAPITokensByUser(context.Context, uuid.UUID) ([]*domain.APIToken, error)
+	UpdateAPITokenUsed(context.Context, uuid.UUID, time.Time) error
+	DeleteAPIToken(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+// NewAPITokenHandler initializes and returns a new instance of APITokenHandler with the provided store
+// and the folder provider checking the folders of the token scope.
+func NewAPITokenHandler(apiTokenStore apiTokenStore, folderProvider folderProvider) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenStore:  apiTokenStore,
+		folderProvider: folderProvider,
+	}
+}
+
+// CreateAPIToken creates the API token of the user with the scope and the lifetime of the request
+// and returns its secret, which is not shown again.
+func (h *APITokenHandler) CreateAPIToken(ctx context.Context, request *pb.CreateAPITokenRequest) (*pb.CreateAPITokenResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	ttlDays := request.GetTtlDays()
+	if ttlDays == 0 {
+		ttlDays = defaultAPITokenTTLDays
+	}
+
+	now := time.Now()
+	token := &domain.APIToken{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Name:    strings.TrimSpace(request.GetName()),
+		Expires: now.AddDate(0, 0, int(ttlDays)),
+		Created: now,
+		Scope: domain.APITokenScope{
+			ReadOnly:  request.GetReadOnly(),
+			DataTypes: request.GetDataTypes(),
+		},
+	}
+
+	for _, v := range request.GetFolderIds() {
+		folderID, err := uuid.Parse(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid folder id %s", v)
+		}
+		token.Scope.FolderIDs = append(token.Scope.FolderIDs, folderID)
+	}
+
+	if err = domain.ValidateAPIToken(token, now); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = h.checkFolders(ctx, userID, token.Scope.FolderIDs); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, apiTokenSecretSize)
+	if _, err = rand.Read(secret); err != nil {
+		slog.ErrorContext(ctx, "could not generate api token", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	hash := sha256.Sum256(secret)
+	token.Hash = hash[:]
+
+	if err = h.apiTokenStore.SaveAPIToken(ctx, token); err != nil {
+		if errors.Is(err, domain.ErrAPITokenExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save api token", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CreateAPITokenResponse{
+		Token:  apiTokenToProto(token),
+		Secret: APITokenPrefix + base64.RawURLEncoding.EncodeToString(append(token.ID[:], secret...)),
+	}, nil
+}
+
+// GetAPITokens returns the API tokens of the user, the expired ones included.
+func (h *APITokenHandler) GetAPITokens(ctx context.Context, request *pb.GetAPITokensRequest) (*pb.GetAPITokensResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := h.apiTokenStore.GetAPITokensByUser(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get api tokens", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.GetAPITokensResponse{Tokens: make([]*pb.APIToken, len(tokens))}
+	for i, v := range tokens {
+		resp.Tokens[i] = apiTokenToProto(v)
+	}
+
+	return resp, nil
+}
+
+// RevokeAPIToken deletes the API token of the user, it is rejected from now on.
+func (h *APITokenHandler) RevokeAPIToken(ctx context.Context, request *pb.RevokeAPITokenRequest) (*pb.RevokeAPITokenResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID, err := uuid.Parse(request.GetTokenId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid token id %s", request.GetTokenId())
+	}
+
+	if err = h.apiTokenStore.DeleteAPIToken(ctx, userID, tokenID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "api token %s not found", tokenID)
+		}
+		slog.ErrorContext(ctx, "could not revoke api token", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeAPITokenResponse{}, nil
+}
+
+// Authenticate finds the API token by the ID encoded in it and checks its secret against the stored hash and its expiry.
+// The last use time of the token is updated at most once a minute.
+func (h *APITokenHandler) Authenticate(ctx context.Context, raw string, now time.Time) (*domain.APIToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(raw, APITokenPrefix))
+	if err != nil || !strings.HasPrefix(raw, APITokenPrefix) || len(decoded) != len(uuid.UUID{})+apiTokenSecretSize {
+		return nil, fmt.Errorf("malformed api token")
+	}
+
+	tokenID, err := uuid.FromBytes(decoded[:len(uuid.UUID{})])
+	if err != nil {
+		return nil, fmt.Errorf("malformed api token")
+	}
+
+	token, err := h.apiTokenStore.GetAPITokenByID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("api token %s not found", tokenID)
+		}
+		return nil, fmt.Errorf("could not get api token: %w", err)
+	}
+
+	hash := sha256.Sum256(decoded[len(uuid.UUID{}):])
+	if subtle.ConstantTimeCompare(hash[:], token.Hash) != 1 {
+		return nil, fmt.Errorf("api token %s secret mismatch", tokenID)
+	}
+
+	if token.IsExpired(now) {
+		return nil, fmt.Errorf("api token %s has expired", tokenID)
+	}
+
+	if now.Sub(token.LastUsed) >= apiTokenLastUsedRefresh {
+		if err = h.apiTokenStore.UpdateAPITokenUsed(ctx, token.ID, now); err != nil {
+			slog.ErrorContext(ctx, "could not update api token last use", slog.String("error", err.Error()))
+		}
+		token.LastUsed = now
+	}
+
+	return token, nil
+}
+
+// checkFolders checks the folders of the token scope are among the folders of the user.
+func (h *APITokenHandler) checkFolders(ctx context.Context, userID uuid.UUID, folderIDs []uuid.UUID) error {
+	if len(folderIDs) == 0 {
+		return nil
+	}
+
+	folders, err := h.folderProvider.GetFoldersByUser(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get folders", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	owned := make(map[uuid.UUID]bool, len(folders))
+	for _, v := range folders {
+		owned[v.ID] = true
+	}
+
+	for _, v := range folderIDs {
+		if !owned[v] {
+			return status.Errorf(codes.InvalidArgument, "folder %s not found", v)
+		}
+	}
+
+	return nil
+}
+
+// apiTokenToProto converts the API token into its gRPC representation without the hash.
+func apiTokenToProto(token *domain.APIToken) *pb.APIToken {
+	res := &pb.APIToken{
+		Id:        token.ID.String(),
+		Name:      token.Name,
+		ReadOnly:  token.Scope.ReadOnly,
+		DataTypes: token.Scope.DataTypes,
+		Expires:   token.Expires.Format(time.RFC3339),
+		Created:   token.Created.Format(time.RFC3339),
+	}
+	for _, v := range token.Scope.FolderIDs {
+		res.FolderIds = append(res.FolderIds, v.String())
+	}
+	if !token.LastUsed.IsZero() {
+		res.LastUsed = token.LastUsed.Format(time.RFC3339)
+	}
+
+	return res
+}
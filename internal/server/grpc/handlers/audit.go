@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+// AuditHandler lists the audit log of the user and implements the gRPC AuditHandlersServer interface.
+// The events are recorded by the server interceptor, the handler only reads them.
+type AuditHandler struct {
+	pb.UnimplementedAuditHandlersServer
+	auditProvider auditProvider
+}
+
+// auditProvider defines a contract for retrieving the audit events of a user narrowed down by a filter.
+type auditProvider interface {
+	GetAuditEvents(context.Context, uuid.UUID, *domain.AuditFilter) ([]*domain.AuditEvent, error)
+}
+
+// NewAuditHandler initializes and returns a new instance of AuditHandler with the provided audit provider.
+func NewAuditHandler(auditProvider auditProvider) *AuditHandler {
+	return &AuditHandler{
+		auditProvider: auditProvider,
+	}
+}
+
+// ListAuditEvents returns a page of the audit events of the user from the latest, narrowed down by the action,
+// the item and the time range of the request when they are set. The next_page_token of the response requests
+// the following page and is empty on the last one.
+func (h *AuditHandler) ListAuditEvents(ctx context.Context, request *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(request.GetPageSize())
+	switch {
+	case pageSize < 0 || pageSize > maxAuditPageSize:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 0 and %d", maxAuditPageSize)
+	case pageSize == 0:
+		pageSize = defaultAuditPageSize
+	}
+
+	filter := &domain.AuditFilter{
+		Action: request.GetAction(),
+		ItemID: request.GetItemId(),
+		Limit:  pageSize + 1,
+	}
+
+	if request.GetPageToken() != "" {
+		if filter.BeforeSeq, err = strconv.ParseInt(request.GetPageToken(), 10, 64); err != nil || filter.BeforeSeq <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token %s", request.GetPageToken())
+		}
+	}
+
+	if request.GetSince() != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, request.GetSince()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid since %s", request.GetSince())
+		}
+	}
+
+	if request.GetUntil() != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, request.GetUntil()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid until %s", request.GetUntil())
+		}
+	}
+
+	events, err := h.auditProvider.GetAuditEvents(ctx, userID, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get audit events", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListAuditEventsResponse{}
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		resp.NextPageToken = strconv.FormatInt(events[pageSize-1].Seq, 10)
+	}
+
+	resp.Events = make([]*pb.AuditEvent, len(events))
+	for i, v := range events {
+		resp.Events[i] = auditEventToProto(v)
+	}
+
+	return resp, nil
+}
+
+// auditEventToProto converts the audit event into its gRPC representation. The creation time keeps the microseconds,
+// so the client recomputes the hash of the event.
+func auditEventToProto(event *domain.AuditEvent) *pb.AuditEvent {
+	res := &pb.AuditEvent{
+		UserId:   event.UserID.String(),
+		Seq:      event.Seq,
+		Action:   event.Action,
+		Success:  event.Success,
+		ItemId:   event.ItemID,
+		Peer:     event.Peer,
+		Details:  event.Details,
+		Created:  event.Created.UTC().Format(time.RFC3339Nano),
+		PrevHash: event.PrevHash,
+		Hash:     event.Hash,
+	}
+	if event.DeviceID != uuid.Nil {
+		res.DeviceId = event.DeviceID.String()
+	}
+	if event.APITokenID != uuid.Nil {
+		res.ApiTokenId = event.APITokenID.String()
+	}
+
+	return res
+}
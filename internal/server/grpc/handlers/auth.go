@@ -2,12 +2,13 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
 	"errors"
 	"log/slog"
 	"time"
 
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
@@ -15,44 +16,103 @@ import (
 
 	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
 	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
-	"github.com/mikhaylov123ty/GophKeeper/internal/server/config"
 )
 
 // AuthHandler handles user authentication and implements the gRPC UserHandlersServer interface.
-// It relies on userCreator to save user data and userProvider to retrieve user details.
+// It relies on userCreator to save user data and userProvider to retrieve user details,
+// vaultKeyCreator and vaultKeyProvider keep the wrapped vault keys of the users, passwordUpdater changes the passwords,
+// recoveryKitCreator stores the recovery kits and srpRecordUpdater upgrades the legacy accounts to the SRP login.
+// srpSessions holds the unfinished SRP exchanges, tokenIssuer signs the access tokens.
+// loginSecret derives the decoy SRP records of the unknown logins.
 type AuthHandler struct {
 	pb.UnimplementedUserHandlersServer
-	userCreator  userCreator
-	userProvider userProvider
+	userCreator        userCreator
+	userProvider       userProvider
+	vaultKeyCreator    vaultKeyCreator
+	vaultKeyProvider   vaultKeyProvider
+	passwordUpdater    passwordUpdater
+	recoveryKitCreator recoveryKitCreator
+	srpRecordUpdater   srpRecordUpdater
+	srpSessions        *srpSessions
+	tokenIssuer        tokenIssuer
+	loginSecret        []byte
 }
 
 // userCreator defines a contract for saving user data to a storage system.
 type userCreator interface {
-	SaveUser(*domain.UserData) error
+	SaveUser(context.Context, *domain.UserData) error
+}
+
+// tokenIssuer defines the contract for signing the access token of a user bound to the session version of the user.
+type tokenIssuer interface {
+	Issue(string, int, time.Time) (string, error)
 }
 
 // userProvider defines the contract for retrieving user information by their login credentials.
 type userProvider interface {
-	GetUserByLogin(string) (*domain.UserData, error)
+	GetUserByLogin(context.Context, string) (*domain.UserData, error)
 }
 
-// NewAuthHandler initializes and returns a new instance of AuthHandler with the provided userCreator and userProvider dependencies.
-func NewAuthHandler(userCreator userCreator, userProvider userProvider) *AuthHandler {
-	return &AuthHandler{
-		userCreator:  userCreator,
-		userProvider: userProvider,
-	}
+// passwordUpdater defines the contract for replacing the password of a user together with the wrapped vault keys.
+type passwordUpdater interface {
+	GetUserByID(context.Context, uuid.UUID) (*domain.UserData, error)
+	UpdatePassword(context.Context, *domain.UserData, []*domain.VaultKey) error
+}
+
+// srpRecordUpdater defines the contract for replacing the bcrypt password hash of a user with the SRP record.
+type srpRecordUpdater interface {
+	SaveSRPRecord(context.Context, *domain.UserData) error
+}
+
+// recoveryKitCreator defines the contract for storing the recovery kit of a user together with the vault keys
+// sealed for the recovery.
+type recoveryKitCreator interface {
+	SaveRecoveryKit(context.Context, *domain.UserData, []*domain.VaultKey) error
+}
+
+// vaultKeyCreator defines a contract for storing the wrapped vault key of a user.
+type vaultKeyCreator interface {
+	SaveVaultKey(context.Context, *domain.VaultKey) error
+}
+
+// vaultKeyProvider defines a contract for retrieving the wrapped vault keys of a user.
+type vaultKeyProvider interface {
+	GetVaultKeys(context.Context, uuid.UUID) ([]*domain.VaultKey, error)
 }
 
-// authClaims defines the structure for JWT claims related to authentication, including UserID and registered claims.
-type authClaims struct {
-	UserID string `json:"userID"`
-	jwt.RegisteredClaims
+// NewAuthHandler initializes and returns a new instance of AuthHandler with the provided userCreator, userProvider,
+// vaultKeyCreator, vaultKeyProvider, passwordUpdater, recoveryKitCreator and srpRecordUpdater dependencies
+// the tokenIssuer signing the access tokens and the login secret of the server.
+func NewAuthHandler(
+	userCreator userCreator,
+	userProvider userProvider,
+	vaultKeyCreator vaultKeyCreator,
+	vaultKeyProvider vaultKeyProvider,
+	passwordUpdater passwordUpdater,
+	recoveryKitCreator recoveryKitCreator,
+	srpRecordUpdater srpRecordUpdater,
+	tokenIssuer tokenIssuer,
+	loginSecret []byte,
+) *AuthHandler {
+	return &AuthHandler{
+		userCreator:        userCreator,
+		userProvider:       userProvider,
+		vaultKeyCreator:    vaultKeyCreator,
+		vaultKeyProvider:   vaultKeyProvider,
+		passwordUpdater:    passwordUpdater,
+		recoveryKitCreator: recoveryKitCreator,
+		srpRecordUpdater:   srpRecordUpdater,
+		srpSessions:        newSRPSessions(),
+		tokenIssuer:        tokenIssuer,
+		loginSecret:        loginSecret,
+	}
 }
 
-// PostUserData processes the authentication or registration of a user based on the provided login and password details.
-// It generates and returns a JWT if authentication is successful or registers the user if they don't already exist.
-// An error is returned if the login or password is invalid, mismatched, or if token generation fails.
+// PostUserData logs in a legacy user created before the SRP login with the login and password, checked against
+// the bcrypt hash. The SRP record sent with the request replaces the hash, so the password is sent to the server
+// for the last time and the user logs in with StartLogin and FinishLogin from now on.
+// New users are registered with RegisterUser. An unknown login and an account upgraded to the SRP login are rejected
+// as a wrong password, so the response doesn't tell whether the account exists.
 func (a *AuthHandler) PostUserData(ctx context.Context, request *pb.PostUserDataRequest) (*pb.PostUserDataResponse, error) {
 	var res pb.PostUserDataResponse
 	if request.GetLogin() == "" || request.GetPassword() == "" {
@@ -61,59 +121,351 @@ func (a *AuthHandler) PostUserData(ctx context.Context, request *pb.PostUserData
 		return &res, status.Error(codes.InvalidArgument, "login or password is empty")
 	}
 
-	storageUser, err := a.userProvider.GetUserByLogin(request.GetLogin())
+	storageUser, err := a.userProvider.GetUserByLogin(ctx, request.GetLogin())
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		slog.Error("failed to get user by login", slog.String("error", err.Error()))
 		res.Error = err.Error()
 		return &res, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if storageUser == nil {
-		pass, err := bcrypt.GenerateFromPassword([]byte(request.GetPassword()), 10)
-		if err != nil {
-			slog.Error("failed to generate password for user", slog.String("error", err.Error()))
-			res.Error = err.Error()
-			return &res, status.Error(codes.InvalidArgument, err.Error())
-		}
+	if storageUser != nil {
+		setAuditSubject(ctx, storageUser.ID)
+	}
 
-		storageUser = &domain.UserData{
-			ID:       uuid.New(),
-			Login:    request.Login,
-			Password: string(pass),
-			Created:  time.Now(),
-			Modified: time.Now(),
-		}
-		if err = a.userCreator.SaveUser(storageUser); err != nil {
-			slog.Error("failed to save user", slog.String("error", err.Error()))
-			res.Error = err.Error()
-			return &res, status.Error(codes.Internal, err.Error())
-		}
-	} else {
-		if bcrypt.CompareHashAndPassword([]byte(storageUser.Password), []byte(request.Password)) != nil {
-			slog.Error("password not match")
-			res.Error = "login or password is incorrect"
-			return &res, status.Error(codes.PermissionDenied, "login or password is incorrect")
+	if storageUser == nil || storageUser.Password == "" ||
+		bcrypt.CompareHashAndPassword([]byte(storageUser.Password), []byte(request.Password)) != nil {
+		slog.Error("password not match")
+		res.Error = "login or password is incorrect"
+		return &res, status.Error(codes.PermissionDenied, "login or password is incorrect")
+	}
+
+	// Учетная запись переводится на вход по SRP, хэш пароля удаляется
+	if err = setSRPRecord(storageUser, request.GetSrpRecord()); err != nil {
+		return nil, err
+	}
+	storageUser.Modified = time.Now()
+
+	if err = a.srpRecordUpdater.SaveSRPRecord(ctx, storageUser); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "account is already upgraded, use StartLogin")
 		}
+		slog.ErrorContext(ctx, "failed to save srp record", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to save srp record")
 	}
 
-	// Create the Claims
-	claims := authClaims{
-		UserID: storageUser.ID.String(),
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
+	return a.loginResponse(ctx, storageUser)
+}
+
+// loginResponse issues the token of the logged in user and returns it with the vault keys of the user.
+// The locked user is rejected once the password is checked.
+func (a *AuthHandler) loginResponse(ctx context.Context, user *domain.UserData) (*pb.PostUserDataResponse, error) {
+	var res pb.PostUserDataResponse
+
+	if user.IsLocked() {
+		res.Error = domain.ErrUserLocked.Error()
+		return &res, status.Error(codes.PermissionDenied, domain.ErrUserLocked.Error())
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	ss, err := token.SignedString([]byte(config.GetKeys().JWTKey))
+	ss, err := a.signToken(user)
 	if err != nil {
-		slog.Error("failed to sign token", slog.String("error", err.Error()))
+		slog.ErrorContext(ctx, "failed to sign token", slog.String("error", err.Error()))
 		res.Error = "failed to sign token"
 		return &res, status.Error(codes.PermissionDenied, "failed to sign token")
 	}
 
-	res.UserId = storageUser.ID.String()
+	vaultKeys, err := a.vaultKeyProvider.GetVaultKeys(ctx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get vault key", slog.String("error", err.Error()))
+		res.Error = "failed to get vault key"
+		return &res, status.Error(codes.Internal, "failed to get vault key")
+	}
+	for _, v := range vaultKeys {
+		switch v.State {
+		case domain.VaultKeyActive:
+			res.VaultKey = vaultKeyToProto(v)
+		case domain.VaultKeyPending:
+			res.PendingVaultKey = vaultKeyToProto(v)
+		}
+	}
+
+	res.UserId = user.ID.String()
 	res.Jwt = ss
+	res.RecoveryPublicKey = user.RecoveryPublicKey
 
 	return &res, nil
 }
+
+// PostVaultKey stores the first vault key of the user wrapped on the client. The key is uploaded once, after the first login,
+// and an attempt to replace an existing key is rejected, so a stolen session can't lock the user out of the vault.
+// Later keys are introduced by the key rotation of the VaultHandler.
+func (a *AuthHandler) PostVaultKey(ctx context.Context, request *pb.PostVaultKeyRequest) (*pb.PostVaultKeyResponse, error) {
+	userID, err := uuid.Parse(request.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", request.GetUserId())
+	}
+
+	if err = checkUserAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if request.GetVaultKey() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty vault key")
+	}
+
+	vaultKey := &domain.VaultKey{
+		UserID:     userID,
+		KeyID:      firstVaultKeyID,
+		State:      domain.VaultKeyActive,
+		WrappedKey: request.GetVaultKey().GetWrappedKey(),
+		Salt:       request.GetVaultKey().GetSalt(),
+		KDFTime:    request.GetVaultKey().GetKdfTime(),
+		KDFMemory:  request.GetVaultKey().GetKdfMemory(),
+		KDFThreads: request.GetVaultKey().GetKdfThreads(),
+		Created:    time.Now(),
+		Modified:   time.Now(),
+	}
+
+	if err = domain.ValidateVaultKey(vaultKey); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = a.vaultKeyCreator.SaveVaultKey(ctx, vaultKey); err != nil {
+		if errors.Is(err, domain.ErrVaultKeyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save vault key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.PostVaultKeyResponse{}, nil
+}
+
+// ChangePassword replaces the SRP record of the user with the record of the new password. The old password is
+// confirmed by an SRP exchange started with StartLogin. The vault keys re-wrapped with the new password on the client
+// are stored in the same transaction, so the vault stays readable whatever happens.
+// All the tokens issued to the user are revoked and a new token is returned for the current session.
+func (a *AuthHandler) ChangePassword(ctx context.Context, request *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	userID, err := uuid.Parse(request.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", request.GetUserId())
+	}
+
+	if err = checkUserAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	vaultKeys, err := rewrappedVaultKeys(userID, request.GetVaultKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.checkPassword(ctx, userID, request.GetSrpSessionId(), request.GetSrpProof())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = setSRPRecord(user, request.GetSrpRecord()); err != nil {
+		return nil, err
+	}
+	user.Modified = time.Now()
+
+	if err = a.passwordUpdater.UpdatePassword(ctx, user, vaultKeys); err != nil {
+		if errors.Is(err, domain.ErrVaultKeysChanged) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not update password", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ss, err := a.signToken(user)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to sign token", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to sign token")
+	}
+
+	return &pb.ChangePasswordResponse{Jwt: ss}, nil
+}
+
+// PostRecoveryKit stores the recovery kit of the user: the public key the vault keys are sealed to and the hash
+// of the token proving the possession of the recovery key. The current password is confirmed by an SRP exchange,
+// so a stolen session can't replace the kit. The vault keys sealed to the public key are stored in the same transaction.
+func (a *AuthHandler) PostRecoveryKit(ctx context.Context, request *pb.PostRecoveryKitRequest) (*pb.PostRecoveryKitResponse, error) {
+	userID, err := uuid.Parse(request.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", request.GetUserId())
+	}
+
+	if err = checkUserAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateRecoveryKit(request.GetPublicKey(), request.GetAuthToken()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if len(request.GetVaultKeys()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty vault keys")
+	}
+
+	vaultKeys := make([]*domain.VaultKey, 0, len(request.GetVaultKeys()))
+	for _, v := range request.GetVaultKeys() {
+		if err = domain.ValidateRecoveryWrappedKey(v.GetRecoveryWrappedKey(), v.GetKeyId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		vaultKeys = append(vaultKeys, &domain.VaultKey{
+			UserID:             userID,
+			KeyID:              v.GetKeyId(),
+			RecoveryWrappedKey: v.GetRecoveryWrappedKey(),
+		})
+	}
+
+	user, err := a.checkPassword(ctx, userID, request.GetSrpSessionId(), request.GetSrpProof())
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := sha256.Sum256(request.GetAuthToken())
+	user.RecoveryPublicKey = request.GetPublicKey()
+	user.RecoveryVerifier = verifier[:]
+	user.Modified = time.Now()
+
+	if err = a.recoveryKitCreator.SaveRecoveryKit(ctx, user, vaultKeys); err != nil {
+		if errors.Is(err, domain.ErrVaultKeysChanged) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save recovery kit", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.PostRecoveryKitResponse{}, nil
+}
+
+// GetRecoveryKit returns the vault keys of the user sealed for the recovery. The request isn't authenticated
+// with a JWT, the token derived from the recovery key is checked instead.
+func (a *AuthHandler) GetRecoveryKit(ctx context.Context, request *pb.GetRecoveryKitRequest) (*pb.GetRecoveryKitResponse, error) {
+	user, err := a.checkRecoveryToken(ctx, request.GetLogin(), request.GetAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	vaultKeys, err := a.vaultKeyProvider.GetVaultKeys(ctx, user.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get vault keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to get vault keys")
+	}
+
+	res := &pb.GetRecoveryKitResponse{}
+	for _, v := range vaultKeys {
+		res.VaultKeys = append(res.VaultKeys, &pb.VaultKey{
+			KeyId:              v.KeyID,
+			RecoveryWrappedKey: v.RecoveryWrappedKey,
+		})
+	}
+
+	return res, nil
+}
+
+// RecoverAccount sets a new password of the user who forgot the old one. The token derived from the recovery key
+// is checked instead of the password, the SRP record of the new password and the vault keys recovered on the client
+// and re-wrapped with the new password are stored in the same transaction. All the tokens issued to the user are revoked and a new token is returned.
+// The locked account can't be recovered.
+func (a *AuthHandler) RecoverAccount(ctx context.Context, request *pb.RecoverAccountRequest) (*pb.RecoverAccountResponse, error) {
+	user, err := a.checkRecoveryToken(ctx, request.GetLogin(), request.GetAuthToken())
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsLocked() {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrUserLocked.Error())
+	}
+
+	vaultKeys, err := rewrappedVaultKeys(user.ID, request.GetVaultKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = setSRPRecord(user, request.GetSrpRecord()); err != nil {
+		return nil, err
+	}
+	user.Modified = time.Now()
+
+	if err = a.passwordUpdater.UpdatePassword(ctx, user, vaultKeys); err != nil {
+		if errors.Is(err, domain.ErrVaultKeysChanged) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not update password", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ss, err := a.signToken(user)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to sign token", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to sign token")
+	}
+
+	return &pb.RecoverAccountResponse{
+		Jwt:    ss,
+		UserId: user.ID.String(),
+	}, nil
+}
+
+// checkRecoveryToken finds the user by login and checks the token proves the possession of the recovery key.
+// An unknown login, a missing recovery kit and a wrong token are reported alike, so the logins can't be enumerated.
+func (a *AuthHandler) checkRecoveryToken(ctx context.Context, login string, authToken []byte) (*domain.UserData, error) {
+	if login == "" || len(authToken) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "login or recovery key is empty")
+	}
+
+	user, err := a.userProvider.GetUserByLogin(ctx, login)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get user by login", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	if user != nil {
+		setAuditSubject(ctx, user.ID)
+	}
+
+	verifier := sha256.Sum256(authToken)
+	if user == nil || len(user.RecoveryVerifier) == 0 || subtle.ConstantTimeCompare(user.RecoveryVerifier, verifier[:]) != 1 {
+		slog.ErrorContext(ctx, "recovery key not match")
+		return nil, status.Error(codes.PermissionDenied, "login or recovery key is incorrect")
+	}
+
+	return user, nil
+}
+
+// rewrappedVaultKeys converts the vault keys re-wrapped with a new password on the client and validates them.
+func rewrappedVaultKeys(userID uuid.UUID, keys []*pb.VaultKey) ([]*domain.VaultKey, error) {
+	if len(keys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty vault keys")
+	}
+
+	vaultKeys := make([]*domain.VaultKey, 0, len(keys))
+	for _, v := range keys {
+		vaultKey := &domain.VaultKey{
+			UserID:     userID,
+			KeyID:      v.GetKeyId(),
+			WrappedKey: v.GetWrappedKey(),
+			Salt:       v.GetSalt(),
+			KDFTime:    v.GetKdfTime(),
+			KDFMemory:  v.GetKdfMemory(),
+			KDFThreads: v.GetKdfThreads(),
+			Modified:   time.Now(),
+		}
+
+		if err := domain.ValidateVaultKey(vaultKey); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		vaultKeys = append(vaultKeys, vaultKey)
+	}
+
+	return vaultKeys, nil
+}
+
+// signToken issues a JWT of the user bound to the current session version of the user.
+func (a *AuthHandler) signToken(user *domain.UserData) (string, error) {
+	return a.tokenIssuer.Issue(user.ID.String(), user.SessionVersion, time.Now())
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+)
+
+// userIDKey is the context key of the authenticated user ID.
+type userIDKey struct{}
+
+// apiTokenKey is the context key of the API token the request is authenticated with.
+type apiTokenKey struct{}
+
+// auditSubjectKey is the context key of the audit subject of the request.
+type auditSubjectKey struct{}
+
+// AuditSubject holds the account an unauthenticated request acts on, e.g. a login, which the handler sets
+// once it finds the account, so the audit log records the failed attempts too.
+type AuditSubject struct {
+	UserID uuid.UUID
+}
+
+// ContextWithUserID returns a copy of the context carrying the ID of the user authenticated by the request token.
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the ID of the authenticated user, if the request was authenticated.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(string)
+	return userID, ok && userID != ""
+}
+
+// ContextWithAPIToken returns a copy of the context carrying the API token the request is authenticated with,
+// its scope restricts the items reached by the request.
+func ContextWithAPIToken(ctx context.Context, token *domain.APIToken) context.Context {
+	return context.WithValue(ctx, apiTokenKey{}, token)
+}
+
+// APITokenFromContext returns the API token of the request, if the request was authenticated with one.
+func APITokenFromContext(ctx context.Context) (*domain.APIToken, bool) {
+	token, ok := ctx.Value(apiTokenKey{}).(*domain.APIToken)
+	return token, ok && token != nil
+}
+
+// ContextWithAuditSubject returns a copy of the context carrying an empty audit subject, which the handler fills in.
+func ContextWithAuditSubject(ctx context.Context) (context.Context, *AuditSubject) {
+	subject := &AuditSubject{}
+	return context.WithValue(ctx, auditSubjectKey{}, subject), subject
+}
+
+// setAuditSubject sets the account the request acts on, if the audit log records the request.
+func setAuditSubject(ctx context.Context, userID uuid.UUID) {
+	if subject, ok := ctx.Value(auditSubjectKey{}).(*AuditSubject); ok {
+		subject.UserID = userID
+	}
+}
+
+// checkTokenScope checks the item of the data type placed into the folder is within the scope of the API token
+// of the request. Requests authenticated otherwise are not restricted.
+func checkTokenScope(ctx context.Context, folderID uuid.UUID, dataType string) error {
+	token, ok := APITokenFromContext(ctx)
+	if !ok || token.Scope.Allows(folderID, dataType) {
+		return nil
+	}
+
+	return status.Error(codes.PermissionDenied, "item is out of the API token scope")
+}
+
+// checkUserAccess checks the user of the request is the authenticated one.
+// Requests are not restricted when the authentication is disabled.
+func checkUserAccess(ctx context.Context, userID uuid.UUID) error {
+	authUserID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	if authUserID != userID.String() {
+		return status.Error(codes.PermissionDenied, "access to another user data is denied")
+	}
+
+	return nil
+}
+
+// parseUserAccess parses the user ID of the request and checks it is the authenticated user.
+func parseUserAccess(ctx context.Context, id string) (uuid.UUID, error) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", id)
+	}
+
+	if err = checkUserAccess(ctx, userID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
+)
+
+// DeviceHandler handles the enrolment of the client devices for the mutual TLS and implements
+// the gRPC DeviceHandlersServer interface. The device generates its key and sends the certificate request
+// after the password login, the server signs it with the client CA and remembers the certificate fingerprint.
+// deviceSigner is nil when the client CA is not configured and the enrolment is disabled.
+type DeviceHandler struct {
+	pb.UnimplementedDeviceHandlersServer
+	deviceStore  deviceStore
+	deviceSigner deviceSigner
+}
+
+// deviceStore defines a contract for storing and revoking the enrolled devices of the users.
+type deviceStore interface {
+	SaveDevice(context.Context, *domain.Device) error
+	GetDevicesByUser(context.Context, uuid.UUID) ([]*domain.Device, error)
+	RevokeDevice(context.Context, uuid.UUID, uuid.UUID, time.Time) error
+}
+
+// deviceSigner defines a contract for signing the certificate requests of the devices with the client CA.
+type deviceSigner interface {
+	SignDevice([]byte, uuid.UUID, uuid.UUID, time.Time) (*x509.Certificate, []byte, error)
+	CertificatePEM() []byte
+}
+
+// NewDeviceHandler initializes and returns a new instance of DeviceHandler with the provided store and signer.
+func NewDeviceHandler(deviceStore deviceStore, deviceSigner deviceSigner) *DeviceHandler {
+	return &DeviceHandler{
+		deviceStore:  deviceStore,
+		deviceSigner: deviceSigner,
+	}
+}
+
+// EnrollDevice signs the certificate request of the device of the user and registers the device.
+func (h *DeviceHandler) EnrollDevice(ctx context.Context, request *pb.EnrollDeviceRequest) (*pb.EnrollDeviceResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if h.deviceSigner == nil {
+		return nil, status.Error(codes.FailedPrecondition, "device enrolment is disabled, client CA is not configured")
+	}
+
+	if err = domain.ValidateDeviceName(request.GetName()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	now := time.Now()
+	deviceID := uuid.New()
+	cert, certPEM, err := h.deviceSigner.SignDevice(request.GetCsr(), userID, deviceID, now)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	device := &domain.Device{
+		ID:          deviceID,
+		UserID:      userID,
+		Name:        request.GetName(),
+		Fingerprint: pki.Fingerprint(cert),
+		Expires:     cert.NotAfter,
+		Created:     now,
+	}
+
+	if err = h.deviceStore.SaveDevice(ctx, device); err != nil {
+		slog.ErrorContext(ctx, "could not save device", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.EnrollDeviceResponse{
+		Device:        deviceToProto(device),
+		Certificate:   certPEM,
+		CaCertificate: h.deviceSigner.CertificatePEM(),
+	}, nil
+}
+
+// GetDevices returns the devices enrolled by the user, the revoked ones included.
+func (h *DeviceHandler) GetDevices(ctx context.Context, request *pb.GetDevicesRequest) (*pb.GetDevicesResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := h.deviceStore.GetDevicesByUser(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get devices", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.GetDevicesResponse{Devices: make([]*pb.Device, len(devices))}
+	for i, v := range devices {
+		resp.Devices[i] = deviceToProto(v)
+	}
+
+	return resp, nil
+}
+
+// RevokeDevice revokes the device of the user, its certificate is rejected from now on.
+func (h *DeviceHandler) RevokeDevice(ctx context.Context, request *pb.RevokeDeviceRequest) (*pb.RevokeDeviceResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	deviceID, err := uuid.Parse(request.GetDeviceId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid device id %s", request.GetDeviceId())
+	}
+
+	if err = h.deviceStore.RevokeDevice(ctx, userID, deviceID, time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "device %s not found or revoked already", deviceID)
+		}
+		slog.ErrorContext(ctx, "could not revoke device", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeDeviceResponse{}, nil
+}
+
+// deviceToProto converts the device into its gRPC representation.
+func deviceToProto(device *domain.Device) *pb.Device {
+	res := &pb.Device{
+		Id:          device.ID.String(),
+		Name:        device.Name,
+		Fingerprint: device.Fingerprint,
+		Expires:     device.Expires.Format(time.RFC3339),
+		Created:     device.Created.Format(time.RFC3339),
+	}
+	if device.IsRevoked() {
+		res.Revoked = device.Revoked.Format(time.RFC3339)
+	}
+
+	return res
+}
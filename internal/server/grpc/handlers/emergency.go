@@ -0,0 +1,459 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// EmergencyHandler handles the emergency access of the trusted contacts to the vaults of their owners
+// and implements the gRPC EmergencyHandlersServer interface. The owner seals the vault key to the public sharing key
+// of the contact when granting the access, the server keeps the sealed key and releases it to the contact only
+// after the access is approved: by the owner or by the background release once the waiting period of the request
+// has passed without the owner rejecting it. The released contact reads the items of the owner, but never changes them.
+type EmergencyHandler struct {
+	pb.UnimplementedEmergencyHandlersServer
+	emergencyStore    emergencyStore
+	userProvider      userProvider
+	sharingKeyStore   sharingKeyStore
+	vaultKeyProvider  vaultKeyProvider
+	metaDataProvider  metaDataProvider
+	itemOwnerProvider itemOwnerProvider
+	itemDataProvider  itemDataProvider
+}
+
+// emergencyStore defines a contract for storing the emergency access and the changes of its status.
+type emergencyStore interface {
+	SaveEmergencyAccess(context.Context, *domain.EmergencyAccess) error
+	GetEmergencyAccessByID(context.Context, uuid.UUID) (*domain.EmergencyAccess, error)
+	GetEmergencyAccessByOwner(context.Context, uuid.UUID) ([]*domain.EmergencyAccess, error)
+	GetEmergencyAccessByContact(context.Context, uuid.UUID) ([]*domain.EmergencyAccess, error)
+	RequestEmergencyAccess(context.Context, uuid.UUID, time.Time) error
+	AnswerEmergencyAccess(context.Context, uuid.UUID, bool) error
+	UpdateEmergencyKeys(context.Context, uuid.UUID, []*domain.EmergencyAccess) error
+	DeleteEmergencyAccess(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+// NewEmergencyHandler initializes and returns a new instance of EmergencyHandler with the provided storage dependencies.
+func NewEmergencyHandler(
+	emergencyStore emergencyStore,
+	userProvider userProvider,
+	sharingKeyStore sharingKeyStore,
+	vaultKeyProvider vaultKeyProvider,
+	metaDataProvider metaDataProvider,
+	itemOwnerProvider itemOwnerProvider,
+	itemDataProvider itemDataProvider,
+) *EmergencyHandler {
+	return &EmergencyHandler{
+		emergencyStore:    emergencyStore,
+		userProvider:      userProvider,
+		sharingKeyStore:   sharingKeyStore,
+		vaultKeyProvider:  vaultKeyProvider,
+		metaDataProvider:  metaDataProvider,
+		itemOwnerProvider: itemOwnerProvider,
+		itemDataProvider:  itemDataProvider,
+	}
+}
+
+// GrantEmergencyAccess designates the user of the login as a trusted contact of the owner with the waiting period.
+// The request carries the vault key of the owner sealed to the public sharing key of the contact.
+func (h *EmergencyHandler) GrantEmergencyAccess(ctx context.Context, request *pb.GrantEmergencyAccessRequest) (*pb.GrantEmergencyAccessResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	wait := time.Duration(request.GetWaitHours()) * time.Hour
+	if err = domain.ValidateEmergencyWait(wait); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = domain.ValidateEmergencyKey(request.GetSealedKey(), request.GetKeyId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = h.checkVaultKey(ctx, userID, request.GetKeyId()); err != nil {
+		return nil, err
+	}
+
+	contact, err := h.userProvider.GetUserByLogin(ctx, request.GetContactLogin())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", request.GetContactLogin())
+		}
+		slog.ErrorContext(ctx, "could not get user", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if contact.ID == userID {
+		return nil, status.Error(codes.InvalidArgument, "can't designate yourself as a trusted contact")
+	}
+
+	key, err := h.sharingKeyStore.GetSharingKey(ctx, contact.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "contact has no sharing key yet and must log in first")
+		}
+		slog.ErrorContext(ctx, "could not get sharing key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	access := &domain.EmergencyAccess{
+		ID:               uuid.New(),
+		OwnerID:          userID,
+		ContactID:        contact.ID,
+		ContactLogin:     contact.Login,
+		ContactPublicKey: key.PublicKey,
+		WaitPeriod:       wait,
+		Status:           domain.EmergencyStatusGranted,
+		KeyID:            request.GetKeyId(),
+		SealedKey:        request.GetSealedKey(),
+		Created:          time.Now(),
+		Modified:         time.Now(),
+	}
+
+	if err = h.emergencyStore.SaveEmergencyAccess(ctx, access); err != nil {
+		if errors.Is(err, domain.ErrEmergencyAccessExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GrantEmergencyAccessResponse{Access: emergencyAccessToProto(access)}, nil
+}
+
+// GetEmergencyAccess returns the trusted contacts of the user and the owners who designated the user as their contact.
+func (h *EmergencyHandler) GetEmergencyAccess(ctx context.Context, request *pb.GetEmergencyAccessRequest) (*pb.GetEmergencyAccessResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	granted, err := h.emergencyStore.GetEmergencyAccessByOwner(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get granted emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	trustedBy, err := h.emergencyStore.GetEmergencyAccessByContact(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get trusted emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.GetEmergencyAccessResponse{
+		Granted:   make([]*pb.EmergencyAccess, len(granted)),
+		TrustedBy: make([]*pb.EmergencyAccess, len(trustedBy)),
+	}
+	for i, v := range granted {
+		resp.Granted[i] = emergencyAccessToProto(v)
+	}
+	for i, v := range trustedBy {
+		resp.TrustedBy[i] = emergencyAccessToProto(v)
+	}
+
+	return resp, nil
+}
+
+// RevokeEmergencyAccess removes the trusted contact of the owner, in any status of the access.
+func (h *EmergencyHandler) RevokeEmergencyAccess(ctx context.Context, request *pb.RevokeEmergencyAccessRequest) (*pb.RevokeEmergencyAccessResponse, error) {
+	access, err := h.ownerAccess(ctx, request.GetUserId(), request.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.emergencyStore.DeleteEmergencyAccess(ctx, access.OwnerID, access.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "emergency access %s not found", access.ID)
+		}
+		slog.ErrorContext(ctx, "could not delete emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeEmergencyAccessResponse{}, nil
+}
+
+// RequestEmergencyAccess starts the waiting period of the access on behalf of the trusted contact. The vault key
+// is released when the period passes unless the owner rejects the request. A rejected access may be requested again.
+func (h *EmergencyHandler) RequestEmergencyAccess(ctx context.Context, request *pb.RequestEmergencyAccessRequest) (*pb.RequestEmergencyAccessResponse, error) {
+	access, err := h.contactAccess(ctx, request.GetUserId(), request.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	requestedAt := time.Now()
+	if err = h.emergencyStore.RequestEmergencyAccess(ctx, access.ID, requestedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.FailedPrecondition, "emergency access is %s already", access.Status)
+		}
+		slog.ErrorContext(ctx, "could not request emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	access.Status = domain.EmergencyStatusRequested
+	access.RequestedAt = requestedAt
+
+	return &pb.RequestEmergencyAccessResponse{Access: emergencyAccessToProto(access)}, nil
+}
+
+// AnswerEmergencyAccess approves the requested access at once or rejects it on behalf of the owner.
+func (h *EmergencyHandler) AnswerEmergencyAccess(ctx context.Context, request *pb.AnswerEmergencyAccessRequest) (*pb.AnswerEmergencyAccessResponse, error) {
+	access, err := h.ownerAccess(ctx, request.GetUserId(), request.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.emergencyStore.AnswerEmergencyAccess(ctx, access.ID, request.GetApprove()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "emergency access is not requested")
+		}
+		slog.ErrorContext(ctx, "could not answer emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.AnswerEmergencyAccessResponse{}, nil
+}
+
+// UpdateEmergencyKeys replaces the vault keys sealed to the trusted contacts of the owner, the client seals
+// the new vault key to them after a key rotation.
+func (h *EmergencyHandler) UpdateEmergencyKeys(ctx context.Context, request *pb.UpdateEmergencyKeysRequest) (*pb.UpdateEmergencyKeysResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	access := make([]*domain.EmergencyAccess, 0, len(request.GetKeys()))
+	for _, v := range request.GetKeys() {
+		id, err := uuid.Parse(v.GetAccessId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid access id %s", v.GetAccessId())
+		}
+
+		if err = domain.ValidateEmergencyKey(v.GetSealedKey(), v.GetKeyId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if err = h.checkVaultKey(ctx, userID, v.GetKeyId()); err != nil {
+			return nil, err
+		}
+
+		access = append(access, &domain.EmergencyAccess{ID: id, KeyID: v.GetKeyId(), SealedKey: v.GetSealedKey()})
+	}
+
+	if err = h.emergencyStore.UpdateEmergencyKeys(ctx, userID, access); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "emergency access not found")
+		}
+		slog.ErrorContext(ctx, "could not update emergency keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UpdateEmergencyKeysResponse{}, nil
+}
+
+// GetEmergencyVault releases the vault key of the owner sealed to the trusted contact once the access is approved.
+func (h *EmergencyHandler) GetEmergencyVault(ctx context.Context, request *pb.GetEmergencyVaultRequest) (*pb.GetEmergencyVaultResponse, error) {
+	access, err := h.approvedAccess(ctx, request.GetUserId(), request.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetEmergencyVaultResponse{
+		OwnerId:   access.OwnerID.String(),
+		KeyId:     access.KeyID,
+		SealedKey: access.SealedKey,
+	}, nil
+}
+
+// GetEmergencyItems returns a page of the metadata of the items of the owner to the released trusted contact.
+func (h *EmergencyHandler) GetEmergencyItems(ctx context.Context, request *pb.GetEmergencyItemsRequest) (*pb.GetEmergencyItemsResponse, error) {
+	access, err := h.approvedAccess(ctx, request.GetUserId(), request.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	afterID, err := decodePageToken(request.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_token %s", request.GetPageToken())
+	}
+
+	// Запрашивается на одну запись больше страницы, чтобы узнать есть ли следующая
+	metas, err := h.metaDataProvider.GetMetaDataByUser(ctx, access.OwnerID, &domain.MetaFilter{
+		AfterID: afterID,
+		Limit:   defaultMetaPageSize + 1,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get metaData", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var nextPageToken string
+	if len(metas) > defaultMetaPageSize {
+		metas = metas[:defaultMetaPageSize]
+		nextPageToken = encodePageToken(metas[defaultMetaPageSize-1].ID)
+	}
+
+	items := make([]*pb.MetaData, len(metas))
+	for i, v := range metas {
+		items[i] = metaToProto(v)
+	}
+
+	return &pb.GetEmergencyItemsResponse{Items: items, NextPageToken: nextPageToken}, nil
+}
+
+// GetEmergencyItem returns the encrypted item of the owner to the released trusted contact.
+func (h *EmergencyHandler) GetEmergencyItem(ctx context.Context, request *pb.GetEmergencyItemRequest) (*pb.GetEmergencyItemResponse, error) {
+	access, err := h.approvedAccess(ctx, request.GetUserId(), request.GetAccessId())
+	if err != nil {
+		return nil, err
+	}
+
+	dataID, err := uuid.Parse(request.GetDataId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid data id %s", request.GetDataId())
+	}
+
+	// Записи других пользователей неотличимы от отсутствующих
+	meta, err := h.itemOwnerProvider.GetMetaDataByDataID(ctx, dataID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get metaData", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err != nil || meta.UserID != access.OwnerID {
+		return nil, status.Errorf(codes.NotFound, "item %s not found", dataID)
+	}
+
+	item, err := h.itemDataProvider.GetItemDataByID(ctx, dataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "item %s not found", dataID)
+		}
+		slog.ErrorContext(ctx, "could not get data", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetEmergencyItemResponse{
+		Data:       item.Data,
+		WrappedKey: item.WrappedKey,
+	}, nil
+}
+
+// access returns the emergency access by the ID of the request.
+func (h *EmergencyHandler) access(ctx context.Context, accessIDParam string) (*domain.EmergencyAccess, error) {
+	accessID, err := uuid.Parse(accessIDParam)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid access id %s", accessIDParam)
+	}
+
+	access, err := h.emergencyStore.GetEmergencyAccessByID(ctx, accessID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "emergency access %s not found", accessID)
+		}
+		slog.ErrorContext(ctx, "could not get emergency access", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return access, nil
+}
+
+// ownerAccess returns the emergency access granted by the user of the request.
+// The access of other owners is indistinguishable from a missing one.
+func (h *EmergencyHandler) ownerAccess(ctx context.Context, userIDParam string, accessIDParam string) (*domain.EmergencyAccess, error) {
+	userID, err := parseUserAccess(ctx, userIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := h.access(ctx, accessIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.OwnerID != userID {
+		return nil, status.Errorf(codes.NotFound, "emergency access %s not found", access.ID)
+	}
+
+	return access, nil
+}
+
+// contactAccess returns the emergency access granted to the user of the request as a trusted contact.
+// The access of other contacts is indistinguishable from a missing one.
+func (h *EmergencyHandler) contactAccess(ctx context.Context, userIDParam string, accessIDParam string) (*domain.EmergencyAccess, error) {
+	userID, err := parseUserAccess(ctx, userIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := h.access(ctx, accessIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.ContactID != userID {
+		return nil, status.Errorf(codes.NotFound, "emergency access %s not found", access.ID)
+	}
+
+	return access, nil
+}
+
+// approvedAccess returns the emergency access of the trusted contact of the request, failing unless it is approved.
+func (h *EmergencyHandler) approvedAccess(ctx context.Context, userIDParam string, accessIDParam string) (*domain.EmergencyAccess, error) {
+	access, err := h.contactAccess(ctx, userIDParam, accessIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if access.Status != domain.EmergencyStatusApproved {
+		return nil, status.Errorf(codes.PermissionDenied, "emergency access is %s, not approved", access.Status)
+	}
+
+	return access, nil
+}
+
+// checkVaultKey checks the vault key the contact receives is one of the vault keys of the owner.
+func (h *EmergencyHandler) checkVaultKey(ctx context.Context, userID uuid.UUID, keyID uint32) error {
+	keys, err := h.vaultKeyProvider.GetVaultKeys(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get vault keys", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, v := range keys {
+		if v.KeyID == keyID {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.FailedPrecondition, "vault key %d is not stored", keyID)
+}
+
+// emergencyAccessToProto converts the emergency access into its gRPC representation, without the sealed key.
+func emergencyAccessToProto(access *domain.EmergencyAccess) *pb.EmergencyAccess {
+	res := &pb.EmergencyAccess{
+		Id:               access.ID.String(),
+		OwnerLogin:       access.OwnerLogin,
+		ContactLogin:     access.ContactLogin,
+		ContactPublicKey: access.ContactPublicKey,
+		WaitHours:        uint32(access.WaitPeriod / time.Hour),
+		Status:           access.Status,
+		KeyId:            access.KeyID,
+		Created:          access.Created.Format(time.RFC3339),
+	}
+	if !access.RequestedAt.IsZero() {
+		res.RequestedAt = access.RequestedAt.Format(time.RFC3339)
+		res.ReleaseAt = access.ReleaseAt().Format(time.RFC3339)
+	}
+
+	return res
+}
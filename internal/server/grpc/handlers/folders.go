@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// FolderHandler handles requests for creating, listing and removing the user folders of items.
+// It embeds pb.UnimplementedFolderHandlersServer for forward compatibility.
+type FolderHandler struct {
+	pb.UnimplementedFolderHandlersServer
+	folderCreator  folderCreator
+	folderProvider folderProvider
+	folderRemover  folderRemover
+}
+
+// folderCreator defines a contract for saving a new or changed folder.
+type folderCreator interface {
+	SaveFolder(context.Context, *domain.Folder) error
+}
+
+// folderProvider defines a contract for retrieving all folders of a user.
+type folderProvider interface {
+	GetFoldersByUser(context.Context, uuid.UUID) ([]*domain.Folder, error)
+}
+
+// folderRemover defines a contract for removing a folder of a user by its unique identifier.
+type folderRemover interface {
+	DeleteFolderByID(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+// NewFolderHandler creates and initializes a new FolderHandler with the provided storage dependencies.
+func NewFolderHandler(folderCreator folderCreator, folderProvider folderProvider, folderRemover folderRemover) *FolderHandler {
+	return &FolderHandler{
+		folderCreator:  folderCreator,
+		folderProvider: folderProvider,
+		folderRemover:  folderRemover,
+	}
+}
+
+// PostFolder creates a folder or renames and moves the existing one, returning the stored folder.
+// The parent folder must belong to the same user and can't be the folder itself or one of its subfolders.
+func (h *FolderHandler) PostFolder(ctx context.Context, request *pb.PostFolderRequest) (*pb.PostFolderResponse, error) {
+	if request.GetFolder() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty folder")
+	}
+
+	userID, err := uuid.Parse(request.GetFolder().GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", request.GetFolder().GetUserId())
+	}
+
+	folderID := uuid.New()
+	if request.GetFolder().GetId() != "" {
+		if folderID, err = uuid.Parse(request.GetFolder().GetId()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", request.GetFolder().GetId())
+		}
+	}
+
+	parentID, err := parseOptionalID(request.GetFolder().GetParentId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid parent id %s", request.GetFolder().GetParentId())
+	}
+
+	folders, err := h.folderProvider.GetFoldersByUser(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get folders", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	folder := &domain.Folder{
+		ID:       folderID,
+		Name:     strings.TrimSpace(request.GetFolder().GetName()),
+		ParentID: parentID,
+		UserID:   userID,
+		Created:  time.Now(),
+		Modified: time.Now(),
+	}
+	for _, v := range folders {
+		if v.ID == folder.ID {
+			folder.Created = v.Created
+		}
+	}
+
+	if err = domain.ValidateFolder(folder, folders); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = h.folderCreator.SaveFolder(ctx, folder); err != nil {
+		slog.ErrorContext(ctx, "could not save folder", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.PostFolderResponse{
+		Folder: folderToProto(folder),
+	}, nil
+}
+
+// GetFolders returns all folders of the user from the request, limited to the folders of the API token scope
+// of the request.
+func (h *FolderHandler) GetFolders(ctx context.Context, request *pb.GetFoldersRequest) (*pb.GetFoldersResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	folders, err := h.folderProvider.GetFoldersByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.ErrorContext(ctx, "no folders found", slog.String("error", err.Error()))
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not get folders", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	token, restricted := APITokenFromContext(ctx)
+	protoFolders := make([]*pb.Folder, 0, len(folders))
+	for _, v := range folders {
+		if restricted && !token.Scope.AllowsFolder(v.ID) {
+			continue
+		}
+		protoFolders = append(protoFolders, folderToProto(v))
+	}
+
+	return &pb.GetFoldersResponse{
+		Folders: protoFolders,
+	}, nil
+}
+
+// DeleteFolder removes the folder of the user with its subfolders, the items of removed folders are moved to the top level.
+func (h *FolderHandler) DeleteFolder(ctx context.Context, request *pb.DeleteFolderRequest) (*pb.DeleteFolderResponse, error) {
+	folderID, err := uuid.Parse(request.GetFolderId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", request.GetFolderId())
+	}
+
+	userID, err := uuid.Parse(request.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id %s", request.GetUserId())
+	}
+
+	if err = h.folderRemover.DeleteFolderByID(ctx, folderID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "folder not found")
+		}
+		slog.ErrorContext(ctx, "could not delete folder", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DeleteFolderResponse{}, nil
+}
+
+// folderToProto converts the domain folder into its protobuf representation.
+func folderToProto(folder *domain.Folder) *pb.Folder {
+	res := &pb.Folder{
+		Id:       folder.ID.String(),
+		Name:     folder.Name,
+		UserId:   folder.UserID.String(),
+		Created:  folder.Created.Format(time.RFC3339),
+		Modified: folder.Modified.Format(time.RFC3339),
+	}
+	if folder.ParentID != uuid.Nil {
+		res.ParentId = folder.ParentID.String()
+	}
+
+	return res
+}
+
+// parseOptionalID parses the optional identifier of the request, an empty string is parsed as uuid.Nil.
+func parseOptionalID(id string) (uuid.UUID, error) {
+	if id == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(id)
+}
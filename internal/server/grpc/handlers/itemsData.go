@@ -15,35 +15,68 @@ import (
 	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
 )
 
+const (
+	encryptedMetaLimit = 64 * 1024
+)
+
 // ItemsDataHandler handles requests for item data and metadata operations by implementing gRPC server methods.
-// It embeds an unimplemented gRPC server and utilizes injected itemDataCreator and itemDataProvider interfaces.
+// It embeds an unimplemented gRPC server and utilizes injected itemDataCreator, itemDataProvider, folderProvider,
+// vaultKeyProvider, itemOwnerProvider and quotaProvider interfaces. defaultQuota limits the storage of the users
+// without the quota set by an operator.
 type ItemsDataHandler struct {
 	pb.UnimplementedItemDataHandlersServer
-	itemDataCreator  itemDataCreator
-	itemDataProvider itemDataProvider
+	itemDataCreator   itemDataCreator
+	itemDataProvider  itemDataProvider
+	folderProvider    folderProvider
+	vaultKeyProvider  vaultKeyProvider
+	itemOwnerProvider itemOwnerProvider
+	quotaProvider     quotaProvider
+	defaultQuota      domain.Quota
 }
 
 // itemDataCreator defines an interface for saving item data and associated metadata.
-// It ensures atomic persistence operations.
+// It ensures atomic persistence operations and checks the item against the quota of the user.
 type itemDataCreator interface {
-	SaveItemData(*domain.ItemData, *domain.Meta) error
+	SaveItemData(context.Context, *domain.ItemData, *domain.Meta, *domain.Quota) error
+}
+
+// quotaProvider defines methods for retrieving the storage usage of a user and the quota set for the user by an operator.
+type quotaProvider interface {
+	GetUsage(context.Context, uuid.UUID) (*domain.StorageUsage, error)
+	GetUserQuota(context.Context, uuid.UUID) (*domain.Quota, error)
 }
 
 // itemDataProvider defines methods for retrieving item data by unique identifier.
 type itemDataProvider interface {
-	GetItemDataByID(uuid.UUID) (*domain.ItemData, error)
+	GetItemDataByID(context.Context, uuid.UUID) (*domain.ItemData, error)
 }
 
-// NewItemsDataHandler creates a new instance of ItemsDataHandler with provided itemDataCreator and itemDataProvider dependencies.
+// NewItemsDataHandler creates a new instance of ItemsDataHandler with provided itemDataCreator, itemDataProvider,
+// folderProvider, vaultKeyProvider, itemOwnerProvider and quotaProvider dependencies and the default quota of the users.
 // It initializes the handler to support operations for managing item data and metadata.
-func NewItemsDataHandler(itemDataCreator itemDataCreator, itemDataProvider itemDataProvider) *ItemsDataHandler {
+func NewItemsDataHandler(
+	itemDataCreator itemDataCreator,
+	itemDataProvider itemDataProvider,
+	folderProvider folderProvider,
+	vaultKeyProvider vaultKeyProvider,
+	itemOwnerProvider itemOwnerProvider,
+	quotaProvider quotaProvider,
+	defaultQuota domain.Quota,
+) *ItemsDataHandler {
 	return &ItemsDataHandler{
-		itemDataCreator:  itemDataCreator,
-		itemDataProvider: itemDataProvider,
+		itemDataCreator:   itemDataCreator,
+		itemDataProvider:  itemDataProvider,
+		folderProvider:    folderProvider,
+		vaultKeyProvider:  vaultKeyProvider,
+		itemOwnerProvider: itemOwnerProvider,
+		quotaProvider:     quotaProvider,
+		defaultQuota:      defaultQuota,
 	}
 }
 
 // PostItemData processes and stores item data and metadata provided in the request, returning a response with IDs and timestamps.
+// The metadata is saved for the authenticated user, an existing metadata record must belong to the user and describe
+// the item data of the request.
 func (h *ItemsDataHandler) PostItemData(ctx context.Context, request *pb.PostItemDataRequest) (*pb.PostItemDataResponse, error) {
 	var dataID uuid.UUID
 
@@ -59,6 +92,10 @@ func (h *ItemsDataHandler) PostItemData(ctx context.Context, request *pb.PostIte
 			return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", request.GetDataId())
 		}
 		dataID = id
+
+		if err = checkItemOwner(ctx, h.itemOwnerProvider, dataID); err != nil {
+			return nil, err
+		}
 	}
 
 	if request.GetMetaData() == nil {
@@ -70,28 +107,85 @@ func (h *ItemsDataHandler) PostItemData(ctx context.Context, request *pb.PostIte
 		return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", request.GetMetaData().Id)
 	}
 
-	userID, err := uuid.Parse(request.GetMetaData().UserId)
+	userID, err := parseUserAccess(ctx, request.GetMetaData().GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkMetaOwner(ctx, h.itemOwnerProvider, metaID, userID, dataID); err != nil {
+		return nil, err
+	}
+
+	tags, err := domain.NormalizeTags(request.GetMetaData().GetTags())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", request.GetMetaData().UserId)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	folderID, err := parseOptionalID(request.GetMetaData().GetFolderId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid folder id %s", request.GetMetaData().GetFolderId())
+	}
+
+	if folderID != uuid.Nil {
+		if err = h.checkFolderOwner(ctx, folderID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Элемент не выносится из области действия токена
+	if err = checkTokenScope(ctx, folderID, request.GetMetaData().GetDataType()); err != nil {
+		return nil, err
+	}
+
+	if len(request.GetMetaData().GetEncryptedMeta()) > encryptedMetaLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "encrypted meta is larger than %d bytes", encryptedMetaLimit)
+	}
+
+	keyID := request.GetKeyId()
+	if len(request.GetWrappedKey()) == 0 {
+		keyID = 0
+	}
+	metaKeyID := request.GetMetaData().GetMetaKeyId()
+	if len(request.GetMetaData().GetEncryptedMeta()) == 0 {
+		metaKeyID = 0
+	}
+
+	if err = checkVaultKeyIDs(ctx, h.vaultKeyProvider, userID, keyID, metaKeyID); err != nil {
+		return nil, err
 	}
 
 	metaData := domain.Meta{
-		ID:          metaID,
-		Title:       request.GetMetaData().Title,
-		Description: request.GetMetaData().Description,
-		Type:        request.GetMetaData().DataType,
-		DataID:      dataID,
-		UserID:      userID,
-		Created:     time.Now(),
-		Modified:    time.Now(),
+		ID:            metaID,
+		Title:         request.GetMetaData().Title,
+		Description:   request.GetMetaData().Description,
+		Type:          request.GetMetaData().DataType,
+		DataID:        dataID,
+		UserID:        userID,
+		FolderID:      folderID,
+		Tags:          tags,
+		EncryptedMeta: request.GetMetaData().GetEncryptedMeta(),
+		MetaKeyID:     metaKeyID,
+		Created:       time.Now(),
+		Modified:      time.Now(),
 	}
 
 	itemData := domain.ItemData{
-		ID:   dataID,
-		Data: request.GetData(),
+		ID:         dataID,
+		Data:       request.GetData(),
+		WrappedKey: request.GetWrappedKey(),
+		KeyID:      keyID,
 	}
 
-	if err = h.itemDataCreator.SaveItemData(&itemData, &metaData); err != nil {
+	// Квота проверяется у аутентифицированного пользователя, владельца метаданных
+	quota, err := userQuota(ctx, h.quotaProvider, h.defaultQuota, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.itemDataCreator.SaveItemData(ctx, &itemData, &metaData, quota); err != nil {
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		slog.ErrorContext(ctx, "could not save data", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -111,7 +205,11 @@ func (h *ItemsDataHandler) GetItemData(ctx context.Context, request *pb.GetItemD
 		return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", request.GetDataId())
 	}
 
-	item, err := h.itemDataProvider.GetItemDataByID(dataID)
+	if err = checkItemOwner(ctx, h.itemOwnerProvider, dataID); err != nil {
+		return nil, err
+	}
+
+	item, err := h.itemDataProvider.GetItemDataByID(ctx, dataID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			slog.ErrorContext(ctx, "no data found", slog.String("error", err.Error()))
@@ -122,6 +220,108 @@ func (h *ItemsDataHandler) GetItemData(ctx context.Context, request *pb.GetItemD
 	}
 
 	return &pb.GetItemDataResponse{
-			Data: item.Data},
+			Data:       item.Data,
+			WrappedKey: item.WrappedKey},
 		status.Errorf(codes.OK, "data gathered")
 }
+
+// checkItemOwner checks the item data, if it is stored already, belongs to the authenticated user
+// and is within the scope of the API token of the request.
+// The recipients of the shared items know their data IDs, but reach them only through their shares.
+func checkItemOwner(ctx context.Context, provider itemOwnerProvider, dataID uuid.UUID) error {
+	meta, err := provider.GetMetaDataByDataID(ctx, dataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		slog.ErrorContext(ctx, "could not get item meta", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if err = checkUserAccess(ctx, meta.UserID); err != nil {
+		return err
+	}
+
+	return checkTokenScope(ctx, meta.FolderID, meta.Type)
+}
+
+// checkMetaOwner checks the metadata record, if it is stored already, belongs to the user and describes the item data,
+// so saving the record can't take over the record of another user or move it to another item.
+func checkMetaOwner(ctx context.Context, provider itemOwnerProvider, metaID uuid.UUID, userID uuid.UUID, dataID uuid.UUID) error {
+	meta, err := provider.GetMetaDataByID(ctx, metaID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		slog.ErrorContext(ctx, "could not get meta", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if meta.UserID != userID {
+		return status.Error(codes.PermissionDenied, "access to another user data is denied")
+	}
+
+	if meta.DataID != dataID {
+		return status.Errorf(codes.InvalidArgument, "metadata %s does not describe data %s", metaID, dataID)
+	}
+
+	return nil
+}
+
+// checkFolderOwner checks the folder exists among the folders of the user.
+func (h *ItemsDataHandler) checkFolderOwner(ctx context.Context, folderID uuid.UUID, userID uuid.UUID) error {
+	folders, err := h.folderProvider.GetFoldersByUser(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get folders", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, v := range folders {
+		if v.ID == folderID {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.InvalidArgument, "folder %s not found", folderID)
+}
+
+// GetUsage returns the number of the items of the user and the size of their encrypted data with the quota of the user.
+func (h *ItemsDataHandler) GetUsage(ctx context.Context, request *pb.GetUsageRequest) (*pb.GetUsageResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := h.quotaProvider.GetUsage(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get usage", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	quota, err := userQuota(ctx, h.quotaProvider, h.defaultQuota, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetUsageResponse{
+		Items:       usage.Items,
+		Bytes:       usage.Bytes,
+		MaxItems:    quota.MaxItems,
+		MaxBytes:    quota.MaxBytes,
+		MaxItemSize: quota.MaxItemSize,
+	}, nil
+}
+
+// userQuota returns the quota set for the user by an operator, or the default quota.
+func userQuota(ctx context.Context, provider quotaProvider, defaultQuota domain.Quota, userID uuid.UUID) (*domain.Quota, error) {
+	quota, err := provider.GetUserQuota(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &defaultQuota, nil
+		}
+		slog.ErrorContext(ctx, "could not get quota", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return quota, nil
+}
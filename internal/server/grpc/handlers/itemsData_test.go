@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// newTestItemsDataHandler creates the items handler storing into the store with the default quota.
+func newTestItemsDataHandler(store *testStore, defaultQuota domain.Quota) *ItemsDataHandler {
+	return NewItemsDataHandler(store, store, store, store, store, store, defaultQuota)
+}
+
+func TestItemsDataHandler_PostItemData_Owner(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	ownMeta := &domain.Meta{ID: uuid.New(), DataID: uuid.New(), UserID: owner, Type: "text"}
+	otherMeta := &domain.Meta{ID: uuid.New(), DataID: uuid.New(), UserID: other, Type: "text"}
+
+	tests := []struct {
+		name     string
+		dataID   string
+		metaID   uuid.UUID
+		userID   uuid.UUID
+		wantCode codes.Code
+	}{
+		{name: "new item", dataID: uuid.NewString(), metaID: uuid.New(), userID: owner, wantCode: codes.OK},
+		{name: "own item", dataID: ownMeta.DataID.String(), metaID: ownMeta.ID, userID: owner, wantCode: codes.OK},
+		{name: "another user id", dataID: uuid.NewString(), metaID: uuid.New(), userID: other, wantCode: codes.PermissionDenied},
+		{name: "another user metadata", dataID: uuid.NewString(), metaID: otherMeta.ID, userID: owner, wantCode: codes.PermissionDenied},
+		{name: "another user item", dataID: otherMeta.DataID.String(), metaID: uuid.New(), userID: owner, wantCode: codes.PermissionDenied},
+		{name: "own metadata moved to another item", dataID: uuid.NewString(), metaID: ownMeta.ID, userID: owner, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(ownMeta, otherMeta)
+			handler := newTestItemsDataHandler(store, domain.Quota{})
+
+			_, err := handler.PostItemData(ContextWithUserID(context.Background(), owner.String()), &pb.PostItemDataRequest{
+				Data:   []byte("data"),
+				DataId: tt.dataID,
+				MetaData: &pb.MetaData{
+					Id:       tt.metaID.String(),
+					UserId:   tt.userID.String(),
+					DataType: "text",
+				},
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode != codes.OK {
+				assert.Empty(t, store.saved)
+				assert.Equal(t, other, store.metas[otherMeta.ID].UserID)
+				assert.Equal(t, otherMeta.DataID, store.metas[otherMeta.ID].DataID)
+				return
+			}
+			require.Len(t, store.saved, 1)
+			assert.Equal(t, owner, store.saved[0].UserID)
+		})
+	}
+}
+
+func TestItemsDataHandler_PostItemData_Quota(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		userID   uuid.UUID
+		data     []byte
+		wantCode codes.Code
+	}{
+		{name: "fits quota of the user", userID: owner, data: make([]byte, 10), wantCode: codes.OK},
+		{name: "exceeds quota of the user", userID: owner, data: make([]byte, 11), wantCode: codes.ResourceExhausted},
+		{name: "charged to another user", userID: other, data: make([]byte, 11), wantCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore()
+			store.quotas[owner] = &domain.Quota{MaxItemSize: 10}
+			store.quotas[other] = &domain.Quota{}
+			handler := newTestItemsDataHandler(store, domain.Quota{MaxItemSize: 1000})
+
+			_, err := handler.PostItemData(ContextWithUserID(context.Background(), owner.String()), &pb.PostItemDataRequest{
+				Data:     tt.data,
+				DataId:   uuid.NewString(),
+				MetaData: &pb.MetaData{Id: uuid.NewString(), UserId: tt.userID.String(), DataType: "text"},
+			})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				require.Len(t, store.savedQuotas, 1)
+				assert.Equal(t, store.quotas[owner], store.savedQuotas[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+	"github.com/mikhaylov123ty/GophKeeper/internal/srp"
+)
+
+const (
+	srpSessionTTL        = time.Minute
+	srpSessionsLimit     = 10000
+	srpSessionsPeerLimit = 20
+)
+
+// The parameters of the decoy SRP records match the ones the client registers with,
+// so the decoy exchange can't be told from the exchange of a registered account.
+const (
+	decoySaltSize   = 16
+	decoyKDFTime    = 3
+	decoyKDFMemory  = 64 * 1024
+	decoyKDFThreads = 4
+)
+
+// srpSession holds the state of an SRP exchange between StartLogin and the request carrying the client proof.
+// The decoy session is started for an unknown login or a legacy account and never succeeds.
+type srpSession struct {
+	user         *domain.UserData
+	clientPublic []byte
+	server       *srp.Server
+	decoy        bool
+	peer         string
+	expires      time.Time
+}
+
+// srpSessions keeps the unfinished SRP exchanges in memory. A session is used once and expires after srpSessionTTL,
+// the number of the sessions is limited in total and for a peer, so the exchanges can't exhaust the memory
+// of the server and a single peer can't take all of the sessions.
+type srpSessions struct {
+	mu       sync.Mutex
+	sessions map[string]*srpSession
+}
+
+// newSRPSessions creates an empty store of the SRP exchanges.
+func newSRPSessions() *srpSessions {
+	return &srpSessions{
+		sessions: map[string]*srpSession{},
+	}
+}
+
+// add stores the session and returns its identifier.
+func (s *srpSessions) add(session *srpSession) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var peerSessions int
+	for id, v := range s.sessions {
+		if now.After(v.expires) {
+			delete(s.sessions, id)
+			continue
+		}
+		if v.peer == session.peer {
+			peerSessions++
+		}
+	}
+
+	if len(s.sessions) >= srpSessionsLimit || peerSessions >= srpSessionsPeerLimit {
+		return "", errors.New("too many login attempts in progress")
+	}
+
+	id := uuid.New().String()
+	session.expires = now.Add(srpSessionTTL)
+	s.sessions[id] = session
+
+	return id, nil
+}
+
+// take removes the session from the store and returns it, if it is not expired.
+func (s *srpSessions) take(id string) (*srpSession, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	delete(s.sessions, id)
+
+	return session, time.Now().Before(session.expires)
+}
+
+// RegisterUser creates a user logging in with SRP. The server receives the verifier of the secret derived from
+// the master password on the client and never sees the password itself.
+func (a *AuthHandler) RegisterUser(ctx context.Context, request *pb.RegisterUserRequest) (*pb.RegisterUserResponse, error) {
+	if request.GetLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "login is empty")
+	}
+
+	user := &domain.UserData{
+		ID:       uuid.New(),
+		Login:    request.GetLogin(),
+		Created:  time.Now(),
+		Modified: time.Now(),
+	}
+	if err := setSRPRecord(user, request.GetSrpRecord()); err != nil {
+		return nil, err
+	}
+
+	storageUser, err := a.userProvider.GetUserByLogin(ctx, request.GetLogin())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get user by login", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+	if storageUser != nil {
+		return nil, status.Error(codes.AlreadyExists, "login is already taken")
+	}
+
+	if err = a.userCreator.SaveUser(ctx, user); err != nil {
+		slog.ErrorContext(ctx, "failed to save user", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to save user")
+	}
+
+	return &pb.RegisterUserResponse{}, nil
+}
+
+// StartLogin starts an SRP exchange: it receives the public key A of the client and returns the public key B
+// of the server with the salt and the Argon2id parameters of the user.
+// The response doesn't tell whether the account exists: an unknown login and an account created before the SRP login
+// get a decoy record derived from the login and the login secret of the server, the same on every request,
+// and the exchange fails on the proof as with a wrong password. The legacy accounts log in once with PostUserData,
+// which upgrades them.
+// The exchange is finished by FinishLogin or by a request confirming the password, such as ChangePassword.
+func (a *AuthHandler) StartLogin(ctx context.Context, request *pb.StartLoginRequest) (*pb.StartLoginResponse, error) {
+	if request.GetLogin() == "" || len(request.GetClientPublicKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "login or public key is empty")
+	}
+
+	user, err := a.userProvider.GetUserByLogin(ctx, request.GetLogin())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to get user by login", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to get user")
+	}
+
+	session := &srpSession{
+		user:         user,
+		clientPublic: request.GetClientPublicKey(),
+		peer:         peerHost(ctx),
+	}
+	if user == nil || len(user.SRPVerifier) == 0 {
+		session.user = a.decoyUser(request.GetLogin(), user)
+		session.decoy = true
+	}
+
+	if session.server, err = srp.NewServer(session.user.SRPVerifier); err != nil {
+		slog.ErrorContext(ctx, "failed to start srp exchange", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "failed to start login")
+	}
+
+	sessionID, err := a.srpSessions.add(session)
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	return &pb.StartLoginResponse{
+		SessionId:       sessionID,
+		ServerPublicKey: session.server.PublicKey(),
+		SrpRecord: &pb.SrpRecord{
+			Salt:       session.user.SRPSalt,
+			KdfTime:    session.user.KDFTime,
+			KdfMemory:  session.user.KDFMemory,
+			KdfThreads: session.user.KDFThreads,
+		},
+	}, nil
+}
+
+// FinishLogin checks the proof M1 of the client and returns the token, the vault keys of the user
+// and the proof M2 of the server, confirming to the client the server knows the verifier.
+func (a *AuthHandler) FinishLogin(ctx context.Context, request *pb.FinishLoginRequest) (*pb.PostUserDataResponse, error) {
+	session, serverProof, err := a.verifySRPSession(ctx, request.GetSessionId(), request.GetClientProof())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.loginResponse(ctx, session.user)
+	if err != nil {
+		return nil, err
+	}
+	res.ServerProof = serverProof
+
+	return res, nil
+}
+
+// checkPassword finishes the SRP exchange started by the user to confirm the current password
+// and returns the stored user.
+func (a *AuthHandler) checkPassword(ctx context.Context, userID uuid.UUID, sessionID string, proof []byte) (*domain.UserData, error) {
+	session, _, err := a.verifySRPSession(ctx, sessionID, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.user.ID != userID {
+		return nil, status.Error(codes.PermissionDenied, "login session belongs to another user")
+	}
+
+	user, err := a.passwordUpdater.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		slog.ErrorContext(ctx, "could not get user", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return user, nil
+}
+
+// verifySRPSession takes the SRP exchange and checks the proof of the client, returning the proof of the server.
+func (a *AuthHandler) verifySRPSession(ctx context.Context, sessionID string, proof []byte) (*srpSession, []byte, error) {
+	session, ok := a.srpSessions.take(sessionID)
+	if !ok {
+		return nil, nil, status.Error(codes.PermissionDenied, "login session is expired, try again")
+	}
+	if session.user.ID != uuid.Nil {
+		setAuditSubject(ctx, session.user.ID)
+	}
+
+	serverProof, err := session.server.Verify(session.user.Login, session.user.SRPSalt, session.clientPublic, proof)
+	if err == nil && session.decoy {
+		err = srp.ErrProof
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "srp proof not match", slog.String("error", err.Error()))
+		return nil, nil, status.Error(codes.PermissionDenied, "login or password is incorrect")
+	}
+
+	return session, serverProof, nil
+}
+
+// decoyUser returns the user with the decoy SRP record of the login. The salt and the secret of the verifier
+// are derived from the login with the login secret of the server, so the record doesn't change between the requests
+// and the restarts, and nobody knows the password matching it. The ID of a legacy account is kept for the audit.
+func (a *AuthHandler) decoyUser(login string, legacy *domain.UserData) *domain.UserData {
+	user := &domain.UserData{
+		Login:      login,
+		SRPSalt:    a.loginSecretMAC("salt", login)[:decoySaltSize],
+		KDFTime:    decoyKDFTime,
+		KDFMemory:  decoyKDFMemory,
+		KDFThreads: decoyKDFThreads,
+	}
+	user.SRPVerifier = srp.Verifier(a.loginSecretMAC("verifier", login))
+	if legacy != nil {
+		user.ID = legacy.ID
+	}
+
+	return user
+}
+
+// loginSecretMAC returns the HMAC-SHA256 of the purpose and the login keyed with the login secret of the server.
+func (a *AuthHandler) loginSecretMAC(purpose string, login string) []byte {
+	mac := hmac.New(sha256.New, a.loginSecret)
+	mac.Write([]byte(purpose + "|" + login))
+
+	return mac.Sum(nil)
+}
+
+// peerHost returns the host of the peer of the request, the port is dropped as the client changes it
+// on every connection.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// setSRPRecord validates the SRP registration record and sets it to the user, dropping the bcrypt password hash.
+func setSRPRecord(user *domain.UserData, record *pb.SrpRecord) error {
+	if record == nil {
+		return status.Error(codes.InvalidArgument, "empty srp record")
+	}
+
+	user.Password = ""
+	user.SRPSalt = record.GetSalt()
+	user.SRPVerifier = record.GetVerifier()
+	user.KDFTime = record.GetKdfTime()
+	user.KDFMemory = record.GetKdfMemory()
+	user.KDFThreads = record.GetKdfThreads()
+
+	if err := domain.ValidateSRPRecord(user); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := srp.ValidateVerifier(user.SRPVerifier); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+	"github.com/mikhaylov123ty/GophKeeper/internal/srp"
+)
+
+// testUserStore keeps the users of the login tests by login.
+type testUserStore struct {
+	users map[string]*domain.UserData
+}
+
+func (s *testUserStore) GetUserByLogin(_ context.Context, login string) (*domain.UserData, error) {
+	if user, ok := s.users[login]; ok {
+		return user, nil
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+// testTokenIssuer issues the user ID as the token.
+type testTokenIssuer struct{}
+
+func (testTokenIssuer) Issue(userID string, _ int, _ time.Time) (string, error) {
+	return userID, nil
+}
+
+// newTestAuthHandler creates the auth handler logging in the users with the login secret.
+func newTestAuthHandler(users *testUserStore, loginSecret string) *AuthHandler {
+	return NewAuthHandler(nil, users, nil, newTestStore(), nil, nil, nil, testTokenIssuer{}, []byte(loginSecret))
+}
+
+// testSRPUser returns the user registered with the SRP record of the secret x.
+func testSRPUser(t *testing.T, login string, x []byte) *domain.UserData {
+	salt := make([]byte, decoySaltSize)
+	_, err := rand.Read(salt)
+	require.NoError(t, err)
+
+	return &domain.UserData{
+		ID:          uuid.New(),
+		Login:       login,
+		SRPSalt:     salt,
+		SRPVerifier: srp.Verifier(x),
+		KDFTime:     decoyKDFTime,
+		KDFMemory:   decoyKDFMemory,
+		KDFThreads:  decoyKDFThreads,
+	}
+}
+
+// testLogin runs the SRP exchange with the secret x and returns the response of StartLogin and FinishLogin.
+func testLogin(t *testing.T, handler *AuthHandler, ctx context.Context, login string, x []byte) (*pb.StartLoginResponse, error) {
+	client, err := srp.NewClient()
+	require.NoError(t, err)
+
+	start, err := handler.StartLogin(ctx, &pb.StartLoginRequest{Login: login, ClientPublicKey: client.PublicKey()})
+	require.NoError(t, err)
+
+	proof, err := client.Proof(login, start.GetSrpRecord().GetSalt(), x, start.GetServerPublicKey())
+	require.NoError(t, err)
+
+	_, err = handler.FinishLogin(ctx, &pb.FinishLoginRequest{SessionId: start.GetSessionId(), ClientProof: proof})
+
+	return start, err
+}
+
+func TestAuthHandler_StartLogin_Decoy(t *testing.T) {
+	x := []byte("secret derived from the password")
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	registered := testSRPUser(t, "registered", x)
+	users := &testUserStore{users: map[string]*domain.UserData{
+		"registered": registered,
+		"legacy":     {ID: uuid.New(), Login: "legacy", Password: string(hash)},
+	}}
+	handler := newTestAuthHandler(users, "login secret")
+
+	start, err := testLogin(t, handler, context.Background(), "registered", x)
+	require.NoError(t, err)
+	assert.Equal(t, registered.SRPSalt, start.GetSrpRecord().GetSalt())
+
+	for _, login := range []string{"unknown", "legacy"} {
+		t.Run(login, func(t *testing.T) {
+			start, err := testLogin(t, handler, context.Background(), login, x)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			assert.Equal(t, "login or password is incorrect", status.Convert(err).Message())
+
+			record := start.GetSrpRecord()
+			assert.Len(t, record.GetSalt(), decoySaltSize)
+			assert.Len(t, start.GetServerPublicKey(), srp.KeySize)
+			assert.NoError(t, domain.ValidateSRPRecord(&domain.UserData{
+				SRPSalt: record.GetSalt(), SRPVerifier: []byte{1},
+				KDFTime: record.GetKdfTime(), KDFMemory: record.GetKdfMemory(), KDFThreads: record.GetKdfThreads(),
+			}))
+
+			again, _ := testLogin(t, handler, context.Background(), login, x)
+			assert.Equal(t, record.GetSalt(), again.GetSrpRecord().GetSalt())
+			assert.NotEqual(t, start.GetServerPublicKey(), again.GetServerPublicKey())
+
+			other, _ := testLogin(t, newTestAuthHandler(users, "another secret"), context.Background(), login, x)
+			assert.NotEqual(t, record.GetSalt(), other.GetSrpRecord().GetSalt())
+		})
+	}
+}
+
+func TestAuthHandler_PostUserData_UnknownLogin(t *testing.T) {
+	users := &testUserStore{users: map[string]*domain.UserData{
+		"registered": testSRPUser(t, "registered", []byte("x")),
+	}}
+	handler := newTestAuthHandler(users, "login secret")
+
+	for _, login := range []string{"unknown", "registered"} {
+		t.Run(login, func(t *testing.T) {
+			_, err := handler.PostUserData(context.Background(), &pb.PostUserDataRequest{Login: login, Password: "password"})
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			assert.Equal(t, "login or password is incorrect", status.Convert(err).Message())
+		})
+	}
+}
+
+func TestAuthHandler_StartLogin_PeerLimit(t *testing.T) {
+	handler := newTestAuthHandler(&testUserStore{}, "login secret")
+	client, err := srp.NewClient()
+	require.NoError(t, err)
+
+	peerContext := func(ip string, port int) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: port}})
+	}
+	request := &pb.StartLoginRequest{Login: "unknown", ClientPublicKey: client.PublicKey()}
+
+	for i := range srpSessionsPeerLimit {
+		_, err = handler.StartLogin(peerContext("192.0.2.1", 1000+i), request)
+		require.NoError(t, err)
+	}
+
+	_, err = handler.StartLogin(peerContext("192.0.2.1", 2000), request)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = handler.StartLogin(peerContext("192.0.2.2", 1000), request)
+	assert.NoError(t, err)
+}
@@ -3,8 +3,10 @@ package handlers
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,44 +17,100 @@ import (
 	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
 )
 
+const (
+	defaultMetaPageSize = 100
+	maxMetaPageSize     = 1000
+)
+
 // MetaDataHandler provides methods to handle metadata operations such as retrieval and deletion.
 // It embeds pb.UnimplementedMetaDataHandlersServer for forward compatibility.
-// Utilizes metaDataProvider for fetching metadata and dataRemover for metadata removal.
+// Utilizes metaDataProvider for fetching metadata, dataRemover for metadata removal and itemOwnerProvider
+// to check the removed item belongs to the user.
 type MetaDataHandler struct {
 	pb.UnimplementedMetaDataHandlersServer
-	metaDataProvider metaDataProvider
-	dataRemover      dataRemover
+	metaDataProvider  metaDataProvider
+	dataRemover       dataRemover
+	itemOwnerProvider itemOwnerProvider
 }
 
 // metaDataProvider defines an interface for retrieving metadata associated with a given user ID.
-// GetMetaDataByUser retrieves metadata associated with the provided user UUID and returns a slice of Meta objects or an error.
+// GetMetaDataByUser retrieves metadata associated with the provided user UUID, narrowed down by the filter,
+// and returns a slice of Meta objects or an error.
 type metaDataProvider interface {
-	GetMetaDataByUser(uuid.UUID) ([]*domain.Meta, error)
+	GetMetaDataByUser(context.Context, uuid.UUID, *domain.MetaFilter) ([]*domain.Meta, error)
 }
 
 // dataRemover defines methods to delete item and metadata by their unique identifier.
 // DeleteItemDataByID removes the associated item data using a UUID.
-// DeleteMetaDataByID removes the metadata associated with a UUID.
+// DeleteMetaDataByID removes the metadata of the user associated with a UUID.
 type dataRemover interface {
-	DeleteItemDataByID(uuid.UUID) error
-	DeleteMetaDataByID(uuid.UUID) error
+	DeleteItemDataByID(context.Context, uuid.UUID) error
+	DeleteMetaDataByID(context.Context, uuid.UUID, uuid.UUID) error
 }
 
-// NewMetaDataHandler creates and initializes a new MetaDataHandler with the provided metaDataProvider and dataRemover.
-func NewMetaDataHandler(metaDataProvider metaDataProvider, dataRemover dataRemover) *MetaDataHandler {
+// NewMetaDataHandler creates and initializes a new MetaDataHandler with the provided metaDataProvider, dataRemover
+// and itemOwnerProvider.
+func NewMetaDataHandler(metaDataProvider metaDataProvider, dataRemover dataRemover,
+	itemOwnerProvider itemOwnerProvider) *MetaDataHandler {
 	return &MetaDataHandler{
-		metaDataProvider: metaDataProvider,
-		dataRemover:      dataRemover,
+		metaDataProvider:  metaDataProvider,
+		dataRemover:       dataRemover,
+		itemOwnerProvider: itemOwnerProvider,
 	}
 }
 
+// GetMetaData returns a page of the metadata of the user items ordered by ID. The items are narrowed down by the tag,
+// folder, data type and modification time of the request when they are set. The next_page_token of the response
+// requests the following page and is empty on the last one. The items out of the scope of the API token
+// of the request are left out.
 func (m *MetaDataHandler) GetMetaData(ctx context.Context, request *pb.GetMetaDataRequest) (*pb.GetMetaDataResponse, error) {
-	userID, err := uuid.Parse(request.GetUserId())
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	folderID, err := parseOptionalID(request.GetFolderId())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id %s", request.GetUserId())
+		return nil, status.Errorf(codes.InvalidArgument, "invalid folder_id %s", request.GetFolderId())
+	}
+
+	token, restricted := APITokenFromContext(ctx)
+	if restricted {
+		if folderID != uuid.Nil && !token.Scope.AllowsFolder(folderID) ||
+			request.GetDataType() != "" && !token.Scope.AllowsDataType(request.GetDataType()) {
+			return nil, status.Error(codes.PermissionDenied, "filter is out of the API token scope")
+		}
+	}
+
+	pageSize := int(request.GetPageSize())
+	switch {
+	case pageSize < 0 || pageSize > maxMetaPageSize:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 0 and %d", maxMetaPageSize)
+	case pageSize == 0:
+		pageSize = defaultMetaPageSize
 	}
 
-	metaDataItems, err := m.metaDataProvider.GetMetaDataByUser(userID)
+	afterID, err := decodePageToken(request.GetPageToken())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_token %s", request.GetPageToken())
+	}
+
+	var modifiedSince time.Time
+	if request.GetModifiedSince() != "" {
+		if modifiedSince, err = time.Parse(time.RFC3339, request.GetModifiedSince()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid modified_since %s", request.GetModifiedSince())
+		}
+	}
+
+	// Запрашивается на одну запись больше страницы, чтобы узнать есть ли следующая
+	metaDataItems, err := m.metaDataProvider.GetMetaDataByUser(ctx, userID, &domain.MetaFilter{
+		Tag:           strings.TrimSpace(request.GetTag()),
+		FolderID:      folderID,
+		DataType:      request.GetDataType(),
+		ModifiedSince: modifiedSince,
+		AfterID:       afterID,
+		Limit:         pageSize + 1,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			slog.ErrorContext(ctx, "no metaData found", slog.String("error", err.Error()))
@@ -62,26 +120,69 @@ func (m *MetaDataHandler) GetMetaData(ctx context.Context, request *pb.GetMetaDa
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	protoItems := make([]*pb.MetaData, len(metaDataItems))
-	for i, v := range metaDataItems {
-		protoItems[i] = &pb.MetaData{
-			Id:          v.ID.String(),
-			Title:       v.Title,
-			Description: v.Description,
-			DataType:    v.Type,
-			DataId:      v.DataID.String(),
-			UserId:      v.UserID.String(),
-			Modified:    v.Modified.Format(time.RFC3339),
-			Created:     v.Created.Format(time.RFC3339),
+	var nextPageToken string
+	if len(metaDataItems) > pageSize {
+		metaDataItems = metaDataItems[:pageSize]
+		nextPageToken = encodePageToken(metaDataItems[pageSize-1].ID)
+	}
+
+	// Страница отсчитывается до фильтрации по токену, поэтому может быть короче запрошенной
+	protoItems := make([]*pb.MetaData, 0, len(metaDataItems))
+	for _, v := range metaDataItems {
+		if restricted && !token.Scope.Allows(v.FolderID, v.Type) {
+			continue
 		}
+		protoItems = append(protoItems, metaToProto(v))
 	}
 
 	return &pb.GetMetaDataResponse{
-			Items: protoItems},
+			Items:         protoItems,
+			NextPageToken: nextPageToken},
 		status.Errorf(codes.OK, "meta gathered")
 }
 
+// metaToProto converts the metadata of an item into its gRPC representation.
+func metaToProto(v *domain.Meta) *pb.MetaData {
+	res := &pb.MetaData{
+		Id:            v.ID.String(),
+		Title:         v.Title,
+		Description:   v.Description,
+		DataType:      v.Type,
+		DataId:        v.DataID.String(),
+		UserId:        v.UserID.String(),
+		Modified:      v.Modified.Format(time.RFC3339),
+		Created:       v.Created.Format(time.RFC3339),
+		Tags:          v.Tags,
+		EncryptedMeta: v.EncryptedMeta,
+	}
+	if v.FolderID != uuid.Nil {
+		res.FolderId = v.FolderID.String()
+	}
+
+	return res
+}
+
+// encodePageToken builds the opaque page token pointing after the item with the given ID.
+func encodePageToken(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// decodePageToken returns the ID of the last item of the previous page, an empty token stands for the first page.
+func decodePageToken(token string) (uuid.UUID, error) {
+	if token == "" {
+		return uuid.Nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.FromBytes(raw)
+}
+
 // DeleteMetaData removes metadata and associated data by their unique IDs parsed from the request and returns a response.
+// The metadata must belong to the authenticated user and describe the item data of the request.
 func (m *MetaDataHandler) DeleteMetaData(ctx context.Context, request *pb.DeleteMetaDataRequest) (*pb.DeleteMetaDataResponse, error) {
 	metaDataID, err := uuid.Parse(request.GetMetadataId())
 	if err != nil {
@@ -93,12 +194,33 @@ func (m *MetaDataHandler) DeleteMetaData(ctx context.Context, request *pb.Delete
 		return nil, status.Errorf(codes.InvalidArgument, "invalid dataId %s", request.GetDataId())
 	}
 
-	if err = m.dataRemover.DeleteItemDataByID(dataID); err != nil {
+	meta, err := m.itemOwnerProvider.GetMetaDataByID(ctx, metaDataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "metadata %s not found", metaDataID)
+		}
+		slog.ErrorContext(ctx, "could not get metaData", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if err = checkUserAccess(ctx, meta.UserID); err != nil {
+		return nil, err
+	}
+
+	if meta.DataID != dataID {
+		return nil, status.Errorf(codes.InvalidArgument, "metadata %s does not describe data %s", metaDataID, dataID)
+	}
+
+	if err = checkTokenScope(ctx, meta.FolderID, meta.Type); err != nil {
+		return nil, err
+	}
+
+	if err = m.dataRemover.DeleteItemDataByID(ctx, dataID); err != nil {
 		slog.ErrorContext(ctx, "could not delete bank card", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	if err = m.dataRemover.DeleteMetaDataByID(metaDataID); err != nil {
+	if err = m.dataRemover.DeleteMetaDataByID(ctx, metaDataID, meta.UserID); err != nil {
 		slog.ErrorContext(ctx, "could not delete metaData", slog.String("error", err.Error()))
 		return nil, status.Error(codes.Internal, err.Error())
 	}
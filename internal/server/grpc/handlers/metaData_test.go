@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+func TestMetaDataHandler_DeleteMetaData(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	ownMeta := &domain.Meta{ID: uuid.New(), DataID: uuid.New(), UserID: owner, Type: "text"}
+	otherMeta := &domain.Meta{ID: uuid.New(), DataID: uuid.New(), UserID: other, Type: "text"}
+
+	tests := []struct {
+		name        string
+		metadataID  uuid.UUID
+		dataID      uuid.UUID
+		wantCode    codes.Code
+		wantDeleted bool
+	}{
+		{name: "own item", metadataID: ownMeta.ID, dataID: ownMeta.DataID, wantCode: codes.OK, wantDeleted: true},
+		{name: "another user metadata with own data", metadataID: otherMeta.ID, dataID: ownMeta.DataID, wantCode: codes.PermissionDenied},
+		{name: "another user item", metadataID: otherMeta.ID, dataID: otherMeta.DataID, wantCode: codes.PermissionDenied},
+		{name: "own metadata with another data", metadataID: ownMeta.ID, dataID: otherMeta.DataID, wantCode: codes.InvalidArgument},
+		{name: "unknown metadata", metadataID: uuid.New(), dataID: ownMeta.DataID, wantCode: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(ownMeta, otherMeta)
+			handler := NewMetaDataHandler(store, store, store)
+
+			_, err := handler.DeleteMetaData(ContextWithUserID(context.Background(), owner.String()),
+				&pb.DeleteMetaDataRequest{MetadataId: tt.metadataID.String(), DataId: tt.dataID.String()})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantDeleted {
+				assert.Equal(t, []uuid.UUID{tt.dataID}, store.deletedData)
+				assert.Equal(t, []uuid.UUID{tt.metadataID}, store.deletedMeta)
+			} else {
+				assert.Empty(t, store.deletedData)
+				assert.Empty(t, store.deletedMeta)
+			}
+		})
+	}
+}
+
+func TestMetaDataHandler_DeleteMetaData_TokenScope(t *testing.T) {
+	owner := uuid.New()
+	meta := &domain.Meta{ID: uuid.New(), DataID: uuid.New(), UserID: owner, Type: "text"}
+	store := newTestStore(meta)
+	handler := NewMetaDataHandler(store, store, store)
+
+	ctx := ContextWithAPIToken(ContextWithUserID(context.Background(), owner.String()),
+		&domain.APIToken{UserID: owner, Scope: domain.APITokenScope{DataTypes: []string{"credentials"}}})
+
+	_, err := handler.DeleteMetaData(ctx, &pb.DeleteMetaDataRequest{MetadataId: meta.ID.String(), DataId: meta.DataID.String()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Empty(t, store.deletedMeta)
+}
@@ -0,0 +1,923 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// firstCollectionKeyID is the version of the key a new collection is created with.
+const firstCollectionKeyID uint32 = 1
+
+// OrgHandler handles the organizations, their members and the collections of items shared by the members,
+// and implements the gRPC OrgHandlersServer interface. Each collection has its own key sealed on the client
+// to the public sharing key of every member, the server never sees the collection keys or the items.
+// The roles of the members are enforced here: the owner and the admins manage the members and the collections,
+// the members change the items and the read-only members only read them.
+// It relies on orgStore and collectionStore to keep the data, on userProvider to find the invited users,
+// on sharingKeyStore to check they are able to receive the collection keys and on quotaProvider to limit the items
+// of the members, defaultQuota applies to the users without the quota set by an operator.
+type OrgHandler struct {
+	pb.UnimplementedOrgHandlersServer
+	orgStore        orgStore
+	collectionStore collectionStore
+	userProvider    userProvider
+	sharingKeyStore sharingKeyStore
+	quotaProvider   quotaProvider
+	defaultQuota    domain.Quota
+}
+
+// orgStore defines a contract for storing the organizations and the memberships of the users.
+type orgStore interface {
+	SaveOrg(context.Context, *domain.Organization, *domain.OrgMember) error
+	GetOrgsByUser(context.Context, uuid.UUID) ([]*domain.Organization, error)
+	DeleteOrg(context.Context, uuid.UUID) error
+	GetOrgMember(context.Context, uuid.UUID, uuid.UUID) (*domain.OrgMember, error)
+	GetOrgMembers(context.Context, uuid.UUID) ([]*domain.OrgMember, error)
+	SaveOrgMember(context.Context, *domain.OrgMember, []*domain.CollectionKey) error
+	AcceptOrgMember(context.Context, uuid.UUID, uuid.UUID) error
+	UpdateOrgMemberRole(context.Context, uuid.UUID, uuid.UUID, string) error
+	DeleteOrgMember(context.Context, uuid.UUID, uuid.UUID) error
+}
+
+// collectionStore defines a contract for storing the collections of the organizations, their keys and items.
+type collectionStore interface {
+	SaveCollection(context.Context, *domain.Collection, []*domain.CollectionKey) error
+	GetCollectionsByOrg(context.Context, uuid.UUID, uuid.UUID) ([]*domain.Collection, error)
+	GetCollectionByID(context.Context, uuid.UUID) (*domain.Collection, error)
+	DeleteCollection(context.Context, uuid.UUID) error
+	GetCollectionItems(context.Context, uuid.UUID) ([]*domain.CollectionItem, error)
+	GetCollectionItem(context.Context, uuid.UUID) (*domain.CollectionItem, error)
+	SaveCollectionItem(context.Context, *domain.CollectionItem, *domain.Quota) error
+	DeleteCollectionItem(context.Context, uuid.UUID, uuid.UUID) error
+	RekeyCollection(context.Context, uuid.UUID, uint32, []*domain.CollectionKey, []*domain.CollectionItem) error
+}
+
+// NewOrgHandler initializes and returns a new instance of OrgHandler with the provided storage dependencies
+// and the default quota of the users.
+func NewOrgHandler(
+	orgStore orgStore,
+	collectionStore collectionStore,
+	userProvider userProvider,
+	sharingKeyStore sharingKeyStore,
+	quotaProvider quotaProvider,
+	defaultQuota domain.Quota,
+) *OrgHandler {
+	return &OrgHandler{
+		orgStore:        orgStore,
+		collectionStore: collectionStore,
+		userProvider:    userProvider,
+		sharingKeyStore: sharingKeyStore,
+		quotaProvider:   quotaProvider,
+		defaultQuota:    defaultQuota,
+	}
+}
+
+// CreateOrg creates an organization owned by the user. The owner needs a sharing key to receive the collection keys.
+func (h *OrgHandler) CreateOrg(ctx context.Context, request *pb.CreateOrgRequest) (*pb.CreateOrgResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(request.GetName())
+	if err = domain.ValidateOrgName(name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if _, err = h.memberKey(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	org := &domain.Organization{
+		ID:       uuid.New(),
+		Name:     name,
+		OwnerID:  userID,
+		Role:     domain.OrgRoleOwner,
+		Accepted: true,
+		Created:  time.Now(),
+		Modified: time.Now(),
+	}
+
+	owner := &domain.OrgMember{
+		OrgID:    org.ID,
+		UserID:   userID,
+		Role:     domain.OrgRoleOwner,
+		Accepted: true,
+		Created:  org.Created,
+		Modified: org.Modified,
+	}
+
+	if err = h.orgStore.SaveOrg(ctx, org, owner); err != nil {
+		slog.ErrorContext(ctx, "could not save org", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CreateOrgResponse{Organization: orgToProto(org)}, nil
+}
+
+// GetOrgs returns the organizations the user is a member of or invited to, with the role of the user.
+func (h *OrgHandler) GetOrgs(ctx context.Context, request *pb.GetOrgsRequest) (*pb.GetOrgsResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := h.orgStore.GetOrgsByUser(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get orgs", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := make([]*pb.Organization, len(orgs))
+	for i, v := range orgs {
+		res[i] = orgToProto(v)
+	}
+
+	return &pb.GetOrgsResponse{Organizations: res}, nil
+}
+
+// DeleteOrg removes the organization with all its collections and items. Only the owner deletes the organization.
+func (h *OrgHandler) DeleteOrg(ctx context.Context, request *pb.DeleteOrgRequest) (*pb.DeleteOrgResponse, error) {
+	member, err := h.member(ctx, request.GetUserId(), request.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role != domain.OrgRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only the owner deletes the organization")
+	}
+
+	if err = h.orgStore.DeleteOrg(ctx, member.OrgID); err != nil {
+		slog.ErrorContext(ctx, "could not delete org", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DeleteOrgResponse{}, nil
+}
+
+// GetOrgMembers returns the members and the invited users of the organization with their public sharing keys,
+// the collection keys are sealed to them.
+func (h *OrgHandler) GetOrgMembers(ctx context.Context, request *pb.GetOrgMembersRequest) (*pb.GetOrgMembersResponse, error) {
+	member, err := h.member(ctx, request.GetUserId(), request.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := h.members(ctx, member.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*pb.OrgMember, len(members))
+	for i, v := range members {
+		res[i] = orgMemberToProto(v)
+	}
+
+	return &pb.GetOrgMembersResponse{Members: res}, nil
+}
+
+// InviteMember invites the user of the login into the organization with the role. The request carries the keys
+// of all collections of the organization sealed to the invited user, who receives them after accepting the invitation.
+func (h *OrgHandler) InviteMember(ctx context.Context, request *pb.InviteMemberRequest) (*pb.InviteMemberResponse, error) {
+	member, err := h.member(ctx, request.GetUserId(), request.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateRoleChange(member.Role, "", request.GetRole()); err != nil {
+		return nil, roleError(err)
+	}
+
+	if request.GetLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty login")
+	}
+
+	user, err := h.userProvider.GetUserByLogin(ctx, request.GetLogin())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", request.GetLogin())
+		}
+		slog.ErrorContext(ctx, "could not get user", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	key, err := h.memberKey(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	invited := &domain.OrgMember{
+		OrgID:     member.OrgID,
+		UserID:    user.ID,
+		Login:     user.Login,
+		Role:      request.GetRole(),
+		PublicKey: key.PublicKey,
+		Created:   time.Now(),
+		Modified:  time.Now(),
+	}
+
+	keys, err := parseCollectionKeys(request.GetKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.checkInvitationKeys(ctx, member, invited, keys); err != nil {
+		return nil, err
+	}
+
+	if err = h.orgStore.SaveOrgMember(ctx, invited, keys); err != nil {
+		if errors.Is(err, domain.ErrOrgMemberExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save org member", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.InviteMemberResponse{Member: orgMemberToProto(invited)}, nil
+}
+
+// AnswerInvitation accepts or declines the invitation of the user into the organization.
+func (h *OrgHandler) AnswerInvitation(ctx context.Context, request *pb.AnswerInvitationRequest) (*pb.AnswerInvitationResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := h.membership(ctx, userID, request.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Accepted {
+		return nil, status.Error(codes.FailedPrecondition, "invitation is already accepted")
+	}
+
+	if request.GetAccept() {
+		err = h.orgStore.AcceptOrgMember(ctx, member.OrgID, userID)
+	} else {
+		err = h.orgStore.DeleteOrgMember(ctx, member.OrgID, userID)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "organization %s not found", member.OrgID)
+		}
+		slog.ErrorContext(ctx, "could not answer invitation", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.AnswerInvitationResponse{}, nil
+}
+
+// RemoveMember removes the member from the organization together with the collection keys sealed to the member.
+// The removed member may have kept the keys, so the client re-keys the collections of the organization afterwards.
+func (h *OrgHandler) RemoveMember(ctx context.Context, request *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	member, target, err := h.targetMember(ctx, request.GetUserId(), request.GetOrgId(), request.GetMemberId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateMemberRemoval(member.Role, target.Role); err != nil {
+		return nil, roleError(err)
+	}
+
+	if err = h.orgStore.DeleteOrgMember(ctx, target.OrgID, target.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "member %s not found", target.UserID)
+		}
+		slog.ErrorContext(ctx, "could not delete org member", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RemoveMemberResponse{}, nil
+}
+
+// ChangeRole changes the role of the member of the organization.
+func (h *OrgHandler) ChangeRole(ctx context.Context, request *pb.ChangeRoleRequest) (*pb.ChangeRoleResponse, error) {
+	member, target, err := h.targetMember(ctx, request.GetUserId(), request.GetOrgId(), request.GetMemberId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateRoleChange(member.Role, target.Role, request.GetRole()); err != nil {
+		return nil, roleError(err)
+	}
+
+	if err = h.orgStore.UpdateOrgMemberRole(ctx, target.OrgID, target.UserID, request.GetRole()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "member %s not found", target.UserID)
+		}
+		slog.ErrorContext(ctx, "could not update org member role", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ChangeRoleResponse{}, nil
+}
+
+// CreateCollection creates a collection of the organization. The collection ID is chosen on the client, as the keys
+// are bound to it, and the request carries the first collection key sealed to every member and invited user.
+func (h *OrgHandler) CreateCollection(ctx context.Context, request *pb.CreateCollectionRequest) (*pb.CreateCollectionResponse, error) {
+	member, err := h.member(ctx, request.GetUserId(), request.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanManageOrg(member.Role) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrOrgPermission.Error())
+	}
+
+	collectionID, err := uuid.Parse(request.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid collection id %s", request.GetId())
+	}
+
+	name := strings.TrimSpace(request.GetName())
+	if err = domain.ValidateOrgName(name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	keys, err := parseCollectionKeys(request.GetKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := h.members(ctx, member.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &domain.Collection{
+		ID:       collectionID,
+		OrgID:    member.OrgID,
+		Name:     name,
+		KeyID:    firstCollectionKeyID,
+		Created:  time.Now(),
+		Modified: time.Now(),
+	}
+
+	for _, v := range keys {
+		if v.CollectionID != collection.ID {
+			return nil, status.Errorf(codes.InvalidArgument, "key of another collection %s", v.CollectionID)
+		}
+		if v.UserID == member.UserID {
+			collection.WrappedKey = v.WrappedKey
+		}
+	}
+
+	if err = domain.ValidateCollectionKeys(keys, members, collection.KeyID); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if err = h.collectionStore.SaveCollection(ctx, collection, keys); err != nil {
+		slog.ErrorContext(ctx, "could not save collection", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CreateCollectionResponse{Collection: collectionToProto(collection)}, nil
+}
+
+// GetCollections returns the collections of the organization with their current keys sealed to the user.
+func (h *OrgHandler) GetCollections(ctx context.Context, request *pb.GetCollectionsRequest) (*pb.GetCollectionsResponse, error) {
+	member, err := h.member(ctx, request.GetUserId(), request.GetOrgId())
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := h.collectionStore.GetCollectionsByOrg(ctx, member.OrgID, member.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get collections", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := make([]*pb.Collection, len(collections))
+	for i, v := range collections {
+		res[i] = collectionToProto(v)
+	}
+
+	return &pb.GetCollectionsResponse{Collections: res}, nil
+}
+
+// DeleteCollection removes the collection with its items.
+func (h *OrgHandler) DeleteCollection(ctx context.Context, request *pb.DeleteCollectionRequest) (*pb.DeleteCollectionResponse, error) {
+	collection, member, err := h.collection(ctx, request.GetUserId(), request.GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanManageOrg(member.Role) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrOrgPermission.Error())
+	}
+
+	if err = h.collectionStore.DeleteCollection(ctx, collection.ID); err != nil {
+		slog.ErrorContext(ctx, "could not delete collection", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DeleteCollectionResponse{}, nil
+}
+
+// GetCollectionItems returns the items of the collection with their encrypted metadata, without the item data.
+func (h *OrgHandler) GetCollectionItems(ctx context.Context, request *pb.GetCollectionItemsRequest) (*pb.GetCollectionItemsResponse, error) {
+	collection, _, err := h.collection(ctx, request.GetUserId(), request.GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := h.collectionStore.GetCollectionItems(ctx, collection.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get collection items", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := make([]*pb.CollectionItem, len(items))
+	for i, v := range items {
+		res[i] = collectionItemToProto(v)
+	}
+
+	return &pb.GetCollectionItemsResponse{Items: res}, nil
+}
+
+// GetCollectionItem returns the item of a collection of the organization the user is a member of.
+func (h *OrgHandler) GetCollectionItem(ctx context.Context, request *pb.GetCollectionItemRequest) (*pb.GetCollectionItemResponse, error) {
+	item, _, err := h.collectionItem(ctx, request.GetUserId(), request.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetCollectionItemResponse{Item: collectionItemToProto(item)}, nil
+}
+
+// PostCollectionItem creates or changes the item of the collection. The item must be encrypted with the current
+// collection key, read-only members can't change the items.
+func (h *OrgHandler) PostCollectionItem(ctx context.Context, request *pb.PostCollectionItemRequest) (*pb.PostCollectionItemResponse, error) {
+	if request.GetItem() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty item")
+	}
+
+	collection, member, err := h.collection(ctx, request.GetUserId(), request.GetItem().GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanWriteItems(member.Role) {
+		return nil, status.Error(codes.PermissionDenied, "items of the collection are read-only for the member")
+	}
+
+	itemID, err := uuid.Parse(request.GetItem().GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid item id %s", request.GetItem().GetId())
+	}
+
+	item := &domain.CollectionItem{
+		ID:            itemID,
+		CollectionID:  collection.ID,
+		Data:          request.GetItem().GetData(),
+		WrappedKey:    request.GetItem().GetWrappedKey(),
+		KeyID:         request.GetItem().GetKeyId(),
+		EncryptedMeta: request.GetItem().GetEncryptedMeta(),
+		DataType:      request.GetItem().GetDataType(),
+		CreatedBy:     member.UserID,
+		Created:       time.Now(),
+		Modified:      time.Now(),
+	}
+
+	if err = domain.ValidateCollectionItem(item); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	quota, err := h.collectionItemQuota(ctx, item, member)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.collectionStore.SaveCollectionItem(ctx, item, quota); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrCollectionKeyChanged):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "item %s not found", item.ID)
+		case errors.Is(err, domain.ErrQuotaExceeded):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save collection item", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.PostCollectionItemResponse{
+		Created:  item.Created.Format(time.RFC3339),
+		Modified: item.Modified.Format(time.RFC3339),
+	}, nil
+}
+
+// collectionItemQuota checks the item fits into the item size limit of the member and returns the quota of the member
+// who created the item, the item counts towards the usage of the creator. The creator of the stored item is kept
+// for the item, the new items are created by the member.
+func (h *OrgHandler) collectionItemQuota(ctx context.Context, item *domain.CollectionItem,
+	member *domain.OrgMember) (*domain.Quota, error) {
+	quota, err := userQuota(ctx, h.quotaProvider, h.defaultQuota, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckItemSize(quota, int64(len(item.Data))); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	stored, err := h.collectionStore.GetCollectionItem(ctx, item.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return quota, nil
+		}
+		slog.ErrorContext(ctx, "could not get collection item", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if stored.CollectionID != item.CollectionID {
+		return nil, status.Errorf(codes.NotFound, "item %s not found", item.ID)
+	}
+
+	if stored.CreatedBy == member.UserID {
+		return quota, nil
+	}
+
+	item.CreatedBy = stored.CreatedBy
+
+	return userQuota(ctx, h.quotaProvider, h.defaultQuota, stored.CreatedBy)
+}
+
+// DeleteCollectionItem removes the item of the collection, read-only members can't remove the items.
+func (h *OrgHandler) DeleteCollectionItem(ctx context.Context, request *pb.DeleteCollectionItemRequest) (*pb.DeleteCollectionItemResponse, error) {
+	item, member, err := h.collectionItem(ctx, request.GetUserId(), request.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanWriteItems(member.Role) {
+		return nil, status.Error(codes.PermissionDenied, "items of the collection are read-only for the member")
+	}
+
+	if err = h.collectionStore.DeleteCollectionItem(ctx, item.CollectionID, item.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "item %s not found", item.ID)
+		}
+		slog.ErrorContext(ctx, "could not delete collection item", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.DeleteCollectionItemResponse{}, nil
+}
+
+// RekeyCollection replaces the collection key with the next version after a member was removed: the new key sealed
+// to every remaining member and all items of the collection encrypted with it replace the previous ones at once.
+func (h *OrgHandler) RekeyCollection(ctx context.Context, request *pb.RekeyCollectionRequest) (*pb.RekeyCollectionResponse, error) {
+	collection, member, err := h.collection(ctx, request.GetUserId(), request.GetCollectionId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.CanManageOrg(member.Role) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrOrgPermission.Error())
+	}
+
+	keyID := request.GetKeyId()
+	if keyID != collection.KeyID+1 {
+		return nil, status.Errorf(codes.FailedPrecondition, "collection key version must be %d", collection.KeyID+1)
+	}
+
+	keys, err := parseCollectionKeys(request.GetKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range keys {
+		if v.CollectionID != collection.ID {
+			return nil, status.Errorf(codes.InvalidArgument, "key of another collection %s", v.CollectionID)
+		}
+	}
+
+	members, err := h.members(ctx, collection.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.ValidateCollectionKeys(keys, members, keyID); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	quota, err := userQuota(ctx, h.quotaProvider, h.defaultQuota, member.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.CollectionItem, 0, len(request.GetItems()))
+	for _, v := range request.GetItems() {
+		itemID, err := uuid.Parse(v.GetId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid item id %s", v.GetId())
+		}
+
+		item := &domain.CollectionItem{
+			ID:            itemID,
+			CollectionID:  collection.ID,
+			Data:          v.GetData(),
+			WrappedKey:    v.GetWrappedKey(),
+			KeyID:         keyID,
+			EncryptedMeta: v.GetEncryptedMeta(),
+			DataType:      v.GetDataType(),
+		}
+
+		if err = domain.ValidateCollectionItem(item); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "item %s: %s", itemID, err.Error())
+		}
+
+		if err = domain.CheckItemSize(quota, int64(len(item.Data))); err != nil {
+			return nil, status.Errorf(codes.ResourceExhausted, "item %s: %s", itemID, err.Error())
+		}
+
+		items = append(items, item)
+	}
+
+	if err = h.collectionStore.RekeyCollection(ctx, collection.ID, keyID, keys, items); err != nil {
+		if errors.Is(err, domain.ErrCollectionKeyChanged) || errors.Is(err, domain.ErrCollectionItemsChanged) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not rekey collection", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RekeyCollectionResponse{}, nil
+}
+
+// member returns the accepted membership of the user of the request in the organization.
+func (h *OrgHandler) member(ctx context.Context, userIDParam string, orgIDParam string) (*domain.OrgMember, error) {
+	userID, err := parseUserAccess(ctx, userIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := h.membership(ctx, userID, orgIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.Accepted {
+		return nil, status.Error(codes.PermissionDenied, "invitation into the organization is not accepted")
+	}
+
+	return member, nil
+}
+
+// membership returns the membership or the invitation of the user in the organization. Organizations of other users
+// are indistinguishable from missing ones.
+func (h *OrgHandler) membership(ctx context.Context, userID uuid.UUID, orgIDParam string) (*domain.OrgMember, error) {
+	orgID, err := uuid.Parse(orgIDParam)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid org id %s", orgIDParam)
+	}
+
+	member, err := h.orgStore.GetOrgMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "organization %s not found", orgID)
+		}
+		slog.ErrorContext(ctx, "could not get org member", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return member, nil
+}
+
+// targetMember returns the membership of the user of the request together with the membership of the member
+// the request is about. A user can't change or remove the own membership.
+func (h *OrgHandler) targetMember(ctx context.Context, userIDParam string, orgIDParam string,
+	memberIDParam string) (*domain.OrgMember, *domain.OrgMember, error) {
+	member, err := h.member(ctx, userIDParam, orgIDParam)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	memberID, err := uuid.Parse(memberIDParam)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid member id %s", memberIDParam)
+	}
+
+	if memberID == member.UserID {
+		return nil, nil, status.Error(codes.InvalidArgument, "own membership can't be changed")
+	}
+
+	target, err := h.orgStore.GetOrgMember(ctx, member.OrgID, memberID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, status.Errorf(codes.NotFound, "member %s not found", memberID)
+		}
+		slog.ErrorContext(ctx, "could not get org member", slog.String("error", err.Error()))
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return member, target, nil
+}
+
+// members returns the members and the invited users of the organization.
+func (h *OrgHandler) members(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	members, err := h.orgStore.GetOrgMembers(ctx, orgID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get org members", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return members, nil
+}
+
+// memberKey returns the sharing key pair of the user the collection keys are sealed to. Users who have not logged in
+// since the sharing was introduced have no sharing key and can't join organizations yet.
+func (h *OrgHandler) memberKey(ctx context.Context, userID uuid.UUID) (*domain.SharingKey, error) {
+	key, err := h.sharingKeyStore.GetSharingKey(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "user has no sharing key yet and must log in first")
+		}
+		slog.ErrorContext(ctx, "could not get sharing key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return key, nil
+}
+
+// collection returns the collection together with the accepted membership of the user of the request
+// in its organization. Collections of other organizations are indistinguishable from missing ones.
+func (h *OrgHandler) collection(ctx context.Context, userIDParam string,
+	collectionIDParam string) (*domain.Collection, *domain.OrgMember, error) {
+	collectionID, err := uuid.Parse(collectionIDParam)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid collection id %s", collectionIDParam)
+	}
+
+	collection, err := h.collectionStore.GetCollectionByID(ctx, collectionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, status.Errorf(codes.NotFound, "collection %s not found", collectionID)
+		}
+		slog.ErrorContext(ctx, "could not get collection", slog.String("error", err.Error()))
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	member, err := h.member(ctx, userIDParam, collection.OrgID.String())
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, status.Errorf(codes.NotFound, "collection %s not found", collectionID)
+		}
+		return nil, nil, err
+	}
+
+	return collection, member, nil
+}
+
+// collectionItem returns the item of a collection together with the membership of the user of the request.
+func (h *OrgHandler) collectionItem(ctx context.Context, userIDParam string,
+	itemIDParam string) (*domain.CollectionItem, *domain.OrgMember, error) {
+	itemID, err := uuid.Parse(itemIDParam)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid item id %s", itemIDParam)
+	}
+
+	item, err := h.collectionStore.GetCollectionItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, status.Errorf(codes.NotFound, "item %s not found", itemID)
+		}
+		slog.ErrorContext(ctx, "could not get collection item", slog.String("error", err.Error()))
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	_, member, err := h.collection(ctx, userIDParam, item.CollectionID.String())
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, status.Errorf(codes.NotFound, "item %s not found", itemID)
+		}
+		return nil, nil, err
+	}
+
+	return item, member, nil
+}
+
+// checkInvitationKeys checks the invitation carries the current key of every collection of the organization sealed
+// to the invited user and nothing else.
+func (h *OrgHandler) checkInvitationKeys(ctx context.Context, member *domain.OrgMember, invited *domain.OrgMember,
+	keys []*domain.CollectionKey) error {
+	collections, err := h.collectionStore.GetCollectionsByOrg(ctx, member.OrgID, member.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get collections", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	byCollection := make(map[uuid.UUID][]*domain.CollectionKey, len(collections))
+	for _, v := range keys {
+		byCollection[v.CollectionID] = append(byCollection[v.CollectionID], v)
+	}
+
+	for _, v := range collections {
+		if err = domain.ValidateCollectionKeys(byCollection[v.ID], []*domain.OrgMember{invited}, v.KeyID); err != nil {
+			return status.Errorf(codes.FailedPrecondition, "collection %s: %s", v.ID, err.Error())
+		}
+		delete(byCollection, v.ID)
+	}
+
+	if len(byCollection) > 0 {
+		return status.Error(codes.FailedPrecondition, "keys of unknown collections")
+	}
+
+	return nil
+}
+
+// parseCollectionKeys converts the collection keys of the request.
+func parseCollectionKeys(keys []*pb.CollectionKey) ([]*domain.CollectionKey, error) {
+	res := make([]*domain.CollectionKey, 0, len(keys))
+	for _, v := range keys {
+		collectionID, err := uuid.Parse(v.GetCollectionId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid collection id %s", v.GetCollectionId())
+		}
+
+		userID, err := uuid.Parse(v.GetUserId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid user id %s", v.GetUserId())
+		}
+
+		res = append(res, &domain.CollectionKey{
+			CollectionID: collectionID,
+			UserID:       userID,
+			KeyID:        v.GetKeyId(),
+			WrappedKey:   v.GetWrappedKey(),
+		})
+	}
+
+	return res, nil
+}
+
+// roleError converts the error of a role check into the gRPC status.
+func roleError(err error) error {
+	if errors.Is(err, domain.ErrOrgPermission) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// orgToProto converts the organization into its gRPC representation.
+func orgToProto(org *domain.Organization) *pb.Organization {
+	return &pb.Organization{
+		Id:       org.ID.String(),
+		Name:     org.Name,
+		Role:     org.Role,
+		Accepted: org.Accepted,
+		Created:  org.Created.Format(time.RFC3339),
+	}
+}
+
+// orgMemberToProto converts the member of the organization into its gRPC representation.
+func orgMemberToProto(member *domain.OrgMember) *pb.OrgMember {
+	return &pb.OrgMember{
+		UserId:    member.UserID.String(),
+		Login:     member.Login,
+		Role:      member.Role,
+		Accepted:  member.Accepted,
+		PublicKey: member.PublicKey,
+	}
+}
+
+// collectionToProto converts the collection into its gRPC representation.
+func collectionToProto(collection *domain.Collection) *pb.Collection {
+	return &pb.Collection{
+		Id:         collection.ID.String(),
+		OrgId:      collection.OrgID.String(),
+		Name:       collection.Name,
+		KeyId:      collection.KeyID,
+		WrappedKey: collection.WrappedKey,
+		Created:    collection.Created.Format(time.RFC3339),
+	}
+}
+
+// collectionItemToProto converts the collection item into its gRPC representation.
+func collectionItemToProto(item *domain.CollectionItem) *pb.CollectionItem {
+	return &pb.CollectionItem{
+		Id:            item.ID.String(),
+		CollectionId:  item.CollectionID.String(),
+		Data:          item.Data,
+		WrappedKey:    item.WrappedKey,
+		KeyId:         item.KeyID,
+		EncryptedMeta: item.EncryptedMeta,
+		DataType:      item.DataType,
+		Created:       item.Created.Format(time.RFC3339),
+		Modified:      item.Modified.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// testOrgStore keeps the memberships of a single organization.
+// The methods the tests don't call are left to the embedded nil interface.
+type testOrgStore struct {
+	orgStore
+	members map[uuid.UUID]*domain.OrgMember
+}
+
+func (s *testOrgStore) GetOrgMember(_ context.Context, _ uuid.UUID, userID uuid.UUID) (*domain.OrgMember, error) {
+	if member, ok := s.members[userID]; ok {
+		return member, nil
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+// testCollectionStore keeps a single collection with its items and checks the saved items against the quota
+// with the store empty otherwise.
+type testCollectionStore struct {
+	collectionStore
+	collection *domain.Collection
+	items      map[uuid.UUID]*domain.CollectionItem
+	saved      []*domain.CollectionItem
+	quotas     []*domain.Quota
+}
+
+func (s *testCollectionStore) GetCollectionByID(_ context.Context, id uuid.UUID) (*domain.Collection, error) {
+	if s.collection.ID != id {
+		return nil, sql.ErrNoRows
+	}
+
+	return s.collection, nil
+}
+
+func (s *testCollectionStore) GetCollectionItem(_ context.Context, id uuid.UUID) (*domain.CollectionItem, error) {
+	if item, ok := s.items[id]; ok {
+		return item, nil
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (s *testCollectionStore) SaveCollectionItem(_ context.Context, item *domain.CollectionItem, quota *domain.Quota) error {
+	if err := domain.CheckQuota(quota, &domain.StorageUsage{}, int64(len(item.Data)), 0, true); err != nil {
+		return err
+	}
+
+	s.saved = append(s.saved, item)
+	s.quotas = append(s.quotas, quota)
+
+	return nil
+}
+
+// testCollectionItem returns a valid item of the collection encrypted with the collection key of the version.
+func testCollectionItem(id uuid.UUID, collectionID uuid.UUID, keyID uint32, size int) *pb.CollectionItem {
+	wrappedKey := make([]byte, 81)
+	wrappedKey[0], wrappedKey[4] = 1, byte(keyID)
+	encryptedMeta := make([]byte, 60)
+	encryptedMeta[0], encryptedMeta[4] = 1, byte(keyID)
+
+	return &pb.CollectionItem{
+		Id:            id.String(),
+		CollectionId:  collectionID.String(),
+		Data:          make([]byte, size),
+		WrappedKey:    wrappedKey,
+		KeyId:         keyID,
+		EncryptedMeta: encryptedMeta,
+		DataType:      "Creds",
+	}
+}
+
+func TestOrgHandler_PostCollectionItem_Quota(t *testing.T) {
+	orgID, creator, editor := uuid.New(), uuid.New(), uuid.New()
+	collection := &domain.Collection{ID: uuid.New(), OrgID: orgID, KeyID: 1}
+	storedID := uuid.New()
+
+	tests := []struct {
+		name         string
+		itemID       uuid.UUID
+		size         int
+		creatorQuota *domain.Quota
+		wantCode     codes.Code
+		wantCharged  uuid.UUID
+	}{
+		{name: "new item fits quota of the member", itemID: uuid.New(), size: 10, creatorQuota: &domain.Quota{MaxBytes: 5},
+			wantCode: codes.OK, wantCharged: editor},
+		{name: "new item exceeds item size of the member", itemID: uuid.New(), size: 11, creatorQuota: &domain.Quota{},
+			wantCode: codes.ResourceExhausted},
+		{name: "edited item charged to the creator", itemID: storedID, size: 10, creatorQuota: &domain.Quota{MaxBytes: 100},
+			wantCode: codes.OK, wantCharged: creator},
+		{name: "edited item exceeds quota of the creator", itemID: storedID, size: 6, creatorQuota: &domain.Quota{MaxBytes: 5},
+			wantCode: codes.ResourceExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := &testOrgStore{members: map[uuid.UUID]*domain.OrgMember{
+				creator: {OrgID: orgID, UserID: creator, Role: domain.OrgRoleMember, Accepted: true},
+				editor:  {OrgID: orgID, UserID: editor, Role: domain.OrgRoleMember, Accepted: true},
+			}}
+			collections := &testCollectionStore{
+				collection: collection,
+				items: map[uuid.UUID]*domain.CollectionItem{
+					storedID: {ID: storedID, CollectionID: collection.ID, CreatedBy: creator},
+				},
+			}
+			store := newTestStore()
+			store.quotas[creator] = tt.creatorQuota
+			store.quotas[editor] = &domain.Quota{MaxItemSize: 10}
+			handler := NewOrgHandler(orgs, collections, nil, nil, store, domain.Quota{})
+
+			_, err := handler.PostCollectionItem(ContextWithUserID(context.Background(), editor.String()),
+				&pb.PostCollectionItemRequest{
+					UserId: editor.String(),
+					Item:   testCollectionItem(tt.itemID, collection.ID, collection.KeyID, tt.size),
+				})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode != codes.OK {
+				assert.Empty(t, collections.saved)
+				return
+			}
+			require.Len(t, collections.saved, 1)
+			assert.Equal(t, tt.wantCharged, collections.saved[0].CreatedBy)
+			assert.Equal(t, store.quotas[tt.wantCharged], collections.quotas[0])
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// SharingHandler handles the sharing of items between users and implements the gRPC SharingHandlersServer interface.
+// The server only keeps the public sharing keys of the users and the data keys of the items sealed to them on the client,
+// it is never able to open a shared item. It relies on sharingKeyStore and shareStore to keep the keys and the shares,
+// on userProvider to find the recipients, on itemOwnerProvider and itemDataProvider to read the shared items,
+// on vaultKeyProvider to check the vault key the sharing key is wrapped with and on quotaProvider to limit
+// the shared items edited by the recipients, defaultQuota applies to the users without the quota set by an operator.
+type SharingHandler struct {
+	pb.UnimplementedSharingHandlersServer
+	sharingKeyStore   sharingKeyStore
+	shareStore        shareStore
+	userProvider      userProvider
+	itemOwnerProvider itemOwnerProvider
+	itemDataProvider  itemDataProvider
+	vaultKeyProvider  vaultKeyProvider
+	quotaProvider     quotaProvider
+	defaultQuota      domain.Quota
+}
+
+// sharingKeyStore defines a contract for storing and retrieving the sharing key pair of a user.
+type sharingKeyStore interface {
+	SaveSharingKey(context.Context, *domain.SharingKey) error
+	GetSharingKey(context.Context, uuid.UUID) (*domain.SharingKey, error)
+}
+
+// shareStore defines a contract for storing, listing and revoking the shares of the items
+// and for saving the shared items edited by the recipients.
+type shareStore interface {
+	SaveShare(context.Context, *domain.Share) error
+	GetSharesByData(context.Context, uuid.UUID, uuid.UUID) ([]*domain.Share, error)
+	GetSharesByRecipient(context.Context, uuid.UUID) ([]*domain.Share, error)
+	GetShareByID(context.Context, uuid.UUID) (*domain.Share, error)
+	DeleteShare(context.Context, uuid.UUID, uuid.UUID) error
+	UpdateSharedItemData(context.Context, *domain.Share, []byte, time.Time, *domain.Quota) error
+}
+
+// itemOwnerProvider defines a contract for retrieving the metadata record, and so the owner, of the item data
+// or by the ID of the record.
+type itemOwnerProvider interface {
+	GetMetaDataByDataID(context.Context, uuid.UUID) (*domain.Meta, error)
+	GetMetaDataByID(context.Context, uuid.UUID) (*domain.Meta, error)
+}
+
+// NewSharingHandler initializes and returns a new instance of SharingHandler with the provided storage dependencies
+// and the default quota of the users.
+func NewSharingHandler(
+	sharingKeyStore sharingKeyStore,
+	shareStore shareStore,
+	userProvider userProvider,
+	itemOwnerProvider itemOwnerProvider,
+	itemDataProvider itemDataProvider,
+	vaultKeyProvider vaultKeyProvider,
+	quotaProvider quotaProvider,
+	defaultQuota domain.Quota,
+) *SharingHandler {
+	return &SharingHandler{
+		sharingKeyStore:   sharingKeyStore,
+		shareStore:        shareStore,
+		userProvider:      userProvider,
+		itemOwnerProvider: itemOwnerProvider,
+		itemDataProvider:  itemDataProvider,
+		vaultKeyProvider:  vaultKeyProvider,
+		quotaProvider:     quotaProvider,
+		defaultQuota:      defaultQuota,
+	}
+}
+
+// PostSharingKey stores the sharing key pair of the user generated on the client. The stored key pair is only re-wrapped
+// with a newer vault key afterwards, an attempt to replace its public key is rejected, so the items already shared
+// with the user stay readable.
+func (h *SharingHandler) PostSharingKey(ctx context.Context, request *pb.PostSharingKeyRequest) (*pb.PostSharingKeyResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetSharingKey() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty sharing key")
+	}
+
+	key := &domain.SharingKey{
+		UserID:            userID,
+		PublicKey:         request.GetSharingKey().GetPublicKey(),
+		WrappedPrivateKey: request.GetSharingKey().GetWrappedPrivateKey(),
+		KeyID:             request.GetSharingKey().GetKeyId(),
+		Created:           time.Now(),
+		Modified:          time.Now(),
+	}
+
+	if err = domain.ValidateSharingKey(key); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = checkVaultKeyIDs(ctx, h.vaultKeyProvider, userID, key.KeyID); err != nil {
+		return nil, err
+	}
+
+	if err = h.sharingKeyStore.SaveSharingKey(ctx, key); err != nil {
+		if errors.Is(err, domain.ErrSharingKeyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save sharing key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.PostSharingKeyResponse{}, nil
+}
+
+// GetSharingKey returns the sharing key pair of the user with the private key wrapped by the vault key.
+func (h *SharingHandler) GetSharingKey(ctx context.Context, request *pb.GetSharingKeyRequest) (*pb.GetSharingKeyResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := h.sharingKey(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetSharingKeyResponse{
+		SharingKey: &pb.SharingKey{
+			PublicKey:         key.PublicKey,
+			WrappedPrivateKey: key.WrappedPrivateKey,
+			KeyId:             key.KeyID,
+		},
+	}, nil
+}
+
+// GetPublicKey returns the public sharing key of the user with the login, for the items to be sealed to.
+func (h *SharingHandler) GetPublicKey(ctx context.Context, request *pb.GetPublicKeyRequest) (*pb.GetPublicKeyResponse, error) {
+	recipient, key, err := h.recipient(ctx, request.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetPublicKeyResponse{
+		UserId:    recipient.ID.String(),
+		PublicKey: key.PublicKey,
+	}, nil
+}
+
+// ShareItem grants the recipient access to the item of the user. The data key of the item and its title and description
+// are sealed to the public key of the recipient on the client. Sharing the item with the same recipient again
+// replaces the sealed key and the permission.
+func (h *SharingHandler) ShareItem(ctx context.Context, request *pb.ShareItemRequest) (*pb.ShareItemResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := h.ownedItem(ctx, userID, request.GetDataId())
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, key, err := h.recipient(ctx, request.GetRecipientLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient.ID == userID {
+		return nil, status.Error(codes.InvalidArgument, "item can't be shared with its owner")
+	}
+
+	share := &domain.Share{
+		ID:                 uuid.New(),
+		DataID:             meta.DataID,
+		OwnerID:            userID,
+		RecipientID:        recipient.ID,
+		RecipientLogin:     recipient.Login,
+		RecipientPublicKey: key.PublicKey,
+		WrappedKey:         request.GetWrappedKey(),
+		EncryptedMeta:      request.GetEncryptedMeta(),
+		DataType:           meta.Type,
+		Permission:         request.GetPermission(),
+		Created:            time.Now(),
+		Modified:           time.Now(),
+	}
+
+	if err = domain.ValidateShare(share); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = h.shareStore.SaveShare(ctx, share); err != nil {
+		slog.ErrorContext(ctx, "could not save share", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.ShareItemResponse{Share: shareToProto(share)}, nil
+}
+
+// GetShares returns the shares of the item of the user with the logins and the public keys of the recipients.
+func (h *SharingHandler) GetShares(ctx context.Context, request *pb.GetSharesRequest) (*pb.GetSharesResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := h.ownedItem(ctx, userID, request.GetDataId())
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := h.shareStore.GetSharesByData(ctx, userID, meta.DataID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get shares", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &pb.GetSharesResponse{Shares: make([]*pb.Share, 0, len(shares))}
+	for _, v := range shares {
+		res.Shares = append(res.Shares, shareToProto(v))
+	}
+
+	return res, nil
+}
+
+// RevokeShare revokes the share of the item of the user. The recipient loses the access through the server at once,
+// the client of the owner re-encrypts the item under a new data key afterwards, so the revoked key opens
+// none of the later versions.
+func (h *SharingHandler) RevokeShare(ctx context.Context, request *pb.RevokeShareRequest) (*pb.RevokeShareResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	shareID, err := uuid.Parse(request.GetShareId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid share id %s", request.GetShareId())
+	}
+
+	if err = h.shareStore.DeleteShare(ctx, userID, shareID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "share %s not found", shareID)
+		}
+		slog.ErrorContext(ctx, "could not revoke share", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RevokeShareResponse{}, nil
+}
+
+// GetSharedWithMe returns the shares of the items other users have shared with the user.
+func (h *SharingHandler) GetSharedWithMe(ctx context.Context, request *pb.GetSharedWithMeRequest) (*pb.GetSharedWithMeResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := h.shareStore.GetSharesByRecipient(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get shared items", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &pb.GetSharedWithMeResponse{Shares: make([]*pb.Share, 0, len(shares))}
+	for _, v := range shares {
+		res.Shares = append(res.Shares, shareToProto(v))
+	}
+
+	return res, nil
+}
+
+// GetSharedItem returns the encrypted data of the item shared with the user together with the share.
+func (h *SharingHandler) GetSharedItem(ctx context.Context, request *pb.GetSharedItemRequest) (*pb.GetSharedItemResponse, error) {
+	share, err := h.receivedShare(ctx, request.GetUserId(), request.GetShareId())
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := h.itemDataProvider.GetItemDataByID(ctx, share.DataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "shared item %s not found", share.DataID)
+		}
+		slog.ErrorContext(ctx, "could not get shared item", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.GetSharedItemResponse{
+		Data:  item.Data,
+		Share: shareToProto(share),
+	}, nil
+}
+
+// UpdateSharedItem replaces the data of the item shared with the user with the edit permission. The data is encrypted
+// on the client with the data key of the share, so the owner and the other recipients keep opening it.
+// The data must fit into the item size limit of the user and into the quota of the owner the item is stored for.
+func (h *SharingHandler) UpdateSharedItem(ctx context.Context, request *pb.UpdateSharedItemRequest) (*pb.UpdateSharedItemResponse, error) {
+	share, err := h.receivedShare(ctx, request.GetUserId(), request.GetShareId())
+	if err != nil {
+		return nil, err
+	}
+
+	if share.Permission != domain.SharePermissionEdit {
+		return nil, status.Error(codes.PermissionDenied, "item is shared read-only")
+	}
+
+	if len(request.GetData()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty item data")
+	}
+
+	// Размер записи ограничен квотой редактирующего пользователя, а объем - квотой владельца, у которого она хранится
+	editorQuota, err := userQuota(ctx, h.quotaProvider, h.defaultQuota, share.RecipientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = domain.CheckItemSize(editorQuota, int64(len(request.GetData()))); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	ownerQuota, err := userQuota(ctx, h.quotaProvider, h.defaultQuota, share.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	modified := time.Now()
+	if err = h.shareStore.UpdateSharedItemData(ctx, share, request.GetData(), modified, ownerQuota); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Error(codes.PermissionDenied, "item is no longer shared for editing")
+		case errors.Is(err, domain.ErrQuotaExceeded):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not update shared item", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UpdateSharedItemResponse{Modified: modified.Format(time.RFC3339)}, nil
+}
+
+// sharingKey returns the sharing key pair of the user, failing if the user has not uploaded one yet.
+func (h *SharingHandler) sharingKey(ctx context.Context, userID uuid.UUID) (*domain.SharingKey, error) {
+	key, err := h.sharingKeyStore.GetSharingKey(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "sharing key is not uploaded")
+		}
+		slog.ErrorContext(ctx, "could not get sharing key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return key, nil
+}
+
+// recipient finds the user with the login together with the public sharing key. Users who have not logged in since
+// the sharing was introduced have no sharing key and can't receive items yet.
+func (h *SharingHandler) recipient(ctx context.Context, login string) (*domain.UserData, *domain.SharingKey, error) {
+	if login == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "empty login")
+	}
+
+	user, err := h.userProvider.GetUserByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, status.Errorf(codes.NotFound, "user %s not found", login)
+		}
+		slog.ErrorContext(ctx, "could not get user", slog.String("error", err.Error()))
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	key, err := h.sharingKey(ctx, user.ID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil, status.Errorf(codes.FailedPrecondition, "user %s can't receive shared items yet", login)
+		}
+		return nil, nil, err
+	}
+
+	return user, key, nil
+}
+
+// ownedItem returns the metadata record of the item data, checking the item belongs to the user.
+func (h *SharingHandler) ownedItem(ctx context.Context, userID uuid.UUID, id string) (*domain.Meta, error) {
+	dataID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid data id %s", id)
+	}
+
+	meta, err := h.itemOwnerProvider.GetMetaDataByDataID(ctx, dataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "item %s not found", dataID)
+		}
+		slog.ErrorContext(ctx, "could not get item meta", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if meta.UserID != userID {
+		return nil, status.Errorf(codes.NotFound, "item %s not found", dataID)
+	}
+
+	return meta, nil
+}
+
+// receivedShare returns the share by its ID, checking it was granted to the user.
+func (h *SharingHandler) receivedShare(ctx context.Context, userIDParam string, shareIDParam string) (*domain.Share, error) {
+	userID, err := parseUserAccess(ctx, userIDParam)
+	if err != nil {
+		return nil, err
+	}
+
+	shareID, err := uuid.Parse(shareIDParam)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid share id %s", shareIDParam)
+	}
+
+	share, err := h.shareStore.GetShareByID(ctx, shareID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "share %s not found", shareID)
+		}
+		slog.ErrorContext(ctx, "could not get share", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// Чужие и отозванные доступы неотличимы для получателя
+	if share.RecipientID != userID {
+		return nil, status.Errorf(codes.NotFound, "share %s not found", shareID)
+	}
+
+	return share, nil
+}
+
+// shareToProto converts the share into its gRPC representation.
+func shareToProto(share *domain.Share) *pb.Share {
+	return &pb.Share{
+		Id:                 share.ID.String(),
+		DataId:             share.DataID.String(),
+		OwnerLogin:         share.OwnerLogin,
+		RecipientLogin:     share.RecipientLogin,
+		WrappedKey:         share.WrappedKey,
+		EncryptedMeta:      share.EncryptedMeta,
+		DataType:           share.DataType,
+		Permission:         share.Permission,
+		RecipientPublicKey: share.RecipientPublicKey,
+		Created:            share.Created.Format(time.RFC3339),
+		Modified:           share.Modified.Format(time.RFC3339),
+	}
+}
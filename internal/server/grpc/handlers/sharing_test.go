@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+// testShareStore keeps a single share and checks the updated data against the quota with the store empty otherwise.
+// The methods the tests don't call are left to the embedded nil interface.
+type testShareStore struct {
+	shareStore
+	share   *domain.Share
+	updated []byte
+	quota   *domain.Quota
+}
+
+func (s *testShareStore) GetShareByID(_ context.Context, id uuid.UUID) (*domain.Share, error) {
+	if s.share == nil || s.share.ID != id {
+		return nil, sql.ErrNoRows
+	}
+
+	return s.share, nil
+}
+
+func (s *testShareStore) UpdateSharedItemData(_ context.Context, _ *domain.Share, data []byte, _ time.Time,
+	quota *domain.Quota) error {
+	if err := domain.CheckQuota(quota, &domain.StorageUsage{}, int64(len(data)), 0, false); err != nil {
+		return err
+	}
+
+	s.updated = data
+	s.quota = quota
+
+	return nil
+}
+
+func TestSharingHandler_UpdateSharedItem_Quota(t *testing.T) {
+	owner, recipient := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		ownerQuota  *domain.Quota
+		editorQuota *domain.Quota
+		data        []byte
+		wantCode    codes.Code
+	}{
+		{name: "fits", ownerQuota: &domain.Quota{MaxBytes: 100}, editorQuota: &domain.Quota{MaxItemSize: 10}, data: make([]byte, 10), wantCode: codes.OK},
+		{name: "exceeds item size of the editor", ownerQuota: &domain.Quota{}, editorQuota: &domain.Quota{MaxItemSize: 10}, data: make([]byte, 11), wantCode: codes.ResourceExhausted},
+		{name: "exceeds quota of the owner", ownerQuota: &domain.Quota{MaxBytes: 5}, editorQuota: &domain.Quota{}, data: make([]byte, 6), wantCode: codes.ResourceExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			share := &domain.Share{ID: uuid.New(), DataID: uuid.New(), OwnerID: owner, RecipientID: recipient,
+				Permission: domain.SharePermissionEdit}
+			shares := &testShareStore{share: share}
+			store := newTestStore()
+			store.quotas[owner] = tt.ownerQuota
+			store.quotas[recipient] = tt.editorQuota
+			handler := NewSharingHandler(nil, shares, nil, store, store, store, store, domain.Quota{})
+
+			_, err := handler.UpdateSharedItem(ContextWithUserID(context.Background(), recipient.String()),
+				&pb.UpdateSharedItemRequest{UserId: recipient.String(), ShareId: share.ID.String(), Data: tt.data})
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, tt.data, shares.updated)
+				assert.Equal(t, tt.ownerQuota, shares.quota)
+			} else {
+				assert.Nil(t, shares.updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+)
+
+// testStore keeps the metadata records, the item data and the quotas of the handler tests in memory.
+// The saved records are kept with the quota they were saved under.
+type testStore struct {
+	metas       map[uuid.UUID]*domain.Meta
+	items       map[uuid.UUID]*domain.ItemData
+	quotas      map[uuid.UUID]*domain.Quota
+	saved       []*domain.Meta
+	savedQuotas []*domain.Quota
+	deletedData []uuid.UUID
+	deletedMeta []uuid.UUID
+}
+
+// newTestStore creates a store holding the metadata records.
+func newTestStore(metas ...*domain.Meta) *testStore {
+	s := &testStore{
+		metas:  map[uuid.UUID]*domain.Meta{},
+		items:  map[uuid.UUID]*domain.ItemData{},
+		quotas: map[uuid.UUID]*domain.Quota{},
+	}
+	for _, v := range metas {
+		s.metas[v.ID] = v
+	}
+
+	return s
+}
+
+func (s *testStore) GetMetaDataByDataID(_ context.Context, dataID uuid.UUID) (*domain.Meta, error) {
+	for _, v := range s.metas {
+		if v.DataID == dataID {
+			return v, nil
+		}
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) GetMetaDataByID(_ context.Context, id uuid.UUID) (*domain.Meta, error) {
+	if meta, ok := s.metas[id]; ok {
+		return meta, nil
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) GetMetaDataByUser(context.Context, uuid.UUID, *domain.MetaFilter) ([]*domain.Meta, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) DeleteItemDataByID(_ context.Context, id uuid.UUID) error {
+	s.deletedData = append(s.deletedData, id)
+	return nil
+}
+
+func (s *testStore) DeleteMetaDataByID(_ context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if meta, ok := s.metas[id]; ok && meta.UserID == userID {
+		s.deletedMeta = append(s.deletedMeta, id)
+	}
+
+	return nil
+}
+
+// SaveItemData checks the item against the quota with the store empty otherwise, as the storage does.
+func (s *testStore) SaveItemData(_ context.Context, item *domain.ItemData, meta *domain.Meta, quota *domain.Quota) error {
+	if err := domain.CheckQuota(quota, &domain.StorageUsage{}, int64(len(item.Data)), 0, true); err != nil {
+		return err
+	}
+
+	s.items[item.ID] = item
+	s.metas[meta.ID] = meta
+	s.saved = append(s.saved, meta)
+	s.savedQuotas = append(s.savedQuotas, quota)
+
+	return nil
+}
+
+func (s *testStore) GetItemDataByID(_ context.Context, id uuid.UUID) (*domain.ItemData, error) {
+	if item, ok := s.items[id]; ok {
+		return item, nil
+	}
+
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) GetFoldersByUser(context.Context, uuid.UUID) ([]*domain.Folder, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) GetVaultKeys(context.Context, uuid.UUID) ([]*domain.VaultKey, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (s *testStore) GetUsage(context.Context, uuid.UUID) (*domain.StorageUsage, error) {
+	return &domain.StorageUsage{}, nil
+}
+
+func (s *testStore) GetUserQuota(_ context.Context, userID uuid.UUID) (*domain.Quota, error) {
+	if quota, ok := s.quotas[userID]; ok {
+		return quota, nil
+	}
+
+	return nil, sql.ErrNoRows
+}
@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
+)
+
+const (
+	firstVaultKeyID = 1
+
+	defaultRotationBatch = 100
+	maxRotationBatch     = 1000
+)
+
+// VaultHandler handles the rotation of the vault keys and implements the gRPC VaultHandlersServer interface.
+// It relies on vaultKeyCreator and vaultKeyProvider to keep the wrapped vault keys and on keyRotator to move
+// the items of the user over to the new key.
+type VaultHandler struct {
+	pb.UnimplementedVaultHandlersServer
+	vaultKeyCreator  vaultKeyCreator
+	vaultKeyProvider vaultKeyProvider
+	keyRotator       keyRotator
+}
+
+// keyRotator defines a contract for finding and replacing the data keys and metadata encrypted with previous vault keys.
+type keyRotator interface {
+	GetStaleItemKeys(context.Context, uuid.UUID, uint32, int) ([]*domain.ItemData, error)
+	GetStaleMetas(context.Context, uuid.UUID, uint32, int) ([]*domain.Meta, error)
+	CountStaleKeys(context.Context, uuid.UUID, uint32) (int64, error)
+	RewrapKeys(context.Context, uuid.UUID, uint32, []*domain.ItemData, []*domain.Meta) error
+	ActivateVaultKey(context.Context, uuid.UUID, uint32) error
+}
+
+// NewVaultHandler initializes and returns a new instance of VaultHandler with the provided vaultKeyCreator,
+// vaultKeyProvider and keyRotator dependencies.
+func NewVaultHandler(vaultKeyCreator vaultKeyCreator, vaultKeyProvider vaultKeyProvider, keyRotator keyRotator) *VaultHandler {
+	return &VaultHandler{
+		vaultKeyCreator:  vaultKeyCreator,
+		vaultKeyProvider: vaultKeyProvider,
+		keyRotator:       keyRotator,
+	}
+}
+
+// StartKeyRotation stores a new vault key of the user as pending and returns its version. Until the rotation is finished
+// both keys are returned at login, so the items re-encrypted with the new key and the remaining ones stay readable.
+// Only one rotation at a time is allowed, an unfinished one has to be resumed.
+func (h *VaultHandler) StartKeyRotation(ctx context.Context, request *pb.StartKeyRotationRequest) (*pb.StartKeyRotationResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetVaultKey() == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty vault key")
+	}
+
+	keys, err := h.vaultKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastKeyID uint32
+	for _, v := range keys {
+		if v.State == domain.VaultKeyPending {
+			return nil, status.Errorf(codes.AlreadyExists, "rotation to vault key %d is not finished", v.KeyID)
+		}
+		lastKeyID = max(lastKeyID, v.KeyID)
+	}
+
+	vaultKey := &domain.VaultKey{
+		UserID:             userID,
+		KeyID:              lastKeyID + 1,
+		State:              domain.VaultKeyPending,
+		WrappedKey:         request.GetVaultKey().GetWrappedKey(),
+		Salt:               request.GetVaultKey().GetSalt(),
+		KDFTime:            request.GetVaultKey().GetKdfTime(),
+		KDFMemory:          request.GetVaultKey().GetKdfMemory(),
+		KDFThreads:         request.GetVaultKey().GetKdfThreads(),
+		Created:            time.Now(),
+		Modified:           time.Now(),
+		RecoveryWrappedKey: request.GetVaultKey().GetRecoveryWrappedKey(),
+	}
+
+	if err = domain.ValidateVaultKey(vaultKey); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err = h.vaultKeyCreator.SaveVaultKey(ctx, vaultKey); err != nil {
+		if errors.Is(err, domain.ErrVaultKeyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		slog.ErrorContext(ctx, "could not save vault key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.StartKeyRotationResponse{KeyId: vaultKey.KeyID}, nil
+}
+
+// GetStaleKeys returns a batch of the wrapped data keys and encrypted metadata of the user not yet re-encrypted with
+// the pending vault key, together with the number of records left. The batches are selected from what is left,
+// so an interrupted rotation resumes from the first record not yet re-encrypted.
+func (h *VaultHandler) GetStaleKeys(ctx context.Context, request *pb.GetStaleKeysRequest) (*pb.GetStaleKeysResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.checkPendingKey(ctx, userID, request.GetKeyId()); err != nil {
+		return nil, err
+	}
+
+	limit := int(request.GetLimit())
+	if limit <= 0 {
+		limit = defaultRotationBatch
+	}
+	limit = min(limit, maxRotationBatch)
+
+	items, err := h.keyRotator.GetStaleItemKeys(ctx, userID, request.GetKeyId(), limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get stale item keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	metas, err := h.keyRotator.GetStaleMetas(ctx, userID, request.GetKeyId(), limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "could not get stale metas", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	remaining, err := h.keyRotator.CountStaleKeys(ctx, userID, request.GetKeyId())
+	if err != nil {
+		slog.ErrorContext(ctx, "could not count stale keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &pb.GetStaleKeysResponse{
+		Items:     make([]*pb.ItemKey, 0, len(items)),
+		Metas:     make([]*pb.MetaData, 0, len(metas)),
+		Remaining: remaining,
+	}
+	for _, v := range items {
+		res.Items = append(res.Items, &pb.ItemKey{
+			DataId:     v.ID.String(),
+			WrappedKey: v.WrappedKey,
+		})
+	}
+	for _, v := range metas {
+		res.Metas = append(res.Metas, &pb.MetaData{
+			Id:            v.ID.String(),
+			DataType:      v.Type,
+			UserId:        v.UserID.String(),
+			EncryptedMeta: v.EncryptedMeta,
+			MetaKeyId:     v.MetaKeyID,
+		})
+	}
+
+	return res, nil
+}
+
+// RewrapKeys stores a batch of the data keys and metadata re-encrypted with the pending vault key
+// and returns the number of records left.
+func (h *VaultHandler) RewrapKeys(ctx context.Context, request *pb.RewrapKeysRequest) (*pb.RewrapKeysResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.checkPendingKey(ctx, userID, request.GetKeyId()); err != nil {
+		return nil, err
+	}
+
+	if len(request.GetItems())+len(request.GetMetas()) > 2*maxRotationBatch {
+		return nil, status.Errorf(codes.InvalidArgument, "batch is larger than %d records", 2*maxRotationBatch)
+	}
+
+	items := make([]*domain.ItemData, 0, len(request.GetItems()))
+	for _, v := range request.GetItems() {
+		id, err := uuid.Parse(v.GetDataId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", v.GetDataId())
+		}
+		if len(v.GetWrappedKey()) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "empty wrapped key of item %s", v.GetDataId())
+		}
+
+		items = append(items, &domain.ItemData{
+			ID:         id,
+			WrappedKey: v.GetWrappedKey(),
+			KeyID:      request.GetKeyId(),
+		})
+	}
+
+	metas := make([]*domain.Meta, 0, len(request.GetMetas()))
+	for _, v := range request.GetMetas() {
+		id, err := uuid.Parse(v.GetId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid id %s", v.GetId())
+		}
+		if len(v.GetEncryptedMeta()) == 0 || len(v.GetEncryptedMeta()) > encryptedMetaLimit {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid encrypted meta of item %s", v.GetId())
+		}
+
+		metas = append(metas, &domain.Meta{
+			ID:            id,
+			UserID:        userID,
+			EncryptedMeta: v.GetEncryptedMeta(),
+			MetaKeyID:     request.GetKeyId(),
+		})
+	}
+
+	if err = h.keyRotator.RewrapKeys(ctx, userID, request.GetKeyId(), items, metas); err != nil {
+		slog.ErrorContext(ctx, "could not rewrap keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	remaining, err := h.keyRotator.CountStaleKeys(ctx, userID, request.GetKeyId())
+	if err != nil {
+		slog.ErrorContext(ctx, "could not count stale keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.RewrapKeysResponse{Remaining: remaining}, nil
+}
+
+// FinishKeyRotation makes the pending vault key the key in use and retires the previous keys.
+// The rotation can't be finished while any record is still encrypted with the previous keys.
+func (h *VaultHandler) FinishKeyRotation(ctx context.Context, request *pb.FinishKeyRotationRequest) (*pb.FinishKeyRotationResponse, error) {
+	userID, err := parseUserAccess(ctx, request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.keyRotator.ActivateVaultKey(ctx, userID, request.GetKeyId()); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRotationIncomplete):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "no pending vault key %d", request.GetKeyId())
+		}
+		slog.ErrorContext(ctx, "could not activate vault key", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.FinishKeyRotationResponse{}, nil
+}
+
+// vaultKeys returns the vault keys of the user, failing if the user has not uploaded one yet.
+func (h *VaultHandler) vaultKeys(ctx context.Context, userID uuid.UUID) ([]*domain.VaultKey, error) {
+	keys, err := h.vaultKeyProvider.GetVaultKeys(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.FailedPrecondition, "vault key is not uploaded")
+		}
+		slog.ErrorContext(ctx, "could not get vault keys", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return keys, nil
+}
+
+// checkPendingKey checks the key is the pending vault key of the user.
+func (h *VaultHandler) checkPendingKey(ctx context.Context, userID uuid.UUID, keyID uint32) error {
+	keys, err := h.vaultKeys(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range keys {
+		if v.KeyID == keyID && v.State == domain.VaultKeyPending {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.FailedPrecondition, "vault key %d is not being rotated to", keyID)
+}
+
+// checkVaultKeyIDs checks the vault key versions the records of the request are encrypted with are still kept for the user,
+// so a client holding a retired key can't store records nobody could decrypt after the rotation.
+// Zero marks records not encrypted with a vault key.
+func checkVaultKeyIDs(ctx context.Context, provider vaultKeyProvider, userID uuid.UUID, keyIDs ...uint32) error {
+	keys, err := provider.GetVaultKeys(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "could not get vault keys", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for _, keyID := range keyIDs {
+		if keyID == 0 {
+			continue
+		}
+
+		if !hasVaultKey(keys, keyID) {
+			return status.Errorf(codes.FailedPrecondition, "vault key %d is retired, log in again", keyID)
+		}
+	}
+
+	return nil
+}
+
+// hasVaultKey checks the key version is among the keys.
+func hasVaultKey(keys []*domain.VaultKey, keyID uint32) bool {
+	for _, v := range keys {
+		if v.KeyID == keyID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// vaultKeyToProto converts the vault key into its gRPC representation.
+func vaultKeyToProto(key *domain.VaultKey) *pb.VaultKey {
+	return &pb.VaultKey{
+		WrappedKey:         key.WrappedKey,
+		Salt:               key.Salt,
+		KdfTime:            key.KDFTime,
+		KdfMemory:          key.KDFMemory,
+		KdfThreads:         key.KDFThreads,
+		KeyId:              key.KeyID,
+		RecoveryWrappedKey: key.RecoveryWrappedKey,
+	}
+}
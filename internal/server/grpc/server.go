@@ -2,21 +2,32 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/credentials"
 
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
 	pb "github.com/mikhaylov123ty/GophKeeper/internal/proto"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/config"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/grpc/handlers"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/metrics"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/tokens"
 )
 
 const (
@@ -25,34 +36,116 @@ const (
 )
 
 // GRPCServer represents a gRPC server instance, providing configuration and initialization of gRPC services.
+// tokenVerifier checks the access tokens, sessionProvider is used to reject the tokens revoked by a password change.
+// deviceProvider is used to map the client certificates to the enrolled devices, it is nil without the mutual TLS.
+// apiTokenAuthenticator checks the personal API tokens sent instead of the access tokens,
+// auditRecorder appends the audited requests to the audit log, metrics records the requests when the metrics are served.
 type GRPCServer struct {
-	Server *grpc.Server
+	Server                *grpc.Server
+	tokenVerifier         tokenVerifier
+	sessionProvider       sessionProvider
+	deviceProvider        deviceProvider
+	apiTokenAuthenticator apiTokenAuthenticator
+	auditRecorder         auditRecorder
+	metrics               *metrics.Registry
+}
+
+// tokenVerifier defines the contract for checking the signature and the claims of an access token.
+type tokenVerifier interface {
+	Verify(string) (*tokens.Claims, error)
+}
+
+// sessionProvider defines the contract for retrieving the current session version of a user.
+type sessionProvider interface {
+	GetSessionVersion(context.Context, uuid.UUID) (int, error)
+}
+
+// apiTokenAuthenticator defines the contract for checking a personal API token and returning it with its scope.
+type apiTokenAuthenticator interface {
+	Authenticate(context.Context, string, time.Time) (*domain.APIToken, error)
+}
+
+// certificateProvider defines the contract for retrieving the current certificate of the server for a TLS handshake.
+type certificateProvider interface {
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+}
+
+// deviceProvider defines the contract for retrieving the device a client certificate was issued to.
+type deviceProvider interface {
+	GetDeviceByID(context.Context, uuid.UUID) (*domain.Device, error)
 }
 
 // NewServer initializes and returns a new GRPCServer instance configured with TLS, interceptors, and registered handlers.
-// It requires handlers for items data, metadata, and authentication, returning an error if TLS setup fails.
+// It requires handlers for items data, metadata, authentication, folders, vault keys, sharing, organizations, emergency access,
+// devices, API tokens and the audit log, the verifier of the access tokens, the provider of the session versions
+// and the recorder of the audit events, returning an error if TLS setup fails.
+// The certificate of the server is taken from certificates on every handshake, so a reloaded certificate is served at once.
+// The client certificates are verified with clientCAs and mapped to the devices by deviceProvider when clientCAs is set.
+// The requests are recorded into the metrics registry when it is not nil.
 func NewServer(
 	itemsDataHandler *handlers.ItemsDataHandler,
 	metaDataHandler *handlers.MetaDataHandler,
 	authHandler *handlers.AuthHandler,
+	folderHandler *handlers.FolderHandler,
+	vaultHandler *handlers.VaultHandler,
+	sharingHandler *handlers.SharingHandler,
+	orgHandler *handlers.OrgHandler,
+	emergencyHandler *handlers.EmergencyHandler,
+	deviceHandler *handlers.DeviceHandler,
+	apiTokenHandler *handlers.APITokenHandler,
+	auditHandler *handlers.AuditHandler,
+	tokenVerifier tokenVerifier,
+	sessionProvider sessionProvider,
+	deviceProvider deviceProvider,
+	auditRecorder auditRecorder,
+	certificates certificateProvider,
+	clientCAs *x509.CertPool,
+	metricsRegistry *metrics.Registry,
 ) (*GRPCServer, error) {
-	instance := &GRPCServer{}
+	instance := &GRPCServer{
+		tokenVerifier:         tokenVerifier,
+		sessionProvider:       sessionProvider,
+		apiTokenAuthenticator: apiTokenHandler,
+		auditRecorder:         auditRecorder,
+		metrics:               metricsRegistry,
+	}
 
 	// Определение перехватчиков
 	interceptors := []grpc.UnaryServerInterceptor{
 		instance.withLogger,
 		instance.withAuth,
+		instance.withClientCert,
+		instance.withAudit,
 	}
+	var streamInterceptors []grpc.StreamServerInterceptor
 
-	creds, err := credentials.NewServerTLSFromFile("public.crt", "private.key")
-	if err != nil {
-		return nil, fmt.Errorf("could not load tls cert: %s", err)
+	// Метрики учитывают все запросы, включая отклоненные остальными перехватчиками
+	if metricsRegistry != nil {
+		interceptors = append([]grpc.UnaryServerInterceptor{instance.withMetrics}, interceptors...)
+		streamInterceptors = append(streamInterceptors, instance.withStreamMetrics)
+	}
+
+	if certificates == nil {
+		return nil, fmt.Errorf("tls certificate is not configured")
 	}
 
-	//Регистрация инстанса gRPC с перехватчиками
+	tlsConfig := &tls.Config{GetCertificate: certificates.GetCertificate}
+
+	// Сертификат клиента необязателен на уровне TLS: без него устройство входит и регистрируется
+	if clientCAs != nil {
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		instance.deviceProvider = deviceProvider
+	}
+
+	creds := credentials.NewTLS(tlsConfig)
+
+	//Регистрация инстанса gRPC с перехватчиками, спаны запросов продолжают трассировку клиента
 	instance.Server = grpc.NewServer(
 		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 		grpc.MaxRecvMsgSize(messageLimit),
 		grpc.MaxSendMsgSize(messageLimit),
 	)
@@ -60,6 +153,14 @@ func NewServer(
 	pb.RegisterItemDataHandlersServer(instance.Server, itemsDataHandler)
 	pb.RegisterMetaDataHandlersServer(instance.Server, metaDataHandler)
 	pb.RegisterUserHandlersServer(instance.Server, authHandler)
+	pb.RegisterFolderHandlersServer(instance.Server, folderHandler)
+	pb.RegisterVaultHandlersServer(instance.Server, vaultHandler)
+	pb.RegisterSharingHandlersServer(instance.Server, sharingHandler)
+	pb.RegisterOrgHandlersServer(instance.Server, orgHandler)
+	pb.RegisterEmergencyHandlersServer(instance.Server, emergencyHandler)
+	pb.RegisterDeviceHandlersServer(instance.Server, deviceHandler)
+	pb.RegisterAPITokenHandlersServer(instance.Server, apiTokenHandler)
+	pb.RegisterAuditHandlersServer(instance.Server, auditHandler)
 
 	return instance, nil
 }
@@ -76,45 +177,255 @@ func (g *GRPCServer) withLogger(ctx context.Context, req any,
 	// Запуск RPC-метода
 	resp, err = handler(ctx, req)
 
-	// Логирует код и таймер
+	// Логирует код и таймер, идентификатор трассировки связывает запись со спанами запроса
 	e, _ := status.FromError(err)
-	slog.InfoContext(ctx, "Request completed ", slog.String("code", e.Code().String()), slog.Any("time spent", time.Since(start)))
+	attrs := []any{slog.String("code", e.Code().String()), slog.Any("time spent", time.Since(start))}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		attrs = append(attrs, slog.String("trace_id", spanContext.TraceID().String()))
+	}
+	slog.InfoContext(ctx, "Request completed ", attrs...)
 
 	return resp, err
 }
 
-// withAuth is a gRPC interceptor that adds JWT authentication for incoming requests, validating tokens if configured.
+// withMetrics is a gRPC server interceptor that records the status code and the duration of the requests
+// and counts the requests rejected for the credentials.
+func (g *GRPCServer) withMetrics(ctx context.Context, req any,
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+
+	resp, err = handler(ctx, req)
+
+	code := status.Code(err)
+	g.metrics.ObserveRequest(info.FullMethod, code.String(), time.Since(start))
+	if isAuthFailure(info.FullMethod, code) {
+		g.metrics.AuthFailure(info.FullMethod)
+	}
+
+	return resp, err
+}
+
+// withStreamMetrics is a gRPC stream interceptor that counts the streams while they are open and records
+// the completed ones as the requests.
+func (g *GRPCServer) withStreamMetrics(srv any, ss grpc.ServerStream,
+	info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	g.metrics.StreamStarted()
+	defer g.metrics.StreamFinished()
+
+	err := handler(srv, ss)
+
+	code := status.Code(err)
+	g.metrics.ObserveRequest(info.FullMethod, code.String(), time.Since(start))
+	if isAuthFailure(info.FullMethod, code) {
+		g.metrics.AuthFailure(info.FullMethod)
+	}
+
+	return err
+}
+
+// isAuthFailure reports whether the request was rejected for the credentials: the missing, invalid or revoked
+// tokens of any method, and the wrong password or recovery key of the public methods.
+func isAuthFailure(method string, code codes.Code) bool {
+	return code == codes.Unauthenticated || (publicMethods[method] && code == codes.PermissionDenied)
+}
+
+// publicMethods lists the methods called without a JWT: the registration, the login and the account recovery,
+// which checks the token derived from the recovery key instead, and the health check called by the probes.
+var publicMethods = map[string]bool{
+	healthgrpc.Health_Check_FullMethodName:        true,
+	pb.UserHandlers_PostUserData_FullMethodName:   true,
+	pb.UserHandlers_RegisterUser_FullMethodName:   true,
+	pb.UserHandlers_StartLogin_FullMethodName:     true,
+	pb.UserHandlers_FinishLogin_FullMethodName:    true,
+	pb.UserHandlers_GetRecoveryKit_FullMethodName: true,
+	pb.UserHandlers_RecoverAccount_FullMethodName: true,
+}
+
+// enrolmentMethods lists the methods a device calls without the client certificate when the certificates are required:
+// the device logs in with the password and enrolls itself.
+var enrolmentMethods = map[string]bool{
+	pb.DeviceHandlers_EnrollDevice_FullMethodName: true,
+}
+
+// apiTokenMethods lists the methods the personal API tokens are accepted by and whether the method changes the items,
+// which the read-only tokens are denied. The account, the keys, the sharing and the tokens themselves need the login.
+var apiTokenMethods = map[string]bool{
+	pb.ItemDataHandlers_GetItemData_FullMethodName:    false,
+	pb.MetaDataHandlers_GetMetaData_FullMethodName:    false,
+	pb.FolderHandlers_GetFolders_FullMethodName:       false,
+	pb.ItemDataHandlers_GetUsage_FullMethodName:       false,
+	pb.ItemDataHandlers_PostItemData_FullMethodName:   true,
+	pb.MetaDataHandlers_DeleteMetaData_FullMethodName: true,
+}
+
+// withAuth is a gRPC interceptor that adds JWT authentication for incoming requests, validating tokens with the keyset.
+// The personal API tokens, told by their prefix, are accepted instead of the JWT by the methods of apiTokenMethods.
 func (g *GRPCServer) withAuth(ctx context.Context, req any,
 	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-	if config.GetKeys().JWTKey != "" {
-		if info.FullMethod != "/server_grpc.UserHandlers/PostUserData" && config.GetKeys().JWTKey != "" {
-			slog.InfoContext(ctx, "starting verifying JWT")
-			meta, ok := metadata.FromIncomingContext(ctx)
-			if !ok {
-				slog.ErrorContext(ctx, "Failed to get metadata")
-				return nil, status.Error(codes.Internal, "can't extract metadata from request")
-			}
+	if !publicMethods[info.FullMethod] {
+		slog.InfoContext(ctx, "starting verifying JWT")
+		meta, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			slog.ErrorContext(ctx, "Failed to get metadata")
+			return nil, status.Error(codes.Internal, "can't extract metadata from request")
+		}
 
-			header, ok := meta["authorization"]
-			if !ok {
-				slog.ErrorContext(ctx, "Failed to get Authorization header")
-				return nil, status.Error(codes.Unauthenticated, "can't found JWT header")
-			}
+		header, ok := meta["authorization"]
+		if !ok {
+			slog.ErrorContext(ctx, "Failed to get Authorization header")
+			return nil, status.Error(codes.Unauthenticated, "can't found JWT header")
+		}
 
-			token, err := jwt.Parse(header[0], func(token *jwt.Token) (interface{}, error) {
-				return []byte(config.GetKeys().JWTKey), nil
-			})
-			if err != nil {
-				slog.ErrorContext(ctx, "Failed to parse Authorization header")
-				return nil, status.Error(codes.Unauthenticated, "can't parse Authorization header")
+		if strings.HasPrefix(header[0], handlers.APITokenPrefix) {
+			if ctx, err = g.checkAPIToken(ctx, header[0], info.FullMethod); err != nil {
+				return nil, err
 			}
 
-			if !token.Valid {
-				slog.ErrorContext(ctx, "JWT token is invalid")
-				return nil, status.Error(codes.PermissionDenied, "JWT token is invalid")
-			}
+			return handler(ctx, req)
+		}
+
+		claims, err := g.tokenVerifier.Verify(header[0])
+		if err != nil {
+			slog.ErrorContext(ctx, "JWT token is invalid", slog.String("error", err.Error()))
+			return nil, status.Error(codes.Unauthenticated, "JWT token is invalid")
 		}
+
+		// Идентификатор пользователя из токена доступен обработчикам для проверки доступа
+		if err = g.checkSession(ctx, claims.UserID, claims.SessionVersion); err != nil {
+			slog.ErrorContext(ctx, "JWT token is revoked", slog.String("error", err.Error()))
+			return nil, status.Error(codes.Unauthenticated, "JWT token is revoked, log in again")
+		}
+		ctx = handlers.ContextWithUserID(ctx, claims.UserID)
 	}
 
 	return handler(ctx, req)
 }
+
+// checkAPIToken authenticates the request with the personal API token, checking the method is allowed to the token,
+// and returns the context carrying the user and the token, whose scope the handlers apply to the items.
+// The API tokens are not bound to the sessions: a password change keeps them, they are revoked one by one.
+// The tokens of the locked user are rejected.
+func (g *GRPCServer) checkAPIToken(ctx context.Context, raw string, method string) (context.Context, error) {
+	write, ok := apiTokenMethods[method]
+	if !ok {
+		slog.ErrorContext(ctx, "method is not available to API tokens", slog.String("method", method))
+		return nil, status.Error(codes.PermissionDenied, "method is not available to API tokens")
+	}
+
+	token, err := g.apiTokenAuthenticator.Authenticate(ctx, raw, time.Now())
+	if err != nil {
+		slog.ErrorContext(ctx, "API token is invalid", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Unauthenticated, "API token is invalid")
+	}
+
+	if write && token.Scope.ReadOnly {
+		return nil, status.Error(codes.PermissionDenied, "API token is read-only")
+	}
+
+	// Токены заблокированного пользователя отклоняются
+	if _, err = g.sessionProvider.GetSessionVersion(ctx, token.UserID); err != nil {
+		slog.ErrorContext(ctx, "API token owner is rejected", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Unauthenticated, "API token is invalid")
+	}
+
+	ctx = handlers.ContextWithUserID(ctx, token.UserID.String())
+	return handlers.ContextWithAPIToken(ctx, token), nil
+}
+
+// checkSession checks the token was issued for the current session version of the user,
+// the tokens issued before a password change, a forced logout or a lock of the account are revoked.
+// Tokens without the version belong to the first session.
+func (g *GRPCServer) checkSession(ctx context.Context, userID string, tokenVersion int) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user id %s", userID)
+	}
+
+	version, err := g.sessionProvider.GetSessionVersion(ctx, id)
+	if err != nil {
+		return fmt.Errorf("could not get session version: %w", err)
+	}
+
+	if tokenVersion != version {
+		return fmt.Errorf("session version %d is revoked", tokenVersion)
+	}
+
+	return nil
+}
+
+// withClientCert is a gRPC interceptor that maps the verified client certificate to the enrolled device, rejecting
+// the certificates of the unknown, revoked or reissued devices and of the devices of other users than the token one.
+// The requests without a certificate pass unless the certificates are required, the login and the enrolment always pass.
+func (g *GRPCServer) withClientCert(ctx context.Context, req any,
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	if g.deviceProvider == nil {
+		return handler(ctx, req)
+	}
+
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		if config.GetKeys().CryptoKeys.RequireClientCert && !publicMethods[info.FullMethod] && !enrolmentMethods[info.FullMethod] {
+			slog.ErrorContext(ctx, "client certificate is missing")
+			return nil, status.Error(codes.Unauthenticated, "device certificate is required, enroll the device")
+		}
+
+		return handler(ctx, req)
+	}
+
+	if err = g.checkDevice(ctx, cert); err != nil {
+		slog.ErrorContext(ctx, "client certificate is rejected", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Unauthenticated, "device certificate is rejected, enroll the device again")
+	}
+
+	return handler(ctx, req)
+}
+
+// checkDevice checks the certificate was issued to a device which is still enrolled, and the device belongs
+// to the user of the token, if the request has one.
+func (g *GRPCServer) checkDevice(ctx context.Context, cert *x509.Certificate) error {
+	userID, deviceID, err := pki.DeviceIdentity(cert)
+	if err != nil {
+		return err
+	}
+
+	device, err := g.deviceProvider.GetDeviceByID(ctx, deviceID)
+	if err != nil {
+		return fmt.Errorf("could not get device %s: %w", deviceID, err)
+	}
+
+	if device.UserID != userID {
+		return fmt.Errorf("device %s belongs to another user", deviceID)
+	}
+
+	if device.IsRevoked() {
+		return fmt.Errorf("device %s is revoked", deviceID)
+	}
+
+	// Повторная регистрация устройства выдает новый сертификат, прежний отклоняется
+	if device.Fingerprint != pki.Fingerprint(cert) {
+		return fmt.Errorf("certificate of device %s is replaced", deviceID)
+	}
+
+	if tokenUserID, ok := handlers.UserIDFromContext(ctx); ok && tokenUserID != userID.String() {
+		return fmt.Errorf("device %s belongs to another user than the token", deviceID)
+	}
+
+	return nil
+}
+
+// peerCertificate returns the client certificate verified by the TLS handshake, or nil if the client sent none.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0]
+}
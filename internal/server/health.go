@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// healthCheckInterval is the period of checking the connection to the database.
+	healthCheckInterval = 10 * time.Second
+
+	// healthPingTimeout limits the time of a single ping of the database.
+	healthPingTimeout = 3 * time.Second
+)
+
+// healthChecker reports the serving status of the server with the standard gRPC health service.
+// The server is serving while the database answers the pings, and is not serving once the shutdown has begun.
+type healthChecker struct {
+	server   *health.Server
+	pinger   pinger
+	services []string
+	interval time.Duration
+	serving  bool
+}
+
+// pinger defines a contract for checking the connection to the database.
+type pinger interface {
+	Ping(context.Context) error
+}
+
+// newHealthChecker initializes and returns a new instance of healthChecker reporting the status of the server as a whole
+// and of the listed services, all of them are serving until the first check.
+func newHealthChecker(pinger pinger, services []string) *healthChecker {
+	h := &healthChecker{
+		server:   health.NewServer(),
+		pinger:   pinger,
+		services: append([]string{""}, services...),
+		interval: healthCheckInterval,
+		serving:  true,
+	}
+	h.setStatus(healthgrpc.HealthCheckResponse_SERVING)
+
+	return h
+}
+
+// Run checks the database at once and then on every tick until the context is done.
+func (h *healthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown reports all the services as not serving and ignores the later checks, so the load balancers stop
+// sending the new requests while the requests in flight are drained.
+func (h *healthChecker) Shutdown() {
+	h.server.Shutdown()
+}
+
+// check pings the database and updates the serving status, the changes of the status are logged.
+func (h *healthChecker) check(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+	defer cancel()
+
+	err := h.pinger.Ping(pingCtx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	switch {
+	case err != nil && h.serving:
+		slog.ErrorContext(ctx, "database is not reachable, server is not serving", slog.String("error", err.Error()))
+		h.serving = false
+		h.setStatus(healthgrpc.HealthCheckResponse_NOT_SERVING)
+	case err == nil && !h.serving:
+		slog.InfoContext(ctx, "database is reachable again, server is serving")
+		h.serving = true
+		h.setStatus(healthgrpc.HealthCheckResponse_SERVING)
+	}
+}
+
+// setStatus sets the serving status of the server and of all the services.
+func (h *healthChecker) setStatus(status healthgrpc.HealthCheckResponse_ServingStatus) {
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
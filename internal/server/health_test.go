@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testPinger answers the pings with the configured error.
+type testPinger struct {
+	err error
+}
+
+func (p *testPinger) Ping(context.Context) error {
+	return p.err
+}
+
+func TestHealthChecker_Check(t *testing.T) {
+	pinger := &testPinger{}
+	checker := newHealthChecker(pinger, []string{"test.Service"})
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Шаги выполняются по порядку, каждый зависит от состояния после предыдущего
+	steps := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		shutdown bool
+		want     healthgrpc.HealthCheckResponse_ServingStatus
+	}{
+		{name: "database reachable", ctx: context.Background(), want: healthgrpc.HealthCheckResponse_SERVING},
+		{name: "database unreachable", ctx: context.Background(), err: errors.New("connection refused"), want: healthgrpc.HealthCheckResponse_NOT_SERVING},
+		{name: "still unreachable", ctx: context.Background(), err: errors.New("connection refused"), want: healthgrpc.HealthCheckResponse_NOT_SERVING},
+		{name: "database reachable again", ctx: context.Background(), want: healthgrpc.HealthCheckResponse_SERVING},
+		{name: "canceled check", ctx: canceled, err: errors.New("context canceled"), want: healthgrpc.HealthCheckResponse_SERVING},
+		{name: "shutdown", ctx: context.Background(), shutdown: true, want: healthgrpc.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			if step.shutdown {
+				checker.Shutdown()
+			}
+
+			pinger.err = step.err
+			checker.check(step.ctx)
+
+			for _, service := range []string{"", "test.Service"} {
+				res, err := checker.server.Check(context.Background(), &healthgrpc.HealthCheckRequest{Service: service})
+				require.NoError(t, err)
+				assert.Equal(t, step.want, res.GetStatus(), "service %q", service)
+			}
+		})
+	}
+}
@@ -0,0 +1,324 @@
+// Package metrics collects the metrics of the server and exposes them in the Prometheus text format.
+// The format is written here rather than with the Prometheus client: the server needs a handful of counters,
+// one histogram and the gauges read at the scrape time.
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+)
+
+// namespace prefixes the names of all the metrics of the server.
+const namespace = "gophkeeper_"
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	// totalsCacheTTL is how long the stored data totals are reused by the scrapes before they are queried again.
+	totalsCacheTTL = time.Minute
+
+	// totalsQueryTimeout limits the time of querying the stored data totals on a scrape.
+	totalsQueryTimeout = 5 * time.Second
+)
+
+// durationBuckets are the upper bounds of the buckets of the RPC latency histogram in seconds.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// statsProvider defines the contract for retrieving the statistics of the database connection pool.
+type statsProvider interface {
+	Stats() sql.DBStats
+}
+
+// totalsProvider defines the contract for retrieving the number of the users, the items and the stored bytes.
+type totalsProvider interface {
+	GetStorageTotals(context.Context) (*domain.StorageTotals, error)
+}
+
+// Registry holds the metrics of the gRPC requests recorded by the interceptors and reads the gauges of the database
+// pool and of the stored data when the metrics are scraped. The stored data totals are cached for totalsCacheTTL,
+// so the frequent scrapes do not query the database every time.
+type Registry struct {
+	statsProvider  statsProvider
+	totalsProvider totalsProvider
+
+	mu            sync.Mutex
+	requests      map[requestKey]uint64
+	durations     map[string]*histogram
+	authFailures  map[string]uint64
+	activeStreams atomic.Int64
+
+	totalsMu  sync.Mutex
+	totals    *domain.StorageTotals
+	totalsAt  time.Time
+	totalsTTL time.Duration
+}
+
+// requestKey identifies the counter of the requests by the full method name and the status code.
+type requestKey struct {
+	method string
+	code   string
+}
+
+// histogram counts the observations by the buckets of durationBuckets, the last count is the +Inf bucket.
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewRegistry creates an empty registry reading the database pool statistics and the stored data totals
+// from the providers, either of them may be nil to leave its metrics out.
+func NewRegistry(statsProvider statsProvider, totalsProvider totalsProvider) *Registry {
+	return &Registry{
+		statsProvider:  statsProvider,
+		totalsProvider: totalsProvider,
+		requests:       map[requestKey]uint64{},
+		durations:      map[string]*histogram{},
+		authFailures:   map[string]uint64{},
+		totalsTTL:      totalsCacheTTL,
+	}
+}
+
+// ObserveRequest records the completed RPC with its status code and duration.
+func (r *Registry) ObserveRequest(method string, code string, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[requestKey{method: method, code: code}]++
+
+	h, ok := r.durations[method]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(durationBuckets)+1)}
+		r.durations[method] = h
+	}
+	h.observe(duration.Seconds())
+}
+
+// AuthFailure records the request of the method rejected for the missing, invalid or revoked credentials.
+func (r *Registry) AuthFailure(method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.authFailures[method]++
+}
+
+// StreamStarted records an opened stream, StreamFinished is to be called when it is closed.
+func (r *Registry) StreamStarted() {
+	r.activeStreams.Add(1)
+}
+
+// StreamFinished records a closed stream.
+func (r *Registry) StreamFinished() {
+	r.activeStreams.Add(-1)
+}
+
+// observe adds the value in seconds to the bucket it falls into.
+func (h *histogram) observe(value float64) {
+	i := sort.SearchFloat64s(durationBuckets, value)
+	h.counts[i]++
+	h.sum += value
+	h.count++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+
+	if err := r.Write(req.Context(), w); err != nil {
+		slog.Error("could not write metrics", slog.String("error", err.Error()))
+	}
+}
+
+// Write writes all the metrics in the Prometheus text format, the series of a metric are sorted by their labels.
+// The stored data gauges are left out when the totals could not be read, the rest of the metrics are still written.
+func (r *Registry) Write(ctx context.Context, w io.Writer) error {
+	buf := bufio.NewWriter(w)
+
+	r.writeRequests(buf)
+
+	writeHeader(buf, "grpc_active_streams", "gauge",
+		"Number of the gRPC streams in progress, such as the health Watch and the reflection streams.")
+	writeSample(buf, "grpc_active_streams", nil, float64(r.activeStreams.Load()))
+
+	if r.statsProvider != nil {
+		writeDBStats(buf, r.statsProvider.Stats())
+	}
+
+	if r.totalsProvider != nil {
+		totals, err := r.storageTotals(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "could not get storage totals", slog.String("error", err.Error()))
+		} else {
+			writeTotals(buf, totals)
+		}
+	}
+
+	return buf.Flush()
+}
+
+// storageTotals returns the cached stored data totals, querying them within totalsQueryTimeout once the cache
+// has expired. The concurrent scrapes wait for the same query rather than running their own.
+func (r *Registry) storageTotals(ctx context.Context) (*domain.StorageTotals, error) {
+	r.totalsMu.Lock()
+	defer r.totalsMu.Unlock()
+
+	if r.totals != nil && time.Since(r.totalsAt) < r.totalsTTL {
+		return r.totals, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, totalsQueryTimeout)
+	defer cancel()
+
+	totals, err := r.totalsProvider.GetStorageTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.totals, r.totalsAt = totals, time.Now()
+
+	return totals, nil
+}
+
+// writeRequests writes the counters and the latency histograms of the requests and the authentication failures.
+func (r *Registry) writeRequests(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := make([]requestKey, 0, len(r.requests))
+	for k := range r.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	writeHeader(w, "grpc_requests_total", "counter", "Number of the completed gRPC requests by the method and the status code.")
+	for _, k := range keys {
+		writeSample(w, "grpc_requests_total", []string{"method", k.method, "code", k.code}, float64(r.requests[k]))
+	}
+
+	writeHeader(w, "grpc_request_duration_seconds", "histogram", "Duration of the gRPC requests by the method.")
+	for _, method := range sortedKeys(r.durations) {
+		h := r.durations[method]
+
+		var cumulative uint64
+		for i, bound := range durationBuckets {
+			cumulative += h.counts[i]
+			writeSample(w, "grpc_request_duration_seconds_bucket",
+				[]string{"method", method, "le", formatFloat(bound)}, float64(cumulative))
+		}
+		writeSample(w, "grpc_request_duration_seconds_bucket", []string{"method", method, "le", "+Inf"}, float64(h.count))
+		writeSample(w, "grpc_request_duration_seconds_sum", []string{"method", method}, h.sum)
+		writeSample(w, "grpc_request_duration_seconds_count", []string{"method", method}, float64(h.count))
+	}
+
+	writeHeader(w, "auth_failures_total", "counter", "Number of the requests rejected for the credentials by the method.")
+	for _, method := range sortedKeys(r.authFailures) {
+		writeSample(w, "auth_failures_total", []string{"method", method}, float64(r.authFailures[method]))
+	}
+}
+
+// writeDBStats writes the gauges and the counters of the database connection pool.
+func writeDBStats(w io.Writer, stats sql.DBStats) {
+	gauges := []struct {
+		name  string
+		help  string
+		value int
+	}{
+		{"db_max_open_connections", "Maximum number of the open connections to the database.", stats.MaxOpenConnections},
+		{"db_open_connections", "Number of the established connections to the database.", stats.OpenConnections},
+		{"db_in_use_connections", "Number of the connections currently in use.", stats.InUse},
+		{"db_idle_connections", "Number of the idle connections.", stats.Idle},
+	}
+	for _, v := range gauges {
+		writeHeader(w, v.name, "gauge", v.help)
+		writeSample(w, v.name, nil, float64(v.value))
+	}
+
+	counters := []struct {
+		name  string
+		help  string
+		value float64
+	}{
+		{"db_wait_count_total", "Number of the connections waited for.", float64(stats.WaitCount)},
+		{"db_wait_duration_seconds_total", "Time blocked waiting for a new connection.", stats.WaitDuration.Seconds()},
+		{"db_max_idle_closed_total", "Number of the connections closed due to the idle limit.", float64(stats.MaxIdleClosed)},
+		{"db_max_idle_time_closed_total", "Number of the connections closed due to the idle time limit.", float64(stats.MaxIdleTimeClosed)},
+		{"db_max_lifetime_closed_total", "Number of the connections closed due to the lifetime limit.", float64(stats.MaxLifetimeClosed)},
+	}
+	for _, v := range counters {
+		writeHeader(w, v.name, "counter", v.help)
+		writeSample(w, v.name, nil, v.value)
+	}
+}
+
+// writeTotals writes the totals of the users, the items and the encrypted bytes stored on the server.
+// The totals are exposed rather than the usage per user to keep the number of the series fixed.
+func writeTotals(w io.Writer, totals *domain.StorageTotals) {
+	writeHeader(w, "users", "gauge", "Number of the registered users.")
+	writeSample(w, "users", nil, float64(totals.Users))
+	writeHeader(w, "stored_items", "gauge", "Number of the stored items.")
+	writeSample(w, "stored_items", nil, float64(totals.Items))
+	writeHeader(w, "stored_bytes", "gauge", "Size of the encrypted data of the stored items in bytes.")
+	writeSample(w, "stored_bytes", nil, float64(totals.Bytes))
+}
+
+// writeHeader writes the HELP and the TYPE lines of the metric.
+func writeHeader(w io.Writer, name string, metricType string, help string) {
+	fmt.Fprintf(w, "# HELP %s%s %s\n# TYPE %s%s %s\n", namespace, name, help, namespace, name, metricType)
+}
+
+// writeSample writes a sample line of the metric, labels are the pairs of the label names and values.
+func writeSample(w io.Writer, name string, labels []string, value float64) {
+	fmt.Fprintf(w, "%s%s", namespace, name)
+
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels)/2)
+		for i := 0; i+1 < len(labels); i += 2 {
+			pairs = append(pairs, labels[i]+`="`+escapeLabel(labels[i+1])+`"`)
+		}
+		fmt.Fprintf(w, "{%s}", strings.Join(pairs, ","))
+	}
+
+	fmt.Fprintf(w, " %s\n", formatFloat(value))
+}
+
+// labelEscaper escapes the backslashes, the double quotes and the line feeds of the label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes the label value for the text format.
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+// formatFloat formats the value in the shortest form parsed back to the same number.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// sortedKeys returns the keys of the map in the ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
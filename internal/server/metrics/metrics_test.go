@@ -0,0 +1,195 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
+)
+
+// testStats returns the fixed statistics of the database pool.
+type testStats sql.DBStats
+
+func (s testStats) Stats() sql.DBStats {
+	return sql.DBStats(s)
+}
+
+// testTotals returns the fixed totals or the error and counts the queries.
+type testTotals struct {
+	totals  *domain.StorageTotals
+	err     error
+	queries int
+}
+
+func (u *testTotals) GetStorageTotals(ctx context.Context) (*domain.StorageTotals, error) {
+	u.queries++
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("no query timeout")
+	}
+
+	return u.totals, u.err
+}
+
+func TestRegistry_Requests(t *testing.T) {
+	r := NewRegistry(nil, nil)
+
+	r.ObserveRequest("/b.Service/Get", "OK", 20*time.Millisecond)
+	r.ObserveRequest("/b.Service/Get", "OK", 3*time.Second)
+	r.ObserveRequest("/b.Service/Get", "NotFound", 20*time.Second)
+	r.ObserveRequest("/a.Service/Post", "OK", 5*time.Millisecond)
+	r.AuthFailure("/b.Service/Get")
+
+	var out strings.Builder
+	require.NoError(t, r.Write(context.Background(), &out))
+
+	for _, line := range []string{
+		"# TYPE gophkeeper_grpc_requests_total counter",
+		`gophkeeper_grpc_requests_total{method="/a.Service/Post",code="OK"} 1`,
+		`gophkeeper_grpc_requests_total{method="/b.Service/Get",code="NotFound"} 1`,
+		`gophkeeper_grpc_requests_total{method="/b.Service/Get",code="OK"} 2`,
+		"# TYPE gophkeeper_grpc_request_duration_seconds histogram",
+		`gophkeeper_grpc_request_duration_seconds_bucket{method="/a.Service/Post",le="0.005"} 1`,
+		`gophkeeper_grpc_request_duration_seconds_bucket{method="/b.Service/Get",le="0.01"} 0`,
+		`gophkeeper_grpc_request_duration_seconds_bucket{method="/b.Service/Get",le="0.025"} 1`,
+		`gophkeeper_grpc_request_duration_seconds_bucket{method="/b.Service/Get",le="5"} 2`,
+		`gophkeeper_grpc_request_duration_seconds_bucket{method="/b.Service/Get",le="10"} 2`,
+		`gophkeeper_grpc_request_duration_seconds_bucket{method="/b.Service/Get",le="+Inf"} 3`,
+		`gophkeeper_grpc_request_duration_seconds_sum{method="/b.Service/Get"} 23.02`,
+		`gophkeeper_grpc_request_duration_seconds_count{method="/b.Service/Get"} 3`,
+		`gophkeeper_auth_failures_total{method="/b.Service/Get"} 1`,
+		"gophkeeper_grpc_active_streams 0",
+	} {
+		assert.Contains(t, out.String(), line+"\n")
+	}
+
+	// Серии метрики отсортированы по меткам
+	assert.Less(t, strings.Index(out.String(), `method="/a.Service/Post",code="OK"`),
+		strings.Index(out.String(), `method="/b.Service/Get",code="NotFound"`))
+	assert.NotContains(t, out.String(), "gophkeeper_db_")
+	assert.NotContains(t, out.String(), "gophkeeper_stored_")
+}
+
+func TestRegistry_Streams(t *testing.T) {
+	r := NewRegistry(nil, nil)
+
+	r.StreamStarted()
+	r.StreamStarted()
+	r.StreamFinished()
+
+	var out strings.Builder
+	require.NoError(t, r.Write(context.Background(), &out))
+	assert.Contains(t, out.String(), "gophkeeper_grpc_active_streams 1\n")
+}
+
+func TestRegistry_Gauges(t *testing.T) {
+	stats := testStats{MaxOpenConnections: 10, OpenConnections: 3, InUse: 1, Idle: 2, WaitCount: 4, WaitDuration: 1500 * time.Millisecond}
+
+	tests := []struct {
+		name     string
+		totals   *testTotals
+		contains []string
+		missing  []string
+	}{
+		{
+			name:   "totals",
+			totals: &testTotals{totals: &domain.StorageTotals{Users: 2, Items: 5, Bytes: 1024}},
+			contains: []string{
+				"gophkeeper_db_max_open_connections 10",
+				"gophkeeper_db_open_connections 3",
+				"gophkeeper_db_in_use_connections 1",
+				"gophkeeper_db_idle_connections 2",
+				"gophkeeper_db_wait_count_total 4",
+				"gophkeeper_db_wait_duration_seconds_total 1.5",
+				"gophkeeper_users 2",
+				"gophkeeper_stored_items 5",
+				"gophkeeper_stored_bytes 1024",
+			},
+		},
+		{
+			name:     "totals error",
+			totals:   &testTotals{err: errors.New("connection refused")},
+			contains: []string{"gophkeeper_db_open_connections 3"},
+			missing:  []string{"gophkeeper_users", "gophkeeper_stored_bytes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(stats, tt.totals)
+
+			var out strings.Builder
+			require.NoError(t, r.Write(context.Background(), &out))
+
+			for _, line := range tt.contains {
+				assert.Contains(t, out.String(), line+"\n")
+			}
+			for _, name := range tt.missing {
+				assert.NotContains(t, out.String(), name)
+			}
+		})
+	}
+}
+
+func TestRegistry_TotalsCache(t *testing.T) {
+	totals := &testTotals{err: errors.New("connection refused")}
+	r := NewRegistry(nil, totals)
+
+	write := func() string {
+		var out strings.Builder
+		require.NoError(t, r.Write(context.Background(), &out))
+		return out.String()
+	}
+
+	// Ошибка не кэшируется, следующий сбор снова обращается к БД
+	assert.NotContains(t, write(), "gophkeeper_users")
+	totals.err = nil
+	totals.totals = &domain.StorageTotals{Users: 1, Items: 2, Bytes: 3}
+	assert.Contains(t, write(), "gophkeeper_users 1\n")
+	assert.Equal(t, 2, totals.queries)
+
+	totals.totals = &domain.StorageTotals{Users: 4}
+	assert.Contains(t, write(), "gophkeeper_users 1\n")
+	assert.Equal(t, 2, totals.queries)
+
+	r.totalsTTL = 0
+	assert.Contains(t, write(), "gophkeeper_users 4\n")
+	assert.Equal(t, 3, totals.queries)
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := NewRegistry(nil, nil)
+	r.ObserveRequest("/a.Service/Post", "OK", time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, contentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `gophkeeper_grpc_requests_total{method="/a.Service/Post",code="OK"} 1`)
+}
+
+func TestEscapeLabel(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "/a.Service/Get", want: "/a.Service/Get"},
+		{value: `say "hi"`, want: `say \"hi\"`},
+		{value: `C:\dir`, want: `C:\\dir`},
+		{value: "two\nlines", want: `two\nlines`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, escapeLabel(tt.value))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+)
+
+// KeyType names the algorithm of the keys generated for the certificates.
+type KeyType string
+
+// Supported key types.
+const (
+	KeyECDSA   KeyType = "ecdsa"
+	KeyEd25519 KeyType = "ed25519"
+	KeyRSA     KeyType = "rsa"
+)
+
+// rsaKeyBits is the size of the generated RSA keys.
+const rsaKeyBits = 3072
+
+// backdate is how long before the issue the certificates become valid, to tolerate clock skew.
+const backdate = time.Minute
+
+// Usage names the purpose of a certificate, which defines its key usages and constraints.
+type Usage string
+
+// Supported certificate usages.
+const (
+	UsageCA     Usage = "ca"
+	UsageServer Usage = "server"
+	UsageClient Usage = "client"
+)
+
+// Profile describes the certificate to be issued. Hosts are the subject alternative names,
+// the IP addresses among them are put into the IP SANs.
+type Profile struct {
+	Usage        Usage
+	CommonName   string
+	Organization []string
+	Hosts        []string
+	Validity     time.Duration
+}
+
+// GenerateKey generates a new private key of the type: ECDSA on the P-256 curve, Ed25519 or RSA.
+func GenerateKey(keyType KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case KeyECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyEd25519:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	case KeyRSA:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+// KeyTypeOf returns the type of the public key.
+func KeyTypeOf(public crypto.PublicKey) (KeyType, error) {
+	switch public.(type) {
+	case *ecdsa.PublicKey:
+		return KeyECDSA, nil
+	case ed25519.PublicKey:
+		return KeyEd25519, nil
+	case *rsa.PublicKey:
+		return KeyRSA, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", public)
+	}
+}
+
+// MarshalPrivateKey encodes the private key into the PEM encoded PKCS#8 form.
+func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// ParseCertificate parses the first PEM encoded certificate.
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("not a PEM certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// ProfileOf returns the profile the certificate was issued with, used to renew it.
+func ProfileOf(cert *x509.Certificate) (*Profile, error) {
+	profile := &Profile{
+		CommonName:   cert.Subject.CommonName,
+		Organization: cert.Subject.Organization,
+		Validity:     cert.NotAfter.Sub(cert.NotBefore),
+	}
+	if profile.Validity > backdate {
+		profile.Validity -= backdate
+	}
+
+	switch {
+	case cert.IsCA:
+		profile.Usage = UsageCA
+	case slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageServerAuth):
+		profile.Usage = UsageServer
+	case slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageClientAuth):
+		profile.Usage = UsageClient
+	default:
+		return nil, fmt.Errorf("certificate is neither a CA, a server nor a client certificate")
+	}
+
+	profile.Hosts = append(profile.Hosts, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		profile.Hosts = append(profile.Hosts, ip.String())
+	}
+
+	return profile, nil
+}
+
+// SelfSigned issues the certificate of the profile signed by its own key, as a root CA
+// or a standalone server certificate.
+func SelfSigned(profile *Profile, key crypto.Signer, now time.Time) (*x509.Certificate, []byte, error) {
+	template, err := profile.template(now, key.Public())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return createCertificate(template, template, key.Public(), key)
+}
+
+// Issue issues the certificate of the profile for the public key signed by the CA.
+// The certificate does not outlive the certificate of the CA.
+func (a *Authority) Issue(profile *Profile, public crypto.PublicKey, now time.Time) (*x509.Certificate, []byte, error) {
+	template, err := profile.template(now, public)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if template.NotAfter.After(a.cert.NotAfter) {
+		template.NotAfter = a.cert.NotAfter
+	}
+
+	return createCertificate(template, a.cert, public, a.key)
+}
+
+// Issued reports whether the certificate is signed by the CA.
+func (a *Authority) Issued(cert *x509.Certificate) bool {
+	return cert.CheckSignatureFrom(a.cert) == nil
+}
+
+// template returns the certificate template of the profile valid from now.
+func (p *Profile) template(now time.Time, public crypto.PublicKey) (*x509.Certificate, error) {
+	if p.Validity <= 0 {
+		return nil, fmt.Errorf("certificate validity must be positive")
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   p.CommonName,
+			Organization: p.Organization,
+		},
+		NotBefore: now.Add(-backdate),
+		NotAfter:  now.Add(p.Validity),
+		KeyUsage:  x509.KeyUsageDigitalSignature,
+	}
+
+	// Ключ RSA в TLS 1.2 может использоваться для обмена ключами
+	if _, ok := public.(*rsa.PublicKey); ok {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	for _, host := range p.Hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	switch p.Usage {
+	case UsageCA:
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.MaxPathLenZero = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	case UsageServer:
+		if len(p.Hosts) == 0 {
+			return nil, fmt.Errorf("server certificate needs at least one host")
+		}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	case UsageClient:
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	default:
+		return nil, fmt.Errorf("unsupported certificate usage %q", p.Usage)
+	}
+
+	return template, nil
+}
+
+// createCertificate signs the template with the key of the parent and returns the certificate with its PEM encoding.
+func createCertificate(template *x509.Certificate, parent *x509.Certificate, public crypto.PublicKey, key crypto.Signer) (*x509.Certificate, []byte, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, public, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
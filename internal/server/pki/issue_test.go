@@ -0,0 +1,153 @@
+package pki
+
+import (
+	"crypto"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType KeyType
+		wantErr bool
+	}{
+		{name: "ecdsa", keyType: KeyECDSA},
+		{name: "ed25519", keyType: KeyEd25519},
+		{name: "rsa", keyType: KeyRSA},
+		{name: "unsupported", keyType: "dsa", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := GenerateKey(tt.keyType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			keyType, err := KeyTypeOf(key.Public())
+			require.NoError(t, err)
+			assert.Equal(t, tt.keyType, keyType)
+
+			keyPEM, err := MarshalPrivateKey(key)
+			require.NoError(t, err)
+			parsed, err := ParsePrivateKey(keyPEM)
+			require.NoError(t, err)
+			assert.True(t, key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(parsed.Public()))
+		})
+	}
+}
+
+func TestAuthority_Issue(t *testing.T) {
+	caKey, err := GenerateKey(KeyECDSA)
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, caPEM, err := SelfSigned(&Profile{Usage: UsageCA, CommonName: "local CA", Validity: 30 * 24 * time.Hour}, caKey, now)
+	require.NoError(t, err)
+	caKeyPEM, err := MarshalPrivateKey(caKey)
+	require.NoError(t, err)
+
+	authority, err := NewAuthority(caPEM, caKeyPEM)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		profile   *Profile
+		keyType   KeyType
+		keyUsage  x509.ExtKeyUsage
+		host      string
+		wantLimit bool
+		wantErr   bool
+	}{
+		{
+			name:     "server",
+			profile:  &Profile{Usage: UsageServer, CommonName: "server", Hosts: []string{"localhost", "127.0.0.1"}, Validity: 24 * time.Hour},
+			keyType:  KeyEd25519,
+			keyUsage: x509.ExtKeyUsageServerAuth,
+			host:     "127.0.0.1",
+		},
+		{
+			name:      "client outliving the CA",
+			profile:   &Profile{Usage: UsageClient, CommonName: "client", Validity: 365 * 24 * time.Hour},
+			keyType:   KeyRSA,
+			keyUsage:  x509.ExtKeyUsageClientAuth,
+			wantLimit: true,
+		},
+		{
+			name:    "server without hosts",
+			profile: &Profile{Usage: UsageServer, CommonName: "server", Validity: time.Hour},
+			keyType: KeyECDSA,
+			wantErr: true,
+		},
+		{
+			name:    "no validity",
+			profile: &Profile{Usage: UsageClient, CommonName: "client"},
+			keyType: KeyECDSA,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := GenerateKey(tt.keyType)
+			require.NoError(t, err)
+
+			cert, certPEM, err := authority.Issue(tt.profile, key.Public(), now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, authority.Issued(cert))
+
+			parsed, err := ParseCertificate(certPEM)
+			require.NoError(t, err)
+			assert.Equal(t, cert.Raw, parsed.Raw)
+
+			_, err = cert.Verify(x509.VerifyOptions{
+				DNSName:     tt.host,
+				Roots:       authority.CertPool(),
+				KeyUsages:   []x509.ExtKeyUsage{tt.keyUsage},
+				CurrentTime: now,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, cert.NotAfter.Equal(authority.cert.NotAfter))
+		})
+	}
+}
+
+func TestProfileOf(t *testing.T) {
+	key, err := GenerateKey(KeyECDSA)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		profile *Profile
+	}{
+		{name: "ca", profile: &Profile{Usage: UsageCA, CommonName: "local CA", Organization: []string{"GophKeeper"}, Validity: 48 * time.Hour}},
+		{name: "server", profile: &Profile{Usage: UsageServer, CommonName: "server", Hosts: []string{"localhost", "::1"}, Validity: time.Hour}},
+		{name: "client", profile: &Profile{Usage: UsageClient, CommonName: "client", Validity: 24 * time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, _, err := SelfSigned(tt.profile, key, time.Now())
+			require.NoError(t, err)
+
+			profile, err := ProfileOf(cert)
+			require.NoError(t, err)
+			assert.Equal(t, tt.profile.Usage, profile.Usage)
+			assert.Equal(t, tt.profile.CommonName, profile.CommonName)
+			assert.Equal(t, tt.profile.Organization, profile.Organization)
+			assert.Equal(t, tt.profile.Hosts, profile.Hosts)
+			assert.Equal(t, tt.profile.Validity, profile.Validity)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Модуль pki подписывает сертификаты устройств клиентским УЦ сервера
+// и выпускает сертификаты локального УЦ для certManager
+package pki
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceCertValidity is the validity period of the device certificates, limited by the validity of the CA.
+const DeviceCertValidity = 365 * 24 * time.Hour
+
+// serialBits is the size of the random serial numbers of the issued certificates.
+const serialBits = 128
+
+// Authority is the client CA of the server: the device certificates it signs are accepted by the mutual TLS.
+// The subject of a device certificate names the device by the common name and the user by the organizational unit.
+type Authority struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	key     crypto.Signer
+}
+
+// LoadAuthority reads the PEM encoded certificate and private key of the client CA from the files.
+func LoadAuthority(certFile string, keyFile string) (*Authority, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA certificate: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA key: %w", err)
+	}
+
+	return NewAuthority(certPEM, keyPEM)
+}
+
+// NewAuthority parses the PEM encoded certificate and private key of the client CA.
+// The key is accepted in the PKCS#8, SEC 1 or PKCS#1 form and must match the certificate.
+func NewAuthority(certPEM []byte, keyPEM []byte) (*Authority, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("client CA certificate is not a PEM certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("client CA certificate is not a CA")
+	}
+
+	key, err := ParsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("client CA key: %w", err)
+	}
+
+	public, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !public.Equal(key.Public()) {
+		return nil, fmt.Errorf("client CA key does not match the certificate")
+	}
+
+	return &Authority{
+		cert:    cert,
+		certPEM: pem.EncodeToMemory(block),
+		key:     key,
+	}, nil
+}
+
+// CertPool returns the pool the client certificates are verified with.
+func (a *Authority) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(a.cert)
+
+	return pool
+}
+
+// CertificatePEM returns the PEM encoded certificate of the client CA.
+func (a *Authority) CertificatePEM() []byte {
+	return a.certPEM
+}
+
+// SignDevice signs the PKCS#10 request of the device with the client CA. The subject of the request is replaced
+// with the identity of the device, only its public key is used. Returns the certificate with its PEM encoding.
+func (a *Authority) SignDevice(csrDER []byte, userID uuid.UUID, deviceID uuid.UUID, now time.Time) (*x509.Certificate, []byte, error) {
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse certificate request: %w", err)
+	}
+	if err = csr.CheckSignature(); err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate request signature: %w", err)
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Сертификат устройства не переживает сертификат УЦ
+	notAfter := now.Add(DeviceCertValidity)
+	if notAfter.After(a.cert.NotAfter) {
+		notAfter = a.cert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:         deviceID.String(),
+			OrganizationalUnit: []string{userID.String()},
+		},
+		NotBefore:   now.Add(-backdate),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	return createCertificate(template, a.cert, csr.PublicKey, a.key)
+}
+
+// DeviceIdentity returns the user and the device named by the subject of the device certificate.
+func DeviceIdentity(cert *x509.Certificate) (uuid.UUID, uuid.UUID, error) {
+	if len(cert.Subject.OrganizationalUnit) != 1 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("certificate does not name the user")
+	}
+
+	userID, err := uuid.Parse(cert.Subject.OrganizationalUnit[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user in certificate: %w", err)
+	}
+
+	deviceID, err := uuid.Parse(cert.Subject.CommonName)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid device in certificate: %w", err)
+	}
+
+	return userID, deviceID, nil
+}
+
+// Fingerprint returns the hex encoded SHA-256 of the certificate.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// ParsePrivateKey parses the PEM encoded private key in the PKCS#8, SEC 1 or PKCS#1 form.
+func ParsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not a PEM key")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("failed to parse private key")
+}
+
+// newSerial returns a random serial number for a new certificate.
+func newSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	return serial, nil
+}
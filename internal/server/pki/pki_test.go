@@ -0,0 +1,176 @@
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCA creates a self-signed CA valid for the period and returns its PEM encoded certificate and PKCS#8 key.
+func newTestCA(t *testing.T, validity time.Duration) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test client CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validity),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
+
+// newTestCSR creates a certificate request signed by the key.
+func newTestCSR(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "requested name"},
+	}, key)
+	require.NoError(t, err)
+
+	return csr
+}
+
+func TestNewAuthority(t *testing.T) {
+	certPEM, keyPEM := newTestCA(t, time.Hour)
+	_, otherKeyPEM := newTestCA(t, time.Hour)
+
+	tests := []struct {
+		name    string
+		certPEM []byte
+		keyPEM  []byte
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid", certPEM: certPEM, keyPEM: keyPEM, wantErr: assert.NoError},
+		{name: "key of another CA", certPEM: certPEM, keyPEM: otherKeyPEM, wantErr: assert.Error},
+		{name: "not a certificate", certPEM: keyPEM, keyPEM: keyPEM, wantErr: assert.Error},
+		{name: "not a key", certPEM: certPEM, keyPEM: []byte("key"), wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthority(tt.certPEM, tt.keyPEM)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestAuthority_SignDevice(t *testing.T) {
+	certPEM, keyPEM := newTestCA(t, 2*DeviceCertValidity)
+	authority, err := NewAuthority(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tampered := newTestCSR(t, ecKey)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := []struct {
+		name    string
+		csr     []byte
+		wantErr bool
+	}{
+		{name: "ecdsa", csr: newTestCSR(t, ecKey)},
+		{name: "ed25519", csr: newTestCSR(t, edKey)},
+		{name: "invalid signature", csr: tampered, wantErr: true},
+		{name: "not a request", csr: []byte("csr"), wantErr: true},
+	}
+
+	userID, deviceID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, certPEM, err := authority.SignDevice(tt.csr, userID, deviceID, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, certPEM)
+
+			_, err = cert.Verify(x509.VerifyOptions{
+				Roots:     authority.CertPool(),
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			assert.NoError(t, err)
+
+			gotUserID, gotDeviceID, err := DeviceIdentity(cert)
+			require.NoError(t, err)
+			assert.Equal(t, userID, gotUserID)
+			assert.Equal(t, deviceID, gotDeviceID)
+			assert.Len(t, Fingerprint(cert), 64)
+		})
+	}
+}
+
+func TestAuthority_SignDevice_LimitedByCA(t *testing.T) {
+	certPEM, keyPEM := newTestCA(t, 24*time.Hour)
+	authority, err := NewAuthority(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	cert, _, err := authority.SignDevice(newTestCSR(t, key), uuid.New(), uuid.New(), time.Now())
+	require.NoError(t, err)
+	assert.False(t, cert.NotAfter.After(authority.cert.NotAfter))
+}
+
+func TestDeviceIdentity(t *testing.T) {
+	userID, deviceID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		subject pkix.Name
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "device certificate",
+			subject: pkix.Name{CommonName: deviceID.String(), OrganizationalUnit: []string{userID.String()}},
+			wantErr: assert.NoError,
+		},
+		{name: "no user", subject: pkix.Name{CommonName: deviceID.String()}, wantErr: assert.Error},
+		{
+			name:    "invalid device",
+			subject: pkix.Name{CommonName: "laptop", OrganizationalUnit: []string{userID.String()}},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DeviceIdentity(&x509.Certificate{Subject: tt.subject})
+			tt.wantErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package pki
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertExpiryWarning is how long before the expiry of the server certificate the warnings are logged.
+const CertExpiryWarning = 30 * 24 * time.Hour
+
+// certExpiryCheckInterval is the period of repeating the expiry warning of a certificate which is not replaced.
+const certExpiryCheckInterval = 24 * time.Hour
+
+// CertReloader serves the TLS certificate of the server from the files and reloads it when they change,
+// so a renewed certificate is used by the new handshakes without restarting the server.
+// The files are polled rather than watched: the renewal replaces them by renaming, which the watchers lose track of.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.RWMutex
+	cert        *tls.Certificate
+	stamp       fileStamp
+	expiryCheck time.Time
+}
+
+// fileStamp identifies the versions of the certificate and the key files by their modification times and sizes.
+type fileStamp struct {
+	certModified time.Time
+	certSize     int64
+	keyModified  time.Time
+	keySize      int64
+}
+
+// NewCertReloader loads the PEM encoded certificate and private key of the server from the files.
+func NewCertReloader(certFile string, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if _, err := r.Reload(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns the current certificate, it is used as the tls.Config GetCertificate callback.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// Reload loads the certificate and the key if any of the files has changed since the last load and reports
+// whether the certificate is replaced. The current certificate is kept if the new pair fails to load,
+// e.g. when the certificate is already replaced and the key is not yet, the next call retries it.
+func (r *CertReloader) Reload(now time.Time) (bool, error) {
+	stamp, err := r.fileStamp()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.cert != nil && stamp == r.stamp
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("could not load tls cert: %w", err)
+	}
+
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return false, fmt.Errorf("could not parse tls cert: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.stamp = stamp
+	r.mu.Unlock()
+
+	slog.Info("TLS certificate loaded",
+		slog.String("file", r.certFile),
+		slog.String("subject", cert.Leaf.Subject.String()),
+		slog.String("fingerprint", Fingerprint(cert.Leaf)),
+		slog.Time("not_after", cert.Leaf.NotAfter),
+	)
+	r.checkExpiry(now)
+
+	return true, nil
+}
+
+// Run reloads the changed certificate on every tick until the context is done,
+// repeating the expiry warning once a day while the certificate is not renewed.
+func (r *CertReloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			reloaded, err := r.Reload(now)
+			if err != nil {
+				slog.Error("could not reload TLS certificate", slog.String("error", err.Error()))
+			}
+
+			if !reloaded && now.Sub(r.expiryCheck) >= certExpiryCheckInterval {
+				r.checkExpiry(now)
+			}
+		}
+	}
+}
+
+// NotAfter returns the expiry of the current certificate.
+func (r *CertReloader) NotAfter() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert.Leaf.NotAfter
+}
+
+// checkExpiry logs a warning if the current certificate expires within CertExpiryWarning, and an error if it has expired.
+func (r *CertReloader) checkExpiry(now time.Time) {
+	r.expiryCheck = now
+	notAfter := r.NotAfter()
+
+	switch left := notAfter.Sub(now); {
+	case left <= 0:
+		slog.Error("TLS certificate has expired, renew it",
+			slog.String("file", r.certFile),
+			slog.Time("not_after", notAfter),
+		)
+	case left <= CertExpiryWarning:
+		slog.Warn("TLS certificate expires soon, renew it",
+			slog.String("file", r.certFile),
+			slog.Time("not_after", notAfter),
+			slog.Int("days_left", int(left.Hours()/24)),
+		)
+	}
+}
+
+// fileStamp returns the current stamp of the certificate and the key files.
+func (r *CertReloader) fileStamp() (fileStamp, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return fileStamp{}, fmt.Errorf("could not stat tls cert: %w", err)
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return fileStamp{}, fmt.Errorf("could not stat tls key: %w", err)
+	}
+
+	return fileStamp{
+		certModified: certInfo.ModTime(),
+		certSize:     certInfo.Size(),
+		keyModified:  keyInfo.ModTime(),
+		keySize:      keyInfo.Size(),
+	}, nil
+}
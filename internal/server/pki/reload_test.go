@@ -0,0 +1,109 @@
+package pki
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestPair writes a new self-signed server certificate with its key into the files,
+// setting their modification time, and returns the fingerprint of the certificate.
+func writeTestPair(t *testing.T, certFile string, keyFile string, modified time.Time) string {
+	t.Helper()
+
+	key, err := GenerateKey(KeyECDSA)
+	require.NoError(t, err)
+
+	cert, certPEM, err := SelfSigned(&Profile{Usage: UsageServer, CommonName: "localhost", Hosts: []string{"localhost"}, Validity: time.Hour}, key, time.Now())
+	require.NoError(t, err)
+	keyPEM, err := MarshalPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0644))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+	require.NoError(t, os.Chtimes(certFile, modified, modified))
+	require.NoError(t, os.Chtimes(keyFile, modified, modified))
+
+	return Fingerprint(cert)
+}
+
+func TestCertReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "public.crt"), filepath.Join(dir, "private.key")
+	modified := time.Now().Add(-time.Hour)
+
+	first := writeTestPair(t, certFile, keyFile, modified)
+	reloader, err := NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	current := func() string {
+		cert, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+		return Fingerprint(cert.Leaf)
+	}
+	assert.Equal(t, first, current())
+
+	tests := []struct {
+		name         string
+		prepare      func() string
+		wantReloaded bool
+		wantErr      bool
+	}{
+		{
+			name:    "unchanged files",
+			prepare: func() string { return first },
+		},
+		{
+			name: "renewed certificate",
+			prepare: func() string {
+				modified = modified.Add(time.Minute)
+				return writeTestPair(t, certFile, keyFile, modified)
+			},
+			wantReloaded: true,
+		},
+		{
+			name: "key does not match yet",
+			prepare: func() string {
+				want := current()
+				require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0600))
+				return want
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing certificate",
+			prepare: func() string {
+				want := current()
+				require.NoError(t, os.Remove(certFile))
+				return want
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.prepare()
+
+			reloaded, err := reloader.Reload(time.Now())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantReloaded, reloaded)
+			assert.Equal(t, want, current())
+		})
+	}
+}
+
+func TestNewCertReloader_Missing(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewCertReloader(filepath.Join(dir, "public.crt"), filepath.Join(dir, "private.key"))
+	assert.Error(t, err)
+}
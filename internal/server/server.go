@@ -1,20 +1,56 @@
 package server
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/domain"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/config"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/grpc"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/grpc/handlers"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/metrics"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/storage"
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/tokens"
+)
+
+const (
+	// certReloadInterval is the period of checking the certificate files of the server for changes.
+	certReloadInterval = 10 * time.Second
+
+	// metricsReadHeaderTimeout limits the time of reading the headers of a metrics scrape.
+	metricsReadHeaderTimeout = 5 * time.Second
+
+	// metricsShutdownTimeout limits the time of finishing the metrics scrapes in progress on shutdown.
+	metricsShutdownTimeout = 5 * time.Second
+
+	// loginSecretInfo separates the login secret from other uses of the private key of the server.
+	loginSecretInfo = "gophkeeper-login-secret|"
 )
 
 // Server represents a gRPC server with authentication capabilities, managing GRPCServer and auth configurations.
+// The emergency releaser approves the emergency access requests whose waiting period has passed.
+// The certificate reloader serves the renewed certificate of the server without a restart.
+// The metrics listener is nil unless the metrics address is configured.
+// The health checker reports the serving status to the health service of the gRPC server.
 type Server struct {
-	grpc *grpc.GRPCServer
-	auth *auth
+	grpc         *grpc.GRPCServer
+	auth         *auth
+	emergency    *emergencyReleaser
+	certificates *pki.CertReloader
+	metrics      *http.Server
+	health       *healthChecker
 }
 
 // auth represents authentication configuration, managing cryptographic and hashing keys for secure operations.
@@ -24,23 +60,172 @@ type auth struct {
 }
 
 // New initializes and returns a new Server instance configured with the provided storage commands, or an error if setup fails.
+// The certificate of the server and the JWT keyset are loaded from the configured files,
+// the mutual TLS is enabled when the client CA is configured.
+// The standard health service is registered on the gRPC server, the reflection service is registered when enabled.
 func New(storageCommands storage.Commands) (*Server, error) {
+	certificates, err := pki.NewCertReloader(config.GetKeys().CryptoKeys.Certificate, config.GetKeys().CryptoKeys.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	keyset, err := newKeyset(config.GetKeys())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT keys: %w", err)
+	}
+
+	loginSecret, err := newLoginSecret(config.GetKeys())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login secret: %w", err)
+	}
+
+	deviceHandler := handlers.NewDeviceHandler(storageCommands, nil)
+	var clientCAs *x509.CertPool
+
+	if cryptoKeys := config.GetKeys().CryptoKeys; cryptoKeys.ClientCA != "" {
+		authority, err := pki.LoadAuthority(cryptoKeys.ClientCA, cryptoKeys.ClientCAKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client CA: %w", err)
+		}
+
+		deviceHandler = handlers.NewDeviceHandler(storageCommands, authority)
+		clientCAs = authority.CertPool()
+	}
+
+	var registry *metrics.Registry
+	var metricsServer *http.Server
+
+	if address := config.GetMetrics().Address; address != "" {
+		registry = metrics.NewRegistry(storageCommands, storageCommands)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", registry)
+		metricsServer = &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: metricsReadHeaderTimeout,
+		}
+	}
+
+	defaultQuota := domain.Quota{
+		MaxBytes:    config.GetQuotas().MaxBytes,
+		MaxItems:    config.GetQuotas().MaxItems,
+		MaxItemSize: config.GetQuotas().MaxItemSize,
+	}
+
 	gRPC, err := grpc.NewServer(
-		handlers.NewItemsDataHandler(storageCommands, storageCommands),
-		handlers.NewMetaDataHandler(storageCommands, storageCommands),
-		handlers.NewAuthHandler(storageCommands, storageCommands),
+		handlers.NewItemsDataHandler(storageCommands, storageCommands, storageCommands, storageCommands, storageCommands,
+			storageCommands, defaultQuota),
+		handlers.NewMetaDataHandler(storageCommands, storageCommands, storageCommands),
+		handlers.NewAuthHandler(storageCommands, storageCommands, storageCommands, storageCommands, storageCommands,
+			storageCommands, storageCommands, keyset, loginSecret),
+		handlers.NewFolderHandler(storageCommands, storageCommands, storageCommands),
+		handlers.NewVaultHandler(storageCommands, storageCommands, storageCommands),
+		handlers.NewSharingHandler(storageCommands, storageCommands, storageCommands, storageCommands, storageCommands,
+			storageCommands, storageCommands, defaultQuota),
+		handlers.NewOrgHandler(storageCommands, storageCommands, storageCommands, storageCommands, storageCommands,
+			defaultQuota),
+		handlers.NewEmergencyHandler(storageCommands, storageCommands, storageCommands, storageCommands, storageCommands,
+			storageCommands, storageCommands),
+		deviceHandler,
+		handlers.NewAPITokenHandler(storageCommands, storageCommands),
+		handlers.NewAuditHandler(storageCommands),
+		keyset,
+		storageCommands,
+		storageCommands,
+		storageCommands,
+		certificates,
+		clientCAs,
+		registry,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed build new server: %w", err)
 	}
 
+	// Статус сервера и каждого сервиса зависит от доступности базы данных
+	services := make([]string, 0, len(gRPC.Server.GetServiceInfo()))
+	for service := range gRPC.Server.GetServiceInfo() {
+		services = append(services, service)
+	}
+	healthChecker := newHealthChecker(storageCommands, services)
+	healthgrpc.RegisterHealthServer(gRPC.Server, healthChecker.server)
+
+	if config.GetGRPC().Reflection {
+		reflection.Register(gRPC.Server)
+		slog.Warn("gRPC reflection is enabled")
+	}
+
 	return &Server{
-		grpc: gRPC,
+		grpc:         gRPC,
+		emergency:    newEmergencyReleaser(storageCommands),
+		certificates: certificates,
+		metrics:      metricsServer,
+		health:       healthChecker,
 	}, nil
 }
 
-// Start initializes the server listener and starts serving gRPC requests on the configured network address.
-func (s *Server) Start() error {
+// newKeyset loads the keyset of the access tokens from the configured keys and logs the ID of the signing key.
+func newKeyset(keys *config.Keys) (*tokens.Keyset, error) {
+	ttl, err := keys.JWT.TokenTTL()
+	if err != nil {
+		return nil, err
+	}
+
+	legacyUntil, err := keys.JWT.LegacyDeadline()
+	if err != nil {
+		return nil, err
+	}
+
+	keyset, err := tokens.NewKeyset(tokens.Options{
+		SigningKey:   keys.JWT.SigningKey,
+		PreviousKeys: keys.JWT.PreviousKeys,
+		HMACSecret:   keys.JWTKey,
+		HMACUntil:    legacyUntil,
+		Issuer:       keys.JWT.Issuer,
+		Audience:     keys.JWT.Audience,
+		TTL:          ttl,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("JWT keys loaded",
+		slog.String("kid", keyset.SigningKeyID()),
+		slog.Int("previous_keys", len(keys.JWT.PreviousKeys)),
+	)
+
+	return keyset, nil
+}
+
+// newLoginSecret returns the secret the decoy login records of the unknown logins are derived from.
+// Unless configured, the secret is derived from the private key of the server, so it stays the same between restarts.
+func newLoginSecret(keys *config.Keys) ([]byte, error) {
+	if keys.LoginSecret != "" {
+		return []byte(keys.LoginSecret), nil
+	}
+
+	privateKey, err := os.ReadFile(keys.CryptoKeys.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	secret := sha256.Sum256(append([]byte(loginSecretInfo), privateKey...))
+
+	return secret[:], nil
+}
+
+// Start initializes the server listener and serves gRPC requests on the configured network address until the context is done,
+// then drains the server gracefully. It returns an error if the server could not be started or stopped serving by itself.
+func (s *Server) Start(ctx context.Context) error {
+	drainTimeout, err := config.GetGRPC().Drain()
+	if err != nil {
+		return err
+	}
+
+	preDrainDelay, err := config.GetGRPC().PreDrain()
+	if err != nil {
+		return err
+	}
+
 	slog.Info("starting server", slog.String("address", config.GetAddress().String()))
 
 	listen, err := net.Listen("tcp", config.GetAddress().String())
@@ -48,5 +233,98 @@ func (s *Server) Start() error {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
-	return s.grpc.Server.Serve(listen)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Фоновое освобождение доступа после периода ожидания
+	go s.emergency.Run(ctx)
+
+	// Отслеживание обновления сертификата сервера
+	go s.certificates.Run(ctx, certReloadInterval)
+
+	// Проверка доступности базы данных для сервиса состояния
+	go s.health.Run(ctx)
+
+	// Метрики отдаются отдельным HTTP сервером, его ошибка не останавливает gRPC сервер
+	if s.metrics != nil {
+		go s.serveMetrics()
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.grpc.Server.Serve(listen)
+	}()
+
+	select {
+	case err = <-serveErr:
+		s.stopMetrics()
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.shutdown(preDrainDelay, drainTimeout)
+
+	return nil
+}
+
+// shutdown reports the server as not serving and keeps serving for the pre-drain delay, so the load balancers
+// see the status before the new requests are refused. Then it waits for the requests in flight to finish within
+// the drain timeout, the connections left are closed after it. The metrics server is stopped last.
+func (s *Server) shutdown(preDrainDelay time.Duration, drainTimeout time.Duration) {
+	slog.Info("shutting down server",
+		slog.Duration("pre_drain_delay", preDrainDelay),
+		slog.Duration("drain_timeout", drainTimeout),
+	)
+
+	s.health.Shutdown()
+
+	// Балансировщики успевают получить NOT_SERVING до отказа в новых запросах
+	if preDrainDelay > 0 {
+		time.Sleep(preDrainDelay)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.Server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		slog.Info("server drained")
+	case <-timer.C:
+		// Принудительное закрытие оставшихся соединений и потоков
+		slog.Warn("drain timeout exceeded, closing remaining connections")
+		s.grpc.Server.Stop()
+		<-stopped
+	}
+
+	s.stopMetrics()
+}
+
+// stopMetrics stops the metrics server, if any, letting the scrapes in progress finish.
+func (s *Server) stopMetrics() {
+	if s.metrics == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+	defer cancel()
+
+	if err := s.metrics.Shutdown(ctx); err != nil {
+		slog.Error("failed to shutdown metrics server", slog.String("error", err.Error()))
+		s.metrics.Close()
+	}
+}
+
+// serveMetrics serves the metrics over HTTP on the configured address until the listener is closed.
+func (s *Server) serveMetrics() {
+	slog.Info("starting metrics server", slog.String("address", s.metrics.Addr))
+
+	if err := s.metrics.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("metrics server failed", slog.String("error", err.Error()))
+	}
 }
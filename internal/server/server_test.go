@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/grpc"
+)
+
+// testServer serves the health service on the in-memory listener and returns the server with its health client.
+func testServer(t *testing.T) (*Server, healthgrpc.HealthClient) {
+	listener := bufconn.Listen(1 << 20)
+
+	checker := newHealthChecker(&testPinger{}, []string{"test.Service"})
+	gRPC := &grpc.GRPCServer{Server: grpclib.NewServer()}
+	healthgrpc.RegisterHealthServer(gRPC.Server, checker.server)
+	go func() {
+		_ = gRPC.Server.Serve(listener)
+	}()
+
+	conn, err := grpclib.NewClient("passthrough:///bufconn",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		gRPC.Server.Stop()
+	})
+
+	return &Server{grpc: gRPC, health: checker}, healthgrpc.NewHealthClient(conn)
+}
+
+// shutdownAsync runs the shutdown of the server and returns the channel closed once it is done.
+func shutdownAsync(s *Server, preDrainDelay time.Duration, drainTimeout time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		s.shutdown(preDrainDelay, drainTimeout)
+		close(done)
+	}()
+
+	return done
+}
+
+func TestServer_Shutdown_PreDrainDelay(t *testing.T) {
+	server, client := testServer(t)
+
+	res, err := client.Check(context.Background(), &healthgrpc.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthgrpc.HealthCheckResponse_SERVING, res.GetStatus())
+
+	started := time.Now()
+	done := shutdownAsync(server, 500*time.Millisecond, time.Second)
+
+	// Во время задержки запросы обслуживаются, а состояние уже NOT_SERVING
+	assert.Eventually(t, func() bool {
+		res, err := client.Check(context.Background(), &healthgrpc.HealthCheckRequest{Service: "test.Service"})
+		return err == nil && res.GetStatus() == healthgrpc.HealthCheckResponse_NOT_SERVING
+	}, 400*time.Millisecond, 10*time.Millisecond)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown is not finished")
+	}
+	assert.GreaterOrEqual(t, time.Since(started), 500*time.Millisecond)
+
+	_, err = client.Check(context.Background(), &healthgrpc.HealthCheckRequest{})
+	assert.Error(t, err)
+}
+
+func TestServer_Shutdown_DrainTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		inFlight     bool
+		drainTimeout time.Duration
+		minDuration  time.Duration
+		maxDuration  time.Duration
+	}{
+		{name: "drained", drainTimeout: 10 * time.Second, maxDuration: 5 * time.Second},
+		{name: "stopped after timeout", inFlight: true, drainTimeout: 200 * time.Millisecond, minDuration: 200 * time.Millisecond, maxDuration: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := testServer(t)
+
+			// Открытый поток Watch не завершается сам и не дает серверу остановиться
+			var stream healthgrpc.Health_WatchClient
+			if tt.inFlight {
+				var err error
+				stream, err = client.Watch(context.Background(), &healthgrpc.HealthCheckRequest{})
+				require.NoError(t, err)
+				res, err := stream.Recv()
+				require.NoError(t, err)
+				require.Equal(t, healthgrpc.HealthCheckResponse_SERVING, res.GetStatus())
+			}
+
+			started := time.Now()
+			select {
+			case <-shutdownAsync(server, 0, tt.drainTimeout):
+			case <-time.After(tt.maxDuration):
+				t.Fatal("shutdown is not finished")
+			}
+			assert.GreaterOrEqual(t, time.Since(started), tt.minDuration)
+
+			if stream != nil {
+				res, err := stream.Recv()
+				require.NoError(t, err)
+				assert.Equal(t, healthgrpc.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+				_, err = stream.Recv()
+				assert.Error(t, err)
+			}
+		})
+	}
+}
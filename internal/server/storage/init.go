@@ -1,8 +1,11 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,15 +14,93 @@ import (
 	"github.com/mikhaylov123ty/GophKeeper/internal/server/storage/psql"
 )
 
-// Commands defines database operations for managing users, items, and metadata, including CRUD and lifecycle methods.
+// Commands defines database operations for managing users, items, metadata, folders, vault keys, shares, organizations, emergency access, devices, API tokens, quotas and the audit log, including CRUD and lifecycle methods, the operator maintenance, the database health check and the connection pool statistics.
 type Commands interface {
-	SaveUser(*domain.UserData) error
-	GetUserByLogin(string) (*domain.UserData, error)
-	SaveItemData(*domain.ItemData, *domain.Meta) error
-	GetItemDataByID(uuid.UUID) (*domain.ItemData, error)
-	DeleteItemDataByID(uuid.UUID) error
-	GetMetaDataByUser(uuid.UUID) ([]*domain.Meta, error)
-	DeleteMetaDataByID(uuid.UUID) error
+	SaveUser(context.Context, *domain.UserData) error
+	GetUserByLogin(context.Context, string) (*domain.UserData, error)
+	GetUserByID(context.Context, uuid.UUID) (*domain.UserData, error)
+	GetSessionVersion(context.Context, uuid.UUID) (int, error)
+	UpdatePassword(context.Context, *domain.UserData, []*domain.VaultKey) error
+	SaveSRPRecord(context.Context, *domain.UserData) error
+	SaveRecoveryKit(context.Context, *domain.UserData, []*domain.VaultKey) error
+	SaveItemData(context.Context, *domain.ItemData, *domain.Meta, *domain.Quota) error
+	GetItemDataByID(context.Context, uuid.UUID) (*domain.ItemData, error)
+	DeleteItemDataByID(context.Context, uuid.UUID) error
+	GetMetaDataByDataID(context.Context, uuid.UUID) (*domain.Meta, error)
+	GetMetaDataByID(context.Context, uuid.UUID) (*domain.Meta, error)
+	GetMetaDataByUser(context.Context, uuid.UUID, *domain.MetaFilter) ([]*domain.Meta, error)
+	DeleteMetaDataByID(context.Context, uuid.UUID, uuid.UUID) error
+	SaveFolder(context.Context, *domain.Folder) error
+	GetFoldersByUser(context.Context, uuid.UUID) ([]*domain.Folder, error)
+	DeleteFolderByID(context.Context, uuid.UUID, uuid.UUID) error
+	SaveVaultKey(context.Context, *domain.VaultKey) error
+	GetVaultKeys(context.Context, uuid.UUID) ([]*domain.VaultKey, error)
+	GetStaleItemKeys(context.Context, uuid.UUID, uint32, int) ([]*domain.ItemData, error)
+	GetStaleMetas(context.Context, uuid.UUID, uint32, int) ([]*domain.Meta, error)
+	CountStaleKeys(context.Context, uuid.UUID, uint32) (int64, error)
+	RewrapKeys(context.Context, uuid.UUID, uint32, []*domain.ItemData, []*domain.Meta) error
+	ActivateVaultKey(context.Context, uuid.UUID, uint32) error
+	SaveSharingKey(context.Context, *domain.SharingKey) error
+	GetSharingKey(context.Context, uuid.UUID) (*domain.SharingKey, error)
+	SaveShare(context.Context, *domain.Share) error
+	GetSharesByData(context.Context, uuid.UUID, uuid.UUID) ([]*domain.Share, error)
+	GetSharesByRecipient(context.Context, uuid.UUID) ([]*domain.Share, error)
+	GetShareByID(context.Context, uuid.UUID) (*domain.Share, error)
+	DeleteShare(context.Context, uuid.UUID, uuid.UUID) error
+	UpdateSharedItemData(context.Context, *domain.Share, []byte, time.Time, *domain.Quota) error
+	SaveOrg(context.Context, *domain.Organization, *domain.OrgMember) error
+	GetOrgsByUser(context.Context, uuid.UUID) ([]*domain.Organization, error)
+	DeleteOrg(context.Context, uuid.UUID) error
+	GetOrgMember(context.Context, uuid.UUID, uuid.UUID) (*domain.OrgMember, error)
+	GetOrgMembers(context.Context, uuid.UUID) ([]*domain.OrgMember, error)
+	SaveOrgMember(context.Context, *domain.OrgMember, []*domain.CollectionKey) error
+	AcceptOrgMember(context.Context, uuid.UUID, uuid.UUID) error
+	UpdateOrgMemberRole(context.Context, uuid.UUID, uuid.UUID, string) error
+	DeleteOrgMember(context.Context, uuid.UUID, uuid.UUID) error
+	SaveCollection(context.Context, *domain.Collection, []*domain.CollectionKey) error
+	GetCollectionsByOrg(context.Context, uuid.UUID, uuid.UUID) ([]*domain.Collection, error)
+	GetCollectionByID(context.Context, uuid.UUID) (*domain.Collection, error)
+	DeleteCollection(context.Context, uuid.UUID) error
+	GetCollectionItems(context.Context, uuid.UUID) ([]*domain.CollectionItem, error)
+	GetCollectionItem(context.Context, uuid.UUID) (*domain.CollectionItem, error)
+	SaveCollectionItem(context.Context, *domain.CollectionItem, *domain.Quota) error
+	DeleteCollectionItem(context.Context, uuid.UUID, uuid.UUID) error
+	RekeyCollection(context.Context, uuid.UUID, uint32, []*domain.CollectionKey, []*domain.CollectionItem) error
+	SaveEmergencyAccess(context.Context, *domain.EmergencyAccess) error
+	GetEmergencyAccessByID(context.Context, uuid.UUID) (*domain.EmergencyAccess, error)
+	GetEmergencyAccessByOwner(context.Context, uuid.UUID) ([]*domain.EmergencyAccess, error)
+	GetEmergencyAccessByContact(context.Context, uuid.UUID) ([]*domain.EmergencyAccess, error)
+	RequestEmergencyAccess(context.Context, uuid.UUID, time.Time) error
+	AnswerEmergencyAccess(context.Context, uuid.UUID, bool) error
+	UpdateEmergencyKeys(context.Context, uuid.UUID, []*domain.EmergencyAccess) error
+	DeleteEmergencyAccess(context.Context, uuid.UUID, uuid.UUID) error
+	ReleaseEmergencyAccess(context.Context, time.Time) (int64, error)
+	SaveDevice(context.Context, *domain.Device) error
+	GetDeviceByID(context.Context, uuid.UUID) (*domain.Device, error)
+	GetDevicesByUser(context.Context, uuid.UUID) ([]*domain.Device, error)
+	RevokeDevice(context.Context, uuid.UUID, uuid.UUID, time.Time) error
+	SaveAPIToken(context.Context, *domain.APIToken) error
+	GetAPITokenByID(context.Context, uuid.UUID) (*domain.APIToken, error)
+	GetAPITokensByUser(context.Context, uuid.UUID) ([]*domain.APIToken, error)
+	UpdateAPITokenUsed(context.Context, uuid.UUID, time.Time) error
+	DeleteAPIToken(context.Context, uuid.UUID, uuid.UUID) error
+	SaveAuditEvent(context.Context, *domain.AuditEvent) error
+	GetAuditEvents(context.Context, uuid.UUID, *domain.AuditFilter) ([]*domain.AuditEvent, error)
+	GetUsers(context.Context) ([]*domain.UserData, error)
+	LockUser(context.Context, uuid.UUID, time.Time) error
+	UnlockUser(context.Context, uuid.UUID) error
+	RevokeSessions(context.Context, uuid.UUID) (int, error)
+	DeleteUser(context.Context, uuid.UUID) error
+	GetStorageUsage(context.Context) ([]*domain.StorageUsage, error)
+	GetStorageTotals(context.Context) (*domain.StorageTotals, error)
+	PurgeOrphanedItemData(context.Context) (int64, error)
+	CheckIntegrity(context.Context) ([]*domain.IntegrityIssue, error)
+	GetUsage(context.Context, uuid.UUID) (*domain.StorageUsage, error)
+	GetUserQuota(context.Context, uuid.UUID) (*domain.Quota, error)
+	SaveUserQuota(context.Context, uuid.UUID, *domain.Quota) error
+	DeleteUserQuota(context.Context, uuid.UUID) error
+	Ping(context.Context) error
+	Stats() sql.DBStats
 	Close() error
 }
 
@@ -31,7 +112,7 @@ func NewInstance(cfg *config.DB) (Commands, error) {
 		return nil, fmt.Errorf("failed to create postgres database instance: %w", err)
 	}
 
-	if err = conn.Ping(); err != nil {
+	if err = conn.Ping(context.Background()); err != nil {
 		return nil, fmt.Errorf("failed to ping postgres database instance: %w", err)
 	}
 
@@ -3,11 +3,13 @@ package psql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/golang-migrate/migrate/v4"
@@ -20,14 +22,30 @@ import (
 )
 
 const (
-	metaTableName      = "metas"
-	itemsDataTableName = "items_data"
-	usersTableName     = "users"
+	metaTableName        = "metas"
+	itemsDataTableName   = "items_data"
+	usersTableName       = "users"
+	foldersTableName     = "folders"
+	metaTagsTableName    = "meta_tags"
+	vaultKeysTableName   = "vault_keys"
+	sharingKeysTableName = "sharing_keys"
+	sharesTableName      = "shares"
+	orgsTableName        = "orgs"
+	orgMembersTableName  = "org_members"
+	collectionsTableName = "collections"
+	collKeysTableName    = "collection_keys"
+	collItemsTableName   = "collection_items"
+	emergencyTableName   = "emergency_access"
+	devicesTableName     = "devices"
+	apiTokensTableName   = "api_tokens"
+	auditEventsTableName = "audit_events"
+	userQuotasTableName  = "user_quotas"
 )
 
 // Storage represents a storage layer that handles database operations using an SQL database connection.
+// The methods take the context of the request, the queries are traced as the children of its span.
 type Storage struct {
-	db *sql.DB
+	db *tracedDB
 }
 
 func New(dsn string, migrationsDir string) (*Storage, error) {
@@ -62,15 +80,18 @@ func New(dsn string, migrationsDir string) (*Storage, error) {
 		return nil, fmt.Errorf("could not apply migrations: %w", err)
 	}
 
-	return &Storage{db: db}, nil
+	return &Storage{db: &tracedDB{DB: db}}, nil
 }
 
 // SaveUser inserts a new user record into the database or returns an error if the operation fails.
-func (s *Storage) SaveUser(data *domain.UserData) error {
+func (s *Storage) SaveUser(ctx context.Context, data *domain.UserData) error {
 	slog.Debug("Save User Data", slog.Any("data", *data))
 
 	query, args, err := squirrel.Insert(usersTableName).
-		Values(data.ID, data.Login, data.Password, data.Created, data.Modified).
+		Columns("id", "login", "password_hash", "srp_salt", "srp_verifier", "kdf_time", "kdf_memory", "kdf_threads",
+			"created_at", "modified_at").
+		Values(data.ID, data.Login, nullPassword(data.Password), data.SRPSalt, data.SRPVerifier, data.KDFTime,
+			data.KDFMemory, data.KDFThreads, data.Created, data.Modified).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -79,7 +100,7 @@ func (s *Storage) SaveUser(data *domain.UserData) error {
 
 	slog.Debug("saving user", slog.String("query", query), slog.Any("args", args))
 
-	_, err = s.db.Exec(query, args...)
+	_, err = s.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("could not save user: %w", err)
 	}
@@ -88,12 +109,26 @@ func (s *Storage) SaveUser(data *domain.UserData) error {
 }
 
 // GetUserByLogin retrieves a user by their login from the database and returns the corresponding UserData or an error.
-func (s *Storage) GetUserByLogin(login string) (*domain.UserData, error) {
+func (s *Storage) GetUserByLogin(ctx context.Context, login string) (*domain.UserData, error) {
 	slog.Debug("Get User Data by Login", slog.String("Login", login))
 
-	query, args, err := squirrel.Select("*").
+	return s.getUser(ctx, squirrel.Eq{"login": login})
+}
+
+// GetUserByID retrieves a user by their ID from the database and returns the corresponding UserData or an error.
+func (s *Storage) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.UserData, error) {
+	slog.Debug("Get User Data by ID", slog.String("ID", id.String()))
+
+	return s.getUser(ctx, squirrel.Eq{"id": id})
+}
+
+// getUser retrieves the user matching the condition.
+func (s *Storage) getUser(ctx context.Context, condition squirrel.Eq) (*domain.UserData, error) {
+	query, args, err := squirrel.Select("id", "login", "COALESCE(password_hash, '')", "srp_salt", "srp_verifier",
+		"kdf_time", "kdf_memory", "kdf_threads", "session_version", "recovery_public_key", "recovery_verifier",
+		"locked_at", "created_at", "modified_at").
 		From(usersTableName).
-		Where(squirrel.Eq{"login": login}).
+		Where(condition).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -102,38 +137,200 @@ func (s *Storage) GetUserByLogin(login string) (*domain.UserData, error) {
 
 	slog.Debug("getting user query", slog.String("query", query), slog.Any("args", args))
 
-	row := s.db.QueryRow(query, args...)
+	row := s.db.QueryRowContext(ctx, query, args...)
 	if row.Err() != nil {
 		return nil, fmt.Errorf("could not execute get user query: %w", row.Err())
 	}
 
-	var user domain.UserData
+	var (
+		user   domain.UserData
+		locked sql.NullTime
+	)
 	if err = row.Scan(
 		&user.ID,
 		&user.Login,
 		&user.Password,
+		&user.SRPSalt,
+		&user.SRPVerifier,
+		&user.KDFTime,
+		&user.KDFMemory,
+		&user.KDFThreads,
+		&user.SessionVersion,
+		&user.RecoveryPublicKey,
+		&user.RecoveryVerifier,
+		&locked,
 		&user.Created,
 		&user.Modified,
 	); err != nil {
 		return nil, fmt.Errorf("could not scan get user data: %w", err)
 	}
+	user.Locked = locked.Time
 
 	return &user, nil
 }
 
+// SaveSRPRecord replaces the bcrypt password hash of the user with the SRP registration record.
+// Only an account still having the password hash is upgraded, so a concurrent upgrade isn't overwritten.
+func (s *Storage) SaveSRPRecord(ctx context.Context, user *domain.UserData) error {
+	slog.Debug("Save SRP Record", slog.String("user ID", user.ID.String()))
+
+	query, args, err := squirrel.Update(usersTableName).
+		Set("password_hash", nil).
+		Set("srp_salt", user.SRPSalt).
+		Set("srp_verifier", user.SRPVerifier).
+		Set("kdf_time", user.KDFTime).
+		Set("kdf_memory", user.KDFMemory).
+		Set("kdf_threads", user.KDFThreads).
+		Set("modified_at", user.Modified).
+		Where(squirrel.And{squirrel.Eq{"id": user.ID}, squirrel.NotEq{"password_hash": nil}}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save srp record query: %w", err)
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save srp record: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get updated users count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetSessionVersion retrieves the current session version of the user, the tokens issued with other versions are revoked.
+// Returns ErrUserLocked if the account is locked, all its tokens are rejected.
+func (s *Storage) GetSessionVersion(ctx context.Context, userID uuid.UUID) (int, error) {
+	query, args, err := squirrel.Select("session_version", "locked_at IS NOT NULL").
+		From(usersTableName).
+		Where(squirrel.Eq{"id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build get session version query: %w", err)
+	}
+
+	var (
+		version int
+		locked  bool
+	)
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&version, &locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("could not get session version: %w", err)
+	}
+
+	if locked {
+		return 0, domain.ErrUserLocked
+	}
+
+	return version, nil
+}
+
+// UpdatePassword atomically replaces the password hash of the user and the wrapped vault keys re-wrapped with the new
+// password, and increments the session version of the user, revoking the issued tokens. The new session version is
+// set into the user. Returns ErrVaultKeysChanged if the keys don't match the stored keys of the user.
+func (s *Storage) UpdatePassword(ctx context.Context, user *domain.UserData, keys []*domain.VaultKey) error {
+	slog.Debug("Update Password", slog.String("user ID", user.ID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userQuery, userArgs, err := squirrel.Update(usersTableName).
+		Set("password_hash", nullPassword(user.Password)).
+		Set("srp_salt", user.SRPSalt).
+		Set("srp_verifier", user.SRPVerifier).
+		Set("kdf_time", user.KDFTime).
+		Set("kdf_memory", user.KDFMemory).
+		Set("kdf_threads", user.KDFThreads).
+		Set("modified_at", user.Modified).
+		Set("session_version", squirrel.Expr("session_version + 1")).
+		Where(squirrel.Eq{"id": user.ID}).
+		Suffix("RETURNING session_version").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update password query: %w", err)
+	}
+
+	if err = tx.QueryRowContext(ctx, userQuery, userArgs...).Scan(&user.SessionVersion); err != nil {
+		return fmt.Errorf("could not update password: %w", err)
+	}
+
+	// Блокировка ключей пользователя, чтобы ротация не началась до конца транзакции
+	if err = lockVaultKeys(ctx, tx, user.ID, len(keys)); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		keyQuery, keyArgs, err := squirrel.Update(vaultKeysTableName).
+			Set("wrapped_key", key.WrappedKey).
+			Set("salt", key.Salt).
+			Set("kdf_time", key.KDFTime).
+			Set("kdf_memory", key.KDFMemory).
+			Set("kdf_threads", key.KDFThreads).
+			Set("modified_at", key.Modified).
+			Where(squirrel.Eq{"user_id": user.ID, "key_id": key.KeyID}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build update vault key query: %w", err)
+		}
+
+		res, err := tx.ExecContext(ctx, keyQuery, keyArgs...)
+		if err != nil {
+			return fmt.Errorf("could not update vault key: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("could not get updated vault keys count: %w", err)
+		}
+		if affected == 0 {
+			return domain.ErrVaultKeysChanged
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // SaveItemData saves the provided item data and its associated metadata in the database within a transaction.
 // The method updates existing records or inserts new ones based on ID conflicts, and returns an error if any step fails.
-func (s *Storage) SaveItemData(item *domain.ItemData, meta *domain.Meta) error {
+// The item is checked against the quota of the user owning the metadata unless it is nil, the saves of the user are
+// serialized so the concurrent uploads can't exceed it. Returns an error wrapping ErrQuotaExceeded if the item doesn't fit.
+func (s *Storage) SaveItemData(ctx context.Context, item *domain.ItemData, meta *domain.Meta, quota *domain.Quota) error {
 	slog.Debug("Save Item Data", slog.Any("data", *item))
-	tx, err := s.db.BeginTx(context.Background(), nil)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("could not begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if quota != nil {
+		if err = checkItemQuota(ctx, tx, item, meta, quota); err != nil {
+			return err
+		}
+	}
+
 	itemDataQuery, itemDataArgs, err := squirrel.Insert(itemsDataTableName).
-		Values(item.ID, item.Data).
-		Suffix("ON CONFLICT(id) DO UPDATE SET data = $2").
+		Columns("id", "data", "wrapped_key", "key_id").
+		Values(item.ID, item.Data, item.WrappedKey, nullKeyID(item.KeyID)).
+		Suffix("ON CONFLICT(id) DO UPDATE SET data = $2, wrapped_key = $3, key_id = $4").
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -141,28 +338,67 @@ func (s *Storage) SaveItemData(item *domain.ItemData, meta *domain.Meta) error {
 	}
 
 	metaDataQuery, metaDataArgs, err := squirrel.Insert(metaTableName).
-		Values(meta.ID, meta.Title, meta.Description, meta.Type, meta.DataID, meta.UserID, meta.Created, meta.Modified).
-		Suffix("ON CONFLICT(id) DO UPDATE SET title = $2, description = $3, data_id = $5, modified_at = $7").
+		Columns("id", "title", "description", "type", "data_id", "user_id", "created_at", "modified_at", "folder_id",
+			"encrypted_meta", "meta_key_id").
+		Values(meta.ID, meta.Title, meta.Description, meta.Type, meta.DataID, meta.UserID, meta.Created, meta.Modified,
+			nullUUID(meta.FolderID), meta.EncryptedMeta, nullKeyID(meta.MetaKeyID)).
+		Suffix("ON CONFLICT(id) DO UPDATE SET title = $2, description = $3, data_id = $5, modified_at = $8, folder_id = $9, " +
+			"encrypted_meta = $10, meta_key_id = $11").
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("could not build save meta query: %w", err)
 	}
 
+	deleteTagsQuery, deleteTagsArgs, err := squirrel.Delete(metaTagsTableName).
+		Where(squirrel.Eq{"meta_id": meta.ID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete tags query: %w", err)
+	}
+
 	slog.Debug("saving item data", slog.String("query", itemDataQuery), slog.Any("args", itemDataArgs))
 
-	_, err = tx.Exec(itemDataQuery, itemDataArgs...)
+	_, err = tx.ExecContext(ctx, itemDataQuery, itemDataArgs...)
 	if err != nil {
 		return fmt.Errorf("could not save item data: %w", err)
 	}
 
 	slog.Debug("saving meta data", slog.String("query", metaDataQuery), slog.Any("args", metaDataArgs))
 
-	_, err = tx.Exec(metaDataQuery, metaDataArgs...)
+	_, err = tx.ExecContext(ctx, metaDataQuery, metaDataArgs...)
 	if err != nil {
 		return fmt.Errorf("could not save meta data: %w", err)
 	}
 
+	// Теги элемента полностью заменяются переданными
+	_, err = tx.ExecContext(ctx, deleteTagsQuery, deleteTagsArgs...)
+	if err != nil {
+		return fmt.Errorf("could not delete meta tags: %w", err)
+	}
+
+	if len(meta.Tags) > 0 {
+		insertTags := squirrel.Insert(metaTagsTableName).
+			Columns("meta_id", "tag").
+			PlaceholderFormat(squirrel.Dollar)
+		for _, tag := range meta.Tags {
+			insertTags = insertTags.Values(meta.ID, tag)
+		}
+
+		tagsQuery, tagsArgs, err := insertTags.ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build save tags query: %w", err)
+		}
+
+		slog.Debug("saving meta tags", slog.String("query", tagsQuery), slog.Any("args", tagsArgs))
+
+		_, err = tx.ExecContext(ctx, tagsQuery, tagsArgs...)
+		if err != nil {
+			return fmt.Errorf("could not save meta tags: %w", err)
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("could not commit transaction: %w", err)
 	}
@@ -171,9 +407,9 @@ func (s *Storage) SaveItemData(item *domain.ItemData, meta *domain.Meta) error {
 }
 
 // GetItemDataByID retrieves the item data by its unique ID from the items_data table and returns it or an error.
-func (s *Storage) GetItemDataByID(id uuid.UUID) (*domain.ItemData, error) {
+func (s *Storage) GetItemDataByID(ctx context.Context, id uuid.UUID) (*domain.ItemData, error) {
 	slog.Debug("Get Item Data by ID", slog.String("ID", id.String()))
-	query, args, err := squirrel.Select("*").
+	query, args, err := squirrel.Select("id", "data", "wrapped_key").
 		From(itemsDataTableName).
 		Where(squirrel.Eq{"id": id}).
 		PlaceholderFormat(squirrel.Dollar).
@@ -184,7 +420,7 @@ func (s *Storage) GetItemDataByID(id uuid.UUID) (*domain.ItemData, error) {
 
 	slog.Debug("getting data", slog.String("query", query), slog.Any("args", args))
 
-	row := s.db.QueryRow(query, args...)
+	row := s.db.QueryRowContext(ctx, query, args...)
 	if row.Err() != nil {
 		return nil, fmt.Errorf("could not execute get item data by id query: %w", row.Err())
 	}
@@ -192,6 +428,7 @@ func (s *Storage) GetItemDataByID(id uuid.UUID) (*domain.ItemData, error) {
 	if err = row.Scan(
 		&res.ID,
 		&res.Data,
+		&res.WrappedKey,
 	); err != nil {
 		return nil, fmt.Errorf("could not scan get item data by id query: %w", err)
 	}
@@ -199,10 +436,25 @@ func (s *Storage) GetItemDataByID(id uuid.UUID) (*domain.ItemData, error) {
 	return &res, nil
 }
 
-// DeleteItemDataByID removes an item record from the items_data table based on its unique ID. Returns an error if it fails.
-func (s *Storage) DeleteItemDataByID(id uuid.UUID) error {
+// DeleteItemDataByID removes an item record from the items_data table based on its unique ID together with the shares
+// of the item. Returns an error if it fails.
+func (s *Storage) DeleteItemDataByID(ctx context.Context, id uuid.UUID) error {
 	slog.Debug("Delete Item Data by ID", slog.String("ID", id.String()))
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	sharesQuery, sharesArgs, err := squirrel.Delete(sharesTableName).
+		Where(squirrel.Eq{"data_id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete item shares query: %w", err)
+	}
+
 	query, args, err := squirrel.Delete(itemsDataTableName).
 		Where(squirrel.Eq{"id": id}).
 		PlaceholderFormat(squirrel.Dollar).
@@ -211,26 +463,113 @@ func (s *Storage) DeleteItemDataByID(id uuid.UUID) error {
 		return fmt.Errorf("could not build delete item data by id query: %w", err)
 	}
 
+	slog.Debug("deleting item shares", slog.String("query", sharesQuery), slog.Any("args", sharesArgs))
+
+	if _, err = tx.ExecContext(ctx, sharesQuery, sharesArgs...); err != nil {
+		return fmt.Errorf("could not delete item shares: %w", err)
+	}
+
 	slog.Debug("deleting item data", slog.String("query", query), slog.Any("args", args))
 
-	_, err = s.db.Exec(query, args...)
-	if err != nil {
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
 		return fmt.Errorf("could not delete item data: %w", err)
 	}
 
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+// GetMetaDataByDataID retrieves the identifiers, the type and the owner of the metadata record of the item data.
+// Returns sql.ErrNoRows if the item has no metadata.
+func (s *Storage) GetMetaDataByDataID(ctx context.Context, dataID uuid.UUID) (*domain.Meta, error) {
+	slog.Debug("Get Meta Data by data ID", slog.String("data ID", dataID.String()))
+
+	query, args, err := squirrel.Select("id", "type", "data_id", "user_id").
+		From(metaTableName).
+		Where(squirrel.Eq{"data_id": dataID.String()}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get meta data by data id query: %w", err)
+	}
+
+	slog.Debug("getting meta data", slog.String("query", query), slog.Any("args", args))
+
+	var res domain.Meta
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&res.ID, &res.Type, &res.DataID, &res.UserID); err != nil {
+		return nil, fmt.Errorf("could not scan meta data by data id: %w", err)
+	}
+
+	return &res, nil
+}
+
+// GetMetaDataByID retrieves the identifiers, the type, the folder and the owner of the metadata record by its ID.
+// Returns sql.ErrNoRows if there is no such record.
+func (s *Storage) GetMetaDataByID(ctx context.Context, id uuid.UUID) (*domain.Meta, error) {
+	slog.Debug("Get Meta Data by ID", slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Select("id", "type", "data_id", "user_id", "folder_id").
+		From(metaTableName).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get meta data by id query: %w", err)
+	}
+
+	slog.Debug("getting meta data", slog.String("query", query), slog.Any("args", args))
+
+	var res domain.Meta
+	var folderID uuid.NullUUID
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&res.ID, &res.Type, &res.DataID, &res.UserID, &folderID); err != nil {
+		return nil, fmt.Errorf("could not scan meta data by id: %w", err)
+	}
+	res.FolderID = folderID.UUID
+
+	return &res, nil
+}
+
 // GetMetaDataByUser retrieves metadata records associated with a specific user ID from the database or returns an error.
-func (s *Storage) GetMetaDataByUser(userID uuid.UUID) ([]*domain.Meta, error) {
-	slog.Debug("Get Meta Data by user", slog.String("user ID", userID.String()))
+// The records are narrowed down by the filter, when it is set, and ordered by ID, so the pages selected by
+// the AfterID and Limit of the filter stay stable.
+func (s *Storage) GetMetaDataByUser(ctx context.Context, userID uuid.UUID, filter *domain.MetaFilter) ([]*domain.Meta, error) {
+	slog.Debug("Get Meta Data by user", slog.String("user ID", userID.String()), slog.Any("filter", filter))
 
-	query, args, err := squirrel.Select("*").
+	conditions := squirrel.And{
+		squirrel.Eq{"user_id": userID},
+	}
+	builder := squirrel.Select("id", "title", "description", "type", "data_id", "user_id", "created_at", "modified_at",
+		"folder_id", "encrypted_meta").
 		From(metaTableName).
-		Where(
-			squirrel.And{
-				squirrel.Eq{"user_id": userID},
-			}).
+		OrderBy("id")
+
+	if filter != nil {
+		if filter.Tag != "" {
+			conditions = append(conditions, squirrel.Expr(
+				fmt.Sprintf("id IN (SELECT meta_id FROM %s WHERE tag = ?)", metaTagsTableName), filter.Tag))
+		}
+		if filter.FolderID != uuid.Nil {
+			conditions = append(conditions, squirrel.Eq{"folder_id": filter.FolderID})
+		}
+		if filter.DataType != "" {
+			conditions = append(conditions, squirrel.Eq{"type": filter.DataType})
+		}
+		if !filter.ModifiedSince.IsZero() {
+			conditions = append(conditions, squirrel.GtOrEq{"modified_at": filter.ModifiedSince})
+		}
+		if filter.AfterID != uuid.Nil {
+			conditions = append(conditions, squirrel.Gt{"id": filter.AfterID})
+		}
+		if filter.Limit > 0 {
+			builder = builder.Limit(uint64(filter.Limit))
+		}
+	}
+
+	query, args, err := builder.
+		Where(conditions).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -239,7 +578,7 @@ func (s *Storage) GetMetaDataByUser(userID uuid.UUID) ([]*domain.Meta, error) {
 
 	slog.Debug("getting meta data", slog.String("query", query), slog.Any("args", args))
 
-	rows, err := s.db.Query(query, args...)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) && rows.Err() != nil {
 		return nil, fmt.Errorf("could not execute get meta query: %w", err)
 	}
@@ -248,6 +587,7 @@ func (s *Storage) GetMetaDataByUser(userID uuid.UUID) ([]*domain.Meta, error) {
 	var res []*domain.Meta
 	for rows.Next() {
 		row := &domain.Meta{}
+		var folderID uuid.NullUUID
 		if err = rows.Scan(
 			&row.ID,
 			&row.Title,
@@ -255,11 +595,14 @@ func (s *Storage) GetMetaDataByUser(userID uuid.UUID) ([]*domain.Meta, error) {
 			&row.Type,
 			&row.DataID,
 			&row.UserID,
-			&row.Modified,
 			&row.Created,
+			&row.Modified,
+			&folderID,
+			&row.EncryptedMeta,
 		); err != nil {
 			return nil, fmt.Errorf("could not execute get meta query: %w", err)
 		}
+		row.FolderID = folderID.UUID
 
 		res = append(res, row)
 	}
@@ -268,15 +611,62 @@ func (s *Storage) GetMetaDataByUser(userID uuid.UUID) ([]*domain.Meta, error) {
 		return nil, sql.ErrNoRows
 	}
 
+	if err = s.fillMetaTags(ctx, res); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
-// DeleteMetaDataByID removes a metadata record from the metas table by its unique ID. Returns an error if the operation fails.
-func (s *Storage) DeleteMetaDataByID(id uuid.UUID) error {
-	slog.Debug("Delete Meta Data by ID", slog.String("ID", id.String()))
+// fillMetaTags loads the tags of the metadata records and sets them on the records.
+func (s *Storage) fillMetaTags(ctx context.Context, metas []*domain.Meta) error {
+	byID := make(map[uuid.UUID]*domain.Meta, len(metas))
+	ids := make([]uuid.UUID, 0, len(metas))
+	for _, v := range metas {
+		byID[v.ID] = v
+		ids = append(ids, v.ID)
+	}
+
+	query, args, err := squirrel.Select("meta_id", "tag").
+		From(metaTagsTableName).
+		Where(squirrel.Eq{"meta_id": ids}).
+		OrderBy("tag").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build get meta tags query: %w", err)
+	}
+
+	slog.Debug("getting meta tags", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not execute get meta tags query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var metaID uuid.UUID
+		var tag string
+		if err = rows.Scan(&metaID, &tag); err != nil {
+			return fmt.Errorf("could not scan meta tag: %w", err)
+		}
+
+		if meta, ok := byID[metaID]; ok {
+			meta.Tags = append(meta.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+// DeleteMetaDataByID removes a metadata record of the user from the metas table by its unique ID.
+// Returns an error if the operation fails.
+func (s *Storage) DeleteMetaDataByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	slog.Debug("Delete Meta Data by ID", slog.String("ID", id.String()), slog.String("user ID", userID.String()))
 
 	query, args, err := squirrel.Delete(metaTableName).
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"id": id, "user_id": userID}).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -285,7 +675,7 @@ func (s *Storage) DeleteMetaDataByID(id uuid.UUID) error {
 
 	slog.Debug("deleting metadata", slog.String("query", query), slog.Any("args", args))
 
-	_, err = s.db.Exec(query, args...)
+	_, err = s.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("could not delete meta data: %w", err)
 	}
@@ -293,12 +683,3060 @@ func (s *Storage) DeleteMetaDataByID(id uuid.UUID) error {
 	return nil
 }
 
-// Close terminates the database connection and releases any associated resources. Returns an error if it fails.
-func (s *Storage) Close() error {
-	return s.db.Close()
+// SaveFolder inserts a new folder or renames and moves the existing one with the same ID and owner.
+func (s *Storage) SaveFolder(ctx context.Context, folder *domain.Folder) error {
+	slog.Debug("Save Folder", slog.Any("folder", *folder))
+
+	query, args, err := squirrel.Insert(foldersTableName).
+		Columns("id", "name", "parent_id", "user_id", "created_at", "modified_at").
+		Values(folder.ID, folder.Name, nullUUID(folder.ParentID), folder.UserID, folder.Created, folder.Modified).
+		Suffix("ON CONFLICT(id) DO UPDATE SET name = $2, parent_id = $3, modified_at = $6 WHERE folders.user_id = $4").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save folder query: %w", err)
+	}
+
+	slog.Debug("saving folder", slog.String("query", query), slog.Any("args", args))
+
+	_, err = s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save folder: %w", err)
+	}
+
+	return nil
 }
 
-// Ping checks the connection to the database and returns an error if the database is not reachable.
-func (s *Storage) Ping() error {
-	return s.db.Ping()
+// GetFoldersByUser retrieves all folders of the user. Returns sql.ErrNoRows if the user has no folders.
+func (s *Storage) GetFoldersByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Folder, error) {
+	slog.Debug("Get Folders by user", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Select("id", "name", "parent_id", "user_id", "created_at", "modified_at").
+		From(foldersTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("name").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get folders query: %w", err)
+	}
+
+	slog.Debug("getting folders", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get folders query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.Folder
+	for rows.Next() {
+		row := &domain.Folder{}
+		var parentID uuid.NullUUID
+		if err = rows.Scan(
+			&row.ID,
+			&row.Name,
+			&parentID,
+			&row.UserID,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan folder: %w", err)
+		}
+		row.ParentID = parentID.UUID
+
+		res = append(res, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read folders: %w", err)
+	}
+
+	if len(res) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return res, nil
+}
+
+// DeleteFolderByID removes the folder of the user together with its subfolders. The items of the removed
+// folders are kept and moved to the top level. Returns sql.ErrNoRows if the user has no such folder.
+func (s *Storage) DeleteFolderByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	slog.Debug("Delete Folder by ID", slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Delete(foldersTableName).
+		Where(squirrel.Eq{"id": id, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete folder query: %w", err)
+	}
+
+	slog.Debug("deleting folder", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete folder: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted folders count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SaveVaultKey stores the wrapped vault key of the user. Returns ErrVaultKeyExists if the user already has a key
+// with the same version or in the same state.
+func (s *Storage) SaveVaultKey(ctx context.Context, key *domain.VaultKey) error {
+	slog.Debug("Save Vault Key", slog.String("user ID", key.UserID.String()), slog.Any("key ID", key.KeyID))
+
+	query, args, err := squirrel.Insert(vaultKeysTableName).
+		Columns("user_id", "key_id", "state", "wrapped_key", "salt", "kdf_time", "kdf_memory", "kdf_threads",
+			"recovery_wrapped_key", "created_at", "modified_at").
+		Values(key.UserID, key.KeyID, key.State, key.WrappedKey, key.Salt, key.KDFTime, key.KDFMemory, key.KDFThreads,
+			key.RecoveryWrappedKey, key.Created, key.Modified).
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save vault key query: %w", err)
+	}
+
+	slog.Debug("saving vault key", slog.String("query", query))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save vault key: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get saved vault keys count: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrVaultKeyExists
+	}
+
+	return nil
+}
+
+// GetVaultKeys retrieves the wrapped vault keys of the user ordered by version: the key in use and the new key
+// of an unfinished rotation. Returns sql.ErrNoRows if the user has not uploaded one.
+func (s *Storage) GetVaultKeys(ctx context.Context, userID uuid.UUID) ([]*domain.VaultKey, error) {
+	slog.Debug("Get Vault Keys", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Select("user_id", "key_id", "state", "wrapped_key", "salt", "kdf_time", "kdf_memory",
+		"kdf_threads", "recovery_wrapped_key", "created_at", "modified_at").
+		From(vaultKeysTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("key_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get vault keys query: %w", err)
+	}
+
+	slog.Debug("getting vault keys", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get vault keys query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.VaultKey
+	for rows.Next() {
+		row := &domain.VaultKey{}
+		if err = rows.Scan(
+			&row.UserID,
+			&row.KeyID,
+			&row.State,
+			&row.WrappedKey,
+			&row.Salt,
+			&row.KDFTime,
+			&row.KDFMemory,
+			&row.KDFThreads,
+			&row.RecoveryWrappedKey,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan vault key: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not read vault keys: %w", err)
+	}
+
+	if len(res) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return res, nil
+}
+
+// GetStaleItemKeys retrieves up to limit items of the user whose data keys are wrapped with a vault key
+// other than keyID. Only the IDs and the wrapped keys are selected, the item data stays in place.
+func (s *Storage) GetStaleItemKeys(ctx context.Context, userID uuid.UUID, keyID uint32, limit int) ([]*domain.ItemData, error) {
+	slog.Debug("Get Stale Item Keys", slog.String("user ID", userID.String()), slog.Any("key ID", keyID))
+
+	query, args, err := squirrel.Select("i.id", "i.wrapped_key", "i.key_id").
+		From(itemsDataTableName + " i").
+		Join(metaTableName + " m ON m.data_id = i.id").
+		Where(squirrel.And{
+			squirrel.Eq{"m.user_id": userID},
+			squirrel.NotEq{"i.key_id": keyID},
+		}).
+		OrderBy("i.id").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get stale item keys query: %w", err)
+	}
+
+	slog.Debug("getting stale item keys", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get stale item keys query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.ItemData
+	for rows.Next() {
+		row := &domain.ItemData{}
+		if err = rows.Scan(&row.ID, &row.WrappedKey, &row.KeyID); err != nil {
+			return nil, fmt.Errorf("could not scan stale item key: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// GetStaleMetas retrieves up to limit metadata records of the user sealed with a vault key other than keyID.
+// Only the IDs, the data types and the encrypted metadata are selected.
+func (s *Storage) GetStaleMetas(ctx context.Context, userID uuid.UUID, keyID uint32, limit int) ([]*domain.Meta, error) {
+	slog.Debug("Get Stale Metas", slog.String("user ID", userID.String()), slog.Any("key ID", keyID))
+
+	query, args, err := squirrel.Select("id", "type", "encrypted_meta", "meta_key_id").
+		From(metaTableName).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.NotEq{"meta_key_id": keyID},
+		}).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get stale metas query: %w", err)
+	}
+
+	slog.Debug("getting stale metas", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get stale metas query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.Meta
+	for rows.Next() {
+		row := &domain.Meta{UserID: userID}
+		if err = rows.Scan(&row.ID, &row.Type, &row.EncryptedMeta, &row.MetaKeyID); err != nil {
+			return nil, fmt.Errorf("could not scan stale meta: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// CountStaleKeys returns the number of items, metadata records and sharing keys of the user still encrypted with
+// a vault key other than keyID.
+func (s *Storage) CountStaleKeys(ctx context.Context, userID uuid.UUID, keyID uint32) (int64, error) {
+	return countStaleKeys(ctx, s.db, userID, keyID)
+}
+
+// RewrapKeys replaces the wrapped data keys of the items and the encrypted metadata of the user with the ones
+// sealed with the vault key keyID. Records of other users are left untouched.
+func (s *Storage) RewrapKeys(ctx context.Context, userID uuid.UUID, keyID uint32, items []*domain.ItemData, metas []*domain.Meta) error {
+	slog.Debug("Rewrap Keys", slog.String("user ID", userID.String()), slog.Any("key ID", keyID),
+		slog.Int("items", len(items)), slog.Int("metas", len(metas)))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, item := range items {
+		query, args, err := squirrel.Update(itemsDataTableName).
+			Set("wrapped_key", item.WrappedKey).
+			Set("key_id", keyID).
+			Where(squirrel.And{
+				squirrel.Eq{"id": item.ID},
+				squirrel.Expr(fmt.Sprintf("id IN (SELECT data_id FROM %s WHERE user_id = ?)", metaTableName), userID),
+			}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build rewrap item key query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("could not rewrap item key: %w", err)
+		}
+	}
+
+	for _, meta := range metas {
+		query, args, err := squirrel.Update(metaTableName).
+			Set("encrypted_meta", meta.EncryptedMeta).
+			Set("meta_key_id", keyID).
+			Where(squirrel.Eq{"id": meta.ID, "user_id": userID}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build reseal meta query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("could not reseal meta: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ActivateVaultKey finishes the rotation: the pending vault key keyID becomes the key in use and the previous keys
+// are removed. Returns ErrRotationIncomplete if some records are still encrypted with the previous keys
+// and sql.ErrNoRows if the user has no such pending key.
+func (s *Storage) ActivateVaultKey(ctx context.Context, userID uuid.UUID, keyID uint32) error {
+	slog.Debug("Activate Vault Key", slog.String("user ID", userID.String()), slog.Any("key ID", keyID))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка ключей пользователя до конца транзакции
+	lockQuery, lockArgs, err := squirrel.Select("key_id").
+		From(vaultKeysTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build lock vault keys query: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, lockQuery, lockArgs...); err != nil {
+		return fmt.Errorf("could not lock vault keys: %w", err)
+	}
+
+	stale, err := countStaleKeys(ctx, tx, userID, keyID)
+	if err != nil {
+		return err
+	}
+	if stale > 0 {
+		return domain.ErrRotationIncomplete
+	}
+
+	deleteQuery, deleteArgs, err := squirrel.Delete(vaultKeysTableName).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.NotEq{"key_id": keyID},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete vault keys query: %w", err)
+	}
+
+	activateQuery, activateArgs, err := squirrel.Update(vaultKeysTableName).
+		Set("state", domain.VaultKeyActive).
+		Set("modified_at", time.Now()).
+		Where(squirrel.Eq{"user_id": userID, "key_id": keyID, "state": domain.VaultKeyPending}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build activate vault key query: %w", err)
+	}
+
+	slog.Debug("retiring vault keys", slog.String("query", deleteQuery), slog.Any("args", deleteArgs))
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf("could not delete vault keys: %w", err)
+	}
+
+	slog.Debug("activating vault key", slog.String("query", activateQuery), slog.Any("args", activateArgs))
+
+	res, err := tx.ExecContext(ctx, activateQuery, activateArgs...)
+	if err != nil {
+		return fmt.Errorf("could not activate vault key: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get activated vault keys count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveRecoveryKit stores the recovery public key and verifier of the user together with the vault keys sealed
+// to the public key. An existing recovery kit is replaced. Returns ErrVaultKeysChanged if the keys don't match
+// the stored keys of the user.
+func (s *Storage) SaveRecoveryKit(ctx context.Context, user *domain.UserData, keys []*domain.VaultKey) error {
+	slog.Debug("Save Recovery Kit", slog.String("user ID", user.ID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	userQuery, userArgs, err := squirrel.Update(usersTableName).
+		Set("recovery_public_key", user.RecoveryPublicKey).
+		Set("recovery_verifier", user.RecoveryVerifier).
+		Set("modified_at", user.Modified).
+		Where(squirrel.Eq{"id": user.ID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save recovery kit query: %w", err)
+	}
+
+	slog.Debug("saving recovery kit", slog.String("query", userQuery))
+
+	if _, err = tx.ExecContext(ctx, userQuery, userArgs...); err != nil {
+		return fmt.Errorf("could not save recovery kit: %w", err)
+	}
+
+	if err = lockVaultKeys(ctx, tx, user.ID, len(keys)); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		keyQuery, keyArgs, err := squirrel.Update(vaultKeysTableName).
+			Set("recovery_wrapped_key", key.RecoveryWrappedKey).
+			Where(squirrel.Eq{"user_id": user.ID, "key_id": key.KeyID}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build save recovery wrapped key query: %w", err)
+		}
+
+		res, err := tx.ExecContext(ctx, keyQuery, keyArgs...)
+		if err != nil {
+			return fmt.Errorf("could not save recovery wrapped key: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("could not get updated vault keys count: %w", err)
+		}
+		if affected == 0 {
+			return domain.ErrVaultKeysChanged
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveSharingKey stores the sharing key pair of the user. A stored key pair is only re-wrapped with another vault key:
+// its public key can't be replaced, as the shared items are sealed to it. Returns ErrSharingKeyExists in that case.
+func (s *Storage) SaveSharingKey(ctx context.Context, key *domain.SharingKey) error {
+	slog.Debug("Save Sharing Key", slog.String("user ID", key.UserID.String()), slog.Any("key ID", key.KeyID))
+
+	query, args, err := squirrel.Insert(sharingKeysTableName).
+		Columns("user_id", "public_key", "wrapped_private_key", "key_id", "created_at", "modified_at").
+		Values(key.UserID, key.PublicKey, key.WrappedPrivateKey, key.KeyID, key.Created, key.Modified).
+		Suffix("ON CONFLICT(user_id) DO UPDATE SET wrapped_private_key = $3, key_id = $4, modified_at = $6 " +
+			"WHERE " + sharingKeysTableName + ".public_key = $2").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save sharing key query: %w", err)
+	}
+
+	slog.Debug("saving sharing key", slog.String("query", query))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save sharing key: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get saved sharing keys count: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrSharingKeyExists
+	}
+
+	return nil
+}
+
+// GetSharingKey retrieves the sharing key pair of the user. Returns sql.ErrNoRows if the user has not uploaded one.
+func (s *Storage) GetSharingKey(ctx context.Context, userID uuid.UUID) (*domain.SharingKey, error) {
+	slog.Debug("Get Sharing Key", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Select("user_id", "public_key", "wrapped_private_key", "key_id", "created_at",
+		"modified_at").
+		From(sharingKeysTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get sharing key query: %w", err)
+	}
+
+	slog.Debug("getting sharing key", slog.String("query", query), slog.Any("args", args))
+
+	var res domain.SharingKey
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.UserID,
+		&res.PublicKey,
+		&res.WrappedPrivateKey,
+		&res.KeyID,
+		&res.Created,
+		&res.Modified,
+	); err != nil {
+		return nil, fmt.Errorf("could not scan sharing key: %w", err)
+	}
+
+	return &res, nil
+}
+
+// SaveShare stores the share of the item with the recipient. Sharing the item with the same recipient again replaces
+// the sealed data key, the metadata and the permission. The ID and the creation time of the stored share are set
+// into the share.
+func (s *Storage) SaveShare(ctx context.Context, share *domain.Share) error {
+	slog.Debug("Save Share", slog.String("data ID", share.DataID.String()),
+		slog.String("recipient ID", share.RecipientID.String()), slog.String("permission", share.Permission))
+
+	query, args, err := squirrel.Insert(sharesTableName).
+		Columns("id", "data_id", "owner_id", "recipient_id", "wrapped_key", "encrypted_meta", "data_type", "permission",
+			"created_at", "modified_at").
+		Values(share.ID, share.DataID, share.OwnerID, share.RecipientID, share.WrappedKey, share.EncryptedMeta,
+			share.DataType, share.Permission, share.Created, share.Modified).
+		Suffix("ON CONFLICT(data_id, recipient_id) DO UPDATE SET wrapped_key = $5, encrypted_meta = $6, " +
+			"permission = $8, modified_at = $10 RETURNING id, created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save share query: %w", err)
+	}
+
+	slog.Debug("saving share", slog.String("query", query))
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&share.ID, &share.Created); err != nil {
+		return fmt.Errorf("could not save share: %w", err)
+	}
+
+	return nil
+}
+
+// GetSharesByData retrieves the shares of the item owned by the user with the logins and public keys of the recipients.
+func (s *Storage) GetSharesByData(ctx context.Context, ownerID uuid.UUID, dataID uuid.UUID) ([]*domain.Share, error) {
+	slog.Debug("Get Shares by data", slog.String("owner ID", ownerID.String()), slog.String("data ID", dataID.String()))
+
+	return s.getShares(ctx, squirrel.Eq{"s.owner_id": ownerID, "s.data_id": dataID})
+}
+
+// GetSharesByRecipient retrieves the shares of the items shared with the user with the logins of their owners.
+func (s *Storage) GetSharesByRecipient(ctx context.Context, recipientID uuid.UUID) ([]*domain.Share, error) {
+	slog.Debug("Get Shares by recipient", slog.String("recipient ID", recipientID.String()))
+
+	return s.getShares(ctx, squirrel.Eq{"s.recipient_id": recipientID})
+}
+
+// GetShareByID retrieves the share by its ID. Returns sql.ErrNoRows if there is no such share.
+func (s *Storage) GetShareByID(ctx context.Context, id uuid.UUID) (*domain.Share, error) {
+	slog.Debug("Get Share by ID", slog.String("ID", id.String()))
+
+	shares, err := s.getShares(ctx, squirrel.Eq{"s.id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shares) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return shares[0], nil
+}
+
+// getShares retrieves the shares matching the condition ordered by creation time, together with the logins
+// of the owners and the recipients and the public keys of the recipients.
+func (s *Storage) getShares(ctx context.Context, condition squirrel.Eq) ([]*domain.Share, error) {
+	query, args, err := squirrel.Select("s.id", "s.data_id", "s.owner_id", "s.recipient_id", "o.login", "r.login",
+		"COALESCE(k.public_key, ''::bytea)", "s.wrapped_key", "s.encrypted_meta", "s.data_type", "s.permission",
+		"s.created_at", "s.modified_at").
+		From(sharesTableName+" s").
+		Join(usersTableName+" o ON o.id = s.owner_id").
+		Join(usersTableName+" r ON r.id = s.recipient_id").
+		LeftJoin(sharingKeysTableName+" k ON k.user_id = s.recipient_id").
+		Where(condition).
+		OrderBy("s.created_at", "s.id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get shares query: %w", err)
+	}
+
+	slog.Debug("getting shares", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get shares query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.Share
+	for rows.Next() {
+		row := &domain.Share{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.DataID,
+			&row.OwnerID,
+			&row.RecipientID,
+			&row.OwnerLogin,
+			&row.RecipientLogin,
+			&row.RecipientPublicKey,
+			&row.WrappedKey,
+			&row.EncryptedMeta,
+			&row.DataType,
+			&row.Permission,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan share: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// DeleteShare revokes the share owned by the user. Returns sql.ErrNoRows if the user has no such share.
+func (s *Storage) DeleteShare(ctx context.Context, ownerID uuid.UUID, id uuid.UUID) error {
+	slog.Debug("Delete Share", slog.String("owner ID", ownerID.String()), slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Delete(sharesTableName).
+		Where(squirrel.Eq{"id": id, "owner_id": ownerID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete share query: %w", err)
+	}
+
+	slog.Debug("deleting share", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete share: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted shares count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdateSharedItemData replaces the data of the shared item edited by the recipient and marks the item of the owner
+// as modified. The data key of the item stays the same, so the wrapped keys are left untouched.
+// The data counts towards the usage of the owner, so it is checked against the quota of the owner unless it is nil.
+// Returns sql.ErrNoRows if the share has been revoked or its permission lowered meanwhile
+// and an error wrapping ErrQuotaExceeded if the data doesn't fit.
+func (s *Storage) UpdateSharedItemData(ctx context.Context, share *domain.Share, data []byte, modified time.Time,
+	quota *domain.Quota) error {
+	slog.Debug("Update Shared Item Data", slog.String("share ID", share.ID.String()),
+		slog.String("data ID", share.DataID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	shareQuery, shareArgs, err := squirrel.Update(sharesTableName).
+		Set("modified_at", modified).
+		Where(squirrel.Eq{"id": share.ID, "recipient_id": share.RecipientID, "permission": domain.SharePermissionEdit}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update share query: %w", err)
+	}
+
+	dataQuery, dataArgs, err := squirrel.Update(itemsDataTableName).
+		Set("data", data).
+		Where(squirrel.Eq{"id": share.DataID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update shared item data query: %w", err)
+	}
+
+	metaQuery, metaArgs, err := squirrel.Update(metaTableName).
+		Set("modified_at", modified).
+		Where(squirrel.Eq{"data_id": share.DataID.String(), "user_id": share.OwnerID.String()}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update shared item meta query: %w", err)
+	}
+
+	// Обновление доступа блокирует его до конца транзакции, отзыв дождется сохранения
+	res, err := tx.ExecContext(ctx, shareQuery, shareArgs...)
+	if err != nil {
+		return fmt.Errorf("could not update share: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get updated shares count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if quota != nil {
+		if err = checkSharedItemQuota(ctx, tx, share, int64(len(data)), quota); err != nil {
+			return err
+		}
+	}
+
+	slog.Debug("updating shared item data", slog.String("query", dataQuery))
+
+	if _, err = tx.ExecContext(ctx, dataQuery, dataArgs...); err != nil {
+		return fmt.Errorf("could not update shared item data: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, metaQuery, metaArgs...); err != nil {
+		return fmt.Errorf("could not update shared item meta: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// checkSharedItemQuota checks the data of the shared item fits into the quota of the owner of the item,
+// the stored data of the item is replaced and not counted.
+func checkSharedItemQuota(ctx context.Context, tx *tracedTx, share *domain.Share, dataSize int64, quota *domain.Quota) error {
+	if err := lockUsage(ctx, tx, share.OwnerID); err != nil {
+		return err
+	}
+
+	query, args, err := squirrel.Select("COALESCE(octet_length(data), 0)").
+		From(itemsDataTableName).
+		Where(squirrel.Eq{"id": share.DataID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build get shared item size query: %w", err)
+	}
+
+	var replacedSize int64
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&replacedSize); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("could not get shared item size: %w", err)
+	}
+
+	return checkQuota(ctx, tx, share.OwnerID, quota, dataSize, replacedSize, false)
+}
+
+// SaveOrg stores the new organization together with the membership of its owner.
+func (s *Storage) SaveOrg(ctx context.Context, org *domain.Organization, owner *domain.OrgMember) error {
+	slog.Debug("Save Org", slog.String("ID", org.ID.String()), slog.String("owner ID", org.OwnerID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args, err := squirrel.Insert(orgsTableName).
+		Columns("id", "name", "owner_id", "created_at", "modified_at").
+		Values(org.ID, org.Name, org.OwnerID, org.Created, org.Modified).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save org query: %w", err)
+	}
+
+	slog.Debug("saving org", slog.String("query", query), slog.Any("args", args))
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not save org: %w", err)
+	}
+
+	if err = insertOrgMember(ctx, tx, owner); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrgsByUser retrieves the organizations the user is a member of or invited to, with the role of the user.
+func (s *Storage) GetOrgsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error) {
+	slog.Debug("Get Orgs by user", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Select("o.id", "o.name", "o.owner_id", "m.role", "m.accepted", "o.created_at",
+		"o.modified_at").
+		From(orgsTableName+" o").
+		Join(orgMembersTableName+" m ON m.org_id = o.id").
+		Where(squirrel.Eq{"m.user_id": userID}).
+		OrderBy("o.name", "o.id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get orgs query: %w", err)
+	}
+
+	slog.Debug("getting orgs", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get orgs query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.Organization
+	for rows.Next() {
+		row := &domain.Organization{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.Name,
+			&row.OwnerID,
+			&row.Role,
+			&row.Accepted,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan org: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// DeleteOrg removes the organization with its members, collections, collection keys and items.
+func (s *Storage) DeleteOrg(ctx context.Context, orgID uuid.UUID) error {
+	slog.Debug("Delete Org", slog.String("ID", orgID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	collections := squirrel.Expr(fmt.Sprintf("collection_id IN (SELECT id FROM %s WHERE org_id = ?)",
+		collectionsTableName), orgID)
+
+	queries := []squirrel.DeleteBuilder{
+		squirrel.Delete(collItemsTableName).Where(collections),
+		squirrel.Delete(collKeysTableName).Where(collections),
+		squirrel.Delete(collectionsTableName).Where(squirrel.Eq{"org_id": orgID}),
+		squirrel.Delete(orgMembersTableName).Where(squirrel.Eq{"org_id": orgID}),
+		squirrel.Delete(orgsTableName).Where(squirrel.Eq{"id": orgID}),
+	}
+	for _, v := range queries {
+		query, args, err := v.PlaceholderFormat(squirrel.Dollar).ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build delete org query: %w", err)
+		}
+
+		slog.Debug("deleting org", slog.String("query", query), slog.Any("args", args))
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("could not delete org: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrgMember retrieves the membership of the user in the organization. Returns sql.ErrNoRows if the user is neither
+// a member nor invited.
+func (s *Storage) GetOrgMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) (*domain.OrgMember, error) {
+	slog.Debug("Get Org Member", slog.String("org ID", orgID.String()), slog.String("user ID", userID.String()))
+
+	members, err := s.getOrgMembers(ctx, squirrel.Eq{"m.org_id": orgID, "m.user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return members[0], nil
+}
+
+// GetOrgMembers retrieves the members and the invited users of the organization with their logins and public keys.
+func (s *Storage) GetOrgMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	slog.Debug("Get Org Members", slog.String("org ID", orgID.String()))
+
+	return s.getOrgMembers(ctx, squirrel.Eq{"m.org_id": orgID})
+}
+
+// getOrgMembers retrieves the memberships matching the condition ordered by creation time, together with the logins
+// and the public sharing keys of the users.
+func (s *Storage) getOrgMembers(ctx context.Context, condition squirrel.Eq) ([]*domain.OrgMember, error) {
+	query, args, err := squirrel.Select("m.org_id", "m.user_id", "u.login", "m.role", "m.accepted",
+		"COALESCE(k.public_key, ''::bytea)", "m.created_at", "m.modified_at").
+		From(orgMembersTableName+" m").
+		Join(usersTableName+" u ON u.id = m.user_id").
+		LeftJoin(sharingKeysTableName+" k ON k.user_id = m.user_id").
+		Where(condition).
+		OrderBy("m.created_at", "m.user_id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get org members query: %w", err)
+	}
+
+	slog.Debug("getting org members", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get org members query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.OrgMember
+	for rows.Next() {
+		row := &domain.OrgMember{}
+		if err = rows.Scan(
+			&row.OrgID,
+			&row.UserID,
+			&row.Login,
+			&row.Role,
+			&row.Accepted,
+			&row.PublicKey,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan org member: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// SaveOrgMember stores the invitation of the user into the organization together with the collection keys sealed
+// to the user. Returns ErrOrgMemberExists if the user is already a member or invited.
+func (s *Storage) SaveOrgMember(ctx context.Context, member *domain.OrgMember, keys []*domain.CollectionKey) error {
+	slog.Debug("Save Org Member", slog.String("org ID", member.OrgID.String()),
+		slog.String("user ID", member.UserID.String()), slog.String("role", member.Role))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = insertOrgMember(ctx, tx, member); err != nil {
+		return err
+	}
+
+	if err = insertCollectionKeys(ctx, tx, keys); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptOrgMember marks the invitation of the user into the organization as accepted.
+// Returns sql.ErrNoRows if the user is not invited.
+func (s *Storage) AcceptOrgMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error {
+	slog.Debug("Accept Org Member", slog.String("org ID", orgID.String()), slog.String("user ID", userID.String()))
+
+	return s.updateOrgMember(ctx, orgID, userID, map[string]any{"accepted": true, "modified_at": time.Now()})
+}
+
+// UpdateOrgMemberRole changes the role of the member of the organization. Returns sql.ErrNoRows if there is no such member.
+func (s *Storage) UpdateOrgMemberRole(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, role string) error {
+	slog.Debug("Update Org Member Role", slog.String("org ID", orgID.String()), slog.String("user ID", userID.String()),
+		slog.String("role", role))
+
+	return s.updateOrgMember(ctx, orgID, userID, map[string]any{"role": role, "modified_at": time.Now()})
+}
+
+// updateOrgMember sets the columns of the membership of the user. Returns sql.ErrNoRows if there is no such membership.
+func (s *Storage) updateOrgMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID, values map[string]any) error {
+	query, args, err := squirrel.Update(orgMembersTableName).
+		SetMap(values).
+		Where(squirrel.Eq{"org_id": orgID, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update org member query: %w", err)
+	}
+
+	slog.Debug("updating org member", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not update org member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get updated org members count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteOrgMember removes the user from the organization together with the collection keys sealed to the user.
+// Returns sql.ErrNoRows if the user is not a member.
+func (s *Storage) DeleteOrgMember(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) error {
+	slog.Debug("Delete Org Member", slog.String("org ID", orgID.String()), slog.String("user ID", userID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	keysQuery, keysArgs, err := squirrel.Delete(collKeysTableName).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.Expr(fmt.Sprintf("collection_id IN (SELECT id FROM %s WHERE org_id = ?)", collectionsTableName), orgID),
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete member collection keys query: %w", err)
+	}
+
+	query, args, err := squirrel.Delete(orgMembersTableName).
+		Where(squirrel.Eq{"org_id": orgID, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete org member query: %w", err)
+	}
+
+	slog.Debug("deleting org member", slog.String("query", query), slog.Any("args", args))
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete org member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted org members count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err = tx.ExecContext(ctx, keysQuery, keysArgs...); err != nil {
+		return fmt.Errorf("could not delete member collection keys: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveCollection stores the new collection of the organization together with its key sealed to every member.
+func (s *Storage) SaveCollection(ctx context.Context, collection *domain.Collection, keys []*domain.CollectionKey) error {
+	slog.Debug("Save Collection", slog.String("ID", collection.ID.String()), slog.String("org ID", collection.OrgID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args, err := squirrel.Insert(collectionsTableName).
+		Columns("id", "org_id", "name", "key_id", "created_at", "modified_at").
+		Values(collection.ID, collection.OrgID, collection.Name, collection.KeyID, collection.Created, collection.Modified).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save collection query: %w", err)
+	}
+
+	slog.Debug("saving collection", slog.String("query", query), slog.Any("args", args))
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not save collection: %w", err)
+	}
+
+	if err = insertCollectionKeys(ctx, tx, keys); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollectionsByOrg retrieves the collections of the organization with their keys sealed to the user.
+func (s *Storage) GetCollectionsByOrg(ctx context.Context, orgID uuid.UUID, userID uuid.UUID) ([]*domain.Collection, error) {
+	slog.Debug("Get Collections by org", slog.String("org ID", orgID.String()), slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Select("c.id", "c.org_id", "c.name", "c.key_id", "COALESCE(k.wrapped_key, ''::bytea)",
+		"c.created_at", "c.modified_at").
+		From(collectionsTableName+" c").
+		LeftJoin(collKeysTableName+" k ON k.collection_id = c.id AND k.key_id = c.key_id AND k.user_id = ?", userID).
+		Where(squirrel.Eq{"c.org_id": orgID}).
+		OrderBy("c.name", "c.id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get collections query: %w", err)
+	}
+
+	slog.Debug("getting collections", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get collections query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.Collection
+	for rows.Next() {
+		row := &domain.Collection{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.OrgID,
+			&row.Name,
+			&row.KeyID,
+			&row.WrappedKey,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan collection: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// GetCollectionByID retrieves the collection without the wrapped key. Returns sql.ErrNoRows if there is no such collection.
+func (s *Storage) GetCollectionByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+	slog.Debug("Get Collection by ID", slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Select("id", "org_id", "name", "key_id", "created_at", "modified_at").
+		From(collectionsTableName).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get collection query: %w", err)
+	}
+
+	slog.Debug("getting collection", slog.String("query", query), slog.Any("args", args))
+
+	var res domain.Collection
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(
+		&res.ID,
+		&res.OrgID,
+		&res.Name,
+		&res.KeyID,
+		&res.Created,
+		&res.Modified,
+	); err != nil {
+		return nil, fmt.Errorf("could not scan collection: %w", err)
+	}
+
+	return &res, nil
+}
+
+// DeleteCollection removes the collection with its keys and items.
+func (s *Storage) DeleteCollection(ctx context.Context, id uuid.UUID) error {
+	slog.Debug("Delete Collection", slog.String("ID", id.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	queries := []squirrel.DeleteBuilder{
+		squirrel.Delete(collItemsTableName).Where(squirrel.Eq{"collection_id": id}),
+		squirrel.Delete(collKeysTableName).Where(squirrel.Eq{"collection_id": id}),
+		squirrel.Delete(collectionsTableName).Where(squirrel.Eq{"id": id}),
+	}
+	for _, v := range queries {
+		query, args, err := v.PlaceholderFormat(squirrel.Dollar).ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build delete collection query: %w", err)
+		}
+
+		slog.Debug("deleting collection", slog.String("query", query), slog.Any("args", args))
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("could not delete collection: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetCollectionItems retrieves the items of the collection without their data, ordered by creation time.
+func (s *Storage) GetCollectionItems(ctx context.Context, collectionID uuid.UUID) ([]*domain.CollectionItem, error) {
+	slog.Debug("Get Collection Items", slog.String("collection ID", collectionID.String()))
+
+	return s.getCollectionItems(ctx, []string{"''::bytea", "''::bytea"}, squirrel.Eq{"collection_id": collectionID})
+}
+
+// GetCollectionItem retrieves the item of a collection with its data. Returns sql.ErrNoRows if there is no such item.
+func (s *Storage) GetCollectionItem(ctx context.Context, id uuid.UUID) (*domain.CollectionItem, error) {
+	slog.Debug("Get Collection Item", slog.String("ID", id.String()))
+
+	items, err := s.getCollectionItems(ctx, []string{"data", "wrapped_key"}, squirrel.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return items[0], nil
+}
+
+// getCollectionItems retrieves the collection items matching the condition. The data and the wrapped key columns
+// are replaced with the given expressions, so the item lists don't load the item data.
+func (s *Storage) getCollectionItems(ctx context.Context, dataColumns []string, condition squirrel.Eq) ([]*domain.CollectionItem, error) {
+	columns := append([]string{"id", "collection_id"}, dataColumns...)
+	columns = append(columns, "key_id", "encrypted_meta", "data_type", "created_by", "created_at", "modified_at")
+
+	query, args, err := squirrel.Select(columns...).
+		From(collItemsTableName).
+		Where(condition).
+		OrderBy("created_at", "id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get collection items query: %w", err)
+	}
+
+	slog.Debug("getting collection items", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get collection items query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.CollectionItem
+	for rows.Next() {
+		row := &domain.CollectionItem{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.CollectionID,
+			&row.Data,
+			&row.WrappedKey,
+			&row.KeyID,
+			&row.EncryptedMeta,
+			&row.DataType,
+			&row.CreatedBy,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan collection item: %w", err)
+		}
+
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// SaveCollectionItem stores the new or changed item of the collection. The collection is locked until the item
+// is saved, so a concurrent re-keying can't leave the item encrypted with the previous collection key.
+// The item counts towards the usage of the member who created it and is checked against the quota of the creator
+// unless it is nil. Returns ErrCollectionKeyChanged if the item is encrypted with a collection key other than
+// the current one, sql.ErrNoRows if the item with the same ID belongs to another collection and an error wrapping
+// ErrQuotaExceeded if the item doesn't fit.
+func (s *Storage) SaveCollectionItem(ctx context.Context, item *domain.CollectionItem, quota *domain.Quota) error {
+	slog.Debug("Save Collection Item", slog.String("ID", item.ID.String()),
+		slog.String("collection ID", item.CollectionID.String()), slog.Any("key ID", item.KeyID))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockCollectionKey(ctx, tx, item.CollectionID, item.KeyID); err != nil {
+		return err
+	}
+
+	if quota != nil {
+		if err = checkCollectionItemQuota(ctx, tx, item, quota); err != nil {
+			return err
+		}
+	}
+
+	query, args, err := squirrel.Insert(collItemsTableName).
+		Columns("id", "collection_id", "data", "wrapped_key", "key_id", "encrypted_meta", "data_type", "created_by",
+			"created_at", "modified_at").
+		Values(item.ID, item.CollectionID, item.Data, item.WrappedKey, item.KeyID, item.EncryptedMeta, item.DataType,
+			item.CreatedBy, item.Created, item.Modified).
+		Suffix("ON CONFLICT(id) DO UPDATE SET data = $3, wrapped_key = $4, key_id = $5, encrypted_meta = $6, " +
+			"modified_at = $10 WHERE " + collItemsTableName + ".collection_id = $2 RETURNING created_at").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save collection item query: %w", err)
+	}
+
+	slog.Debug("saving collection item", slog.String("query", query))
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&item.Created); err != nil {
+		return fmt.Errorf("could not save collection item: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// checkCollectionItemQuota checks the collection item fits into the quota of its creator. The stored data of the item
+// created by the same member is replaced and not counted, the item adds to the number of the items unless it is stored.
+func checkCollectionItemQuota(ctx context.Context, tx *tracedTx, item *domain.CollectionItem, quota *domain.Quota) error {
+	if err := lockUsage(ctx, tx, item.CreatedBy); err != nil {
+		return err
+	}
+
+	query, args, err := squirrel.Select().
+		Column(squirrel.Expr(fmt.Sprintf("COALESCE((SELECT octet_length(data) FROM %s WHERE id = ? AND created_by = ?), 0)",
+			collItemsTableName), item.ID, item.CreatedBy)).
+		Column(squirrel.Expr(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s WHERE id = ?)", collItemsTableName), item.ID)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build get replaced collection item query: %w", err)
+	}
+
+	slog.Debug("getting replaced collection item", slog.String("query", query), slog.Any("args", args))
+
+	var (
+		replacedSize int64
+		newItem      bool
+	)
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&replacedSize, &newItem); err != nil {
+		return fmt.Errorf("could not get replaced collection item: %w", err)
+	}
+
+	return checkQuota(ctx, tx, item.CreatedBy, quota, int64(len(item.Data)), replacedSize, newItem)
+}
+
+// DeleteCollectionItem removes the item of the collection. Returns sql.ErrNoRows if the collection has no such item.
+func (s *Storage) DeleteCollectionItem(ctx context.Context, collectionID uuid.UUID, id uuid.UUID) error {
+	slog.Debug("Delete Collection Item", slog.String("collection ID", collectionID.String()), slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Delete(collItemsTableName).
+		Where(squirrel.Eq{"id": id, "collection_id": collectionID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete collection item query: %w", err)
+	}
+
+	slog.Debug("deleting collection item", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete collection item: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted collection items count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// RekeyCollection replaces the key of the collection with the new version keyID: the keys sealed to the members
+// and the items encrypted with the new key replace the previous ones at once.
+// Returns ErrCollectionKeyChanged if the collection key is no longer the version preceding keyID and
+// ErrCollectionItemsChanged if some items of the collection are left encrypted with the previous key.
+func (s *Storage) RekeyCollection(ctx context.Context, collectionID uuid.UUID, keyID uint32, keys []*domain.CollectionKey,
+	items []*domain.CollectionItem) error {
+	slog.Debug("Rekey Collection", slog.String("ID", collectionID.String()), slog.Any("key ID", keyID),
+		slog.Int("items", len(items)))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query, args, err := squirrel.Update(collectionsTableName).
+		Set("key_id", keyID).
+		Set("modified_at", time.Now()).
+		Where(squirrel.Eq{"id": collectionID, "key_id": keyID - 1}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build rekey collection query: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not rekey collection: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get rekeyed collections count: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrCollectionKeyChanged
+	}
+
+	keysQuery, keysArgs, err := squirrel.Delete(collKeysTableName).
+		Where(squirrel.Eq{"collection_id": collectionID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete collection keys query: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, keysQuery, keysArgs...); err != nil {
+		return fmt.Errorf("could not delete collection keys: %w", err)
+	}
+
+	if err = insertCollectionKeys(ctx, tx, keys); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		query, args, err := squirrel.Update(collItemsTableName).
+			Set("data", item.Data).
+			Set("wrapped_key", item.WrappedKey).
+			Set("key_id", keyID).
+			Set("encrypted_meta", item.EncryptedMeta).
+			Where(squirrel.Eq{"id": item.ID, "collection_id": collectionID}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build rekey collection item query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("could not rekey collection item: %w", err)
+		}
+	}
+
+	staleQuery, staleArgs, err := squirrel.Select("COUNT(*)").
+		From(collItemsTableName).
+		Where(squirrel.And{
+			squirrel.Eq{"collection_id": collectionID},
+			squirrel.NotEq{"key_id": keyID},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build count stale collection items query: %w", err)
+	}
+
+	var stale int64
+	if err = tx.QueryRowContext(ctx, staleQuery, staleArgs...).Scan(&stale); err != nil {
+		return fmt.Errorf("could not count stale collection items: %w", err)
+	}
+	if stale > 0 {
+		return domain.ErrCollectionItemsChanged
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveEmergencyAccess stores the emergency access granted by the owner to the trusted contact.
+// Returns ErrEmergencyAccessExists if the contact is already designated by the owner.
+func (s *Storage) SaveEmergencyAccess(ctx context.Context, access *domain.EmergencyAccess) error {
+	slog.Debug("Save Emergency Access", slog.String("owner ID", access.OwnerID.String()),
+		slog.String("contact ID", access.ContactID.String()))
+
+	query, args, err := squirrel.Insert(emergencyTableName).
+		Columns("id", "owner_id", "contact_id", "wait_seconds", "status", "key_id", "sealed_key", "created_at",
+			"modified_at").
+		Values(access.ID, access.OwnerID, access.ContactID, int64(access.WaitPeriod/time.Second), access.Status,
+			access.KeyID, access.SealedKey, access.Created, access.Modified).
+		Suffix("ON CONFLICT(owner_id, contact_id) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save emergency access query: %w", err)
+	}
+
+	slog.Debug("saving emergency access", slog.String("query", query))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save emergency access: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get saved emergency access count: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrEmergencyAccessExists
+	}
+
+	return nil
+}
+
+// GetEmergencyAccessByID retrieves the emergency access by its ID. Returns sql.ErrNoRows if there is no such access.
+func (s *Storage) GetEmergencyAccessByID(ctx context.Context, id uuid.UUID) (*domain.EmergencyAccess, error) {
+	slog.Debug("Get Emergency Access by ID", slog.String("ID", id.String()))
+
+	access, err := s.getEmergencyAccess(ctx, squirrel.Eq{"e.id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(access) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return access[0], nil
+}
+
+// GetEmergencyAccessByOwner retrieves the trusted contacts designated by the owner.
+func (s *Storage) GetEmergencyAccessByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.EmergencyAccess, error) {
+	slog.Debug("Get Emergency Access by owner", slog.String("owner ID", ownerID.String()))
+
+	return s.getEmergencyAccess(ctx, squirrel.Eq{"e.owner_id": ownerID})
+}
+
+// GetEmergencyAccessByContact retrieves the emergency access granted to the user as a trusted contact.
+func (s *Storage) GetEmergencyAccessByContact(ctx context.Context, contactID uuid.UUID) ([]*domain.EmergencyAccess, error) {
+	slog.Debug("Get Emergency Access by contact", slog.String("contact ID", contactID.String()))
+
+	return s.getEmergencyAccess(ctx, squirrel.Eq{"e.contact_id": contactID})
+}
+
+// getEmergencyAccess retrieves the emergency access matching the condition ordered by creation time, together with
+// the logins of the owners and the contacts and the public keys of the contacts.
+func (s *Storage) getEmergencyAccess(ctx context.Context, condition squirrel.Eq) ([]*domain.EmergencyAccess, error) {
+	query, args, err := squirrel.Select("e.id", "e.owner_id", "o.login", "e.contact_id", "c.login",
+		"COALESCE(k.public_key, ''::bytea)", "e.wait_seconds", "e.status", "e.requested_at", "e.key_id",
+		"e.sealed_key", "e.created_at", "e.modified_at").
+		From(emergencyTableName+" e").
+		Join(usersTableName+" o ON o.id = e.owner_id").
+		Join(usersTableName+" c ON c.id = e.contact_id").
+		LeftJoin(sharingKeysTableName+" k ON k.user_id = e.contact_id").
+		Where(condition).
+		OrderBy("e.created_at", "e.id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get emergency access query: %w", err)
+	}
+
+	slog.Debug("getting emergency access", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get emergency access query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.EmergencyAccess
+	for rows.Next() {
+		var waitSeconds int64
+		var requestedAt sql.NullTime
+		row := &domain.EmergencyAccess{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.OwnerID,
+			&row.OwnerLogin,
+			&row.ContactID,
+			&row.ContactLogin,
+			&row.ContactPublicKey,
+			&waitSeconds,
+			&row.Status,
+			&requestedAt,
+			&row.KeyID,
+			&row.SealedKey,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan emergency access: %w", err)
+		}
+
+		row.WaitPeriod = time.Duration(waitSeconds) * time.Second
+		row.RequestedAt = requestedAt.Time
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// RequestEmergencyAccess starts the waiting period of the emergency access requested by the contact.
+// Returns sql.ErrNoRows if the access is requested or approved already.
+func (s *Storage) RequestEmergencyAccess(ctx context.Context, id uuid.UUID, requestedAt time.Time) error {
+	slog.Debug("Request Emergency Access", slog.String("ID", id.String()))
+
+	return s.updateEmergencyAccess(ctx, squirrel.Eq{
+		"id":     id,
+		"status": []string{domain.EmergencyStatusGranted, domain.EmergencyStatusRejected},
+	}, map[string]any{
+		"status":       domain.EmergencyStatusRequested,
+		"requested_at": requestedAt,
+		"modified_at":  time.Now(),
+	})
+}
+
+// AnswerEmergencyAccess approves or rejects the requested emergency access on behalf of the owner.
+// Returns sql.ErrNoRows if the access is not requested anymore, for example released meanwhile.
+func (s *Storage) AnswerEmergencyAccess(ctx context.Context, id uuid.UUID, approve bool) error {
+	slog.Debug("Answer Emergency Access", slog.String("ID", id.String()), slog.Bool("approve", approve))
+
+	status := domain.EmergencyStatusRejected
+	if approve {
+		status = domain.EmergencyStatusApproved
+	}
+
+	return s.updateEmergencyAccess(ctx, squirrel.Eq{
+		"id":     id,
+		"status": domain.EmergencyStatusRequested,
+	}, map[string]any{
+		"status":      status,
+		"modified_at": time.Now(),
+	})
+}
+
+// updateEmergencyAccess updates the emergency access matching the condition. Returns sql.ErrNoRows if none matches.
+func (s *Storage) updateEmergencyAccess(ctx context.Context, condition squirrel.Eq, values map[string]any) error {
+	query, args, err := squirrel.Update(emergencyTableName).
+		SetMap(values).
+		Where(condition).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update emergency access query: %w", err)
+	}
+
+	slog.Debug("updating emergency access", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not update emergency access: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get updated emergency access count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// UpdateEmergencyKeys replaces the vault keys sealed to the trusted contacts of the owner after a vault key rotation.
+// Returns sql.ErrNoRows if some of the access has been revoked meanwhile.
+func (s *Storage) UpdateEmergencyKeys(ctx context.Context, ownerID uuid.UUID, access []*domain.EmergencyAccess) error {
+	slog.Debug("Update Emergency Keys", slog.String("owner ID", ownerID.String()), slog.Int("count", len(access)))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, v := range access {
+		query, args, err := squirrel.Update(emergencyTableName).
+			Set("key_id", v.KeyID).
+			Set("sealed_key", v.SealedKey).
+			Set("modified_at", time.Now()).
+			Where(squirrel.Eq{"id": v.ID, "owner_id": ownerID}).
+			PlaceholderFormat(squirrel.Dollar).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build update emergency key query: %w", err)
+		}
+
+		slog.Debug("updating emergency key", slog.String("query", query))
+
+		res, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("could not update emergency key: %w", err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("could not get updated emergency keys count: %w", err)
+		}
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteEmergencyAccess revokes the emergency access granted by the owner. Returns sql.ErrNoRows if there is no such access.
+func (s *Storage) DeleteEmergencyAccess(ctx context.Context, ownerID uuid.UUID, id uuid.UUID) error {
+	slog.Debug("Delete Emergency Access", slog.String("owner ID", ownerID.String()), slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Delete(emergencyTableName).
+		Where(squirrel.Eq{"id": id, "owner_id": ownerID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete emergency access query: %w", err)
+	}
+
+	slog.Debug("deleting emergency access", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete emergency access: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted emergency access count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ReleaseEmergencyAccess approves the requested emergency access whose waiting period has passed by now
+// without the owner rejecting it. Returns the number of the released access.
+func (s *Storage) ReleaseEmergencyAccess(ctx context.Context, now time.Time) (int64, error) {
+	query, args, err := squirrel.Update(emergencyTableName).
+		Set("status", domain.EmergencyStatusApproved).
+		Set("modified_at", now).
+		Where(squirrel.Eq{"status": domain.EmergencyStatusRequested}).
+		Where(squirrel.Expr("requested_at + wait_seconds * INTERVAL '1 second' <= ?", now)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build release emergency access query: %w", err)
+	}
+
+	slog.Debug("releasing emergency access", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("could not release emergency access: %w", err)
+	}
+
+	released, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get released emergency access count: %w", err)
+	}
+
+	return released, nil
+}
+
+// SaveDevice stores the device enrolled by the user.
+func (s *Storage) SaveDevice(ctx context.Context, device *domain.Device) error {
+	slog.Debug("Save Device", slog.String("user ID", device.UserID.String()), slog.String("ID", device.ID.String()))
+
+	query, args, err := squirrel.Insert(devicesTableName).
+		Columns("id", "user_id", "name", "fingerprint", "expires_at", "created_at").
+		Values(device.ID, device.UserID, device.Name, device.Fingerprint, device.Expires, device.Created).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save device query: %w", err)
+	}
+
+	slog.Debug("saving device", slog.String("query", query))
+
+	if _, err = s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not save device: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeviceByID returns the device by its ID. Returns sql.ErrNoRows if there is no such device.
+func (s *Storage) GetDeviceByID(ctx context.Context, id uuid.UUID) (*domain.Device, error) {
+	slog.Debug("Get Device by ID", slog.String("ID", id.String()))
+
+	devices, err := s.getDevices(ctx, squirrel.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(devices) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return devices[0], nil
+}
+
+// GetDevicesByUser returns the devices enrolled by the user, the revoked ones included.
+func (s *Storage) GetDevicesByUser(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	slog.Debug("Get Devices by User", slog.String("user ID", userID.String()))
+
+	return s.getDevices(ctx, squirrel.Eq{"user_id": userID})
+}
+
+// getDevices returns the devices matching the condition ordered by the enrolment time.
+func (s *Storage) getDevices(ctx context.Context, condition squirrel.Eq) ([]*domain.Device, error) {
+	query, args, err := squirrel.Select("id", "user_id", "name", "fingerprint", "expires_at", "created_at", "revoked_at").
+		From(devicesTableName).
+		Where(condition).
+		OrderBy("created_at", "id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get devices query: %w", err)
+	}
+
+	slog.Debug("getting devices", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get devices query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.Device
+	for rows.Next() {
+		var revoked sql.NullTime
+		row := &domain.Device{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.UserID,
+			&row.Name,
+			&row.Fingerprint,
+			&row.Expires,
+			&row.Created,
+			&revoked,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan device: %w", err)
+		}
+
+		row.Revoked = revoked.Time
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// RevokeDevice marks the device of the user revoked. Returns sql.ErrNoRows if the user has no such device
+// or it is revoked already.
+func (s *Storage) RevokeDevice(ctx context.Context, userID uuid.UUID, id uuid.UUID, revoked time.Time) error {
+	slog.Debug("Revoke Device", slog.String("user ID", userID.String()), slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Update(devicesTableName).
+		Set("revoked_at", revoked).
+		Where(squirrel.Eq{"id": id, "user_id": userID, "revoked_at": nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build revoke device query: %w", err)
+	}
+
+	slog.Debug("revoking device", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not revoke device: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get revoked devices count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SaveAPIToken stores the API token created by the user. Returns ErrAPITokenExists if the user already has a token
+// with the name.
+func (s *Storage) SaveAPIToken(ctx context.Context, token *domain.APIToken) error {
+	slog.Debug("Save API Token", slog.String("user ID", token.UserID.String()), slog.String("ID", token.ID.String()))
+
+	scope, err := json.Marshal(token.Scope)
+	if err != nil {
+		return fmt.Errorf("could not marshal api token scope: %w", err)
+	}
+
+	query, args, err := squirrel.Insert(apiTokensTableName).
+		Columns("id", "user_id", "name", "token_hash", "scope", "expires_at", "created_at").
+		Values(token.ID, token.UserID, token.Name, token.Hash, scope, token.Expires, token.Created).
+		Suffix("ON CONFLICT(user_id, name) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save api token query: %w", err)
+	}
+
+	slog.Debug("saving api token", slog.String("query", query))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save api token: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get saved api tokens count: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrAPITokenExists
+	}
+
+	return nil
+}
+
+// GetAPITokenByID returns the API token by its ID. Returns sql.ErrNoRows if there is no such token.
+func (s *Storage) GetAPITokenByID(ctx context.Context, id uuid.UUID) (*domain.APIToken, error) {
+	slog.Debug("Get API Token by ID", slog.String("ID", id.String()))
+
+	tokens, err := s.getAPITokens(ctx, squirrel.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return tokens[0], nil
+}
+
+// GetAPITokensByUser returns the API tokens of the user, the expired ones included.
+func (s *Storage) GetAPITokensByUser(ctx context.Context, userID uuid.UUID) ([]*domain.APIToken, error) {
+	slog.Debug("Get API Tokens by User", slog.String("user ID", userID.String()))
+
+	return s.getAPITokens(ctx, squirrel.Eq{"user_id": userID})
+}
+
+// getAPITokens returns the API tokens matching the condition ordered by the creation time.
+func (s *Storage) getAPITokens(ctx context.Context, condition squirrel.Eq) ([]*domain.APIToken, error) {
+	query, args, err := squirrel.Select("id", "user_id", "name", "token_hash", "scope", "expires_at", "created_at",
+		"last_used_at").
+		From(apiTokensTableName).
+		Where(condition).
+		OrderBy("created_at", "id").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get api tokens query: %w", err)
+	}
+
+	slog.Debug("getting api tokens", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get api tokens query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.APIToken
+	for rows.Next() {
+		var (
+			scope    []byte
+			lastUsed sql.NullTime
+		)
+		row := &domain.APIToken{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.UserID,
+			&row.Name,
+			&row.Hash,
+			&scope,
+			&row.Expires,
+			&row.Created,
+			&lastUsed,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan api token: %w", err)
+		}
+
+		if err = json.Unmarshal(scope, &row.Scope); err != nil {
+			return nil, fmt.Errorf("could not unmarshal api token scope: %w", err)
+		}
+		row.LastUsed = lastUsed.Time
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// UpdateAPITokenUsed sets the last use time of the API token.
+func (s *Storage) UpdateAPITokenUsed(ctx context.Context, id uuid.UUID, used time.Time) error {
+	slog.Debug("Update API Token Used", slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Update(apiTokensTableName).
+		Set("last_used_at", used).
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update api token query: %w", err)
+	}
+
+	slog.Debug("updating api token", slog.String("query", query), slog.Any("args", args))
+
+	if _, err = s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not update api token: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAPIToken deletes the API token of the user. Returns sql.ErrNoRows if the user has no such token.
+func (s *Storage) DeleteAPIToken(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	slog.Debug("Delete API Token", slog.String("user ID", userID.String()), slog.String("ID", id.String()))
+
+	query, args, err := squirrel.Delete(apiTokensTableName).
+		Where(squirrel.Eq{"id": id, "user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete api token query: %w", err)
+	}
+
+	slog.Debug("deleting api token", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete api token: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted api tokens count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// SaveAuditEvent appends the event to the audit log of its user: the event gets the next number and is chained
+// to the last event of the user. The events of a user are appended one by one under an advisory lock.
+func (s *Storage) SaveAuditEvent(ctx context.Context, event *domain.AuditEvent) error {
+	slog.Debug("Save Audit Event", slog.String("user ID", event.UserID.String()), slog.String("action", event.Action))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", auditEventsTableName+event.UserID.String()); err != nil {
+		return fmt.Errorf("could not lock audit log: %w", err)
+	}
+
+	lastQuery, lastArgs, err := squirrel.Select("seq", "hash").
+		From(auditEventsTableName).
+		Where(squirrel.Eq{"user_id": event.UserID}).
+		OrderBy("seq DESC").
+		Limit(1).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build get last audit event query: %w", err)
+	}
+
+	var (
+		lastSeq  int64
+		lastHash []byte
+	)
+	if err = tx.QueryRowContext(ctx, lastQuery, lastArgs...).Scan(&lastSeq, &lastHash); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("could not get last audit event: %w", err)
+	}
+
+	event.Seq = lastSeq + 1
+	event.Seal(lastHash)
+
+	query, args, err := squirrel.Insert(auditEventsTableName).
+		Columns("user_id", "seq", "action", "success", "item_id", "device_id", "api_token_id", "peer", "details",
+			"created_at", "prev_hash", "hash").
+		Values(event.UserID, event.Seq, event.Action, event.Success, event.ItemID, event.DeviceID, event.APITokenID,
+			event.Peer, event.Details, event.Created, event.PrevHash, event.Hash).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save audit event query: %w", err)
+	}
+
+	slog.Debug("saving audit event", slog.String("query", query))
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not save audit event: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// GetAuditEvents returns the audit events of the user narrowed down by the filter, from the latest.
+func (s *Storage) GetAuditEvents(ctx context.Context, userID uuid.UUID, filter *domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	slog.Debug("Get Audit Events", slog.String("user ID", userID.String()), slog.Any("filter", filter))
+
+	conditions := squirrel.And{
+		squirrel.Eq{"user_id": userID},
+	}
+	builder := squirrel.Select("user_id", "seq", "action", "success", "item_id", "device_id", "api_token_id", "peer",
+		"details", "created_at", "prev_hash", "hash").
+		From(auditEventsTableName).
+		OrderBy("seq DESC")
+
+	if filter != nil {
+		if filter.Action != "" {
+			conditions = append(conditions, squirrel.Eq{"action": filter.Action})
+		}
+		if filter.ItemID != "" {
+			conditions = append(conditions, squirrel.Eq{"item_id": filter.ItemID})
+		}
+		if !filter.Since.IsZero() {
+			conditions = append(conditions, squirrel.GtOrEq{"created_at": filter.Since.UTC()})
+		}
+		if !filter.Until.IsZero() {
+			conditions = append(conditions, squirrel.Lt{"created_at": filter.Until.UTC()})
+		}
+		if filter.BeforeSeq > 0 {
+			conditions = append(conditions, squirrel.Lt{"seq": filter.BeforeSeq})
+		}
+		if filter.Limit > 0 {
+			builder = builder.Limit(uint64(filter.Limit))
+		}
+	}
+
+	query, args, err := builder.
+		Where(conditions).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get audit events query: %w", err)
+	}
+
+	slog.Debug("getting audit events", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get audit events query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.AuditEvent
+	for rows.Next() {
+		row := &domain.AuditEvent{}
+		if err = rows.Scan(
+			&row.UserID,
+			&row.Seq,
+			&row.Action,
+			&row.Success,
+			&row.ItemID,
+			&row.DeviceID,
+			&row.APITokenID,
+			&row.Peer,
+			&row.Details,
+			&row.Created,
+			&row.PrevHash,
+			&row.Hash,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan audit event: %w", err)
+		}
+
+		row.Created = row.Created.UTC()
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// GetUsers retrieves the accounts of all users ordered by the login, with the lock and the session state
+// but without the credentials.
+func (s *Storage) GetUsers(ctx context.Context) ([]*domain.UserData, error) {
+	slog.Debug("Get Users")
+
+	query, args, err := squirrel.Select("id", "login", "session_version", "locked_at", "created_at", "modified_at").
+		From(usersTableName).
+		OrderBy("login").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get users query: %w", err)
+	}
+
+	slog.Debug("getting users", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get users query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.UserData
+	for rows.Next() {
+		var locked sql.NullTime
+		row := &domain.UserData{}
+		if err = rows.Scan(
+			&row.ID,
+			&row.Login,
+			&row.SessionVersion,
+			&locked,
+			&row.Created,
+			&row.Modified,
+		); err != nil {
+			return nil, fmt.Errorf("could not scan user: %w", err)
+		}
+
+		row.Locked = locked.Time
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// LockUser locks the account of the user and increments its session version, revoking the issued tokens.
+// Returns sql.ErrNoRows if the user is not found.
+func (s *Storage) LockUser(ctx context.Context, userID uuid.UUID, locked time.Time) error {
+	slog.Debug("Lock User", slog.String("user ID", userID.String()))
+
+	return s.updateUser(ctx, userID, map[string]any{
+		"locked_at":       locked,
+		"session_version": squirrel.Expr("session_version + 1"),
+	})
+}
+
+// UnlockUser unlocks the account of the user, the user logs in again. Returns sql.ErrNoRows if the user is not found.
+func (s *Storage) UnlockUser(ctx context.Context, userID uuid.UUID) error {
+	slog.Debug("Unlock User", slog.String("user ID", userID.String()))
+
+	return s.updateUser(ctx, userID, map[string]any{
+		"locked_at": nil,
+	})
+}
+
+// RevokeSessions increments the session version of the user, revoking the issued tokens, and returns the new version.
+// Returns sql.ErrNoRows if the user is not found.
+func (s *Storage) RevokeSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	slog.Debug("Revoke Sessions", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Update(usersTableName).
+		Set("session_version", squirrel.Expr("session_version + 1")).
+		Where(squirrel.Eq{"id": userID}).
+		Suffix("RETURNING session_version").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build revoke sessions query: %w", err)
+	}
+
+	slog.Debug("revoking sessions", slog.String("query", query), slog.Any("args", args))
+
+	var version int
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("could not revoke sessions: %w", err)
+	}
+
+	return version, nil
+}
+
+// updateUser sets the values of the columns of the user. Returns sql.ErrNoRows if the user is not found.
+func (s *Storage) updateUser(ctx context.Context, userID uuid.UUID, values map[string]any) error {
+	query, args, err := squirrel.Update(usersTableName).
+		SetMap(values).
+		Where(squirrel.Eq{"id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build update user query: %w", err)
+	}
+
+	slog.Debug("updating user", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not update user: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get updated users count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteUser atomically deletes the account of the user with the items, folders, keys, shares, organizations
+// owned by the user, memberships, emergency access, devices, API tokens and the quota. The audit log of the user is append-only
+// and is kept. Returns sql.ErrNoRows if the user is not found.
+func (s *Storage) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+	slog.Debug("Delete User", slog.String("user ID", userID.String()))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	items := squirrel.Expr(fmt.Sprintf("id::text IN (SELECT data_id FROM %s WHERE user_id = ?)", metaTableName),
+		userID.String())
+	orgs := fmt.Sprintf("SELECT id FROM %s WHERE owner_id = ?", orgsTableName)
+	collections := squirrel.Expr(fmt.Sprintf("collection_id IN (SELECT id FROM %s WHERE org_id IN (%s))",
+		collectionsTableName, orgs), userID)
+
+	// Сначала удаляются данные, затем метаданные, на которые они ссылаются
+	queries := []squirrel.DeleteBuilder{
+		squirrel.Delete(sharesTableName).Where(squirrel.Or{squirrel.Eq{"owner_id": userID}, squirrel.Eq{"recipient_id": userID}}),
+		squirrel.Delete(itemsDataTableName).Where(items),
+		squirrel.Delete(metaTableName).Where(squirrel.Eq{"user_id": userID.String()}),
+		squirrel.Delete(foldersTableName).Where(squirrel.Eq{"user_id": userID.String()}),
+		squirrel.Delete(vaultKeysTableName).Where(squirrel.Eq{"user_id": userID}),
+		squirrel.Delete(sharingKeysTableName).Where(squirrel.Eq{"user_id": userID}),
+		squirrel.Delete(collItemsTableName).Where(collections),
+		squirrel.Delete(collKeysTableName).Where(collections),
+		squirrel.Delete(collectionsTableName).Where(squirrel.Expr(fmt.Sprintf("org_id IN (%s)", orgs), userID)),
+		squirrel.Delete(orgMembersTableName).Where(squirrel.Expr(fmt.Sprintf("org_id IN (%s)", orgs), userID)),
+		squirrel.Delete(orgsTableName).Where(squirrel.Eq{"owner_id": userID}),
+		squirrel.Delete(collKeysTableName).Where(squirrel.Eq{"user_id": userID}),
+		squirrel.Delete(orgMembersTableName).Where(squirrel.Eq{"user_id": userID}),
+		squirrel.Delete(emergencyTableName).Where(squirrel.Or{squirrel.Eq{"owner_id": userID}, squirrel.Eq{"contact_id": userID}}),
+		squirrel.Delete(devicesTableName).Where(squirrel.Eq{"user_id": userID}),
+		squirrel.Delete(apiTokensTableName).Where(squirrel.Eq{"user_id": userID}),
+		squirrel.Delete(userQuotasTableName).Where(squirrel.Eq{"user_id": userID}),
+	}
+	for _, v := range queries {
+		query, args, err := v.PlaceholderFormat(squirrel.Dollar).ToSql()
+		if err != nil {
+			return fmt.Errorf("could not build delete user data query: %w", err)
+		}
+
+		slog.Debug("deleting user data", slog.String("query", query), slog.Any("args", args))
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("could not delete user data: %w", err)
+		}
+	}
+
+	query, args, err := squirrel.Delete(usersTableName).
+		Where(squirrel.Eq{"id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete user query: %w", err)
+	}
+
+	slog.Debug("deleting user", slog.String("query", query), slog.Any("args", args))
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete user: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted users count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetStorageUsage retrieves the number of the items and the size of their encrypted data of every user,
+// including the collection items the user created, ordered by the login.
+func (s *Storage) GetStorageUsage(ctx context.Context) ([]*domain.StorageUsage, error) {
+	slog.Debug("Get Storage Usage")
+
+	query, args, err := squirrel.Select("u.id", "u.login", "COALESCE(m.items, 0) + COALESCE(c.items, 0)",
+		"COALESCE(m.bytes, 0) + COALESCE(c.bytes, 0)").
+		From(usersTableName + " u").
+		LeftJoin(fmt.Sprintf("(SELECT m.user_id, COUNT(*) AS items, SUM(octet_length(d.data)) AS bytes FROM %s m "+
+			"LEFT JOIN %s d ON d.id::text = m.data_id GROUP BY m.user_id) m ON m.user_id = u.id::text",
+			metaTableName, itemsDataTableName)).
+		LeftJoin(fmt.Sprintf("(SELECT created_by, COUNT(*) AS items, SUM(octet_length(data)) AS bytes FROM %s "+
+			"GROUP BY created_by) c ON c.created_by = u.id", collItemsTableName)).
+		OrderBy("u.login").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get storage usage query: %w", err)
+	}
+
+	slog.Debug("getting storage usage", slog.String("query", query), slog.Any("args", args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute get storage usage query: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*domain.StorageUsage
+	for rows.Next() {
+		row := &domain.StorageUsage{}
+		if err = rows.Scan(&row.UserID, &row.Login, &row.Items, &row.Bytes); err != nil {
+			return nil, fmt.Errorf("could not scan storage usage: %w", err)
+		}
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// GetStorageTotals retrieves the number of the users, of their items and the size of the encrypted data
+// of the items with a single aggregate query, counting the same items as GetStorageUsage.
+func (s *Storage) GetStorageTotals(ctx context.Context) (*domain.StorageTotals, error) {
+	slog.Debug("Get Storage Totals")
+
+	query, args, err := squirrel.Select(
+		fmt.Sprintf("(SELECT COUNT(*) FROM %s)", usersTableName),
+		fmt.Sprintf("(SELECT COUNT(*) FROM %s m JOIN %s u ON m.user_id = u.id::text) + "+
+			"(SELECT COUNT(*) FROM %s c JOIN %s u ON c.created_by = u.id)",
+			metaTableName, usersTableName, collItemsTableName, usersTableName),
+		fmt.Sprintf("(SELECT COALESCE(SUM(octet_length(d.data)), 0) FROM %s m JOIN %s u ON m.user_id = u.id::text "+
+			"LEFT JOIN %s d ON d.id::text = m.data_id) + "+
+			"(SELECT COALESCE(SUM(octet_length(c.data)), 0) FROM %s c JOIN %s u ON c.created_by = u.id)",
+			metaTableName, usersTableName, itemsDataTableName, collItemsTableName, usersTableName),
+	).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get storage totals query: %w", err)
+	}
+
+	slog.Debug("getting storage totals", slog.String("query", query), slog.Any("args", args))
+
+	totals := &domain.StorageTotals{}
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&totals.Users, &totals.Items, &totals.Bytes); err != nil {
+		return nil, fmt.Errorf("could not get storage totals: %w", err)
+	}
+
+	return totals, nil
+}
+
+// PurgeOrphanedItemData deletes the item data no metadata refers to, with its shares, and returns the number
+// of the deleted items. Such data is left behind when the metadata is deleted or moved to other data.
+func (s *Storage) PurgeOrphanedItemData(ctx context.Context) (int64, error) {
+	slog.Debug("Purge Orphaned Item Data")
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	orphaned := fmt.Sprintf("SELECT d.id FROM %s d WHERE NOT EXISTS (SELECT 1 FROM %s m WHERE m.data_id = d.id::text)",
+		itemsDataTableName, metaTableName)
+
+	sharesQuery, sharesArgs, err := squirrel.Delete(sharesTableName).
+		Where(fmt.Sprintf("data_id IN (%s)", orphaned)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build delete orphaned shares query: %w", err)
+	}
+
+	query, args, err := squirrel.Delete(itemsDataTableName).
+		Where(fmt.Sprintf("id IN (%s)", orphaned)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build delete orphaned item data query: %w", err)
+	}
+
+	slog.Debug("deleting orphaned shares", slog.String("query", sharesQuery), slog.Any("args", sharesArgs))
+
+	if _, err = tx.ExecContext(ctx, sharesQuery, sharesArgs...); err != nil {
+		return 0, fmt.Errorf("could not delete orphaned shares: %w", err)
+	}
+
+	slog.Debug("deleting orphaned item data", slog.String("query", query), slog.Any("args", args))
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete orphaned item data: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("could not get deleted item data count: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return affected, nil
+}
+
+// CheckIntegrity runs the integrity checks of the stored data and returns the inconsistencies found: the metadata
+// without data, owner or with a folder of another user, the orphaned item data, the shares of the deleted data
+// and the items encrypted with a vault key the owner doesn't have.
+func (s *Storage) CheckIntegrity(ctx context.Context) ([]*domain.IntegrityIssue, error) {
+	slog.Debug("Check Integrity")
+
+	checks := []struct {
+		name    string
+		builder squirrel.SelectBuilder
+	}{
+		{
+			name: domain.IntegrityMetaWithoutData,
+			builder: squirrel.Select("m.id::text", "m.user_id", "'data ' || m.data_id").
+				From(metaTableName + " m").
+				Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s d WHERE d.id::text = m.data_id)", itemsDataTableName)),
+		},
+		{
+			name: domain.IntegrityOrphanedItemData,
+			builder: squirrel.Select("d.id::text", "''", "''").
+				From(itemsDataTableName + " d").
+				Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s m WHERE m.data_id = d.id::text)", metaTableName)),
+		},
+		{
+			name: domain.IntegrityMetaWithoutUser,
+			builder: squirrel.Select("m.id::text", "m.user_id", "''").
+				From(metaTableName + " m").
+				Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s u WHERE u.id::text = m.user_id)", usersTableName)),
+		},
+		{
+			name: domain.IntegrityForeignFolder,
+			builder: squirrel.Select("m.id::text", "m.user_id", "'folder ' || f.id::text").
+				From(metaTableName + " m").
+				Join(foldersTableName + " f ON f.id = m.folder_id").
+				Where("f.user_id <> m.user_id"),
+		},
+		{
+			name: domain.IntegrityShareWithoutData,
+			builder: squirrel.Select("s.id::text", "s.owner_id::text", "'data ' || s.data_id::text").
+				From(sharesTableName + " s").
+				Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s d WHERE d.id = s.data_id)", itemsDataTableName)),
+		},
+		{
+			name: domain.IntegrityMissingVaultKey,
+			builder: squirrel.Select("d.id::text", "m.user_id", "'key ' || d.key_id::text").
+				From(itemsDataTableName + " d").
+				Join(metaTableName + " m ON m.data_id = d.id::text").
+				Where(fmt.Sprintf("d.key_id IS NOT NULL AND NOT EXISTS "+
+					"(SELECT 1 FROM %s k WHERE k.user_id::text = m.user_id AND k.key_id = d.key_id)", vaultKeysTableName)),
+		},
+	}
+
+	var res []*domain.IntegrityIssue
+	for _, check := range checks {
+		query, args, err := check.builder.PlaceholderFormat(squirrel.Dollar).ToSql()
+		if err != nil {
+			return nil, fmt.Errorf("could not build %s check query: %w", check.name, err)
+		}
+
+		slog.Debug("checking integrity", slog.String("check", check.name), slog.String("query", query))
+
+		issues, err := s.getIntegrityIssues(ctx, check.name, query, args)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, issues...)
+	}
+
+	return res, nil
+}
+
+// getIntegrityIssues executes the query of the integrity check selecting the ID of the inconsistent row, its owner
+// and the details of the inconsistency.
+func (s *Storage) getIntegrityIssues(ctx context.Context, check string, query string, args []any) ([]*domain.IntegrityIssue, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute %s check query: %w", check, err)
+	}
+	defer rows.Close()
+
+	var res []*domain.IntegrityIssue
+	for rows.Next() {
+		var userID string
+		row := &domain.IntegrityIssue{Check: check}
+		if err = rows.Scan(&row.ObjectID, &userID, &row.Details); err != nil {
+			return nil, fmt.Errorf("could not scan %s check: %w", check, err)
+		}
+
+		// Владелец в текстовых колонках может быть некорректным, тогда он не указывается
+		if id, err := uuid.Parse(userID); err == nil {
+			row.UserID = id
+		}
+		res = append(res, row)
+	}
+
+	return res, rows.Err()
+}
+
+// GetUsage retrieves the number of the items of the user and the size of their encrypted data.
+func (s *Storage) GetUsage(ctx context.Context, userID uuid.UUID) (*domain.StorageUsage, error) {
+	slog.Debug("Get Usage", slog.String("user ID", userID.String()))
+
+	return getUsage(ctx, s.db, userID)
+}
+
+// GetUserQuota retrieves the quota set for the user by an operator. Returns sql.ErrNoRows if the user has the default quota.
+func (s *Storage) GetUserQuota(ctx context.Context, userID uuid.UUID) (*domain.Quota, error) {
+	slog.Debug("Get User Quota", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Select("max_bytes", "max_items", "max_item_size").
+		From(userQuotasTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get user quota query: %w", err)
+	}
+
+	slog.Debug("getting user quota", slog.String("query", query), slog.Any("args", args))
+
+	var quota domain.Quota
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&quota.MaxBytes, &quota.MaxItems, &quota.MaxItemSize); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("could not get user quota: %w", err)
+	}
+
+	return &quota, nil
+}
+
+// SaveUserQuota sets the quota of the user replacing the default quota.
+func (s *Storage) SaveUserQuota(ctx context.Context, userID uuid.UUID, quota *domain.Quota) error {
+	slog.Debug("Save User Quota", slog.String("user ID", userID.String()), slog.Any("quota", *quota))
+
+	query, args, err := squirrel.Insert(userQuotasTableName).
+		Columns("user_id", "max_bytes", "max_items", "max_item_size", "modified_at").
+		Values(userID, quota.MaxBytes, quota.MaxItems, quota.MaxItemSize, time.Now()).
+		Suffix("ON CONFLICT(user_id) DO UPDATE SET max_bytes = $2, max_items = $3, max_item_size = $4, modified_at = $5").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save user quota query: %w", err)
+	}
+
+	slog.Debug("saving user quota", slog.String("query", query), slog.Any("args", args))
+
+	if _, err = s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not save user quota: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUserQuota returns the user to the default quota. Returns sql.ErrNoRows if the user has the default quota.
+func (s *Storage) DeleteUserQuota(ctx context.Context, userID uuid.UUID) error {
+	slog.Debug("Delete User Quota", slog.String("user ID", userID.String()))
+
+	query, args, err := squirrel.Delete(userQuotasTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build delete user quota query: %w", err)
+	}
+
+	slog.Debug("deleting user quota", slog.String("query", query), slog.Any("args", args))
+
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not delete user quota: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get deleted user quotas count: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// insertOrgMember stores the membership within the transaction. Returns ErrOrgMemberExists if the user is already
+// a member of the organization.
+func insertOrgMember(ctx context.Context, tx *tracedTx, member *domain.OrgMember) error {
+	query, args, err := squirrel.Insert(orgMembersTableName).
+		Columns("org_id", "user_id", "role", "accepted", "created_at", "modified_at").
+		Values(member.OrgID, member.UserID, member.Role, member.Accepted, member.Created, member.Modified).
+		Suffix("ON CONFLICT(org_id, user_id) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save org member query: %w", err)
+	}
+
+	slog.Debug("saving org member", slog.String("query", query), slog.Any("args", args))
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not save org member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get saved org members count: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrOrgMemberExists
+	}
+
+	return nil
+}
+
+// insertCollectionKeys stores the collection keys sealed to the members within the transaction.
+func insertCollectionKeys(ctx context.Context, tx *tracedTx, keys []*domain.CollectionKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	builder := squirrel.Insert(collKeysTableName).
+		Columns("collection_id", "user_id", "key_id", "wrapped_key")
+	for _, v := range keys {
+		builder = builder.Values(v.CollectionID, v.UserID, v.KeyID, v.WrappedKey)
+	}
+
+	query, args, err := builder.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build save collection keys query: %w", err)
+	}
+
+	slog.Debug("saving collection keys", slog.String("query", query))
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("could not save collection keys: %w", err)
+	}
+
+	return nil
+}
+
+// lockCollectionKey locks the collection until the end of the transaction and checks its key is of version keyID.
+// Returns ErrCollectionKeyChanged otherwise and sql.ErrNoRows if there is no such collection.
+func lockCollectionKey(ctx context.Context, tx *tracedTx, collectionID uuid.UUID, keyID uint32) error {
+	query, args, err := squirrel.Select("key_id").
+		From(collectionsTableName).
+		Where(squirrel.Eq{"id": collectionID}).
+		Suffix("FOR SHARE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build lock collection query: %w", err)
+	}
+
+	var current uint32
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&current); err != nil {
+		return fmt.Errorf("could not lock collection: %w", err)
+	}
+	if current != keyID {
+		return domain.ErrCollectionKeyChanged
+	}
+
+	return nil
+}
+
+// lockVaultKeys locks the vault keys of the user until the end of the transaction and checks the user has
+// the expected number of keys. Returns ErrVaultKeysChanged otherwise.
+func lockVaultKeys(ctx context.Context, tx *tracedTx, userID uuid.UUID, expected int) error {
+	query, args, err := squirrel.Select("key_id").
+		From(vaultKeysTableName).
+		Where(squirrel.Eq{"user_id": userID}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build lock vault keys query: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("could not lock vault keys: %w", err)
+	}
+
+	stored, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("could not get vault keys count: %w", err)
+	}
+	if stored != int64(expected) {
+		return domain.ErrVaultKeysChanged
+	}
+
+	return nil
+}
+
+// queryer is implemented by both the database connection and a transaction.
+type queryer interface {
+	QueryRowContext(context.Context, string, ...any) *sql.Row
+}
+
+// getUsage counts the items of the user, including the collection items the user created, and the size of their
+// encrypted data.
+func getUsage(ctx context.Context, db queryer, userID uuid.UUID) (*domain.StorageUsage, error) {
+	query, args, err := squirrel.Select().
+		Column(squirrel.Expr(fmt.Sprintf("(SELECT COUNT(*) FROM %s WHERE user_id = ?) + "+
+			"(SELECT COUNT(*) FROM %s WHERE created_by = ?)", metaTableName, collItemsTableName),
+			userID.String(), userID)).
+		Column(squirrel.Expr(fmt.Sprintf("(SELECT COALESCE(SUM(octet_length(d.data)), 0) FROM %s m "+
+			"JOIN %s d ON d.id::text = m.data_id WHERE m.user_id = ?) + "+
+			"(SELECT COALESCE(SUM(octet_length(data)), 0) FROM %s WHERE created_by = ?)",
+			metaTableName, itemsDataTableName, collItemsTableName), userID.String(), userID)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("could not build get usage query: %w", err)
+	}
+
+	slog.Debug("getting usage", slog.String("query", query), slog.Any("args", args))
+
+	usage := &domain.StorageUsage{UserID: userID}
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&usage.Items, &usage.Bytes); err != nil {
+		return nil, fmt.Errorf("could not get usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// lockUsage serializes the saves charged to the user until the end of the transaction,
+// so the concurrent uploads can't exceed the quota of the user.
+func lockUsage(ctx context.Context, tx *tracedTx, userID uuid.UUID) error {
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", userQuotasTableName+userID.String()); err != nil {
+		return fmt.Errorf("could not lock user usage: %w", err)
+	}
+
+	return nil
+}
+
+// checkQuota checks the item of itemSize bytes fits into the quota of the user with the current usage read within
+// the transaction, the usage is to be locked with lockUsage beforehand. replacedSize is the size of the stored data
+// of the user the item replaces, newItem is set when the item adds to the number of the items.
+func checkQuota(ctx context.Context, tx *tracedTx, userID uuid.UUID, quota *domain.Quota, itemSize int64,
+	replacedSize int64, newItem bool) error {
+	usage, err := getUsage(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	return domain.CheckQuota(quota, usage, itemSize, replacedSize, newItem)
+}
+
+// checkItemQuota checks the item fits into the quota of the owner of its metadata within the transaction saving it.
+// The stored data of the user the item replaces is not counted, the item adds to the number of the items unless
+// its metadata is already stored.
+func checkItemQuota(ctx context.Context, tx *tracedTx, item *domain.ItemData, meta *domain.Meta, quota *domain.Quota) error {
+	if err := lockUsage(ctx, tx, meta.UserID); err != nil {
+		return err
+	}
+
+	query, args, err := squirrel.Select().
+		Column(squirrel.Expr(fmt.Sprintf("COALESCE((SELECT octet_length(d.data) FROM %s d WHERE d.id = ? AND EXISTS "+
+			"(SELECT 1 FROM %s m WHERE m.data_id = d.id::text AND m.user_id = ?)), 0)", itemsDataTableName, metaTableName),
+			item.ID, meta.UserID.String())).
+		Column(squirrel.Expr(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s WHERE id = ?)", metaTableName), meta.ID)).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("could not build get replaced item query: %w", err)
+	}
+
+	slog.Debug("getting replaced item", slog.String("query", query), slog.Any("args", args))
+
+	var (
+		replacedSize int64
+		newItem      bool
+	)
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&replacedSize, &newItem); err != nil {
+		return fmt.Errorf("could not get replaced item: %w", err)
+	}
+
+	return checkQuota(ctx, tx, meta.UserID, quota, int64(len(item.Data)), replacedSize, newItem)
+}
+
+// countStaleKeys counts the items, metadata records and the sharing key of the user encrypted with a vault key
+// other than keyID.
+func countStaleKeys(ctx context.Context, db queryer, userID uuid.UUID, keyID uint32) (int64, error) {
+	itemsQuery, itemsArgs, err := squirrel.Select("COUNT(*)").
+		From(itemsDataTableName + " i").
+		Join(metaTableName + " m ON m.data_id = i.id").
+		Where(squirrel.And{
+			squirrel.Eq{"m.user_id": userID},
+			squirrel.NotEq{"i.key_id": keyID},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build count stale item keys query: %w", err)
+	}
+
+	metasQuery, metasArgs, err := squirrel.Select("COUNT(*)").
+		From(metaTableName).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.NotEq{"meta_key_id": keyID},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build count stale metas query: %w", err)
+	}
+
+	sharingQuery, sharingArgs, err := squirrel.Select("COUNT(*)").
+		From(sharingKeysTableName).
+		Where(squirrel.And{
+			squirrel.Eq{"user_id": userID},
+			squirrel.NotEq{"key_id": keyID},
+		}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("could not build count stale sharing keys query: %w", err)
+	}
+
+	var items, metas, sharing int64
+	if err = db.QueryRowContext(ctx, itemsQuery, itemsArgs...).Scan(&items); err != nil {
+		return 0, fmt.Errorf("could not count stale item keys: %w", err)
+	}
+	if err = db.QueryRowContext(ctx, metasQuery, metasArgs...).Scan(&metas); err != nil {
+		return 0, fmt.Errorf("could not count stale metas: %w", err)
+	}
+	if err = db.QueryRowContext(ctx, sharingQuery, sharingArgs...).Scan(&sharing); err != nil {
+		return 0, fmt.Errorf("could not count stale sharing keys: %w", err)
+	}
+
+	return items + metas + sharing, nil
+}
+
+// Close terminates the database connection and releases any associated resources. Returns an error if it fails.
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+// Stats returns the statistics of the connection pool of the database.
+func (s *Storage) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// Ping checks the connection to the database and returns an error if the database is not reachable.
+func (s *Storage) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// nullUUID converts uuid.Nil into the SQL NULL value for the optional reference columns.
+func nullUUID(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return id
+}
+
+// nullPassword converts the empty password hash of the accounts logging in with SRP into NULL.
+func nullPassword(hash string) any {
+	if hash == "" {
+		return nil
+	}
+
+	return hash
+}
+
+// nullKeyID converts the zero vault key version of plain records into NULL.
+func nullKeyID(id uint32) any {
+	if id == 0 {
+		return nil
+	}
+
+	return id
 }
@@ -0,0 +1,121 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts the spans of the SQL queries, they are children of the span of the request carried by the context.
+var tracer = otel.Tracer("github.com/mikhaylov123ty/GophKeeper/internal/server/storage/psql")
+
+// tracedDB wraps the database connection to record a span for every query and transaction.
+// Only the context taking methods are traced, the storage uses them exclusively.
+type tracedDB struct {
+	*sql.DB
+}
+
+// tracedTx wraps a transaction to record a span for every query of it.
+type tracedTx struct {
+	*sql.Tx
+}
+
+// ExecContext executes the query within a span.
+func (db *tracedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return res, err
+}
+
+// QueryContext executes the query within a span, the span ends before the rows are read.
+func (db *tracedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return rows, err
+}
+
+// QueryRowContext executes the query within a span.
+func (db *tracedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	endSpan(span, row.Err())
+
+	return row
+}
+
+// BeginTx starts a transaction whose queries are traced.
+func (db *tracedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*tracedTx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedTx{Tx: tx}, nil
+}
+
+// ExecContext executes the query of the transaction within a span.
+func (tx *tracedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	res, err := tx.Tx.ExecContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return res, err
+}
+
+// QueryContext executes the query of the transaction within a span, the span ends before the rows are read.
+func (tx *tracedTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	endSpan(span, err)
+
+	return rows, err
+}
+
+// QueryRowContext executes the query of the transaction within a span.
+func (tx *tracedTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	row := tx.Tx.QueryRowContext(ctx, query, args...)
+	endSpan(span, row.Err())
+
+	return row
+}
+
+// startQuerySpan starts the client span of the query named after its operation, e.g. "SELECT".
+// The statement is recorded with the placeholders, the arguments are not: they hold the encrypted data and the hashes.
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, queryOperation(query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation", queryOperation(query)),
+			attribute.String("db.statement", query),
+		),
+	)
+}
+
+// endSpan records the error of the query, if any, and ends the span.
+// sql.ErrNoRows is a regular outcome of the lookups and is not recorded.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryOperation returns the first keyword of the query in the upper case.
+func queryOperation(query string) string {
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+
+	return strings.ToUpper(operation)
+}
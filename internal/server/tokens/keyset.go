@@ -0,0 +1,259 @@
+// Модуль tokens выпускает и проверяет JWT сервера набором ключей с идентификаторами
+package tokens
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
+)
+
+// DefaultTTL is the lifetime of the issued tokens unless configured.
+const DefaultTTL = 24 * time.Hour
+
+// kidLength is the number of hex characters of the key thumbprint used as the key ID.
+const kidLength = 16
+
+// Claims defines the claims of the access token: the user, the session version of the user and the registered claims.
+type Claims struct {
+	UserID         string `json:"userID"`
+	SessionVersion int    `json:"sessionVersion"`
+	jwt.RegisteredClaims
+}
+
+// Keyset signs the tokens with the current key and verifies them with the current and the previous keys,
+// selected by the kid header. The asymmetric keys are Ed25519 (EdDSA) or ECDSA P-256 (ES256), the legacy HMAC
+// secret signs with HS256 when no signing key is configured and verifies the tokens issued before the key IDs.
+// Once the signing key is configured, the legacy secret is accepted until legacyUntil only, so it can't mint
+// the tokens afterwards.
+type Keyset struct {
+	signing     *key
+	keys        map[string]*key
+	legacy      *key
+	legacyUntil time.Time
+	issuer      string
+	audience    string
+	ttl         time.Duration
+}
+
+// Options configures the keyset. SigningKey and PreviousKeys are the paths to the PEM encoded keys: the signing key
+// is private, the previous keys are public or private. HMACSecret is the legacy HS256 secret, with the signing key
+// it is accepted until HMACUntil and ignored if HMACUntil is zero.
+// Issuer and Audience are put into the tokens and required from them when set.
+type Options struct {
+	SigningKey   string
+	PreviousKeys []string
+	HMACSecret   string
+	HMACUntil    time.Time
+	Issuer       string
+	Audience     string
+	TTL          time.Duration
+}
+
+// key is a key of the keyset with its ID and signing method. private is nil for the verification only keys.
+type key struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  any
+}
+
+// NewKeyset loads the keys of the options. At least a signing key or an HMAC secret is required.
+func NewKeyset(opts Options) (*Keyset, error) {
+	k := &Keyset{
+		keys:     make(map[string]*key),
+		issuer:   opts.Issuer,
+		audience: opts.Audience,
+		ttl:      opts.TTL,
+	}
+	if k.ttl <= 0 {
+		k.ttl = DefaultTTL
+	}
+
+	if opts.HMACSecret != "" {
+		sum := sha256.Sum256([]byte(opts.HMACSecret))
+		k.legacy = &key{
+			id:     "hs-" + hex.EncodeToString(sum[:])[:kidLength],
+			method: jwt.SigningMethodHS256,
+			public: []byte(opts.HMACSecret),
+		}
+		k.keys[k.legacy.id] = k.legacy
+		k.signing = k.legacy
+	}
+
+	if opts.SigningKey != "" {
+		signing, err := loadKey(opts.SigningKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT signing key: %w", err)
+		}
+		if signing.private == nil {
+			return nil, fmt.Errorf("JWT signing key %s is not a private key", opts.SigningKey)
+		}
+
+		k.signing = signing
+		k.keys[signing.id] = signing
+
+		// Общий секрет принимается только до заданного срока
+		if k.legacy != nil && opts.HMACUntil.IsZero() {
+			delete(k.keys, k.legacy.id)
+			k.legacy = nil
+		}
+		k.legacyUntil = opts.HMACUntil
+	}
+
+	for _, file := range opts.PreviousKeys {
+		previous, err := loadKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous JWT key: %w", err)
+		}
+
+		// Предыдущие ключи только проверяют подпись
+		previous.private = nil
+		if _, ok := k.keys[previous.id]; !ok {
+			k.keys[previous.id] = previous
+		}
+	}
+
+	if k.signing == nil {
+		return nil, fmt.Errorf("JWT signing key or secret is required")
+	}
+
+	return k, nil
+}
+
+// Issue signs the token of the user bound to the session version, valid for the TTL of the keyset from now.
+func (k *Keyset) Issue(userID string, sessionVersion int, now time.Time) (string, error) {
+	claims := Claims{
+		UserID:         userID,
+		SessionVersion: sessionVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    k.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(k.ttl)),
+		},
+	}
+	if k.audience != "" {
+		claims.Audience = jwt.ClaimStrings{k.audience}
+	}
+
+	token := jwt.NewWithClaims(k.signing.method, claims)
+	token.Header["kid"] = k.signing.id
+
+	signingKey := any(k.signing.private)
+	if k.signing.private == nil {
+		signingKey = k.signing.public
+	}
+
+	return token.SignedString(signingKey)
+}
+
+// Verify parses the token and checks its signature with the key named by the kid header, its algorithm matching
+// the key, its expiry, which is required, and the issuer and the audience, if configured.
+// The tokens without the kid header are verified with the legacy HMAC secret only.
+func (k *Keyset) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, k.verificationKey); err != nil {
+		return nil, err
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+
+	if k.issuer != "" && !claims.VerifyIssuer(k.issuer, true) {
+		return nil, fmt.Errorf("token issuer %q is not accepted", claims.Issuer)
+	}
+
+	if k.audience != "" && !claims.VerifyAudience(k.audience, true) {
+		return nil, fmt.Errorf("token audience %v is not accepted", claims.Audience)
+	}
+
+	return claims, nil
+}
+
+// SigningKeyID returns the ID of the key the new tokens are signed with.
+func (k *Keyset) SigningKeyID() string {
+	return k.signing.id
+}
+
+// verificationKey selects the key of the token by its kid header and checks the algorithm of the token is the key one,
+// so a token signed with HS256 and a public key as the secret is rejected.
+// The legacy secret is rejected once its acceptance period is over.
+func (k *Keyset) verificationKey(token *jwt.Token) (any, error) {
+	verifying := k.legacy
+	if kid, ok := token.Header["kid"].(string); ok {
+		verifying = k.keys[kid]
+		if verifying == nil {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+	}
+
+	if verifying == nil {
+		return nil, fmt.Errorf("token has no key id")
+	}
+
+	if verifying == k.legacy && !k.legacyUntil.IsZero() && time.Now().After(k.legacyUntil) {
+		return nil, fmt.Errorf("legacy key %s is retired", verifying.id)
+	}
+
+	if token.Method.Alg() != verifying.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %s", token.Method.Alg(), verifying.id)
+	}
+
+	return verifying.public, nil
+}
+
+// loadKey reads the PEM encoded private or public Ed25519 or ECDSA P-256 key from the file.
+// The ID of the key is the prefix of the SHA-256 thumbprint of its public key.
+func loadKey(file string) (*key, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &key{}
+	var public crypto.PublicKey
+	if block, _ := pem.Decode(data); block != nil && block.Type == "PUBLIC KEY" {
+		if public, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, fmt.Errorf("%s: failed to parse public key: %w", file, err)
+		}
+	} else {
+		if res.private, err = pki.ParsePrivateKey(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		public = res.private.Public()
+	}
+
+	switch v := public.(type) {
+	case ed25519.PublicKey:
+		res.method = jwt.SigningMethodEdDSA
+	case *ecdsa.PublicKey:
+		if v.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%s: only P-256 ECDSA keys are supported", file)
+		}
+		res.method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("%s: unsupported key type %T, expected Ed25519 or ECDSA P-256", file, public)
+	}
+	res.public = public
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encode public key: %w", file, err)
+	}
+	sum := sha256.Sum256(der)
+	res.id = hex.EncodeToString(sum[:])[:kidLength]
+
+	return res, nil
+}
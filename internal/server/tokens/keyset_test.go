@@ -0,0 +1,163 @@
+package tokens
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mikhaylov123ty/GophKeeper/internal/server/pki"
+)
+
+// writeTestKey generates a key of the type and writes it into the directory, returning the path of the private key
+// and the path of its public key.
+func writeTestKey(t *testing.T, dir string, name string, keyType pki.KeyType) (string, string) {
+	t.Helper()
+
+	key, err := pki.GenerateKey(keyType)
+	require.NoError(t, err)
+
+	keyPEM, err := pki.MarshalPrivateKey(key)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	privateFile, publicFile := filepath.Join(dir, name+".key"), filepath.Join(dir, name+".pub")
+	require.NoError(t, os.WriteFile(privateFile, keyPEM, 0600))
+	require.NoError(t, os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0644))
+
+	return privateFile, publicFile
+}
+
+func TestNewKeyset(t *testing.T) {
+	dir := t.TempDir()
+	edKey, edPublic := writeTestKey(t, dir, "ed", pki.KeyEd25519)
+	ecKey, _ := writeTestKey(t, dir, "ec", pki.KeyECDSA)
+	rsaKey, _ := writeTestKey(t, dir, "rsa", pki.KeyRSA)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	p384PEM, err := pki.MarshalPrivateKey(p384)
+	require.NoError(t, err)
+	p384Key := filepath.Join(dir, "p384.key")
+	require.NoError(t, os.WriteFile(p384Key, p384PEM, 0600))
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "legacy secret", opts: Options{HMACSecret: "secret"}, wantErr: assert.NoError},
+		{name: "ed25519", opts: Options{SigningKey: edKey}, wantErr: assert.NoError},
+		{name: "es256 with previous", opts: Options{SigningKey: ecKey, PreviousKeys: []string{edPublic}}, wantErr: assert.NoError},
+		{name: "no keys", opts: Options{}, wantErr: assert.Error},
+		{name: "public signing key", opts: Options{SigningKey: edPublic}, wantErr: assert.Error},
+		{name: "rsa signing key", opts: Options{SigningKey: rsaKey}, wantErr: assert.Error},
+		{name: "p384 signing key", opts: Options{SigningKey: p384Key}, wantErr: assert.Error},
+		{name: "missing previous key", opts: Options{SigningKey: edKey, PreviousKeys: []string{filepath.Join(dir, "none")}}, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyset(tt.opts)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestKeyset_Verify(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, oldPublic := writeTestKey(t, dir, "old", pki.KeyEd25519)
+	newKey, _ := writeTestKey(t, dir, "new", pki.KeyECDSA)
+	otherKey, _ := writeTestKey(t, dir, "other", pki.KeyEd25519)
+
+	newKeyset := func(opts Options) *Keyset {
+		keyset, err := NewKeyset(opts)
+		require.NoError(t, err)
+		return keyset
+	}
+
+	now := time.Now()
+	legacy := newKeyset(Options{HMACSecret: "secret"})
+	old := newKeyset(Options{SigningKey: oldKey})
+	rotated := newKeyset(Options{SigningKey: newKey, PreviousKeys: []string{oldPublic}, HMACSecret: "secret",
+		HMACUntil: now.Add(time.Hour)})
+	retired := newKeyset(Options{SigningKey: newKey, HMACSecret: "secret", HMACUntil: now.Add(-time.Second)})
+	unlimited := newKeyset(Options{SigningKey: newKey, HMACSecret: "secret"})
+	strict := newKeyset(Options{SigningKey: oldKey, Issuer: "gophkeeper", Audience: "clients"})
+
+	issue := func(keyset *Keyset, issued time.Time) string {
+		token, err := keyset.Issue("user", 3, issued)
+		require.NoError(t, err)
+		return token
+	}
+
+	// Токен без идентификатора ключа, выпущенный до появления набора ключей
+	noKid, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID:           "user",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour))},
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	noExpiry, err := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{UserID: "user"}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	// Подпись HS256 открытым ключом вместо секрета
+	publicPEM, err := os.ReadFile(oldPublic)
+	require.NoError(t, err)
+	confused := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID:           "user",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour))},
+	})
+	confused.Header["kid"] = old.SigningKeyID()
+	confusedToken, err := confused.SignedString(publicPEM)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		keyset  *Keyset
+		token   string
+		wantErr bool
+	}{
+		{name: "legacy token", keyset: legacy, token: issue(legacy, now)},
+		{name: "current key", keyset: rotated, token: issue(rotated, now)},
+		{name: "previous key", keyset: rotated, token: issue(old, now)},
+		{name: "legacy token after rotation", keyset: rotated, token: issue(legacy, now)},
+		{name: "token without kid", keyset: rotated, token: noKid},
+		{name: "token without kid, no legacy secret", keyset: old, token: noKid, wantErr: true},
+		{name: "legacy token, legacy secret retired", keyset: retired, token: issue(legacy, now), wantErr: true},
+		{name: "token without kid, legacy secret retired", keyset: retired, token: noKid, wantErr: true},
+		{name: "legacy token, no legacy period", keyset: unlimited, token: issue(legacy, now), wantErr: true},
+		{name: "token without kid, no legacy period", keyset: unlimited, token: noKid, wantErr: true},
+		{name: "signing key, legacy secret retired", keyset: retired, token: issue(retired, now)},
+		{name: "token without expiry", keyset: legacy, token: noExpiry, wantErr: true},
+		{name: "expired token", keyset: rotated, token: issue(rotated, now.Add(-2*DefaultTTL)), wantErr: true},
+		{name: "unknown key", keyset: rotated, token: issue(newKeyset(Options{SigningKey: otherKey}), now), wantErr: true},
+		{name: "accepted issuer and audience", keyset: strict, token: issue(strict, now)},
+		{name: "not accepted issuer", keyset: strict, token: issue(newKeyset(Options{SigningKey: oldKey, Issuer: "other", Audience: "clients"}), now), wantErr: true},
+		{name: "no audience", keyset: strict, token: issue(newKeyset(Options{SigningKey: oldKey, Issuer: "gophkeeper"}), now), wantErr: true},
+		{name: "algorithm confusion", keyset: old, token: confusedToken, wantErr: true},
+		{name: "garbage", keyset: legacy, token: "token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := tt.keyset.Verify(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user", claims.UserID)
+		})
+	}
+}
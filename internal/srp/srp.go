@@ -0,0 +1,244 @@
+// Модуль srp реализует протокол SRP-6a (RFC 5054), по которому клиент доказывает знание пароля,
+// не передавая серверу ни пароль, ни его хэш
+package srp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// groupPrime is the 2048-bit prime of the RFC 5054 group, the generator of the group is 2.
+const groupPrime = "" +
+	"AC6BDB41324A9A9BF166DE5E1389582FAF72B6651987EE07FC3192943DB56050A37329CBB4A099ED8193E0757767A13DD52312AB4B03310D" +
+	"CD7F48A9DA04FD50E8083969EDB767B0CF6095179A163AB3661A05FBD5FAAAE82918A9962F0B93B855F97993EC975EEAA80D740ADBF4FF74" +
+	"7359D041D5C33EA71D281E446B14773BCA97B43A23FB801676BD207A436C6481F1D2B9078717461A5B9D32E688F87748544523B524B0D57D" +
+	"5EA77A2775D2ECFA032CFBDBF52FB3786160279004E57AE6AF874E7303CE53299CCC041C7BC308D82A5698F3A8D0C38271AE35F8E9DBFBB6" +
+	"94B5C803D89F7AE435DE236D525F54759B65E372FCD68EF20FA7111F9E4AFF73"
+
+const (
+	// KeySize is the size of the public keys and the verifiers, padded to the size of the group prime.
+	KeySize = 256
+	// ProofSize is the size of the proofs exchanged by the client and the server.
+	ProofSize = sha256.Size
+
+	ephemeralSize = 32
+)
+
+var (
+	n = mustPrime(groupPrime)
+	g = big.NewInt(2)
+	k = new(big.Int).SetBytes(hash(pad(n), pad(g)))
+)
+
+// ErrProof is returned when the proof of the other side doesn't match, i.e. the password is wrong.
+var ErrProof = errors.New("srp proof mismatch")
+
+// Verifier returns the verifier g^x of the secret x derived from the password on the client.
+// The server stores the verifier instead of the password.
+func Verifier(x []byte) []byte {
+	return pad(new(big.Int).Exp(g, new(big.Int).SetBytes(x), n))
+}
+
+// Client holds the ephemeral key pair of the client for a single login exchange.
+type Client struct {
+	a *big.Int
+	A *big.Int
+	k []byte
+	m []byte
+}
+
+// NewClient generates the ephemeral key pair of the client.
+func NewClient() (*Client, error) {
+	a, err := randomExponent()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		a: a,
+		A: new(big.Int).Exp(g, a, n),
+	}, nil
+}
+
+// PublicKey returns the ephemeral public key A sent to the server.
+func (c *Client) PublicKey() []byte {
+	return pad(c.A)
+}
+
+// Proof computes the session key from the public key B of the server and the secret x derived from the password,
+// and returns the proof M1 of the session key sent to the server.
+func (c *Client) Proof(identity string, salt []byte, x []byte, serverPublic []byte) ([]byte, error) {
+	B, err := publicKey(serverPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	u := scrambler(c.A, B)
+	if u.Sign() == 0 {
+		return nil, fmt.Errorf("invalid server public key")
+	}
+
+	xInt := new(big.Int).SetBytes(x)
+
+	// S = (B - k*g^x) ^ (a + u*x) mod N
+	base := new(big.Int).Mul(k, new(big.Int).Exp(g, xInt, n))
+	base.Sub(B, base).Mod(base, n)
+	exp := new(big.Int).Mul(u, xInt)
+	exp.Add(exp, c.a)
+	S := new(big.Int).Exp(base, exp, n)
+
+	c.k = hash(pad(S))
+	c.m = clientProof(identity, salt, c.A, B, c.k)
+
+	return c.m, nil
+}
+
+// VerifyServer checks the proof M2 of the server, confirming the server knows the verifier.
+func (c *Client) VerifyServer(proof []byte) error {
+	if c.k == nil {
+		return fmt.Errorf("client proof is not computed")
+	}
+
+	if subtle.ConstantTimeCompare(proof, hash(pad(c.A), c.m, c.k)) != 1 {
+		return ErrProof
+	}
+
+	return nil
+}
+
+// Server holds the ephemeral key pair of the server for a single login exchange.
+type Server struct {
+	b *big.Int
+	B *big.Int
+	v *big.Int
+}
+
+// NewServer generates the ephemeral key pair of the server for the verifier of the user.
+func NewServer(verifier []byte) (*Server, error) {
+	v, err := publicKey(verifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verifier: %w", err)
+	}
+
+	b, err := randomExponent()
+	if err != nil {
+		return nil, err
+	}
+
+	// B = k*v + g^b mod N
+	B := new(big.Int).Mul(k, v)
+	B.Add(B, new(big.Int).Exp(g, b, n)).Mod(B, n)
+
+	return &Server{
+		b: b,
+		B: B,
+		v: v,
+	}, nil
+}
+
+// PublicKey returns the ephemeral public key B sent to the client.
+func (s *Server) PublicKey() []byte {
+	return pad(s.B)
+}
+
+// Verify checks the proof M1 of the client against the public key A of the client
+// and returns the proof M2 of the server. ErrProof is returned if the password of the client is wrong.
+func (s *Server) Verify(identity string, salt []byte, clientPublic []byte, proof []byte) ([]byte, error) {
+	A, err := publicKey(clientPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	u := scrambler(A, s.B)
+	if u.Sign() == 0 {
+		return nil, fmt.Errorf("invalid client public key")
+	}
+
+	// S = (A * v^u) ^ b mod N
+	S := new(big.Int).Exp(s.v, u, n)
+	S.Mul(S, A).Mod(S, n)
+	S.Exp(S, s.b, n)
+
+	key := hash(pad(S))
+	expected := clientProof(identity, salt, A, s.B, key)
+	if subtle.ConstantTimeCompare(proof, expected) != 1 {
+		return nil, ErrProof
+	}
+
+	return hash(pad(A), expected, key), nil
+}
+
+// ValidateVerifier checks the verifier is an element of the group.
+func ValidateVerifier(verifier []byte) error {
+	_, err := publicKey(verifier)
+	return err
+}
+
+// clientProof computes M1 = H(H(N) xor H(g) | H(I) | s | A | B | K).
+func clientProof(identity string, salt []byte, A *big.Int, B *big.Int, key []byte) []byte {
+	hn := hash(pad(n))
+	hg := hash(pad(g))
+	for i := range hn {
+		hn[i] ^= hg[i]
+	}
+
+	return hash(hn, hash([]byte(identity)), salt, pad(A), pad(B), key)
+}
+
+// scrambler computes u = H(A | B).
+func scrambler(A *big.Int, B *big.Int) *big.Int {
+	return new(big.Int).SetBytes(hash(pad(A), pad(B)))
+}
+
+// publicKey parses the public key of the other side, rejecting the values which are zero modulo N.
+func publicKey(b []byte) (*big.Int, error) {
+	if len(b) == 0 || len(b) > KeySize {
+		return nil, fmt.Errorf("invalid public key size %d", len(b))
+	}
+
+	v := new(big.Int).SetBytes(b)
+	if new(big.Int).Mod(v, n).Sign() == 0 {
+		return nil, fmt.Errorf("invalid public key")
+	}
+
+	return v, nil
+}
+
+// randomExponent generates a random ephemeral secret.
+func randomExponent() (*big.Int, error) {
+	b := make([]byte, ephemeralSize)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// hash returns the SHA-256 hash of the concatenated values.
+func hash(values ...[]byte) []byte {
+	h := sha256.New()
+	for _, v := range values {
+		h.Write(v)
+	}
+
+	return h.Sum(nil)
+}
+
+// pad returns the big-endian bytes of the value left padded to the size of the group prime.
+func pad(v *big.Int) []byte {
+	return v.FillBytes(make([]byte, KeySize))
+}
+
+// mustPrime parses the hex encoded group prime.
+func mustPrime(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("srp: invalid group prime")
+	}
+
+	return v
+}
@@ -0,0 +1,61 @@
+package srp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchange(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	x := hash([]byte("correct horse"))
+	verifier := Verifier(x)
+
+	tests := []struct {
+		name     string
+		identity string
+		x        []byte
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{name: "valid password", identity: "user", x: x, wantErr: assert.NoError},
+		{name: "wrong password", identity: "user", x: hash([]byte("battery staple")), wantErr: assert.Error},
+		{name: "wrong identity", identity: "other", x: x, wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient()
+			require.NoError(t, err)
+
+			server, err := NewServer(verifier)
+			require.NoError(t, err)
+
+			proof, err := client.Proof(tt.identity, salt, tt.x, server.PublicKey())
+			require.NoError(t, err)
+
+			serverProof, err := server.Verify("user", salt, client.PublicKey(), proof)
+			tt.wantErr(t, err)
+			if err == nil {
+				assert.NoError(t, client.VerifyServer(serverProof))
+				assert.ErrorIs(t, client.VerifyServer(make([]byte, ProofSize)), ErrProof)
+			}
+		})
+	}
+}
+
+func TestServer_VerifyInvalidPublicKey(t *testing.T) {
+	server, err := NewServer(Verifier(hash([]byte("password"))))
+	require.NoError(t, err)
+
+	for _, public := range [][]byte{nil, make([]byte, KeySize), pad(n), make([]byte, KeySize+1)} {
+		_, err = server.Verify("user", nil, public, make([]byte, ProofSize))
+		assert.Error(t, err)
+	}
+}
+
+func TestValidateVerifier(t *testing.T) {
+	assert.NoError(t, ValidateVerifier(Verifier([]byte{1, 2, 3})))
+	assert.Error(t, ValidateVerifier(make([]byte, KeySize)))
+	assert.Error(t, ValidateVerifier(nil))
+}
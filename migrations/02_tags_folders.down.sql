@@ -0,0 +1,5 @@
+DROP TABLE meta_tags;
+
+ALTER TABLE metas DROP COLUMN folder_id;
+
+DROP TABLE folders;
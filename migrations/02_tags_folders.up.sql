@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS folders(
+    id UUID PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL,
+    parent_id UUID REFERENCES folders (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE metas ADD COLUMN IF NOT EXISTS folder_id UUID REFERENCES folders (id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS meta_tags(
+    meta_id UUID NOT NULL REFERENCES metas (id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (meta_id, tag)
+);
+
+CREATE INDEX folders_user_id_ix ON folders (user_id);
+CREATE INDEX folder_id_ix ON metas (folder_id);
+CREATE INDEX tag_ix ON meta_tags (tag);
+
+COMMIT ;
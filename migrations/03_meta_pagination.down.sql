@@ -0,0 +1,3 @@
+DROP INDEX user_id_type_ix;
+
+DROP INDEX user_id_page_ix;
@@ -0,0 +1,6 @@
+BEGIN;
+
+CREATE INDEX user_id_page_ix ON metas (user_id, id);
+CREATE INDEX user_id_type_ix ON metas (user_id, type);
+
+COMMIT ;
@@ -0,0 +1,3 @@
+ALTER TABLE metas DROP COLUMN encrypted_meta;
+
+DROP TABLE vault_keys;
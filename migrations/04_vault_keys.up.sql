@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS vault_keys(
+    user_id UUID PRIMARY KEY NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    salt BYTEA NOT NULL,
+    kdf_time INTEGER NOT NULL,
+    kdf_memory INTEGER NOT NULL,
+    kdf_threads INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE metas ADD COLUMN IF NOT EXISTS encrypted_meta BYTEA;
+
+COMMIT ;
@@ -0,0 +1 @@
+ALTER TABLE items_data DROP COLUMN wrapped_key;
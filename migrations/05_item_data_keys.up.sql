@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE items_data ADD COLUMN IF NOT EXISTS wrapped_key BYTEA;
+
+COMMIT ;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS metas_user_key_idx;
+
+ALTER TABLE metas DROP COLUMN IF EXISTS meta_key_id;
+ALTER TABLE items_data DROP COLUMN IF EXISTS key_id;
+
+DELETE FROM vault_keys WHERE state <> 'active';
+DROP INDEX IF EXISTS vault_keys_user_state_idx;
+ALTER TABLE vault_keys DROP CONSTRAINT IF EXISTS vault_keys_pkey;
+ALTER TABLE vault_keys ADD PRIMARY KEY (user_id);
+ALTER TABLE vault_keys DROP COLUMN IF EXISTS state;
+ALTER TABLE vault_keys DROP COLUMN IF EXISTS key_id;
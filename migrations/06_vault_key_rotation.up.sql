@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE vault_keys ADD COLUMN IF NOT EXISTS key_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE vault_keys ADD COLUMN IF NOT EXISTS state TEXT NOT NULL DEFAULT 'active';
+ALTER TABLE vault_keys DROP CONSTRAINT IF EXISTS vault_keys_pkey;
+ALTER TABLE vault_keys ADD PRIMARY KEY (user_id, key_id);
+CREATE UNIQUE INDEX IF NOT EXISTS vault_keys_user_state_idx ON vault_keys(user_id, state);
+
+ALTER TABLE items_data ADD COLUMN IF NOT EXISTS key_id INTEGER;
+ALTER TABLE metas ADD COLUMN IF NOT EXISTS meta_key_id INTEGER;
+
+UPDATE items_data SET key_id = 1 WHERE wrapped_key IS NOT NULL;
+UPDATE metas SET meta_key_id = 1 WHERE encrypted_meta IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS metas_user_key_idx ON metas(user_id, meta_key_id);
+
+COMMIT ;
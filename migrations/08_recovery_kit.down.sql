@@ -0,0 +1,3 @@
+ALTER TABLE vault_keys DROP COLUMN IF EXISTS recovery_wrapped_key;
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_verifier;
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_public_key;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS recovery_public_key BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS recovery_verifier BYTEA;
+ALTER TABLE vault_keys ADD COLUMN IF NOT EXISTS recovery_wrapped_key BYTEA;
+
+COMMIT ;
@@ -0,0 +1,8 @@
+UPDATE users SET password_hash = '' WHERE password_hash IS NULL;
+
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_threads;
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_memory;
+ALTER TABLE users DROP COLUMN IF EXISTS kdf_time;
+ALTER TABLE users DROP COLUMN IF EXISTS srp_verifier;
+ALTER TABLE users DROP COLUMN IF EXISTS srp_salt;
+ALTER TABLE users ALTER COLUMN password_hash SET NOT NULL;
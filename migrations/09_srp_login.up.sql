@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE users ALTER COLUMN password_hash DROP NOT NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS srp_salt BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS srp_verifier BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_time INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_memory INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS kdf_threads INTEGER NOT NULL DEFAULT 0;
+
+COMMIT ;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS shares_recipient_id_idx;
+DROP TABLE IF EXISTS shares;
+DROP TABLE IF EXISTS sharing_keys;
@@ -0,0 +1,28 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sharing_keys(
+    user_id UUID PRIMARY KEY NOT NULL,
+    public_key BYTEA NOT NULL,
+    wrapped_private_key BYTEA NOT NULL,
+    key_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS shares(
+    id UUID PRIMARY KEY NOT NULL,
+    data_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    recipient_id UUID NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    encrypted_meta BYTEA NOT NULL,
+    data_type TEXT NOT NULL,
+    permission TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL,
+    UNIQUE (data_id, recipient_id)
+);
+
+CREATE INDEX IF NOT EXISTS shares_recipient_id_idx ON shares(recipient_id);
+
+COMMIT ;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS collection_items_collection_id_idx;
+DROP TABLE IF EXISTS collection_items;
+DROP TABLE IF EXISTS collection_keys;
+DROP INDEX IF EXISTS collections_org_id_idx;
+DROP TABLE IF EXISTS collections;
+DROP INDEX IF EXISTS org_members_user_id_idx;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS orgs;
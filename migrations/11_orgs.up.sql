@@ -0,0 +1,57 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS orgs(
+    id UUID PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL,
+    owner_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS org_members(
+    org_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL,
+    accepted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS org_members_user_id_idx ON org_members(user_id);
+
+CREATE TABLE IF NOT EXISTS collections(
+    id UUID PRIMARY KEY NOT NULL,
+    org_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    key_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS collections_org_id_idx ON collections(org_id);
+
+CREATE TABLE IF NOT EXISTS collection_keys(
+    collection_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    key_id INTEGER NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    PRIMARY KEY (collection_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS collection_items(
+    id UUID PRIMARY KEY NOT NULL,
+    collection_id UUID NOT NULL,
+    data BYTEA NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    key_id INTEGER NOT NULL,
+    encrypted_meta BYTEA NOT NULL,
+    data_type TEXT NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS collection_items_collection_id_idx ON collection_items(collection_id);
+
+COMMIT ;
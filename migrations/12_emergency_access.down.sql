@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS emergency_access_status_idx;
+DROP INDEX IF EXISTS emergency_access_contact_id_idx;
+DROP TABLE IF EXISTS emergency_access;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS emergency_access(
+    id UUID PRIMARY KEY NOT NULL,
+    owner_id UUID NOT NULL,
+    contact_id UUID NOT NULL,
+    wait_seconds BIGINT NOT NULL,
+    status TEXT NOT NULL,
+    requested_at TIMESTAMP,
+    key_id INTEGER NOT NULL,
+    sealed_key BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    modified_at TIMESTAMP NOT NULL,
+    UNIQUE (owner_id, contact_id)
+);
+
+CREATE INDEX IF NOT EXISTS emergency_access_contact_id_idx ON emergency_access(contact_id);
+CREATE INDEX IF NOT EXISTS emergency_access_status_idx ON emergency_access(status);
+
+COMMIT ;
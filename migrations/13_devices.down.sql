@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS devices_user_id_idx;
+DROP TABLE IF EXISTS devices;
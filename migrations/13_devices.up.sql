@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS devices(
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS devices_user_id_idx ON devices(user_id);
+
+COMMIT ;
@@ -0,0 +1,4 @@
+DROP RULE IF EXISTS audit_events_no_delete ON audit_events;
+DROP RULE IF EXISTS audit_events_no_update ON audit_events;
+DROP INDEX IF EXISTS audit_events_created_at_idx;
+DROP TABLE IF EXISTS audit_events;
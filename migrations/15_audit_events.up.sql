@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_events(
+    user_id UUID NOT NULL,
+    seq BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    success BOOLEAN NOT NULL,
+    item_id TEXT NOT NULL,
+    device_id UUID NOT NULL,
+    api_token_id UUID NOT NULL,
+    peer TEXT NOT NULL,
+    details TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    prev_hash BYTEA,
+    hash BYTEA NOT NULL,
+    PRIMARY KEY (user_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_created_at_idx ON audit_events(user_id, created_at);
+
+-- Журнал только дополняется: изменения и удаления событий игнорируются
+CREATE OR REPLACE RULE audit_events_no_update AS ON UPDATE TO audit_events DO INSTEAD NOTHING;
+CREATE OR REPLACE RULE audit_events_no_delete AS ON DELETE TO audit_events DO INSTEAD NOTHING;
+
+COMMIT ;
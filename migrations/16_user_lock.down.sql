@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS locked_at;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_at TIMESTAMP;
+
+COMMIT ;
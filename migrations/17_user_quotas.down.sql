@@ -0,0 +1 @@
+DROP TABLE IF EXISTS user_quotas;
\ No newline at end of file
@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_quotas(
+    user_id UUID PRIMARY KEY NOT NULL,
+    max_bytes BIGINT NOT NULL,
+    max_items BIGINT NOT NULL,
+    max_item_size BIGINT NOT NULL,
+    modified_at TIMESTAMP NOT NULL
+);
+
+COMMIT ;
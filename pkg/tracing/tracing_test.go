@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+		wantSpan bool
+	}{
+		{name: "disabled", exporter: ExporterNone},
+		{name: "stdout", exporter: ExporterStdout, wantSpan: true},
+		{name: "unknown", exporter: "jaeger", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			shutdown, err := Init(context.Background(), Options{
+				ServiceName: "gophkeeper-test",
+				Exporter:    tt.exporter,
+				Output:      &out,
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+			span.End()
+
+			require.NoError(t, shutdown(context.Background()))
+			if tt.wantSpan {
+				assert.Contains(t, out.String(), `"Name":"test-span"`)
+				assert.Contains(t, out.String(), "gophkeeper-test")
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
+
+func TestInit_Propagator(t *testing.T) {
+	shutdown, err := Init(context.Background(), Options{ServiceName: "gophkeeper-test"})
+	require.NoError(t, err)
+	defer shutdown(context.Background())
+
+	carrier := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+
+	out := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, out)
+	assert.Equal(t, carrier["traceparent"], out["traceparent"])
+}
+
+func TestValidExporter(t *testing.T) {
+	assert.True(t, ValidExporter(ExporterNone))
+	assert.True(t, ValidExporter(ExporterStdout))
+	assert.True(t, ValidExporter(ExporterOTLP))
+	assert.False(t, ValidExporter("zipkin"))
+}